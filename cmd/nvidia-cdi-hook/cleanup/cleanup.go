@@ -0,0 +1,199 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/urfave/cli/v3"
+
+	computemode "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-cdi-hook/compute-mode"
+	gpulimits "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-cdi-hook/gpu-limits"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/audit"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/gpustate"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/ledger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+type command struct {
+	logger  logger.Interface
+	nvmllib nvml.Interface
+}
+
+type options struct {
+	allocationLedger    string
+	computeModeStateDir string
+	gpuLimitsStateDir   string
+	auditLog            string
+	containerSpec       string
+}
+
+// NewCommand constructs a cleanup command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build the cleanup command
+func (m command) build() *cli.Command {
+	cfg := options{}
+
+	c := cli.Command{
+		Name:  "cleanup",
+		Usage: "Release the resources allocated to a container and revert the changes made to its GPUs when the container exits.",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(cmd, &cfg)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "allocation-ledger",
+				Usage:       "The path to the allocation ledger that the devices of the container are released from.",
+				Destination: &cfg.allocationLedger,
+			},
+			&cli.StringFlag{
+				Name:        "compute-mode-state-dir",
+				Usage:       "The directory in which the original compute mode of the GPUs of each container is recorded.",
+				Value:       computemode.DefaultStateDir,
+				Destination: &cfg.computeModeStateDir,
+			},
+			&cli.StringFlag{
+				Name:        "gpu-limits-state-dir",
+				Usage:       "The directory in which the original limits of the GPUs of each container are recorded.",
+				Value:       gpulimits.DefaultStateDir,
+				Destination: &cfg.gpuLimitsStateDir,
+			},
+			&cli.StringFlag{
+				Name:        "audit-log",
+				Usage:       "The path to the audit log that reverted changes are recorded in.",
+				Destination: &cfg.auditLog,
+			},
+			&cli.StringFlag{
+				Name:        "container-spec",
+				Hidden:      true,
+				Category:    "testing-only",
+				Usage:       "Specify the path to the OCI container spec. If empty or '-' the spec will be read from STDIN",
+				Destination: &cfg.containerSpec,
+			},
+		},
+	}
+
+	return &c
+}
+
+// run performs all cleanup steps for the container. A failing step does not
+// prevent the remaining steps from being performed and all errors are
+// returned.
+func (m command) run(_ *cli.Command, cfg *options) error {
+	s, err := oci.LoadContainerState(cfg.containerSpec)
+	if err != nil {
+		return fmt.Errorf("failed to load container state: %w", err)
+	}
+	if s.ID == "" {
+		return fmt.Errorf("container ID is required")
+	}
+
+	var errs error
+	if err := m.releaseDevices(cfg.allocationLedger, s.Bundle); err != nil {
+		errs = errors.Join(errs, err)
+	}
+	if err := m.restoreGPUs(cfg, s.ID, s.Bundle); err != nil {
+		errs = errors.Join(errs, err)
+	}
+	return errs
+}
+
+// releaseDevices releases the devices allocated to the container with the
+// specified bundle from the allocation ledger.
+func (m command) releaseDevices(allocationLedger string, bundle string) error {
+	if allocationLedger == "" {
+		return nil
+	}
+	if _, err := os.Stat(allocationLedger); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	l, err := ledger.Open(m.logger, allocationLedger)
+	if err != nil {
+		return err
+	}
+	released := l.Release(bundle)
+	if err := l.Close(); err != nil {
+		return fmt.Errorf("failed to update allocation ledger: %w", err)
+	}
+	if len(released) > 0 {
+		m.logger.Debugf("Released devices %v", released)
+	}
+	return nil
+}
+
+// restoreGPUs reverts the compute mode and limits of the GPUs of the
+// container. NVML is only initialized if changes were recorded for the
+// container.
+func (m command) restoreGPUs(cfg *options, containerID string, bundle string) error {
+	hasComputeModeState := gpustate.HasClaims(cfg.computeModeStateDir, containerID)
+	hasGPULimitsState := gpustate.HasClaims(cfg.gpuLimitsStateDir, containerID)
+	if !hasComputeModeState && !hasGPULimitsState {
+		return nil
+	}
+
+	// Changing the settings of a GPU requires root privileges.
+	if os.Geteuid() != 0 {
+		return fmt.Errorf("the cleanup hook must be run as root to restore GPU settings")
+	}
+
+	nvmllib := m.nvmllib
+	if nvmllib == nil {
+		nvmllib = nvml.New()
+	}
+	if ret := nvmllib.Init(); ret != nvml.SUCCESS {
+		return fmt.Errorf("failed to initialize NVML: %v", ret)
+	}
+	defer func() {
+		if ret := nvmllib.Shutdown(); ret != nvml.SUCCESS {
+			m.logger.Warningf("Failed to shutdown NVML: %v", ret)
+		}
+	}()
+
+	var errs error
+	if hasComputeModeState {
+		restored, err := computemode.Restore(m.logger, nvmllib, cfg.computeModeStateDir, containerID)
+		if err != nil {
+			errs = errors.Join(errs, err)
+		}
+		if len(restored) > 0 {
+			audit.Record(m.logger, cfg.auditLog, audit.NewComputeModeEntry(audit.EventComputeModeRestore, bundle, "", restored))
+		}
+	}
+	if hasGPULimitsState {
+		restored, err := gpulimits.Restore(m.logger, nvmllib, cfg.gpuLimitsStateDir, containerID)
+		if err != nil {
+			errs = errors.Join(errs, err)
+		}
+		if len(restored) > 0 {
+			audit.Record(m.logger, cfg.auditLog, audit.NewGPULimitsEntry(audit.EventGPULimitsRestore, bundle, "", "", restored))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,94 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package cleanup
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/ledger"
+)
+
+func TestCleanup(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description       string
+		ledgerExists      bool
+		expectedAllocated map[string]string
+	}{
+		{
+			description: "missing ledger is ignored",
+		},
+		{
+			description:       "devices are released from ledger",
+			ledgerExists:      true,
+			expectedAllocated: map[string]string{"GPU-1": "other"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			bundle := t.TempDir()
+			other := filepath.Join(t.TempDir(), "other")
+			require.NoError(t, os.Mkdir(other, 0755))
+
+			ledgerPath := filepath.Join(t.TempDir(), "allocations.json")
+			if tc.ledgerExists {
+				l, err := ledger.Open(logger, ledgerPath)
+				require.NoError(t, err)
+				require.NoError(t, l.Allocate(bundle, "", "GPU-0"))
+				require.NoError(t, l.Allocate(other, "", "GPU-1"))
+				require.NoError(t, l.Close())
+			}
+
+			state, err := json.Marshal(specs.State{ID: "container", Bundle: bundle})
+			require.NoError(t, err)
+			statePath := filepath.Join(t.TempDir(), "state.json")
+			require.NoError(t, os.WriteFile(statePath, state, 0600))
+
+			cfg := &options{
+				allocationLedger:    ledgerPath,
+				computeModeStateDir: t.TempDir(),
+				gpuLimitsStateDir:   t.TempDir(),
+				containerSpec:       statePath,
+			}
+			m := command{logger: logger}
+			require.NoError(t, m.run(nil, cfg))
+
+			if !tc.ledgerExists {
+				require.NoFileExists(t, ledgerPath)
+				return
+			}
+			l, err := ledger.Open(logger, ledgerPath)
+			require.NoError(t, err)
+			allocated := make(map[string]string)
+			for device, owner := range l.Allocated() {
+				allocated[device] = filepath.Base(owner)
+			}
+			require.NoError(t, l.Close())
+			require.EqualValues(t, tc.expectedAllocated, allocated)
+		})
+	}
+}
@@ -20,11 +20,17 @@ import (
 	"github.com/urfave/cli/v3"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-cdi-hook/chmod"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-cdi-hook/cleanup"
+	computemode "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-cdi-hook/compute-mode"
+	createdevicemetadata "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-cdi-hook/create-device-metadata"
 	createsonamesymlinks "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-cdi-hook/create-soname-symlinks"
 	symlinks "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-cdi-hook/create-symlinks"
 	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-cdi-hook/cudacompat"
 	disabledevicenodemodification "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-cdi-hook/disable-device-node-modification"
+	gpulimits "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-cdi-hook/gpu-limits"
+	gpumemory "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-cdi-hook/gpu-memory"
 	ldcache "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-cdi-hook/update-ldcache"
+	validatecontainer "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-cdi-hook/validate-container"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 )
 
@@ -38,6 +44,12 @@ func New(logger logger.Interface) []*cli.Command {
 		cudacompat.NewCommand(logger),
 		createsonamesymlinks.NewCommand(logger),
 		disabledevicenodemodification.NewCommand(logger),
+		computemode.NewCommand(logger),
+		gpulimits.NewCommand(logger),
+		gpumemory.NewCommand(logger),
+		cleanup.NewCommand(logger),
+		createdevicemetadata.NewCommand(logger),
+		validatecontainer.NewCommand(logger),
 	}
 }
 
@@ -0,0 +1,288 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package computemode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/audit"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/gpustate"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+const (
+	// DefaultStateDir is the directory in which the original compute mode of
+	// each GPU that was changed for containers is recorded.
+	DefaultStateDir = "/run/nvidia-container-toolkit/compute-mode"
+)
+
+type command struct {
+	logger  logger.Interface
+	nvmllib nvml.Interface
+}
+
+type options struct {
+	mode          string
+	devices       []string
+	restore       bool
+	stateDir      string
+	auditLog      string
+	containerSpec string
+}
+
+// NewCommand constructs a compute-mode command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build the compute-mode command
+func (m command) build() *cli.Command {
+	cfg := options{}
+
+	c := cli.Command{
+		Name:  "compute-mode",
+		Usage: "Set the compute mode of the GPUs of a container and restore the original compute mode when the container exits.",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(cmd, &cfg)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(cmd, &cfg)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "mode",
+				Usage:       "The compute mode to set. One of DEFAULT, EXCLUSIVE_PROCESS, or PROHIBITED.",
+				Destination: &cfg.mode,
+			},
+			&cli.StringSliceFlag{
+				Name:        "device",
+				Usage:       "The index or UUID of a GPU to set the compute mode for. The value 'all' selects all GPUs.",
+				Destination: &cfg.devices,
+			},
+			&cli.BoolFlag{
+				Name:        "restore",
+				Usage:       "Restore the original compute mode of the GPUs of the container unless these are still used by other containers.",
+				Destination: &cfg.restore,
+			},
+			&cli.StringFlag{
+				Name:        "state-dir",
+				Usage:       "The directory used to record the original compute mode of the GPUs that were changed for containers.",
+				Value:       DefaultStateDir,
+				Destination: &cfg.stateDir,
+			},
+			&cli.StringFlag{
+				Name:        "audit-log",
+				Usage:       "The path to the audit log that changes to the compute mode are recorded in.",
+				Destination: &cfg.auditLog,
+			},
+			&cli.StringFlag{
+				Name:        "container-spec",
+				Hidden:      true,
+				Category:    "testing-only",
+				Usage:       "Specify the path to the OCI container spec. If empty or '-' the spec will be read from STDIN",
+				Destination: &cfg.containerSpec,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(_ *cli.Command, cfg *options) error {
+	if cfg.restore {
+		return nil
+	}
+	if _, err := gpustate.ParseComputeMode(cfg.mode); err != nil {
+		return err
+	}
+	if len(cfg.devices) == 0 {
+		return fmt.Errorf("at least one device must be specified")
+	}
+	return nil
+}
+
+func (m command) run(_ *cli.Command, cfg *options) error {
+	// Changing the compute mode of a GPU affects all users of the GPU and
+	// requires root privileges.
+	if os.Geteuid() != 0 {
+		return fmt.Errorf("the compute-mode hook must be run as root")
+	}
+
+	s, err := oci.LoadContainerState(cfg.containerSpec)
+	if err != nil {
+		return fmt.Errorf("failed to load container state: %w", err)
+	}
+	if s.ID == "" {
+		return fmt.Errorf("container ID is required")
+	}
+
+	store, err := gpustate.OpenStore(cfg.stateDir)
+	if err != nil {
+		return fmt.Errorf("failed to open compute mode state: %w", err)
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			m.logger.Warningf("Failed to close compute mode state: %v", err)
+		}
+	}()
+
+	nvmllib := m.nvmllib
+	if nvmllib == nil {
+		nvmllib = nvml.New()
+	}
+	if ret := nvmllib.Init(); ret != nvml.SUCCESS {
+		return fmt.Errorf("failed to initialize NVML: %v", ret)
+	}
+	defer func() {
+		if ret := nvmllib.Shutdown(); ret != nvml.SUCCESS {
+			m.logger.Warningf("Failed to shutdown NVML: %v", ret)
+		}
+	}()
+
+	if cfg.restore {
+		restored, err := m.restore(nvmllib, store, s.ID)
+		if len(restored) > 0 {
+			audit.Record(m.logger, cfg.auditLog, audit.NewComputeModeEntry(audit.EventComputeModeRestore, s.Bundle, "", restored))
+		}
+		return err
+	}
+
+	mode, _ := gpustate.ParseComputeMode(cfg.mode)
+	changed, err := m.set(nvmllib, store, s.ID, mode, cfg.devices...)
+	if err != nil {
+		return err
+	}
+	audit.Record(m.logger, cfg.auditLog, audit.NewComputeModeEntry(audit.EventComputeModeSet, s.Bundle, cfg.mode, changed))
+	return nil
+}
+
+// set sets the compute mode of the specified devices for the container with
+// the specified ID. The original compute mode of each device is recorded in
+// the store before it is changed. A device whose compute mode was already set
+// to the same mode for another container is shared with that container and is
+// not changed. A device whose compute mode was set to a different mode is not
+// changed and an error is returned. If the compute mode of a device cannot be
+// set, the changes made for the container are reverted. The UUIDs of the
+// changed devices are returned.
+func (m command) set(nvmllib nvml.Interface, store *gpustate.Store, containerID string, mode nvml.ComputeMode, ids ...string) ([]string, error) {
+	devices, err := gpustate.Devices(nvmllib, ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	fail := func(err error) ([]string, error) {
+		if _, restoreErr := m.restore(nvmllib, store, containerID); restoreErr != nil {
+			m.logger.Warningf("Failed to restore compute mode: %v", restoreErr)
+		}
+		return nil, err
+	}
+
+	var changed []string
+	for _, device := range devices {
+		uuid, ret := device.GetUUID()
+		if ret != nvml.SUCCESS {
+			return fail(fmt.Errorf("failed to get device UUID: %v", ret))
+		}
+		current, ret := device.GetComputeMode()
+		if ret != nvml.SUCCESS {
+			return fail(fmt.Errorf("failed to get compute mode of %v: %v", uuid, ret))
+		}
+		claimed, err := store.Claim(uuid, containerID, current, mode)
+		if err != nil {
+			return fail(err)
+		}
+		if !claimed {
+			m.logger.Debugf("Compute mode of %v is already set to %v", uuid, mode)
+			continue
+		}
+		if ret := device.SetComputeMode(mode); ret != nvml.SUCCESS {
+			return fail(fmt.Errorf("failed to set compute mode of %v: %v", uuid, ret))
+		}
+		m.logger.Debugf("Set compute mode of %v to %v", uuid, mode)
+		changed = append(changed, uuid)
+	}
+	return changed, nil
+}
+
+// restore releases the devices claimed by the container with the specified ID
+// and restores the original compute mode of the devices that are not used by
+// other containers. The UUIDs of the restored devices are returned.
+func (m command) restore(nvmllib nvml.Interface, store *gpustate.Store, containerID string) ([]string, error) {
+	uuids, err := store.Claimed(containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var restored []string
+	var errs error
+	for _, uuid := range uuids {
+		record, err := store.Release(uuid, containerID)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		if record == nil {
+			m.logger.Debugf("Not restoring compute mode of %v since it is used by other containers", uuid)
+			continue
+		}
+		var original nvml.ComputeMode
+		if err := record.GetOriginal(&original); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to get original compute mode of %v: %w", uuid, err))
+			continue
+		}
+		device, ret := nvmllib.DeviceGetHandleByUUID(uuid)
+		if ret != nvml.SUCCESS {
+			errs = errors.Join(errs, fmt.Errorf("failed to get device %v: %v", uuid, ret))
+			continue
+		}
+		if ret := device.SetComputeMode(original); ret != nvml.SUCCESS {
+			errs = errors.Join(errs, fmt.Errorf("failed to restore compute mode of %v: %v", uuid, ret))
+			continue
+		}
+		if err := store.Delete(uuid); err != nil {
+			errs = errors.Join(errs, err)
+		}
+		m.logger.Debugf("Restored compute mode of %v to %v", uuid, original)
+		restored = append(restored, uuid)
+	}
+	return restored, errs
+}
+
+// Restore restores the compute mode of the GPUs claimed by the container with
+// the specified ID in the specified state directory. The UUIDs of the
+// restored devices are returned.
+func Restore(logger logger.Interface, nvmllib nvml.Interface, stateDir string, containerID string) ([]string, error) {
+	store, err := gpustate.OpenStore(stateDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open compute mode state: %w", err)
+	}
+	defer store.Close()
+
+	m := command{logger: logger, nvmllib: nvmllib}
+	return m.restore(nvmllib, store, containerID)
+}
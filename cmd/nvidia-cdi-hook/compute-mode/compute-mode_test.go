@@ -0,0 +1,168 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package computemode
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/gpustate"
+)
+
+func TestSetAndRestore(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description     string
+		ids             []string
+		failingDevice   int
+		expectedError   bool
+		expectedChanged []string
+		expectedModes   []nvml.ComputeMode
+	}{
+		{
+			description:     "selected devices are changed",
+			ids:             []string{"1"},
+			failingDevice:   -1,
+			expectedChanged: []string{"GPU-1"},
+			expectedModes:   []nvml.ComputeMode{nvml.COMPUTEMODE_DEFAULT, nvml.COMPUTEMODE_EXCLUSIVE_PROCESS},
+		},
+		{
+			description:     "all devices are changed",
+			ids:             []string{"all", "0:1"},
+			failingDevice:   -1,
+			expectedChanged: []string{"GPU-0", "GPU-1"},
+			expectedModes:   []nvml.ComputeMode{nvml.COMPUTEMODE_EXCLUSIVE_PROCESS, nvml.COMPUTEMODE_EXCLUSIVE_PROCESS},
+		},
+		{
+			description:   "failure restores changed devices",
+			ids:           []string{"GPU-0", "GPU-1"},
+			failingDevice: 1,
+			expectedError: true,
+			expectedModes: []nvml.ComputeMode{nvml.COMPUTEMODE_DEFAULT, nvml.COMPUTEMODE_PROHIBITED},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			modes := []nvml.ComputeMode{nvml.COMPUTEMODE_DEFAULT, nvml.COMPUTEMODE_PROHIBITED}
+			nvmllib := newMockNVML(modes, tc.failingDevice)
+
+			m := command{logger: logger}
+			stateDir := t.TempDir()
+			store, err := gpustate.OpenStore(stateDir)
+			require.NoError(t, err)
+			defer store.Close()
+
+			changed, err := m.set(nvmllib, store, "container", nvml.COMPUTEMODE_EXCLUSIVE_PROCESS, tc.ids...)
+			require.EqualValues(t, tc.expectedModes, modes)
+			if tc.expectedError {
+				require.Error(t, err)
+				require.False(t, gpustate.HasClaims(stateDir, "container"))
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedChanged, changed)
+
+			restored, err := m.restore(nvmllib, store, "container")
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedChanged, restored)
+			require.EqualValues(t, []nvml.ComputeMode{nvml.COMPUTEMODE_DEFAULT, nvml.COMPUTEMODE_PROHIBITED}, modes)
+			require.False(t, gpustate.HasClaims(stateDir, "container"))
+		})
+	}
+}
+
+func TestSharedGPU(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	modes := []nvml.ComputeMode{nvml.COMPUTEMODE_DEFAULT}
+	nvmllib := newMockNVML(modes, -1)
+
+	m := command{logger: logger}
+	store, err := gpustate.OpenStore(t.TempDir())
+	require.NoError(t, err)
+	defer store.Close()
+
+	changed, err := m.set(nvmllib, store, "first", nvml.COMPUTEMODE_EXCLUSIVE_PROCESS, "GPU-0")
+	require.NoError(t, err)
+	require.EqualValues(t, []string{"GPU-0"}, changed)
+
+	changed, err = m.set(nvmllib, store, "second", nvml.COMPUTEMODE_EXCLUSIVE_PROCESS, "GPU-0")
+	require.NoError(t, err)
+	require.Empty(t, changed)
+
+	_, err = m.set(nvmllib, store, "conflicting", nvml.COMPUTEMODE_PROHIBITED, "GPU-0")
+	require.Error(t, err)
+	require.Equal(t, nvml.COMPUTEMODE_EXCLUSIVE_PROCESS, modes[0])
+
+	restored, err := m.restore(nvmllib, store, "first")
+	require.NoError(t, err)
+	require.Empty(t, restored)
+	require.Equal(t, nvml.COMPUTEMODE_EXCLUSIVE_PROCESS, modes[0])
+
+	restored, err = m.restore(nvmllib, store, "second")
+	require.NoError(t, err)
+	require.EqualValues(t, []string{"GPU-0"}, restored)
+	require.Equal(t, nvml.COMPUTEMODE_DEFAULT, modes[0])
+}
+
+// newMockNVML returns an NVML library with a device for each of the specified
+// compute modes. Setting the EXCLUSIVE_PROCESS mode fails for the device with
+// the specified index.
+func newMockNVML(modes []nvml.ComputeMode, failingDevice int) nvml.Interface {
+	uuids := []string{"GPU-0", "GPU-1"}
+	devices := make([]*mock.Device, len(modes))
+	for i := range devices {
+		devices[i] = &mock.Device{
+			GetUUIDFunc: func() (string, nvml.Return) {
+				return uuids[i], nvml.SUCCESS
+			},
+			GetComputeModeFunc: func() (nvml.ComputeMode, nvml.Return) {
+				return modes[i], nvml.SUCCESS
+			},
+			SetComputeModeFunc: func(mode nvml.ComputeMode) nvml.Return {
+				if i == failingDevice && mode == nvml.COMPUTEMODE_EXCLUSIVE_PROCESS {
+					return nvml.ERROR_NO_PERMISSION
+				}
+				modes[i] = mode
+				return nvml.SUCCESS
+			},
+		}
+	}
+	return &mock.Interface{
+		DeviceGetCountFunc: func() (int, nvml.Return) {
+			return len(devices), nvml.SUCCESS
+		},
+		DeviceGetHandleByIndexFunc: func(n int) (nvml.Device, nvml.Return) {
+			return devices[n], nvml.SUCCESS
+		},
+		DeviceGetHandleByUUIDFunc: func(uuid string) (nvml.Device, nvml.Return) {
+			for i := range devices {
+				if uuids[i] == uuid {
+					return devices[i], nvml.SUCCESS
+				}
+			}
+			return nil, nvml.ERROR_NOT_FOUND
+		},
+	}
+}
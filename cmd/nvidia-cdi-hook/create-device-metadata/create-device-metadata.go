@@ -0,0 +1,148 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package createdevicemetadata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/moby/sys/symlink"
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+const (
+	defaultMetadataPath = "/run/nvidia/devices.json"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	metadata      string
+	path          string
+	containerSpec string
+}
+
+// NewCommand constructs a create-device-metadata command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build the create-device-metadata command
+func (m command) build() *cli.Command {
+	cfg := options{}
+
+	c := cli.Command{
+		Name:  "create-device-metadata",
+		Usage: "Create a file in the container that describes the mapping between the devices in the container and on the host.",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(cmd, &cfg)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(cmd, &cfg)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "metadata",
+				Usage:       "The device metadata as a JSON document.",
+				Destination: &cfg.metadata,
+			},
+			&cli.StringFlag{
+				Name:        "path",
+				Usage:       "The path in the container at which the metadata file is created.",
+				Value:       defaultMetadataPath,
+				Destination: &cfg.path,
+			},
+			&cli.StringFlag{
+				Name:        "container-spec",
+				Hidden:      true,
+				Category:    "testing-only",
+				Usage:       "Specify the path to the OCI container spec. If empty or '-' the spec will be read from STDIN",
+				Destination: &cfg.containerSpec,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(_ *cli.Command, cfg *options) error {
+	if !json.Valid([]byte(cfg.metadata)) {
+		return fmt.Errorf("invalid device metadata %q", cfg.metadata)
+	}
+	if !filepath.IsAbs(cfg.path) {
+		return fmt.Errorf("the metadata path must be absolute")
+	}
+	return nil
+}
+
+func (m command) run(_ *cli.Command, cfg *options) error {
+	s, err := oci.LoadContainerState(cfg.containerSpec)
+	if err != nil {
+		return fmt.Errorf("failed to load container state: %w", err)
+	}
+
+	containerRoot, err := s.GetContainerRoot()
+	if err != nil {
+		return fmt.Errorf("failed to determined container root: %w", err)
+	}
+
+	return m.createMetadataFile(containerRoot, cfg.path, []byte(cfg.metadata))
+}
+
+// createMetadataFile writes the metadata to the specified path in the
+// container root. Symlinks are resolved in the container root.
+func (m command) createMetadataFile(containerRoot string, path string, contents []byte) error {
+	resolved, err := symlink.FollowSymlinkInScope(filepath.Join(containerRoot, path), containerRoot)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %v: %w", path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(resolved), 0755); err != nil {
+		return fmt.Errorf("failed to create metadata directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(resolved), ".devices-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to create metadata file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if _, err := tmp.Write(contents); err != nil {
+		return fmt.Errorf("failed to write metadata file: %w", err)
+	}
+	// The created file needs to be world readable for the cases where the container is run as a non-root user.
+	if err := tmp.Chmod(0644); err != nil {
+		return fmt.Errorf("failed to chmod metadata file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), resolved); err != nil {
+		return fmt.Errorf("failed to create metadata file: %w", err)
+	}
+	m.logger.Debugf("Created device metadata file %v", path)
+	return nil
+}
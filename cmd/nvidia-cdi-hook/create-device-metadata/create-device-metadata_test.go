@@ -0,0 +1,74 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package createdevicemetadata
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateMetadataFile(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description  string
+		setup        func(string)
+		path         string
+		expectedPath string
+	}{
+		{
+			description:  "file is created in container root",
+			path:         "/run/nvidia/devices.json",
+			expectedPath: "/run/nvidia/devices.json",
+		},
+		{
+			description: "symlinks are resolved in container root",
+			setup: func(containerRoot string) {
+				require.NoError(t, os.MkdirAll(filepath.Join(containerRoot, "var", "run"), 0755))
+				require.NoError(t, os.Symlink("/run", filepath.Join(containerRoot, "var", "run", "nvidia")))
+			},
+			path:         "/var/run/nvidia/devices.json",
+			expectedPath: "/run/devices.json",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			containerRoot := t.TempDir()
+			if tc.setup != nil {
+				tc.setup(containerRoot)
+			}
+
+			c := command{logger: logger}
+			contents := []byte(`{"devices":[{"index":"0","hostIndex":"1","uuid":"GPU-1"}]}`)
+			require.NoError(t, c.createMetadataFile(containerRoot, tc.path, contents))
+
+			written, err := os.ReadFile(filepath.Join(containerRoot, tc.expectedPath))
+			require.NoError(t, err)
+			require.Equal(t, contents, written)
+
+			info, err := os.Stat(filepath.Join(containerRoot, tc.expectedPath))
+			require.NoError(t, err)
+			require.Equal(t, os.FileMode(0644), info.Mode().Perm())
+		})
+	}
+}
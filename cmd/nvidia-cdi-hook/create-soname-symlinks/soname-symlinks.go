@@ -21,10 +21,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 	"os"
 
 	"github.com/moby/sys/reexec"
+	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v3"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/ldconfig"
@@ -44,6 +44,9 @@ type options struct {
 	folders       []string
 	ldconfigPath  string
 	containerSpec string
+
+	ldconfigAllowedPaths  []string
+	ldconfigAllowedSHA256 []string
 }
 
 func init() {
@@ -87,6 +90,16 @@ func (m command) build() *cli.Command {
 				Destination: &cfg.ldconfigPath,
 				Value:       "/sbin/ldconfig",
 			},
+			&cli.StringSliceFlag{
+				Name:        "ldconfig-allowed-path",
+				Usage:       "Specify an additional path pattern at which the ldconfig program is trusted",
+				Destination: &cfg.ldconfigAllowedPaths,
+			},
+			&cli.StringSliceFlag{
+				Name:        "ldconfig-sha256",
+				Usage:       "Specify an allowed SHA256 digest for the ldconfig program. If specified, the ldconfig program must match one of the digests",
+				Destination: &cfg.ldconfigAllowedSHA256,
+			},
 			&cli.StringFlag{
 				Name:        "container-spec",
 				Usage:       "Specify the path to the OCI container spec. If empty or '-' the spec will be read from STDIN",
@@ -116,6 +129,15 @@ func (m command) run(_ *cli.Command, cfg *options) error {
 		return fmt.Errorf("failed to determined container root: %v", err)
 	}
 
+	err = ldconfig.Verify(
+		cfg.ldconfigPath,
+		ldconfig.WithAllowedPaths(cfg.ldconfigAllowedPaths...),
+		ldconfig.WithAllowedSHA256(cfg.ldconfigAllowedSHA256...),
+	)
+	if err != nil {
+		return fmt.Errorf("refusing to run untrusted ldconfig: %w", err)
+	}
+
 	cmd, err := ldconfig.NewRunner(
 		reexecUpdateLdCacheCommandName,
 		cfg.ldconfigPath,
@@ -131,8 +153,9 @@ func (m command) run(_ *cli.Command, cfg *options) error {
 
 // createSonameSymlinksHandler wraps createSonameSymlinks with error handling.
 func createSonameSymlinksHandler() {
-	if err := createSonameSymlinks(os.Args); err != nil {
-		log.Printf("Error updating ldcache: %v", err)
+	logger := logrus.New()
+	if err := createSonameSymlinks(logger, os.Args); err != nil {
+		logger.Errorf("Error updating ldcache: %v", err)
 		os.Exit(1)
 	}
 }
@@ -147,7 +170,7 @@ func createSonameSymlinksHandler() {
 // args[1] is the path of the ldconfig binary on the host
 // args[2] is the container root directory
 // The remaining args are directories where soname symlinks need to be created.
-func createSonameSymlinks(args []string) error {
+func createSonameSymlinks(logger logger.Interface, args []string) error {
 	if len(args) < 3 {
 		return fmt.Errorf("incorrect arguments: %v", args)
 	}
@@ -155,6 +178,7 @@ func createSonameSymlinks(args []string) error {
 	containerRootDirPath := args[2]
 
 	ldconfig, err := ldconfig.New(
+		logger,
 		hostLdconfigPath,
 		containerRootDirPath,
 	)
@@ -30,6 +30,7 @@ import (
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/symlinks"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/rootfs"
 )
 
 type command struct {
@@ -142,6 +143,12 @@ func (m command) createLink(containerRoot string, targetPath string, link string
 	}
 	resolvedLinkPath := filepath.Join(resolvedLinkParent, filepath.Base(linkPath))
 
+	// If the container root is read-only, a writable overlay is mounted over
+	// the (existing) parent of the link.
+	if err := rootfs.EnsureWritable(m.logger, containerRoot, strings.TrimPrefix(resolvedLinkParent, containerRoot)); err != nil {
+		return fmt.Errorf("failed to ensure that %v is writable: %w", resolvedLinkParent, err)
+	}
+
 	m.logger.Infof("Symlinking %v to %v", resolvedLinkPath, targetPath)
 	err = os.MkdirAll(filepath.Dir(resolvedLinkPath), 0755)
 	if err != nil {
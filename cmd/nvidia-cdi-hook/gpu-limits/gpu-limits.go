@@ -0,0 +1,368 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package gpulimits
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/audit"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/gpustate"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+const (
+	// DefaultStateDir is the directory in which the original settings of each
+	// GPU that limits were applied to for containers are recorded.
+	DefaultStateDir = "/run/nvidia-container-toolkit/gpu-limits"
+)
+
+type command struct {
+	logger  logger.Interface
+	nvmllib nvml.Interface
+}
+
+type options struct {
+	powerLimit    string
+	clocks        string
+	devices       []string
+	restore       bool
+	stateDir      string
+	auditLog      string
+	containerSpec string
+}
+
+// limits are the limits applied to the GPUs of a container.
+type limits struct {
+	// PowerLimit is the power limit in milliwatts. A value of 0 indicates that
+	// the power limit is not changed.
+	PowerLimit uint32 `json:"powerLimit,omitempty"`
+	// MinClock and MaxClock are the range of GPU clocks in MHz. A MaxClock of
+	// 0 indicates that the clocks are not locked.
+	MinClock uint32 `json:"minClock,omitempty"`
+	MaxClock uint32 `json:"maxClock,omitempty"`
+}
+
+// A deviceState records the settings of a GPU before limits were applied.
+type deviceState struct {
+	// PowerLimit is the original power limit in milliwatts if the power
+	// limit is changed.
+	PowerLimit uint32 `json:"powerLimit,omitempty"`
+	// ClocksLocked indicates that the GPU clocks are locked and must be
+	// reset.
+	ClocksLocked bool `json:"clocksLocked,omitempty"`
+}
+
+// NewCommand constructs a gpu-limits command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build the gpu-limits command
+func (m command) build() *cli.Command {
+	cfg := options{}
+
+	c := cli.Command{
+		Name:  "gpu-limits",
+		Usage: "Apply power and clock limits to the GPUs of a container and revert them when the container exits.",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(cmd, &cfg)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(cmd, &cfg)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "power-limit",
+				Usage:       "The power limit to apply in watts.",
+				Destination: &cfg.powerLimit,
+			},
+			&cli.StringFlag{
+				Name:        "clocks",
+				Usage:       "The range of GPU clocks in MHz to lock the GPUs to in the form <min>-<max>. A single value locks the clocks to that value.",
+				Destination: &cfg.clocks,
+			},
+			&cli.StringSliceFlag{
+				Name:        "device",
+				Usage:       "The index or UUID of a GPU to apply the limits to. The value 'all' selects all GPUs.",
+				Destination: &cfg.devices,
+			},
+			&cli.BoolFlag{
+				Name:        "restore",
+				Usage:       "Revert the limits that were applied to the GPUs of the container unless these are still used by other containers.",
+				Destination: &cfg.restore,
+			},
+			&cli.StringFlag{
+				Name:        "state-dir",
+				Usage:       "The directory used to record the original settings of the GPUs that limits were applied to for containers.",
+				Value:       DefaultStateDir,
+				Destination: &cfg.stateDir,
+			},
+			&cli.StringFlag{
+				Name:        "audit-log",
+				Usage:       "The path to the audit log that applied limits are recorded in.",
+				Destination: &cfg.auditLog,
+			},
+			&cli.StringFlag{
+				Name:        "container-spec",
+				Hidden:      true,
+				Category:    "testing-only",
+				Usage:       "Specify the path to the OCI container spec. If empty or '-' the spec will be read from STDIN",
+				Destination: &cfg.containerSpec,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(_ *cli.Command, cfg *options) error {
+	if cfg.restore {
+		return nil
+	}
+	if cfg.powerLimit == "" && cfg.clocks == "" {
+		return fmt.Errorf("a power limit or clocks must be specified")
+	}
+	if len(cfg.devices) == 0 {
+		return fmt.Errorf("at least one device must be specified")
+	}
+	_, err := cfg.getLimits()
+	return err
+}
+
+func (cfg *options) getLimits() (*limits, error) {
+	l := &limits{}
+	if cfg.powerLimit != "" {
+		powerLimit, err := gpustate.ParsePowerLimit(cfg.powerLimit)
+		if err != nil {
+			return nil, err
+		}
+		l.PowerLimit = powerLimit
+	}
+	if cfg.clocks != "" {
+		minClock, maxClock, err := gpustate.ParseClocks(cfg.clocks)
+		if err != nil {
+			return nil, err
+		}
+		l.MinClock = minClock
+		l.MaxClock = maxClock
+	}
+	return l, nil
+}
+
+func (m command) run(_ *cli.Command, cfg *options) error {
+	// Changing the limits of a GPU affects all users of the GPU and requires
+	// root privileges.
+	if os.Geteuid() != 0 {
+		return fmt.Errorf("the gpu-limits hook must be run as root")
+	}
+
+	s, err := oci.LoadContainerState(cfg.containerSpec)
+	if err != nil {
+		return fmt.Errorf("failed to load container state: %w", err)
+	}
+	if s.ID == "" {
+		return fmt.Errorf("container ID is required")
+	}
+
+	store, err := gpustate.OpenStore(cfg.stateDir)
+	if err != nil {
+		return fmt.Errorf("failed to open GPU limits state: %w", err)
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			m.logger.Warningf("Failed to close GPU limits state: %v", err)
+		}
+	}()
+
+	nvmllib := m.nvmllib
+	if nvmllib == nil {
+		nvmllib = nvml.New()
+	}
+	if ret := nvmllib.Init(); ret != nvml.SUCCESS {
+		return fmt.Errorf("failed to initialize NVML: %v", ret)
+	}
+	defer func() {
+		if ret := nvmllib.Shutdown(); ret != nvml.SUCCESS {
+			m.logger.Warningf("Failed to shutdown NVML: %v", ret)
+		}
+	}()
+
+	if cfg.restore {
+		restored, err := m.restore(nvmllib, store, s.ID)
+		if len(restored) > 0 {
+			audit.Record(m.logger, cfg.auditLog, audit.NewGPULimitsEntry(audit.EventGPULimitsRestore, s.Bundle, "", "", restored))
+		}
+		return err
+	}
+
+	l, err := cfg.getLimits()
+	if err != nil {
+		return err
+	}
+	changed, err := m.apply(nvmllib, store, s.ID, l, cfg.devices...)
+	if err != nil {
+		return err
+	}
+	audit.Record(m.logger, cfg.auditLog, audit.NewGPULimitsEntry(audit.EventGPULimitsSet, s.Bundle, cfg.powerLimit, cfg.clocks, changed))
+	return nil
+}
+
+// apply applies the specified limits to the specified devices for the
+// container with the specified ID. The original settings of each device are
+// recorded in the store before any limit is applied to it. A device that the
+// same limits were already applied to for another container is shared with
+// that container and is not changed. A device that different limits were
+// applied to is not changed and an error is returned. If a limit cannot be
+// applied, the changes made for the container are reverted. The UUIDs of the
+// changed devices are returned.
+func (m command) apply(nvmllib nvml.Interface, store *gpustate.Store, containerID string, l *limits, ids ...string) ([]string, error) {
+	devices, err := gpustate.Devices(nvmllib, ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	fail := func(err error) ([]string, error) {
+		if _, restoreErr := m.restore(nvmllib, store, containerID); restoreErr != nil {
+			m.logger.Warningf("Failed to revert GPU limits: %v", restoreErr)
+		}
+		return nil, err
+	}
+
+	var changed []string
+	for _, device := range devices {
+		uuid, ret := device.GetUUID()
+		if ret != nvml.SUCCESS {
+			return fail(fmt.Errorf("failed to get device UUID: %v", ret))
+		}
+
+		original := deviceState{ClocksLocked: l.MaxClock != 0}
+		if l.PowerLimit != 0 {
+			minLimit, maxLimit, ret := device.GetPowerManagementLimitConstraints()
+			if ret != nvml.SUCCESS {
+				return fail(fmt.Errorf("failed to get power limit constraints of %v: %v", uuid, ret))
+			}
+			if l.PowerLimit < minLimit || l.PowerLimit > maxLimit {
+				return fail(fmt.Errorf("power limit of %dW for %v is outside the supported range of %dW to %dW", l.PowerLimit/1000, uuid, minLimit/1000, maxLimit/1000))
+			}
+			current, ret := device.GetPowerManagementLimit()
+			if ret != nvml.SUCCESS {
+				return fail(fmt.Errorf("failed to get power limit of %v: %v", uuid, ret))
+			}
+			original.PowerLimit = current
+		}
+
+		claimed, err := store.Claim(uuid, containerID, original, l)
+		if err != nil {
+			return fail(err)
+		}
+		if !claimed {
+			m.logger.Debugf("Limits are already applied to %v", uuid)
+			continue
+		}
+
+		if l.PowerLimit != 0 {
+			if ret := device.SetPowerManagementLimit(l.PowerLimit); ret != nvml.SUCCESS {
+				return fail(fmt.Errorf("failed to set power limit of %v: %v", uuid, ret))
+			}
+		}
+		if l.MaxClock != 0 {
+			if ret := device.SetGpuLockedClocks(l.MinClock, l.MaxClock); ret != nvml.SUCCESS {
+				return fail(fmt.Errorf("failed to lock clocks of %v: %v", uuid, ret))
+			}
+		}
+		m.logger.Debugf("Applied limits to %v", uuid)
+		changed = append(changed, uuid)
+	}
+	return changed, nil
+}
+
+// restore releases the devices claimed by the container with the specified ID
+// and reverts the limits of the devices that are not used by other
+// containers. The UUIDs of the restored devices are returned.
+func (m command) restore(nvmllib nvml.Interface, store *gpustate.Store, containerID string) ([]string, error) {
+	uuids, err := store.Claimed(containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var restored []string
+	var errs error
+	for _, uuid := range uuids {
+		record, err := store.Release(uuid, containerID)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		if record == nil {
+			m.logger.Debugf("Not reverting limits of %v since it is used by other containers", uuid)
+			continue
+		}
+		var original deviceState
+		if err := record.GetOriginal(&original); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to get original settings of %v: %w", uuid, err))
+			continue
+		}
+		device, ret := nvmllib.DeviceGetHandleByUUID(uuid)
+		if ret != nvml.SUCCESS {
+			errs = errors.Join(errs, fmt.Errorf("failed to get device %v: %v", uuid, ret))
+			continue
+		}
+		if original.PowerLimit != 0 {
+			if ret := device.SetPowerManagementLimit(original.PowerLimit); ret != nvml.SUCCESS {
+				errs = errors.Join(errs, fmt.Errorf("failed to restore power limit of %v: %v", uuid, ret))
+				continue
+			}
+		}
+		if original.ClocksLocked {
+			if ret := device.ResetGpuLockedClocks(); ret != nvml.SUCCESS {
+				errs = errors.Join(errs, fmt.Errorf("failed to reset clocks of %v: %v", uuid, ret))
+				continue
+			}
+		}
+		if err := store.Delete(uuid); err != nil {
+			errs = errors.Join(errs, err)
+		}
+		m.logger.Debugf("Reverted limits of %v", uuid)
+		restored = append(restored, uuid)
+	}
+	return restored, errs
+}
+
+// Restore reverts the limits of the GPUs claimed by the container with the
+// specified ID in the specified state directory. The UUIDs of the restored
+// devices are returned.
+func Restore(logger logger.Interface, nvmllib nvml.Interface, stateDir string, containerID string) ([]string, error) {
+	store, err := gpustate.OpenStore(stateDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GPU limits state: %w", err)
+	}
+	defer store.Close()
+
+	m := command{logger: logger, nvmllib: nvmllib}
+	return m.restore(nvmllib, store, containerID)
+}
@@ -0,0 +1,196 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package gpulimits
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/gpustate"
+)
+
+type fakeGPU struct {
+	powerLimit   uint32
+	clocksLocked bool
+}
+
+func TestApplyAndRestore(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description     string
+		limits          limits
+		failingClocks   int
+		expectedError   bool
+		expectedChanged []string
+		expectedGPUs    []fakeGPU
+	}{
+		{
+			description:     "power limit is applied",
+			limits:          limits{PowerLimit: 200000},
+			failingClocks:   -1,
+			expectedChanged: []string{"GPU-0", "GPU-1"},
+			expectedGPUs:    []fakeGPU{{powerLimit: 200000}, {powerLimit: 200000}},
+		},
+		{
+			description:     "clocks are locked",
+			limits:          limits{MinClock: 1000, MaxClock: 1400},
+			failingClocks:   -1,
+			expectedChanged: []string{"GPU-0", "GPU-1"},
+			expectedGPUs:    []fakeGPU{{powerLimit: 300000, clocksLocked: true}, {powerLimit: 300000, clocksLocked: true}},
+		},
+		{
+			description:   "power limit outside constraints is rejected",
+			limits:        limits{PowerLimit: 500000},
+			failingClocks: -1,
+			expectedError: true,
+			expectedGPUs:  []fakeGPU{{powerLimit: 300000}, {powerLimit: 300000}},
+		},
+		{
+			description:   "failure reverts applied limits",
+			limits:        limits{PowerLimit: 200000, MinClock: 1000, MaxClock: 1400},
+			failingClocks: 1,
+			expectedError: true,
+			expectedGPUs:  []fakeGPU{{powerLimit: 300000}, {powerLimit: 300000}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			gpus := []fakeGPU{{powerLimit: 300000}, {powerLimit: 300000}}
+			stateDir := t.TempDir()
+			nvmllib := newMockNVML(gpus, tc.failingClocks, func() {
+				require.True(t, gpustate.HasClaims(stateDir, "container"), "original settings must be recorded before limits are applied")
+			})
+
+			m := command{logger: logger}
+			store, err := gpustate.OpenStore(stateDir)
+			require.NoError(t, err)
+			defer store.Close()
+
+			changed, err := m.apply(nvmllib, store, "container", &tc.limits, "all")
+			require.EqualValues(t, tc.expectedGPUs, gpus)
+			if tc.expectedError {
+				require.Error(t, err)
+				require.False(t, gpustate.HasClaims(stateDir, "container"))
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedChanged, changed)
+
+			restored, err := m.restore(nvmllib, store, "container")
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedChanged, restored)
+			require.EqualValues(t, []fakeGPU{{powerLimit: 300000}, {powerLimit: 300000}}, gpus)
+			require.False(t, gpustate.HasClaims(stateDir, "container"))
+		})
+	}
+}
+
+func TestSharedGPU(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	gpus := []fakeGPU{{powerLimit: 300000}}
+	nvmllib := newMockNVML(gpus, -1, func() {})
+
+	m := command{logger: logger}
+	store, err := gpustate.OpenStore(t.TempDir())
+	require.NoError(t, err)
+	defer store.Close()
+
+	l := &limits{PowerLimit: 200000}
+	changed, err := m.apply(nvmllib, store, "first", l, "GPU-0")
+	require.NoError(t, err)
+	require.EqualValues(t, []string{"GPU-0"}, changed)
+
+	changed, err = m.apply(nvmllib, store, "second", l, "GPU-0")
+	require.NoError(t, err)
+	require.Empty(t, changed)
+
+	_, err = m.apply(nvmllib, store, "conflicting", &limits{PowerLimit: 250000}, "GPU-0")
+	require.Error(t, err)
+	require.EqualValues(t, 200000, gpus[0].powerLimit)
+
+	restored, err := m.restore(nvmllib, store, "first")
+	require.NoError(t, err)
+	require.Empty(t, restored)
+	require.EqualValues(t, 200000, gpus[0].powerLimit)
+
+	restored, err = m.restore(nvmllib, store, "second")
+	require.NoError(t, err)
+	require.EqualValues(t, []string{"GPU-0"}, restored)
+	require.EqualValues(t, 300000, gpus[0].powerLimit)
+}
+
+// newMockNVML returns an NVML library with a device for each of the specified
+// GPUs. Locking the clocks fails for the GPU with the specified index. The
+// beforeSet function is called before a limit is applied.
+func newMockNVML(gpus []fakeGPU, failingClocks int, beforeSet func()) nvml.Interface {
+	uuids := []string{"GPU-0", "GPU-1"}
+	devices := make([]*mock.Device, len(gpus))
+	for i := range devices {
+		devices[i] = &mock.Device{
+			GetUUIDFunc: func() (string, nvml.Return) {
+				return uuids[i], nvml.SUCCESS
+			},
+			GetPowerManagementLimitConstraintsFunc: func() (uint32, uint32, nvml.Return) {
+				return 100000, 400000, nvml.SUCCESS
+			},
+			GetPowerManagementLimitFunc: func() (uint32, nvml.Return) {
+				return gpus[i].powerLimit, nvml.SUCCESS
+			},
+			SetPowerManagementLimitFunc: func(limit uint32) nvml.Return {
+				beforeSet()
+				gpus[i].powerLimit = limit
+				return nvml.SUCCESS
+			},
+			SetGpuLockedClocksFunc: func(minClock uint32, maxClock uint32) nvml.Return {
+				beforeSet()
+				if i == failingClocks {
+					return nvml.ERROR_NOT_SUPPORTED
+				}
+				gpus[i].clocksLocked = true
+				return nvml.SUCCESS
+			},
+			ResetGpuLockedClocksFunc: func() nvml.Return {
+				gpus[i].clocksLocked = false
+				return nvml.SUCCESS
+			},
+		}
+	}
+	return &mock.Interface{
+		DeviceGetCountFunc: func() (int, nvml.Return) {
+			return len(devices), nvml.SUCCESS
+		},
+		DeviceGetHandleByIndexFunc: func(n int) (nvml.Device, nvml.Return) {
+			return devices[n], nvml.SUCCESS
+		},
+		DeviceGetHandleByUUIDFunc: func(uuid string) (nvml.Device, nvml.Return) {
+			for i := range devices {
+				if uuids[i] == uuid {
+					return devices[i], nvml.SUCCESS
+				}
+			}
+			return nil, nvml.ERROR_NOT_FOUND
+		},
+	}
+}
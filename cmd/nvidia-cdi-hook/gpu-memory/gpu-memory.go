@@ -0,0 +1,268 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package gpumemory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+const (
+	mib = 1024 * 1024
+)
+
+type command struct {
+	logger  logger.Interface
+	nvmllib nvml.Interface
+}
+
+type options struct {
+	memory  uint64
+	devices []string
+}
+
+// NewCommand constructs a gpu-memory command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build the gpu-memory command
+func (m command) build() *cli.Command {
+	cfg := options{}
+
+	c := cli.Command{
+		Name:  "gpu-memory",
+		Usage: "Check that the requested amount of memory is available on the GPUs of a container.",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(cmd, &cfg)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(cmd, &cfg)
+		},
+		Flags: []cli.Flag{
+			&cli.Uint64Flag{
+				Name:        "memory",
+				Usage:       "The amount of memory in MiB that is required on each GPU.",
+				Destination: &cfg.memory,
+			},
+			&cli.StringSliceFlag{
+				Name:        "device",
+				Usage:       "The index or UUID of a GPU or MIG device to check. The value 'all' selects all GPUs.",
+				Destination: &cfg.devices,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(_ *cli.Command, cfg *options) error {
+	if cfg.memory == 0 {
+		return fmt.Errorf("the required memory must be specified")
+	}
+	if len(cfg.devices) == 0 {
+		return fmt.Errorf("at least one device must be specified")
+	}
+	return nil
+}
+
+func (m command) run(_ *cli.Command, cfg *options) error {
+	nvmllib := m.nvmllib
+	if nvmllib == nil {
+		nvmllib = nvml.New()
+	}
+	if ret := nvmllib.Init(); ret != nvml.SUCCESS {
+		return fmt.Errorf("failed to initialize NVML: %v", ret)
+	}
+	defer func() {
+		if ret := nvmllib.Shutdown(); ret != nvml.SUCCESS {
+			m.logger.Warningf("Failed to shutdown NVML: %v", ret)
+		}
+	}()
+
+	return m.check(nvmllib, cfg.memory, cfg.devices...)
+}
+
+// check returns an error if the requested memory is not available on one of
+// the specified devices. Since the memory of a MIG device is not shared with
+// other MIG devices, the total memory of a MIG device is checked. For full
+// GPUs the memory may be in use by other containers and the free memory is
+// checked instead. A GPU with MIG mode enabled is checked as the set of its
+// MIG devices.
+func (m command) check(nvmllib nvml.Interface, required uint64, ids ...string) error {
+	devices, err := getDevices(nvmllib, ids...)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for _, device := range devices {
+		uuid, ret := device.GetUUID()
+		if ret != nvml.SUCCESS {
+			return fmt.Errorf("failed to get device UUID: %v", ret)
+		}
+		memory, ret := device.GetMemoryInfo()
+		if ret != nvml.SUCCESS {
+			return fmt.Errorf("failed to get memory info of %v: %v", uuid, ret)
+		}
+
+		isMig, ret := device.IsMigDeviceHandle()
+		if ret != nvml.SUCCESS {
+			return fmt.Errorf("failed to check whether %v is a MIG device: %v", uuid, ret)
+		}
+		if isMig {
+			if total := memory.Total / mib; total < required {
+				errs = errors.Join(errs, fmt.Errorf("MIG device %v has %d MiB of memory but %d MiB were requested", uuid, total, required))
+			}
+			continue
+		}
+		if free := memory.Free / mib; free < required {
+			errs = errors.Join(errs, fmt.Errorf("GPU %v has %d MiB of free memory but %d MiB were requested", uuid, free, required))
+			continue
+		}
+		m.logger.Debugf("%d MiB of memory are available on %v", required, uuid)
+	}
+	return errs
+}
+
+// getDevices returns the devices with the specified indices or UUIDs. MIG
+// devices can be selected by UUID or by an index of the form <gpu>:<mig>.
+// GPUs with MIG mode enabled are replaced by their MIG devices.
+func getDevices(nvmllib nvml.Interface, ids ...string) ([]nvml.Device, error) {
+	var devices []nvml.Device
+	for _, id := range ids {
+		switch {
+		case id == "all":
+			count, ret := nvmllib.DeviceGetCount()
+			if ret != nvml.SUCCESS {
+				return nil, fmt.Errorf("failed to get device count: %v", ret)
+			}
+			for i := 0; i < count; i++ {
+				device, ret := nvmllib.DeviceGetHandleByIndex(i)
+				if ret != nvml.SUCCESS {
+					return nil, fmt.Errorf("failed to get device %d: %v", i, ret)
+				}
+				devices = append(devices, device)
+			}
+		case strings.HasPrefix(id, "GPU-"), strings.HasPrefix(id, "MIG-"):
+			device, ret := nvmllib.DeviceGetHandleByUUID(id)
+			if ret != nvml.SUCCESS {
+				return nil, fmt.Errorf("failed to get device %v: %v", id, ret)
+			}
+			devices = append(devices, device)
+		case strings.Contains(id, ":"):
+			gpuIndex, migIndex, _ := strings.Cut(id, ":")
+			gpu, err := getDeviceByIndex(nvmllib, gpuIndex)
+			if err != nil {
+				return nil, err
+			}
+			index, err := strconv.Atoi(migIndex)
+			if err != nil {
+				return nil, fmt.Errorf("invalid device %q", id)
+			}
+			device, ret := gpu.GetMigDeviceHandleByIndex(index)
+			if ret != nvml.SUCCESS {
+				return nil, fmt.Errorf("failed to get MIG device %v: %v", id, ret)
+			}
+			devices = append(devices, device)
+		default:
+			device, err := getDeviceByIndex(nvmllib, id)
+			if err != nil {
+				return nil, err
+			}
+			devices = append(devices, device)
+		}
+	}
+
+	var expanded []nvml.Device
+	for _, device := range devices {
+		migDevices, err := getMigDevices(device)
+		if err != nil {
+			return nil, err
+		}
+		if len(migDevices) > 0 {
+			expanded = append(expanded, migDevices...)
+			continue
+		}
+		expanded = append(expanded, device)
+	}
+	return expanded, nil
+}
+
+func getDeviceByIndex(nvmllib nvml.Interface, id string) (nvml.Device, error) {
+	index, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid device %q", id)
+	}
+	device, ret := nvmllib.DeviceGetHandleByIndex(index)
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get device %d: %v", index, ret)
+	}
+	return device, nil
+}
+
+// getMigDevices returns the MIG devices of the specified GPU if MIG mode is
+// enabled. If the device is a MIG device or does not support MIG, no devices
+// are returned.
+func getMigDevices(device nvml.Device) ([]nvml.Device, error) {
+	isMig, ret := device.IsMigDeviceHandle()
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to check for MIG device: %v", ret)
+	}
+	if isMig {
+		return nil, nil
+	}
+	mode, _, ret := device.GetMigMode()
+	if ret == nvml.ERROR_NOT_SUPPORTED {
+		return nil, nil
+	}
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get MIG mode: %v", ret)
+	}
+	if mode != nvml.DEVICE_MIG_ENABLE {
+		return nil, nil
+	}
+
+	count, ret := device.GetMaxMigDeviceCount()
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get MIG device count: %v", ret)
+	}
+	var migDevices []nvml.Device
+	for i := 0; i < count; i++ {
+		migDevice, ret := device.GetMigDeviceHandleByIndex(i)
+		if ret == nvml.ERROR_NOT_FOUND {
+			continue
+		}
+		if ret != nvml.SUCCESS {
+			return nil, fmt.Errorf("failed to get MIG device %d: %v", i, ret)
+		}
+		migDevices = append(migDevices, migDevice)
+	}
+	return migDevices, nil
+}
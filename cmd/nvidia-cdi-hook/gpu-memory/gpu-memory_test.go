@@ -0,0 +1,147 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package gpumemory
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func newDevice(uuid string, isMig bool, total uint64, free uint64, migDevices ...*mock.Device) *mock.Device {
+	return &mock.Device{
+		GetUUIDFunc: func() (string, nvml.Return) {
+			return uuid, nvml.SUCCESS
+		},
+		GetMemoryInfoFunc: func() (nvml.Memory, nvml.Return) {
+			return nvml.Memory{Total: total * mib, Free: free * mib}, nvml.SUCCESS
+		},
+		IsMigDeviceHandleFunc: func() (bool, nvml.Return) {
+			return isMig, nvml.SUCCESS
+		},
+		GetMigModeFunc: func() (int, int, nvml.Return) {
+			if len(migDevices) == 0 {
+				return nvml.DEVICE_MIG_DISABLE, nvml.DEVICE_MIG_DISABLE, nvml.SUCCESS
+			}
+			return nvml.DEVICE_MIG_ENABLE, nvml.DEVICE_MIG_ENABLE, nvml.SUCCESS
+		},
+		GetMaxMigDeviceCountFunc: func() (int, nvml.Return) {
+			return 7, nvml.SUCCESS
+		},
+		GetMigDeviceHandleByIndexFunc: func(n int) (nvml.Device, nvml.Return) {
+			if n >= len(migDevices) {
+				return nil, nvml.ERROR_NOT_FOUND
+			}
+			return migDevices[n], nvml.SUCCESS
+		},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	gpus := []*mock.Device{
+		newDevice("GPU-0", false, 81920, 40960),
+		newDevice("GPU-1", false, 81920, 81920,
+			newDevice("MIG-1", true, 10240, 10240),
+			newDevice("MIG-2", true, 20480, 0),
+		),
+	}
+	uuids := map[string]*mock.Device{
+		"GPU-0": gpus[0],
+		"GPU-1": gpus[1],
+		"MIG-2": newDevice("MIG-2", true, 20480, 0),
+	}
+	nvmllib := &mock.Interface{
+		DeviceGetCountFunc: func() (int, nvml.Return) {
+			return len(gpus), nvml.SUCCESS
+		},
+		DeviceGetHandleByIndexFunc: func(n int) (nvml.Device, nvml.Return) {
+			return gpus[n], nvml.SUCCESS
+		},
+		DeviceGetHandleByUUIDFunc: func(uuid string) (nvml.Device, nvml.Return) {
+			device, ok := uuids[uuid]
+			if !ok {
+				return nil, nvml.ERROR_NOT_FOUND
+			}
+			return device, nvml.SUCCESS
+		},
+	}
+
+	testCases := []struct {
+		description   string
+		memory        uint64
+		devices       []string
+		expectedError bool
+	}{
+		{
+			description: "free memory of GPU is sufficient",
+			memory:      40960,
+			devices:     []string{"0"},
+		},
+		{
+			description:   "free memory of GPU is insufficient",
+			memory:        40961,
+			devices:       []string{"GPU-0"},
+			expectedError: true,
+		},
+		{
+			description: "total memory of MIG device is checked",
+			memory:      20480,
+			devices:     []string{"MIG-2"},
+		},
+		{
+			description: "MIG device is selected by index",
+			memory:      20480,
+			devices:     []string{"1:1"},
+		},
+		{
+			description:   "too small MIG device is rejected",
+			memory:        20480,
+			devices:       []string{"1:0"},
+			expectedError: true,
+		},
+		{
+			description:   "GPU with MIG enabled checks all MIG devices",
+			memory:        20480,
+			devices:       []string{"all"},
+			expectedError: true,
+		},
+		{
+			description:   "unknown device returns error",
+			memory:        1024,
+			devices:       []string{"GPU-2"},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			m := command{logger: logger}
+			err := m.check(nvmllib, tc.memory, tc.devices...)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -18,11 +18,16 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/failinject"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/info"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/messages"
 
 	cli "github.com/urfave/cli/v3"
 
@@ -59,6 +64,10 @@ func main() {
 				logLevel = logrus.ErrorLevel
 			}
 			logger.SetLevel(logLevel)
+
+			if failinject.Enabled(failinject.HookTimeout) {
+				return ctx, waitForTermination(ctx, logger)
+			}
 			return ctx, nil
 		},
 		// We set the default action for the `nvidia-cdi-hook` command to issue a
@@ -90,13 +99,37 @@ func main() {
 				// TODO: Support for NVIDIA_CDI_QUIET is deprecated and NVIDIA_CTK_QUIET should be used instead.
 				Sources: cli.EnvVars("NVIDIA_CTK_QUIET", "NVIDIA_CDI_QUIET"),
 			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "Specify the output format for --version. One of [text, json]",
+				Value: "text",
+				Local: true,
+			},
 		},
 	}
 
+	// Allow the version to be output as JSON using --version --output=json.
+	cli.VersionPrinter = func(cmd *cli.Command) {
+		if err := info.WriteVersion(cmd.Root().Writer, cmd.Root().Name, cmd.Root().String("output")); err != nil {
+			logger.Errorf("%v", err)
+		}
+	}
+
 	// Run the CLI
 	err := c.Run(context.Background(), os.Args)
 	if err != nil {
-		logger.Errorf("%v", err)
+		messages.Log(logger, err)
 		os.Exit(1)
 	}
 }
+
+// waitForTermination blocks until the hook is terminated. This is used to
+// trigger the hook timeout of the low-level runtime when a hook timeout is
+// injected for testing.
+func waitForTermination(ctx context.Context, logger *logrus.Logger) error {
+	logger.Warningf("Blocking until terminated due to injected %v failure", failinject.HookTimeout)
+	ctx, cancel := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+	<-ctx.Done()
+	return fmt.Errorf("terminated while blocking due to injected %v failure", failinject.HookTimeout)
+}
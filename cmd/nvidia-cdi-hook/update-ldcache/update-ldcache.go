@@ -20,15 +20,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 	"os"
 
 	"github.com/moby/sys/reexec"
+	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v3"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/ldconfig"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/rootfs"
 )
 
 const (
@@ -43,6 +44,9 @@ type options struct {
 	folders       []string
 	ldconfigPath  string
 	containerSpec string
+
+	ldconfigAllowedPaths  []string
+	ldconfigAllowedSHA256 []string
 }
 
 func init() {
@@ -86,6 +90,16 @@ func (m command) build() *cli.Command {
 				Destination: &cfg.ldconfigPath,
 				Value:       "/sbin/ldconfig",
 			},
+			&cli.StringSliceFlag{
+				Name:        "ldconfig-allowed-path",
+				Usage:       "Specify an additional path pattern at which the ldconfig program is trusted",
+				Destination: &cfg.ldconfigAllowedPaths,
+			},
+			&cli.StringSliceFlag{
+				Name:        "ldconfig-sha256",
+				Usage:       "Specify an allowed SHA256 digest for the ldconfig program. If specified, the ldconfig program must match one of the digests",
+				Destination: &cfg.ldconfigAllowedSHA256,
+			},
 			&cli.StringFlag{
 				Name:        "container-spec",
 				Usage:       "Specify the path to the OCI container spec. If empty or '-' the spec will be read from STDIN",
@@ -115,6 +129,21 @@ func (m command) run(_ *cli.Command, cfg *options) error {
 		return fmt.Errorf("failed to determined container root: %v", err)
 	}
 
+	// Containers with read-only root filesystems would otherwise fail when
+	// the ldcache and ld.so.conf.d files are updated.
+	if err := rootfs.EnsureWritable(m.logger, containerRootDir, "/etc"); err != nil {
+		return fmt.Errorf("failed to ensure that /etc is writable: %w", err)
+	}
+
+	err = ldconfig.Verify(
+		cfg.ldconfigPath,
+		ldconfig.WithAllowedPaths(cfg.ldconfigAllowedPaths...),
+		ldconfig.WithAllowedSHA256(cfg.ldconfigAllowedSHA256...),
+	)
+	if err != nil {
+		return fmt.Errorf("refusing to run untrusted ldconfig: %w", err)
+	}
+
 	runner, err := ldconfig.NewRunner(
 		reexecUpdateLdCacheCommandName,
 		cfg.ldconfigPath,
@@ -129,8 +158,9 @@ func (m command) run(_ *cli.Command, cfg *options) error {
 
 // updateLdCacheHandler wraps updateLdCache with error handling.
 func updateLdCacheHandler() {
-	if err := updateLdCache(os.Args); err != nil {
-		log.Printf("Error updating ldcache: %v", err)
+	logger := logrus.New()
+	if err := updateLdCache(logger, os.Args); err != nil {
+		logger.Errorf("Error updating ldcache: %v", err)
 		os.Exit(1)
 	}
 }
@@ -145,7 +175,7 @@ func updateLdCacheHandler() {
 // args[1] is the path of the ldconfig binary on the host
 // args[2] is the container root directory
 // The remaining args are folders where soname symlinks need to be created.
-func updateLdCache(args []string) error {
+func updateLdCache(logger logger.Interface, args []string) error {
 	if len(args) < 3 {
 		return fmt.Errorf("incorrect arguments: %v", args)
 	}
@@ -153,6 +183,7 @@ func updateLdCache(args []string) error {
 	containerRootDirPath := args[2]
 
 	ldconfig, err := ldconfig.New(
+		logger,
 		hostLdconfigPath,
 		containerRootDirPath,
 	)
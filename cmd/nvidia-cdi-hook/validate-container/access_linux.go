@@ -0,0 +1,53 @@
+//go:build linux
+
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package validatecontainer
+
+import (
+	"os"
+	"syscall"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// isAccessible checks whether the specified file can be opened for reading
+// and writing by the specified user.
+func isAccessible(info os.FileInfo, user specs.User) bool {
+	if user.UID == 0 {
+		return true
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return true
+	}
+
+	perm := info.Mode().Perm()
+	if stat.Uid == user.UID {
+		return perm&0600 == 0600
+	}
+	if stat.Gid == user.GID {
+		return perm&0060 == 0060
+	}
+	for _, gid := range user.AdditionalGids {
+		if stat.Gid == gid {
+			return perm&0060 == 0060
+		}
+	}
+	return perm&0006 == 0006
+}
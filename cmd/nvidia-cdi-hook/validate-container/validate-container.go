@@ -0,0 +1,270 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package validatecontainer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/moby/sys/symlink"
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info/proc"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/ldcache"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+// defaultLibraryDirs are the directories that are searched by the dynamic
+// linker if a library is not found in the LD_LIBRARY_PATH or the ldcache.
+var defaultLibraryDirs = []string{"/lib64", "/usr/lib64", "/lib", "/usr/lib"}
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	libraries     []string
+	driverVersion string
+	containerSpec string
+}
+
+// NewCommand constructs a validate-container command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build the validate-container command
+func (m command) build() *cli.Command {
+	cfg := options{}
+
+	c := cli.Command{
+		Name: "validate-container",
+		Usage: "Check that the injected driver is usable in the container. " +
+			"This checks that the NVIDIA device nodes in the container are accessible by the container user and that the specified libraries are resolvable and match the driver version.",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(cmd, &cfg)
+		},
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:        "library",
+				Usage:       "Specify a library (e.g. libcuda.so.1) that must be resolvable by the dynamic linker in the container.",
+				Destination: &cfg.libraries,
+			},
+			&cli.StringFlag{
+				Name:        "driver-version",
+				Usage:       "Specify the version of the driver that the libraries must match. If this is not specified, the version of the loaded kernel module is used.",
+				Destination: &cfg.driverVersion,
+			},
+			&cli.StringFlag{
+				Name:        "container-spec",
+				Hidden:      true,
+				Category:    "testing-only",
+				Usage:       "Specify the path to the OCI container spec. If empty or '-' the spec will be read from STDIN",
+				Destination: &cfg.containerSpec,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) run(_ *cli.Command, cfg *options) error {
+	s, err := oci.LoadContainerState(cfg.containerSpec)
+	if err != nil {
+		return fmt.Errorf("failed to load container state: %w", err)
+	}
+
+	spec, err := s.LoadSpec()
+	if err != nil {
+		return fmt.Errorf("failed to load container spec: %w", err)
+	}
+
+	containerRoot, err := s.GetContainerRoot()
+	if err != nil {
+		return fmt.Errorf("failed to determined container root: %w", err)
+	}
+
+	driverVersion := cfg.driverVersion
+	if driverVersion == "" {
+		info, err := proc.GetKernelModuleInfo("/")
+		if err != nil {
+			m.logger.Warningf("Failed to determine driver version; skipping version checks: %v", err)
+		} else {
+			driverVersion = info.Version
+		}
+	}
+
+	v := newValidator(m.logger, containerRoot, spec)
+	if err := v.validate(cfg.libraries, driverVersion); err != nil {
+		return fmt.Errorf("container validation failed: %w", err)
+	}
+	return nil
+}
+
+// A validator checks the NVIDIA device nodes and driver libraries in a
+// container root.
+type validator struct {
+	logger        logger.Interface
+	containerRoot string
+	env           []string
+	user          specs.User
+	devices       []specs.LinuxDevice
+}
+
+func newValidator(logger logger.Interface, containerRoot string, spec *specs.Spec) *validator {
+	v := &validator{
+		logger:        logger,
+		containerRoot: containerRoot,
+	}
+	if spec.Process != nil {
+		v.env = spec.Process.Env
+		v.user = spec.Process.User
+	}
+	if spec.Linux != nil {
+		v.devices = spec.Linux.Devices
+	}
+	return v
+}
+
+// validate checks the NVIDIA device nodes and the specified libraries and
+// returns an error describing all failed checks.
+func (v *validator) validate(libraries []string, driverVersion string) error {
+	var errs []error
+	for _, device := range v.devices {
+		if !strings.HasPrefix(device.Path, "/dev/nvidia") {
+			continue
+		}
+		if err := v.checkDeviceNode(device.Path); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, library := range libraries {
+		if err := v.checkLibrary(library, driverVersion); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkDeviceNode checks that the specified device node exists in the
+// container and can be opened for reading and writing by the container user.
+func (v *validator) checkDeviceNode(path string) error {
+	info, err := os.Stat(filepath.Join(v.containerRoot, path))
+	if err != nil {
+		return fmt.Errorf("device node %v is not present in the container: %w", path, err)
+	}
+	if info.Mode()&os.ModeCharDevice == 0 && !(os.Getenv("__NVCT_TESTING_DEVICES_ARE_FILES") == "true" && info.Mode().IsRegular()) {
+		return fmt.Errorf("%v is not a char device", path)
+	}
+	if !isAccessible(info, v.user) {
+		return fmt.Errorf("device node %v (mode %v) is not accessible by user %d:%d", path, info.Mode().Perm(), v.user.UID, v.user.GID)
+	}
+	return nil
+}
+
+// checkLibrary checks that the specified library is resolvable in the
+// container. If a driver version is specified, the version of the resolved
+// library must match it.
+func (v *validator) checkLibrary(name string, driverVersion string) error {
+	path := v.resolveLibrary(name)
+	if path == "" {
+		return fmt.Errorf("%v is not resolvable in the container", name)
+	}
+	resolved, err := symlink.FollowSymlinkInScope(filepath.Join(v.containerRoot, path), v.containerRoot)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %v: %w", path, err)
+	}
+	v.logger.Debugf("Resolved %v to %v", name, strings.TrimPrefix(resolved, v.containerRoot))
+
+	if driverVersion == "" {
+		return nil
+	}
+	stem, _, _ := strings.Cut(name, ".so")
+	libraryVersion, ok := strings.CutPrefix(filepath.Base(resolved), stem+".so.")
+	if !ok || !strings.Contains(libraryVersion, ".") {
+		v.logger.Debugf("Unable to determine version of %v; skipping version check", resolved)
+		return nil
+	}
+	if libraryVersion != driverVersion {
+		return fmt.Errorf("%v resolves to %v with version %v which does not match the host driver version %v", name, path, libraryVersion, driverVersion)
+	}
+	return nil
+}
+
+// resolveLibrary returns the path in the container for the specified library
+// as resolved by the dynamic linker. The LD_LIBRARY_PATH, the ldcache, and the
+// default library directories are searched in order. If the library cannot be
+// resolved, an empty string is returned.
+func (v *validator) resolveLibrary(name string) string {
+	for _, dir := range filepath.SplitList(v.getenv("LD_LIBRARY_PATH")) {
+		if !filepath.IsAbs(dir) {
+			continue
+		}
+		if path := filepath.Join(dir, name); v.exists(path) {
+			return path
+		}
+	}
+
+	if cache, err := ldcache.New(v.logger, v.containerRoot); err != nil {
+		v.logger.Debugf("Failed to load ldcache: %v", err)
+	} else {
+		libs32, libs64 := cache.List()
+		for _, lib := range append(libs64, libs32...) {
+			if filepath.Base(lib) == name {
+				return strings.TrimPrefix(lib, v.containerRoot)
+			}
+		}
+	}
+
+	for _, dir := range defaultLibraryDirs {
+		if path := filepath.Join(dir, name); v.exists(path) {
+			return path
+		}
+	}
+	return ""
+}
+
+// exists checks whether the specified path exists in the container.
+// Symlinks are resolved in the container root.
+func (v *validator) exists(path string) bool {
+	resolved, err := symlink.FollowSymlinkInScope(filepath.Join(v.containerRoot, path), v.containerRoot)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(resolved)
+	return err == nil
+}
+
+func (v *validator) getenv(key string) string {
+	var value string
+	for _, env := range v.env {
+		if k, val, _ := strings.Cut(env, "="); k == key {
+			value = val
+		}
+	}
+	return value
+}
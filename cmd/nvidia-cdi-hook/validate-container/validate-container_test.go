@@ -0,0 +1,125 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package validatecontainer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	t.Setenv("__NVCT_TESTING_DEVICES_ARE_FILES", "true")
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description   string
+		deviceMode    os.FileMode
+		user          specs.User
+		devices       []string
+		env           []string
+		libraries     []string
+		driverVersion string
+		expectedError bool
+	}{
+		{
+			description:   "valid container",
+			deviceMode:    0666,
+			user:          specs.User{UID: 12345, GID: 12345},
+			devices:       []string{"/dev/nvidia0", "/dev/fuse"},
+			env:           []string{"LD_LIBRARY_PATH=/opt/lib"},
+			libraries:     []string{"libcuda.so.1"},
+			driverVersion: "550.54.15",
+		},
+		{
+			description:   "library in default directory is resolved",
+			deviceMode:    0666,
+			libraries:     []string{"libnvidia-ml.so.1"},
+			driverVersion: "550.54.15",
+		},
+		{
+			description:   "missing library is an error",
+			deviceMode:    0666,
+			libraries:     []string{"libcuda.so.1"},
+			expectedError: true,
+		},
+		{
+			description:   "version mismatch is an error",
+			deviceMode:    0666,
+			env:           []string{"LD_LIBRARY_PATH=/opt/lib"},
+			libraries:     []string{"libcuda.so.1"},
+			driverVersion: "535.104.05",
+			expectedError: true,
+		},
+		{
+			description:   "missing device node is an error",
+			deviceMode:    0666,
+			devices:       []string{"/dev/nvidia1"},
+			expectedError: true,
+		},
+		{
+			description:   "inaccessible device node is an error",
+			deviceMode:    0600,
+			user:          specs.User{UID: 12345, GID: 12345},
+			devices:       []string{"/dev/nvidia0"},
+			expectedError: true,
+		},
+		{
+			description: "device node is accessible by root",
+			deviceMode:  0600,
+			devices:     []string{"/dev/nvidia0"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			containerRoot := t.TempDir()
+			require.NoError(t, os.MkdirAll(filepath.Join(containerRoot, "dev"), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(containerRoot, "dev", "nvidia0"), nil, tc.deviceMode))
+			require.NoError(t, os.Chmod(filepath.Join(containerRoot, "dev", "nvidia0"), tc.deviceMode))
+			require.NoError(t, os.MkdirAll(filepath.Join(containerRoot, "opt", "lib"), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(containerRoot, "opt", "lib", "libcuda.so.550.54.15"), nil, 0644))
+			require.NoError(t, os.Symlink("libcuda.so.550.54.15", filepath.Join(containerRoot, "opt", "lib", "libcuda.so.1")))
+			require.NoError(t, os.MkdirAll(filepath.Join(containerRoot, "usr", "lib64"), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(containerRoot, "usr", "lib64", "libnvidia-ml.so.550.54.15"), nil, 0644))
+			require.NoError(t, os.Symlink("/usr/lib64/libnvidia-ml.so.550.54.15", filepath.Join(containerRoot, "usr", "lib64", "libnvidia-ml.so.1")))
+
+			spec := &specs.Spec{
+				Process: &specs.Process{
+					User: tc.user,
+					Env:  tc.env,
+				},
+				Linux: &specs.Linux{},
+			}
+			for _, device := range tc.devices {
+				spec.Linux.Devices = append(spec.Linux.Devices, specs.LinuxDevice{Path: device})
+			}
+
+			err := newValidator(logger, containerRoot, spec).validate(tc.libraries, tc.driverVersion)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
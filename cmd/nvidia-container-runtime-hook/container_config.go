@@ -11,6 +11,8 @@ import (
 	"golang.org/x/mod/semver"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
 )
 
 type nvidiaConfig struct {
@@ -242,6 +244,15 @@ func (hookConfig *hookConfig) getNvidiaConfig(image image.CUDA, privileged bool)
 	}
 }
 
+// getGPULister returns the lister used to resolve device requests that
+// exclude specific devices.
+func (hookConfig *hookConfig) getGPULister() image.GPULister {
+	driver := root.New(
+		root.WithDriverRoot(hookConfig.NVIDIAContainerCLIConfig.Root),
+	)
+	return info.NewGPULister(&logInterceptor{}, driver)
+}
+
 func (hookConfig *hookConfig) getContainerConfig() (config *containerConfig) {
 	hookConfig.Lock()
 	defer hookConfig.Unlock()
@@ -265,14 +276,25 @@ func (hookConfig *hookConfig) getContainerConfig() (config *containerConfig) {
 
 	privileged := isPrivileged(s)
 
+	var deviceAliases image.DeviceAliases
+	if path := hookConfig.NVIDIAContainerRuntimeConfig.DeviceAliasesPath; path != "" {
+		var err error
+		deviceAliases, err = image.LoadDeviceAliases(path)
+		if err != nil {
+			log.Panicln(err)
+		}
+	}
+
 	i, err := image.New(
 		image.WithEnv(s.Process.Env),
+		image.WithDeviceAliases(deviceAliases),
 		image.WithMounts(s.Mounts),
 		image.WithPrivileged(privileged),
 		image.WithDisableRequire(hookConfig.DisableRequire),
 		image.WithAcceptDeviceListAsVolumeMounts(hookConfig.AcceptDeviceListAsVolumeMounts),
 		image.WithAcceptEnvvarUnprivileged(hookConfig.AcceptEnvvarUnprivileged),
 		image.WithPreferredVisibleDevicesEnvVars(hookConfig.getSwarmResourceEnvvars()...),
+		image.WithGPULister(hookConfig.getGPULister()),
 	)
 	if err != nil {
 		log.Panicln(err)
@@ -23,6 +23,7 @@ var (
 	debugflag   = flag.Bool("debug", false, "enable debug output")
 	versionflag = flag.Bool("version", false, "enable version output")
 	configflag  = flag.String("config", "", "configuration file")
+	outputflag  = flag.String("output", "text", "the output format for --version. One of [text, json]")
 )
 
 func exit() {
@@ -169,7 +170,10 @@ func main() {
 	flag.Parse()
 
 	if *versionflag {
-		fmt.Printf("%v version %v\n", "NVIDIA Container Runtime Hook", info.GetVersionString())
+		if err := info.WriteVersion(os.Stdout, "NVIDIA Container Runtime Hook", *outputflag); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(2)
+		}
 		return
 	}
 
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"os"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/runtime"
@@ -10,6 +11,10 @@ func main() {
 	r := runtime.New()
 	err := r.Run(os.Args)
 	if err != nil {
+		var exitCoder interface{ ExitCode() int }
+		if errors.As(err, &exitCoder) {
+			os.Exit(exitCoder.ExitCode())
+		}
 		os.Exit(1)
 	}
 }
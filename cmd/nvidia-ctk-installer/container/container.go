@@ -24,6 +24,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk-installer/container/operator"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/filelock"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine"
 )
 
@@ -51,6 +52,16 @@ type Options struct {
 	HostRootMount string
 }
 
+// WithConfigLock runs the specified function while holding an exclusive lock
+// for the config file. This allows the config to be loaded, updated, and
+// flushed without being modified concurrently by other components.
+func (o Options) WithConfigLock(f func() error) error {
+	if o.Config == "" {
+		return f()
+	}
+	return filelock.WithLock(o.Config, f)
+}
+
 // Configure applies the options to the specified config
 func (o Options) Configure(cfg engine.Interface) error {
 	err := o.UpdateConfig(cfg)
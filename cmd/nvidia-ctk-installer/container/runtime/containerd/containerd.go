@@ -88,14 +88,18 @@ func Flags(opts *Options) []cli.Flag {
 func Setup(c *cli.Command, o *container.Options, co *Options) error {
 	log.Infof("Starting 'setup' for %v", c.Name)
 
-	cfg, err := getRuntimeConfig(o, co)
-	if err != nil {
-		return fmt.Errorf("unable to load config: %v", err)
-	}
-
-	err = o.Configure(cfg)
+	err := o.WithConfigLock(func() error {
+		cfg, err := getRuntimeConfig(o, co)
+		if err != nil {
+			return fmt.Errorf("unable to load config: %v", err)
+		}
+		if err := o.Configure(cfg); err != nil {
+			return fmt.Errorf("unable to configure containerd: %v", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("unable to configure containerd: %v", err)
+		return err
 	}
 
 	err = RestartContainerd(o)
@@ -112,14 +116,18 @@ func Setup(c *cli.Command, o *container.Options, co *Options) error {
 func Cleanup(c *cli.Command, o *container.Options, co *Options) error {
 	log.Infof("Starting 'cleanup' for %v", c.Name)
 
-	cfg, err := getRuntimeConfig(o, co)
-	if err != nil {
-		return fmt.Errorf("unable to load config: %v", err)
-	}
-
-	err = o.Unconfigure(cfg)
+	err := o.WithConfigLock(func() error {
+		cfg, err := getRuntimeConfig(o, co)
+		if err != nil {
+			return fmt.Errorf("unable to load config: %v", err)
+		}
+		if err := o.Unconfigure(cfg); err != nil {
+			return fmt.Errorf("unable to unconfigure containerd: %v", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("unable to unconfigure containerd: %v", err)
+		return err
 	}
 
 	err = RestartContainerd(o)
@@ -117,14 +117,18 @@ func setupHook(o *container.Options, co *Options) error {
 func setupConfig(o *container.Options) error {
 	log.Infof("Updating config file")
 
-	cfg, err := getRuntimeConfig(o)
-	if err != nil {
-		return fmt.Errorf("unable to load config: %v", err)
-	}
-
-	err = o.Configure(cfg)
+	err := o.WithConfigLock(func() error {
+		cfg, err := getRuntimeConfig(o)
+		if err != nil {
+			return fmt.Errorf("unable to load config: %v", err)
+		}
+		if err := o.Configure(cfg); err != nil {
+			return fmt.Errorf("unable to configure cri-o: %v", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("unable to configure cri-o: %v", err)
+		return err
 	}
 
 	err = RestartCrio(o)
@@ -166,14 +170,18 @@ func cleanupHook(co *Options) error {
 func cleanupConfig(o *container.Options) error {
 	log.Infof("Reverting config file modifications")
 
-	cfg, err := getRuntimeConfig(o)
-	if err != nil {
-		return fmt.Errorf("unable to load config: %v", err)
-	}
-
-	err = o.Unconfigure(cfg)
+	err := o.WithConfigLock(func() error {
+		cfg, err := getRuntimeConfig(o)
+		if err != nil {
+			return fmt.Errorf("unable to load config: %v", err)
+		}
+		if err := o.Unconfigure(cfg); err != nil {
+			return fmt.Errorf("unable to unconfigure cri-o: %v", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("unable to unconfigure cri-o: %v", err)
+		return err
 	}
 
 	err = RestartCrio(o)
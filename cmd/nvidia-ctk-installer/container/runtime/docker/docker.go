@@ -45,14 +45,18 @@ func Flags(opts *Options) []cli.Flag {
 func Setup(c *cli.Command, o *container.Options) error {
 	log.Infof("Starting 'setup' for %v", c.Name)
 
-	cfg, err := getRuntimeConfig(o)
+	err := o.WithConfigLock(func() error {
+		cfg, err := getRuntimeConfig(o)
+		if err != nil {
+			return fmt.Errorf("unable to load config: %v", err)
+		}
+		if err := o.Configure(cfg); err != nil {
+			return fmt.Errorf("unable to configure docker: %v", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("unable to load config: %v", err)
-	}
-
-	err = o.Configure(cfg)
-	if err != nil {
-		return fmt.Errorf("unable to configure docker: %v", err)
+		return err
 	}
 
 	err = RestartDocker(o)
@@ -69,14 +73,18 @@ func Setup(c *cli.Command, o *container.Options) error {
 func Cleanup(c *cli.Command, o *container.Options) error {
 	log.Infof("Starting 'cleanup' for %v", c.Name)
 
-	cfg, err := getRuntimeConfig(o)
-	if err != nil {
-		return fmt.Errorf("unable to load config: %v", err)
-	}
-
-	err = o.Unconfigure(cfg)
+	err := o.WithConfigLock(func() error {
+		cfg, err := getRuntimeConfig(o)
+		if err != nil {
+			return fmt.Errorf("unable to load config: %v", err)
+		}
+		if err := o.Unconfigure(cfg); err != nil {
+			return fmt.Errorf("unable to unconfigure docker: %v", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("unable to unconfigure docker: %v", err)
+		return err
 	}
 
 	err = RestartDocker(o)
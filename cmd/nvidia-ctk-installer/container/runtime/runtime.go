@@ -190,6 +190,21 @@ func Cleanup(c *cli.Command, opts *Options, runtime string) error {
 	}
 }
 
+// Restart restarts or signals the specified runtime using the configured
+// restart mode.
+func Restart(opts *Options, runtime string) error {
+	switch runtime {
+	case containerd.Name:
+		return containerd.RestartContainerd(&opts.Options)
+	case crio.Name:
+		return crio.RestartCrio(&opts.Options)
+	case docker.Name:
+		return docker.RestartDocker(&opts.Options)
+	default:
+		return fmt.Errorf("undefined runtime %v", runtime)
+	}
+}
+
 func GetLowlevelRuntimePaths(opts *Options, runtime string) ([]string, error) {
 	switch runtime {
 	case containerd.Name:
@@ -16,6 +16,7 @@ import (
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/info"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/messages"
 )
 
 const (
@@ -56,10 +57,17 @@ func main() {
 	logger := logger.New()
 	c := NewApp(logger)
 
+	// Allow the version to be output as JSON using --version --output=json.
+	cli.VersionPrinter = func(cmd *cli.Command) {
+		if err := info.WriteVersion(cmd.Root().Writer, cmd.Root().Name, cmd.Root().String("output")); err != nil {
+			logger.Errorf("%v", err)
+		}
+	}
+
 	// Run the CLI
 	logger.Infof("Starting %v", c.Name)
 	if err := c.Run(context.Background(), os.Args); err != nil {
-		logger.Errorf("error running %v: %v", c.Name, err)
+		messages.Log(logger, fmt.Errorf("error running %v: %w", c.Name, err))
 		os.Exit(1)
 	}
 
@@ -143,6 +151,12 @@ func (a app) build() *cli.Command {
 				Destination: &options.pidFile,
 				Sources:     cli.EnvVars("TOOLKIT_PID_FILE", "PID_FILE"),
 			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "Specify the output format for --version. One of [text, json]",
+				Value: "text",
+				Local: true,
+			},
 		},
 	}
 
@@ -222,7 +236,13 @@ func (a *app) Run(c *cli.Command, o *options) error {
 	}
 
 	if !o.noDaemon {
+		stopWatching := a.toolkit.WatchCDISpec(&o.toolkitOptions)
+		stopWatchingDriver := a.toolkit.WatchDriverState(&o.toolkitOptions, func() error {
+			return runtime.Restart(&o.runtimeOptions, o.runtime)
+		})
 		err = a.waitForSignal()
+		stopWatchingDriver()
+		stopWatching()
 		if err != nil {
 			return fmt.Errorf("unable to wait for signal: %v", err)
 		}
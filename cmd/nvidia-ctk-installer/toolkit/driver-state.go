@@ -0,0 +1,222 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package toolkit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/driverstate"
+)
+
+const (
+	// driverStateDebounce is the period for which filesystem events in the
+	// driver state directory are accumulated before the state is processed.
+	driverStateDebounce = 500 * time.Millisecond
+)
+
+// A driverStateReconciler performs the actions required when the state of
+// a containerized driver changes and acknowledges the observed state.
+type driverStateReconciler struct {
+	logger logger.Interface
+	dir    string
+
+	refreshLDCache func() error
+	updateCDISpec  func() (bool, error)
+	restartRuntime func() error
+}
+
+// WatchDriverState starts a background loop that implements the toolkit side
+// of the driver container handshake if --driver-state-dir is specified. The
+// restartRuntime function is called if the CDI spec changed as a result of a
+// driver change. The returned function stops the loop and waits for it to
+// exit.
+func (t *Installer) WatchDriverState(opts *Options, restartRuntime func() error) func() {
+	if t == nil || opts.driverStateDir == "" {
+		return func() {}
+	}
+
+	r := &driverStateReconciler{
+		logger:         t.logger,
+		dir:            opts.driverStateDir,
+		refreshLDCache: func() error { return refreshDriverRootLDCache(t.logger, opts.DriverRootCtrPath) },
+		restartRuntime: restartRuntime,
+	}
+	if opts.CDI.Enabled {
+		r.updateCDISpec = func() (bool, error) { return t.writeCDISpecIfChanged(opts) }
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := r.watch(ctx, driverStateDebounce); err != nil {
+			t.logger.Errorf("Stopped watching for driver state changes: %v", err)
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// watch reconciles the current driver state and then again each time the
+// driver state file is updated.
+func (r *driverStateReconciler) watch(ctx context.Context, debounce time.Duration) error {
+	if err := os.MkdirAll(r.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create driver state directory: %w", err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(r.dir); err != nil {
+		return fmt.Errorf("failed to watch %v: %w", r.dir, err)
+	}
+	r.logger.Infof("Watching %v for driver state changes", r.dir)
+
+	r.reconcile()
+
+	var pending <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return fmt.Errorf("watcher closed unexpectedly")
+			}
+			if filepath.Base(event.Name) != driverstate.DriverStateFilename {
+				continue
+			}
+			r.logger.Debugf("Received event %v", event)
+			if pending == nil {
+				pending = time.After(debounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return fmt.Errorf("watcher closed unexpectedly")
+			}
+			r.logger.Warningf("Error watching for driver state changes: %v", err)
+		case <-pending:
+			pending = nil
+			r.reconcile()
+		}
+	}
+}
+
+// reconcile processes the current driver state if it has not yet been
+// acknowledged. Errors are recorded in the acknowledgement so that the
+// driver container can surface them.
+func (r *driverStateReconciler) reconcile() {
+	driver, err := driverstate.ReadDriver(r.dir)
+	if err != nil {
+		r.logger.Warningf("Failed to read driver state: %v", err)
+		return
+	}
+	if driver == nil {
+		return
+	}
+	current, err := driverstate.ReadToolkit(r.dir)
+	if err != nil {
+		r.logger.Warningf("Failed to read toolkit state: %v", err)
+	}
+	if current.Acknowledges(driver) {
+		return
+	}
+
+	r.logger.Infof("Processing driver state: generation=%v phase=%v version=%v", driver.Generation, driver.Phase, driver.Version)
+	ack := &driverstate.Toolkit{
+		ObservedGeneration: driver.Generation,
+		ObservedPhase:      driver.Phase,
+		Phase:              driverstate.ToolkitPhaseReconciled,
+	}
+	if err := r.apply(driver); err != nil {
+		r.logger.Warningf("Failed to process driver state: %v", err)
+		ack.Phase = driverstate.ToolkitPhaseFailed
+		ack.Message = err.Error()
+	}
+	if err := driverstate.WriteToolkit(r.dir, ack); err != nil {
+		r.logger.Warningf("Failed to acknowledge driver state: %v", err)
+	}
+}
+
+// apply performs the actions required for the specified driver state. Once
+// a driver is ready, the ldcache of the driver root is refreshed so that the
+// new libraries are found, the CDI spec is regenerated, and the container
+// engine is signaled if the CDI spec changed. No actions are required while
+// a driver is unloading.
+func (r *driverStateReconciler) apply(driver *driverstate.Driver) error {
+	switch driver.Phase {
+	case driverstate.DriverPhaseUnloading:
+		return nil
+	case driverstate.DriverPhaseReady:
+	default:
+		return fmt.Errorf("unsupported driver phase %q", driver.Phase)
+	}
+
+	if r.refreshLDCache != nil {
+		if err := r.refreshLDCache(); err != nil {
+			return fmt.Errorf("failed to refresh ldcache: %w", err)
+		}
+	}
+	if r.updateCDISpec == nil {
+		return nil
+	}
+	updated, err := r.updateCDISpec()
+	if err != nil {
+		return fmt.Errorf("failed to update CDI spec: %w", err)
+	}
+	if !updated || r.restartRuntime == nil {
+		return nil
+	}
+	if err := r.restartRuntime(); err != nil {
+		return fmt.Errorf("failed to signal container engine: %w", err)
+	}
+	return nil
+}
+
+// refreshDriverRootLDCache updates the ldcache in the specified driver root.
+// The ldcache of the host is not modified.
+func refreshDriverRootLDCache(logger logger.Interface, driverRoot string) error {
+	if driverRoot == "" || driverRoot == "/" {
+		return nil
+	}
+	ldconfig, err := exec.LookPath("ldconfig")
+	if err != nil {
+		logger.Warningf("Skipping ldcache refresh: %v", err)
+		return nil
+	}
+	//nolint:gosec // The driver root is specified by the administrator.
+	cmd := exec.Command(ldconfig, "-r", driverRoot)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, output)
+	}
+	return nil
+}
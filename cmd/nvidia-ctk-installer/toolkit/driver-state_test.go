@@ -0,0 +1,145 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package toolkit
+
+import (
+	"fmt"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/driverstate"
+)
+
+func TestDriverStateReconcile(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description   string
+		driver        *driverstate.Driver
+		toolkit       *driverstate.Toolkit
+		specUpdated   bool
+		specError     error
+		expectedCalls []string
+		expectedAck   *driverstate.Toolkit
+	}{
+		{
+			description: "no driver state is ignored",
+		},
+		{
+			description: "ready driver triggers reconciliation",
+			driver:      &driverstate.Driver{Generation: "1", Phase: driverstate.DriverPhaseReady},
+			specUpdated: true,
+			expectedCalls: []string{
+				"ldcache", "cdi", "restart",
+			},
+			expectedAck: &driverstate.Toolkit{
+				ObservedGeneration: "1",
+				ObservedPhase:      driverstate.DriverPhaseReady,
+				Phase:              driverstate.ToolkitPhaseReconciled,
+			},
+		},
+		{
+			description: "runtime is not signaled for unchanged spec",
+			driver:      &driverstate.Driver{Generation: "1", Phase: driverstate.DriverPhaseReady},
+			expectedCalls: []string{
+				"ldcache", "cdi",
+			},
+			expectedAck: &driverstate.Toolkit{
+				ObservedGeneration: "1",
+				ObservedPhase:      driverstate.DriverPhaseReady,
+				Phase:              driverstate.ToolkitPhaseReconciled,
+			},
+		},
+		{
+			description: "acknowledged state is not reconciled again",
+			driver:      &driverstate.Driver{Generation: "1", Phase: driverstate.DriverPhaseReady},
+			toolkit: &driverstate.Toolkit{
+				ObservedGeneration: "1",
+				ObservedPhase:      driverstate.DriverPhaseReady,
+				Phase:              driverstate.ToolkitPhaseReconciled,
+			},
+			expectedAck: &driverstate.Toolkit{
+				ObservedGeneration: "1",
+				ObservedPhase:      driverstate.DriverPhaseReady,
+				Phase:              driverstate.ToolkitPhaseReconciled,
+			},
+		},
+		{
+			description: "unloading driver is acknowledged",
+			driver:      &driverstate.Driver{Generation: "2", Phase: driverstate.DriverPhaseUnloading},
+			expectedAck: &driverstate.Toolkit{
+				ObservedGeneration: "2",
+				ObservedPhase:      driverstate.DriverPhaseUnloading,
+				Phase:              driverstate.ToolkitPhaseReconciled,
+			},
+		},
+		{
+			description: "failure is recorded in acknowledgement",
+			driver:      &driverstate.Driver{Generation: "1", Phase: driverstate.DriverPhaseReady},
+			specError:   fmt.Errorf("driver not found"),
+			expectedCalls: []string{
+				"ldcache", "cdi",
+			},
+			expectedAck: &driverstate.Toolkit{
+				ObservedGeneration: "1",
+				ObservedPhase:      driverstate.DriverPhaseReady,
+				Phase:              driverstate.ToolkitPhaseFailed,
+				Message:            "failed to update CDI spec: driver not found",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			dir := t.TempDir()
+			if tc.driver != nil {
+				require.NoError(t, driverstate.WriteDriver(dir, tc.driver))
+			}
+			if tc.toolkit != nil {
+				require.NoError(t, driverstate.WriteToolkit(dir, tc.toolkit))
+			}
+
+			var calls []string
+			r := &driverStateReconciler{
+				logger: logger,
+				dir:    dir,
+				refreshLDCache: func() error {
+					calls = append(calls, "ldcache")
+					return nil
+				},
+				updateCDISpec: func() (bool, error) {
+					calls = append(calls, "cdi")
+					return tc.specUpdated, tc.specError
+				},
+				restartRuntime: func() error {
+					calls = append(calls, "restart")
+					return nil
+				},
+			}
+
+			r.reconcile()
+			require.EqualValues(t, tc.expectedCalls, calls)
+
+			ack, err := driverstate.ReadToolkit(dir)
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedAck, ack)
+		})
+	}
+}
@@ -38,6 +38,7 @@ func newArtifactRoot(logger logger.Interface, rootDirectoryPath string) (*artifa
 		"/usr/lib64",
 		"/usr/lib/x86_64-linux-gnu",
 		"/usr/lib/aarch64-linux-gnu",
+		"/usr/lib/powerpc64le-linux-gnu",
 	}
 	var librarySearchPaths []string
 	for _, l := range relativeLibrarySearchPaths {
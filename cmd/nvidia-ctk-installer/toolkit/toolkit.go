@@ -21,6 +21,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/urfave/cli/v3"
 	"tags.cncf.io/container-device-interface/pkg/cdi"
@@ -28,9 +29,12 @@ import (
 
 	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk-installer/toolkit/installer"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/filelock"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/system/nvdevices"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/driverstate"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
 	transformroot "github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/transform/root"
 )
 
@@ -45,6 +49,9 @@ type cdiOptions struct {
 	kind      string
 	vendor    string
 	class     string
+
+	watch         bool
+	watchInterval time.Duration
 }
 
 type Options struct {
@@ -71,6 +78,8 @@ type Options struct {
 
 	createDeviceNodes []string
 
+	driverStateDir string
+
 	acceptNVIDIAVisibleDevicesWhenUnprivileged bool
 	acceptNVIDIAVisibleDevicesAsVolumeMounts   bool
 
@@ -187,6 +196,25 @@ func Flags(opts *Options) []cli.Flag {
 			Destination: &opts.CDI.kind,
 			Sources:     cli.EnvVars("CDI_KIND"),
 		},
+		&cli.BoolFlag{
+			Name:        "cdi-watch",
+			Usage:       "(Only applicable with --cdi-enabled) keep the generated CDI specification up to date by watching the driver root and MIG state for changes while the toolkit container is running",
+			Destination: &opts.CDI.watch,
+			Sources:     cli.EnvVars("CDI_WATCH"),
+		},
+		&cli.DurationFlag{
+			Name:        "cdi-watch-resync-interval",
+			Usage:       "(Only applicable with --cdi-watch) the interval at which the CDI specification is regenerated even if no changes are detected. This is required to detect changes that do not trigger filesystem events such as MIG reconfiguration.",
+			Value:       time.Minute,
+			Destination: &opts.CDI.watchInterval,
+			Sources:     cli.EnvVars("CDI_WATCH_RESYNC_INTERVAL"),
+		},
+		&cli.StringFlag{
+			Name:        "driver-state-dir",
+			Usage:       "the directory used to coordinate with a driver container. If set, the ldcache of the driver root is refreshed, the CDI specification is regenerated, and the runtime is signaled each time the driver container updates its state. Set to " + driverstate.DefaultDir + " to use the default location.",
+			Destination: &opts.driverStateDir,
+			Sources:     cli.EnvVars("DRIVER_STATE_DIR"),
+		},
 		&cli.BoolFlag{
 			Name:        "ignore-errors",
 			Usage:       "ignore errors when installing the NVIDIA Container toolkit. This is used for testing purposes only.",
@@ -257,6 +285,13 @@ func (t *Installer) ValidateOptions(opts *Options) error {
 		t.logger.Warning("Skipping CDI spec generation (no output directory specified)")
 		opts.CDI.Enabled = false
 	}
+	if opts.CDI.watch && !opts.CDI.Enabled {
+		t.logger.Info("disabling CDI spec watching since --cdi-enabled=false")
+		opts.CDI.watch = false
+	}
+	if opts.CDI.watch && opts.CDI.watchInterval <= 0 {
+		return fmt.Errorf("invalid --cdi-watch-resync-interval value: %v", opts.CDI.watchInterval)
+	}
 
 	isDisabled := false
 	for _, mode := range opts.createDeviceNodes {
@@ -328,8 +363,7 @@ func (t *Installer) Install(cli *cli.Command, opts *Options) error {
 		t.logger.Errorf("Ignoring error: %v", fmt.Errorf("error creating device nodes: %v", err))
 	}
 
-	nvidiaCDIHookPath := filepath.Join(t.toolkitRoot, "nvidia-cdi-hook")
-	err = t.generateCDISpec(opts, nvidiaCDIHookPath)
+	err = t.generateCDISpec(opts, t.nvidiaCDIHookPath())
 	if err != nil && !opts.ignoreErrors {
 		return fmt.Errorf("error generating CDI specification: %v", err)
 	} else if err != nil {
@@ -355,6 +389,18 @@ func (t *Installer) installToolkitConfig(c *cli.Command, opts *Options, toolkitC
 	nvidiaCTKPath := filepath.Join(t.toolkitRoot, "nvidia-ctk")
 	nvidiaContainerRuntimeHookPath := filepath.Join(t.toolkitRoot, "nvidia-container-runtime-hook")
 
+	// The lock is held while the config is loaded and written so that the
+	// config is not modified concurrently by other components.
+	lock, err := filelock.Acquire(toolkitConfigPath)
+	if err != nil {
+		return fmt.Errorf("could not lock target config file: %v", err)
+	}
+	defer func() {
+		if err := lock.Unlock(); err != nil {
+			t.logger.Warningf("Failed to unlock %v: %v", toolkitConfigPath, err)
+		}
+	}()
+
 	cfg, err := config.New()
 	if err != nil {
 		return fmt.Errorf("could not open source config file: %v", err)
@@ -493,6 +539,21 @@ func (t *Installer) generateCDISpec(opts *Options, nvidiaCDIHookPath string) err
 		return nil
 	}
 	t.logger.Info("Generating CDI spec for management containers")
+	spec, path, err := t.getCDISpec(opts, nvidiaCDIHookPath)
+	if err != nil {
+		return err
+	}
+	err = spec.Save(path)
+	if err != nil {
+		return fmt.Errorf("failed to save CDI spec for management containers: %v", err)
+	}
+
+	return nil
+}
+
+// getCDISpec returns the CDI spec for use in management containers and the
+// path in the output directory at which it is to be saved.
+func (t *Installer) getCDISpec(opts *Options, nvidiaCDIHookPath string) (spec.Interface, string, error) {
 	cdilib, err := nvcdi.New(
 		nvcdi.WithLogger(t.logger),
 		nvcdi.WithMode(nvcdi.ModeManagement),
@@ -503,12 +564,12 @@ func (t *Installer) generateCDISpec(opts *Options, nvidiaCDIHookPath string) err
 		nvcdi.WithClass(opts.CDI.class),
 	)
 	if err != nil {
-		return fmt.Errorf("failed to create CDI library for management containers: %v", err)
+		return nil, "", fmt.Errorf("failed to create CDI library for management containers: %v", err)
 	}
 
 	spec, err := cdilib.GetSpec()
 	if err != nil {
-		return fmt.Errorf("failed to genereate CDI spec for management containers: %v", err)
+		return nil, "", fmt.Errorf("failed to genereate CDI spec for management containers: %v", err)
 	}
 
 	transformer := transformroot.NewDriverTransformer(
@@ -518,17 +579,19 @@ func (t *Installer) generateCDISpec(opts *Options, nvidiaCDIHookPath string) err
 		transformroot.WithTargetDevRoot(opts.DevRoot),
 	)
 	if err := transformer.Transform(spec.Raw()); err != nil {
-		return fmt.Errorf("failed to transform driver root in CDI spec: %v", err)
+		return nil, "", fmt.Errorf("failed to transform driver root in CDI spec: %v", err)
 	}
 
 	name, err := cdi.GenerateNameForSpec(spec.Raw())
 	if err != nil {
-		return fmt.Errorf("failed to generate CDI name for management containers: %v", err)
-	}
-	err = spec.Save(filepath.Join(opts.CDI.outputDir, name))
-	if err != nil {
-		return fmt.Errorf("failed to save CDI spec for management containers: %v", err)
+		return nil, "", fmt.Errorf("failed to generate CDI name for management containers: %v", err)
 	}
 
-	return nil
+	// The spec is generated in the default (YAML) format. We include the
+	// extension in the path so that an existing spec can be compared against.
+	return spec, filepath.Join(opts.CDI.outputDir, name+".yaml"), nil
+}
+
+func (t *Installer) nvidiaCDIHookPath() string {
+	return filepath.Join(t.toolkitRoot, "nvidia-cdi-hook")
 }
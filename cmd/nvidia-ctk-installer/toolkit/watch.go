@@ -0,0 +1,179 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package toolkit
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	// cdiWatchDebounce is the period for which filesystem events are
+	// accumulated before the CDI spec is regenerated. This prevents a
+	// regeneration for each device node that is created while a driver is
+	// being loaded.
+	cdiWatchDebounce = 2 * time.Second
+)
+
+// WatchCDISpec starts a background loop that keeps the CDI spec for
+// management containers up to date if --cdi-watch is specified. The returned
+// function stops the loop and waits for it to exit.
+func (t *Installer) WatchCDISpec(opts *Options) func() {
+	if t == nil || !opts.CDI.watch {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := t.watchCDISpec(ctx, opts, cdiWatchDebounce); err != nil {
+			t.logger.Errorf("Stopped watching for CDI spec changes: %v", err)
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// watchCDISpec regenerates the CDI spec for management containers when the
+// contents of the driver root or the device nodes change. Since the MIG
+// capabilities in procfs do not generate inotify events, the spec is also
+// regenerated at the configured resync interval. The spec is only written if
+// its contents have changed.
+func (t *Installer) watchCDISpec(ctx context.Context, opts *Options, debounce time.Duration) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	for _, path := range getCDIWatchPaths(opts) {
+		if err := watcher.Add(path); err != nil {
+			t.logger.Warningf("Not watching %v for changes: %v", path, err)
+			continue
+		}
+		t.logger.Infof("Watching %v for changes", path)
+	}
+
+	resync := time.NewTicker(opts.CDI.watchInterval)
+	defer resync.Stop()
+
+	var pending <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return fmt.Errorf("watcher closed unexpectedly")
+			}
+			t.logger.Debugf("Received event %v", event)
+			if event.Has(fsnotify.Create) && isDir(event.Name) {
+				// Directories such as /dev/nvidia-caps may only be created
+				// once the driver is loaded.
+				_ = watcher.Add(event.Name)
+			}
+			if pending == nil {
+				pending = time.After(debounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return fmt.Errorf("watcher closed unexpectedly")
+			}
+			t.logger.Warningf("Error watching for changes: %v", err)
+		case <-pending:
+			pending = nil
+			t.updateCDISpec(opts)
+		case <-resync.C:
+			t.updateCDISpec(opts)
+		}
+	}
+}
+
+// updateCDISpec regenerates the CDI spec for management containers and
+// replaces the existing spec if its contents have changed. Errors are logged
+// since the toolkit container should keep running if the driver is
+// temporarily unavailable (e.g. while it is being upgraded).
+func (t *Installer) updateCDISpec(opts *Options) {
+	if _, err := t.writeCDISpecIfChanged(opts); err != nil {
+		t.logger.Warningf("Failed to update CDI spec for management containers: %v", err)
+	}
+}
+
+func (t *Installer) writeCDISpecIfChanged(opts *Options) (bool, error) {
+	spec, path, err := t.getCDISpec(opts, t.nvidiaCDIHookPath())
+	if err != nil {
+		return false, err
+	}
+
+	contents := &bytes.Buffer{}
+	if _, err := spec.WriteTo(contents); err != nil {
+		return false, fmt.Errorf("failed to render CDI spec: %w", err)
+	}
+	existing, err := os.ReadFile(path)
+	if err == nil && bytes.Equal(existing, contents.Bytes()) {
+		return false, nil
+	}
+
+	// The spec is written to a temporary file in the output directory and
+	// renamed so that consumers never observe a partially written spec.
+	if err := spec.Save(path); err != nil {
+		return false, fmt.Errorf("failed to save CDI spec: %w", err)
+	}
+	t.logger.Infof("Updated CDI spec %v", path)
+	return true, nil
+}
+
+// getCDIWatchPaths returns the paths that are watched for changes that affect
+// the generated CDI spec. This includes the driver root as well as the device
+// nodes for MIG capabilities.
+func getCDIWatchPaths(opts *Options) []string {
+	devRoot := opts.DevRootCtrPath
+	if devRoot == "" {
+		devRoot = opts.DriverRootCtrPath
+	}
+
+	var paths []string
+	seen := make(map[string]bool)
+	for _, path := range []string{
+		opts.DriverRootCtrPath,
+		filepath.Join(devRoot, "dev"),
+		filepath.Join(devRoot, "dev", "nvidia-caps"),
+	} {
+		if path == "" || seen[path] {
+			continue
+		}
+		seen[path] = true
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
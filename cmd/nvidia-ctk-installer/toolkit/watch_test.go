@@ -0,0 +1,131 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package toolkit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+)
+
+func TestWriteCDISpecIfChanged(t *testing.T) {
+	t.Setenv("__NVCT_TESTING_DEVICES_ARE_FILES", "true")
+	logger, _ := testlog.NewNullLogger()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+
+	cdiOutputDir := t.TempDir()
+	options := Options{
+		DriverRoot:        "/host/driver/root",
+		DriverRootCtrPath: filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1"),
+		CDI: cdiOptions{
+			Enabled:   true,
+			outputDir: cdiOutputDir,
+			kind:      "example.com/class",
+		},
+	}
+
+	ti := NewInstaller(
+		WithLogger(logger),
+		WithToolkitRoot(t.TempDir()),
+	)
+	require.NoError(t, ti.ValidateOptions(&options))
+
+	cdiSpecFile := filepath.Join(cdiOutputDir, "example.com-class.yaml")
+
+	updated, err := ti.writeCDISpecIfChanged(&options)
+	require.NoError(t, err)
+	require.True(t, updated)
+	require.FileExists(t, cdiSpecFile)
+
+	info, err := os.Stat(cdiSpecFile)
+	require.NoError(t, err)
+
+	updated, err = ti.writeCDISpecIfChanged(&options)
+	require.NoError(t, err)
+	require.False(t, updated, "an unchanged spec should not be rewritten")
+
+	unchanged, err := os.Stat(cdiSpecFile)
+	require.NoError(t, err)
+	require.True(t, os.SameFile(info, unchanged))
+
+	require.NoError(t, os.WriteFile(cdiSpecFile, []byte("stale"), 0600))
+	updated, err = ti.writeCDISpecIfChanged(&options)
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	contents, err := os.ReadFile(cdiSpecFile)
+	require.NoError(t, err)
+	require.NotEqual(t, "stale", string(contents))
+}
+
+func TestGetCDIWatchPaths(t *testing.T) {
+	testCases := []struct {
+		description   string
+		options       Options
+		expectedPaths []string
+	}{
+		{
+			description: "dev root defaults to driver root",
+			options: Options{
+				DriverRootCtrPath: "/driver-root",
+			},
+			expectedPaths: []string{
+				"/driver-root",
+				"/driver-root/dev",
+				"/driver-root/dev/nvidia-caps",
+			},
+		},
+		{
+			description: "dev root is used for device nodes",
+			options: Options{
+				DriverRootCtrPath: "/driver-root",
+				DevRootCtrPath:    "/host",
+			},
+			expectedPaths: []string{
+				"/driver-root",
+				"/host/dev",
+				"/host/dev/nvidia-caps",
+			},
+		},
+		{
+			description: "duplicate paths are removed",
+			options: Options{
+				DriverRootCtrPath: "/",
+				DevRootCtrPath:    "/",
+			},
+			expectedPaths: []string{
+				"/",
+				"/dev",
+				"/dev/nvidia-caps",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.EqualValues(t, tc.expectedPaths, getCDIWatchPaths(&tc.options))
+		})
+	}
+}
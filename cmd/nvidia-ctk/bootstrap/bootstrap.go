@@ -0,0 +1,329 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi/generate"
+	waitfordriver "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/system/wait-for-driver"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/engines"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine"
+)
+
+const (
+	defaultNVIDIARuntimeName = "nvidia"
+	defaultCDIOutputFile     = "/var/run/cdi/nvidia.yaml"
+	defaultStatusFile        = "/run/nvidia-container-toolkit/bootstrap.json"
+)
+
+type command struct {
+	logger         logger.Interface
+	configFilePath *string
+}
+
+type options struct {
+	driverRoot        string
+	devRoot           string
+	timeout           time.Duration
+	cdiOutput         string
+	nvidiaRuntimeName string
+	statusFile        string
+}
+
+// NewCommand constructs a bootstrap command with the specified logger
+func NewCommand(logger logger.Interface, configFilePath *string) *cli.Command {
+	c := command{
+		logger:         logger,
+		configFilePath: configFilePath,
+	}
+	return c.build()
+}
+
+// build
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name:  "bootstrap",
+		Usage: "Prepare a node for GPU containers on first boot: wait for the NVIDIA driver, generate a CDI specification, verify the container engine configuration, and write a status file. This is intended to be run as a oneshot systemd unit",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(&opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			b := &bootstrapper{
+				logger:            m.logger,
+				nvidiaRuntimeName: opts.nvidiaRuntimeName,
+				engines:           engines.Defaults,
+				steps:             m.getSteps(&opts),
+			}
+			return b.run(ctx, opts.statusFile)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "driver-root",
+				Usage:       "the path to the driver root. If this is not specified, the defaults of the wait-for-driver and cdi generate commands are used",
+				Destination: &opts.driverRoot,
+			},
+			&cli.StringFlag{
+				Name:        "dev-root",
+				Usage:       "specify the root where `/dev` is located. If this is not specified, the driver root is assumed",
+				Destination: &opts.devRoot,
+			},
+			&cli.DurationFlag{
+				Name:        "timeout",
+				Usage:       "the maximum time to wait for the driver to be ready. A value of 0 waits indefinitely",
+				Value:       5 * time.Minute,
+				Destination: &opts.timeout,
+			},
+			&cli.StringFlag{
+				Name:        "cdi-output",
+				Usage:       "the path to which the generated CDI specification is written",
+				Value:       defaultCDIOutputFile,
+				Destination: &opts.cdiOutput,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_OUTPUT_FILE_PATH"),
+			},
+			&cli.StringFlag{
+				Name:        "nvidia-runtime-name",
+				Usage:       "the name of the NVIDIA runtime that is expected in the container engine configs",
+				Value:       defaultNVIDIARuntimeName,
+				Destination: &opts.nvidiaRuntimeName,
+			},
+			&cli.StringFlag{
+				Name:        "status-file",
+				Usage:       "the path to which the bootstrap status is written as JSON",
+				Value:       defaultStatusFile,
+				Destination: &opts.statusFile,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(opts *options) error {
+	if opts.timeout < 0 {
+		return fmt.Errorf("the timeout must not be negative")
+	}
+	if opts.cdiOutput == "" {
+		return fmt.Errorf("a CDI output file must be specified")
+	}
+	if opts.statusFile == "" {
+		return fmt.Errorf("a status file must be specified")
+	}
+	return nil
+}
+
+// getSteps returns the steps that require the NVIDIA driver. These are
+// performed by running the equivalent nvidia-ctk commands so that the same
+// defaults and environment variables apply.
+func (m command) getSteps(opts *options) []step {
+	var rootArgs []string
+	if opts.driverRoot != "" {
+		rootArgs = append(rootArgs, "--driver-root="+opts.driverRoot)
+	}
+	if opts.devRoot != "" {
+		rootArgs = append(rootArgs, "--dev-root="+opts.devRoot)
+	}
+
+	waitArgs := append([]string{"wait-for-driver", "--timeout=" + opts.timeout.String()}, rootArgs...)
+	generateArgs := append([]string{"generate", "--output=" + opts.cdiOutput}, rootArgs...)
+
+	return []step{
+		{
+			name: "wait-for-driver",
+			run: func(ctx context.Context) (string, error) {
+				if err := waitfordriver.NewCommand(m.logger).Run(ctx, waitArgs); err != nil {
+					return "", err
+				}
+				return "the NVIDIA driver is ready", nil
+			},
+		},
+		{
+			name: "generate-cdi-spec",
+			run: func(ctx context.Context) (string, error) {
+				if err := generate.NewCommand(m.logger, m.configFilePath).Run(ctx, generateArgs); err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("generated CDI specification %v", opts.cdiOutput), nil
+			},
+		},
+	}
+}
+
+// A step is performed as part of the bootstrap process. If a step fails, the
+// remaining steps are skipped.
+type step struct {
+	name string
+	run  func(context.Context) (string, error)
+}
+
+// A status records the outcome of the bootstrap process.
+type status struct {
+	Started  time.Time    `json:"started"`
+	Finished time.Time    `json:"finished"`
+	Success  bool         `json:"success"`
+	Steps    []stepStatus `json:"steps"`
+}
+
+type stepStatus struct {
+	Name    string `json:"name"`
+	Result  string `json:"result"`
+	Message string `json:"message,omitempty"`
+}
+
+const (
+	resultSucceeded = "succeeded"
+	resultFailed    = "failed"
+	resultSkipped   = "skipped"
+)
+
+type bootstrapper struct {
+	logger            logger.Interface
+	nvidiaRuntimeName string
+	engines           []engines.Engine
+	steps             []step
+}
+
+// run performs the bootstrap steps, verifies the engine configuration and
+// writes the status file. The status file is written even if a step fails.
+func (b *bootstrapper) run(ctx context.Context, statusFile string) error {
+	s := &status{
+		Started: time.Now().UTC(),
+	}
+
+	var errs error
+	for _, st := range b.steps {
+		if errs != nil {
+			s.Steps = append(s.Steps, stepStatus{Name: st.name, Result: resultSkipped})
+			continue
+		}
+		b.logger.Infof("Running bootstrap step %v", st.name)
+		message, err := st.run(ctx)
+		if err != nil {
+			errs = fmt.Errorf("%v failed: %w", st.name, err)
+			s.Steps = append(s.Steps, stepStatus{Name: st.name, Result: resultFailed, Message: err.Error()})
+			continue
+		}
+		s.Steps = append(s.Steps, stepStatus{Name: st.name, Result: resultSucceeded, Message: message})
+	}
+
+	// The engine configuration is verified even if the driver is not ready
+	// since this is independent of the driver.
+	for _, es := range b.verifyEngines() {
+		if es.Result == resultFailed {
+			errs = errors.Join(errs, fmt.Errorf("%v: %v", es.Name, es.Message))
+		}
+		s.Steps = append(s.Steps, es)
+	}
+
+	s.Finished = time.Now().UTC()
+	s.Success = errs == nil
+	if err := writeStatus(statusFile, s); err != nil {
+		errs = errors.Join(errs, fmt.Errorf("failed to write status file: %w", err))
+	}
+	if errs == nil {
+		b.logger.Infof("Bootstrap completed successfully")
+	}
+	return errs
+}
+
+// verifyEngines checks that each container engine that has a config file is
+// configured to use the NVIDIA Container Runtime or has CDI enabled.
+func (b *bootstrapper) verifyEngines() []stepStatus {
+	var statuses []stepStatus
+	for _, e := range b.engines {
+		s := stepStatus{
+			Name: "verify-" + e.Name,
+		}
+		if _, err := os.Stat(e.ConfigFilePath); err != nil {
+			s.Result = resultSkipped
+			s.Message = fmt.Sprintf("config file %v not found", e.ConfigFilePath)
+			statuses = append(statuses, s)
+			continue
+		}
+
+		cfg, err := e.LoadConfig(b.logger)
+		if err != nil {
+			s.Result = resultFailed
+			s.Message = fmt.Sprintf("failed to load config: %v", err)
+			statuses = append(statuses, s)
+			continue
+		}
+
+		var runtimeConfigured bool
+		if runtime, err := cfg.GetRuntimeConfig(b.nvidiaRuntimeName); err == nil && runtime != nil {
+			runtimeConfigured = runtime.GetBinaryPath() != ""
+		}
+		var cdiEnabled bool
+		if checker, ok := cfg.(engine.CDIChecker); ok {
+			cdiEnabled = checker.IsCDIEnabled()
+		}
+
+		switch {
+		case runtimeConfigured:
+			s.Result = resultSucceeded
+			s.Message = fmt.Sprintf("the %v runtime is configured", b.nvidiaRuntimeName)
+		case cdiEnabled:
+			s.Result = resultSucceeded
+			s.Message = "CDI is enabled"
+		default:
+			s.Result = resultFailed
+			s.Message = fmt.Sprintf("the %v runtime is not configured and CDI is not enabled; run nvidia-ctk runtime configure --runtime=%v", b.nvidiaRuntimeName, e.Name)
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses
+}
+
+// writeStatus atomically writes the status to the specified file.
+func writeStatus(path string, s *status) error {
+	contents, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(contents, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
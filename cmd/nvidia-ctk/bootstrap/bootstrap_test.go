@@ -0,0 +1,132 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/engines"
+)
+
+func TestBootstrap(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	succeeds := func(ctx context.Context) (string, error) {
+		return "done", nil
+	}
+	fails := func(ctx context.Context) (string, error) {
+		return "", fmt.Errorf("driver not ready")
+	}
+
+	testCases := []struct {
+		description     string
+		steps           []step
+		dockerConfig    string
+		expectedError   bool
+		expectedResults map[string]string
+	}{
+		{
+			description: "all steps succeed",
+			steps: []step{
+				{name: "first", run: succeeds},
+				{name: "second", run: succeeds},
+			},
+			dockerConfig: `{"runtimes": {"nvidia": {"path": "/usr/bin/nvidia-container-runtime"}}}`,
+			expectedResults: map[string]string{
+				"first":         resultSucceeded,
+				"second":        resultSucceeded,
+				"verify-docker": resultSucceeded,
+				"verify-crio":   resultSkipped,
+			},
+		},
+		{
+			description: "steps after a failure are skipped",
+			steps: []step{
+				{name: "first", run: fails},
+				{name: "second", run: succeeds},
+			},
+			dockerConfig:  `{"features": {"cdi": true}}`,
+			expectedError: true,
+			expectedResults: map[string]string{
+				"first":         resultFailed,
+				"second":        resultSkipped,
+				"verify-docker": resultSucceeded,
+				"verify-crio":   resultSkipped,
+			},
+		},
+		{
+			description: "unconfigured engine fails",
+			steps: []step{
+				{name: "first", run: succeeds},
+			},
+			dockerConfig:  `{"runtimes": {"runc": {"path": "runc"}}}`,
+			expectedError: true,
+			expectedResults: map[string]string{
+				"first":         resultSucceeded,
+				"verify-docker": resultFailed,
+				"verify-crio":   resultSkipped,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			testDir := t.TempDir()
+			dockerConfig := filepath.Join(testDir, "daemon.json")
+			require.NoError(t, os.WriteFile(dockerConfig, []byte(tc.dockerConfig), 0600))
+			statusFile := filepath.Join(testDir, "run", "bootstrap.json")
+
+			b := &bootstrapper{
+				logger:            logger,
+				nvidiaRuntimeName: "nvidia",
+				engines: []engines.Engine{
+					{Name: "docker", ConfigFilePath: dockerConfig},
+					{Name: "crio", ConfigFilePath: filepath.Join(testDir, "crio.conf")},
+				},
+				steps: tc.steps,
+			}
+
+			err := b.run(context.Background(), statusFile)
+			if tc.expectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			contents, err := os.ReadFile(statusFile)
+			require.NoError(t, err)
+			var s status
+			require.NoError(t, json.Unmarshal(contents, &s))
+
+			require.Equal(t, !tc.expectedError, s.Success)
+			results := make(map[string]string)
+			for _, st := range s.Steps {
+				results[st.Name] = st.Result
+			}
+			require.EqualValues(t, tc.expectedResults, results)
+		})
+	}
+}
@@ -19,9 +19,6 @@ package cdi
 import (
 	"github.com/urfave/cli/v3"
 
-	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi/generate"
-	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi/list"
-	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi/transform"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 )
 
@@ -43,13 +40,9 @@ func NewCommand(logger logger.Interface, configFilePath *string) *cli.Command {
 func (m command) build() *cli.Command {
 	// Create the 'cdi' command
 	cdi := cli.Command{
-		Name:  "cdi",
-		Usage: "Provide tools for interacting with Container Device Interface specifications",
-		Commands: []*cli.Command{
-			generate.NewCommand(m.logger, m.configFilePath),
-			list.NewCommand(m.logger),
-			transform.NewCommand(m.logger),
-		},
+		Name:     "cdi",
+		Usage:    "Provide tools for interacting with Container Device Interface specifications",
+		Commands: m.getCommands(),
 	}
 
 	return &cdi
@@ -18,6 +18,7 @@ package generate
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
@@ -101,11 +102,20 @@ func (c *configValueLookup) Lookup() (string, bool) {
 	if c == nil || c.from == nil {
 		return "", false
 	}
-	if value := c.from.Get(c.key); value != nil {
+	switch value := c.from.Get(c.key).(type) {
+	case nil:
+		return "", false
+	case []interface{}:
+		// Slice values (e.g. for StringSliceFlags) are joined as a
+		// comma-separated list.
+		var values []string
+		for _, v := range value {
+			values = append(values, fmt.Sprintf("%v", v))
+		}
+		return strings.Join(values, ","), true
+	default:
 		return fmt.Sprintf("%v", value), true
 	}
-
-	return "", false
 }
 
 // GoString returns a Go-syntax representation of the configuration source.
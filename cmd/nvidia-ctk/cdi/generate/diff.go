@@ -0,0 +1,390 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+)
+
+const (
+	defaultInstalledSpecPath = "/etc/cdi/nvidia.yaml"
+	generatedSpecName        = "generated"
+
+	diffOutputText = "text"
+	diffOutputJSON = "json"
+
+	changeAdded   = "added"
+	changeRemoved = "removed"
+	changeChanged = "changed"
+)
+
+// errSpecsDiffer is returned by the diff command if the specifications differ.
+var errSpecsDiffer = errors.New("the CDI specifications differ")
+
+// diffExcludedFlags lists the generate flags that are not applicable when
+// comparing a generated spec since the spec is not written.
+var diffExcludedFlags = map[string]bool{
+	"dry-run":          true,
+	"format":           true,
+	"include-mofed":    true,
+	"merge-existing":   true,
+	"output":           true,
+	"output-dir":       true,
+	"per-device-specs": true,
+	"tenant":           true,
+	"tenant-group":     true,
+	"watch":            true,
+	"watch-interval":   true,
+}
+
+// A specDiff describes the differences between two CDI specifications.
+type specDiff struct {
+	Old     string   `json:"old"`
+	New     string   `json:"new"`
+	Changes []change `json:"changes"`
+}
+
+// A change describes a single entry that was added, removed, or changed. The
+// device is empty for changes to the spec itself or the common edits.
+type change struct {
+	Type   string `json:"type"`
+	Device string `json:"device,omitempty"`
+	Field  string `json:"field"`
+	Old    string `json:"old,omitempty"`
+	New    string `json:"new,omitempty"`
+}
+
+// NewDiffCommand constructs a cdi diff command with the specified logger
+func NewDiffCommand(logger logger.Interface, configFilePath *string) *cli.Command {
+	c := command{
+		logger: logger,
+		config: New(configFilePath),
+	}
+	return c.buildDiff()
+}
+
+// buildDiff creates the CLI command
+func (m command) buildDiff() *cli.Command {
+	opts := options{}
+	var output string
+
+	var flags []cli.Flag
+	for _, flag := range m.getFlags(&opts) {
+		if diffExcludedFlags[flag.Names()[0]] {
+			continue
+		}
+		flags = append(flags, flag)
+	}
+	flags = append(flags, &cli.StringFlag{
+		Name:        "output",
+		Aliases:     []string{"o"},
+		Usage:       "the output format of the diff; one of [text, json]",
+		Value:       diffOutputText,
+		Destination: &output,
+	})
+
+	c := cli.Command{
+		Name: "diff",
+		Usage: "Compare two CDI specifications. If only OLD_SPEC is specified, it is compared against a newly generated specification. " +
+			"If no specification is specified, " + defaultInstalledSpecPath + " is used. The generation flags match those of the generate command",
+		ArgsUsage: "[OLD_SPEC [NEW_SPEC]]",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			switch output {
+			case diffOutputText, diffOutputJSON:
+			default:
+				return ctx, fmt.Errorf("unrecognized output format: %v", output)
+			}
+			if cmd.Args().Len() > 2 {
+				return ctx, fmt.Errorf("at most two CDI specifications can be compared")
+			}
+			opts.format = spec.FormatYAML
+			return ctx, m.validateFlags(cmd, &opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.diff(os.Stdout, &opts, output, cmd.Args().Slice())
+		},
+		Flags: flags,
+	}
+
+	return &c
+}
+
+// diff prints the differences between the specified specs. If fewer than two
+// specs are specified, a spec is generated and compared against the specified
+// spec or the installed spec. An error is returned if the specs differ.
+func (m command) diff(w io.Writer, opts *options, output string, args []string) error {
+	oldPath := defaultInstalledSpecPath
+	if len(args) > 0 {
+		oldPath = args[0]
+	}
+	oldSpec, err := loadRawSpec(oldPath)
+	if err != nil {
+		return err
+	}
+
+	newPath := generatedSpecName
+	var newSpec *specs.Spec
+	if len(args) > 1 {
+		newPath = args[1]
+		newSpec, err = loadRawSpec(newPath)
+		if err != nil {
+			return err
+		}
+	} else {
+		generated, err := m.generateSpec(opts)
+		if err != nil {
+			return fmt.Errorf("failed to generate CDI spec: %v", err)
+		}
+		newSpec = generated.Raw()
+	}
+
+	d := &specDiff{
+		Old:     oldPath,
+		New:     newPath,
+		Changes: compareSpecs(oldSpec, newSpec),
+	}
+	if err := d.write(w, output); err != nil {
+		return err
+	}
+	if len(d.Changes) > 0 {
+		return errSpecsDiffer
+	}
+	return nil
+}
+
+// loadRawSpec reads the CDI specification at the specified path.
+func loadRawSpec(path string) (*specs.Spec, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CDI specification: %w", err)
+	}
+	raw, err := cdi.ParseSpec(contents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CDI specification %v: %w", path, err)
+	}
+	return raw, nil
+}
+
+// compareSpecs returns the changes from the first to the second spec. The changes to
+// the spec itself and to the common edits are returned first, followed by
+// the changes to the devices ordered by name.
+func compareSpecs(from *specs.Spec, to *specs.Spec) []change {
+	var changes []change
+	if from.Version != to.Version {
+		changes = append(changes, change{Type: changeChanged, Field: "cdiVersion", Old: from.Version, New: to.Version})
+	}
+	if from.Kind != to.Kind {
+		changes = append(changes, change{Type: changeChanged, Field: "kind", Old: from.Kind, New: to.Kind})
+	}
+	changes = append(changes, compareContainerEdits("", from.ContainerEdits, to.ContainerEdits)...)
+
+	oldDevices := make(map[string]specs.Device)
+	for _, device := range from.Devices {
+		oldDevices[device.Name] = device
+	}
+	newDevices := make(map[string]specs.Device)
+	for _, device := range to.Devices {
+		newDevices[device.Name] = device
+	}
+
+	for _, name := range sortedKeys(oldDevices, newDevices) {
+		oldDevice, inOld := oldDevices[name]
+		newDevice, inNew := newDevices[name]
+		switch {
+		case !inNew:
+			changes = append(changes, change{Type: changeRemoved, Device: name, Field: "device"})
+		case !inOld:
+			changes = append(changes, change{Type: changeAdded, Device: name, Field: "device"})
+		default:
+			changes = append(changes, compareContainerEdits(name, oldDevice.ContainerEdits, newDevice.ContainerEdits)...)
+		}
+	}
+	return changes
+}
+
+// compareContainerEdits returns the changes to the device nodes, mounts,
+// hooks, and environment variables of the specified container edits. Entries
+// are identified by their path in the container (or the variable name) so
+// that a change in the host path is reported as a change. Hooks are
+// identified by all their properties.
+func compareContainerEdits(device string, from specs.ContainerEdits, to specs.ContainerEdits) []change {
+	var changes []change
+	changes = append(changes, compareEntries(device, "deviceNode", deviceNodeEntries(from.DeviceNodes), deviceNodeEntries(to.DeviceNodes))...)
+	changes = append(changes, compareEntries(device, "mount", mountEntries(from.Mounts), mountEntries(to.Mounts))...)
+	changes = append(changes, compareEntries(device, "hook", hookEntries(from.Hooks), hookEntries(to.Hooks))...)
+	changes = append(changes, compareEntries(device, "env", envEntries(from.Env), envEntries(to.Env))...)
+	return changes
+}
+
+// compareEntries compares the entries with the same key and returns a change
+// for each entry that was added, removed, or has a different description.
+func compareEntries(device string, field string, from map[string]string, to map[string]string) []change {
+	var changes []change
+	for _, key := range sortedKeys(from, to) {
+		oldEntry, inOld := from[key]
+		newEntry, inNew := to[key]
+		switch {
+		case !inNew:
+			changes = append(changes, change{Type: changeRemoved, Device: device, Field: field, Old: oldEntry})
+		case !inOld:
+			changes = append(changes, change{Type: changeAdded, Device: device, Field: field, New: newEntry})
+		case oldEntry != newEntry:
+			changes = append(changes, change{Type: changeChanged, Device: device, Field: field, Old: oldEntry, New: newEntry})
+		}
+	}
+	return changes
+}
+
+func deviceNodeEntries(deviceNodes []*specs.DeviceNode) map[string]string {
+	entries := make(map[string]string)
+	for _, d := range deviceNodes {
+		if d == nil {
+			continue
+		}
+		entry := d.Path
+		if d.HostPath != "" && d.HostPath != d.Path {
+			entry += " from " + d.HostPath
+		}
+		if d.Permissions != "" {
+			entry += " (" + d.Permissions + ")"
+		}
+		entries[d.Path] = entry
+	}
+	return entries
+}
+
+func mountEntries(mounts []*specs.Mount) map[string]string {
+	entries := make(map[string]string)
+	for _, m := range mounts {
+		if m == nil {
+			continue
+		}
+		entry := m.ContainerPath + " from " + m.HostPath
+		if len(m.Options) > 0 {
+			entry += " (" + strings.Join(m.Options, ",") + ")"
+		}
+		entries[m.ContainerPath] = entry
+	}
+	return entries
+}
+
+func hookEntries(hooks []*specs.Hook) map[string]string {
+	entries := make(map[string]string)
+	for _, h := range hooks {
+		if h == nil {
+			continue
+		}
+		entry := h.HookName + ": " + strings.Join(append([]string{h.Path}, h.Args...), " ")
+		if len(h.Env) > 0 {
+			entry += " (env: " + strings.Join(h.Env, ",") + ")"
+		}
+		entries[entry] = entry
+	}
+	return entries
+}
+
+func envEntries(env []string) map[string]string {
+	entries := make(map[string]string)
+	for _, e := range env {
+		name, _, _ := strings.Cut(e, "=")
+		entries[name] = e
+	}
+	return entries
+}
+
+// sortedKeys returns the sorted union of the keys of the specified maps.
+func sortedKeys[T any](a map[string]T, b map[string]T) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, m := range []map[string]T{a, b} {
+		for key := range m {
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (d *specDiff) write(w io.Writer, output string) error {
+	if output == diffOutputJSON {
+		if d.Changes == nil {
+			d.Changes = []change{}
+		}
+		data, err := json.MarshalIndent(d, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to convert diff to JSON: %v", err)
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, "--- %v\n+++ %v\n", d.Old, d.New); err != nil {
+		return err
+	}
+	for _, c := range d.Changes {
+		if _, err := fmt.Fprintln(w, c.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// String returns the text representation of a change.
+func (c change) String() string {
+	scope := "common edits"
+	if c.Device != "" {
+		scope = "device " + c.Device
+	}
+	if c.Field == "cdiVersion" || c.Field == "kind" {
+		scope = "spec"
+	}
+	if c.Field == "device" {
+		switch c.Type {
+		case changeAdded:
+			return fmt.Sprintf("+ %v", scope)
+		default:
+			return fmt.Sprintf("- %v", scope)
+		}
+	}
+	switch c.Type {
+	case changeAdded:
+		return fmt.Sprintf("+ %v: %v %v", scope, c.Field, c.New)
+	case changeRemoved:
+		return fmt.Sprintf("- %v: %v %v", scope, c.Field, c.Old)
+	default:
+		return fmt.Sprintf("~ %v: %v %v -> %v", scope, c.Field, c.Old, c.New)
+	}
+}
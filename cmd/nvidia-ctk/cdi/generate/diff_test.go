@@ -0,0 +1,194 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+func TestCompareSpecs(t *testing.T) {
+	testCases := []struct {
+		description     string
+		from            *specs.Spec
+		to              *specs.Spec
+		expectedChanges []change
+	}{
+		{
+			description: "identical specs have no changes",
+			from: &specs.Spec{
+				Version: "0.5.0",
+				Kind:    "nvidia.com/gpu",
+				Devices: []specs.Device{{Name: "0"}},
+			},
+			to: &specs.Spec{
+				Version: "0.5.0",
+				Kind:    "nvidia.com/gpu",
+				Devices: []specs.Device{{Name: "0"}},
+			},
+		},
+		{
+			description: "changes are reported",
+			from: &specs.Spec{
+				Version: "0.5.0",
+				Kind:    "nvidia.com/gpu",
+				ContainerEdits: specs.ContainerEdits{
+					Env: []string{"NVIDIA_CTK_LIBCUDA_DIR=/usr/lib64"},
+					Mounts: []*specs.Mount{
+						{HostPath: "/usr/lib64/libcuda.so.550.54.14", ContainerPath: "/usr/lib64/libcuda.so.550.54.14"},
+					},
+					Hooks: []*specs.Hook{
+						{HookName: "createContainer", Path: "/usr/bin/nvidia-cdi-hook", Args: []string{"nvidia-cdi-hook", "update-ldcache"}},
+					},
+				},
+				Devices: []specs.Device{
+					{
+						Name: "0",
+						ContainerEdits: specs.ContainerEdits{
+							DeviceNodes: []*specs.DeviceNode{{Path: "/dev/nvidia0"}},
+						},
+					},
+					{Name: "1"},
+				},
+			},
+			to: &specs.Spec{
+				Version: "0.6.0",
+				Kind:    "nvidia.com/gpu",
+				ContainerEdits: specs.ContainerEdits{
+					Env: []string{"NVIDIA_CTK_LIBCUDA_DIR=/usr/lib"},
+					Mounts: []*specs.Mount{
+						{HostPath: "/usr/lib64/libcuda.so.550.54.15", ContainerPath: "/usr/lib64/libcuda.so.550.54.15"},
+					},
+					Hooks: []*specs.Hook{
+						{HookName: "createContainer", Path: "/usr/bin/nvidia-cdi-hook", Args: []string{"nvidia-cdi-hook", "update-ldcache"}},
+					},
+				},
+				Devices: []specs.Device{
+					{
+						Name: "0",
+						ContainerEdits: specs.ContainerEdits{
+							DeviceNodes: []*specs.DeviceNode{{Path: "/dev/nvidia0", HostPath: "/host/dev/nvidia0"}},
+						},
+					},
+					{Name: "2"},
+				},
+			},
+			expectedChanges: []change{
+				{Type: changeChanged, Field: "cdiVersion", Old: "0.5.0", New: "0.6.0"},
+				{Type: changeRemoved, Field: "mount", Old: "/usr/lib64/libcuda.so.550.54.14 from /usr/lib64/libcuda.so.550.54.14"},
+				{Type: changeAdded, Field: "mount", New: "/usr/lib64/libcuda.so.550.54.15 from /usr/lib64/libcuda.so.550.54.15"},
+				{Type: changeChanged, Field: "env", Old: "NVIDIA_CTK_LIBCUDA_DIR=/usr/lib64", New: "NVIDIA_CTK_LIBCUDA_DIR=/usr/lib"},
+				{Type: changeChanged, Device: "0", Field: "deviceNode", Old: "/dev/nvidia0", New: "/dev/nvidia0 from /host/dev/nvidia0"},
+				{Type: changeRemoved, Device: "1", Field: "device"},
+				{Type: changeAdded, Device: "2", Field: "device"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			changes := compareSpecs(tc.from, tc.to)
+			require.EqualValues(t, tc.expectedChanges, changes)
+		})
+	}
+}
+
+func TestDiffSpecFiles(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	specDir := t.TempDir()
+	installed := filepath.Join(specDir, "installed.yaml")
+	updated := filepath.Join(specDir, "updated.yaml")
+	require.NoError(t, os.WriteFile(installed, []byte(`cdiVersion: 0.5.0
+kind: nvidia.com/gpu
+devices:
+- name: "0"
+  containerEdits:
+    deviceNodes:
+    - path: /dev/nvidia0
+`), 0600))
+	require.NoError(t, os.WriteFile(updated, []byte(`cdiVersion: 0.5.0
+kind: nvidia.com/gpu
+devices:
+- name: "0"
+  containerEdits:
+    deviceNodes:
+    - path: /dev/nvidia0
+- name: "1"
+  containerEdits:
+    deviceNodes:
+    - path: /dev/nvidia1
+`), 0600))
+
+	c := command{logger: logger}
+
+	testCases := []struct {
+		description    string
+		args           []string
+		output         string
+		expectedError  error
+		expectedOutput string
+	}{
+		{
+			description:    "identical specs",
+			args:           []string{installed, installed},
+			output:         diffOutputText,
+			expectedOutput: "--- " + installed + "\n+++ " + installed + "\n",
+		},
+		{
+			description:    "text output",
+			args:           []string{installed, updated},
+			output:         diffOutputText,
+			expectedError:  errSpecsDiffer,
+			expectedOutput: "--- " + installed + "\n+++ " + updated + "\n+ device 1\n",
+		},
+		{
+			description:   "JSON output",
+			args:          []string{installed, updated},
+			output:        diffOutputJSON,
+			expectedError: errSpecsDiffer,
+			expectedOutput: `{
+  "old": "` + installed + `",
+  "new": "` + updated + `",
+  "changes": [
+    {
+      "type": "added",
+      "device": "1",
+      "field": "device"
+    }
+  ]
+}
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			err := c.diff(output, &options{}, tc.output, tc.args)
+			require.ErrorIs(t, err, tc.expectedError)
+			require.Equal(t, tc.expectedOutput, output.String())
+		})
+	}
+}
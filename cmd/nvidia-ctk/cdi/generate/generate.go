@@ -17,19 +17,27 @@
 package generate
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/urfave/cli/v3"
+	"golang.org/x/mod/semver"
 
+	cdiapi "tags.cncf.io/container-device-interface/pkg/cdi"
 	cdi "tags.cncf.io/container-device-interface/pkg/parser"
+	"tags.cncf.io/container-device-interface/specs-go"
 
 	"github.com/NVIDIA/go-nvml/pkg/nvml"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/changeset"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/platform-support/tegra/csv"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
@@ -49,25 +57,58 @@ type command struct {
 
 type options struct {
 	output               string
+	outputDir            string
 	format               string
+	specVersion          string
 	deviceNameStrategies []string
+	migDeviceNaming      string
+	perDeviceSpecs       bool
+	checksumAnnotations  bool
 	driverRoot           string
-	devRoot              string
-	nvidiaCDIHookPath    string
-	ldconfigPath         string
-	mode                 string
-	vendor               string
-	class                string
+	// driverRoots stores the values of the --driver-root flag. The first
+	// value is used as the driverRoot and the remaining values as
+	// additionalDriverRoots.
+	driverRoots           []string
+	additionalDriverRoots []string
+	driverVersion         string
+	devRoot               string
+	nvidiaCDIHookPath     string
+	ldconfigPath          string
+	mode                  string
+	vendor                string
+	class                 string
+	includeRDMA           bool
+	includeMOFED          bool
+	capabilityDevices     bool
+	minimalDriverFiles    bool
+	nvidiaSMILitePath     string
+	fromSnapshot          string
+	mergeExisting         bool
 
 	configSearchPaths  []string
 	librarySearchPaths []string
 	disabledHooks      []string
+	plugins            []string
+	dryRun             bool
+	devices            []string
+
+	watch         bool
+	watchInterval time.Duration
+
+	tenant tenantOptions
 
 	csv struct {
 		files          []string
 		ignorePatterns []string
 	}
 
+	deviceNodes struct {
+		user      string
+		group     string
+		mode      string
+		ownership config.DeviceNodeOwnership
+	}
+
 	// the following are used for dependency injection during spec generation.
 	nvmllib nvml.Interface
 }
@@ -95,133 +136,336 @@ func (m command) build() *cli.Command {
 			return ctx, m.validateFlags(cmd, &opts)
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if opts.watch {
+				return m.watch(ctx, &opts, watchDebounce)
+			}
 			return m.run(&opts)
 		},
-		Flags: []cli.Flag{
-			&cli.StringSliceFlag{
-				Name:        "config-search-path",
-				Usage:       "Specify the path to search for config files when discovering the entities that should be included in the CDI specification.",
-				Destination: &opts.configSearchPaths,
-				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_CONFIG_SEARCH_PATHS"),
-			},
-			&cli.StringFlag{
-				Name:        "output",
-				Usage:       "Specify the file to output the generated CDI specification to. If this is '' the specification is output to STDOUT",
-				Destination: &opts.output,
-				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_OUTPUT_FILE_PATH"),
-			},
-			&cli.StringFlag{
-				Name:        "format",
-				Usage:       "The output format for the generated spec [json | yaml]. This overrides the format defined by the output file extension (if specified).",
-				Value:       spec.FormatYAML,
-				Destination: &opts.format,
-				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_OUTPUT_FORMAT"),
-			},
-			&cli.StringFlag{
-				Name:    "mode",
-				Aliases: []string{"discovery-mode"},
-				Usage: "The mode to use when discovering the available entities. " +
-					"One of [" + strings.Join(nvcdi.AllModes[string](), " | ") + "]. " +
-					"If mode is set to 'auto' the mode will be determined based on the system configuration.",
-				Value:       string(nvcdi.ModeAuto),
-				Destination: &opts.mode,
-				Sources: cli.NewValueSourceChain(
-					cli.EnvVar("NVIDIA_CTK_CDI_GENERATE_MODE"),
-					m.config.ValueFrom("nvidia-container-runtime.mode"),
-				),
-			},
-			&cli.StringFlag{
-				Name:        "dev-root",
-				Usage:       "Specify the root where `/dev` is located. If this is not specified, the driver-root is assumed.",
-				Destination: &opts.devRoot,
-				Sources:     cli.EnvVars("NVIDIA_CTK_DEV_ROOT"),
-			},
-			&cli.StringSliceFlag{
-				Name:        "device-name-strategy",
-				Usage:       "Specify the strategy for generating device names. If this is specified multiple times, the devices will be duplicated for each strategy. One of [index | uuid | type-index]",
-				Value:       []string{nvcdi.DeviceNameStrategyIndex, nvcdi.DeviceNameStrategyUUID},
-				Destination: &opts.deviceNameStrategies,
-				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_DEVICE_NAME_STRATEGIES"),
-			},
-			&cli.StringFlag{
-				Name:        "driver-root",
-				Usage:       "Specify the NVIDIA GPU driver root to use when discovering the entities that should be included in the CDI specification.",
-				Destination: &opts.driverRoot,
-				Sources: cli.NewValueSourceChain(
-					cli.EnvVar("NVIDIA_CTK_DRIVER_ROOT"),
-					m.config.ValueFrom("nvidia-container-cli.root"),
-				),
-			},
-			&cli.StringSliceFlag{
-				Name:        "library-search-path",
-				Usage:       "Specify the path to search for libraries when discovering the entities that should be included in the CDI specification.\n\tNote: This option only applies to CSV mode.",
-				Destination: &opts.librarySearchPaths,
-				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_LIBRARY_SEARCH_PATHS"),
-			},
-			&cli.StringFlag{
-				Name:    "nvidia-cdi-hook-path",
-				Aliases: []string{"nvidia-ctk-path"},
-				Usage: "Specify the path to use for the nvidia-cdi-hook in the generated CDI specification. " +
-					"If not specified, the PATH will be searched for `nvidia-cdi-hook`. " +
-					"NOTE: That if this is specified as `nvidia-ctk`, the PATH will be searched for `nvidia-ctk` instead.",
-				Destination: &opts.nvidiaCDIHookPath,
-				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_HOOK_PATH"),
-			},
-			&cli.StringFlag{
-				Name:        "ldconfig-path",
-				Usage:       "Specify the path to use for ldconfig in the generated CDI specification",
-				Destination: &opts.ldconfigPath,
-				Sources: cli.NewValueSourceChain(
-					cli.EnvVar("NVIDIA_CTK_CDI_GENERATE_LDCONFIG_PATH"),
-					m.config.ValueFrom("nvidia-container-cli.ldconfig"),
-				),
-			},
-			&cli.StringFlag{
-				Name:        "vendor",
-				Aliases:     []string{"cdi-vendor"},
-				Usage:       "the vendor string to use for the generated CDI specification.",
-				Value:       "nvidia.com",
-				Destination: &opts.vendor,
-				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_VENDOR"),
-			},
-			&cli.StringFlag{
-				Name:        "class",
-				Aliases:     []string{"cdi-class"},
-				Usage:       "the class string to use for the generated CDI specification.",
-				Value:       "gpu",
-				Destination: &opts.class,
-				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_CLASS"),
-			},
-			&cli.StringSliceFlag{
-				Name:        "csv.file",
-				Usage:       "The path to the list of CSV files to use when generating the CDI specification in CSV mode.",
-				Value:       csv.DefaultFileList(),
-				Destination: &opts.csv.files,
-				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_CSV_FILES"),
-			},
-			&cli.StringSliceFlag{
-				Name:        "csv.ignore-pattern",
-				Usage:       "specify a pattern the CSV mount specifications.",
-				Destination: &opts.csv.ignorePatterns,
-				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_CSV_IGNORE_PATTERNS"),
-			},
-			&cli.StringSliceFlag{
-				Name:    "disable-hook",
-				Aliases: []string{"disable-hooks"},
-				Usage: "specify a specific hook to skip when generating CDI " +
-					"specifications. This can be specified multiple times and the " +
-					"special hook name 'all' can be used ensure that the generated " +
-					"CDI specification does not include any hooks.",
-				Destination: &opts.disabledHooks,
-				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_DISABLED_HOOKS"),
-			},
-		},
+		Flags: m.getFlags(&opts),
 	}
 
 	return &c
 }
 
+// getFlags returns the flags for the generation of a CDI specification.
+func (m command) getFlags(opts *options) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:        "config-search-path",
+			Usage:       "Specify the path to search for config files when discovering the entities that should be included in the CDI specification.",
+			Destination: &opts.configSearchPaths,
+			Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_CONFIG_SEARCH_PATHS"),
+		},
+		&cli.StringFlag{
+			Name:        "output",
+			Usage:       "Specify the file to output the generated CDI specification to. If this is '' the specification is output to STDOUT",
+			Destination: &opts.output,
+			Sources:     cli.EnvVars("NVIDIA_CTK_CDI_OUTPUT_FILE_PATH"),
+		},
+		&cli.StringFlag{
+			Name: "output-dir",
+			Usage: "Specify a CDI spec directory that is shared with other vendors to output the generated CDI specification to. " +
+				"The specification is named by vendor and class (e.g. nvidia.com-gpu.yaml) and an existing specification for a different vendor or class is never overwritten. " +
+				"This is ignored if --output is specified.",
+			Destination: &opts.outputDir,
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("NVIDIA_CTK_CDI_OUTPUT_DIR"),
+				m.config.ValueFrom("nvidia-ctk.cdi-output-dir"),
+			),
+		},
+		&cli.BoolFlag{
+			Name: "per-device-specs",
+			Usage: "write a separate CDI specification for each GPU and MIG device to the --output-dir. The specifications are named by device UUID (e.g. nvidia.com-gpu_GPU-<uuid>.yaml) " +
+				"and are only rewritten if their contents change. The 'all' device and devices that are not associated with a single GPU are written to the specification named by vendor and class.",
+			Destination: &opts.perDeviceSpecs,
+			Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_PER_DEVICE_SPECS"),
+		},
+		&cli.StringFlag{
+			Name:        "format",
+			Usage:       "The output format for the generated spec [json | yaml]. This overrides the format defined by the output file extension (if specified).",
+			Value:       spec.FormatYAML,
+			Destination: &opts.format,
+			Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_OUTPUT_FORMAT"),
+		},
+		&cli.StringFlag{
+			Name: "spec-version",
+			Usage: "The CDI specification version of the generated spec (e.g. 0.5.0). " +
+				"Features that are not supported by this version are removed with a warning. " +
+				"If not specified, the minimum version that supports the generated spec is used.",
+			Destination: &opts.specVersion,
+			Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_SPEC_VERSION"),
+		},
+		&cli.BoolFlag{
+			Name: "checksum-annotations",
+			Usage: "Add annotations containing a checksum of the contents of the generated spec and the version of the NVIDIA Container Toolkit. " +
+				"The checksum only changes if the devices or container edits in the spec change. This requires CDI spec version 0.6.0 or later.",
+			Destination: &opts.checksumAnnotations,
+			Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_CHECKSUM_ANNOTATIONS"),
+		},
+		&cli.StringFlag{
+			Name:    "mode",
+			Aliases: []string{"discovery-mode"},
+			Usage: "The mode to use when discovering the available entities. " +
+				"One of [" + strings.Join(nvcdi.AllModes[string](), " | ") + "]. " +
+				"If mode is set to 'auto' the mode will be determined based on the system configuration.",
+			Value:       string(nvcdi.ModeAuto),
+			Destination: &opts.mode,
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("NVIDIA_CTK_CDI_GENERATE_MODE"),
+				m.config.ValueFrom("nvidia-container-runtime.mode"),
+			),
+		},
+		&cli.StringFlag{
+			Name:        "dev-root",
+			Usage:       "Specify the root where `/dev` is located. If this is not specified, the driver-root is assumed.",
+			Destination: &opts.devRoot,
+			Sources:     cli.EnvVars("NVIDIA_CTK_DEV_ROOT"),
+		},
+		&cli.StringSliceFlag{
+			Name:        "device-name-strategy",
+			Usage:       "Specify the strategy for generating device names. If this is specified multiple times, the devices will be duplicated for each strategy. One of [index | uuid | type-index]",
+			Value:       []string{nvcdi.DeviceNameStrategyIndex, nvcdi.DeviceNameStrategyUUID},
+			Destination: &opts.deviceNameStrategies,
+			Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_DEVICE_NAME_STRATEGIES"),
+		},
+		&cli.StringFlag{
+			Name: "mig-naming",
+			Usage: "Specify the strategy for generating the names of MIG devices. If this is set, the device-name-strategy is only applied to full GPUs. " +
+				"With the profile strategy devices are named by their MIG profile (e.g. 1g.10gb-0). One of [index | uuid | profile]",
+			Destination: &opts.migDeviceNaming,
+			Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_MIG_NAMING"),
+		},
+		&cli.StringSliceFlag{
+			Name: "driver-root",
+			Usage: "Specify the NVIDIA GPU driver root to use when discovering the entities that should be included in the CDI specification. " +
+				"This can be specified multiple times, in which case driver libraries and binaries that are not found in the first driver root are included from " +
+				"the other driver roots in the order specified. All other entities are discovered in the first driver root.",
+			Destination: &opts.driverRoots,
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("NVIDIA_CTK_DRIVER_ROOT"),
+				m.config.ValueFrom("nvidia-container-cli.root"),
+			),
+		},
+		&cli.StringFlag{
+			Name: "driver-version",
+			Usage: "Specify the driver version to generate the CDI specification for. " +
+				"This allows a specification to be generated for each driver version on systems where multiple versions are installed in versioned directories (e.g. /usr/lib/nvidia-550). " +
+				"If the class is not explicitly set, the driver version is appended to the default class.",
+			Destination: &opts.driverVersion,
+			Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_DRIVER_VERSION"),
+		},
+		&cli.StringSliceFlag{
+			Name:        "library-search-path",
+			Usage:       "Specify the path to search for libraries when discovering the entities that should be included in the CDI specification.",
+			Destination: &opts.librarySearchPaths,
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("NVIDIA_CTK_CDI_GENERATE_LIBRARY_SEARCH_PATHS"),
+				m.config.ValueFrom("nvidia-container-cli.library-search-paths"),
+			),
+		},
+		&cli.BoolFlag{
+			Name: "include-rdma",
+			Usage: "Include the devices required for GPUDirect RDMA in the generated CDI specification. " +
+				"This adds the RDMA connection manager to the common edits and the InfiniBand uverbs devices co-located with each full GPU to the edits for that GPU. " +
+				"Note that the nvidia-peermem kernel module must be loaded on the host.",
+			Destination: &opts.includeRDMA,
+			Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_INCLUDE_RDMA"),
+		},
+		&cli.BoolFlag{
+			Name: "include-mofed",
+			Usage: "Write a separate CDI specification for the InfiniBand devices and the user-space RDMA libraries (e.g. from a MOFED install) to the --output-dir. " +
+				"The specification defines a single device (e.g. nvidia.com/mofed=all) that can be requested in addition to the GPUs.",
+			Destination: &opts.includeMOFED,
+			Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_INCLUDE_MOFED"),
+		},
+		&cli.BoolFlag{
+			Name: "capability-devices",
+			Usage: "Generate a separate CDI device for the driver files associated with each of the compute, utility, graphics, and video driver capabilities. " +
+				"These files are then not included in the common edits and the required capabilities must be requested explicitly (e.g. nvidia.com/gpu=0 and nvidia.com/gpu=compute). " +
+				"This is only supported in nvml mode.",
+			Destination: &opts.capabilityDevices,
+			Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_CAPABILITY_DEVICES"),
+		},
+		&cli.BoolFlag{
+			Name: "minimal-driver-files",
+			Usage: "Only include the CUDA driver library (libcuda.so) and NVML (libnvidia-ml.so) from the driver files. " +
+				"The driver binaries such as nvidia-smi and the graphics libraries are not included. " +
+				"This is intended for minimal (e.g. distroless) images and is only supported in nvml mode.",
+			Destination: &opts.minimalDriverFiles,
+			Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_MINIMAL_DRIVER_FILES"),
+		},
+		&cli.StringFlag{
+			Name: "nvidia-smi-lite-path",
+			Usage: "Specify the path of the nvidia-smi-lite executable to include in the generated CDI specification. " +
+				"This is mounted at /usr/bin/nvidia-smi-lite in the container and can be used for debugging if the driver binaries are not included. " +
+				"If not specified, nvidia-smi-lite is not included.",
+			Destination: &opts.nvidiaSMILitePath,
+			Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_NVIDIA_SMI_LITE_PATH"),
+		},
+		&cli.StringFlag{
+			Name: "from-snapshot",
+			Usage: "Generate the CDI specification from a snapshot created using 'nvidia-ctk system snapshot' instead of querying NVML. " +
+				"This allows a specification to be generated without access to the GPUs. " +
+				"Note that the device nodes and driver files are still located in the specified --driver-root and --dev-root. " +
+				"This is only supported in nvml mode.",
+			Destination: &opts.fromSnapshot,
+			Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_FROM_SNAPSHOT"),
+		},
+		&cli.BoolFlag{
+			Name: "merge-existing",
+			Usage: "Preserve the user-defined devices and edits of the existing CDI specification at the output path when it is regenerated. " +
+				"Devices annotated with " + transform.UserDefinedAnnotation + "=true are kept as is and the container edits in the " + transform.UserEditsAnnotation + " annotation " +
+				"of the spec or of a generated device are added to the common edits or to the edits of the device, respectively.",
+			Destination: &opts.mergeExisting,
+			Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_MERGE_EXISTING"),
+		},
+		&cli.StringFlag{
+			Name:    "nvidia-cdi-hook-path",
+			Aliases: []string{"nvidia-ctk-path"},
+			Usage: "Specify the path to use for the nvidia-cdi-hook in the generated CDI specification. " +
+				"If not specified, the PATH will be searched for `nvidia-cdi-hook`. " +
+				"NOTE: That if this is specified as `nvidia-ctk`, the PATH will be searched for `nvidia-ctk` instead.",
+			Destination: &opts.nvidiaCDIHookPath,
+			Sources:     cli.EnvVars("NVIDIA_CTK_CDI_HOOK_PATH"),
+		},
+		&cli.StringFlag{
+			Name:        "ldconfig-path",
+			Usage:       "Specify the path to use for ldconfig in the generated CDI specification",
+			Destination: &opts.ldconfigPath,
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("NVIDIA_CTK_CDI_GENERATE_LDCONFIG_PATH"),
+				m.config.ValueFrom("nvidia-container-cli.ldconfig"),
+			),
+		},
+		&cli.StringFlag{
+			Name:        "vendor",
+			Aliases:     []string{"cdi-vendor"},
+			Usage:       "the vendor string to use for the generated CDI specification.",
+			Value:       "nvidia.com",
+			Destination: &opts.vendor,
+			Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_VENDOR"),
+		},
+		&cli.StringFlag{
+			Name:        "class",
+			Aliases:     []string{"cdi-class"},
+			Usage:       "the class string to use for the generated CDI specification.",
+			Value:       "gpu",
+			Destination: &opts.class,
+			Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_CLASS"),
+		},
+		&cli.StringSliceFlag{
+			Name:        "csv.file",
+			Usage:       "The path to the list of CSV files to use when generating the CDI specification in CSV mode.",
+			Value:       csv.DefaultFileList(),
+			Destination: &opts.csv.files,
+			Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_CSV_FILES"),
+		},
+		&cli.StringSliceFlag{
+			Name:        "csv.ignore-pattern",
+			Usage:       "specify a pattern the CSV mount specifications.",
+			Destination: &opts.csv.ignorePatterns,
+			Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_CSV_IGNORE_PATTERNS"),
+		},
+		&cli.StringSliceFlag{
+			Name:    "disable-hook",
+			Aliases: []string{"disable-hooks"},
+			Usage: "specify a specific hook to skip when generating CDI " +
+				"specifications. This can be specified multiple times and the " +
+				"special hook name 'all' can be used ensure that the generated " +
+				"CDI specification does not include any hooks. " +
+				"One of [" + strings.Join(specHookNames(), " | ") + "].",
+			Destination: &opts.disabledHooks,
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("NVIDIA_CTK_CDI_GENERATE_DISABLED_HOOKS"),
+				m.config.ValueFrom("nvidia-ctk.cdi-disabled-hooks"),
+			),
+		},
+		&cli.BoolFlag{
+			Name: "dry-run",
+			Usage: "generate the CDI specification but don't write it to the output file. " +
+				"Instead, the change to the output file is written to STDOUT as a JSON change set.",
+			Destination: &opts.dryRun,
+		},
+		&cli.BoolFlag{
+			Name: "watch",
+			Usage: "keep running and regenerate the CDI specification when GPUs are added or removed, MIG devices are reconfigured, or the driver is reloaded. " +
+				"The specification is only rewritten if its contents change. This requires --output or --output-dir.",
+			Destination: &opts.watch,
+			Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_WATCH"),
+		},
+		&cli.DurationFlag{
+			Name:        "watch-interval",
+			Usage:       "the interval at which the CDI specification is regenerated when --watch is specified, regardless of whether a change was detected.",
+			Value:       5 * time.Minute,
+			Destination: &opts.watchInterval,
+			Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_WATCH_INTERVAL"),
+		},
+		&cli.StringSliceFlag{
+			Name: "plugin",
+			Usage: "specify the path to a plugin that is invoked to add container edits to the common edits " +
+				"of the generated CDI specification. This can be specified multiple times.",
+			Destination: &opts.plugins,
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("NVIDIA_CTK_CDI_GENERATE_PLUGINS"),
+				m.config.ValueFrom("nvidia-container-runtime.plugins"),
+			),
+		},
+		&cli.StringSliceFlag{
+			Name:        "device",
+			Usage:       "the devices (indices, UUIDs, or PCI bus IDs) to include in the generated CDI specification. This can be used with --tenant to restrict the devices that are visible to a tenant.",
+			Value:       []string{"all"},
+			Destination: &opts.devices,
+			Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_DEVICES"),
+		},
+		&cli.StringFlag{
+			Name: "tenant",
+			Usage: "generate the CDI specification for the specified tenant. The specification is written to the tenants/`TENANT` subdirectory of --output-dir " +
+				"and is only readable by the --tenant-group",
+			Destination: &opts.tenant.name,
+			Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_TENANT"),
+		},
+		&cli.StringFlag{
+			Name:        "tenant-group",
+			Usage:       "the group (name or GID) that is allowed to read the CDI specification of the tenant. If this is not specified, the specification is only readable by root.",
+			Destination: &opts.tenant.group,
+			Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_TENANT_GROUP"),
+		},
+		&cli.StringFlag{
+			Name:        "device-node-user",
+			Usage:       "the user (name or UID) to set as the owner of device nodes in the generated CDI specification. If not set, the owner of the host device node is used.",
+			Destination: &opts.deviceNodes.user,
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("NVIDIA_CTK_CDI_GENERATE_DEVICE_NODE_USER"),
+				m.config.ValueFrom("device-nodes.user"),
+			),
+		},
+		&cli.StringFlag{
+			Name:        "device-node-group",
+			Usage:       "the group (name or GID) to set for device nodes in the generated CDI specification. If not set, the group of the host device node is used.",
+			Destination: &opts.deviceNodes.group,
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("NVIDIA_CTK_CDI_GENERATE_DEVICE_NODE_GROUP"),
+				m.config.ValueFrom("device-nodes.group"),
+			),
+		},
+		&cli.StringFlag{
+			Name:        "device-node-mode",
+			Usage:       "the octal file mode (e.g. 0660) to set for device nodes in the generated CDI specification. If not set, the mode of the host device node is used.",
+			Destination: &opts.deviceNodes.mode,
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("NVIDIA_CTK_CDI_GENERATE_DEVICE_NODE_MODE"),
+				m.config.ValueFrom("device-nodes.mode"),
+			),
+		},
+	}
+}
+
 func (m command) validateFlags(c *cli.Command, opts *options) error {
+	if len(opts.driverRoots) > 0 {
+		opts.driverRoot = opts.driverRoots[0]
+		opts.additionalDriverRoots = opts.driverRoots[1:]
+	}
+
 	opts.format = strings.ToLower(opts.format)
 	switch opts.format {
 	case spec.FormatJSON:
@@ -230,11 +474,34 @@ func (m command) validateFlags(c *cli.Command, opts *options) error {
 		return fmt.Errorf("invalid output format: %v", opts.format)
 	}
 
+	if opts.specVersion != "" {
+		specVersion, err := transform.NormalizeVersion(opts.specVersion)
+		if err != nil {
+			return err
+		}
+		opts.specVersion = specVersion
+		if opts.checksumAnnotations && semver.Compare("v"+specVersion, "v0.6.0") < 0 {
+			return fmt.Errorf("--checksum-annotations requires CDI spec version 0.6.0 or later")
+		}
+	}
+
+	for _, hook := range opts.disabledHooks {
+		if !nvcdi.IsSpecHook(hook) {
+			return fmt.Errorf("unrecognized hook %q; supported hooks are [%v]", hook, strings.Join(specHookNames(), " | "))
+		}
+	}
+
 	opts.mode = strings.ToLower(opts.mode)
 	if !nvcdi.IsValidMode(opts.mode) {
 		return fmt.Errorf("invalid discovery mode: %v", opts.mode)
 	}
 
+	if opts.fromSnapshot != "" {
+		if err := m.loadSnapshot(opts); err != nil {
+			return err
+		}
+	}
+
 	for _, strategy := range opts.deviceNameStrategies {
 		_, err := nvcdi.NewDeviceNamer(strategy)
 		if err != nil {
@@ -242,6 +509,12 @@ func (m command) validateFlags(c *cli.Command, opts *options) error {
 		}
 	}
 
+	if opts.migDeviceNaming != "" {
+		if _, err := nvcdi.NewMigDeviceNamer(opts.migDeviceNaming); err != nil {
+			return err
+		}
+	}
+
 	opts.nvidiaCDIHookPath = config.ResolveNVIDIACDIHookPath(m.logger, opts.nvidiaCDIHookPath)
 
 	if outputFileFormat := formatFromFilename(opts.output); outputFileFormat != "" {
@@ -253,22 +526,123 @@ func (m command) validateFlags(c *cli.Command, opts *options) error {
 		}
 	}
 
+	ownership, err := config.ResolveDeviceNodeOwnership(opts.deviceNodes.user, opts.deviceNodes.group, opts.deviceNodes.mode)
+	if err != nil {
+		return err
+	}
+	opts.deviceNodes.ownership = ownership
+
+	if nvcdi.Mode(opts.mode) == nvcdi.ModeVfio && !c.IsSet("class") {
+		opts.class = "vfio"
+	}
+
+	if opts.driverVersion != "" && !c.IsSet("class") {
+		opts.class = opts.class + "-" + opts.driverVersion
+		m.logger.Infof("Using CDI class %q for driver version %v", opts.class, opts.driverVersion)
+	}
+
 	if err := cdi.ValidateVendorName(opts.vendor); err != nil {
 		return fmt.Errorf("invalid CDI vendor name: %v", err)
 	}
 	if err := cdi.ValidateClassName(opts.class); err != nil {
 		return fmt.Errorf("invalid CDI class name: %v", err)
 	}
+
+	if opts.tenant.group != "" && opts.tenant.name == "" {
+		return fmt.Errorf("--tenant-group requires --tenant")
+	}
+	if opts.tenant.name != "" {
+		if err := validateTenantName(opts.tenant.name); err != nil {
+			return err
+		}
+		if opts.outputDir == "" || opts.output != "" {
+			return fmt.Errorf("--tenant requires --output-dir to be specified instead of --output")
+		}
+		opts.outputDir = getTenantDir(opts.outputDir, opts.tenant.name)
+	}
+	if opts.tenant.group != "" {
+		gid, err := config.ResolveGroupID(opts.tenant.group)
+		if err != nil {
+			return fmt.Errorf("invalid tenant group %q: %w", opts.tenant.group, err)
+		}
+		opts.tenant.gid = &gid
+	}
+
+	if opts.perDeviceSpecs {
+		if opts.outputDir == "" || opts.output != "" {
+			return fmt.Errorf("--per-device-specs requires --output-dir to be specified instead of --output")
+		}
+		if opts.dryRun {
+			return fmt.Errorf("--per-device-specs cannot be used with --dry-run")
+		}
+	}
+
+	if opts.includeMOFED {
+		if opts.outputDir == "" || opts.output != "" {
+			return fmt.Errorf("--include-mofed requires --output-dir to be specified instead of --output")
+		}
+		if opts.dryRun {
+			return fmt.Errorf("--include-mofed cannot be used with --dry-run")
+		}
+		if nvcdi.Mode(opts.mode) == nvcdi.ModeMofed || opts.class == mofedClass {
+			return fmt.Errorf("--include-mofed cannot be used to generate a spec with the %q class", mofedClass)
+		}
+	}
+
+	if opts.outputDir != "" {
+		if opts.output != "" {
+			m.logger.Warningf("Ignoring output directory %v since an output file is specified", opts.outputDir)
+			opts.outputDir = ""
+		} else {
+			opts.output = filepath.Join(opts.outputDir, opts.vendor+"-"+opts.class+"."+opts.format)
+		}
+	}
+
+	if opts.mergeExisting {
+		if opts.output == "" {
+			return fmt.Errorf("--merge-existing requires --output or --output-dir to be specified")
+		}
+		if opts.perDeviceSpecs {
+			return fmt.Errorf("--merge-existing cannot be used with --per-device-specs")
+		}
+	}
+
+	if opts.watch {
+		if opts.output == "" {
+			return fmt.Errorf("--watch requires --output or --output-dir to be specified")
+		}
+		if opts.dryRun {
+			return fmt.Errorf("--watch cannot be used with --dry-run")
+		}
+		if opts.watchInterval <= 0 {
+			return fmt.Errorf("invalid watch interval: %v", opts.watchInterval)
+		}
+	}
 	return nil
 }
 
 func (m command) run(opts *options) error {
+	if opts.includeMOFED {
+		if _, err := m.writeMOFEDSpec(opts); err != nil {
+			return err
+		}
+	}
+
+	if opts.perDeviceSpecs {
+		_, err := m.writePerDeviceSpecs(opts)
+		return err
+	}
+
 	spec, err := m.generateSpec(opts)
 	if err != nil {
 		return fmt.Errorf("failed to generate CDI spec: %v", err)
 	}
 	m.logger.Infof("Generated CDI spec with version %v", spec.Raw().Version)
 
+	if opts.dryRun {
+		return writeChangeSet(os.Stdout, spec, opts)
+	}
+
 	if opts.output == "" {
 		_, err := spec.WriteTo(os.Stdout)
 		if err != nil {
@@ -277,7 +651,57 @@ func (m command) run(opts *options) error {
 		return nil
 	}
 
-	return spec.Save(opts.output)
+	return writeSpec(spec, opts)
+}
+
+// writeSpec saves the specified spec to the configured output file.
+func writeSpec(spec spec.Interface, opts *options) error {
+	if opts.tenant.name != "" {
+		if err := opts.tenant.createTenantDir(opts.outputDir); err != nil {
+			return err
+		}
+	}
+	if opts.outputDir != "" {
+		if err := checkExistingSpecKind(opts.output, spec.Raw().Kind); err != nil {
+			return err
+		}
+	}
+
+	if err := spec.Save(opts.output); err != nil {
+		return err
+	}
+	return opts.tenant.setGroup(opts.output)
+}
+
+// writeChangeSet writes the change to the output file that would be made by
+// saving the specified spec as a JSON change set.
+func writeChangeSet(w io.Writer, s spec.Interface, opts *options) error {
+	c := changeset.New(true)
+	if opts.output != "" {
+		path := getOutputPath(opts)
+		var updated bytes.Buffer
+		if _, err := s.WriteTo(&updated); err != nil {
+			return fmt.Errorf("failed to generate CDI spec contents: %w", err)
+		}
+		original, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read existing CDI spec: %w", err)
+		}
+		c.AddFile(path, original, updated.Bytes())
+	}
+	_, err := c.WriteTo(w)
+	return err
+}
+
+// getOutputPath returns the path of the file that the spec is saved to. If the
+// output file does not have an extension, the extension for the output format
+// is added when saving the spec.
+func getOutputPath(opts *options) string {
+	path := opts.output
+	if formatFromFilename(path) == "" {
+		path += "." + opts.format
+	}
+	return path
 }
 
 func formatFromFilename(filename string) string {
@@ -293,6 +717,36 @@ func formatFromFilename(filename string) string {
 }
 
 func (m command) generateSpec(opts *options) (spec.Interface, error) {
+	cdilib, err := m.newCDILib(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	deviceSpecs, err := cdilib.GetDeviceSpecsByID(getDeviceIDs(opts)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create device CDI specs: %v", err)
+	}
+
+	commonEdits, err := cdilib.GetCommonEdits()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create edits common for entities: %v", err)
+	}
+
+	return m.newSpec(opts, cdilib, deviceSpecs, commonEdits, true)
+}
+
+// getDeviceIDs returns the IDs of the devices to include in the generated
+// spec.
+func getDeviceIDs(opts *options) []string {
+	if len(opts.devices) == 0 {
+		return []string{"all"}
+	}
+	return opts.devices
+}
+
+// newCDILib creates the CDI library used to generate specs for the specified
+// options.
+func (m command) newCDILib(opts *options) (nvcdi.Interface, error) {
 	var deviceNamers []nvcdi.DeviceNamer
 	for _, strategy := range opts.deviceNameStrategies {
 		deviceNamer, err := nvcdi.NewDeviceNamer(strategy)
@@ -302,18 +756,40 @@ func (m command) generateSpec(opts *options) (spec.Interface, error) {
 		deviceNamers = append(deviceNamers, deviceNamer)
 	}
 
+	var migDeviceNamer nvcdi.DeviceNamer
+	if opts.migDeviceNaming != "" {
+		namer, err := nvcdi.NewMigDeviceNamer(opts.migDeviceNaming)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create MIG device namer: %v", err)
+		}
+		migDeviceNamer = namer
+	}
+
 	cdiOptions := []nvcdi.Option{
 		nvcdi.WithLogger(m.logger),
 		nvcdi.WithDriverRoot(opts.driverRoot),
+		nvcdi.WithAdditionalDriverRoots(opts.additionalDriverRoots...),
+		nvcdi.WithDriverVersion(opts.driverVersion),
 		nvcdi.WithDevRoot(opts.devRoot),
 		nvcdi.WithNVIDIACDIHookPath(opts.nvidiaCDIHookPath),
 		nvcdi.WithLdconfigPath(opts.ldconfigPath),
 		nvcdi.WithDeviceNamers(deviceNamers...),
+		nvcdi.WithMigDeviceNamer(migDeviceNamer),
 		nvcdi.WithMode(opts.mode),
 		nvcdi.WithConfigSearchPaths(opts.configSearchPaths),
 		nvcdi.WithLibrarySearchPaths(opts.librarySearchPaths),
 		nvcdi.WithCSVFiles(opts.csv.files),
 		nvcdi.WithCSVIgnorePatterns(opts.csv.ignorePatterns),
+		nvcdi.WithGPUDirectRDMA(opts.includeRDMA),
+		nvcdi.WithCapabilityDevices(opts.capabilityDevices),
+		nvcdi.WithMinimalDriverFiles(opts.minimalDriverFiles),
+		nvcdi.WithNvidiaSMILitePath(opts.nvidiaSMILitePath),
+		nvcdi.WithPlugins(opts.plugins...),
+		nvcdi.WithDeviceNodeOwnership(
+			opts.deviceNodes.ownership.UID,
+			opts.deviceNodes.ownership.GID,
+			opts.deviceNodes.ownership.FileMode,
+		),
 		// We set the following to allow for dependency injection:
 		nvcdi.WithNvmlLib(opts.nvmllib),
 	}
@@ -321,32 +797,95 @@ func (m command) generateSpec(opts *options) (spec.Interface, error) {
 	for _, hook := range opts.disabledHooks {
 		cdiOptions = append(cdiOptions, nvcdi.WithDisabledHook(hook))
 	}
+	if opts.fromSnapshot != "" {
+		// The driver version is queried from NVML so that the version in the
+		// snapshot is used.
+		cdiOptions = append(cdiOptions, nvcdi.WithFeatureFlag(nvcdi.FeatureDisableNvsandboxUtils))
+	}
 
 	cdilib, err := nvcdi.New(cdiOptions...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create CDI library: %v", err)
 	}
+	return cdilib, nil
+}
 
-	deviceSpecs, err := cdilib.GetDeviceSpecsByID("all")
-	if err != nil {
-		return nil, fmt.Errorf("failed to create device CDI specs: %v", err)
-	}
-
-	commonEdits, err := cdilib.GetCommonEdits()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create edits common for entities: %v", err)
-	}
-
-	return spec.New(
+// newSpec creates a spec for the specified devices and common edits. If
+// includeAllDevice is set, an additional device that includes all devices in
+// the spec is added.
+func (m command) newSpec(opts *options, cdilib nvcdi.Interface, deviceSpecs []specs.Device, commonEdits *cdiapi.ContainerEdits, includeAllDevice bool) (spec.Interface, error) {
+	specOptions := []spec.Option{
+		spec.WithVersion(opts.specVersion),
 		spec.WithVendor(opts.vendor),
 		spec.WithClass(opts.class),
+		spec.WithAnnotations(getSpecAnnotations(cdilib)),
 		spec.WithDeviceSpecs(deviceSpecs),
 		spec.WithEdits(*commonEdits.ContainerEdits),
 		spec.WithFormat(opts.format),
-		spec.WithMergedDeviceOptions(
-			transform.WithName(allDeviceName),
-			transform.WithSkipIfExists(true),
-		),
-		spec.WithPermissions(0644),
-	)
+		spec.WithPermissions(opts.tenant.specPermissions()),
+	}
+	if includeAllDevice {
+		specOptions = append(specOptions,
+			spec.WithMergedDeviceOptions(
+				transform.WithName(allDeviceName),
+				transform.WithSkipIfExists(true),
+			),
+		)
+	}
+	s, err := spec.New(specOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.mergeExisting {
+		if err := m.mergeExistingSpec(opts, s); err != nil {
+			return nil, err
+		}
+	}
+	if opts.specVersion != "" {
+		versionTransformer, err := transform.NewVersionTransformer(m.logger, opts.specVersion)
+		if err != nil {
+			return nil, err
+		}
+		if err := versionTransformer.Transform(s.Raw()); err != nil {
+			return nil, fmt.Errorf("failed to generate CDI spec version %v: %w", opts.specVersion, err)
+		}
+	}
+	if err := annotateChecksum(opts, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// getSpecAnnotations returns the spec annotations of the specified CDI library
+// if it provides these.
+func getSpecAnnotations(cdilib nvcdi.Interface) map[string]string {
+	annotator, ok := cdilib.(nvcdi.SpecAnnotator)
+	if !ok {
+		return nil
+	}
+	return annotator.GetSpecAnnotations()
+}
+
+// specHookNames returns the names of the hooks that can be disabled.
+func specHookNames() []string {
+	names := []string{string(nvcdi.AllHooks)}
+	for _, hook := range nvcdi.SpecHooks {
+		names = append(names, string(hook))
+	}
+	return names
+}
+
+// annotateChecksum adds the checksum annotations to the specified spec if
+// these were requested. Since the checksum covers the contents of the spec,
+// this must be called after the spec has been modified.
+func annotateChecksum(opts *options, s spec.Interface) error {
+	if !opts.checksumAnnotations {
+		return nil
+	}
+	annotator := transform.NewChecksumAnnotator(info.GetVersion("nvidia-ctk").Version)
+	if err := annotator.Transform(s.Raw()); err != nil {
+		return fmt.Errorf("failed to add checksum annotations: %w", err)
+	}
+	return nil
 }
@@ -26,12 +26,17 @@ import (
 	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
 	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
 )
 
 func TestGenerateSpec(t *testing.T) {
 	t.Setenv("__NVCT_TESTING_DEVICES_ARE_FILES", "true")
+	// Requested failures must not be propagated to the hooks of generated
+	// specs.
+	t.Setenv("__NVCT_TESTING_INJECT_FAILURES", "hook-timeout")
 	moduleRoot, err := test.GetModuleRoot()
 	require.NoError(t, err)
 
@@ -374,11 +379,22 @@ containerEdits:
 			server.DeviceGetCountFunc = func() (int, nvml.Return) {
 				return 1, nvml.SUCCESS
 			}
+			// Driver features are not reported so that no spec annotations
+			// are added.
+			server.SystemGetConfComputeStateFunc = func() (nvml.ConfComputeSystemState, nvml.Return) {
+				return nvml.ConfComputeSystemState{}, nvml.ERROR_NOT_SUPPORTED
+			}
 			for _, d := range server.Devices {
 				// TODO: This is not implemented in the mock.
 				(d.(*dgxa100.Device)).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
 					return 0, nvml.SUCCESS
 				}
+				(d.(*dgxa100.Device)).GetGspFirmwareModeFunc = func() (bool, bool, nvml.Return) {
+					return false, false, nvml.ERROR_NOT_SUPPORTED
+				}
+				(d.(*dgxa100.Device)).GetPersistenceModeFunc = func() (nvml.EnableState, nvml.Return) {
+					return 0, nvml.ERROR_NOT_SUPPORTED
+				}
 			}
 			tc.options.nvmllib = server
 
@@ -393,3 +409,174 @@ containerEdits:
 		})
 	}
 }
+
+func TestWriteChangeSet(t *testing.T) {
+	s, err := spec.New(
+		spec.WithVendor("example.com"),
+		spec.WithClass("device"),
+		spec.WithFormat(spec.FormatYAML),
+		spec.WithDeviceSpecs([]specs.Device{
+			{
+				Name: "0",
+				ContainerEdits: specs.ContainerEdits{
+					Env: []string{"FOO=bar"},
+				},
+			},
+		}),
+	)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+
+	testCases := []struct {
+		description       string
+		output            string
+		save              bool
+		expectedChangeSet string
+	}{
+		{
+			description:       "no output file reports no changes",
+			expectedChangeSet: `{"dryRun":true,"changed":false,"changes":[]}`,
+		},
+		{
+			description:       "new output file is reported as created",
+			output:            filepath.Join(dir, "new"),
+			expectedChangeSet: `{"dryRun":true,"changed":true,"changes":[{"action":"create","path":"` + filepath.Join(dir, "new.yaml") + `"}]}`,
+		},
+		{
+			description:       "unchanged output file reports no changes",
+			output:            filepath.Join(dir, "existing.yaml"),
+			save:              true,
+			expectedChangeSet: `{"dryRun":true,"changed":false,"changes":[]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if tc.save {
+				require.NoError(t, s.Save(tc.output))
+			}
+
+			var buf bytes.Buffer
+			err := writeChangeSet(&buf, s, &options{output: tc.output, format: spec.FormatYAML})
+			require.NoError(t, err)
+			require.JSONEq(t, tc.expectedChangeSet, buf.String())
+			if tc.output != "" && !tc.save {
+				require.NoFileExists(t, tc.output+".yaml")
+			}
+		})
+	}
+}
+
+func TestValidateFlagsDriverRoots(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	opts := options{
+		format:      "yaml",
+		mode:        "nvml",
+		vendor:      "example.com",
+		class:       "device",
+		driverRoots: []string{"/run/nvidia/driver", "/"},
+	}
+	require.NoError(t, c.validateFlags(nil, &opts))
+	require.Equal(t, "/run/nvidia/driver", opts.driverRoot)
+	require.EqualValues(t, []string{"/"}, opts.additionalDriverRoots)
+}
+
+func TestValidateFlagsChecksumAnnotations(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	testCases := []struct {
+		description   string
+		specVersion   string
+		expectedError bool
+	}{
+		{
+			description: "minimum version is detected",
+		},
+		{
+			description: "supported version",
+			specVersion: "0.6",
+		},
+		{
+			description:   "unsupported version",
+			specVersion:   "0.5.0",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			opts := options{
+				format:              "yaml",
+				mode:                "nvml",
+				vendor:              "example.com",
+				class:               "device",
+				specVersion:         tc.specVersion,
+				checksumAnnotations: true,
+			}
+			err := c.validateFlags(nil, &opts)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateFlagsDisabledHooks(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	testCases := []struct {
+		description   string
+		disabledHooks []string
+		expectedError bool
+	}{
+		{
+			description:   "spec hooks are accepted",
+			disabledHooks: []string{"update-ldcache", "create-symlinks"},
+		},
+		{
+			description:   "all is accepted",
+			disabledHooks: []string{"all"},
+		},
+		{
+			description:   "unrecognized hook is rejected",
+			disabledHooks: []string{"update-ld-cache"},
+			expectedError: true,
+		},
+		{
+			description:   "runtime hook is rejected",
+			disabledHooks: []string{"gpu-limits"},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			opts := options{
+				format:        "yaml",
+				mode:          "nvml",
+				vendor:        "example.com",
+				class:         "device",
+				disabledHooks: tc.disabledHooks,
+			}
+			err := c.validateFlags(nil, &opts)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
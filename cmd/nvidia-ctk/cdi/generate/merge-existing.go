@@ -0,0 +1,56 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	cdiapi "tags.cncf.io/container-device-interface/pkg/cdi"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/transform"
+)
+
+// mergeExistingSpec re-applies the user-defined devices and edits from the
+// existing spec at the output path to the specified generated spec. If no spec
+// exists at the output path, the generated spec is not modified.
+func (m command) mergeExistingSpec(opts *options, s spec.Interface) error {
+	path := getOutputPath(opts)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read existing spec %v: %w", path, err)
+	}
+
+	existing, err := cdiapi.ParseSpec(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse existing spec %v: %w", path, err)
+	}
+	if existing.Kind != s.Raw().Kind {
+		return fmt.Errorf("cannot merge existing spec %v: existing spec is for kind %q and not %q", path, existing.Kind, s.Raw().Kind)
+	}
+
+	if err := transform.NewUserEditsMerger(m.logger, existing).Transform(s.Raw()); err != nil {
+		return fmt.Errorf("failed to merge existing spec %v: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	cdiapi "tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/transform"
+)
+
+func TestMergeExisting(t *testing.T) {
+	t.Setenv("__NVCT_TESTING_DEVICES_ARE_FILES", "true")
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	opts := newWatchOptions(t)
+	opts.mergeExisting = true
+	require.NoError(t, c.run(opts))
+
+	existing := readRawSpec(t, opts.output)
+	existing.Annotations = map[string]string{
+		transform.UserEditsAnnotation: `{"env": ["LICENSE_SERVER=license.example.com"]}`,
+	}
+	existing.ContainerEdits.Env = append(existing.ContainerEdits.Env, "UNMARKED=true")
+	existing.Devices = append(existing.Devices, specs.Device{
+		Name: "monitoring",
+		Annotations: map[string]string{
+			transform.UserDefinedAnnotation: "true",
+		},
+		ContainerEdits: specs.ContainerEdits{
+			Mounts: []*specs.Mount{
+				{HostPath: "/run/monitoring.sock", ContainerPath: "/run/monitoring.sock"},
+			},
+		},
+	})
+	// Annotations require CDI spec version 0.6.0 or later.
+	existing.Version = "0.6.0"
+	writeRawSpec(t, opts.output, existing)
+
+	require.NoError(t, c.run(opts))
+	merged := readRawSpec(t, opts.output)
+	require.Contains(t, merged.ContainerEdits.Env, "LICENSE_SERVER=license.example.com")
+	require.NotContains(t, merged.ContainerEdits.Env, "UNMARKED=true")
+	require.Equal(t, existing.Annotations, merged.Annotations)
+
+	var deviceNames []string
+	for _, device := range merged.Devices {
+		deviceNames = append(deviceNames, device.Name)
+	}
+	require.Contains(t, deviceNames, "monitoring")
+
+	// Regenerating the merged spec does not add the user edits again.
+	mergedContents, err := os.ReadFile(opts.output)
+	require.NoError(t, err)
+	require.NoError(t, c.run(opts))
+	regeneratedContents, err := os.ReadFile(opts.output)
+	require.NoError(t, err)
+	require.Equal(t, string(mergedContents), string(regeneratedContents))
+}
+
+func TestValidateMergeExistingFlags(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	testCases := []struct {
+		description   string
+		options       options
+		expectedError bool
+	}{
+		{
+			description: "output is required",
+			options: options{
+				format:        "yaml",
+				mode:          "nvml",
+				vendor:        "example.com",
+				class:         "device",
+				mergeExisting: true,
+			},
+			expectedError: true,
+		},
+		{
+			description: "per-device specs are not supported",
+			options: options{
+				format:         "yaml",
+				mode:           "nvml",
+				vendor:         "example.com",
+				class:          "device",
+				outputDir:      "/etc/cdi",
+				perDeviceSpecs: true,
+				mergeExisting:  true,
+			},
+			expectedError: true,
+		},
+		{
+			description: "output file is supported",
+			options: options{
+				format:        "yaml",
+				mode:          "nvml",
+				vendor:        "example.com",
+				class:         "device",
+				output:        "/etc/cdi/nvidia",
+				mergeExisting: true,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := c.validateFlags(nil, &tc.options)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func readRawSpec(t *testing.T, path string) *specs.Spec {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	s, err := cdiapi.ParseSpec(data)
+	require.NoError(t, err)
+	return s
+}
+
+func writeRawSpec(t *testing.T, path string, s *specs.Spec) {
+	require.NoError(t, os.Remove(path))
+	cache, _ := cdiapi.NewCache(
+		cdiapi.WithAutoRefresh(false),
+		cdiapi.WithSpecDirs(filepath.Dir(path)),
+	)
+	require.NoError(t, cache.WriteSpec(s, filepath.Base(path)))
+}
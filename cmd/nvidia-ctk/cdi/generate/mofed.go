@@ -0,0 +1,69 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
+
+// mofedClass is the CDI class of the MOFED spec that is written alongside the
+// GPU spec if --include-mofed is specified.
+const mofedClass = "mofed"
+
+// writeMOFEDSpec generates the spec for the InfiniBand devices and RDMA
+// libraries and writes it to the output directory if its contents have
+// changed. Returns true if the spec was updated.
+func (m command) writeMOFEDSpec(opts *options) (bool, error) {
+	mofedOpts := getMOFEDOptions(opts)
+
+	s, err := m.generateSpec(mofedOpts)
+	if err != nil {
+		return false, fmt.Errorf("failed to generate MOFED CDI spec: %w", err)
+	}
+
+	if opts.tenant.name != "" {
+		if err := opts.tenant.createTenantDir(opts.outputDir); err != nil {
+			return false, err
+		}
+	}
+
+	changed, err := saveSpecIfChanged(s, mofedOpts.output, mofedOpts)
+	if err != nil {
+		return false, err
+	}
+	if changed {
+		m.logger.Infof("Updated CDI spec %v", mofedOpts.output)
+	}
+	return changed, nil
+}
+
+// getMOFEDOptions returns the options used to generate the MOFED spec. These
+// are the same as the specified options, except that MOFED mode is used to
+// generate a single device with the MOFED class.
+func getMOFEDOptions(opts *options) *options {
+	mofedOpts := *opts
+	mofedOpts.mode = string(nvcdi.ModeMofed)
+	mofedOpts.class = mofedClass
+	mofedOpts.devices = nil
+	mofedOpts.perDeviceSpecs = false
+	mofedOpts.output = filepath.Join(opts.outputDir, opts.vendor+"-"+mofedClass+"."+opts.format)
+	return &mofedOpts
+}
@@ -0,0 +1,141 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteMOFEDSpec(t *testing.T) {
+	t.Setenv("__NVCT_TESTING_DEVICES_ARE_FILES", "true")
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	opts := newWatchOptions(t)
+	opts.includeMOFED = true
+	opts.outputDir = t.TempDir()
+	opts.output = filepath.Join(opts.outputDir, "example.com-device.yaml")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(opts.devRoot, "dev", "infiniband"), 0755))
+	for _, deviceNode := range []string{"rdma_cm", "uverbs0"} {
+		require.NoError(t, os.WriteFile(filepath.Join(opts.devRoot, "dev", "infiniband", deviceNode), nil, 0600))
+	}
+
+	changed, err := c.writeSpecIfChanged(opts)
+	require.NoError(t, err)
+	require.True(t, changed)
+
+	mofedSpec, err := os.ReadFile(filepath.Join(opts.outputDir, "example.com-mofed.yaml"))
+	require.NoError(t, err)
+	require.Contains(t, string(mofedSpec), "kind: example.com/mofed")
+	require.Contains(t, string(mofedSpec), "path: /dev/infiniband/uverbs0")
+	require.Contains(t, string(mofedSpec), "path: /dev/infiniband/rdma_cm")
+	require.NotContains(t, string(mofedSpec), "/dev/nvidia0")
+
+	gpuSpec, err := os.ReadFile(opts.output)
+	require.NoError(t, err)
+	require.Contains(t, string(gpuSpec), "kind: example.com/device")
+	require.NotContains(t, string(gpuSpec), "/dev/infiniband")
+
+	changed, err = c.writeSpecIfChanged(opts)
+	require.NoError(t, err)
+	require.False(t, changed)
+}
+
+func TestValidateIncludeMOFEDFlags(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	testCases := []struct {
+		description   string
+		options       options
+		expectedError bool
+	}{
+		{
+			description: "output dir is required",
+			options: options{
+				output: "/etc/cdi/nvidia",
+				mode:   "nvml",
+				class:  "device",
+			},
+			expectedError: true,
+		},
+		{
+			description: "dry-run is not supported",
+			options: options{
+				outputDir: "/etc/cdi",
+				dryRun:    true,
+				mode:      "nvml",
+				class:     "device",
+			},
+			expectedError: true,
+		},
+		{
+			description: "mofed mode is not supported",
+			options: options{
+				outputDir: "/etc/cdi",
+				mode:      "mofed",
+				class:     "device",
+			},
+			expectedError: true,
+		},
+		{
+			description: "mofed class is not supported",
+			options: options{
+				outputDir: "/etc/cdi",
+				mode:      "nvml",
+				class:     "mofed",
+			},
+			expectedError: true,
+		},
+		{
+			description: "output dir is accepted",
+			options: options{
+				outputDir: "/etc/cdi",
+				mode:      "nvml",
+				class:     "device",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			opts := tc.options
+			opts.includeMOFED = true
+			opts.format = "yaml"
+			opts.vendor = "example.com"
+
+			err := c.validateFlags(nil, &opts)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "/etc/cdi/example.com-device.yaml", opts.output)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	cdiapi "tags.cncf.io/container-device-interface/pkg/cdi"
+)
+
+// checkExistingSpecKind ensures that a spec at the specified path, if any,
+// defines devices of the specified kind. This prevents specs generated into a
+// spec directory that is shared with other vendors from overwriting the specs
+// of those vendors.
+func checkExistingSpecKind(path string, kind string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read existing spec %v: %w", path, err)
+	}
+
+	existing, err := cdiapi.ParseSpec(data)
+	if err != nil {
+		return fmt.Errorf("refusing to overwrite %v: failed to parse existing spec: %w", path, err)
+	}
+	if existing.Kind != kind {
+		return fmt.Errorf("refusing to overwrite %v: existing spec is for kind %q and not %q", path, existing.Kind, kind)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckExistingSpecKind(t *testing.T) {
+	testCases := []struct {
+		description   string
+		existing      string
+		expectedError bool
+	}{
+		{
+			description: "no existing spec",
+		},
+		{
+			description: "existing spec with same kind",
+			existing:    "cdiVersion: 0.5.0\nkind: nvidia.com/gpu\ndevices: []\n",
+		},
+		{
+			description:   "existing spec for other vendor",
+			existing:      "cdiVersion: 0.5.0\nkind: amd.com/gpu\ndevices: []\n",
+			expectedError: true,
+		},
+		{
+			description:   "existing file that is not a spec",
+			existing:      "not: [a spec",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "nvidia.com-gpu.yaml")
+			if tc.existing != "" {
+				require.NoError(t, os.WriteFile(path, []byte(tc.existing), 0600))
+			}
+
+			err := checkExistingSpecKind(path, "nvidia.com/gpu")
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
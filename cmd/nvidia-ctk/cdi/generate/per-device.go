@@ -0,0 +1,198 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+)
+
+// perDeviceSpecSeparator separates the vendor and class from the device UUID
+// in the file names of per-device specs (e.g. nvidia.com-gpu_GPU-<uuid>.yaml).
+const perDeviceSpecSeparator = "_"
+
+// writePerDeviceSpecs generates a spec for each device and writes the specs
+// that have changed to the output directory. Specs for devices that no longer
+// exist are removed. Returns true if any spec was updated or removed.
+func (m command) writePerDeviceSpecs(opts *options) (bool, error) {
+	perDeviceSpecs, err := m.generatePerDeviceSpecs(opts)
+	if err != nil {
+		return false, fmt.Errorf("failed to generate CDI specs: %w", err)
+	}
+
+	if opts.tenant.name != "" {
+		if err := opts.tenant.createTenantDir(opts.outputDir); err != nil {
+			return false, err
+		}
+	}
+
+	var paths []string
+	for path := range perDeviceSpecs {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+
+	var updated bool
+	for _, path := range paths {
+		changed, err := saveSpecIfChanged(perDeviceSpecs[path], path, opts)
+		if err != nil {
+			return updated, err
+		}
+		if changed {
+			m.logger.Infof("Updated CDI spec %v", path)
+			updated = true
+		}
+	}
+
+	removed, err := m.removeStalePerDeviceSpecs(opts, perDeviceSpecs)
+	if err != nil {
+		return updated, err
+	}
+	return updated || removed, nil
+}
+
+// generatePerDeviceSpecs generates a spec for each device keyed by the path
+// that the spec is written to. Each spec includes the common edits. The device
+// specs that are not associated with a single device (e.g. capability devices)
+// as well as the device that includes all devices are included in a common
+// spec that is written to the output file for the vendor and class.
+func (m command) generatePerDeviceSpecs(opts *options) (map[string]spec.Interface, error) {
+	cdilib, err := m.newCDILib(opts)
+	if err != nil {
+		return nil, err
+	}
+	generator, ok := cdilib.(nvcdi.PerDeviceSpecGenerator)
+	if !ok {
+		return nil, fmt.Errorf("per-device specs are not supported")
+	}
+
+	perDeviceSpecs, err := generator.GetPerDeviceSpecsByID(getDeviceIDs(opts)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create device CDI specs: %v", err)
+	}
+
+	commonEdits, err := cdilib.GetCommonEdits()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create edits common for entities: %v", err)
+	}
+
+	var uuids []string
+	for uuid := range perDeviceSpecs {
+		uuids = append(uuids, uuid)
+	}
+	slices.Sort(uuids)
+
+	generated := make(map[string]spec.Interface)
+	var allDeviceSpecs []specs.Device
+	for _, uuid := range uuids {
+		allDeviceSpecs = append(allDeviceSpecs, perDeviceSpecs[uuid]...)
+		if uuid == "" {
+			continue
+		}
+		s, err := m.newSpec(opts, cdilib, perDeviceSpecs[uuid], commonEdits, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create CDI spec for device %v: %w", uuid, err)
+		}
+		generated[getPerDeviceSpecPath(opts, uuid)] = s
+	}
+
+	// The device that includes all devices is generated from all device
+	// specs, after which the device specs that are included in a per-device
+	// spec are removed from the common spec.
+	common, err := m.newSpec(opts, cdilib, allDeviceSpecs, commonEdits, true)
+	if err != nil {
+		return nil, err
+	}
+	commonDevices := map[string]bool{allDeviceName: true}
+	for _, deviceSpec := range perDeviceSpecs[""] {
+		commonDevices[deviceSpec.Name] = true
+	}
+	common.Raw().Devices = slices.DeleteFunc(common.Raw().Devices, func(d specs.Device) bool {
+		return !commonDevices[d.Name]
+	})
+	if err := annotateChecksum(opts, common); err != nil {
+		return nil, err
+	}
+	generated[getOutputPath(opts)] = common
+
+	return generated, nil
+}
+
+// getPerDeviceSpecPath returns the path of the spec for the device with the
+// specified UUID.
+func getPerDeviceSpecPath(opts *options, uuid string) string {
+	return filepath.Join(opts.outputDir, opts.vendor+"-"+opts.class+perDeviceSpecSeparator+uuid+"."+opts.format)
+}
+
+// saveSpecIfChanged saves the specified spec to the specified path if its
+// contents differ from the existing file. Returns true if the spec was saved.
+func saveSpecIfChanged(s spec.Interface, path string, opts *options) (bool, error) {
+	contents := &bytes.Buffer{}
+	if _, err := s.WriteTo(contents); err != nil {
+		return false, fmt.Errorf("failed to render CDI spec: %w", err)
+	}
+	existing, err := os.ReadFile(path)
+	if err == nil && bytes.Equal(existing, contents.Bytes()) {
+		return false, nil
+	}
+
+	if err := checkExistingSpecKind(path, s.Raw().Kind); err != nil {
+		return false, err
+	}
+	if err := s.Save(path); err != nil {
+		return false, err
+	}
+	return true, opts.tenant.setGroup(path)
+}
+
+// removeStalePerDeviceSpecs removes the per-device specs in the output
+// directory that were not generated, for example because a GPU was removed or
+// MIG devices were reconfigured. Returns true if any spec was removed.
+func (m command) removeStalePerDeviceSpecs(opts *options, generated map[string]spec.Interface) (bool, error) {
+	pattern := filepath.Join(opts.outputDir, opts.vendor+"-"+opts.class+perDeviceSpecSeparator+"*")
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return false, fmt.Errorf("failed to find existing CDI specs: %w", err)
+	}
+
+	kind := opts.vendor + "/" + opts.class
+	var removed bool
+	for _, path := range matches {
+		if _, ok := generated[path]; ok || formatFromFilename(path) == "" {
+			continue
+		}
+		if err := checkExistingSpecKind(path, kind); err != nil {
+			m.logger.Warningf("Not removing stale CDI spec: %v", err)
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return removed, fmt.Errorf("failed to remove stale CDI spec %v: %w", path, err)
+		}
+		m.logger.Infof("Removed stale CDI spec %v", path)
+		removed = true
+	}
+	return removed, nil
+}
@@ -0,0 +1,123 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWritePerDeviceSpecs(t *testing.T) {
+	t.Setenv("__NVCT_TESTING_DEVICES_ARE_FILES", "true")
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	opts := newWatchOptions(t)
+	opts.perDeviceSpecs = true
+	opts.outputDir = t.TempDir()
+	opts.output = filepath.Join(opts.outputDir, "example.com-device.yaml")
+
+	stale := filepath.Join(opts.outputDir, "example.com-device_GPU-removed.yaml")
+	require.NoError(t, os.WriteFile(stale, []byte("cdiVersion: 0.5.0\nkind: example.com/device\n"), 0600))
+	unrelated := filepath.Join(opts.outputDir, "example.com-other_GPU-removed.yaml")
+	require.NoError(t, os.WriteFile(unrelated, []byte("cdiVersion: 0.5.0\nkind: example.com/other\n"), 0600))
+
+	changed, err := c.writePerDeviceSpecs(opts)
+	require.NoError(t, err)
+	require.True(t, changed)
+
+	matches, err := filepath.Glob(filepath.Join(opts.outputDir, "example.com-device_GPU-*.yaml"))
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	require.NoFileExists(t, stale)
+	require.FileExists(t, unrelated)
+
+	deviceSpec, err := os.ReadFile(matches[0])
+	require.NoError(t, err)
+	require.Contains(t, string(deviceSpec), `name: "0"`)
+	require.NotContains(t, string(deviceSpec), "name: all")
+
+	commonSpec, err := os.ReadFile(opts.output)
+	require.NoError(t, err)
+	require.Contains(t, string(commonSpec), "name: all")
+	require.NotContains(t, string(commonSpec), `name: "0"`)
+
+	changed, err = c.writePerDeviceSpecs(opts)
+	require.NoError(t, err)
+	require.False(t, changed)
+}
+
+func TestValidatePerDeviceSpecsFlags(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	testCases := []struct {
+		description   string
+		options       options
+		expectedError bool
+	}{
+		{
+			description: "output dir is required",
+			options: options{
+				output: "/etc/cdi/nvidia",
+			},
+			expectedError: true,
+		},
+		{
+			description: "dry-run is not supported",
+			options: options{
+				outputDir: "/etc/cdi",
+				dryRun:    true,
+			},
+			expectedError: true,
+		},
+		{
+			description: "output dir is accepted",
+			options: options{
+				outputDir: "/etc/cdi",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			opts := tc.options
+			opts.perDeviceSpecs = true
+			opts.format = "yaml"
+			opts.mode = "nvml"
+			opts.vendor = "example.com"
+			opts.class = "device"
+
+			err := c.validateFlags(nil, &opts)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "/etc/cdi/example.com-device.yaml", opts.output)
+		})
+	}
+}
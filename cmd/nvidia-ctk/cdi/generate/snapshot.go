@@ -0,0 +1,50 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/snapshot"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
+
+// loadSnapshot loads the snapshot specified by --from-snapshot and uses it in
+// place of NVML when generating the spec. Since the snapshot only replaces
+// NVML, it can only be used in nvml mode. Note that a snapshot is static and
+// watching for changes is not supported.
+func (m command) loadSnapshot(opts *options) error {
+	switch nvcdi.Mode(opts.mode) {
+	case nvcdi.ModeAuto:
+		opts.mode = string(nvcdi.ModeNvml)
+	case nvcdi.ModeNvml:
+	default:
+		return fmt.Errorf("--from-snapshot is not supported in %v mode", opts.mode)
+	}
+	if opts.watch {
+		return fmt.Errorf("--from-snapshot cannot be used with --watch")
+	}
+
+	s, err := snapshot.Load(opts.fromSnapshot)
+	if err != nil {
+		return err
+	}
+	m.logger.Infof("Using snapshot %v with driver version %v and %d devices", opts.fromSnapshot, s.DriverVersion, len(s.Devices))
+	opts.nvmllib = s.NVML()
+	return nil
+}
@@ -0,0 +1,120 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/snapshot"
+)
+
+func TestGenerateSpecFromSnapshot(t *testing.T) {
+	t.Setenv("__NVCT_TESTING_DEVICES_ARE_FILES", "true")
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	opts := newWatchOptions(t)
+	expected, err := c.generateSpec(opts)
+	require.NoError(t, err)
+
+	s, err := snapshot.FromNVML(opts.nvmllib)
+	require.NoError(t, err)
+	f, err := os.Create(filepath.Join(t.TempDir(), "snapshot.json"))
+	require.NoError(t, err)
+	_, err = s.WriteTo(f)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	opts.nvmllib = nil
+	opts.mode = "auto"
+	opts.fromSnapshot = f.Name()
+	require.NoError(t, c.loadSnapshot(opts))
+	require.Equal(t, "nvml", opts.mode)
+
+	generated, err := c.generateSpec(opts)
+	require.NoError(t, err)
+	require.EqualValues(t, expected.Raw(), generated.Raw())
+}
+
+func TestLoadSnapshotFlags(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	filename := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, os.WriteFile(filename, []byte(`{"version": "1.0", "driverVersion": "999.88.77"}`), 0600))
+
+	testCases := []struct {
+		description   string
+		options       options
+		expectedError bool
+	}{
+		{
+			description: "nvml mode is supported",
+			options: options{
+				mode:         "nvml",
+				fromSnapshot: filename,
+			},
+		},
+		{
+			description: "csv mode is not supported",
+			options: options{
+				mode:         "csv",
+				fromSnapshot: filename,
+			},
+			expectedError: true,
+		},
+		{
+			description: "watch is not supported",
+			options: options{
+				mode:         "nvml",
+				fromSnapshot: filename,
+				watch:        true,
+			},
+			expectedError: true,
+		},
+		{
+			description: "missing snapshot returns error",
+			options: options{
+				mode:         "nvml",
+				fromSnapshot: filepath.Join(t.TempDir(), "missing.json"),
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := c.loadSnapshot(&tc.options)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, tc.options.nvmllib)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// tenantsDir is the subdirectory of the output directory that contains the
+// per-tenant spec directories. Since CDI does not search subdirectories of
+// the configured spec directories, tenant specs are not visible to container
+// engines that use the default spec directories.
+const tenantsDir = "tenants"
+
+var validTenantName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+type tenantOptions struct {
+	name  string
+	group string
+	gid   *uint32
+}
+
+// validateTenantName checks whether the specified tenant name can be used as
+// the name of a directory.
+func validateTenantName(name string) error {
+	if !validTenantName.MatchString(name) {
+		return fmt.Errorf("invalid tenant name %q: must start with an alphanumeric character and contain only alphanumeric characters, '_', '.', or '-'", name)
+	}
+	return nil
+}
+
+// getTenantDir returns the spec directory for the specified tenant.
+func getTenantDir(outputDir string, tenant string) string {
+	return filepath.Join(outputDir, tenantsDir, tenant)
+}
+
+// dirPermissions returns the permissions of the spec directory of a tenant.
+// Only the tenant group (if specified) is allowed to list the directory.
+func (t tenantOptions) dirPermissions() os.FileMode {
+	if t.gid != nil {
+		return 0750
+	}
+	return 0700
+}
+
+// specPermissions returns the permissions of the specs of a tenant. If no
+// tenant is specified, the spec is readable by everyone.
+func (t tenantOptions) specPermissions() os.FileMode {
+	switch {
+	case t.name == "":
+		return 0644
+	case t.gid != nil:
+		return 0640
+	}
+	return 0600
+}
+
+// createTenantDir creates the spec directory for a tenant and ensures that it
+// has the required permissions and group. The permissions of an existing
+// directory are also updated.
+func (t tenantOptions) createTenantDir(dir string) error {
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return fmt.Errorf("failed to create tenants directory: %w", err)
+	}
+	if err := os.Mkdir(dir, t.dirPermissions()); err != nil && !os.IsExist(err) {
+		return fmt.Errorf("failed to create tenant directory: %w", err)
+	}
+	if err := os.Chmod(dir, t.dirPermissions()); err != nil {
+		return fmt.Errorf("failed to set permissions on tenant directory: %w", err)
+	}
+	return t.setGroup(dir)
+}
+
+// setGroup sets the group of the specified path to the tenant group.
+func (t tenantOptions) setGroup(path string) error {
+	if t.gid == nil {
+		return nil
+	}
+	if err := os.Chown(path, -1, int(*t.gid)); err != nil {
+		return fmt.Errorf("failed to set group of %v: %w", path, err)
+	}
+	return nil
+}
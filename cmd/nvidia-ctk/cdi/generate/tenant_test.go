@@ -0,0 +1,104 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTenantName(t *testing.T) {
+	testCases := []struct {
+		name          string
+		expectedError bool
+	}{
+		{name: "team-a"},
+		{name: "team_b.1"},
+		{name: "", expectedError: true},
+		{name: ".hidden", expectedError: true},
+		{name: "../escape", expectedError: true},
+		{name: "team/a", expectedError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateTenantName(tc.name)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCreateTenantDir(t *testing.T) {
+	gid := uint32(os.Getgid())
+
+	testCases := []struct {
+		description         string
+		tenant              tenantOptions
+		existing            bool
+		expectedPermissions os.FileMode
+		expectedSpecMode    os.FileMode
+	}{
+		{
+			description:         "tenant without group is only accessible by owner",
+			tenant:              tenantOptions{name: "team-a"},
+			expectedPermissions: 0700,
+			expectedSpecMode:    0600,
+		},
+		{
+			description:         "tenant with group is accessible by group",
+			tenant:              tenantOptions{name: "team-a", gid: &gid},
+			expectedPermissions: 0750,
+			expectedSpecMode:    0640,
+		},
+		{
+			description:         "permissions of existing directory are updated",
+			tenant:              tenantOptions{name: "team-a", gid: &gid},
+			existing:            true,
+			expectedPermissions: 0750,
+			expectedSpecMode:    0640,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			dir := getTenantDir(t.TempDir(), tc.tenant.name)
+			if tc.existing {
+				require.NoError(t, os.MkdirAll(dir, 0755))
+			}
+
+			require.NoError(t, tc.tenant.createTenantDir(dir))
+
+			info, err := os.Stat(dir)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedPermissions, info.Mode().Perm())
+
+			parent, err := os.Stat(filepath.Dir(dir))
+			require.NoError(t, err)
+			require.Equal(t, os.FileMode(0755), parent.Mode().Perm())
+
+			require.Equal(t, tc.expectedSpecMode, tc.tenant.specPermissions())
+		})
+	}
+}
@@ -0,0 +1,288 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+)
+
+const (
+	// watchDebounce is the period for which events are accumulated before the
+	// CDI spec is regenerated. This prevents a regeneration for each device
+	// node that is created while a driver is being loaded.
+	watchDebounce = 2 * time.Second
+
+	// migEventWaitTimeout is the timeout in milliseconds for a single wait on
+	// the NVML event set. This bounds the time taken to react to cancellation.
+	migEventWaitTimeout = 5000
+	// migEventRetryInterval is the interval at which monitoring MIG
+	// configuration changes is retried if NVML is not available (e.g. while
+	// the driver is not loaded).
+	migEventRetryInterval = 30 * time.Second
+)
+
+// watch keeps the CDI spec at the configured output up to date until the
+// context is cancelled or a SIGINT or SIGTERM is received. The spec is
+// regenerated when device nodes are created or removed, which is the case when
+// GPUs are hot-plugged or the driver is reloaded, and when NVML reports that
+// the MIG configuration of a device has changed. Since not all changes generate
+// events, the spec is also regenerated at the configured watch interval.
+func (m command) watch(ctx context.Context, opts *options, debounce time.Duration) error {
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	for _, path := range getWatchPaths(opts) {
+		if err := watcher.Add(path); err != nil {
+			m.logger.Warningf("Not watching %v for changes: %v", path, err)
+			continue
+		}
+		m.logger.Infof("Watching %v for changes", path)
+	}
+
+	migConfigChanges := make(chan struct{}, 1)
+	go m.watchMIGConfigChanges(ctx, m.getNVMLLib(opts), migConfigChanges)
+
+	m.updateSpec(opts)
+
+	resync := time.NewTicker(opts.watchInterval)
+	defer resync.Stop()
+
+	var pending <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return fmt.Errorf("watcher closed unexpectedly")
+			}
+			m.logger.Debugf("Received event %v", event)
+			if event.Has(fsnotify.Create) && isDir(event.Name) {
+				// Directories such as /dev/nvidia-caps may only be created
+				// once the driver is loaded.
+				_ = watcher.Add(event.Name)
+			}
+			if pending == nil {
+				pending = time.After(debounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return fmt.Errorf("watcher closed unexpectedly")
+			}
+			m.logger.Warningf("Error watching for changes: %v", err)
+		case <-migConfigChanges:
+			m.logger.Debugf("Received MIG configuration change")
+			if pending == nil {
+				pending = time.After(debounce)
+			}
+		case <-pending:
+			pending = nil
+			m.updateSpec(opts)
+		case <-resync.C:
+			m.updateSpec(opts)
+		}
+	}
+}
+
+// updateSpec regenerates the CDI spec and replaces the existing spec if its
+// contents have changed. Errors are logged since the command should keep
+// running if the driver is temporarily unavailable (e.g. while it is being
+// upgraded).
+func (m command) updateSpec(opts *options) {
+	if _, err := m.writeSpecIfChanged(opts); err != nil {
+		m.logger.Warningf("Failed to update CDI spec: %v", err)
+	}
+}
+
+func (m command) writeSpecIfChanged(opts *options) (bool, error) {
+	var mofedUpdated bool
+	if opts.includeMOFED {
+		updated, err := m.writeMOFEDSpec(opts)
+		if err != nil {
+			return false, err
+		}
+		mofedUpdated = updated
+	}
+
+	if opts.perDeviceSpecs {
+		updated, err := m.writePerDeviceSpecs(opts)
+		return mofedUpdated || updated, err
+	}
+
+	spec, err := m.generateSpec(opts)
+	if err != nil {
+		return mofedUpdated, fmt.Errorf("failed to generate CDI spec: %w", err)
+	}
+
+	contents := &bytes.Buffer{}
+	if _, err := spec.WriteTo(contents); err != nil {
+		return mofedUpdated, fmt.Errorf("failed to render CDI spec: %w", err)
+	}
+	existing, err := os.ReadFile(getOutputPath(opts))
+	if err == nil && bytes.Equal(existing, contents.Bytes()) {
+		return mofedUpdated, nil
+	}
+
+	if err := writeSpec(spec, opts); err != nil {
+		return mofedUpdated, err
+	}
+	m.logger.Infof("Updated CDI spec %v", getOutputPath(opts))
+	return true, nil
+}
+
+// watchMIGConfigChanges sends to the specified channel whenever NVML reports a
+// MIG configuration change for any device. If NVML cannot be used, for example
+// because the driver is not loaded, monitoring is retried periodically until
+// the context is cancelled.
+func (m command) watchMIGConfigChanges(ctx context.Context, nvmllib nvml.Interface, changes chan<- struct{}) {
+	for {
+		err := m.waitForMIGConfigChanges(ctx, nvmllib, changes)
+		if ctx.Err() != nil {
+			return
+		}
+		m.logger.Debugf("Not monitoring MIG configuration changes: %v", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(migEventRetryInterval):
+		}
+	}
+}
+
+func (m command) waitForMIGConfigChanges(ctx context.Context, nvmllib nvml.Interface, changes chan<- struct{}) error {
+	if ret := nvmllib.Init(); ret != nvml.SUCCESS {
+		return fmt.Errorf("failed to initialize NVML: %v", ret)
+	}
+	defer func() {
+		_ = nvmllib.Shutdown()
+	}()
+
+	set, ret := nvmllib.EventSetCreate()
+	if ret != nvml.SUCCESS {
+		return fmt.Errorf("failed to create event set: %v", ret)
+	}
+	defer func() {
+		_ = set.Free()
+	}()
+
+	count, ret := nvmllib.DeviceGetCount()
+	if ret != nvml.SUCCESS {
+		return fmt.Errorf("failed to get device count: %v", ret)
+	}
+	var registered int
+	for i := 0; i < count; i++ {
+		device, ret := nvmllib.DeviceGetHandleByIndex(i)
+		if ret != nvml.SUCCESS {
+			return fmt.Errorf("failed to get device handle for index %d: %v", i, ret)
+		}
+		ret = device.RegisterEvents(nvml.EventMigConfigChange, set)
+		if ret == nvml.ERROR_NOT_SUPPORTED {
+			continue
+		}
+		if ret != nvml.SUCCESS {
+			return fmt.Errorf("failed to register events for device %d: %v", i, ret)
+		}
+		registered++
+	}
+	if registered == 0 {
+		return errors.New("no devices support MIG configuration change events")
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		_, ret := set.Wait(migEventWaitTimeout)
+		if ret == nvml.ERROR_TIMEOUT {
+			continue
+		}
+		if ret != nvml.SUCCESS {
+			// An unloaded driver or a lost GPU is detected through the device
+			// nodes, so we only need to reinitialize NVML here.
+			return fmt.Errorf("failed to wait for events: %v", ret)
+		}
+		select {
+		case changes <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// getNVMLLib returns the NVML library used to monitor MIG configuration changes.
+// As is the case when generating the spec, libnvidia-ml.so.1 is located in the
+// driver root.
+func (m command) getNVMLLib(opts *options) nvml.Interface {
+	if opts.nvmllib != nil {
+		return opts.nvmllib
+	}
+	driver := root.New(
+		root.WithLogger(m.logger),
+		root.WithDriverRoot(opts.driverRoot),
+		root.WithLibrarySearchPaths(opts.librarySearchPaths...),
+	)
+	var nvmlOpts []nvml.LibraryOption
+	if candidates, err := driver.Libraries().Locate("libnvidia-ml.so.1"); err == nil {
+		nvmlOpts = append(nvmlOpts, nvml.WithLibraryPath(candidates[0]))
+	}
+	return nvml.New(nvmlOpts...)
+}
+
+// getWatchPaths returns the paths that are watched for changes that affect the
+// generated CDI spec. This includes the /dev folder where the device nodes for
+// GPUs are created, as well as the folder containing the device nodes for MIG
+// capabilities.
+func getWatchPaths(opts *options) []string {
+	devRoot := opts.devRoot
+	if devRoot == "" {
+		devRoot = opts.driverRoot
+	}
+	if devRoot == "" {
+		devRoot = "/"
+	}
+	return []string{
+		filepath.Join(devRoot, "dev"),
+		filepath.Join(devRoot, "dev", "nvidia-caps"),
+	}
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
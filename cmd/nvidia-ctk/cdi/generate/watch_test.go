@@ -0,0 +1,213 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+)
+
+func TestValidateWatchFlags(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	testCases := []struct {
+		description   string
+		options       options
+		expectedError bool
+	}{
+		{
+			description: "watch with output is valid",
+			options: options{
+				output:        "/etc/cdi/nvidia",
+				watch:         true,
+				watchInterval: time.Minute,
+			},
+		},
+		{
+			description: "watch with output dir is valid",
+			options: options{
+				outputDir:     "/var/run/cdi",
+				watch:         true,
+				watchInterval: time.Minute,
+			},
+		},
+		{
+			description: "watch requires output",
+			options: options{
+				watch:         true,
+				watchInterval: time.Minute,
+			},
+			expectedError: true,
+		},
+		{
+			description: "watch cannot be used with dry-run",
+			options: options{
+				output:        "/etc/cdi/nvidia",
+				watch:         true,
+				watchInterval: time.Minute,
+				dryRun:        true,
+			},
+			expectedError: true,
+		},
+		{
+			description: "watch requires positive interval",
+			options: options{
+				output: "/etc/cdi/nvidia",
+				watch:  true,
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c := command{
+				logger: logger,
+			}
+			tc.options.format = "yaml"
+			tc.options.mode = "nvml"
+			tc.options.vendor = "nvidia.com"
+			tc.options.class = "gpu"
+
+			err := c.validateFlags(nil, &tc.options)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestWriteSpecIfChanged(t *testing.T) {
+	t.Setenv("__NVCT_TESTING_DEVICES_ARE_FILES", "true")
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	opts := newWatchOptions(t)
+
+	changed, err := c.writeSpecIfChanged(opts)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.FileExists(t, opts.output)
+
+	changed, err = c.writeSpecIfChanged(opts)
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	require.NoError(t, os.WriteFile(opts.output, []byte("modified"), 0600))
+	changed, err = c.writeSpecIfChanged(opts)
+	require.NoError(t, err)
+	require.True(t, changed)
+}
+
+func TestWatch(t *testing.T) {
+	t.Setenv("__NVCT_TESTING_DEVICES_ARE_FILES", "true")
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	opts := newWatchOptions(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- c.watch(ctx, opts, 10*time.Millisecond)
+	}()
+
+	// The spec is generated when the watch is started.
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(opts.output)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Creating a device node triggers a regeneration of the spec.
+	require.NoError(t, os.Remove(opts.output))
+	require.NoError(t, os.WriteFile(filepath.Join(opts.devRoot, "dev", "nvidia-uvm"), nil, 0600))
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(opts.output)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+// newWatchOptions returns the options for generating a spec for a single GPU
+// from the rootfs-1 test driver root. The device nodes are created in a
+// temporary dev root so that these can be modified.
+func newWatchOptions(t *testing.T) *options {
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+
+	devRoot := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(devRoot, "dev"), 0755))
+	for _, deviceNode := range []string{"nvidia0", "nvidiactl"} {
+		require.NoError(t, os.WriteFile(filepath.Join(devRoot, "dev", deviceNode), nil, 0600))
+	}
+
+	server := dgxa100.New()
+	server.SystemGetDriverVersionFunc = func() (string, nvml.Return) {
+		return "999.88.77", nvml.SUCCESS
+	}
+	server.DeviceGetCountFunc = func() (int, nvml.Return) {
+		return 1, nvml.SUCCESS
+	}
+	server.SystemGetConfComputeStateFunc = func() (nvml.ConfComputeSystemState, nvml.Return) {
+		return nvml.ConfComputeSystemState{}, nvml.ERROR_NOT_SUPPORTED
+	}
+	server.EventSetCreateFunc = func() (nvml.EventSet, nvml.Return) {
+		return nil, nvml.ERROR_NOT_SUPPORTED
+	}
+	for _, d := range server.Devices {
+		(d.(*dgxa100.Device)).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+			return 0, nvml.SUCCESS
+		}
+		(d.(*dgxa100.Device)).GetGspFirmwareModeFunc = func() (bool, bool, nvml.Return) {
+			return false, false, nvml.ERROR_NOT_SUPPORTED
+		}
+		(d.(*dgxa100.Device)).GetPersistenceModeFunc = func() (nvml.EnableState, nvml.Return) {
+			return 0, nvml.ERROR_NOT_SUPPORTED
+		}
+	}
+
+	return &options{
+		output:            filepath.Join(t.TempDir(), "nvidia.yaml"),
+		format:            "yaml",
+		mode:              "nvml",
+		vendor:            "example.com",
+		class:             "device",
+		driverRoot:        filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1"),
+		devRoot:           devRoot,
+		nvidiaCDIHookPath: "/usr/bin/nvidia-cdi-hook",
+		watchInterval:     time.Hour,
+		nvmllib:           server,
+	}
+}
@@ -0,0 +1,176 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package windows
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+	"tags.cncf.io/container-device-interface/pkg/parser"
+	specs "tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/platform-support/windows"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+)
+
+const (
+	allDeviceName = "all"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	output          string
+	format          string
+	vendor          string
+	class           string
+	driverStorePath string
+}
+
+// NewCommand constructs a generate command for Windows hosts with the
+// specified logger.
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build creates the CLI command
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name:  "generate",
+		Usage: "Generate CDI specifications for use with Windows containers",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(cmd, &opts)
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			return m.run(&opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "output",
+				Usage:       "Specify the file to output the generated CDI specification to. If this is '' the specification is output to STDOUT",
+				Destination: &opts.output,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_OUTPUT_FILE_PATH"),
+			},
+			&cli.StringFlag{
+				Name:        "format",
+				Usage:       "The output format for the generated spec [json | yaml]. This overrides the format defined by the output file extension (if specified).",
+				Value:       spec.FormatYAML,
+				Destination: &opts.format,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_OUTPUT_FORMAT"),
+			},
+			&cli.StringFlag{
+				Name:        "vendor",
+				Aliases:     []string{"cdi-vendor"},
+				Usage:       "the vendor string to use for the generated CDI specification.",
+				Value:       "nvidia.com",
+				Destination: &opts.vendor,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_VENDOR"),
+			},
+			&cli.StringFlag{
+				Name:        "class",
+				Aliases:     []string{"cdi-class"},
+				Usage:       "the class string to use for the generated CDI specification.",
+				Value:       "gpu",
+				Destination: &opts.class,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_CLASS"),
+			},
+			&cli.StringFlag{
+				Name:        "driver-store",
+				Usage:       "Specify the path to the driver store in which the NVIDIA driver packages are located.",
+				Value:       windows.DefaultDriverStorePath,
+				Destination: &opts.driverStorePath,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_DRIVER_STORE"),
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(_ *cli.Command, opts *options) error {
+	opts.format = strings.ToLower(opts.format)
+	switch opts.format {
+	case spec.FormatJSON:
+	case spec.FormatYAML:
+	default:
+		return fmt.Errorf("invalid output format: %v", opts.format)
+	}
+
+	if err := parser.ValidateVendorName(opts.vendor); err != nil {
+		return fmt.Errorf("invalid CDI vendor name: %v", err)
+	}
+	if err := parser.ValidateClassName(opts.class); err != nil {
+		return fmt.Errorf("invalid CDI class name: %v", err)
+	}
+	return nil
+}
+
+func (m command) run(opts *options) error {
+	spec, err := m.generateSpec(opts)
+	if err != nil {
+		return fmt.Errorf("failed to generate CDI spec: %v", err)
+	}
+
+	if opts.output == "" {
+		_, err := spec.WriteTo(os.Stdout)
+		if err != nil {
+			return fmt.Errorf("failed to write CDI spec to STDOUT: %v", err)
+		}
+		return nil
+	}
+
+	return spec.Save(opts.output)
+}
+
+// generateSpec generates a CDI spec with a single device that makes the GPUs
+// and the most recent NVIDIA driver package of the host available in a
+// Windows container. Since individual GPUs cannot be assigned to
+// process-isolated Windows containers, only the "all" device is generated.
+func (m command) generateSpec(opts *options) (spec.Interface, error) {
+	packages, err := windows.FindDriverPackages(m.logger, opts.driverStorePath)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range packages[1:] {
+		m.logger.Infof("Ignoring older driver package %v", p.Name())
+	}
+	m.logger.Infof("Using driver package %v", packages[0].Path)
+
+	return spec.New(
+		spec.WithVendor(opts.vendor),
+		spec.WithClass(opts.class),
+		spec.WithFormat(opts.format),
+		spec.WithDeviceSpecs([]specs.Device{
+			{
+				Name:           allDeviceName,
+				ContainerEdits: windows.GetContainerEdits(packages[0]),
+			},
+		}),
+	)
+}
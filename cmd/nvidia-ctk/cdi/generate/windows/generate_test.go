@@ -0,0 +1,70 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package windows
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateSpec(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	driverStore := t.TempDir()
+	driverPackage := filepath.Join(driverStore, "nv_dispi.inf_amd64_4567")
+	require.NoError(t, os.MkdirAll(driverPackage, 0755))
+	for _, file := range []string{"nvcuda64.dll", "nvml.dll"} {
+		require.NoError(t, os.WriteFile(filepath.Join(driverPackage, file), nil, 0600))
+	}
+
+	c := command{
+		logger: logger,
+	}
+	spec, err := c.generateSpec(&options{
+		format:          "yaml",
+		vendor:          "nvidia.com",
+		class:           "gpu",
+		driverStorePath: driverStore,
+	})
+	require.NoError(t, err)
+
+	var output bytes.Buffer
+	_, err = spec.WriteTo(&output)
+	require.NoError(t, err)
+
+	expected := `---
+cdiVersion: 0.3.0
+kind: nvidia.com/gpu
+devices:
+    - name: all
+      containerEdits:
+        deviceNodes:
+            - path: class/5B45201D-F2F2-4F3B-85BB-30FF1F953599
+        mounts:
+            - hostPath: ` + driverPackage + `
+              containerPath: C:\Windows\System32\HostDriverStore\FileRepository\nv_dispi.inf_amd64_4567
+              options:
+                - ro
+`
+	require.Equal(t, expected, output.String())
+}
@@ -18,21 +18,53 @@ package list
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/urfave/cli/v3"
+	"sigs.k8s.io/yaml"
 	"tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/pkg/parser"
+	"tags.cncf.io/container-device-interface/specs-go"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 )
 
+const (
+	outputFormatTable = "table"
+	outputFormatJSON  = "json"
+	outputFormatYAML  = "yaml"
+)
+
 type command struct {
 	logger logger.Interface
 }
 
 type config struct {
 	cdiSpecDirs []string
+	format      string
+	vendor      string
+	class       string
+	deviceName  string
+	showEdits   bool
+}
+
+// A device describes a CDI device that is available in the registry.
+// If requested, the edits that are applied when the device is requested are
+// included. These consist of the edits of the device and of its spec.
+type device struct {
+	Name   string                `json:"name"`
+	Vendor string                `json:"vendor"`
+	Class  string                `json:"class"`
+	Device string                `json:"device"`
+	Spec   string                `json:"spec"`
+	Edits  *specs.ContainerEdits `json:"edits,omitempty"`
 }
 
 // NewCommand constructs a cdi list command with the specified logger
@@ -55,7 +87,7 @@ func (m command) build() *cli.Command {
 			return ctx, m.validateFlags(&cfg)
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
-			return m.run(&cfg)
+			return m.run(os.Stdout, &cfg)
 		},
 		Flags: []cli.Flag{
 			&cli.StringSliceFlag{
@@ -65,6 +97,32 @@ func (m command) build() *cli.Command {
 				Destination: &cfg.cdiSpecDirs,
 				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_SPEC_DIRS"),
 			},
+			&cli.StringFlag{
+				Name:        "format",
+				Usage:       "the output format; one of [table, json, yaml]",
+				Value:       outputFormatTable,
+				Destination: &cfg.format,
+			},
+			&cli.StringFlag{
+				Name:        "vendor",
+				Usage:       "only list devices with a vendor matching the specified glob (e.g. nvidia.com)",
+				Destination: &cfg.vendor,
+			},
+			&cli.StringFlag{
+				Name:        "class",
+				Usage:       "only list devices with a class matching the specified glob (e.g. gpu)",
+				Destination: &cfg.class,
+			},
+			&cli.StringFlag{
+				Name:        "device-name",
+				Usage:       "only list devices with a name matching the specified glob (e.g. 'GPU-*')",
+				Destination: &cfg.deviceName,
+			},
+			&cli.BoolFlag{
+				Name:        "show-edits",
+				Usage:       "show the device nodes, mounts, hooks, and environment variables that are injected for each device. This includes the edits common to all devices in a spec.",
+				Destination: &cfg.showEdits,
+			},
 		},
 	}
 
@@ -75,10 +133,20 @@ func (m command) validateFlags(cfg *config) error {
 	if len(cfg.cdiSpecDirs) == 0 {
 		return errors.New("at least one CDI specification directory must be specified")
 	}
+	switch cfg.format {
+	case outputFormatTable, outputFormatJSON, outputFormatYAML:
+	default:
+		return fmt.Errorf("unrecognized output format: %v", cfg.format)
+	}
+	for _, pattern := range []string{cfg.vendor, cfg.class, cfg.deviceName} {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid filter %q: %w", pattern, err)
+		}
+	}
 	return nil
 }
 
-func (m command) run(cfg *config) error {
+func (m command) run(w io.Writer, cfg *config) error {
 	registry, err := cdi.NewCache(
 		cdi.WithAutoRefresh(false),
 		cdi.WithSpecDirs(cfg.cdiSpecDirs...),
@@ -95,11 +163,135 @@ func (m command) run(cfg *config) error {
 		}
 	}
 
-	devices := registry.ListDevices()
+	devices := m.getDevices(registry, cfg)
 	m.logger.Infof("Found %d CDI devices", len(devices))
-	for _, device := range devices {
-		fmt.Printf("%s\n", device)
+
+	switch cfg.format {
+	case outputFormatJSON:
+		output, err := json.MarshalIndent(devices, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to convert devices to JSON: %v", err)
+		}
+		_, err = fmt.Fprintf(w, "%s\n", output)
+		return err
+	case outputFormatYAML:
+		output, err := yaml.Marshal(devices)
+		if err != nil {
+			return fmt.Errorf("failed to convert devices to YAML: %v", err)
+		}
+		_, err = w.Write(output)
+		return err
 	}
+	return writeTable(w, devices, cfg.showEdits)
+}
 
-	return nil
+// getDevices returns the devices in the registry that match the filters.
+func (m command) getDevices(registry *cdi.Cache, cfg *config) []device {
+	devices := []device{}
+	for _, name := range registry.ListDevices() {
+		vendor, class, deviceName, err := parser.ParseQualifiedName(name)
+		if err != nil {
+			m.logger.Warningf("Ignoring invalid device name %q: %v", name, err)
+			continue
+		}
+		if !matches(cfg.vendor, vendor) || !matches(cfg.class, class) || !matches(cfg.deviceName, deviceName) {
+			continue
+		}
+		d := registry.GetDevice(name)
+		if d == nil {
+			continue
+		}
+		listed := device{
+			Name:   name,
+			Vendor: vendor,
+			Class:  class,
+			Device: deviceName,
+			Spec:   d.GetSpec().GetPath(),
+		}
+		if cfg.showEdits {
+			listed.Edits = getResolvedEdits(d)
+		}
+		devices = append(devices, listed)
+	}
+	return devices
+}
+
+// getResolvedEdits returns the edits that are applied to a container if the
+// specified device is requested. The edits common to all devices in the spec
+// are applied before the edits of the device itself.
+func getResolvedEdits(d *cdi.Device) *specs.ContainerEdits {
+	edits := &cdi.ContainerEdits{ContainerEdits: &specs.ContainerEdits{}}
+	edits.Append(&cdi.ContainerEdits{ContainerEdits: &d.GetSpec().ContainerEdits})
+	edits.Append(&cdi.ContainerEdits{ContainerEdits: &d.ContainerEdits})
+	return edits.ContainerEdits
+}
+
+// matches returns true if the value matches the specified glob. An empty glob
+// matches all values.
+func matches(pattern string, value string) bool {
+	if pattern == "" {
+		return true
+	}
+	match, _ := filepath.Match(pattern, value)
+	return match
+}
+
+func writeTable(w io.Writer, devices []device, showEdits bool) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if !showEdits {
+		fmt.Fprintln(tw, "DEVICE\tSPEC")
+		for _, d := range devices {
+			fmt.Fprintf(tw, "%s\t%s\n", d.Name, d.Spec)
+		}
+		return tw.Flush()
+	}
+
+	fmt.Fprintln(tw, "DEVICE\tSPEC\tEDIT\tVALUE")
+	for _, d := range devices {
+		name, spec := d.Name, d.Spec
+		for _, edit := range describeEdits(d.Edits) {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", name, spec, edit[0], edit[1])
+			// The device and spec are only shown for the first edit of each
+			// device.
+			name, spec = "", ""
+		}
+		if name != "" {
+			fmt.Fprintf(tw, "%s\t%s\t-\t-\n", name, spec)
+		}
+	}
+	return tw.Flush()
+}
+
+// describeEdits returns the type and a description for each of the
+// specified edits.
+func describeEdits(edits *specs.ContainerEdits) [][2]string {
+	if edits == nil {
+		return nil
+	}
+	var described [][2]string
+	for _, deviceNode := range edits.DeviceNodes {
+		value := deviceNode.Path
+		if deviceNode.HostPath != "" && deviceNode.HostPath != deviceNode.Path {
+			value = deviceNode.HostPath + ":" + deviceNode.Path
+		}
+		described = append(described, [2]string{"device-node", value})
+	}
+	for _, mount := range edits.Mounts {
+		value := mount.HostPath + ":" + mount.ContainerPath
+		if len(mount.Options) > 0 {
+			value += ":" + strings.Join(mount.Options, ",")
+		}
+		described = append(described, [2]string{"mount", value})
+	}
+	for _, hook := range edits.Hooks {
+		value := hook.Path
+		if len(hook.Args) > 1 {
+			value += " " + strings.Join(hook.Args[1:], " ")
+		}
+		described = append(described, [2]string{hook.HookName + "-hook", value})
+	}
+	for _, env := range edits.Env {
+		described = append(described, [2]string{"env", env})
+	}
+	return described
 }
@@ -0,0 +1,182 @@
+/**
+# Copyright (c) 2022, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package list
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpec = `---
+cdiVersion: 0.5.0
+kind: nvidia.com/gpu
+devices:
+  - name: "0"
+    containerEdits:
+      deviceNodes:
+        - path: /dev/nvidia0
+  - name: GPU-0123
+    containerEdits:
+      deviceNodes:
+        - path: /dev/nvidia0
+containerEdits:
+  env:
+    - NVIDIA_VISIBLE_DEVICES=void
+  deviceNodes:
+    - path: /dev/nvidiactl
+  mounts:
+    - hostPath: /usr/lib/libcuda.so.1
+      containerPath: /usr/lib/libcuda.so.1
+      options:
+        - ro
+  hooks:
+    - hookName: createContainer
+      path: /usr/bin/nvidia-cdi-hook
+      args:
+        - nvidia-cdi-hook
+        - update-ldcache
+`
+
+const otherSpec = `---
+cdiVersion: 0.5.0
+kind: example.com/device
+devices:
+  - name: dev0
+    containerEdits:
+      deviceNodes:
+        - path: /dev/example0
+`
+
+func TestList(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	specDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "nvidia.yaml"), []byte(testSpec), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "example.yaml"), []byte(otherSpec), 0600))
+
+	nvidiaSpec := filepath.Join(specDir, "nvidia.yaml")
+	exampleSpec := filepath.Join(specDir, "example.yaml")
+
+	testCases := []struct {
+		description    string
+		cfg            config
+		expectedOutput string
+	}{
+		{
+			description: "table lists all devices",
+			cfg: config{
+				format: outputFormatTable,
+			},
+			expectedOutput: "DEVICE                   SPEC\n" +
+				"example.com/device=dev0  " + exampleSpec + "\n" +
+				"nvidia.com/gpu=0         " + nvidiaSpec + "\n" +
+				"nvidia.com/gpu=GPU-0123  " + nvidiaSpec + "\n",
+		},
+		{
+			description: "json output is filtered",
+			cfg: config{
+				format:     outputFormatJSON,
+				vendor:     "nvidia.*",
+				deviceName: "GPU-*",
+			},
+			expectedOutput: `[
+  {
+    "name": "nvidia.com/gpu=GPU-0123",
+    "vendor": "nvidia.com",
+    "class": "gpu",
+    "device": "GPU-0123",
+    "spec": "` + nvidiaSpec + `"
+  }
+]
+`,
+		},
+		{
+			description: "no matching devices",
+			cfg: config{
+				format: outputFormatYAML,
+				class:  "vfio",
+			},
+			expectedOutput: "[]\n",
+		},
+		{
+			description: "yaml includes resolved edits",
+			cfg: config{
+				format:     outputFormatYAML,
+				class:      "gpu",
+				deviceName: "0",
+				showEdits:  true,
+			},
+			expectedOutput: `- class: gpu
+  device: "0"
+  edits:
+    deviceNodes:
+    - path: /dev/nvidiactl
+    - path: /dev/nvidia0
+    env:
+    - NVIDIA_VISIBLE_DEVICES=void
+    hooks:
+    - args:
+      - nvidia-cdi-hook
+      - update-ldcache
+      hookName: createContainer
+      path: /usr/bin/nvidia-cdi-hook
+    mounts:
+    - containerPath: /usr/lib/libcuda.so.1
+      hostPath: /usr/lib/libcuda.so.1
+      options:
+      - ro
+  name: nvidia.com/gpu=0
+  spec: ` + nvidiaSpec + `
+  vendor: nvidia.com
+`,
+		},
+		{
+			description: "table includes resolved edits",
+			cfg: config{
+				format:    outputFormatTable,
+				vendor:    "example.com",
+				showEdits: true,
+			},
+			expectedOutput: "DEVICE                   SPEC" + spaces(len(exampleSpec)-4+2) + "EDIT         VALUE\n" +
+				"example.com/device=dev0  " + exampleSpec + "  device-node  /dev/example0\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cfg := tc.cfg
+			cfg.cdiSpecDirs = []string{specDir}
+			require.NoError(t, c.validateFlags(&cfg))
+
+			output := &bytes.Buffer{}
+			require.NoError(t, c.run(output, &cfg))
+			require.Equal(t, tc.expectedOutput, output.String())
+		})
+	}
+}
+
+func spaces(n int) string {
+	return string(bytes.Repeat([]byte(" "), n))
+}
@@ -0,0 +1,246 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/urfave/cli/v3"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type config struct {
+	specFiles   []string
+	cdiSpecDirs []string
+}
+
+// NewCommand constructs a cdi validate command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build creates the CLI command
+func (m command) build() *cli.Command {
+	cfg := config{}
+
+	// Create the command
+	c := cli.Command{
+		Name:  "validate",
+		Usage: "Check that the device nodes, mounts, and hooks referenced by CDI specifications exist on the host",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(&cfg)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(&cfg)
+		},
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:        "spec",
+				Usage:       "specify the CDI specification files to validate. If this is not specified, the specifications in the --spec-dir directories are validated",
+				Destination: &cfg.specFiles,
+			},
+			&cli.StringSliceFlag{
+				Name:        "spec-dir",
+				Usage:       "specify the directories to scan for CDI specifications",
+				Value:       cdi.DefaultSpecDirs,
+				Destination: &cfg.cdiSpecDirs,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_SPEC_DIRS"),
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(cfg *config) error {
+	if len(cfg.specFiles) == 0 && len(cfg.cdiSpecDirs) == 0 {
+		return errors.New("at least one CDI specification file or directory must be specified")
+	}
+	return nil
+}
+
+func (m command) run(cfg *config) error {
+	specFiles := cfg.specFiles
+	if len(specFiles) == 0 {
+		specFiles = m.findSpecFiles(cfg.cdiSpecDirs)
+	}
+	if len(specFiles) == 0 {
+		return errors.New("no CDI specifications found")
+	}
+
+	var stale int
+	for _, specFile := range specFiles {
+		problems, err := validateSpecFile(specFile)
+		if err != nil {
+			return err
+		}
+		if len(problems) == 0 {
+			m.logger.Infof("%v: OK", specFile)
+			continue
+		}
+		for _, problem := range problems {
+			m.logger.Warningf("%v: %v", specFile, problem)
+		}
+		stale += len(problems)
+	}
+
+	if stale > 0 {
+		return fmt.Errorf("found %d stale entries; regenerate the affected CDI specifications", stale)
+	}
+	return nil
+}
+
+// findSpecFiles returns the CDI specification files in the specified
+// directories. Directories that do not exist are ignored.
+func (m command) findSpecFiles(dirs []string) []string {
+	var specFiles []string
+	for _, dir := range dirs {
+		for _, pattern := range []string{"*.yaml", "*.json"} {
+			matches, err := filepath.Glob(filepath.Join(dir, pattern))
+			if err != nil {
+				m.logger.Warningf("Failed to search %v: %v", dir, err)
+				continue
+			}
+			specFiles = append(specFiles, matches...)
+		}
+	}
+	sort.Strings(specFiles)
+	return specFiles
+}
+
+// validateSpecFile loads the specified CDI specification and returns a
+// description of each entry that references a path that does not exist on the
+// host.
+func validateSpecFile(path string) ([]string, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CDI specification: %w", err)
+	}
+	raw, err := cdi.ParseSpec(contents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CDI specification %v: %w", path, err)
+	}
+	return validateSpec(raw), nil
+}
+
+// validateSpec returns a description of each entry in the specified CDI
+// specification that references a path that does not exist on the host. The
+// problems for the common edits are prefixed with the kind of the spec and
+// those for a device with the fully-qualified device name.
+func validateSpec(spec *specs.Spec) []string {
+	var problems []string
+	for _, problem := range validateContainerEdits(spec.ContainerEdits) {
+		problems = append(problems, fmt.Sprintf("%v: %v", spec.Kind, problem))
+	}
+	for _, device := range spec.Devices {
+		for _, problem := range validateContainerEdits(device.ContainerEdits) {
+			problems = append(problems, fmt.Sprintf("%v=%v: %v", spec.Kind, device.Name, problem))
+		}
+	}
+	return problems
+}
+
+// validateContainerEdits checks the device nodes, mounts, and hooks in the
+// specified container edits.
+func validateContainerEdits(edits specs.ContainerEdits) []string {
+	var problems []string
+	for _, deviceNode := range edits.DeviceNodes {
+		if deviceNode == nil {
+			continue
+		}
+		if err := checkDeviceNode(deviceNode); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	for _, mount := range edits.Mounts {
+		if mount == nil || !isBindMount(mount) {
+			continue
+		}
+		if _, err := os.Stat(mount.HostPath); err != nil {
+			problems = append(problems, fmt.Sprintf("missing mount source %v", mount.HostPath))
+		}
+	}
+	for _, hook := range edits.Hooks {
+		if hook == nil {
+			continue
+		}
+		if err := checkHook(hook); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	return problems
+}
+
+// checkDeviceNode checks that the host path of the specified device node
+// exists and is a device node.
+func checkDeviceNode(deviceNode *specs.DeviceNode) error {
+	hostPath := deviceNode.HostPath
+	if hostPath == "" {
+		hostPath = deviceNode.Path
+	}
+	info, err := os.Stat(hostPath)
+	if err != nil {
+		return fmt.Errorf("missing device node %v", hostPath)
+	}
+	// TODO: We should have a better way to inject this logic than this envvar.
+	if os.Getenv("__NVCT_TESTING_DEVICES_ARE_FILES") == "true" && info.Mode().IsRegular() {
+		return nil
+	}
+	if info.Mode()&os.ModeDevice == 0 {
+		return fmt.Errorf("%v is not a device node", hostPath)
+	}
+	return nil
+}
+
+// checkHook checks that the specified hook binary exists and is executable.
+func checkHook(hook *specs.Hook) error {
+	info, err := os.Stat(hook.Path)
+	if err != nil {
+		return fmt.Errorf("missing %v hook %v", hook.HookName, hook.Path)
+	}
+	if !info.Mode().IsRegular() || info.Mode().Perm()&0111 == 0 {
+		return fmt.Errorf("%v hook %v is not executable", hook.HookName, hook.Path)
+	}
+	return nil
+}
+
+// isBindMount returns true if the specified mount is a bind mount and the host
+// path is therefore expected to exist.
+func isBindMount(mount *specs.Mount) bool {
+	switch mount.Type {
+	case "", "bind", "rbind":
+		return true
+	}
+	return slices.Contains(mount.Options, "bind") || slices.Contains(mount.Options, "rbind")
+}
@@ -0,0 +1,166 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package validate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+func TestValidateSpec(t *testing.T) {
+	t.Setenv("__NVCT_TESTING_DEVICES_ARE_FILES", "true")
+
+	root := t.TempDir()
+	deviceNode := filepath.Join(root, "dev", "nvidia0")
+	library := filepath.Join(root, "lib", "libcuda.so.1")
+	hook := filepath.Join(root, "bin", "nvidia-cdi-hook")
+	notExecutable := filepath.Join(root, "bin", "not-executable")
+	require.NoError(t, os.MkdirAll(filepath.Dir(deviceNode), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Dir(library), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Dir(hook), 0755))
+	require.NoError(t, os.WriteFile(deviceNode, nil, 0600))
+	require.NoError(t, os.WriteFile(library, nil, 0600))
+	require.NoError(t, os.WriteFile(hook, nil, 0755))
+	require.NoError(t, os.WriteFile(notExecutable, nil, 0600))
+
+	testCases := []struct {
+		description      string
+		spec             *specs.Spec
+		expectedProblems []string
+	}{
+		{
+			description: "valid spec has no problems",
+			spec: &specs.Spec{
+				Kind: "nvidia.com/gpu",
+				ContainerEdits: specs.ContainerEdits{
+					Mounts: []*specs.Mount{
+						{HostPath: library, ContainerPath: "/lib/libcuda.so.1", Options: []string{"ro", "rbind"}},
+						{HostPath: "tmpfs", ContainerPath: "/tmp", Type: "tmpfs"},
+					},
+					Hooks: []*specs.Hook{
+						{HookName: "createContainer", Path: hook},
+					},
+				},
+				Devices: []specs.Device{
+					{
+						Name: "0",
+						ContainerEdits: specs.ContainerEdits{
+							DeviceNodes: []*specs.DeviceNode{
+								{Path: "/dev/nvidia0", HostPath: deviceNode},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "missing entries are reported",
+			spec: &specs.Spec{
+				Kind: "nvidia.com/gpu",
+				ContainerEdits: specs.ContainerEdits{
+					Mounts: []*specs.Mount{
+						{HostPath: filepath.Join(root, "lib", "libcuda.so.2"), ContainerPath: "/lib/libcuda.so.2"},
+					},
+					Hooks: []*specs.Hook{
+						{HookName: "createContainer", Path: filepath.Join(root, "bin", "missing")},
+						{HookName: "createContainer", Path: notExecutable},
+					},
+				},
+				Devices: []specs.Device{
+					{
+						Name: "1",
+						ContainerEdits: specs.ContainerEdits{
+							DeviceNodes: []*specs.DeviceNode{
+								{Path: filepath.Join(root, "dev", "nvidia1")},
+								{Path: "/dev/nvidiactl", HostPath: filepath.Join(root, "lib")},
+							},
+						},
+					},
+				},
+			},
+			expectedProblems: []string{
+				"nvidia.com/gpu: missing mount source " + filepath.Join(root, "lib", "libcuda.so.2"),
+				"nvidia.com/gpu: missing createContainer hook " + filepath.Join(root, "bin", "missing"),
+				"nvidia.com/gpu: createContainer hook " + notExecutable + " is not executable",
+				"nvidia.com/gpu=1: missing device node " + filepath.Join(root, "dev", "nvidia1"),
+				"nvidia.com/gpu=1: " + filepath.Join(root, "lib") + " is not a device node",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			problems := validateSpec(tc.spec)
+			require.EqualValues(t, tc.expectedProblems, problems)
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	logger, hook := testlog.NewNullLogger()
+
+	specDir := t.TempDir()
+	cdiHook := filepath.Join(specDir, "nvidia-cdi-hook")
+	require.NoError(t, os.WriteFile(cdiHook, nil, 0755))
+
+	valid := `cdiVersion: 0.5.0
+kind: nvidia.com/gpu
+devices:
+- name: all
+  containerEdits:
+    hooks:
+    - hookName: createContainer
+      path: ` + cdiHook + `
+`
+	stale := `cdiVersion: 0.5.0
+kind: nvidia.com/gpu
+devices:
+- name: all
+  containerEdits:
+    deviceNodes:
+    - path: ` + filepath.Join(specDir, "nvidia0") + `
+`
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "valid.yaml"), []byte(valid), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "stale.yaml"), []byte(stale), 0600))
+
+	c := command{logger: logger}
+
+	err := c.run(&config{specFiles: []string{filepath.Join(specDir, "valid.yaml")}})
+	require.NoError(t, err)
+
+	hook.Reset()
+	err = c.run(&config{cdiSpecDirs: []string{specDir}})
+	require.ErrorContains(t, err, "found 1 stale entries")
+	var warnings []string
+	for _, entry := range hook.AllEntries() {
+		if entry.Level == logrus.WarnLevel {
+			warnings = append(warnings, entry.Message)
+		}
+	}
+	require.Len(t, warnings, 1)
+	require.Contains(t, warnings[0], filepath.Join(specDir, "stale.yaml"))
+
+	err = c.run(&config{cdiSpecDirs: []string{filepath.Join(specDir, "missing")}})
+	require.Error(t, err)
+}
@@ -0,0 +1,274 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package checkupdate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+	"golang.org/x/mod/semver"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+const (
+	defaultChannel = "stable"
+	defaultTimeout = 10 * time.Second
+
+	outputFormatText = "text"
+	outputFormatJSON = "json"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	manifest string
+	channel  string
+	output   string
+	timeout  time.Duration
+
+	// installedVersion is the version to compare against the channel. This
+	// is the version of the running binary and is only overridden in tests.
+	installedVersion string
+}
+
+// A manifest lists the current release of each release channel.
+type manifest struct {
+	Channels map[string]release `json:"channels"`
+}
+
+// A release describes the current release of a channel.
+type release struct {
+	Version      string `json:"version"`
+	ReleaseDate  string `json:"releaseDate,omitempty"`
+	ReleaseNotes string `json:"releaseNotes,omitempty"`
+}
+
+// A result describes whether an update is available on the requested channel.
+type result struct {
+	InstalledVersion string `json:"installedVersion"`
+	Channel          string `json:"channel"`
+	LatestVersion    string `json:"latestVersion"`
+	UpdateAvailable  bool   `json:"updateAvailable"`
+	ReleaseDate      string `json:"releaseDate,omitempty"`
+	ReleaseNotes     string `json:"releaseNotes,omitempty"`
+}
+
+// NewCommand constructs a check-update command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build creates the CLI command
+func (m command) build() *cli.Command {
+	opts := options{
+		installedVersion: info.GetVersion("").Version,
+	}
+
+	c := cli.Command{
+		Name: "check-update",
+		Usage: "Check whether a newer version of the NVIDIA Container Toolkit is available on a release channel. " +
+			"No changes are made to the system",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(&opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(ctx, os.Stdout, &opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "manifest",
+				Usage:       "the URL or path of the release channel manifest",
+				Destination: &opts.manifest,
+				Sources:     cli.EnvVars("NVIDIA_CTK_UPDATE_MANIFEST"),
+			},
+			&cli.StringFlag{
+				Name:        "channel",
+				Usage:       "the release channel to compare the installed version against",
+				Value:       defaultChannel,
+				Destination: &opts.channel,
+				Sources:     cli.EnvVars("NVIDIA_CTK_UPDATE_CHANNEL"),
+			},
+			&cli.StringFlag{
+				Name:        "output",
+				Aliases:     []string{"o"},
+				Usage:       "the output format; one of [text, json]",
+				Value:       outputFormatText,
+				Destination: &opts.output,
+			},
+			&cli.DurationFlag{
+				Name:        "timeout",
+				Usage:       "the timeout for fetching the manifest",
+				Value:       defaultTimeout,
+				Destination: &opts.timeout,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(opts *options) error {
+	if opts.manifest == "" {
+		return fmt.Errorf("a release channel manifest must be specified")
+	}
+	if opts.channel == "" {
+		return fmt.Errorf("a release channel must be specified")
+	}
+	switch opts.output {
+	case outputFormatText, outputFormatJSON:
+	default:
+		return fmt.Errorf("unrecognized output format: %v", opts.output)
+	}
+	if opts.timeout <= 0 {
+		return fmt.Errorf("the timeout must be positive")
+	}
+	return nil
+}
+
+func (m command) run(ctx context.Context, w io.Writer, opts *options) error {
+	manifest, err := m.loadManifest(ctx, opts.manifest, opts.timeout)
+	if err != nil {
+		return err
+	}
+
+	r, err := check(manifest, opts.channel, opts.installedVersion)
+	if err != nil {
+		return err
+	}
+
+	if opts.output == outputFormatJSON {
+		output, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to convert result to JSON: %v", err)
+		}
+		_, err = fmt.Fprintf(w, "%s\n", output)
+		return err
+	}
+	return r.writeText(w)
+}
+
+// check compares the installed version against the current release of the
+// specified channel.
+func check(manifest *manifest, channel string, installedVersion string) (*result, error) {
+	latest, ok := manifest.Channels[channel]
+	if !ok {
+		return nil, fmt.Errorf("release channel %q not found in manifest", channel)
+	}
+
+	installed, err := toSemver(installedVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine installed version: %w", err)
+	}
+	available, err := toSemver(latest.Version)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version for release channel %q: %w", channel, err)
+	}
+
+	r := &result{
+		InstalledVersion: installedVersion,
+		Channel:          channel,
+		LatestVersion:    latest.Version,
+		UpdateAvailable:  semver.Compare(available, installed) > 0,
+		ReleaseDate:      latest.ReleaseDate,
+		ReleaseNotes:     latest.ReleaseNotes,
+	}
+	return r, nil
+}
+
+// toSemver converts a toolkit version (e.g. 1.18.0 or 1.18.0-rc.1) to the
+// canonical semantic version used for comparisons.
+func toSemver(version string) (string, error) {
+	v := "v" + strings.TrimPrefix(version, "v")
+	if !semver.IsValid(v) {
+		return "", fmt.Errorf("%q is not a valid version", version)
+	}
+	return semver.Canonical(v), nil
+}
+
+// loadManifest reads the manifest from the specified URL or path.
+func (m command) loadManifest(ctx context.Context, location string, timeout time.Duration) (*manifest, error) {
+	var contents []byte
+	var err error
+	if u, parseErr := url.Parse(location); parseErr == nil && (u.Scheme == "http" || u.Scheme == "https") {
+		contents, err = fetch(ctx, location, timeout)
+	} else {
+		contents, err = os.ReadFile(strings.TrimPrefix(location, "file://"))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	m.logger.Debugf("Read manifest from %v", location)
+
+	var mf manifest
+	if err := json.Unmarshal(contents, &mf); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	return &mf, nil
+}
+
+// fetch returns the body of the response for the specified URL.
+func fetch(ctx context.Context, location string, timeout time.Duration) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response from %v: %v", location, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (r *result) writeText(w io.Writer) error {
+	if !r.UpdateAvailable {
+		_, err := fmt.Fprintf(w, "The installed version %v is up to date on the %v channel (latest: %v)\n", r.InstalledVersion, r.Channel, r.LatestVersion)
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "Version %v is available on the %v channel (installed: %v)\n", r.LatestVersion, r.Channel, r.InstalledVersion); err != nil {
+		return err
+	}
+	if r.ReleaseNotes != "" {
+		if _, err := fmt.Fprintf(w, "Release notes: %v\n", r.ReleaseNotes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
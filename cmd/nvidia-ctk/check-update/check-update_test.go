@@ -0,0 +1,207 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package checkupdate
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+const testManifest = `{
+  "channels": {
+    "stable": {"version": "1.18.0", "releaseNotes": "https://example.com/1.18.0"},
+    "experimental": {"version": "1.19.0-rc.1"}
+  }
+}`
+
+func TestCheck(t *testing.T) {
+	m := &manifest{
+		Channels: map[string]release{
+			"stable":       {Version: "1.18.0", ReleaseNotes: "https://example.com/1.18.0"},
+			"experimental": {Version: "1.19.0-rc.1"},
+			"invalid":      {Version: "latest"},
+		},
+	}
+
+	testCases := []struct {
+		description      string
+		channel          string
+		installedVersion string
+		expectedError    bool
+		expectedResult   *result
+	}{
+		{
+			description:      "older version has update",
+			channel:          "stable",
+			installedVersion: "1.17.8",
+			expectedResult: &result{
+				InstalledVersion: "1.17.8",
+				Channel:          "stable",
+				LatestVersion:    "1.18.0",
+				UpdateAvailable:  true,
+				ReleaseNotes:     "https://example.com/1.18.0",
+			},
+		},
+		{
+			description:      "same version is up to date",
+			channel:          "stable",
+			installedVersion: "1.18.0",
+			expectedResult: &result{
+				InstalledVersion: "1.18.0",
+				Channel:          "stable",
+				LatestVersion:    "1.18.0",
+				ReleaseNotes:     "https://example.com/1.18.0",
+			},
+		},
+		{
+			description:      "release candidate is older than release",
+			channel:          "experimental",
+			installedVersion: "1.19.0-rc.1",
+			expectedResult: &result{
+				InstalledVersion: "1.19.0-rc.1",
+				Channel:          "experimental",
+				LatestVersion:    "1.19.0-rc.1",
+			},
+		},
+		{
+			description:      "newer version is up to date",
+			channel:          "experimental",
+			installedVersion: "1.19.0",
+			expectedResult: &result{
+				InstalledVersion: "1.19.0",
+				Channel:          "experimental",
+				LatestVersion:    "1.19.0-rc.1",
+			},
+		},
+		{
+			description:      "unknown channel returns error",
+			channel:          "nightly",
+			installedVersion: "1.18.0",
+			expectedError:    true,
+		},
+		{
+			description:      "unknown installed version returns error",
+			channel:          "stable",
+			installedVersion: "unknown",
+			expectedError:    true,
+		},
+		{
+			description:      "invalid channel version returns error",
+			channel:          "invalid",
+			installedVersion: "1.18.0",
+			expectedError:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := check(m, tc.channel, tc.installedVersion)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedResult, r)
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/channels.json" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(testManifest))
+	}))
+	defer server.Close()
+
+	manifestFile := filepath.Join(t.TempDir(), "channels.json")
+	require.NoError(t, os.WriteFile(manifestFile, []byte(testManifest), 0600))
+
+	testCases := []struct {
+		description    string
+		opts           options
+		expectedError  bool
+		expectedOutput string
+	}{
+		{
+			description: "manifest from URL as text",
+			opts: options{
+				manifest:         server.URL + "/channels.json",
+				channel:          "stable",
+				output:           outputFormatText,
+				installedVersion: "1.17.8",
+			},
+			expectedOutput: "Version 1.18.0 is available on the stable channel (installed: 1.17.8)\nRelease notes: https://example.com/1.18.0\n",
+		},
+		{
+			description: "manifest from file as JSON",
+			opts: options{
+				manifest:         manifestFile,
+				channel:          "experimental",
+				output:           outputFormatJSON,
+				installedVersion: "1.19.0",
+			},
+			expectedOutput: `{
+  "installedVersion": "1.19.0",
+  "channel": "experimental",
+  "latestVersion": "1.19.0-rc.1",
+  "updateAvailable": false
+}
+`,
+		},
+		{
+			description: "missing manifest returns error",
+			opts: options{
+				manifest:         server.URL + "/missing.json",
+				channel:          "stable",
+				output:           outputFormatText,
+				installedVersion: "1.17.8",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c := command{logger: logger}
+			tc.opts.timeout = 5 * time.Second
+
+			output := &bytes.Buffer{}
+			err := c.run(context.Background(), output, &tc.opts)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedOutput, output.String())
+		})
+	}
+}
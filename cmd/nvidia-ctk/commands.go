@@ -0,0 +1,63 @@
+//go:build !windows
+
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package main
+
+import (
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/bootstrap"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi"
+	checkupdate "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/check-update"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/csv"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/daemon"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/docs"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/generate"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/hook"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/image"
+	infoCLI "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/info"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/migrate"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/runtime"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/setup"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/system"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/uninstall"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+func getCommands(logger logger.Interface, configFilePath *string) []*cli.Command {
+	return []*cli.Command{
+		hook.NewCommand(logger),
+		runtime.NewCommand(logger),
+		infoCLI.NewCommand(logger),
+		cdi.NewCommand(logger, configFilePath),
+		system.NewCommand(logger),
+		config.NewCommand(logger),
+		csv.NewCommand(logger),
+		generate.NewCommand(logger, configFilePath),
+		daemon.NewCommand(logger, configFilePath),
+		migrate.NewCommand(logger),
+		uninstall.NewCommand(logger, configFilePath),
+		docs.NewCommand(logger),
+		image.NewCommand(logger),
+		bootstrap.NewCommand(logger, configFilePath),
+		setup.NewCommand(logger, configFilePath),
+		checkupdate.NewCommand(logger),
+	}
+}
@@ -0,0 +1,134 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package completion
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+const bashCompletionTemplate = `#!/bin/bash
+# bash completion for %[1]s
+
+__%[2]s_bash_autocomplete() {
+  if [[ "${COMP_WORDS[0]}" != "source" ]]; then
+    local cur opts words cword
+    COMPREPLY=()
+    if declare -F _init_completion >/dev/null 2>&1; then
+      _init_completion -n "=:" || return
+    else
+      cur="${COMP_WORDS[COMP_CWORD]}"
+      words=("${COMP_WORDS[@]}")
+      cword=${COMP_CWORD}
+    fi
+    words=("${words[@]:0:$cword}")
+    if [[ "$cur" == "-"* ]]; then
+      requestComp="${words[*]} ${cur} --generate-shell-completion"
+    else
+      requestComp="${words[*]} --generate-shell-completion"
+    fi
+    opts=$(eval "${requestComp}" 2>/dev/null)
+    COMPREPLY=($(compgen -W "${opts}" -- ${cur}))
+    return 0
+  fi
+}
+
+complete -o bashdefault -o default -o nospace -F __%[2]s_bash_autocomplete %[1]s
+`
+
+const zshCompletionTemplate = `#compdef %[1]s
+# zsh completion for %[1]s
+
+_%[2]s() {
+	local -a opts
+	local current
+	current=${words[-1]}
+	if [[ "$current" == "-"* ]]; then
+		opts=("${(@f)$(${words[@]:0:#words[@]-1} ${current} --generate-shell-completion)}")
+	else
+		opts=("${(@f)$(${words[@]:0:#words[@]-1} --generate-shell-completion)}")
+	fi
+
+	if [[ "${opts[1]}" != "" ]]; then
+		_describe 'values' opts
+	else
+		_files
+	fi
+}
+
+if [ "$funcstack[1]" = "_%[2]s" ]; then
+	_%[2]s
+fi
+`
+
+// Configure returns a function that configures the shell completion command
+// that is added to a CLI with shell completion enabled. The generated scripts
+// complete the specified program name instead of the (descriptive) name of
+// the root command.
+func Configure(programName string) func(*cli.Command) {
+	return func(c *cli.Command) {
+		c.Hidden = false
+		c.Usage = "Output the shell completion script for bash, zsh, or fish"
+		c.ArgsUsage = "bash|zsh|fish"
+		c.Description = fmt.Sprintf(`Output the shell completion script for the specified shell. For example:
+
+# bash
+source <(%[1]s completion bash)
+
+# zsh
+%[1]s completion zsh > "${fpath[1]}/_%[1]s"
+
+# fish
+%[1]s completion fish > ~/.config/fish/completions/%[1]s.fish`, programName)
+		c.Action = func(ctx context.Context, cmd *cli.Command) error {
+			script, err := render(cmd.Root(), programName, cmd.Args().First())
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprint(cmd.Root().Writer, script)
+			return err
+		}
+	}
+}
+
+// render returns the completion script for the specified shell.
+func render(root *cli.Command, programName string, shell string) (string, error) {
+	functionName := strings.NewReplacer("-", "_", ".", "_").Replace(programName)
+	switch shell {
+	case "bash":
+		return fmt.Sprintf(bashCompletionTemplate, programName, functionName), nil
+	case "zsh":
+		return fmt.Sprintf(zshCompletionTemplate, programName, functionName), nil
+	case "fish":
+		// The fish completions are generated for the name of the root command
+		// and we override this with the program name.
+		name := root.Name
+		root.Name = programName
+		defer func() {
+			root.Name = name
+		}()
+		return root.ToFishCompletion()
+	case "":
+		return "", fmt.Errorf("a shell must be specified; one of [bash, zsh, fish]")
+	default:
+		return "", fmt.Errorf("unsupported shell %q; one of [bash, zsh, fish]", shell)
+	}
+}
@@ -0,0 +1,95 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package completion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+func TestRender(t *testing.T) {
+	testCases := []struct {
+		description      string
+		shell            string
+		expectedError    bool
+		expectedContents []string
+	}{
+		{
+			description: "bash completes program name",
+			shell:       "bash",
+			expectedContents: []string{
+				"__nvidia_ctk_bash_autocomplete()",
+				"complete -o bashdefault -o default -o nospace -F __nvidia_ctk_bash_autocomplete nvidia-ctk",
+				"--generate-shell-completion",
+			},
+		},
+		{
+			description: "zsh completes program name",
+			shell:       "zsh",
+			expectedContents: []string{
+				"#compdef nvidia-ctk",
+				"_nvidia_ctk()",
+			},
+		},
+		{
+			description: "fish completes program name and subcommands",
+			shell:       "fish",
+			expectedContents: []string{
+				"complete -x -c nvidia-ctk",
+				"-a 'runtime'",
+			},
+		},
+		{
+			description:   "missing shell returns error",
+			expectedError: true,
+		},
+		{
+			description:   "unsupported shell returns error",
+			shell:         "powershell",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			root := &cli.Command{
+				Name: "NVIDIA Container Toolkit CLI",
+				Commands: []*cli.Command{
+					{
+						Name:  "runtime",
+						Usage: "Runtime utilities",
+					},
+				},
+			}
+
+			script, err := render(root, "nvidia-ctk", tc.shell)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			for _, expected := range tc.expectedContents {
+				require.Contains(t, script, expected)
+			}
+			require.Equal(t, "NVIDIA Container Toolkit CLI", root.Name)
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"reflect"
 	"strconv"
 	"strings"
@@ -28,6 +29,7 @@ import (
 
 	createdefault "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/config/create-default"
 	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/config/flags"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/changeset"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 )
@@ -41,6 +43,7 @@ type options struct {
 	flags.Options
 	setListSeparator string
 	sets             []string
+	dryRun           bool
 }
 
 // NewCommand constructs an config command with the specified logger
@@ -106,6 +109,11 @@ func (m command) build() *cli.Command {
 				Usage:       "Specify the output file to write to; If not specified, the output is written to stdout",
 				Destination: &opts.Output,
 			},
+			&cli.BoolFlag{
+				Name:        "dry-run",
+				Usage:       "Write the changes that would be made to the config as a JSON change set to stdout instead of writing the config",
+				Destination: &opts.dryRun,
+			},
 		},
 	}
 
@@ -119,7 +127,21 @@ func (m command) validateFlags(opts *options) error {
 	return nil
 }
 
-func (m command) run(opts *options) error {
+func (m command) run(opts *options) (rerr error) {
+	// The lock is held while the config is loaded and saved so that changes
+	// made concurrently by other components are not lost.
+	if !opts.dryRun {
+		lock, err := opts.LockOutput()
+		if err != nil {
+			return fmt.Errorf("failed to lock output file: %v", err)
+		}
+		defer func() {
+			if err := lock.Unlock(); err != nil && rerr == nil {
+				rerr = fmt.Errorf("failed to unlock output file: %v", err)
+			}
+		}()
+	}
+
 	cfgToml, err := config.New(
 		config.WithConfigFile(opts.Config),
 	)
@@ -127,11 +149,17 @@ func (m command) run(opts *options) error {
 		return fmt.Errorf("unable to create config: %v", err)
 	}
 
+	var keys []string
+	originals := make(map[string]interface{})
 	for _, set := range opts.sets {
 		key, value, err := setFlagToKeyValue(set, opts.setListSeparator)
 		if err != nil {
 			return fmt.Errorf("invalid --set option %v: %w", set, err)
 		}
+		if _, ok := originals[key]; !ok {
+			keys = append(keys, key)
+			originals[key] = cfgToml.Get(key)
+		}
 		if value == nil {
 			_ = cfgToml.Delete(key)
 		} else {
@@ -139,6 +167,19 @@ func (m command) run(opts *options) error {
 		}
 	}
 
+	if opts.dryRun {
+		path := opts.GetOutput()
+		if path == "" {
+			path = opts.Config
+		}
+		c := changeset.New(true)
+		for _, key := range keys {
+			c.Add(getChanges(path, key, originals[key], cfgToml.Get(key))...)
+		}
+		_, err := c.WriteTo(os.Stdout)
+		return err
+	}
+
 	if err := opts.EnsureOutputFolder(); err != nil {
 		return fmt.Errorf("failed to create output directory: %v", err)
 	}
@@ -155,6 +196,31 @@ func (m command) run(opts *options) error {
 	return nil
 }
 
+// getChanges returns the change to the specified config option given its
+// original and updated values. A nil value indicates that the option is not
+// set.
+func getChanges(path string, key string, from interface{}, to interface{}) []changeset.Change {
+	change := changeset.Change{
+		Path: path,
+		Key:  key,
+		From: from,
+		To:   to,
+	}
+	switch {
+	case from == nil && to == nil:
+		return nil
+	case from == nil:
+		change.Action = changeset.ActionCreate
+	case to == nil:
+		change.Action = changeset.ActionDelete
+	case fmt.Sprintf("%v", from) == fmt.Sprintf("%v", to):
+		return nil
+	default:
+		change.Action = changeset.ActionUpdate
+	}
+	return []changeset.Change{change}
+}
+
 var errInvalidConfigOption = errors.New("invalid config option")
 var errUndefinedField = errors.New("undefined field")
 var errInvalidFormat = errors.New("invalid format")
@@ -20,6 +20,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/changeset"
 )
 
 func TestSetFlagToKeyValue(t *testing.T) {
@@ -141,3 +143,49 @@ func TestSetFlagToKeyValue(t *testing.T) {
 		})
 	}
 }
+
+func TestGetChanges(t *testing.T) {
+	testCases := []struct {
+		description     string
+		from            interface{}
+		to              interface{}
+		expectedChanges []changeset.Change
+	}{
+		{
+			description: "unset option is not changed",
+		},
+		{
+			description: "new option is created",
+			to:          "cdi",
+			expectedChanges: []changeset.Change{
+				{Action: changeset.ActionCreate, Path: "config.toml", Key: "key", To: "cdi"},
+			},
+		},
+		{
+			description: "modified option is updated",
+			from:        "auto",
+			to:          "cdi",
+			expectedChanges: []changeset.Change{
+				{Action: changeset.ActionUpdate, Path: "config.toml", Key: "key", From: "auto", To: "cdi"},
+			},
+		},
+		{
+			description: "equal lists are not changed",
+			from:        []interface{}{"a", "b"},
+			to:          []string{"a", "b"},
+		},
+		{
+			description: "removed option is deleted",
+			from:        true,
+			expectedChanges: []changeset.Change{
+				{Action: changeset.ActionDelete, Path: "config.toml", Key: "key", From: true},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.EqualValues(t, tc.expectedChanges, getChanges("config.toml", "key", tc.from, tc.to))
+		})
+	}
+}
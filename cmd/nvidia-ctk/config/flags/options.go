@@ -17,10 +17,13 @@
 package flags
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/filelock"
 )
 
 // Options stores options for the config commands
@@ -61,14 +64,51 @@ func (o Options) EnsureOutputFolder() error {
 	return nil
 }
 
-// CreateOutput creates the writer for the output.
+// LockOutput acquires an exclusive lock for the output file. This allows a
+// config to be loaded, updated, and saved without being modified concurrently
+// by another component. If the output is STDOUT, no lock is acquired. The
+// lock must be released by calling Unlock.
+func (o Options) LockOutput() (*filelock.Lock, error) {
+	output := o.GetOutput()
+	if output == "" {
+		return nil, nil
+	}
+	return filelock.Acquire(output)
+}
+
+// CreateOutput creates the writer for the output. An exclusive lock is held
+// for the output file until the writer is closed unless the lock is already
+// held by the caller.
 func (o Options) CreateOutput() (io.WriteCloser, error) {
 	output := o.GetOutput()
 	if output == "" {
 		return nullCloser{os.Stdout}, nil
 	}
 
-	return os.Create(output)
+	var lock *filelock.Lock
+	if !filelock.IsHeld(output) {
+		l, err := filelock.Acquire(output)
+		if err != nil {
+			return nil, err
+		}
+		lock = l
+	}
+	f, err := os.Create(output)
+	if err != nil {
+		return nil, errors.Join(err, lock.Unlock())
+	}
+	return &lockedFile{File: f, lock: lock}, nil
+}
+
+// A lockedFile is a file that is unlocked when it is closed.
+type lockedFile struct {
+	*os.File
+	lock *filelock.Lock
+}
+
+// Close closes the file and releases the lock.
+func (f *lockedFile) Close() error {
+	return errors.Join(f.File.Close(), f.lock.Unlock())
 }
 
 // nullCloser is a writer that does nothing on Close.
@@ -0,0 +1,63 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package flags
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/filelock"
+)
+
+func TestCreateOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	opts := Options{Output: path}
+
+	output, err := opts.CreateOutput()
+	require.NoError(t, err)
+	require.True(t, filelock.IsHeld(path))
+	_, err = output.Write([]byte("test"))
+	require.NoError(t, err)
+	require.NoError(t, output.Close())
+	require.False(t, filelock.IsHeld(path))
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "test", string(contents))
+
+	// If the caller holds the lock, the lock is not acquired again and is
+	// still held once the output is closed.
+	lock, err := opts.LockOutput()
+	require.NoError(t, err)
+	output, err = opts.CreateOutput()
+	require.NoError(t, err)
+	require.NoError(t, output.Close())
+	require.True(t, filelock.IsHeld(path))
+	require.NoError(t, lock.Unlock())
+	require.False(t, filelock.IsHeld(path))
+}
+
+func TestLockOutputForStdout(t *testing.T) {
+	lock, err := Options{}.LockOutput()
+	require.NoError(t, err)
+	require.Nil(t, lock)
+	require.NoError(t, lock.Unlock())
+}
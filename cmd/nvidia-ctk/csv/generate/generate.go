@@ -0,0 +1,113 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/platform-support/tegra/csv"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	root     string
+	template string
+	output   string
+}
+
+// NewCommand constructs a generate command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name: "generate",
+		Usage: "Generate a CSV mount specification from a template or, if no template is specified, from the installed Jetson BSP. " +
+			"Each line of a template is of the form [TYPE,] PATTERN where PATTERN is a glob that is expanded at the root. " +
+			"If TYPE is omitted or set to auto, the type of each match is inferred",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(&opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "root",
+				Usage:       "the root at which the template patterns are expanded",
+				Value:       "/",
+				Destination: &opts.root,
+				Sources:     cli.EnvVars("NVIDIA_DRIVER_ROOT", "DRIVER_ROOT"),
+			},
+			&cli.StringFlag{
+				Name:        "template",
+				Usage:       "the template to generate the CSV file from",
+				Destination: &opts.template,
+			},
+			&cli.StringFlag{
+				Name:        "output",
+				Usage:       "the file to write the generated CSV file to. If this is not specified, the output is written to STDOUT",
+				Destination: &opts.output,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) run(opts *options) error {
+	var parser csv.Parser
+	if opts.template != "" {
+		parser = csv.NewTemplateParser(m.logger, opts.root, opts.template)
+	} else {
+		m.logger.Infof("No template specified; generating CSV file from the installed BSP")
+		parser = csv.NewBSPParser(m.logger, opts.root)
+	}
+
+	specs, err := parser.Parse()
+	if err != nil {
+		return fmt.Errorf("failed to generate mount specs: %w", err)
+	}
+	if len(specs) == 0 {
+		m.logger.Warningf("No mount specs generated")
+	}
+
+	var w io.Writer = os.Stdout
+	if opts.output != "" {
+		f, err := os.Create(opts.output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+	return csv.WriteMountSpecs(w, specs)
+}
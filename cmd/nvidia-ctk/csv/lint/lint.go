@@ -0,0 +1,124 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package lint
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/platform-support/tegra/csv"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	root   string
+	strict bool
+	files  []string
+}
+
+// NewCommand constructs a lint command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name:      "lint",
+		Usage:     "Check CSV mount specifications for malformed entries and entries that do not match the files at the root",
+		ArgsUsage: "[CSV_FILE...]",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			opts.files = cmd.Args().Slice()
+			return ctx, m.validateFlags(&opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(os.Stdout, &opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "root",
+				Usage:       "the root at which the paths in the CSV files are checked",
+				Value:       "/",
+				Destination: &opts.root,
+				Sources:     cli.EnvVars("NVIDIA_DRIVER_ROOT", "DRIVER_ROOT"),
+			},
+			&cli.BoolFlag{
+				Name:        "strict",
+				Usage:       "treat warnings (e.g. missing paths or duplicate entries) as errors",
+				Destination: &opts.strict,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(opts *options) error {
+	if len(opts.files) > 0 {
+		return nil
+	}
+	files, err := csv.GetFileList(csv.DefaultMountSpecPath)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no CSV files specified and none found in %v", csv.DefaultMountSpecPath)
+	}
+	opts.files = files
+	return nil
+}
+
+func (m command) run(w io.Writer, opts *options) error {
+	linter := csv.NewLinter(opts.root)
+
+	var errors, warnings int
+	for _, file := range opts.files {
+		issues, err := linter.LintFile(file)
+		if err != nil {
+			return err
+		}
+		for _, issue := range issues {
+			fmt.Fprintln(w, issue.String())
+			switch issue.Severity {
+			case csv.SeverityError:
+				errors++
+			case csv.SeverityWarning:
+				warnings++
+			}
+		}
+	}
+
+	m.logger.Infof("Checked %d file(s): %d error(s), %d warning(s)", len(opts.files), errors, warnings)
+	if errors > 0 || (opts.strict && warnings > 0) {
+		return fmt.Errorf("found %d error(s) and %d warning(s)", errors, warnings)
+	}
+	return nil
+}
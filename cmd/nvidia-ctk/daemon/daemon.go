@@ -0,0 +1,116 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/daemon"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+type command struct {
+	logger         logger.Interface
+	configFilePath *string
+}
+
+type options struct {
+	socketPath  string
+	httpAddress string
+}
+
+// NewCommand constructs a daemon command with the specified logger
+func NewCommand(logger logger.Interface, configFilePath *string) *cli.Command {
+	c := command{
+		logger:         logger,
+		configFilePath: configFilePath,
+	}
+	return c.build()
+}
+
+// build creates the CLI command
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name: "daemon",
+		Usage: "Run a long-running daemon that exposes the ModifySpec, ListDevices, and GenerateSpec methods " +
+			"as a gRPC service on a unix socket",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(ctx, &opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "socket",
+				Usage:       "the path of the unix socket to listen on",
+				Value:       daemon.DefaultSocketPath,
+				Destination: &opts.socketPath,
+				Sources:     cli.EnvVars("NVIDIA_CTK_DAEMON_SOCKET"),
+			},
+			&cli.StringFlag{
+				Name: "http-address",
+				Usage: "if set, serve the generated CDI spec (/v1/cdi/spec) and device inventory (/v1/devices) on a read-only HTTP endpoint. " +
+					"This is either a unix socket (unix://PATH) or a loopback address (e.g. 127.0.0.1:8080)",
+				Destination: &opts.httpAddress,
+				Sources:     cli.EnvVars("NVIDIA_CTK_DAEMON_HTTP_ADDRESS"),
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) run(ctx context.Context, opts *options) error {
+	configFilePath := *m.configFilePath
+	if configFilePath == "" {
+		configFilePath = config.GetConfigFilePath()
+	}
+	cfgToml, err := config.New(config.WithConfigFile(configFilePath))
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	cfg, err := cfgToml.Config()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	// We resolve the paths as the NVIDIA Container Runtime does to ensure that
+	// the modifications match.
+	//nolint:staticcheck  // TODO(elezar): We should swith the nvidia-container-runtime from using nvidia-ctk to using nvidia-cdi-hook.
+	cfg.NVIDIACTKConfig.Path = config.ResolveNVIDIACTKPath(m.logger, cfg.NVIDIACTKConfig.Path)
+	cfg.NVIDIAContainerRuntimeHookConfig.Path = config.ResolveNVIDIAContainerRuntimeHookPath(m.logger, cfg.NVIDIAContainerRuntimeHookConfig.Path)
+
+	server, err := daemon.NewServer(cfg,
+		daemon.WithLogger(m.logger),
+		daemon.WithSocketPath(opts.socketPath),
+		daemon.WithHTTPAddress(opts.httpAddress),
+	)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	return server.Serve(ctx)
+}
@@ -0,0 +1,71 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package docs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+// NewCommand constructs a docs command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build
+func (m command) build() *cli.Command {
+	// Create the 'docs' command
+	c := cli.Command{
+		Name:  "docs",
+		Usage: "Generate documentation from the CLI definitions",
+	}
+
+	c.Commands = []*cli.Command{
+		m.buildMan(),
+	}
+
+	return &c
+}
+
+func (m command) buildMan() *cli.Command {
+	c := cli.Command{
+		Name:  "man",
+		Usage: "Output a man page (in roff format) for the CLI",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			page, err := toMan(cmd.Root(), programName)
+			if err != nil {
+				return fmt.Errorf("failed to generate man page: %w", err)
+			}
+			_, err = fmt.Fprint(cmd.Root().Writer, page)
+			return err
+		},
+	}
+
+	return &c
+}
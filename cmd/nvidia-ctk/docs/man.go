@@ -0,0 +1,148 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package docs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+const programName = "nvidia-ctk"
+
+// toMan renders a man page for the specified root command. The page includes
+// all visible commands and flags. Note that no date is included to ensure
+// that the generated output is reproducible.
+func toMan(root *cli.Command, name string) (string, error) {
+	var w strings.Builder
+
+	fmt.Fprintf(&w, ".TH %s 1\n", strings.ToUpper(name))
+
+	w.WriteString(".SH NAME\n")
+	fmt.Fprintf(&w, "%s \\- %s\n", escape(name), escape(root.Usage))
+
+	w.WriteString(".SH SYNOPSIS\n")
+	fmt.Fprintf(&w, ".B %s\n", escape(name))
+	w.WriteString("[GLOBAL OPTIONS] COMMAND [COMMAND OPTIONS] [ARGUMENTS...]\n")
+
+	if root.Description != "" {
+		w.WriteString(".SH DESCRIPTION\n")
+		fmt.Fprintf(&w, "%s\n", escapeLines(root.Description))
+	}
+
+	if flags := root.VisibleFlags(); len(flags) > 0 {
+		w.WriteString(".SH GLOBAL OPTIONS\n")
+		writeFlags(&w, flags)
+	}
+
+	if commands := root.VisibleCommands(); len(commands) > 0 {
+		w.WriteString(".SH COMMANDS\n")
+		for _, c := range commands {
+			if c.Name == "help" {
+				continue
+			}
+			writeCommand(&w, name, c)
+		}
+	}
+
+	return w.String(), nil
+}
+
+// writeCommand writes the section for the specified command and its visible
+// subcommands.
+func writeCommand(w *strings.Builder, parent string, c *cli.Command) {
+	name := parent + " " + c.Name
+	fmt.Fprintf(w, ".SS %s\n", escape(name))
+	if len(c.Aliases) > 0 {
+		fmt.Fprintf(w, "Aliases: %s\n.PP\n", escape(strings.Join(c.Aliases, ", ")))
+	}
+	if c.Usage != "" {
+		fmt.Fprintf(w, "%s\n", escape(c.Usage))
+	}
+	if c.ArgsUsage != "" {
+		fmt.Fprintf(w, ".PP\nArguments: %s\n", escape(c.ArgsUsage))
+	}
+	if c.Description != "" {
+		fmt.Fprintf(w, ".PP\n.nf\n%s\n.fi\n", escapeLines(c.Description))
+	}
+	if flags := c.VisibleFlags(); len(flags) > 0 {
+		w.WriteString(".PP\nOptions:\n")
+		writeFlags(w, flags)
+	}
+	for _, sub := range c.VisibleCommands() {
+		if sub.Name == "help" {
+			continue
+		}
+		writeCommand(w, name, sub)
+	}
+}
+
+// writeFlags writes a tagged paragraph for each of the specified flags.
+func writeFlags(w *strings.Builder, flags []cli.Flag) {
+	for _, flag := range flags {
+		var names []string
+		for _, n := range flag.Names() {
+			if len(n) == 1 {
+				names = append(names, "-"+n)
+			} else {
+				names = append(names, "--"+n)
+			}
+		}
+		tag := "\\fB" + escape(strings.Join(names, ", ")) + "\\fR"
+
+		var usage string
+		if df, ok := flag.(cli.DocGenerationFlag); ok {
+			if df.TakesValue() {
+				tag += "=\\fIvalue\\fR"
+			}
+			usage = df.GetUsage()
+			if df.TakesValue() && df.IsDefaultVisible() {
+				if d := df.GetDefaultText(); d != "" && d != `""` {
+					usage += fmt.Sprintf(" (default: %s)", d)
+				}
+			}
+			if envVars := df.GetEnvVars(); len(envVars) > 0 {
+				usage += fmt.Sprintf(" [$%s]", strings.Join(envVars, ", $"))
+			}
+		}
+
+		fmt.Fprintf(w, ".TP\n%s\n%s\n", tag, escape(usage))
+	}
+}
+
+// escapeLines escapes each line of the specified text for roff.
+func escapeLines(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	for i, line := range lines {
+		lines[i] = escape(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// escape escapes the specified text so that it is rendered as-is by roff.
+// Backslashes are replaced and lines starting with a control character are
+// prefixed with a zero-width character.
+func escape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\e`)
+	s = strings.ReplaceAll(s, "\n", " ")
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
+	return s
+}
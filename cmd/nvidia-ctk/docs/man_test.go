@@ -0,0 +1,124 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package docs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+func TestToMan(t *testing.T) {
+	root := &cli.Command{
+		Name:  "NVIDIA Container Toolkit CLI",
+		Usage: "Tools to configure the NVIDIA Container Toolkit",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "debug",
+				Aliases: []string{"d"},
+				Usage:   "Enable debug-level logging",
+				Sources: cli.EnvVars("NVIDIA_CTK_DEBUG"),
+			},
+		},
+		Commands: []*cli.Command{
+			{
+				Name:  "runtime",
+				Usage: "A collection of runtime-related utilities",
+				Commands: []*cli.Command{
+					{
+						Name:  "configure",
+						Usage: `Add a runtime to the .config and escape \ characters`,
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "runtime",
+								Usage: "the target runtime engine",
+								Value: "docker",
+							},
+						},
+					},
+				},
+			},
+			{
+				Name:   "hidden",
+				Hidden: true,
+			},
+		},
+	}
+
+	page, err := toMan(root, "nvidia-ctk")
+	require.NoError(t, err)
+
+	expected := `.TH NVIDIA-CTK 1
+.SH NAME
+nvidia-ctk \- Tools to configure the NVIDIA Container Toolkit
+.SH SYNOPSIS
+.B nvidia-ctk
+[GLOBAL OPTIONS] COMMAND [COMMAND OPTIONS] [ARGUMENTS...]
+.SH GLOBAL OPTIONS
+.TP
+\fB--debug, -d\fR
+Enable debug-level logging [$NVIDIA_CTK_DEBUG]
+.SH COMMANDS
+.SS nvidia-ctk runtime
+A collection of runtime-related utilities
+.SS nvidia-ctk runtime configure
+Add a runtime to the .config and escape \e characters
+.PP
+Options:
+.TP
+\fB--runtime\fR=\fIvalue\fR
+the target runtime engine (default: "docker")
+`
+	require.Equal(t, expected, page)
+}
+
+func TestEscape(t *testing.T) {
+	testCases := []struct {
+		description string
+		input       string
+		expected    string
+	}{
+		{
+			description: "plain text is unchanged",
+			input:       "plain text",
+			expected:    "plain text",
+		},
+		{
+			description: "backslashes are escaped",
+			input:       `C:\path`,
+			expected:    `C:\epath`,
+		},
+		{
+			description: "leading control characters are escaped",
+			input:       ".hidden",
+			expected:    `\&.hidden`,
+		},
+		{
+			description: "leading quote is escaped",
+			input:       "'quoted'",
+			expected:    `\&'quoted'`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expected, escape(tc.input))
+		})
+	}
+}
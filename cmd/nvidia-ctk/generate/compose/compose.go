@@ -0,0 +1,260 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package compose
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+	"tags.cncf.io/container-device-interface/pkg/parser"
+
+	cdigenerate "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi/generate"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+const (
+	formatCompose        = "compose"
+	formatDeviceRequests = "device-requests"
+
+	driverCDI    = "cdi"
+	driverNVIDIA = "nvidia"
+)
+
+type configValueSource interface {
+	ValueFrom(string) cli.ValueSource
+}
+
+type command struct {
+	logger logger.Interface
+	config configValueSource
+}
+
+type options struct {
+	service string
+	devices []string
+	mode    string
+	cdiKind string
+	format  string
+	output  string
+}
+
+// A deviceRequest represents a request for devices as supported by the Docker
+// Engine API and the deploy.resources.reservations.devices field of a Compose
+// file.
+type deviceRequest struct {
+	Driver       string     `json:"Driver"`
+	Count        int        `json:"Count,omitempty"`
+	DeviceIDs    []string   `json:"DeviceIDs,omitempty"`
+	Capabilities [][]string `json:"Capabilities"`
+}
+
+// NewCommand constructs a compose command with the specified logger
+func NewCommand(logger logger.Interface, configFilePath *string) *cli.Command {
+	c := command{
+		logger: logger,
+		config: cdigenerate.New(configFilePath),
+	}
+	return c.build()
+}
+
+// build creates the CLI command
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name:  "compose",
+		Usage: "Generate the Compose (or Docker Engine API) device request for accessing the specified GPUs",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(&opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(&opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "service",
+				Usage:       "the name of the service in the generated Compose snippet",
+				Value:       "app",
+				Destination: &opts.service,
+			},
+			&cli.StringSliceFlag{
+				Name:        "devices",
+				Usage:       "the GPUs to request. These are either indices, UUIDs, or all",
+				Value:       []string{"all"},
+				Destination: &opts.devices,
+			},
+			&cli.StringFlag{
+				Name: "mode",
+				Usage: "the mode of the NVIDIA Container Runtime on the host; one of [auto | legacy | csv | cdi | jit-cdi]. " +
+					"If set to 'cdi', CDI device names are requested using the cdi driver instead of the nvidia driver.",
+				Value:       "auto",
+				Destination: &opts.mode,
+				Sources: cli.NewValueSourceChain(
+					m.config.ValueFrom("nvidia-container-runtime.mode"),
+				),
+			},
+			&cli.StringFlag{
+				Name:        "cdi-kind",
+				Usage:       "the vendor and class of the CDI devices requested in cdi mode",
+				Value:       "nvidia.com/gpu",
+				Destination: &opts.cdiKind,
+			},
+			&cli.StringFlag{
+				Name:        "format",
+				Usage:       "the output format; one of [" + formatCompose + " | " + formatDeviceRequests + "]",
+				Value:       formatCompose,
+				Destination: &opts.format,
+			},
+			&cli.StringFlag{
+				Name:        "output",
+				Usage:       "the file to write the output to. If this is not specified, the output is written to STDOUT",
+				Destination: &opts.output,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(opts *options) error {
+	if len(opts.devices) == 0 {
+		return fmt.Errorf("at least one device must be specified")
+	}
+	switch opts.format {
+	case formatCompose, formatDeviceRequests:
+	default:
+		return fmt.Errorf("invalid format %q", opts.format)
+	}
+	if opts.format == formatCompose && opts.service == "" {
+		return fmt.Errorf("a service name must be specified")
+	}
+
+	vendor, class := parser.ParseQualifier(opts.cdiKind)
+	if err := parser.ValidateVendorName(vendor); err != nil {
+		return fmt.Errorf("invalid CDI kind %q: %w", opts.cdiKind, err)
+	}
+	if err := parser.ValidateClassName(class); err != nil {
+		return fmt.Errorf("invalid CDI kind %q: %w", opts.cdiKind, err)
+	}
+	return nil
+}
+
+func (m command) run(opts *options) error {
+	mode := info.NewRuntimeModeResolver(
+		info.WithLogger(m.logger),
+		info.WithImage(&image.CUDA{}),
+	).ResolveRuntimeMode(opts.mode)
+
+	request := opts.getDeviceRequest(mode)
+
+	var output string
+	switch opts.format {
+	case formatCompose:
+		output = request.compose(opts.service)
+	case formatDeviceRequests:
+		data, err := json.MarshalIndent([]deviceRequest{*request}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal device requests: %w", err)
+		}
+		output = string(data) + "\n"
+	}
+
+	var w io.Writer = os.Stdout
+	if opts.output != "" {
+		f, err := os.Create(opts.output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if _, err := io.WriteString(w, output); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
+
+// getDeviceRequest returns the device request for the requested devices.
+// If the NVIDIA Container Runtime is configured in cdi mode, the devices are
+// requested as fully-qualified CDI devices. Otherwise the nvidia driver is
+// used. In this case, all devices are requested using a count of -1 instead
+// of the special 'all' device ID.
+func (opts *options) getDeviceRequest(mode info.RuntimeMode) *deviceRequest {
+	if mode == info.CDIRuntimeMode {
+		vendor, class := parser.ParseQualifier(opts.cdiKind)
+		var deviceIDs []string
+		for _, device := range opts.devices {
+			if !parser.IsQualifiedName(device) {
+				device = parser.QualifiedName(vendor, class, device)
+			}
+			deviceIDs = append(deviceIDs, device)
+		}
+		return &deviceRequest{
+			Driver:       driverCDI,
+			DeviceIDs:    deviceIDs,
+			Capabilities: [][]string{{"gpu"}},
+		}
+	}
+
+	request := &deviceRequest{
+		Driver:       driverNVIDIA,
+		Capabilities: [][]string{{"gpu"}},
+	}
+	for _, device := range opts.devices {
+		if device == "all" {
+			request.Count = -1
+			request.DeviceIDs = nil
+			break
+		}
+		request.DeviceIDs = append(request.DeviceIDs, device)
+	}
+	return request
+}
+
+// compose returns the Compose snippet for the device request for the
+// specified service.
+func (r *deviceRequest) compose(service string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "services:\n")
+	fmt.Fprintf(&b, "  %s:\n", service)
+	fmt.Fprintf(&b, "    deploy:\n")
+	fmt.Fprintf(&b, "      resources:\n")
+	fmt.Fprintf(&b, "        reservations:\n")
+	fmt.Fprintf(&b, "          devices:\n")
+	fmt.Fprintf(&b, "            - driver: %s\n", r.Driver)
+	if r.Count < 0 {
+		fmt.Fprintf(&b, "              count: all\n")
+	}
+	if len(r.DeviceIDs) > 0 {
+		var quoted []string
+		for _, id := range r.DeviceIDs {
+			quoted = append(quoted, strconv.Quote(id))
+		}
+		fmt.Fprintf(&b, "              device_ids: [%s]\n", strings.Join(quoted, ", "))
+	}
+	fmt.Fprintf(&b, "              capabilities: [gpu]\n")
+	return b.String()
+}
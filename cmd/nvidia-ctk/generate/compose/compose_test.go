@@ -0,0 +1,112 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package compose
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info"
+)
+
+func TestCompose(t *testing.T) {
+	testCases := []struct {
+		description     string
+		options         options
+		mode            info.RuntimeMode
+		expectedRequest *deviceRequest
+		expectedCompose string
+	}{
+		{
+			description: "all devices in legacy mode",
+			options: options{
+				devices: []string{"all"},
+			},
+			mode: info.LegacyRuntimeMode,
+			expectedRequest: &deviceRequest{
+				Driver:       "nvidia",
+				Count:        -1,
+				Capabilities: [][]string{{"gpu"}},
+			},
+			expectedCompose: `services:
+  app:
+    deploy:
+      resources:
+        reservations:
+          devices:
+            - driver: nvidia
+              count: all
+              capabilities: [gpu]
+`,
+		},
+		{
+			description: "device IDs in jit-cdi mode",
+			options: options{
+				devices: []string{"0", "GPU-1"},
+			},
+			mode: info.JitCDIRuntimeMode,
+			expectedRequest: &deviceRequest{
+				Driver:       "nvidia",
+				DeviceIDs:    []string{"0", "GPU-1"},
+				Capabilities: [][]string{{"gpu"}},
+			},
+			expectedCompose: `services:
+  app:
+    deploy:
+      resources:
+        reservations:
+          devices:
+            - driver: nvidia
+              device_ids: ["0", "GPU-1"]
+              capabilities: [gpu]
+`,
+		},
+		{
+			description: "CDI devices in cdi mode",
+			options: options{
+				devices: []string{"all", "example.com/device=foo"},
+				cdiKind: "nvidia.com/gpu",
+			},
+			mode: info.CDIRuntimeMode,
+			expectedRequest: &deviceRequest{
+				Driver:       "cdi",
+				DeviceIDs:    []string{"nvidia.com/gpu=all", "example.com/device=foo"},
+				Capabilities: [][]string{{"gpu"}},
+			},
+			expectedCompose: `services:
+  app:
+    deploy:
+      resources:
+        reservations:
+          devices:
+            - driver: cdi
+              device_ids: ["nvidia.com/gpu=all", "example.com/device=foo"]
+              capabilities: [gpu]
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			request := tc.options.getDeviceRequest(tc.mode)
+			require.EqualValues(t, tc.expectedRequest, request)
+			require.Equal(t, tc.expectedCompose, request.compose("app"))
+		})
+	}
+}
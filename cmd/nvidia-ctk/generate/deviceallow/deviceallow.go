@@ -0,0 +1,192 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package deviceallow
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/system/devicenode"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	devices     []string
+	driverRoot  string
+	devRoot     string
+	permissions string
+	output      string
+}
+
+// NewCommand constructs a device-allow command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build creates the CLI command
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name:  "device-allow",
+		Usage: "Generate a systemd drop-in with the DeviceAllow= entries required to access GPUs from a service",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(&opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(&opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:        "device",
+				Usage:       "the GPUs to allow access to. These are specified as for nvidia-ctk cdi generate (e.g. all, an index, or a UUID)",
+				Value:       []string{"all"},
+				Destination: &opts.devices,
+			},
+			&cli.StringFlag{
+				Name:        "driver-root",
+				Usage:       "Specify the NVIDIA GPU driver root to use when discovering the device nodes",
+				Value:       "/",
+				Destination: &opts.driverRoot,
+			},
+			&cli.StringFlag{
+				Name:        "dev-root",
+				Usage:       "Specify the root where `/dev` is located. If this is not specified, the driver-root is assumed.",
+				Destination: &opts.devRoot,
+			},
+			&cli.StringFlag{
+				Name:        "permissions",
+				Usage:       "the access to allow to the device nodes. One or more of r, w, and m.",
+				Value:       "rw",
+				Destination: &opts.permissions,
+			},
+			&cli.StringFlag{
+				Name:        "output",
+				Usage:       "the file to write the drop-in to (e.g. /etc/systemd/system/SERVICE.service.d/nvidia.conf). If this is not specified, it is written to STDOUT",
+				Destination: &opts.output,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(opts *options) error {
+	if len(opts.devices) == 0 {
+		return fmt.Errorf("at least one device must be specified")
+	}
+	if opts.permissions == "" || strings.Trim(opts.permissions, "rwm") != "" {
+		return fmt.Errorf("invalid permissions %q; expected one or more of r, w, and m", opts.permissions)
+	}
+	if opts.devRoot == "" {
+		opts.devRoot = opts.driverRoot
+	}
+	return nil
+}
+
+func (m command) run(opts *options) error {
+	cdilib, err := nvcdi.New(
+		nvcdi.WithLogger(m.logger),
+		nvcdi.WithDriverRoot(opts.driverRoot),
+		nvcdi.WithDevRoot(opts.devRoot),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create CDI library: %w", err)
+	}
+
+	deviceSpecs, err := cdilib.GetDeviceSpecsByID(opts.devices...)
+	if err != nil {
+		return fmt.Errorf("failed to get device specs: %w", err)
+	}
+	commonEdits, err := cdilib.GetCommonEdits()
+	if err != nil {
+		return fmt.Errorf("failed to get common edits: %w", err)
+	}
+
+	edits := []*specs.ContainerEdits{commonEdits.ContainerEdits}
+	for i := range deviceSpecs {
+		edits = append(edits, &deviceSpecs[i].ContainerEdits)
+	}
+
+	dropIn := m.getDropIn(opts.permissions, edits...)
+	if err := writeTo(opts.output, dropIn); err != nil {
+		return fmt.Errorf("failed to write drop-in: %w", err)
+	}
+	return nil
+}
+
+// getDropIn returns a [Service] drop-in with a DeviceAllow= entry for each
+// device node in the specified CDI container edits. Where possible, device
+// nodes are referred to by their /dev/char/MAJOR:MINOR path so that the
+// entries do not depend on the device node being present in the namespace
+// of the service.
+func (m command) getDropIn(permissions string, edits ...*specs.ContainerEdits) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[Service]\n")
+
+	seen := make(map[string]bool)
+	for _, e := range edits {
+		if e == nil {
+			continue
+		}
+		for _, dn := range e.DeviceNodes {
+			hostPath := dn.HostPath
+			if hostPath == "" {
+				hostPath = dn.Path
+			}
+			if seen[hostPath] {
+				continue
+			}
+			seen[hostPath] = true
+
+			specifier := hostPath
+			if major, minor, err := devicenode.Numbers(hostPath); err != nil {
+				m.logger.Warningf("Failed to determine device numbers for %v; using path: %v", hostPath, err)
+			} else {
+				specifier = fmt.Sprintf("/dev/char/%d:%d", major, minor)
+			}
+			fmt.Fprintf(&b, "# %s\n", hostPath)
+			fmt.Fprintf(&b, "DeviceAllow=%s %s\n", specifier, permissions)
+		}
+	}
+	return b.String()
+}
+
+// writeTo writes the specified contents to the specified file. If no file is
+// specified, the contents are written to STDOUT.
+func writeTo(path string, contents string) error {
+	if path != "" {
+		return os.WriteFile(path, []byte(contents), 0644)
+	}
+	_, err := io.WriteString(os.Stdout, contents)
+	return err
+}
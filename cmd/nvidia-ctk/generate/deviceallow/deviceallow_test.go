@@ -0,0 +1,91 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package deviceallow
+
+import (
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+func TestGetDropIn(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	regularFile := filepath.Join(t.TempDir(), "nvidia0")
+
+	testCases := []struct {
+		description    string
+		edits          []*specs.ContainerEdits
+		expectedDropIn string
+	}{
+		{
+			description:    "no device nodes",
+			edits:          []*specs.ContainerEdits{nil, {}},
+			expectedDropIn: "[Service]\n",
+		},
+		{
+			description: "character devices are referenced by number",
+			edits: []*specs.ContainerEdits{
+				{
+					DeviceNodes: []*specs.DeviceNode{
+						{Path: "/dev/nvidiactl", HostPath: "/dev/null"},
+					},
+				},
+			},
+			expectedDropIn: "[Service]\n# /dev/null\nDeviceAllow=/dev/char/1:3 rw\n",
+		},
+		{
+			description: "path is used if device numbers are unknown",
+			edits: []*specs.ContainerEdits{
+				{
+					DeviceNodes: []*specs.DeviceNode{
+						{Path: "/dev/nvidia0", HostPath: regularFile},
+					},
+				},
+			},
+			expectedDropIn: "[Service]\n# " + regularFile + "\nDeviceAllow=" + regularFile + " rw\n",
+		},
+		{
+			description: "duplicate device nodes are skipped",
+			edits: []*specs.ContainerEdits{
+				{
+					DeviceNodes: []*specs.DeviceNode{
+						{Path: "/dev/null"},
+					},
+				},
+				{
+					DeviceNodes: []*specs.DeviceNode{
+						{Path: "/dev/null", HostPath: "/dev/null"},
+					},
+				},
+			},
+			expectedDropIn: "[Service]\n# /dev/null\nDeviceAllow=/dev/char/1:3 rw\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c := command{
+				logger: logger,
+			}
+			require.Equal(t, tc.expectedDropIn, c.getDropIn("rw", tc.edits...))
+		})
+	}
+}
@@ -0,0 +1,65 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/generate/compose"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/generate/deviceallow"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/generate/kubevirt"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/generate/lxc"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/generate/microvm"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/generate/nspawn"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/generate/systemd"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+type command struct {
+	logger         logger.Interface
+	configFilePath *string
+}
+
+// NewCommand constructs a generate command with the specified logger
+func NewCommand(logger logger.Interface, configFilePath *string) *cli.Command {
+	c := command{
+		logger:         logger,
+		configFilePath: configFilePath,
+	}
+	return c.build()
+}
+
+// build
+func (m command) build() *cli.Command {
+	// Create the 'generate' command
+	generate := cli.Command{
+		Name:  "generate",
+		Usage: "Generate configuration for running GPU containers",
+		Commands: []*cli.Command{
+			compose.NewCommand(m.logger, m.configFilePath),
+			deviceallow.NewCommand(m.logger),
+			kubevirt.NewCommand(m.logger),
+			lxc.NewCommand(m.logger),
+			microvm.NewCommand(m.logger),
+			nspawn.NewCommand(m.logger),
+			systemd.NewCommand(m.logger),
+		},
+	}
+
+	return &generate
+}
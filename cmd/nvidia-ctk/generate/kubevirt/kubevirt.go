@@ -0,0 +1,261 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package kubevirt
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvpci"
+	"github.com/urfave/cli/v3"
+	"sigs.k8s.io/yaml"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+const (
+	defaultSysfsRoot = "/sys"
+	nvidiaVendorID   = "0x10de"
+	vfioPCIDriver    = "vfio-pci"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	resourcePrefix           string
+	externalResourceProvider bool
+	output                   string
+
+	sysfsRoot string
+	pcilib    nvpci.Interface
+}
+
+// permittedHostDevices represents the permittedHostDevices field of the
+// KubeVirt CR configuration.
+type permittedHostDevices struct {
+	PCIHostDevices  []pciHostDevice  `json:"pciHostDevices,omitempty"`
+	MediatedDevices []mediatedDevice `json:"mediatedDevices,omitempty"`
+}
+
+type pciHostDevice struct {
+	PCIVendorSelector        string `json:"pciVendorSelector"`
+	ResourceName             string `json:"resourceName"`
+	ExternalResourceProvider bool   `json:"externalResourceProvider,omitempty"`
+}
+
+type mediatedDevice struct {
+	MDEVNameSelector         string `json:"mdevNameSelector"`
+	ResourceName             string `json:"resourceName"`
+	ExternalResourceProvider bool   `json:"externalResourceProvider,omitempty"`
+}
+
+// NewCommand constructs a kubevirt command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build creates the CLI command
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name: "kubevirt",
+		Usage: "Generate the KubeVirt permittedHostDevices configuration for the GPUs bound to vfio-pci (passthrough) " +
+			"and the NVIDIA vGPU mediated devices on this node",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(&opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(&opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "resource-prefix",
+				Usage:       "the prefix for the generated resource names",
+				Value:       "nvidia.com",
+				Destination: &opts.resourcePrefix,
+			},
+			&cli.BoolFlag{
+				Name: "external-resource-provider",
+				Usage: "indicate that the devices are advertised by an external device plugin such as the NVIDIA KubeVirt GPU device plugin. " +
+					"If this is false, the devices are advertised by KubeVirt",
+				Value:       true,
+				Destination: &opts.externalResourceProvider,
+			},
+			&cli.StringFlag{
+				Name:        "output",
+				Usage:       "the file to write the configuration to. If this is not specified, the configuration is written to STDOUT",
+				Destination: &opts.output,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(opts *options) error {
+	if opts.resourcePrefix == "" {
+		return fmt.Errorf("a resource prefix must be specified")
+	}
+	return nil
+}
+
+func (m command) run(opts *options) error {
+	if opts.sysfsRoot == "" {
+		opts.sysfsRoot = defaultSysfsRoot
+	}
+	if opts.pcilib == nil {
+		opts.pcilib = nvpci.New(
+			nvpci.WithPCIDevicesRoot(filepath.Join(opts.sysfsRoot, "bus", "pci", "devices")),
+			nvpci.WithLogger(m.logger),
+		)
+	}
+
+	devices, err := m.getPermittedHostDevices(opts)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(map[string]interface{}{"permittedHostDevices": devices})
+	if err != nil {
+		return fmt.Errorf("failed to marshal configuration: %w", err)
+	}
+
+	var w io.Writer = os.Stdout
+	if opts.output != "" {
+		f, err := os.Create(opts.output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write configuration: %w", err)
+	}
+	return nil
+}
+
+// getPermittedHostDevices returns the permitted host devices for the GPUs that
+// are bound to the vfio-pci driver and the NVIDIA mediated devices.
+// Each distinct GPU model and vGPU type is only included once.
+func (m command) getPermittedHostDevices(opts *options) (*permittedHostDevices, error) {
+	gpus, err := opts.pcilib.GetGPUs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GPUs: %w", err)
+	}
+
+	devices := &permittedHostDevices{}
+	seen := make(map[string]bool)
+	for _, gpu := range gpus {
+		if gpu.Driver != vfioPCIDriver {
+			m.logger.Debugf("Skipping GPU %v bound to driver %q", gpu.Address, gpu.Driver)
+			continue
+		}
+		selector := fmt.Sprintf("%04X:%04X", gpu.Vendor, gpu.Device)
+		if seen[selector] {
+			continue
+		}
+		seen[selector] = true
+		name := gpu.DeviceName
+		if name == "" {
+			name = fmt.Sprintf("%04X", gpu.Device)
+		}
+		devices.PCIHostDevices = append(devices.PCIHostDevices, pciHostDevice{
+			PCIVendorSelector:        selector,
+			ResourceName:             opts.resourcePrefix + "/" + getResourceName(name),
+			ExternalResourceProvider: opts.externalResourceProvider,
+		})
+	}
+
+	mdevTypes, err := m.getMediatedDeviceTypes(opts.sysfsRoot)
+	if err != nil {
+		return nil, err
+	}
+	for _, mdevType := range mdevTypes {
+		devices.MediatedDevices = append(devices.MediatedDevices, mediatedDevice{
+			MDEVNameSelector:         mdevType,
+			ResourceName:             opts.resourcePrefix + "/" + getResourceName(mdevType),
+			ExternalResourceProvider: opts.externalResourceProvider,
+		})
+	}
+
+	return devices, nil
+}
+
+// getMediatedDeviceTypes returns the names of the types of the mediated
+// devices created on NVIDIA GPUs.
+func (m command) getMediatedDeviceTypes(sysfsRoot string) ([]string, error) {
+	mdevs, err := filepath.Glob(filepath.Join(sysfsRoot, "bus", "mdev", "devices", "*"))
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var names []string
+	for _, mdev := range mdevs {
+		resolved, err := filepath.EvalSymlinks(mdev)
+		if err != nil {
+			m.logger.Warningf("Failed to resolve mediated device %v: %v", mdev, err)
+			continue
+		}
+		vendor, err := os.ReadFile(filepath.Join(filepath.Dir(resolved), "vendor"))
+		if err != nil || strings.TrimSpace(string(vendor)) != nvidiaVendorID {
+			continue
+		}
+		name, err := os.ReadFile(filepath.Join(resolved, "mdev_type", "name"))
+		if err != nil {
+			m.logger.Warningf("Failed to read type of mediated device %v: %v", mdev, err)
+			continue
+		}
+		mdevType := strings.TrimSpace(string(name))
+		if mdevType == "" || seen[mdevType] {
+			continue
+		}
+		seen[mdevType] = true
+		names = append(names, mdevType)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+var (
+	whitespaceRegexp  = regexp.MustCompile(`\s+`)
+	unsupportedRegexp = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
+)
+
+// getResourceName returns the resource name for the specified device name.
+// This uses the same conventions as the NVIDIA KubeVirt GPU device plugin,
+// meaning that a device name such as "GA100 [A100 PCIe 40GB]" results in the
+// resource name GA100_A100_PCIE_40GB.
+func getResourceName(name string) string {
+	name = strings.ToUpper(name)
+	name = strings.NewReplacer("/", "_", ".", "_").Replace(name)
+	name = whitespaceRegexp.ReplaceAllString(name, "_")
+	return unsupportedRegexp.ReplaceAllString(name, "")
+}
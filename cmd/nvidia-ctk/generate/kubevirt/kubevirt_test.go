@@ -0,0 +1,95 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package kubevirt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvpci"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePCILib struct {
+	nvpci.Interface
+	gpus []*nvpci.NvidiaPCIDevice
+}
+
+func (f *fakePCILib) GetGPUs() ([]*nvpci.NvidiaPCIDevice, error) {
+	return f.gpus, nil
+}
+
+// createMediatedDevice creates a mediated device of the specified type in the
+// specified sysfs root with a parent device with the specified vendor.
+func createMediatedDevice(t *testing.T, sysfs string, parent string, vendor string, uuid string, mdevType string) {
+	parentPath := filepath.Join(sysfs, "devices", "pci0000:00", parent)
+	typePath := filepath.Join(parentPath, "mdev_supported_types", "nvidia-"+uuid[:4])
+	devicePath := filepath.Join(parentPath, uuid)
+	require.NoError(t, os.MkdirAll(typePath, 0755))
+	require.NoError(t, os.MkdirAll(devicePath, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(parentPath, "vendor"), []byte(vendor+"\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(typePath, "name"), []byte(mdevType+"\n"), 0600))
+	require.NoError(t, os.Symlink(typePath, filepath.Join(devicePath, "mdev_type")))
+
+	mdevs := filepath.Join(sysfs, "bus", "mdev", "devices")
+	require.NoError(t, os.MkdirAll(mdevs, 0755))
+	require.NoError(t, os.Symlink(devicePath, filepath.Join(mdevs, uuid)))
+}
+
+func TestGetPermittedHostDevices(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	sysfs := t.TempDir()
+
+	createMediatedDevice(t, sysfs, "0000:3b:00.4", "0x10de", "11111111-0000-0000-0000-000000000000", "GRID A100-4C")
+	createMediatedDevice(t, sysfs, "0000:3b:00.5", "0x10de", "22222222-0000-0000-0000-000000000000", "GRID A100-4C")
+	createMediatedDevice(t, sysfs, "0000:5e:00.0", "0x8086", "33333333-0000-0000-0000-000000000000", "i915-GVTg_V5_4")
+
+	c := command{
+		logger: logger,
+	}
+	opts := &options{
+		resourcePrefix:           "nvidia.com",
+		externalResourceProvider: true,
+		sysfsRoot:                sysfs,
+		pcilib: &fakePCILib{
+			gpus: []*nvpci.NvidiaPCIDevice{
+				{Address: "0000:01:00.0", Vendor: 0x10de, Device: 0x20b0, DeviceName: "GA100 [A100 PCIe 40GB]", Driver: "vfio-pci"},
+				{Address: "0000:02:00.0", Vendor: 0x10de, Device: 0x20b0, DeviceName: "GA100 [A100 PCIe 40GB]", Driver: "vfio-pci"},
+				{Address: "0000:03:00.0", Vendor: 0x10de, Device: 0x2236, DeviceName: "GA102GL [A10]", Driver: "nvidia"},
+				{Address: "0000:04:00.0", Vendor: 0x10de, Device: 0x1eb8, Driver: "vfio-pci"},
+			},
+		},
+	}
+
+	devices, err := c.getPermittedHostDevices(opts)
+	require.NoError(t, err)
+	require.EqualValues(t,
+		&permittedHostDevices{
+			PCIHostDevices: []pciHostDevice{
+				{PCIVendorSelector: "10DE:20B0", ResourceName: "nvidia.com/GA100_A100_PCIE_40GB", ExternalResourceProvider: true},
+				{PCIVendorSelector: "10DE:1EB8", ResourceName: "nvidia.com/1EB8", ExternalResourceProvider: true},
+			},
+			MediatedDevices: []mediatedDevice{
+				{MDEVNameSelector: "GRID A100-4C", ResourceName: "nvidia.com/GRID_A1004C", ExternalResourceProvider: true},
+			},
+		},
+		devices,
+	)
+}
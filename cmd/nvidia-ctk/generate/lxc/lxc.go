@@ -0,0 +1,306 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package lxc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/system/devicenode"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
+
+const (
+	defaultHookPath = "/usr/share/lxc/hooks/nvidia-cdi"
+
+	hookScriptHeader = `#!/bin/sh
+# Generated by nvidia-ctk generate lxc.
+set -eu
+
+bundle=$(mktemp -d)
+trap 'rm -rf "$bundle"' EXIT
+printf '{"ociVersion":"1.0.0","root":{"path":"%s"}}' "$LXC_ROOTFS_MOUNT" > "$bundle/config.json"
+state=$(printf '{"ociVersion":"1.0.0","id":"%s","status":"creating","bundle":"%s"}' "$LXC_NAME" "$bundle")
+
+`
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	devices    []string
+	driverRoot string
+	devRoot    string
+
+	configOutput string
+	hookOutput   string
+	hookPath     string
+}
+
+// NewCommand constructs an lxc command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build creates the CLI command
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name:  "lxc",
+		Usage: "Generate LXC container config and an lxc.hook.mount hook for accessing GPUs from an LXC container",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(&opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(&opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:        "device",
+				Usage:       "the GPUs to make available. These are specified as for nvidia-ctk cdi generate (e.g. all, an index, or a UUID)",
+				Value:       []string{"all"},
+				Destination: &opts.devices,
+			},
+			&cli.StringFlag{
+				Name:        "driver-root",
+				Usage:       "Specify the NVIDIA GPU driver root to use when discovering the entities that should be bind-mounted",
+				Value:       "/",
+				Destination: &opts.driverRoot,
+			},
+			&cli.StringFlag{
+				Name:        "dev-root",
+				Usage:       "Specify the root where `/dev` is located. If this is not specified, the driver-root is assumed.",
+				Destination: &opts.devRoot,
+			},
+			&cli.StringFlag{
+				Name:        "config-output",
+				Usage:       "the file to write the LXC config to (e.g. /var/lib/lxc/CONTAINER/nvidia.conf). If this is not specified, it is written to STDOUT",
+				Destination: &opts.configOutput,
+			},
+			&cli.StringFlag{
+				Name:        "hook-output",
+				Usage:       "the file to write the lxc.hook.mount script to. If this is not specified, it is written to STDOUT",
+				Destination: &opts.hookOutput,
+			},
+			&cli.StringFlag{
+				Name:        "hook-path",
+				Usage:       "the path of the lxc.hook.mount script referenced in the LXC config. If this is not specified, the hook-output path or " + defaultHookPath + " is used",
+				Destination: &opts.hookPath,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(opts *options) error {
+	if len(opts.devices) == 0 {
+		return fmt.Errorf("at least one device must be specified")
+	}
+	if opts.devRoot == "" {
+		opts.devRoot = opts.driverRoot
+	}
+	if opts.hookPath == "" {
+		opts.hookPath = opts.hookOutput
+	}
+	if opts.hookPath == "" {
+		opts.hookPath = defaultHookPath
+	}
+	return nil
+}
+
+func (m command) run(opts *options) error {
+	cdilib, err := nvcdi.New(
+		nvcdi.WithLogger(m.logger),
+		nvcdi.WithDriverRoot(opts.driverRoot),
+		nvcdi.WithDevRoot(opts.devRoot),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create CDI library: %w", err)
+	}
+
+	deviceSpecs, err := cdilib.GetDeviceSpecsByID(opts.devices...)
+	if err != nil {
+		return fmt.Errorf("failed to get device specs: %w", err)
+	}
+	commonEdits, err := cdilib.GetCommonEdits()
+	if err != nil {
+		return fmt.Errorf("failed to get common edits: %w", err)
+	}
+
+	edits := []*specs.ContainerEdits{commonEdits.ContainerEdits}
+	for i := range deviceSpecs {
+		edits = append(edits, &deviceSpecs[i].ContainerEdits)
+	}
+	s := m.getSettings(edits...)
+
+	if err := writeTo(opts.configOutput, s.config(opts.hookPath), 0644, "# LXC config\n"); err != nil {
+		return fmt.Errorf("failed to write LXC config: %w", err)
+	}
+	if len(s.hooks) == 0 {
+		return nil
+	}
+	if err := writeTo(opts.hookOutput, s.hookScript(), 0755, "# "+opts.hookPath+"\n"); err != nil {
+		return fmt.Errorf("failed to write hook: %w", err)
+	}
+	return nil
+}
+
+// settings define the LXC settings required to make the GPUs accessible in
+// a container.
+type settings struct {
+	env          []string
+	mountEntries []string
+	devicesAllow []string
+	hooks        [][]string
+}
+
+// getSettings converts the specified CDI container edits to LXC settings.
+// Device nodes and mounts are added as lxc.mount.entry bind mounts and the
+// device nodes are allowed in the devices cgroup of the container. CDI hooks
+// are run from an lxc.hook.mount script.
+func (m command) getSettings(edits ...*specs.ContainerEdits) *settings {
+	s := &settings{}
+	for _, e := range edits {
+		if e == nil {
+			continue
+		}
+		s.env = append(s.env, e.Env...)
+		for _, dn := range e.DeviceNodes {
+			hostPath := dn.HostPath
+			if hostPath == "" {
+				hostPath = dn.Path
+			}
+			s.mountEntries = appendUnique(s.mountEntries, mountEntry(hostPath, dn.Path, "bind,create=file"))
+
+			major, minor, err := devicenode.Numbers(hostPath)
+			if err != nil {
+				m.logger.Warningf("Failed to determine device numbers for %v; skipping devices cgroup entry: %v", hostPath, err)
+				continue
+			}
+			s.devicesAllow = appendUnique(s.devicesAllow, fmt.Sprintf("c %d:%d rwm", major, minor))
+		}
+		for _, mount := range e.Mounts {
+			options := "bind,create=file"
+			if info, err := os.Stat(mount.HostPath); err == nil && info.IsDir() {
+				options = "bind,create=dir"
+			}
+			if slices.Contains(mount.Options, "ro") {
+				options += ",ro"
+			}
+			s.mountEntries = appendUnique(s.mountEntries, mountEntry(mount.HostPath, mount.ContainerPath, options))
+		}
+		for _, hook := range e.Hooks {
+			if hook.HookName != "createContainer" {
+				m.logger.Warningf("Skipping unsupported %v hook %v %v", hook.HookName, hook.Path, hook.Args)
+				continue
+			}
+			args := slices.Clone(hook.Args)
+			if len(args) == 0 {
+				args = []string{hook.Path}
+			}
+			s.hooks = append(s.hooks, append([]string{hook.Path}, args[1:]...))
+		}
+	}
+	return s
+}
+
+// config returns the LXC container config. The lxc.hook.mount entry refers
+// to the hook script at the specified path.
+func (s *settings) config(hookPath string) string {
+	var b strings.Builder
+	for _, env := range s.env {
+		fmt.Fprintf(&b, "lxc.environment = %s\n", env)
+	}
+	for _, device := range s.devicesAllow {
+		fmt.Fprintf(&b, "lxc.cgroup2.devices.allow = %s\n", device)
+	}
+	for _, entry := range s.mountEntries {
+		fmt.Fprintf(&b, "lxc.mount.entry = %s\n", entry)
+	}
+	if len(s.hooks) > 0 {
+		fmt.Fprintf(&b, "lxc.hook.mount = %s\n", hookPath)
+	}
+	return b.String()
+}
+
+// hookScript returns an lxc.hook.mount script that runs the CDI hooks for the
+// container. Since the hooks expect the OCI container state on STDIN, a
+// minimal bundle referring to the mounted rootfs of the container is created.
+func (s *settings) hookScript() string {
+	var b strings.Builder
+	b.WriteString(hookScriptHeader)
+	for _, hook := range s.hooks {
+		fmt.Fprintf(&b, "echo \"$state\" | %s\n", shellQuote(hook))
+	}
+	return b.String()
+}
+
+// mountEntry returns an lxc.mount.entry for bind-mounting the specified host
+// path at the specified path in the container. Whitespace in paths is escaped
+// as required by fstab entries.
+func mountEntry(hostPath string, containerPath string, options string) string {
+	escape := strings.NewReplacer(" ", `\040`, "\t", `\011`)
+	return fmt.Sprintf("%s %s none %s 0 0", escape.Replace(hostPath), escape.Replace(strings.TrimPrefix(containerPath, "/")), options)
+}
+
+// shellQuote joins the specified arguments as a POSIX shell command line.
+// Arguments are single-quoted if they contain characters that have a special
+// meaning to the shell.
+func shellQuote(args []string) string {
+	var quoted []string
+	for _, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n\"'\\$`;&|<>()*?[]#~") {
+			arg = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+		quoted = append(quoted, arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+func appendUnique(s []string, v string) []string {
+	if slices.Contains(s, v) {
+		return s
+	}
+	return append(s, v)
+}
+
+// writeTo writes the specified contents to the specified file with the
+// specified mode. If no file is specified, the contents are written to STDOUT
+// with the specified header.
+func writeTo(path string, contents string, mode os.FileMode, header string) error {
+	if path != "" {
+		return os.WriteFile(path, []byte(contents), mode)
+	}
+	_, err := io.WriteString(os.Stdout, header+contents+"\n")
+	return err
+}
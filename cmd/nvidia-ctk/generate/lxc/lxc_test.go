@@ -0,0 +1,105 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package lxc
+
+import (
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+func TestGetSettings(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	libDir := t.TempDir()
+
+	testCases := []struct {
+		description    string
+		edits          []*specs.ContainerEdits
+		expectedConfig string
+		expectedHook   string
+	}{
+		{
+			description: "device nodes and mounts are bind-mounted",
+			edits: []*specs.ContainerEdits{
+				{
+					Env: []string{"NVIDIA_VISIBLE_DEVICES=void"},
+					DeviceNodes: []*specs.DeviceNode{
+						{Path: "/dev/nvidiactl", HostPath: "/dev/null"},
+					},
+					Mounts: []*specs.Mount{
+						{HostPath: "/usr/lib/libcuda.so.1", ContainerPath: "/usr/lib/libcuda.so.1", Options: []string{"ro", "nosuid", "nodev", "bind"}},
+						{HostPath: libDir, ContainerPath: "/lib/firmware/nvidia/550.54.15"},
+					},
+				},
+				nil,
+			},
+			expectedConfig: `lxc.environment = NVIDIA_VISIBLE_DEVICES=void
+lxc.cgroup2.devices.allow = c 1:3 rwm
+lxc.mount.entry = /dev/null dev/nvidiactl none bind,create=file 0 0
+lxc.mount.entry = /usr/lib/libcuda.so.1 usr/lib/libcuda.so.1 none bind,create=file,ro 0 0
+lxc.mount.entry = ` + libDir + ` lib/firmware/nvidia/550.54.15 none bind,create=dir 0 0
+`,
+		},
+		{
+			description: "hooks are run from mount hook",
+			edits: []*specs.ContainerEdits{
+				{
+					Hooks: []*specs.Hook{
+						{
+							HookName: "createContainer",
+							Path:     "/usr/bin/nvidia-cdi-hook",
+							Args:     []string{"nvidia-cdi-hook", "create-symlinks", "--link", "libcuda.so.1::/usr/lib/libcuda.so"},
+						},
+						{
+							HookName: "createContainer",
+							Path:     "/usr/bin/nvidia-cdi-hook",
+							Args:     []string{"nvidia-cdi-hook", "update-ldcache", "--folder", "/usr/lib/my libs"},
+						},
+						{
+							HookName: "prestart",
+							Path:     "/usr/bin/nvidia-container-runtime-hook",
+						},
+					},
+				},
+			},
+			expectedConfig: "lxc.hook.mount = /usr/share/lxc/hooks/nvidia-cdi\n",
+			expectedHook: hookScriptHeader +
+				"echo \"$state\" | /usr/bin/nvidia-cdi-hook create-symlinks --link libcuda.so.1::/usr/lib/libcuda.so\n" +
+				"echo \"$state\" | /usr/bin/nvidia-cdi-hook update-ldcache --folder '/usr/lib/my libs'\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c := command{
+				logger: logger,
+			}
+			s := c.getSettings(tc.edits...)
+			require.Equal(t, tc.expectedConfig, s.config(defaultHookPath))
+			if tc.expectedHook != "" {
+				require.Equal(t, tc.expectedHook, s.hookScript())
+			}
+		})
+	}
+}
+
+func TestMountEntry(t *testing.T) {
+	require.Equal(t, `/opt/my\040driver/lib opt/my\040driver/lib none bind 0 0`, mountEntry("/opt/my driver/lib", "/opt/my driver/lib", "bind"))
+}
@@ -0,0 +1,318 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package microvm
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvpci"
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/vfio"
+)
+
+const (
+	defaultSysfsRoot = "/sys"
+
+	vmmCloudHypervisor = "cloud-hypervisor"
+	vmmFirecracker     = "firecracker"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	devices       []string
+	vmm           string
+	output        string
+	prepareOutput string
+
+	sysfsRoot string
+	pcilib    nvpci.Interface
+}
+
+// passthroughDevice represents a GPU that is to be passed through to a
+// microVM and the devices in its IOMMU group that must be bound to vfio-pci
+// on the host.
+type passthroughDevice struct {
+	address    string
+	iommuGroup int
+	sysfsPath  string
+	rebind     []pciDevice
+}
+
+type pciDevice struct {
+	address string
+	driver  string
+}
+
+// NewCommand constructs a microvm command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build creates the CLI command
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name: "microvm",
+		Usage: "Generate the VFIO device arguments and the host preparation steps required to pass GPUs through to a " +
+			"microVM such as a Cloud Hypervisor VM",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(&opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(&opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:        "device",
+				Usage:       "the GPUs to pass through. These are specified as an index, a PCI bus ID, or all",
+				Value:       []string{"all"},
+				Destination: &opts.devices,
+			},
+			&cli.StringFlag{
+				Name:        "vmm",
+				Usage:       "the virtual machine monitor to generate the device arguments for. One of [cloud-hypervisor]",
+				Value:       vmmCloudHypervisor,
+				Destination: &opts.vmm,
+			},
+			&cli.StringFlag{
+				Name:        "output",
+				Usage:       "the file to write the VMM device arguments to. If this is not specified, the arguments are written to STDOUT",
+				Destination: &opts.output,
+			},
+			&cli.StringFlag{
+				Name:        "prepare-output",
+				Usage:       "the file to write the host preparation script to. If this is not specified, the script is written to STDOUT",
+				Destination: &opts.prepareOutput,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(opts *options) error {
+	switch opts.vmm {
+	case vmmCloudHypervisor:
+	case vmmFirecracker:
+		return fmt.Errorf("%v does not support VFIO PCI device passthrough; use %v instead", vmmFirecracker, vmmCloudHypervisor)
+	default:
+		return fmt.Errorf("unsupported VMM %q", opts.vmm)
+	}
+	if len(opts.devices) == 0 {
+		return fmt.Errorf("at least one device must be specified")
+	}
+	return nil
+}
+
+func (m command) run(opts *options) error {
+	if opts.sysfsRoot == "" {
+		opts.sysfsRoot = defaultSysfsRoot
+	}
+	if opts.pcilib == nil {
+		opts.pcilib = nvpci.New(
+			nvpci.WithPCIDevicesRoot(filepath.Join(opts.sysfsRoot, "bus", "pci", "devices")),
+			nvpci.WithLogger(m.logger),
+		)
+	}
+
+	devices, err := m.getPassthroughDevices(opts)
+	if err != nil {
+		return err
+	}
+
+	if err := writeTo(opts.prepareOutput, prepareScript(opts.sysfsRoot, devices)); err != nil {
+		return fmt.Errorf("failed to write host preparation script: %w", err)
+	}
+	if err := writeTo(opts.output, cloudHypervisorArgs(devices)); err != nil {
+		return fmt.Errorf("failed to write device arguments: %w", err)
+	}
+	return nil
+}
+
+// getPassthroughDevices returns the selected GPUs after checking that the
+// IOMMU is enabled on the host and that each GPU is in an IOMMU group.
+func (m command) getPassthroughDevices(opts *options) ([]*passthroughDevice, error) {
+	if err := checkIOMMU(opts.sysfsRoot); err != nil {
+		return nil, err
+	}
+
+	gpus, err := opts.pcilib.GetGPUs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GPUs: %w", err)
+	}
+	selected, err := selectGPUs(gpus, opts.devices...)
+	if err != nil {
+		return nil, err
+	}
+
+	var devices []*passthroughDevice
+	for _, gpu := range selected {
+		if gpu.IommuGroup < 0 {
+			return nil, fmt.Errorf("GPU %v is not in an IOMMU group", gpu.Address)
+		}
+		rebind, err := m.getDevicesToRebind(gpu)
+		if err != nil {
+			return nil, err
+		}
+		devices = append(devices, &passthroughDevice{
+			address:    gpu.Address,
+			iommuGroup: gpu.IommuGroup,
+			sysfsPath:  filepath.Join(opts.sysfsRoot, "bus", "pci", "devices", gpu.Address),
+			rebind:     rebind,
+		})
+	}
+	return devices, nil
+}
+
+// checkIOMMU returns an error if no IOMMU groups are present on the host.
+// This is the case if the IOMMU is disabled in the firmware or on the kernel
+// command line.
+func checkIOMMU(sysfsRoot string) error {
+	groups, err := os.ReadDir(filepath.Join(sysfsRoot, "kernel", "iommu_groups"))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read IOMMU groups: %w", err)
+	}
+	if len(groups) == 0 {
+		return fmt.Errorf("no IOMMU groups found; ensure that the IOMMU is enabled (e.g. intel_iommu=on or amd_iommu=on)")
+	}
+	return nil
+}
+
+// selectGPUs returns the GPUs matching the specified IDs.
+// Valid IDs are:
+// * the index of the GPU
+// * the PCI bus ID of the GPU
+// * the special ID 'all'
+func selectGPUs(gpus []*nvpci.NvidiaPCIDevice, ids ...string) ([]*nvpci.NvidiaPCIDevice, error) {
+	var selected []*nvpci.NvidiaPCIDevice
+	for _, id := range ids {
+		if id == "all" {
+			return gpus, nil
+		}
+		if index, err := strconv.Atoi(id); err == nil {
+			if index < 0 || index >= len(gpus) {
+				return nil, fmt.Errorf("invalid GPU index %v", id)
+			}
+			selected = append(selected, gpus[index])
+			continue
+		}
+		gpu := findGPU(gpus, id)
+		if gpu == nil {
+			return nil, fmt.Errorf("no GPU with PCI bus ID %v", id)
+		}
+		selected = append(selected, gpu)
+	}
+	return selected, nil
+}
+
+func findGPU(gpus []*nvpci.NvidiaPCIDevice, address string) *nvpci.NvidiaPCIDevice {
+	for _, gpu := range gpus {
+		if gpu.Address == address {
+			return gpu
+		}
+	}
+	return nil
+}
+
+// getDevicesToRebind returns the devices in the IOMMU group of the specified
+// GPU (including the GPU itself) that are not bound to a VFIO-compatible
+// driver. Unbound devices are also returned since these must be bound to
+// vfio-pci before the group can be passed through.
+func (m command) getDevicesToRebind(gpu *nvpci.NvidiaPCIDevice) ([]pciDevice, error) {
+	members, err := vfio.GetIOMMUGroupDevices(gpu)
+	if err != nil {
+		return nil, err
+	}
+
+	var devices []pciDevice
+	for _, member := range members {
+		if vfio.IsCompatibleDriver(member.Driver) {
+			continue
+		}
+		m.logger.Debugf("Device %v in IOMMU group %v is bound to driver %q", member.Address, gpu.IommuGroup, member.Driver)
+		devices = append(devices, pciDevice{address: member.Address, driver: member.Driver})
+	}
+	return devices, nil
+}
+
+// prepareScript returns a shell script that binds the devices in the IOMMU
+// groups of the selected GPUs to the vfio-pci driver.
+func prepareScript(sysfsRoot string, devices []*passthroughDevice) string {
+	var sb strings.Builder
+	sb.WriteString("#!/bin/sh\n")
+	sb.WriteString("# Generated by nvidia-ctk generate microvm.\n")
+	sb.WriteString("set -eu\n\n")
+	sb.WriteString("modprobe vfio-pci\n")
+
+	pciDevicesRoot := filepath.Join(sysfsRoot, "bus", "pci", "devices")
+	for _, device := range devices {
+		if len(device.rebind) == 0 {
+			fmt.Fprintf(&sb, "\n# GPU %v (IOMMU group %d) is ready for passthrough\n", device.address, device.iommuGroup)
+			continue
+		}
+		fmt.Fprintf(&sb, "\n# Bind the devices in IOMMU group %d of GPU %v to %v\n", device.iommuGroup, device.address, vfio.PCIDriver)
+		for _, pci := range device.rebind {
+			path := filepath.Join(pciDevicesRoot, pci.address)
+			fmt.Fprintf(&sb, "echo %v > %v\n", vfio.PCIDriver, filepath.Join(path, "driver_override"))
+			if pci.driver != "" {
+				fmt.Fprintf(&sb, "echo %v > %v\n", pci.address, filepath.Join(path, "driver", "unbind"))
+			}
+			fmt.Fprintf(&sb, "echo %v > %v\n", pci.address, filepath.Join(sysfsRoot, "bus", "pci", "drivers_probe"))
+		}
+	}
+	return sb.String()
+}
+
+// cloudHypervisorArgs returns the Cloud Hypervisor command line arguments
+// that pass the selected GPUs through to the VM.
+func cloudHypervisorArgs(devices []*passthroughDevice) string {
+	var sb strings.Builder
+	for _, device := range devices {
+		fmt.Fprintf(&sb, "--device path=%v/\n", device.sysfsPath)
+	}
+	return sb.String()
+}
+
+func writeTo(path string, contents string) error {
+	var w io.Writer = os.Stdout
+	if path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	_, err := io.WriteString(w, contents)
+	return err
+}
@@ -0,0 +1,171 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package microvm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvpci"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+)
+
+type fakePCILib struct {
+	nvpci.Interface
+	gpus []*nvpci.NvidiaPCIDevice
+}
+
+func (f *fakePCILib) GetGPUs() ([]*nvpci.NvidiaPCIDevice, error) {
+	return f.gpus, nil
+}
+
+func TestGetPassthroughDevices(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description     string
+		setup           func(string) []*nvpci.NvidiaPCIDevice
+		devices         []string
+		expectedError   bool
+		expectedPrepare string
+		expectedArgs    string
+	}{
+		{
+			description: "disabled IOMMU returns error",
+			setup: func(sysfs string) []*nvpci.NvidiaPCIDevice {
+				return []*nvpci.NvidiaPCIDevice{
+					{Address: "0000:01:00.0", Driver: "nvidia", IommuGroup: -1},
+				}
+			},
+			devices:       []string{"all"},
+			expectedError: true,
+		},
+		{
+			description: "GPU and group members are rebound",
+			setup: func(sysfs string) []*nvpci.NvidiaPCIDevice {
+				test.CreatePCIDevice(t, sysfs, "0000:01:00.1", "10", "snd_hda_intel")
+				return []*nvpci.NvidiaPCIDevice{
+					{
+						Address:    "0000:01:00.0",
+						Driver:     "nvidia",
+						IommuGroup: 10,
+						Path:       test.CreatePCIDevice(t, sysfs, "0000:01:00.0", "10", "nvidia"),
+					},
+					{
+						Address:    "0000:02:00.0",
+						Driver:     "vfio-pci",
+						IommuGroup: 20,
+						Path:       test.CreatePCIDevice(t, sysfs, "0000:02:00.0", "20", "vfio-pci"),
+					},
+				}
+			},
+			devices: []string{"all"},
+			expectedPrepare: `#!/bin/sh
+# Generated by nvidia-ctk generate microvm.
+set -eu
+
+modprobe vfio-pci
+
+# Bind the devices in IOMMU group 10 of GPU 0000:01:00.0 to vfio-pci
+echo vfio-pci > {{ .SysfsRoot }}/bus/pci/devices/0000:01:00.0/driver_override
+echo 0000:01:00.0 > {{ .SysfsRoot }}/bus/pci/devices/0000:01:00.0/driver/unbind
+echo 0000:01:00.0 > {{ .SysfsRoot }}/bus/pci/drivers_probe
+echo vfio-pci > {{ .SysfsRoot }}/bus/pci/devices/0000:01:00.1/driver_override
+echo 0000:01:00.1 > {{ .SysfsRoot }}/bus/pci/devices/0000:01:00.1/driver/unbind
+echo 0000:01:00.1 > {{ .SysfsRoot }}/bus/pci/drivers_probe
+
+# GPU 0000:02:00.0 (IOMMU group 20) is ready for passthrough
+`,
+			expectedArgs: `--device path={{ .SysfsRoot }}/bus/pci/devices/0000:01:00.0/
+--device path={{ .SysfsRoot }}/bus/pci/devices/0000:02:00.0/
+`,
+		},
+		{
+			description: "GPU can be selected by PCI bus ID",
+			setup: func(sysfs string) []*nvpci.NvidiaPCIDevice {
+				return []*nvpci.NvidiaPCIDevice{
+					{
+						Address:    "0000:01:00.0",
+						Driver:     "nvidia",
+						IommuGroup: 10,
+						Path:       test.CreatePCIDevice(t, sysfs, "0000:01:00.0", "10", "nvidia"),
+					},
+					{
+						Address:    "0000:02:00.0",
+						Driver:     "vfio-pci",
+						IommuGroup: 20,
+						Path:       test.CreatePCIDevice(t, sysfs, "0000:02:00.0", "20", "vfio-pci"),
+					},
+				}
+			},
+			devices: []string{"0000:02:00.0"},
+			expectedPrepare: `#!/bin/sh
+# Generated by nvidia-ctk generate microvm.
+set -eu
+
+modprobe vfio-pci
+
+# GPU 0000:02:00.0 (IOMMU group 20) is ready for passthrough
+`,
+			expectedArgs: "--device path={{ .SysfsRoot }}/bus/pci/devices/0000:02:00.0/\n",
+		},
+		{
+			description: "invalid index returns error",
+			setup: func(sysfs string) []*nvpci.NvidiaPCIDevice {
+				return []*nvpci.NvidiaPCIDevice{
+					{
+						Address:    "0000:01:00.0",
+						Driver:     "nvidia",
+						IommuGroup: 10,
+						Path:       test.CreatePCIDevice(t, sysfs, "0000:01:00.0", "10", "nvidia"),
+					},
+				}
+			},
+			devices:       []string{"1"},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			sysfs := t.TempDir()
+			c := command{
+				logger: logger,
+			}
+			opts := &options{
+				devices:   tc.devices,
+				sysfsRoot: sysfs,
+				pcilib:    &fakePCILib{gpus: tc.setup(sysfs)},
+			}
+
+			devices, err := c.getPassthroughDevices(opts)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			replacer := strings.NewReplacer("{{ .SysfsRoot }}", sysfs)
+			require.Equal(t, replacer.Replace(tc.expectedPrepare), prepareScript(sysfs, devices))
+			require.Equal(t, replacer.Replace(tc.expectedArgs), cloudHypervisorArgs(devices))
+		})
+	}
+}
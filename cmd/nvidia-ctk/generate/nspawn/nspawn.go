@@ -0,0 +1,255 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nspawn
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	machine    string
+	devices    []string
+	driverRoot string
+	devRoot    string
+
+	nspawnOutput string
+	unitOutput   string
+}
+
+// NewCommand constructs an nspawn command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build creates the CLI command
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name:  "nspawn",
+		Usage: "Generate systemd-nspawn settings and a unit drop-in for accessing GPUs from a system container",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(&opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(&opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "machine",
+				Usage:       "the name of the machine (container) to generate the settings for",
+				Destination: &opts.machine,
+			},
+			&cli.StringSliceFlag{
+				Name:        "device",
+				Usage:       "the GPUs to make available. These are specified as for nvidia-ctk cdi generate (e.g. all, an index, or a UUID)",
+				Value:       []string{"all"},
+				Destination: &opts.devices,
+			},
+			&cli.StringFlag{
+				Name:        "driver-root",
+				Usage:       "Specify the NVIDIA GPU driver root to use when discovering the entities that should be bind-mounted",
+				Value:       "/",
+				Destination: &opts.driverRoot,
+			},
+			&cli.StringFlag{
+				Name:        "dev-root",
+				Usage:       "Specify the root where `/dev` is located. If this is not specified, the driver-root is assumed.",
+				Destination: &opts.devRoot,
+			},
+			&cli.StringFlag{
+				Name:        "nspawn-output",
+				Usage:       "the file to write the .nspawn settings to (e.g. /etc/systemd/nspawn/MACHINE.nspawn). If this is not specified, these are written to STDOUT",
+				Destination: &opts.nspawnOutput,
+			},
+			&cli.StringFlag{
+				Name:        "unit-output",
+				Usage:       "the file to write the systemd-nspawn@MACHINE.service drop-in to (e.g. /etc/systemd/system/systemd-nspawn@MACHINE.service.d/nvidia.conf). If this is not specified, this is written to STDOUT",
+				Destination: &opts.unitOutput,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(opts *options) error {
+	if opts.machine == "" {
+		return fmt.Errorf("a machine name must be specified")
+	}
+	if len(opts.devices) == 0 {
+		return fmt.Errorf("at least one device must be specified")
+	}
+	if opts.devRoot == "" {
+		opts.devRoot = opts.driverRoot
+	}
+	return nil
+}
+
+func (m command) run(opts *options) error {
+	cdilib, err := nvcdi.New(
+		nvcdi.WithLogger(m.logger),
+		nvcdi.WithDriverRoot(opts.driverRoot),
+		nvcdi.WithDevRoot(opts.devRoot),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create CDI library: %w", err)
+	}
+
+	deviceSpecs, err := cdilib.GetDeviceSpecsByID(opts.devices...)
+	if err != nil {
+		return fmt.Errorf("failed to get device specs: %w", err)
+	}
+	commonEdits, err := cdilib.GetCommonEdits()
+	if err != nil {
+		return fmt.Errorf("failed to get common edits: %w", err)
+	}
+
+	edits := []*specs.ContainerEdits{commonEdits.ContainerEdits}
+	for i := range deviceSpecs {
+		edits = append(edits, &deviceSpecs[i].ContainerEdits)
+	}
+	s := m.getSettings(edits...)
+
+	if err := writeTo(opts.nspawnOutput, s.nspawnFile(), "# "+opts.machine+".nspawn\n"); err != nil {
+		return fmt.Errorf("failed to write nspawn settings: %w", err)
+	}
+	if err := writeTo(opts.unitOutput, s.unitDropIn(), "# systemd-nspawn@"+opts.machine+".service drop-in\n"); err != nil {
+		return fmt.Errorf("failed to write unit drop-in: %w", err)
+	}
+	return nil
+}
+
+// settings define the nspawn settings required to make the GPUs accessible in
+// a system container.
+type settings struct {
+	env           []string
+	bind          []string
+	bindReadOnly  []string
+	deviceAllowed []string
+}
+
+// getSettings converts the specified CDI container edits to nspawn settings.
+// Device nodes are bind-mounted and allowed in the device cgroup of the
+// systemd-nspawn@.service unit. Since hooks cannot be expressed as
+// nspawn settings, these are skipped.
+func (m command) getSettings(edits ...*specs.ContainerEdits) *settings {
+	s := &settings{}
+	for _, e := range edits {
+		if e == nil {
+			continue
+		}
+		s.env = append(s.env, e.Env...)
+		for _, dn := range e.DeviceNodes {
+			hostPath := dn.HostPath
+			if hostPath == "" {
+				hostPath = dn.Path
+			}
+			s.bind = appendUnique(s.bind, bindSpec(hostPath, dn.Path))
+			s.deviceAllowed = appendUnique(s.deviceAllowed, hostPath+" rwm")
+		}
+		for _, mount := range e.Mounts {
+			spec := bindSpec(mount.HostPath, mount.ContainerPath)
+			if slices.Contains(mount.Options, "ro") {
+				s.bindReadOnly = appendUnique(s.bindReadOnly, spec)
+			} else {
+				s.bind = appendUnique(s.bind, spec)
+			}
+		}
+		for _, hook := range e.Hooks {
+			m.logger.Warningf("Skipping %v hook %v %v; this must be run in the container manually", hook.HookName, hook.Path, hook.Args)
+		}
+	}
+	return s
+}
+
+// nspawnFile returns the contents of the .nspawn settings file.
+func (s *settings) nspawnFile() string {
+	var b strings.Builder
+	if len(s.env) > 0 {
+		fmt.Fprintf(&b, "[Exec]\n")
+		for _, env := range s.env {
+			fmt.Fprintf(&b, "Environment=%s\n", env)
+		}
+		fmt.Fprintf(&b, "\n")
+	}
+	fmt.Fprintf(&b, "[Files]\n")
+	for _, bind := range s.bind {
+		fmt.Fprintf(&b, "Bind=%s\n", bind)
+	}
+	for _, bind := range s.bindReadOnly {
+		fmt.Fprintf(&b, "BindReadOnly=%s\n", bind)
+	}
+	return b.String()
+}
+
+// unitDropIn returns the contents of the drop-in for the
+// systemd-nspawn@.service unit.
+func (s *settings) unitDropIn() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[Service]\n")
+	for _, device := range s.deviceAllowed {
+		fmt.Fprintf(&b, "DeviceAllow=%s\n", device)
+	}
+	return b.String()
+}
+
+// bindSpec returns the nspawn bind specification for the specified paths.
+// Colons in paths are escaped as required by systemd-nspawn.
+func bindSpec(hostPath string, containerPath string) string {
+	escape := strings.NewReplacer(`\`, `\\`, ":", `\:`)
+	if hostPath == containerPath {
+		return escape.Replace(hostPath)
+	}
+	return escape.Replace(hostPath) + ":" + escape.Replace(containerPath)
+}
+
+func appendUnique(s []string, v string) []string {
+	if slices.Contains(s, v) {
+		return s
+	}
+	return append(s, v)
+}
+
+// writeTo writes the specified contents to the specified file. If no file is
+// specified, the contents are written to STDOUT with the specified header.
+func writeTo(path string, contents string, header string) error {
+	if path != "" {
+		return os.WriteFile(path, []byte(contents), 0644)
+	}
+	_, err := io.WriteString(os.Stdout, header+contents+"\n")
+	return err
+}
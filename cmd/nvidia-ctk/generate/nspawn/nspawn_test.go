@@ -0,0 +1,75 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nspawn
+
+import (
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+func TestSettings(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	common := &specs.ContainerEdits{
+		Env: []string{"NVIDIA_VISIBLE_DEVICES=void"},
+		DeviceNodes: []*specs.DeviceNode{
+			{Path: "/dev/nvidiactl", HostPath: "/dev/nvidiactl"},
+		},
+		Mounts: []*specs.Mount{
+			{HostPath: "/usr/lib/libcuda.so.1", ContainerPath: "/usr/lib/libcuda.so.1", Options: []string{"ro", "nosuid", "nodev", "bind"}},
+			{HostPath: "/run/nvidia-persistenced/socket", ContainerPath: "/run/nvidia-persistenced/socket", Options: []string{"nosuid", "nodev", "bind"}},
+		},
+		Hooks: []*specs.Hook{
+			{HookName: "createContainer", Path: "/usr/bin/nvidia-cdi-hook", Args: []string{"nvidia-cdi-hook", "update-ldcache"}},
+		},
+	}
+	device := &specs.ContainerEdits{
+		DeviceNodes: []*specs.DeviceNode{
+			{Path: "/dev/nvidia0", HostPath: "/driver/root/dev/nvidia0"},
+			{Path: "/dev/nvidiactl", HostPath: "/dev/nvidiactl"},
+		},
+	}
+
+	s := c.getSettings(common, device)
+
+	require.Equal(t, `[Exec]
+Environment=NVIDIA_VISIBLE_DEVICES=void
+
+[Files]
+Bind=/dev/nvidiactl
+Bind=/run/nvidia-persistenced/socket
+Bind=/driver/root/dev/nvidia0:/dev/nvidia0
+BindReadOnly=/usr/lib/libcuda.so.1
+`, s.nspawnFile())
+
+	require.Equal(t, `[Service]
+DeviceAllow=/dev/nvidiactl rwm
+DeviceAllow=/driver/root/dev/nvidia0 rwm
+`, s.unitDropIn())
+}
+
+func TestBindSpec(t *testing.T) {
+	require.Equal(t, `/dev/nvidia-caps/nvidia-cap1`, bindSpec("/dev/nvidia-caps/nvidia-cap1", "/dev/nvidia-caps/nvidia-cap1"))
+	require.Equal(t, `/host/a\:b:/a\:b`, bindSpec("/host/a:b", "/a:b"))
+}
@@ -0,0 +1,263 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package systemd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+	"tags.cncf.io/container-device-interface/pkg/parser"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+const (
+	formatQuadlet = "quadlet"
+	formatService = "service"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	name    string
+	image   string
+	gpus    []string
+	cdiKind string
+	format  string
+	podman  string
+	output  string
+
+	command []string
+}
+
+// NewCommand constructs a systemd command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build creates the CLI command
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name:      "systemd",
+		Usage:     "Generate a Podman quadlet or systemd service unit for running a GPU container",
+		ArgsUsage: "[COMMAND [ARG...]]",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(cmd, &opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(&opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "name",
+				Usage:       "the name of the container",
+				Destination: &opts.name,
+			},
+			&cli.StringFlag{
+				Name:        "image",
+				Usage:       "the image to run",
+				Destination: &opts.image,
+			},
+			&cli.StringSliceFlag{
+				Name: "gpus",
+				Usage: "the GPUs to inject into the container. " +
+					"These are either device names of the specified --cdi-kind (e.g. 0, all, or a UUID) or fully-qualified CDI device names",
+				Destination: &opts.gpus,
+			},
+			&cli.StringFlag{
+				Name:        "cdi-kind",
+				Usage:       "the CDI vendor and class for devices that are not fully-qualified",
+				Value:       "nvidia.com/gpu",
+				Destination: &opts.cdiKind,
+			},
+			&cli.StringFlag{
+				Name:        "format",
+				Usage:       "the format of the unit to generate. One of [" + strings.Join([]string{formatQuadlet, formatService}, " | ") + "]",
+				Value:       formatQuadlet,
+				Destination: &opts.format,
+			},
+			&cli.StringFlag{
+				Name:        "podman",
+				Usage:       "the path to the podman executable used in generated service units",
+				Value:       "/usr/bin/podman",
+				Destination: &opts.podman,
+			},
+			&cli.StringFlag{
+				Name:        "output",
+				Usage:       "the file to write the unit to. If this is not specified, the unit is written to STDOUT",
+				Destination: &opts.output,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(c *cli.Command, opts *options) error {
+	if opts.name == "" {
+		return fmt.Errorf("a container name must be specified")
+	}
+	if opts.image == "" {
+		return fmt.Errorf("an image must be specified")
+	}
+	if len(opts.gpus) == 0 {
+		return fmt.Errorf("at least one GPU must be specified")
+	}
+	switch opts.format {
+	case formatQuadlet, formatService:
+	default:
+		return fmt.Errorf("invalid format %q", opts.format)
+	}
+
+	vendor, class := parser.ParseQualifier(opts.cdiKind)
+	if err := parser.ValidateVendorName(vendor); err != nil {
+		return fmt.Errorf("invalid CDI kind %q: %w", opts.cdiKind, err)
+	}
+	if err := parser.ValidateClassName(class); err != nil {
+		return fmt.Errorf("invalid CDI kind %q: %w", opts.cdiKind, err)
+	}
+
+	var devices []string
+	for _, gpu := range opts.gpus {
+		device := gpu
+		if !parser.IsQualifiedName(device) {
+			device = parser.QualifiedName(vendor, class, gpu)
+		}
+		if _, _, _, err := parser.ParseQualifiedName(device); err != nil {
+			return fmt.Errorf("invalid GPU %q: %w", gpu, err)
+		}
+		devices = append(devices, device)
+	}
+	opts.gpus = devices
+
+	if c != nil {
+		opts.command = c.Args().Slice()
+	}
+	return nil
+}
+
+func (m command) run(opts *options) error {
+	var w io.Writer = os.Stdout
+	if opts.output != "" {
+		f, err := os.Create(opts.output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	var unit string
+	switch opts.format {
+	case formatQuadlet:
+		unit = opts.quadlet()
+	case formatService:
+		unit = opts.service()
+	}
+
+	if _, err := io.WriteString(w, unit); err != nil {
+		return fmt.Errorf("failed to write unit: %w", err)
+	}
+	if opts.output != "" {
+		m.logger.Infof("Generated %v unit %v", opts.format, opts.output)
+	}
+	return nil
+}
+
+// quadlet returns a Podman quadlet .container file for the container.
+// CDI devices are requested using the AddDevice key.
+func (opts *options) quadlet() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[Unit]\n")
+	fmt.Fprintf(&b, "Description=%s GPU container\n", opts.name)
+	fmt.Fprintf(&b, "\n[Container]\n")
+	fmt.Fprintf(&b, "ContainerName=%s\n", opts.name)
+	fmt.Fprintf(&b, "Image=%s\n", opts.image)
+	for _, device := range opts.gpus {
+		fmt.Fprintf(&b, "AddDevice=%s\n", device)
+	}
+	if len(opts.command) > 0 {
+		fmt.Fprintf(&b, "Exec=%s\n", quoteArgs(opts.command))
+	}
+	fmt.Fprintf(&b, "\n[Install]\n")
+	fmt.Fprintf(&b, "WantedBy=default.target\n")
+	return b.String()
+}
+
+// service returns a plain systemd service unit that uses podman to run the
+// container. No container engine daemon is required.
+func (opts *options) service() string {
+	args := []string{
+		opts.podman, "run",
+		"--rm",
+		"--replace",
+		"--cgroups=split",
+		"--sdnotify=conmon",
+		"--detach",
+		"--name", opts.name,
+	}
+	for _, device := range opts.gpus {
+		args = append(args, "--device", device)
+	}
+	args = append(args, opts.image)
+	args = append(args, opts.command...)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "[Unit]\n")
+	fmt.Fprintf(&b, "Description=%s GPU container\n", opts.name)
+	fmt.Fprintf(&b, "Wants=network-online.target\n")
+	fmt.Fprintf(&b, "After=network-online.target\n")
+	fmt.Fprintf(&b, "\n[Service]\n")
+	fmt.Fprintf(&b, "Environment=PODMAN_SYSTEMD_UNIT=%%n\n")
+	fmt.Fprintf(&b, "Restart=on-failure\n")
+	fmt.Fprintf(&b, "ExecStart=%s\n", quoteArgs(args))
+	fmt.Fprintf(&b, "ExecStop=%s\n", quoteArgs([]string{opts.podman, "stop", "--ignore", "--time", "10", opts.name}))
+	fmt.Fprintf(&b, "ExecStopPost=%s\n", quoteArgs([]string{opts.podman, "rm", "--force", "--ignore", opts.name}))
+	fmt.Fprintf(&b, "Type=notify\n")
+	fmt.Fprintf(&b, "NotifyAccess=all\n")
+	fmt.Fprintf(&b, "\n[Install]\n")
+	fmt.Fprintf(&b, "WantedBy=default.target\n")
+	return b.String()
+}
+
+// quoteArgs joins the specified arguments as a systemd command line.
+// Arguments containing whitespace or quotes are double-quoted and the % and $
+// characters are escaped so that these are not expanded by systemd.
+func quoteArgs(args []string) string {
+	var quoted []string
+	for _, arg := range args {
+		arg = strings.ReplaceAll(arg, "%", "%%")
+		arg = strings.ReplaceAll(arg, "$", "$$")
+		if arg == "" || strings.ContainsAny(arg, " \t\n\"'\\") {
+			arg = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(arg) + `"`
+		}
+		quoted = append(quoted, arg)
+	}
+	return strings.Join(quoted, " ")
+}
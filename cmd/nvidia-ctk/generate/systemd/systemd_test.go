@@ -0,0 +1,134 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package systemd
+
+import (
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateSystemd(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description   string
+		options       options
+		expectedError bool
+		expectedUnit  string
+	}{
+		{
+			description: "name is required",
+			options: options{
+				image:   "ubuntu",
+				gpus:    []string{"0"},
+				cdiKind: "nvidia.com/gpu",
+				format:  formatQuadlet,
+			},
+			expectedError: true,
+		},
+		{
+			description: "invalid GPU returns error",
+			options: options{
+				name:    "cuda",
+				image:   "ubuntu",
+				gpus:    []string{"gpu 0"},
+				cdiKind: "nvidia.com/gpu",
+				format:  formatQuadlet,
+			},
+			expectedError: true,
+		},
+		{
+			description: "quadlet with devices",
+			options: options{
+				name:    "cuda",
+				image:   "ubuntu",
+				gpus:    []string{"0", "example.com/device=foo"},
+				cdiKind: "nvidia.com/gpu",
+				format:  formatQuadlet,
+				command: []string{"nvidia-smi", "-L"},
+			},
+			expectedUnit: `[Unit]
+Description=cuda GPU container
+
+[Container]
+ContainerName=cuda
+Image=ubuntu
+AddDevice=nvidia.com/gpu=0
+AddDevice=example.com/device=foo
+Exec=nvidia-smi -L
+
+[Install]
+WantedBy=default.target
+`,
+		},
+		{
+			description: "service with quoted command",
+			options: options{
+				name:    "cuda",
+				image:   "ubuntu",
+				gpus:    []string{"all"},
+				cdiKind: "nvidia.com/gpu",
+				format:  formatService,
+				podman:  "/usr/bin/podman",
+				command: []string{"bash", "-c", "echo $HOME"},
+			},
+			expectedUnit: `[Unit]
+Description=cuda GPU container
+Wants=network-online.target
+After=network-online.target
+
+[Service]
+Environment=PODMAN_SYSTEMD_UNIT=%n
+Restart=on-failure
+ExecStart=/usr/bin/podman run --rm --replace --cgroups=split --sdnotify=conmon --detach --name cuda --device nvidia.com/gpu=all ubuntu bash -c "echo $$HOME"
+ExecStop=/usr/bin/podman stop --ignore --time 10 cuda
+ExecStopPost=/usr/bin/podman rm --force --ignore cuda
+Type=notify
+NotifyAccess=all
+
+[Install]
+WantedBy=default.target
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c := command{
+				logger: logger,
+			}
+			err := c.validateFlags(nil, &tc.options)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var unit string
+			switch tc.options.format {
+			case formatQuadlet:
+				unit = tc.options.quadlet()
+			case formatService:
+				unit = tc.options.service()
+			}
+			require.Equal(t, tc.expectedUnit, unit)
+		})
+	}
+}
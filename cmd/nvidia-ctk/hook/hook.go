@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-cdi-hook/commands"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/hook/run"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 
 	"github.com/urfave/cli/v3"
@@ -54,7 +55,7 @@ func (m hookCommand) build() *cli.Command {
 			commands.IssueUnsupportedHookWarning(m.logger, cmd)
 			return nil
 		},
-		Commands: commands.New(m.logger),
+		Commands: append(commands.New(m.logger), run.NewCommand(m.logger)),
 	}
 
 	return &hook
@@ -0,0 +1,337 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-cdi-hook/commands"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+const (
+	// containerID is the ID of the container in the generated container state.
+	containerID = "nvidia-ctk-hook-run"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	bundle string
+	rootfs string
+	hook   string
+	args   []string
+}
+
+// NewCommand constructs a hook run command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name:      "run",
+		Usage:     "Run a CDI hook against a bundle on the host and report the changes made to its root filesystem",
+		ArgsUsage: "[-- HOOK_ARGS...]",
+		Description: `Run the specified CDI hook against a bundle without launching a container. This allows the
+behavior of the hooks referenced in a CDI specification to be tested. For example:
+
+nvidia-ctk --debug hook run --bundle=/tmp/bundle --hook=create-symlinks -- --link=libcuda.so.1::/usr/lib64/libcuda.so
+
+If the bundle does not contain an OCI spec (config.json), a minimal spec referring to the
+specified root filesystem is used.`,
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			opts.args = cmd.Args().Slice()
+			return ctx, m.validateFlags(&opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(ctx, &opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "bundle",
+				Usage:       "Specify the path to the OCI bundle to run the hook against",
+				Destination: &opts.bundle,
+				Required:    true,
+			},
+			&cli.StringFlag{
+				Name:        "rootfs",
+				Usage:       "Specify the root filesystem to use if the bundle does not contain an OCI spec. Relative paths are relative to the bundle. This is ignored if the bundle contains a config.json file.",
+				Value:       "rootfs",
+				Destination: &opts.rootfs,
+			},
+			&cli.StringFlag{
+				Name:        "hook",
+				Usage:       "Specify the name of the CDI hook to run; for example update-ldcache",
+				Destination: &opts.hook,
+				Required:    true,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(opts *options) error {
+	if !isSupportedHook(m.logger, opts.hook) {
+		return fmt.Errorf("unsupported CDI hook %q", opts.hook)
+	}
+	return nil
+}
+
+func (m command) run(ctx context.Context, opts *options) error {
+	bundle, cleanup, err := m.prepareBundle(opts.bundle, opts.rootfs)
+	if err != nil {
+		return fmt.Errorf("failed to prepare bundle: %w", err)
+	}
+	defer cleanup()
+
+	state := &specs.State{
+		Version: specs.Version,
+		ID:      containerID,
+		Status:  specs.StateCreating,
+		Bundle:  bundle,
+	}
+	stateFile, err := writeContainerState(state)
+	if err != nil {
+		return fmt.Errorf("failed to write container state: %w", err)
+	}
+	defer os.Remove(stateFile)
+
+	containerRoot, err := (*oci.State)(state).GetContainerRoot()
+	if err != nil {
+		return fmt.Errorf("failed to determine container root: %w", err)
+	}
+	m.logger.Infof("Running hook %v against container root %v", opts.hook, containerRoot)
+	m.logger.Debugf("Container state: %+v", state)
+
+	before, err := snapshot(containerRoot)
+	if err != nil {
+		return fmt.Errorf("failed to inspect container root: %w", err)
+	}
+
+	args := append([]string{"nvidia-cdi-hook", opts.hook, "--container-spec", stateFile}, opts.args...)
+	m.logger.Infof("Invoking %v", strings.Join(args, " "))
+	hookErr := newHookCommand(m.logger).Run(ctx, args)
+	if hookErr != nil {
+		m.logger.Warningf("Hook %v failed: %v", opts.hook, hookErr)
+	}
+
+	after, err := snapshot(containerRoot)
+	if err != nil {
+		return errors.Join(hookErr, fmt.Errorf("failed to inspect container root: %w", err))
+	}
+	changes := diff(before, after)
+	if len(changes) == 0 {
+		m.logger.Infof("No changes made to the container root")
+	}
+	for _, change := range changes {
+		m.logger.Infof("%v", change)
+	}
+
+	return hookErr
+}
+
+// prepareBundle returns the path to a bundle containing an OCI spec. If the
+// specified bundle does not contain an OCI spec, a temporary bundle is created
+// with a minimal spec that refers to the specified root filesystem. This
+// ensures that the input bundle is not modified.
+func (m command) prepareBundle(bundle string, rootfs string) (string, func(), error) {
+	bundle, err := filepath.Abs(bundle)
+	if err != nil {
+		return "", nil, err
+	}
+	if _, err := os.Stat(oci.GetSpecFilePath(bundle)); err == nil {
+		return bundle, func() {}, nil
+	} else if !os.IsNotExist(err) {
+		return "", nil, err
+	}
+
+	if !filepath.IsAbs(rootfs) {
+		rootfs = filepath.Join(bundle, rootfs)
+	}
+	if info, err := os.Stat(rootfs); err != nil {
+		return "", nil, fmt.Errorf("failed to stat root filesystem: %w", err)
+	} else if !info.IsDir() {
+		return "", nil, fmt.Errorf("root filesystem %v is not a directory", rootfs)
+	}
+	m.logger.Infof("No OCI spec found in %v; using root filesystem %v", bundle, rootfs)
+
+	tmpBundle, err := os.MkdirTemp("", "nvidia-ctk-hook-run-")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() {
+		_ = os.RemoveAll(tmpBundle)
+	}
+
+	spec := specs.Spec{
+		Version: specs.Version,
+		Root: &specs.Root{
+			Path: rootfs,
+		},
+	}
+	contents, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	if err := os.WriteFile(oci.GetSpecFilePath(tmpBundle), contents, 0600); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return tmpBundle, cleanup, nil
+}
+
+// writeContainerState writes the specified container state to a temporary
+// file. This is passed to the hook instead of STDIN.
+func writeContainerState(state *specs.State) (string, error) {
+	f, err := os.CreateTemp("", "nvidia-ctk-hook-run-state-*.json")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if err := json.NewEncoder(f).Encode(state); err != nil {
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// newHookCommand constructs a command that runs the supported CDI hooks in the
+// same way as the nvidia-cdi-hook executable.
+func newHookCommand(logger logger.Interface) *cli.Command {
+	return &cli.Command{
+		Name:     "nvidia-cdi-hook",
+		Commands: commands.New(logger),
+	}
+}
+
+func isSupportedHook(logger logger.Interface, name string) bool {
+	for _, c := range commands.New(logger) {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// An entry records the metadata of a path in a root filesystem.
+type entry struct {
+	mode   fs.FileMode
+	size   int64
+	target string
+}
+
+// snapshot records the metadata of all paths in the specified root.
+func snapshot(root string) (map[string]entry, error) {
+	entries := make(map[string]entry)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		e := entry{
+			mode: info.Mode(),
+		}
+		if info.Mode().IsRegular() {
+			e.size = info.Size()
+		}
+		if info.Mode()&fs.ModeSymlink != 0 {
+			e.target, err = os.Readlink(path)
+			if err != nil {
+				return err
+			}
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		entries[filepath.Join("/", rel)] = e
+		return nil
+	})
+	return entries, err
+}
+
+// A change describes a modification to a path in a root filesystem.
+type change struct {
+	path        string
+	description string
+}
+
+func (c change) String() string {
+	return c.description
+}
+
+// diff returns the changes between two snapshots of a root filesystem ordered
+// by path.
+func diff(before map[string]entry, after map[string]entry) []change {
+	var changes []change
+	for path, a := range after {
+		b, ok := before[path]
+		var description string
+		switch {
+		case !ok && a.target != "":
+			description = fmt.Sprintf("Created symlink %v -> %v", path, a.target)
+		case !ok:
+			description = fmt.Sprintf("Created %v (%v)", path, a.mode)
+		case a.target != b.target:
+			description = fmt.Sprintf("Changed symlink %v -> %v (was %v)", path, a.target, b.target)
+		case a.mode != b.mode:
+			description = fmt.Sprintf("Changed mode of %v to %v (was %v)", path, a.mode, b.mode)
+		case a.size != b.size:
+			description = fmt.Sprintf("Modified %v", path)
+		default:
+			continue
+		}
+		changes = append(changes, change{path: path, description: description})
+	}
+	for path := range before {
+		if _, ok := after[path]; !ok {
+			changes = append(changes, change{path: path, description: fmt.Sprintf("Removed %v", path)})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].path < changes[j].path
+	})
+	return changes
+}
@@ -0,0 +1,173 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package run
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	logger, hook := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description     string
+		withSpec        bool
+		opts            options
+		expectedError   bool
+		expectedLink    string
+		expectedChanges []string
+	}{
+		{
+			description: "hook is run against rootfs in bundle without spec",
+			opts: options{
+				rootfs: "rootfs",
+				hook:   "create-symlinks",
+				args:   []string{"--link", "libcuda.so.1::/usr/lib64/libcuda.so"},
+			},
+			expectedLink:    "libcuda.so.1",
+			expectedChanges: []string{"Created symlink /usr/lib64/libcuda.so -> libcuda.so.1"},
+		},
+		{
+			description: "hook is run against root of spec in bundle",
+			withSpec:    true,
+			opts: options{
+				rootfs: "ignored",
+				hook:   "create-symlinks",
+				args:   []string{"--link", "libcuda.so.1::/usr/lib64/libcuda.so"},
+			},
+			expectedLink:    "libcuda.so.1",
+			expectedChanges: []string{"Created symlink /usr/lib64/libcuda.so -> libcuda.so.1"},
+		},
+		{
+			description: "hook error is returned",
+			opts: options{
+				rootfs: "rootfs",
+				hook:   "create-symlinks",
+				args:   []string{"--link", "invalid"},
+			},
+			expectedError:   true,
+			expectedChanges: []string{"No changes made to the container root"},
+		},
+		{
+			description: "missing rootfs returns error",
+			opts: options{
+				rootfs: "missing",
+				hook:   "create-symlinks",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			hook.Reset()
+			bundle := t.TempDir()
+			rootfs := filepath.Join(bundle, "rootfs")
+			require.NoError(t, os.MkdirAll(filepath.Join(rootfs, "usr", "lib64"), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(rootfs, "usr", "lib64", "libcuda.so.1"), nil, 0600))
+			if tc.withSpec {
+				require.NoError(t, os.WriteFile(filepath.Join(bundle, "config.json"), []byte(`{"root": {"path": "rootfs"}}`), 0600))
+			}
+
+			opts := tc.opts
+			opts.bundle = bundle
+
+			c := command{logger: logger}
+			require.NoError(t, c.validateFlags(&opts))
+			err := c.run(context.Background(), &opts)
+			if tc.expectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			if tc.expectedLink != "" {
+				target, err := os.Readlink(filepath.Join(rootfs, "usr", "lib64", "libcuda.so"))
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedLink, target)
+			}
+
+			var messages []string
+			for _, entry := range hook.AllEntries() {
+				messages = append(messages, entry.Message)
+			}
+			for _, expected := range tc.expectedChanges {
+				require.Contains(t, messages, expected)
+			}
+
+			entries, err := os.ReadDir(bundle)
+			require.NoError(t, err)
+			require.Len(t, entries, len(bundleContents(tc.withSpec)))
+		})
+	}
+}
+
+func TestValidateFlags(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	c := command{logger: logger}
+
+	require.NoError(t, c.validateFlags(&options{hook: "update-ldcache"}))
+	require.Error(t, c.validateFlags(&options{hook: "unsupported"}))
+}
+
+func TestDiff(t *testing.T) {
+	before := map[string]entry{
+		"/":        {mode: os.ModeDir | 0755},
+		"/removed": {mode: 0644},
+		"/file":    {mode: 0644, size: 1},
+		"/chmod":   {mode: 0644},
+		"/link":    {mode: os.ModeSymlink | 0777, target: "a"},
+	}
+	after := map[string]entry{
+		"/":        {mode: os.ModeDir | 0755},
+		"/file":    {mode: 0644, size: 2},
+		"/chmod":   {mode: 0755},
+		"/link":    {mode: os.ModeSymlink | 0777, target: "b"},
+		"/created": {mode: 0644},
+	}
+
+	var changes []string
+	for _, c := range diff(before, after) {
+		changes = append(changes, c.String())
+	}
+	require.EqualValues(t,
+		[]string{
+			"Changed mode of /chmod to -rwxr-xr-x (was -rw-r--r--)",
+			"Created /created (-rw-r--r--)",
+			"Modified /file",
+			"Changed symlink /link -> b (was a)",
+			"Removed /removed",
+		},
+		changes,
+	)
+}
+
+// bundleContents returns the expected entries of the test bundle after
+// running a hook. The bundle must not be modified by the command.
+func bundleContents(withSpec bool) []string {
+	if withSpec {
+		return []string{"config.json", "rootfs"}
+	}
+	return []string{"rootfs"}
+}
@@ -0,0 +1,50 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package image
+
+import (
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/image/inspect"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+type imageCommand struct {
+	logger logger.Interface
+}
+
+// NewCommand constructs an image command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := imageCommand{
+		logger: logger,
+	}
+	return c.build()
+}
+
+func (m imageCommand) build() *cli.Command {
+	// Create the 'image' command
+	image := cli.Command{
+		Name:  "image",
+		Usage: "A collection of utilities for checking container images against the NVIDIA driver on a host",
+		Commands: []*cli.Command{
+			inspect.NewCommand(m.logger),
+		},
+	}
+
+	return &image
+}
@@ -0,0 +1,373 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package inspect
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/requirements"
+)
+
+const (
+	// cudaVersionLabel is the label used by the CUDA base images to record the
+	// CUDA version.
+	cudaVersionLabel = "com.nvidia.cuda.version"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	engine string
+	image  string
+}
+
+// NewCommand constructs an image inspect command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name:      "inspect",
+		Usage:     "Check whether the CUDA requirements of an image (NVIDIA_REQUIRE_* and CUDA_VERSION) are met by the NVIDIA driver and GPUs on this host",
+		ArgsUsage: "IMAGE",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			opts.image = cmd.Args().First()
+			return ctx, m.validateFlags(&opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			config, err := getImageConfig(ctx, opts.engine, opts.image)
+			if err != nil {
+				return fmt.Errorf("failed to inspect image %v: %w", opts.image, err)
+			}
+			host, err := getHostProperties(nvml.New())
+			if err != nil {
+				return fmt.Errorf("failed to get host properties: %w", err)
+			}
+			return m.run(os.Stdout, opts.image, config, host)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "engine",
+				Usage:       "the container engine used to inspect the image. One of [docker, podman, nerdctl]",
+				Value:       "docker",
+				Destination: &opts.engine,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(opts *options) error {
+	if opts.image == "" {
+		return fmt.Errorf("an image must be specified")
+	}
+	switch opts.engine {
+	case "docker", "podman", "nerdctl":
+	default:
+		return fmt.Errorf("unsupported container engine %q", opts.engine)
+	}
+	return nil
+}
+
+// An imageConfig represents the parts of the image config relevant to the
+// requirement checks.
+type imageConfig struct {
+	Env    []string          `json:"Env"`
+	Labels map[string]string `json:"Labels"`
+}
+
+// getImageConfig uses the specified container engine to inspect the image.
+// The image must be available locally.
+func getImageConfig(ctx context.Context, engine string, imageName string) (*imageConfig, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, engine, "image", "inspect", imageName)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%v image inspect failed: %w: %v", engine, err, strings.TrimSpace(stderr.String()))
+	}
+
+	var inspected []struct {
+		Config imageConfig `json:"Config"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &inspected); err != nil {
+		return nil, fmt.Errorf("failed to parse image inspect output: %w", err)
+	}
+	if len(inspected) == 0 {
+		return nil, fmt.Errorf("image not found")
+	}
+	return &inspected[0].Config, nil
+}
+
+// environment returns the environment of the image. Requirements that are
+// only specified as labels are included as if they were set as environment
+// variables with environment variables taking precedence.
+func (c *imageConfig) environment() []string {
+	env := append([]string{}, c.Env...)
+	isSet := make(map[string]bool)
+	for _, e := range c.Env {
+		name, _, _ := strings.Cut(e, "=")
+		isSet[name] = true
+	}
+	for name, value := range c.Labels {
+		if name == cudaVersionLabel {
+			name = image.EnvVarCudaVersion
+		}
+		if !strings.HasPrefix(name, image.NvidiaRequirePrefix) && name != image.EnvVarCudaVersion && name != image.EnvVarNvidiaDisableRequire {
+			continue
+		}
+		if isSet[name] {
+			continue
+		}
+		env = append(env, name+"="+value)
+		isSet[name] = true
+	}
+	return env
+}
+
+// hostProperties stores the driver and GPU properties that requirements are
+// checked against.
+type hostProperties struct {
+	driverVersion string
+	cudaVersion   string
+	devices       []deviceProperties
+}
+
+type deviceProperties struct {
+	index string
+	name  string
+	arch  string
+	brand string
+}
+
+// getHostProperties queries the driver and GPU properties using NVML.
+func getHostProperties(nvmllib nvml.Interface) (*hostProperties, error) {
+	if ret := nvmllib.Init(); ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to initialize NVML: %v", ret)
+	}
+	defer func() {
+		_ = nvmllib.Shutdown()
+	}()
+
+	driverVersion, ret := nvmllib.SystemGetDriverVersion()
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get driver version: %v", ret)
+	}
+	cudaDriverVersion, ret := nvmllib.SystemGetCudaDriverVersion()
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get CUDA driver version: %v", ret)
+	}
+
+	host := &hostProperties{
+		driverVersion: driverVersion,
+		cudaVersion:   fmt.Sprintf("%d.%d", cudaDriverVersion/1000, (cudaDriverVersion%1000)/10),
+	}
+
+	count, ret := nvmllib.DeviceGetCount()
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get device count: %v", ret)
+	}
+	for i := 0; i < count; i++ {
+		device, ret := nvmllib.DeviceGetHandleByIndex(i)
+		if ret != nvml.SUCCESS {
+			return nil, fmt.Errorf("failed to get device %d: %v", i, ret)
+		}
+		name, ret := device.GetName()
+		if ret != nvml.SUCCESS {
+			return nil, fmt.Errorf("failed to get name of device %d: %v", i, ret)
+		}
+		major, minor, ret := device.GetCudaComputeCapability()
+		if ret != nvml.SUCCESS {
+			return nil, fmt.Errorf("failed to get compute capability of device %d: %v", i, ret)
+		}
+		brand, ret := device.GetBrand()
+		if ret != nvml.SUCCESS {
+			return nil, fmt.Errorf("failed to get brand of device %d: %v", i, ret)
+		}
+		host.devices = append(host.devices, deviceProperties{
+			index: strconv.Itoa(i),
+			name:  name,
+			arch:  fmt.Sprintf("%d.%d", major, minor),
+			brand: brandName(brand),
+		})
+	}
+	return host, nil
+}
+
+// brandName returns the name used for the specified brand in requirements.
+func brandName(brand nvml.BrandType) string {
+	switch brand {
+	case nvml.BRAND_QUADRO:
+		return "quadro"
+	case nvml.BRAND_TESLA:
+		return "tesla"
+	case nvml.BRAND_NVS:
+		return "nvs"
+	case nvml.BRAND_GRID:
+		return "grid"
+	case nvml.BRAND_GEFORCE:
+		return "geforce"
+	case nvml.BRAND_TITAN:
+		return "titan"
+	case nvml.BRAND_NVIDIA_VAPPS:
+		return "vapps"
+	case nvml.BRAND_NVIDIA_VPC:
+		return "vpc"
+	case nvml.BRAND_NVIDIA_VCS:
+		return "vcs"
+	case nvml.BRAND_NVIDIA_VWS:
+		return "vws"
+	case nvml.BRAND_NVIDIA_CLOUD_GAMING:
+		return "cloudgaming"
+	case nvml.BRAND_QUADRO_RTX:
+		return "quadrortx"
+	case nvml.BRAND_NVIDIA_RTX:
+		return "nvidiartx"
+	case nvml.BRAND_NVIDIA:
+		return "nvidia"
+	case nvml.BRAND_GEFORCE_RTX:
+		return "geforcertx"
+	case nvml.BRAND_TITAN_RTX:
+		return "titanrtx"
+	default:
+		return "unknown"
+	}
+}
+
+// A deviceResult records the outcome of the requirement checks for a device.
+type deviceResult struct {
+	device deviceProperties
+	err    error
+}
+
+func (m command) run(w io.Writer, imageName string, config *imageConfig, host *hostProperties) error {
+	cudaImage, err := image.New(
+		image.WithLogger(m.logger),
+		image.WithEnv(config.environment()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to construct image: %w", err)
+	}
+
+	requirementsList, err := cudaImage.GetRequirements()
+	if err != nil {
+		return fmt.Errorf("failed to get image requirements: %w", err)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Image:\t%v\n", imageName)
+	fmt.Fprintf(tw, "Driver version:\t%v\n", host.driverVersion)
+	fmt.Fprintf(tw, "CUDA driver version:\t%v\n", host.cudaVersion)
+	for _, r := range requirementsList {
+		fmt.Fprintf(tw, "Requirement:\t%v\n", r)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	switch {
+	case cudaImage.HasDisableRequire():
+		_, err := fmt.Fprintf(w, "\nRequirement checks are disabled for this image (%v is set)\n", image.EnvVarNvidiaDisableRequire)
+		return err
+	case len(requirementsList) == 0:
+		_, err := fmt.Fprintf(w, "\nThe image does not specify any CUDA requirements\n")
+		return err
+	case len(host.devices) == 0:
+		return fmt.Errorf("no GPUs found on this host")
+	}
+
+	results := m.checkRequirements(requirementsList, host)
+
+	var failed int
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "\nGPU\tNAME\tARCH\tBRAND\tRESULT\n")
+	for _, r := range results {
+		result := "OK"
+		if r.err != nil {
+			result = r.err.Error()
+			failed++
+		}
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\n", r.device.index, r.device.name, r.device.arch, r.device.brand, result)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("the requirements of image %v are not met by %d of %d GPU(s)", imageName, failed, len(results))
+	}
+	_, err = fmt.Fprintf(w, "\nThe requirements of the image are met by all GPUs\n")
+	return err
+}
+
+// checkRequirements checks the specified requirements against the properties
+// of each of the GPUs on the host.
+func (m command) checkRequirements(requirementsList []string, host *hostProperties) []deviceResult {
+	var results []deviceResult
+	for _, device := range host.devices {
+		r := requirements.New(m.logger, requirementsList)
+		r.AddVersionProperty(requirements.CUDA, host.cudaVersion)
+		r.AddVersionProperty(requirements.DRIVER, normalizeVersion(host.driverVersion))
+		r.AddVersionProperty(requirements.ARCH, device.arch)
+		r.AddStringProperty(requirements.BRAND, device.brand)
+
+		results = append(results, deviceResult{
+			device: device,
+			err:    r.Assert(),
+		})
+	}
+	return results
+}
+
+// normalizeVersion removes leading zeros from the components of a driver
+// version (e.g. 535.104.05) so that it can be compared as a semantic version.
+func normalizeVersion(version string) string {
+	parts := strings.Split(version, ".")
+	for i, part := range parts {
+		if trimmed := strings.TrimLeft(part, "0"); trimmed != "" {
+			parts[i] = trimmed
+		} else if part != "" {
+			parts[i] = "0"
+		}
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,188 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package inspect
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	host := &hostProperties{
+		driverVersion: "535.104.05",
+		cudaVersion:   "12.2",
+		devices: []deviceProperties{
+			{index: "0", name: "NVIDIA A100-SXM4-40GB", arch: "8.0", brand: "nvidia"},
+			{index: "1", name: "Tesla T4", arch: "7.5", brand: "tesla"},
+		},
+	}
+
+	testCases := []struct {
+		description    string
+		config         *imageConfig
+		expectedError  bool
+		expectedOutput []string
+	}{
+		{
+			description: "image without requirements",
+			config: &imageConfig{
+				Env: []string{"PATH=/usr/bin"},
+			},
+			expectedOutput: []string{"The image does not specify any CUDA requirements"},
+		},
+		{
+			description: "requirements are met",
+			config: &imageConfig{
+				Env: []string{"NVIDIA_REQUIRE_CUDA=cuda>=12.0 brand=tesla,driver>=470,driver<471"},
+			},
+			expectedOutput: []string{
+				"Requirement:          cuda>=12.0 brand=tesla,driver>=470,driver<471",
+				"The requirements of the image are met by all GPUs",
+			},
+		},
+		{
+			description: "driver too old",
+			config: &imageConfig{
+				Env: []string{"NVIDIA_REQUIRE_CUDA=cuda>=12.4"},
+			},
+			expectedError: true,
+		},
+		{
+			description: "legacy CUDA_VERSION is checked",
+			config: &imageConfig{
+				Env: []string{"CUDA_VERSION=12.4.1"},
+			},
+			expectedError:  true,
+			expectedOutput: []string{"Requirement:          cuda>=12.4"},
+		},
+		{
+			description: "requirement only met by some GPUs",
+			config: &imageConfig{
+				Env: []string{"NVIDIA_REQUIRE_ARCH=arch>=8.0"},
+			},
+			expectedError: true,
+		},
+		{
+			description: "label requirements are checked",
+			config: &imageConfig{
+				Labels: map[string]string{"NVIDIA_REQUIRE_CUDA": "cuda>=12.4"},
+			},
+			expectedError: true,
+		},
+		{
+			description: "requirements are disabled",
+			config: &imageConfig{
+				Env: []string{"NVIDIA_REQUIRE_CUDA=cuda>=12.4", "NVIDIA_DISABLE_REQUIRE=true"},
+			},
+			expectedOutput: []string{"Requirement checks are disabled for this image"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c := command{logger: logger}
+
+			output := &bytes.Buffer{}
+			err := c.run(output, "example/image", tc.config, host)
+			if tc.expectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			for _, expected := range tc.expectedOutput {
+				require.Contains(t, output.String(), expected)
+			}
+		})
+	}
+}
+
+func TestEnvironment(t *testing.T) {
+	config := &imageConfig{
+		Env: []string{"NVIDIA_REQUIRE_CUDA=cuda>=12.0", "PATH=/usr/bin"},
+		Labels: map[string]string{
+			"NVIDIA_REQUIRE_CUDA":     "cuda>=11.0",
+			"com.nvidia.cuda.version": "12.2.0",
+			"maintainer":              "NVIDIA CORPORATION",
+		},
+	}
+
+	require.ElementsMatch(t,
+		[]string{"NVIDIA_REQUIRE_CUDA=cuda>=12.0", "PATH=/usr/bin", "CUDA_VERSION=12.2.0"},
+		config.environment(),
+	)
+}
+
+func TestGetHostProperties(t *testing.T) {
+	device := &mock.Device{
+		GetNameFunc: func() (string, nvml.Return) {
+			return "Tesla T4", nvml.SUCCESS
+		},
+		GetCudaComputeCapabilityFunc: func() (int, int, nvml.Return) {
+			return 7, 5, nvml.SUCCESS
+		},
+		GetBrandFunc: func() (nvml.BrandType, nvml.Return) {
+			return nvml.BRAND_TESLA, nvml.SUCCESS
+		},
+	}
+	nvmllib := &mock.Interface{
+		InitFunc: func() nvml.Return {
+			return nvml.SUCCESS
+		},
+		ShutdownFunc: func() nvml.Return {
+			return nvml.SUCCESS
+		},
+		SystemGetDriverVersionFunc: func() (string, nvml.Return) {
+			return "535.104.05", nvml.SUCCESS
+		},
+		SystemGetCudaDriverVersionFunc: func() (int, nvml.Return) {
+			return 12020, nvml.SUCCESS
+		},
+		DeviceGetCountFunc: func() (int, nvml.Return) {
+			return 1, nvml.SUCCESS
+		},
+		DeviceGetHandleByIndexFunc: func(int) (nvml.Device, nvml.Return) {
+			return device, nvml.SUCCESS
+		},
+	}
+
+	host, err := getHostProperties(nvmllib)
+	require.NoError(t, err)
+	require.EqualValues(t,
+		&hostProperties{
+			driverVersion: "535.104.05",
+			cudaVersion:   "12.2",
+			devices: []deviceProperties{
+				{index: "0", name: "Tesla T4", arch: "7.5", brand: "tesla"},
+			},
+		},
+		host,
+	)
+}
+
+func TestNormalizeVersion(t *testing.T) {
+	require.Equal(t, "535.104.5", normalizeVersion("535.104.05"))
+	require.Equal(t, "550.0.1", normalizeVersion("550.00.01"))
+	require.Equal(t, "470", normalizeVersion("470"))
+}
@@ -22,14 +22,9 @@ import (
 
 	"github.com/sirupsen/logrus"
 
-	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi"
-	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/config"
-	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/hook"
-	infoCLI "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/info"
-	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/runtime"
-	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/system"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/completion"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/info"
-	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/messages"
 
 	cli "github.com/urfave/cli/v3"
 )
@@ -57,8 +52,11 @@ func main() {
 		Name:                      "NVIDIA Container Toolkit CLI",
 		UseShortOptionHandling:    true,
 		EnableShellCompletion:     true,
-		Usage:                     "Tools to configure the NVIDIA Container Toolkit",
-		Version:                   info.GetVersionString(),
+		// The shell completion scripts are generated for the nvidia-ctk
+		// executable instead of the name of the CLI.
+		ConfigureShellCompletionCommand: completion.Configure("nvidia-ctk"),
+		Usage:                           "Tools to configure the NVIDIA Container Toolkit",
+		Version:                         info.GetVersionString(),
 		// Set log-level for all subcommands
 		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
 			logLevel := logrus.InfoLevel
@@ -94,24 +92,26 @@ func main() {
 				Destination: &opts.Config,
 				Sources:     cli.EnvVars("NVIDIA_CTK_CONFIG"),
 			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "Specify the output format for --version. One of [text, json]",
+				Value: "text",
+				Local: true,
+			},
 		},
 	}
 
+	// Allow the version to be output as JSON using --version --output=json.
+	cli.VersionPrinter = func(cmd *cli.Command) {
+		if err := info.WriteVersion(cmd.Root().Writer, cmd.Root().Name, cmd.Root().String("output")); err != nil {
+			logger.Errorf("%v", err)
+		}
+	}
+
 	// Run the CLI
 	err := c.Run(context.Background(), os.Args)
 	if err != nil {
-		logger.Errorf("%v", err)
+		messages.Log(logger, err)
 		os.Exit(1)
 	}
 }
-
-func getCommands(logger logger.Interface, configFilePath *string) []*cli.Command {
-	return []*cli.Command{
-		hook.NewCommand(logger),
-		runtime.NewCommand(logger),
-		infoCLI.NewCommand(logger),
-		cdi.NewCommand(logger, configFilePath),
-		system.NewCommand(logger),
-		config.NewCommand(logger),
-	}
-}
@@ -0,0 +1,360 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package analyze
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/audit"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+const (
+	levelInfo    = "info"
+	levelWarning = "warning"
+
+	// maxListedBundles is the maximum number of container bundles that are
+	// listed for a single finding.
+	maxListedBundles = 5
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	config     string
+	auditLog   string
+	targetMode string
+	since      time.Duration
+
+	now func() time.Time
+}
+
+// A finding describes a single difference in behavior that would result from
+// switching the node to the target mode.
+type finding struct {
+	level   string
+	message string
+	bundles []string
+}
+
+// A report summarizes the findings for the node configuration and for the
+// containers recorded in the audit log.
+type report struct {
+	configuredMode string
+	targetMode     string
+
+	node []finding
+
+	auditLog       string
+	launches       int
+	legacyLaunches int
+	containers     []finding
+}
+
+// NewCommand constructs an analyze command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build creates the CLI command
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name: "analyze",
+		Usage: "Report what would change if this node switched from legacy mode to cdi or jit-cdi mode based on the " +
+			"node configuration and the container launches recorded in the audit log",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(&opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(&opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "config",
+				Usage:       "Specify the NVIDIA Container Toolkit config file to analyze",
+				Value:       config.GetConfigFilePath(),
+				Destination: &opts.config,
+			},
+			&cli.StringFlag{
+				Name:        "audit-log",
+				Usage:       "Specify the audit log to read container launches from. If this is not specified, the nvidia-container-runtime.audit-log setting from the config is used",
+				Destination: &opts.auditLog,
+			},
+			&cli.StringFlag{
+				Name:        "target-mode",
+				Usage:       "the mode that the node would be switched to. One of [cdi | jit-cdi]",
+				Value:       string(info.JitCDIRuntimeMode),
+				Destination: &opts.targetMode,
+			},
+			&cli.DurationFlag{
+				Name:        "since",
+				Usage:       "only consider container launches within the specified duration. A value of 0 considers all recorded launches",
+				Value:       7 * 24 * time.Hour,
+				Destination: &opts.since,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(opts *options) error {
+	switch info.RuntimeMode(opts.targetMode) {
+	case info.CDIRuntimeMode, info.JitCDIRuntimeMode:
+	default:
+		return fmt.Errorf("unsupported target mode %q", opts.targetMode)
+	}
+	if opts.since < 0 {
+		return fmt.Errorf("the since duration must not be negative")
+	}
+	return nil
+}
+
+func (m command) run(opts *options) error {
+	cfgToml, err := config.New(config.WithConfigFile(opts.config))
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	cfg, err := cfgToml.Config()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	r, err := m.analyze(cfg, opts)
+	if err != nil {
+		return err
+	}
+	return r.writeTo(os.Stdout)
+}
+
+func (m command) analyze(cfg *config.Config, opts *options) (*report, error) {
+	r := &report{
+		configuredMode: cfg.NVIDIAContainerRuntimeConfig.Mode,
+		targetMode:     opts.targetMode,
+		node:           m.analyzeConfig(cfg, info.RuntimeMode(opts.targetMode)),
+		auditLog:       opts.auditLog,
+	}
+	if r.auditLog == "" {
+		r.auditLog = cfg.NVIDIAContainerRuntimeConfig.AuditLogFilePath
+	}
+	if r.auditLog == "" {
+		return r, nil
+	}
+
+	entries, err := audit.Read(r.auditLog)
+	if os.IsNotExist(err) {
+		m.logger.Warningf("Audit log %v does not exist", r.auditLog)
+		return r, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now
+	if opts.now != nil {
+		now = opts.now
+	}
+	var recent []audit.Entry
+	for _, e := range entries {
+		if e.Event != audit.EventCreate {
+			continue
+		}
+		if opts.since > 0 && now().Sub(e.Timestamp) > opts.since {
+			continue
+		}
+		recent = append(recent, e)
+	}
+	r.launches = len(recent)
+	r.legacyLaunches, r.containers = analyzeContainers(recent, info.RuntimeMode(opts.targetMode))
+	return r, nil
+}
+
+// analyzeConfig returns the findings for the node configuration when
+// switching to the specified mode.
+func (m command) analyzeConfig(cfg *config.Config, targetMode info.RuntimeMode) []finding {
+	var findings []finding
+
+	switch info.RuntimeMode(cfg.NVIDIAContainerRuntimeConfig.Mode) {
+	case targetMode:
+		findings = append(findings, finding{
+			level:   levelInfo,
+			message: fmt.Sprintf("The NVIDIA Container Runtime is already configured to use %v mode", targetMode),
+		})
+	case info.CSVRuntimeMode:
+		findings = append(findings, finding{
+			level:   levelWarning,
+			message: "The NVIDIA Container Runtime is configured to use csv mode; generate a CDI specification using nvidia-ctk cdi generate --mode=csv instead of relying on the CSV files in cdi mode",
+		})
+	}
+
+	if targetMode == info.CDIRuntimeMode {
+		if !hasCDISpecs(cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.SpecDirs) {
+			findings = append(findings, finding{
+				level:   levelWarning,
+				message: fmt.Sprintf("No CDI specifications were found in %v; generate one using nvidia-ctk cdi generate or use jit-cdi mode", strings.Join(cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.SpecDirs, ", ")),
+			})
+		}
+		if root := cfg.NVIDIAContainerCLIConfig.Root; root != "" && root != "/" {
+			findings = append(findings, finding{
+				level:   levelInfo,
+				message: fmt.Sprintf("The driver is installed at %v; specify --driver-root=%v when generating the CDI specification", root, root),
+			})
+		}
+		if cfg.NVIDIAContainerCLIConfig.LoadKmods {
+			findings = append(findings, finding{
+				level:   levelInfo,
+				message: "The NVIDIA kernel modules are not loaded on demand in cdi mode (nvidia-container-cli.load-kmods); ensure that they are loaded when the node starts",
+			})
+		}
+	}
+
+	if cfg.Features.DisableCUDACompatLibHook.IsEnabled() {
+		findings = append(findings, finding{
+			level:   levelWarning,
+			message: fmt.Sprintf("The disable-cuda-compat-lib-hook feature is enabled; CUDA Forward Compatibility libraries from containers will not be used in %v mode", targetMode),
+		})
+	}
+
+	return findings
+}
+
+// hasCDISpecs checks whether any of the specified directories contain CDI
+// specifications.
+func hasCDISpecs(specDirs []string) bool {
+	for _, dir := range specDirs {
+		for _, pattern := range []string{"*.json", "*.yaml"} {
+			matches, _ := filepath.Glob(filepath.Join(dir, pattern))
+			if len(matches) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// analyzeContainers returns the number of the specified container launches
+// that used legacy mode and the findings for the containers that rely on
+// behavior that is only available in legacy mode.
+func analyzeContainers(entries []audit.Entry, targetMode info.RuntimeMode) (int, []finding) {
+	requirements := finding{
+		level:   levelWarning,
+		message: fmt.Sprintf("container(s) specify CUDA requirements (NVIDIA_REQUIRE_*) which are not checked in %v mode", targetMode),
+	}
+	migDevices := finding{
+		level:   levelWarning,
+		message: fmt.Sprintf("container(s) request MIG configuration or monitoring capabilities (NVIDIA_MIG_CONFIG_DEVICES or NVIDIA_MIG_MONITOR_DEVICES) which are not supported in %v mode", targetMode),
+	}
+
+	var legacyLaunches int
+	for _, e := range entries {
+		if info.RuntimeMode(e.Mode) != info.LegacyRuntimeMode {
+			continue
+		}
+		legacyLaunches++
+		if hasRequirements(e) {
+			requirements.bundles = append(requirements.bundles, e.Bundle)
+		}
+		if hasEnv(e, image.EnvVarNvidiaMigConfigDevices) || hasEnv(e, image.EnvVarNvidiaMigMonitorDevices) {
+			migDevices.bundles = append(migDevices.bundles, e.Bundle)
+		}
+	}
+
+	var findings []finding
+	for _, f := range []finding{requirements, migDevices} {
+		if len(f.bundles) > 0 {
+			f.message = fmt.Sprintf("%d %v", len(f.bundles), f.message)
+			findings = append(findings, f)
+		}
+	}
+	return legacyLaunches, findings
+}
+
+// hasRequirements checks whether the container has CUDA requirements that
+// are checked by the nvidia-container-cli in legacy mode.
+func hasRequirements(e audit.Entry) bool {
+	if disable, _ := e.LookupEnv(image.EnvVarNvidiaDisableRequire); disable == "true" || disable == "1" {
+		return false
+	}
+	for _, env := range e.Env {
+		if strings.HasPrefix(env, image.NvidiaRequirePrefix) && !strings.HasPrefix(env, image.EnvVarNvidiaRequireJetpack) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasEnv(e audit.Entry, name string) bool {
+	value, ok := e.LookupEnv(name)
+	return ok && value != ""
+}
+
+func (r *report) writeTo(w io.Writer) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Configured mode: %v\n", r.configuredMode)
+	fmt.Fprintf(&sb, "Target mode: %v\n", r.targetMode)
+
+	sb.WriteString("\nNode configuration:\n")
+	writeFindings(&sb, r.node)
+
+	sb.WriteString("\nContainer launches:\n")
+	switch {
+	case r.auditLog == "":
+		sb.WriteString("  No audit log is configured; set nvidia-container-runtime.audit-log in the config to record container launches\n")
+	default:
+		fmt.Fprintf(&sb, "  Analyzed %d container launch(es) from %v, of which %d used legacy mode\n", r.launches, r.auditLog, r.legacyLaunches)
+		writeFindings(&sb, r.containers)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func writeFindings(sb *strings.Builder, findings []finding) {
+	if len(findings) == 0 {
+		sb.WriteString("  No changes in behavior were detected\n")
+		return
+	}
+	for _, f := range findings {
+		fmt.Fprintf(sb, "  [%v] %v\n", f.level, f.message)
+		for i, bundle := range f.bundles {
+			if i == maxListedBundles {
+				fmt.Fprintf(sb, "      ... and %d more\n", len(f.bundles)-maxListedBundles)
+				break
+			}
+			fmt.Fprintf(sb, "      %v\n", bundle)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package analyze
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/audit"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+)
+
+func TestAnalyze(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	now := time.Now()
+	specDir := t.TempDir()
+
+	auditLog := filepath.Join(t.TempDir(), "audit.log")
+	for _, e := range []*audit.Entry{
+		{Timestamp: now.Add(-time.Hour), Event: audit.EventCreate, Bundle: "/run/bundle/requires", Mode: "legacy", Env: []string{"NVIDIA_VISIBLE_DEVICES=all", "NVIDIA_REQUIRE_CUDA=cuda>=12.0"}},
+		{Timestamp: now.Add(-time.Hour), Event: audit.EventCreate, Bundle: "/run/bundle/disabled", Mode: "legacy", Env: []string{"NVIDIA_REQUIRE_CUDA=cuda>=12.0", "NVIDIA_DISABLE_REQUIRE=true"}},
+		{Timestamp: now.Add(-time.Hour), Event: audit.EventCreate, Bundle: "/run/bundle/mig", Mode: "legacy", Env: []string{"NVIDIA_MIG_CONFIG_DEVICES=all"}},
+		{Timestamp: now.Add(-time.Hour), Event: audit.EventCreate, Bundle: "/run/bundle/cdi", Mode: "cdi", Env: []string{"NVIDIA_REQUIRE_CUDA=cuda>=12.0"}},
+		{Timestamp: now.Add(-30 * 24 * time.Hour), Event: audit.EventCreate, Bundle: "/run/bundle/old", Mode: "legacy", Env: []string{"NVIDIA_MIG_MONITOR_DEVICES=all"}},
+	} {
+		require.NoError(t, audit.Append(auditLog, e))
+	}
+
+	cdiModeConfig := &config.Config{
+		NVIDIAContainerCLIConfig: config.ContainerCLIConfig{
+			Root: "/run/nvidia/driver",
+		},
+		NVIDIAContainerRuntimeConfig: config.RuntimeConfig{
+			Mode:             "auto",
+			AuditLogFilePath: auditLog,
+		},
+	}
+	cdiModeConfig.NVIDIAContainerRuntimeConfig.Modes.CDI.SpecDirs = []string{specDir}
+
+	testCases := []struct {
+		description    string
+		cfg            *config.Config
+		opts           options
+		expectedReport string
+	}{
+		{
+			description: "no audit log",
+			cfg: &config.Config{
+				NVIDIAContainerRuntimeConfig: config.RuntimeConfig{
+					Mode: "legacy",
+				},
+			},
+			opts: options{
+				targetMode: "jit-cdi",
+			},
+			expectedReport: `Configured mode: legacy
+Target mode: jit-cdi
+
+Node configuration:
+  No changes in behavior were detected
+
+Container launches:
+  No audit log is configured; set nvidia-container-runtime.audit-log in the config to record container launches
+`,
+		},
+		{
+			description: "cdi mode without specs",
+			cfg:         cdiModeConfig,
+			opts: options{
+				targetMode: "cdi",
+				since:      7 * 24 * time.Hour,
+			},
+			expectedReport: `Configured mode: auto
+Target mode: cdi
+
+Node configuration:
+  [warning] No CDI specifications were found in ` + specDir + `; generate one using nvidia-ctk cdi generate or use jit-cdi mode
+  [info] The driver is installed at /run/nvidia/driver; specify --driver-root=/run/nvidia/driver when generating the CDI specification
+
+Container launches:
+  Analyzed 4 container launch(es) from ` + auditLog + `, of which 3 used legacy mode
+  [warning] 1 container(s) specify CUDA requirements (NVIDIA_REQUIRE_*) which are not checked in cdi mode
+      /run/bundle/requires
+  [warning] 1 container(s) request MIG configuration or monitoring capabilities (NVIDIA_MIG_CONFIG_DEVICES or NVIDIA_MIG_MONITOR_DEVICES) which are not supported in cdi mode
+      /run/bundle/mig
+`,
+		},
+		{
+			description: "all launches are considered",
+			cfg: &config.Config{
+				NVIDIAContainerRuntimeConfig: config.RuntimeConfig{
+					Mode: "jit-cdi",
+				},
+			},
+			opts: options{
+				targetMode: "jit-cdi",
+				auditLog:   auditLog,
+			},
+			expectedReport: `Configured mode: jit-cdi
+Target mode: jit-cdi
+
+Node configuration:
+  [info] The NVIDIA Container Runtime is already configured to use jit-cdi mode
+
+Container launches:
+  Analyzed 5 container launch(es) from ` + auditLog + `, of which 4 used legacy mode
+  [warning] 1 container(s) specify CUDA requirements (NVIDIA_REQUIRE_*) which are not checked in jit-cdi mode
+      /run/bundle/requires
+  [warning] 2 container(s) request MIG configuration or monitoring capabilities (NVIDIA_MIG_CONFIG_DEVICES or NVIDIA_MIG_MONITOR_DEVICES) which are not supported in jit-cdi mode
+      /run/bundle/mig
+      /run/bundle/old
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c := command{
+				logger: logger,
+			}
+			tc.opts.now = func() time.Time { return now }
+
+			r, err := c.analyze(tc.cfg, &tc.opts)
+			require.NoError(t, err)
+
+			buf := &bytes.Buffer{}
+			require.NoError(t, r.writeTo(buf))
+			require.Equal(t, tc.expectedReport, buf.String())
+		})
+	}
+}
+
+func TestHasRequirements(t *testing.T) {
+	legacyEntry := audit.NewCreateEntry("/run/bundle", "legacy", &specs.Spec{
+		Process: &specs.Process{
+			Env: []string{"NVIDIA_REQUIRE_JETPACK=csv-mounts=all"},
+		},
+	})
+	require.False(t, hasRequirements(*legacyEntry))
+}
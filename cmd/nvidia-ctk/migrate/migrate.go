@@ -0,0 +1,53 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package migrate
+
+import (
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/migrate/analyze"
+	nvidiadocker1 "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/migrate/nvidia-docker1"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+// NewCommand constructs a migrate command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build
+func (m command) build() *cli.Command {
+	// Create the 'migrate' command
+	migrate := cli.Command{
+		Name:  "migrate",
+		Usage: "Migrate legacy GPU container setups to the NVIDIA Container Toolkit",
+		Commands: []*cli.Command{
+			analyze.NewCommand(m.logger),
+			nvidiadocker1.NewCommand(m.logger),
+		},
+	}
+
+	return &migrate
+}
@@ -0,0 +1,304 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvidiadocker1
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine/docker"
+)
+
+const (
+	defaultDockerConfigFilePath = "/etc/docker/daemon.json"
+	defaultNVIDIARuntimeName    = "nvidia"
+	defaultNVIDIARuntimePath    = "nvidia-container-runtime"
+
+	// legacyVolumesRoot is the path at which nvidia-docker-plugin created the
+	// nvidia_driver_<VERSION> volumes.
+	legacyVolumesRoot = "/var/lib/nvidia-docker/volumes"
+)
+
+var (
+	// legacyPluginSpecs are the locations at which the nvidia-docker volume
+	// plugin could be registered with the Docker daemon.
+	legacyPluginSpecs = []string{
+		"/etc/docker/plugins/nvidia-docker.spec",
+		"/etc/docker/plugins/nvidia-docker.json",
+		"/usr/lib/docker/plugins/nvidia-docker.spec",
+		"/usr/lib/docker/plugins/nvidia-docker.json",
+	}
+	// legacyPluginSockets are the sockets at which a running nvidia-docker
+	// volume plugin listens.
+	legacyPluginSockets = []string{
+		"/run/docker/plugins/nvidia-docker.sock",
+	}
+	// legacyUnitLinks are the symlinks created when enabling the
+	// nvidia-docker service.
+	legacyUnitLinks = []string{
+		"/etc/systemd/system/multi-user.target.wants/nvidia-docker.service",
+	}
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	root             string
+	dockerConfigPath string
+	dryRun           bool
+	cleanup          bool
+
+	nvidiaRuntime struct {
+		name         string
+		path         string
+		setAsDefault bool
+	}
+}
+
+// legacyInstallation records the artifacts of an nvidia-docker 1.0
+// installation that were detected on the host.
+type legacyInstallation struct {
+	pluginSpecs   []string
+	pluginSockets []string
+	unitLinks     []string
+	volumesRoot   string
+	volumes       []string
+}
+
+// NewCommand constructs an nvidia-docker1 command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build creates the CLI command
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name:  "nvidia-docker1",
+		Usage: "Migrate an nvidia-docker 1.0 (volume plugin) setup to the NVIDIA Container Runtime",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(&opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(&opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "root",
+				Usage:       "the root of the host filesystem. All other paths are resolved relative to this root.",
+				Value:       "/",
+				Destination: &opts.root,
+			},
+			&cli.StringFlag{
+				Name:        "docker-config",
+				Usage:       "the path to the Docker daemon config file to update",
+				Value:       defaultDockerConfigFilePath,
+				Destination: &opts.dockerConfigPath,
+			},
+			&cli.StringFlag{
+				Name:        "nvidia-runtime-name",
+				Usage:       "specify the name of the NVIDIA runtime that will be added",
+				Value:       defaultNVIDIARuntimeName,
+				Destination: &opts.nvidiaRuntime.name,
+			},
+			&cli.StringFlag{
+				Name:        "nvidia-runtime-path",
+				Usage:       "specify the path to the NVIDIA runtime executable",
+				Value:       defaultNVIDIARuntimePath,
+				Destination: &opts.nvidiaRuntime.path,
+			},
+			&cli.BoolFlag{
+				Name:        "nvidia-set-as-default",
+				Usage:       "set the NVIDIA runtime as the default Docker runtime",
+				Destination: &opts.nvidiaRuntime.setAsDefault,
+			},
+			&cli.BoolFlag{
+				Name:        "cleanup",
+				Usage:       "remove the obsolete nvidia-docker plugin registration, service links, and driver volumes",
+				Destination: &opts.cleanup,
+			},
+			&cli.BoolFlag{
+				Name:        "dry-run",
+				Usage:       "report the changes that would be made without modifying the host",
+				Destination: &opts.dryRun,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(opts *options) error {
+	if opts.root == "" {
+		opts.root = "/"
+	}
+	if opts.dockerConfigPath == "" {
+		return fmt.Errorf("the docker config path must be specified")
+	}
+	if opts.nvidiaRuntime.name == "" {
+		return fmt.Errorf("the NVIDIA runtime name must be specified")
+	}
+	if opts.nvidiaRuntime.path == "" {
+		return fmt.Errorf("the NVIDIA runtime path must be specified")
+	}
+	return nil
+}
+
+func (m command) run(opts *options) error {
+	legacy, err := m.detect(opts.root)
+	if err != nil {
+		return fmt.Errorf("failed to detect legacy nvidia-docker installation: %w", err)
+	}
+	if legacy.isEmpty() {
+		m.logger.Infof("No legacy nvidia-docker installation detected")
+		return nil
+	}
+
+	if opts.cleanup && len(legacy.pluginSockets) > 0 {
+		return fmt.Errorf("the nvidia-docker plugin is still running (%v); stop the nvidia-docker service before cleaning up", legacy.pluginSockets)
+	}
+
+	if err := m.configureDocker(opts); err != nil {
+		return err
+	}
+
+	if len(legacy.volumes) > 0 {
+		m.logger.Warningf("Containers using the legacy driver volumes %v must be recreated requesting GPUs using --runtime=%v or --gpus", legacy.volumes, opts.nvidiaRuntime.name)
+	}
+
+	if !opts.cleanup {
+		m.logger.Infof("Rerun with --cleanup to remove the obsolete nvidia-docker plugin artifacts")
+		return nil
+	}
+	if err := m.cleanup(opts, legacy); err != nil {
+		return err
+	}
+	m.logger.Infof("The nvidia-docker 1.0 package (if installed) should be removed using the system package manager")
+	return nil
+}
+
+// detect locates the artifacts of an nvidia-docker 1.0 installation under
+// the specified root.
+func (m command) detect(root string) (*legacyInstallation, error) {
+	legacy := &legacyInstallation{}
+
+	for _, path := range legacyPluginSpecs {
+		if exists(filepath.Join(root, path)) {
+			m.logger.Infof("Found legacy nvidia-docker plugin registration %v", path)
+			legacy.pluginSpecs = append(legacy.pluginSpecs, path)
+		}
+	}
+	for _, path := range legacyPluginSockets {
+		if exists(filepath.Join(root, path)) {
+			m.logger.Infof("Found running nvidia-docker plugin at %v", path)
+			legacy.pluginSockets = append(legacy.pluginSockets, path)
+		}
+	}
+	for _, path := range legacyUnitLinks {
+		if exists(filepath.Join(root, path)) {
+			m.logger.Infof("Found enabled nvidia-docker service %v", path)
+			legacy.unitLinks = append(legacy.unitLinks, path)
+		}
+	}
+
+	entries, err := os.ReadDir(filepath.Join(root, legacyVolumesRoot))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %v: %w", legacyVolumesRoot, err)
+	}
+	if err == nil {
+		legacy.volumesRoot = legacyVolumesRoot
+	}
+	for _, entry := range entries {
+		m.logger.Infof("Found legacy nvidia-docker volume %v", entry.Name())
+		legacy.volumes = append(legacy.volumes, entry.Name())
+	}
+
+	return legacy, nil
+}
+
+// configureDocker adds the NVIDIA Container Runtime to the Docker daemon config.
+func (m command) configureDocker(opts *options) error {
+	configPath := filepath.Join(opts.root, opts.dockerConfigPath)
+	cfg, err := docker.New(
+		docker.WithLogger(m.logger),
+		docker.WithPath(configPath),
+	)
+	if err != nil {
+		return fmt.Errorf("unable to load docker config: %w", err)
+	}
+
+	if err := cfg.AddRuntime(opts.nvidiaRuntime.name, opts.nvidiaRuntime.path, opts.nvidiaRuntime.setAsDefault); err != nil {
+		return fmt.Errorf("unable to update docker config: %w", err)
+	}
+
+	if opts.dryRun {
+		m.logger.Infof("Would add the %q runtime to %v", opts.nvidiaRuntime.name, opts.dockerConfigPath)
+		return nil
+	}
+	if _, err := cfg.Save(configPath); err != nil {
+		return fmt.Errorf("unable to flush docker config: %w", err)
+	}
+	m.logger.Infof("Wrote updated config to %v", opts.dockerConfigPath)
+	m.logger.Infof("It is recommended that docker daemon be restarted.")
+	return nil
+}
+
+// cleanup removes the obsolete nvidia-docker 1.0 artifacts from the host.
+func (m command) cleanup(opts *options, legacy *legacyInstallation) error {
+	var paths []string
+	paths = append(paths, legacy.pluginSpecs...)
+	paths = append(paths, legacy.unitLinks...)
+	if legacy.volumesRoot != "" {
+		paths = append(paths, legacy.volumesRoot)
+	}
+
+	for _, path := range paths {
+		if opts.dryRun {
+			m.logger.Infof("Would remove %v", path)
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(opts.root, path)); err != nil {
+			return fmt.Errorf("failed to remove %v: %w", path, err)
+		}
+		m.logger.Infof("Removed %v", path)
+	}
+	return nil
+}
+
+func (l *legacyInstallation) isEmpty() bool {
+	return len(l.pluginSpecs) == 0 &&
+		len(l.pluginSockets) == 0 &&
+		len(l.unitLinks) == 0 &&
+		l.volumesRoot == ""
+}
+
+func exists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
@@ -0,0 +1,155 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvidiadocker1
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description        string
+		files              []string
+		dryRun             bool
+		cleanup            bool
+		expectedError      bool
+		expectedConfig     string
+		expectedFilesExist []string
+		expectedFilesGone  []string
+	}{
+		{
+			description: "no legacy installation is a no-op",
+		},
+		{
+			description: "legacy installation configures docker",
+			files: []string{
+				"/etc/docker/plugins/nvidia-docker.spec",
+				"/var/lib/nvidia-docker/volumes/nvidia_driver_550.54.15/bin/nvidia-smi",
+			},
+			expectedConfig: `{
+    "runtimes": {
+        "nvidia": {
+            "args": [],
+            "path": "nvidia-container-runtime"
+        }
+    }
+}`,
+			expectedFilesExist: []string{
+				"/etc/docker/plugins/nvidia-docker.spec",
+				"/var/lib/nvidia-docker/volumes",
+			},
+		},
+		{
+			description: "cleanup removes legacy artifacts",
+			files: []string{
+				"/etc/docker/plugins/nvidia-docker.spec",
+				"/etc/systemd/system/multi-user.target.wants/nvidia-docker.service",
+				"/var/lib/nvidia-docker/volumes/nvidia_driver_550.54.15/bin/nvidia-smi",
+			},
+			cleanup: true,
+			expectedConfig: `{
+    "runtimes": {
+        "nvidia": {
+            "args": [],
+            "path": "nvidia-container-runtime"
+        }
+    }
+}`,
+			expectedFilesGone: []string{
+				"/etc/docker/plugins/nvidia-docker.spec",
+				"/etc/systemd/system/multi-user.target.wants/nvidia-docker.service",
+				"/var/lib/nvidia-docker/volumes",
+			},
+		},
+		{
+			description: "dry-run makes no changes",
+			files: []string{
+				"/etc/docker/plugins/nvidia-docker.spec",
+			},
+			dryRun:  true,
+			cleanup: true,
+			expectedFilesExist: []string{
+				"/etc/docker/plugins/nvidia-docker.spec",
+			},
+		},
+		{
+			description: "cleanup with running plugin returns error",
+			files: []string{
+				"/etc/docker/plugins/nvidia-docker.spec",
+				"/run/docker/plugins/nvidia-docker.sock",
+			},
+			cleanup:       true,
+			expectedError: true,
+			expectedFilesExist: []string{
+				"/etc/docker/plugins/nvidia-docker.spec",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			root := t.TempDir()
+			for _, file := range tc.files {
+				path := filepath.Join(root, file)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+				require.NoError(t, os.WriteFile(path, nil, 0600))
+			}
+
+			c := command{
+				logger: logger,
+			}
+			opts := &options{
+				root:             root,
+				dockerConfigPath: defaultDockerConfigFilePath,
+				dryRun:           tc.dryRun,
+				cleanup:          tc.cleanup,
+			}
+			opts.nvidiaRuntime.name = defaultNVIDIARuntimeName
+			opts.nvidiaRuntime.path = defaultNVIDIARuntimePath
+
+			err := c.run(opts)
+			if tc.expectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			config, err := os.ReadFile(filepath.Join(root, defaultDockerConfigFilePath))
+			if tc.expectedConfig == "" {
+				require.ErrorIs(t, err, os.ErrNotExist)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedConfig, string(config))
+			}
+
+			for _, file := range tc.expectedFilesExist {
+				require.True(t, exists(filepath.Join(root, file)), file)
+			}
+			for _, file := range tc.expectedFilesGone {
+				require.False(t, exists(filepath.Join(root, file)), file)
+			}
+		})
+	}
+}
@@ -19,15 +19,22 @@ package configure
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 
 	"github.com/urfave/cli/v3"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/filelock"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/manifest"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/messages"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine/buildkit"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine/containerd"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine/crio"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine/docker"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine/nomad"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine/podman"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/ocihook"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/toml"
 )
@@ -42,13 +49,19 @@ const (
 	defaultNVIDIARuntimeExpecutablePath     = "/usr/bin/nvidia-container-runtime"
 	defaultNVIDIARuntimeHookExpecutablePath = "/usr/bin/nvidia-container-runtime-hook"
 
+	defaultBuildkitConfigFilePath   = "/etc/buildkit/buildkitd.toml"
 	defaultContainerdConfigFilePath = "/etc/containerd/config.toml"
 	defaultCrioConfigFilePath       = "/etc/crio/crio.conf"
 	defaultDockerConfigFilePath     = "/etc/docker/daemon.json"
+	defaultNomadConfigFilePath      = "/etc/nomad.d/nvidia.json"
+	defaultPodmanConfigFilePath     = "/etc/containers/containers.conf.d/99-nvidia.conf"
 
 	defaultConfigSource = configSourceFile
 	configSourceCommand = "command"
 	configSourceFile    = "file"
+
+	outputFormatText = "text"
+	outputFormatJSON = "json"
 )
 
 type command struct {
@@ -67,12 +80,14 @@ func NewCommand(logger logger.Interface) *cli.Command {
 // environment variables, or command line config
 type config struct {
 	dryRun         bool
+	output         string
 	runtime        string
 	configFilePath string
 	executablePath string
 	configSource   string
 	mode           string
 	hookFilePath   string
+	manifestPath   string
 
 	nvidiaRuntime struct {
 		name         string
@@ -83,7 +98,8 @@ type config struct {
 
 	// cdi-specific options
 	cdi struct {
-		enabled bool
+		enabled  bool
+		specDirs []string
 	}
 }
 
@@ -107,9 +123,15 @@ func (m command) build() *cli.Command {
 				Usage:       "update the runtime configuration as required but don't write changes to disk",
 				Destination: &config.dryRun,
 			},
+			&cli.StringFlag{
+				Name:        "output",
+				Usage:       "the output format; one of [text, json]. If json is specified, a report of the changed files and the services that need to be restarted is written to STDOUT",
+				Value:       outputFormatText,
+				Destination: &config.output,
+			},
 			&cli.StringFlag{
 				Name:        "runtime",
-				Usage:       "the target runtime engine; one of [containerd, crio, docker]",
+				Usage:       "the target runtime engine; one of [buildkit, containerd, crio, docker, nomad-docker, nomad-podman]",
 				Value:       defaultRuntime,
 				Destination: &config.runtime,
 			},
@@ -170,6 +192,18 @@ func (m command) build() *cli.Command {
 				Usage:       "Enable CDI in the configured runtime",
 				Destination: &config.cdi.enabled,
 			},
+			&cli.StringSliceFlag{
+				Name:        "cdi.spec-dirs",
+				Usage:       "the directories that the configured runtime searches for CDI specifications. This can be used to restrict a runtime to the specifications generated for a tenant.",
+				Destination: &config.cdi.specDirs,
+			},
+			&cli.StringFlag{
+				Name:        "manifest-path",
+				Usage:       "the path of the manifest in which the changes are recorded so that these can be reverted by nvidia-ctk uninstall",
+				Value:       manifest.DefaultPath,
+				Destination: &config.manifestPath,
+				Hidden:      true,
+			},
 		},
 	}
 
@@ -177,10 +211,19 @@ func (m command) build() *cli.Command {
 }
 
 func (m command) validateFlags(config *config) error {
+	switch config.output {
+	case outputFormatText, outputFormatJSON:
+	default:
+		return fmt.Errorf("unrecognized output format: %v", config.output)
+	}
+
 	if config.mode == "oci-hook" {
 		if !filepath.IsAbs(config.nvidiaRuntime.hookPath) {
 			return fmt.Errorf("the NVIDIA runtime hook path %q is not an absolute path", config.nvidiaRuntime.hookPath)
 		}
+		if config.output == outputFormatJSON && config.hookFilePath == "" {
+			return fmt.Errorf("an OCI hook path must be specified for json output")
+		}
 		return nil
 	}
 	if config.mode != "" && config.mode != "config-file" {
@@ -189,14 +232,20 @@ func (m command) validateFlags(config *config) error {
 	config.mode = "config-file"
 
 	switch config.runtime {
-	case "containerd", "crio", "docker":
+	case "buildkit", "containerd", "crio", "docker":
 		break
+	case "nomad-docker":
+		m.logger.Infof("The %v runtime must also be added to the Docker daemon config using --runtime=docker", config.nvidiaRuntime.name)
+	case "nomad-podman":
+		if !config.nvidiaRuntime.setAsDefault {
+			m.logger.Warningf("The Nomad podman driver uses the default podman runtime; specify --nvidia-set-as-default to use the NVIDIA runtime for Nomad tasks")
+		}
 	default:
-		return fmt.Errorf("unrecognized runtime '%v'", config.runtime)
+		return messages.New(messages.UnsupportedRuntime, messages.Data{"Runtime": config.runtime})
 	}
 
 	switch config.runtime {
-	case "containerd", "crio":
+	case "buildkit", "containerd", "crio", "nomad-podman":
 		if config.nvidiaRuntime.path == defaultNVIDIARuntimeExecutable {
 			config.nvidiaRuntime.path = defaultNVIDIARuntimeExpecutablePath
 		}
@@ -205,21 +254,21 @@ func (m command) validateFlags(config *config) error {
 		}
 	}
 
-	if config.runtime != "containerd" && config.runtime != "docker" {
+	if config.runtime != "buildkit" && config.runtime != "containerd" && config.runtime != "docker" {
 		if config.cdi.enabled {
 			m.logger.Warningf("Ignoring cdi.enabled flag for %v", config.runtime)
 		}
 		config.cdi.enabled = false
 	}
 
-	if config.executablePath != "" && config.runtime == "docker" {
+	if config.executablePath != "" && config.runtime != "containerd" && config.runtime != "crio" {
 		m.logger.Warningf("Ignoring executable-path=%q flag for %v", config.executablePath, config.runtime)
 		config.executablePath = ""
 	}
 
 	switch config.configSource {
 	case configSourceCommand:
-		if config.runtime == "docker" {
+		if config.runtime != "containerd" && config.runtime != "crio" {
 			m.logger.Warningf("A %v Config Source is not supported for %v; using %v", config.configSource, config.runtime, configSourceFile)
 			config.configSource = configSourceFile
 		}
@@ -231,12 +280,18 @@ func (m command) validateFlags(config *config) error {
 
 	if config.configFilePath == "" {
 		switch config.runtime {
+		case "buildkit":
+			config.configFilePath = defaultBuildkitConfigFilePath
 		case "containerd":
 			config.configFilePath = defaultContainerdConfigFilePath
 		case "crio":
 			config.configFilePath = defaultCrioConfigFilePath
 		case "docker":
 			config.configFilePath = defaultDockerConfigFilePath
+		case "nomad-docker":
+			config.configFilePath = defaultNomadConfigFilePath
+		case "nomad-podman":
+			config.configFilePath = defaultPodmanConfigFilePath
 		}
 	}
 
@@ -245,24 +300,142 @@ func (m command) validateFlags(config *config) error {
 
 // configureWrapper updates the specified container engine config to enable the NVIDIA runtime
 func (m command) configureWrapper(config *config) error {
+	var r *report
+	var err error
 	switch config.mode {
 	case "oci-hook":
-		return m.configureOCIHook(config)
+		r, err = m.configureOCIHook(config)
 	case "config-file":
-		return m.configureConfigFile(config)
+		r, err = m.configureConfigFile(config)
+	default:
+		return fmt.Errorf("unsupported config-mode: %v", config.mode)
+	}
+	if err != nil {
+		return err
 	}
-	return fmt.Errorf("unsupported config-mode: %v", config.mode)
+
+	if config.output == outputFormatJSON {
+		return r.writeTo(os.Stdout)
+	}
+	return nil
 }
 
 // configureConfigFile updates the specified container engine config file to enable the NVIDIA runtime.
-func (m command) configureConfigFile(config *config) error {
+func (m command) configureConfigFile(config *config) (_ *report, rerr error) {
+	outputPath := config.getOutputConfigPath()
+	// The lock is held while the config is loaded and saved so that changes
+	// made concurrently by other components are not lost.
+	if outputPath != "" {
+		lock, err := filelock.Acquire(outputPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to lock config: %v", err)
+		}
+		defer func() {
+			if err := lock.Unlock(); err != nil && rerr == nil {
+				rerr = fmt.Errorf("unable to unlock config: %v", err)
+			}
+		}()
+	}
+
 	configSource, err := config.resolveConfigSource()
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	cfg, err := m.newEngineConfig(config, configSource)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := getManifestChanges(cfg, config)
+	if err := m.updateEngineConfig(cfg, config); err != nil {
+		return nil, err
+	}
+
+	r := newReport(config)
+	original := readContents(config.configFilePath)
+
+	if outputPath == "" && config.output == outputFormatJSON {
+		// For a dry-run with json output we determine whether the config
+		// would change without writing the updated config to STDOUT.
+		updated, err := getContents(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("unable to flush config: %v", err)
+		}
+		r.addChange(config.configFilePath, original, updated, restartService(config.runtime))
+		return r, nil
+	}
+
+	n, err := cfg.Save(outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to flush config: %v", err)
 	}
 
+	if outputPath != "" {
+		if n == 0 {
+			m.logger.Infof("Removed empty config from %v", outputPath)
+		} else {
+			m.logger.Infof("Wrote updated config to %v", outputPath)
+		}
+		service := restartService(config.runtime)
+		if service != "" {
+			m.logger.Infof("It is recommended that %v daemon be restarted.", service)
+		}
+		r.addChange(outputPath, original, readContents(outputPath), service)
+		m.recordChanges(config, changes)
+	}
+
+	return r, nil
+}
+
+// getManifestChanges returns the changes to the engine config that need to be
+// reverted in addition to removing the NVIDIA runtime when the NVIDIA
+// Container Toolkit is uninstalled. This is determined before the config is
+// updated.
+func getManifestChanges(cfg engine.Interface, config *config) manifest.EngineConfig {
+	changes := manifest.EngineConfig{
+		Path:        config.configFilePath,
+		RuntimeName: config.nvidiaRuntime.name,
+	}
+	if config.nvidiaRuntime.setAsDefault {
+		if previous := cfg.DefaultRuntime(); previous != config.nvidiaRuntime.name {
+			changes.PreviousDefaultRuntime = previous
+		}
+	}
+	if config.cdi.enabled {
+		checker, ok := cfg.(engine.CDIChecker)
+		changes.EnabledCDI = ok && !checker.IsCDIEnabled()
+	}
+	return changes
+}
+
+// recordChanges records the changes made to the engine config in the
+// manifest. Since the config has already been written, a failure is only
+// logged.
+func (m command) recordChanges(config *config, changes manifest.EngineConfig) {
+	if config.manifestPath == "" {
+		return
+	}
+	err := manifest.Update(config.manifestPath, func(mf *manifest.Manifest) {
+		mf.AddEngineConfig(changes)
+	})
+	if err != nil {
+		m.logger.Warningf("Failed to record changes to %v in %v: %v", changes.Path, config.manifestPath, err)
+	}
+}
+
+// newEngineConfig loads the config for the container engine specified in the
+// config.
+func (m command) newEngineConfig(config *config, configSource toml.Loader) (engine.Interface, error) {
 	var cfg engine.Interface
+	var err error
 	switch config.runtime {
+	case "buildkit":
+		cfg, err = buildkit.New(
+			buildkit.WithLogger(m.logger),
+			buildkit.WithPath(config.configFilePath),
+			buildkit.WithConfigSource(configSource),
+		)
 	case "containerd":
 		cfg, err = containerd.New(
 			containerd.WithLogger(m.logger),
@@ -280,14 +453,30 @@ func (m command) configureConfigFile(config *config) error {
 			docker.WithLogger(m.logger),
 			docker.WithPath(config.configFilePath),
 		)
+	case "nomad-docker":
+		cfg, err = nomad.New(
+			nomad.WithLogger(m.logger),
+			nomad.WithPath(config.configFilePath),
+		)
+	case "nomad-podman":
+		cfg, err = podman.New(
+			podman.WithLogger(m.logger),
+			podman.WithPath(config.configFilePath),
+			podman.WithConfigSource(configSource),
+		)
 	default:
-		err = fmt.Errorf("unrecognized runtime '%v'", config.runtime)
+		err = messages.New(messages.UnsupportedRuntime, messages.Data{"Runtime": config.runtime})
 	}
 	if err != nil || cfg == nil {
-		return fmt.Errorf("unable to load config for runtime %v: %v", config.runtime, err)
+		return nil, fmt.Errorf("unable to load config for runtime %v: %w", config.runtime, err)
 	}
+	return cfg, nil
+}
 
-	err = cfg.AddRuntime(
+// updateEngineConfig applies the requested changes to the specified engine
+// config. The config is not saved.
+func (m command) updateEngineConfig(cfg engine.Interface, config *config) error {
+	err := cfg.AddRuntime(
 		config.nvidiaRuntime.name,
 		config.nvidiaRuntime.path,
 		config.nvidiaRuntime.setAsDefault,
@@ -299,20 +488,12 @@ func (m command) configureConfigFile(config *config) error {
 	if config.cdi.enabled {
 		cfg.EnableCDI()
 	}
-
-	outputPath := config.getOutputConfigPath()
-	n, err := cfg.Save(outputPath)
-	if err != nil {
-		return fmt.Errorf("unable to flush config: %v", err)
-	}
-
-	if outputPath != "" {
-		if n == 0 {
-			m.logger.Infof("Removed empty config from %v", outputPath)
+	if len(config.cdi.specDirs) > 0 {
+		if setter, ok := cfg.(engine.CDISpecDirsSetter); ok {
+			setter.SetCDISpecDirs(config.cdi.specDirs...)
 		} else {
-			m.logger.Infof("Wrote updated config to %v", outputPath)
+			m.logger.Warningf("Ignoring cdi.spec-dirs flag for %v", config.runtime)
 		}
-		m.logger.Infof("It is recommended that %v daemon be restarted.", config.runtime)
 	}
 
 	return nil
@@ -350,10 +531,29 @@ func (c *config) getOutputConfigPath() string {
 }
 
 // configureOCIHook creates and configures the OCI hook for the NVIDIA runtime
-func (m *command) configureOCIHook(config *config) error {
-	err := ocihook.CreateHook(config.hookFilePath, config.nvidiaRuntime.hookPath)
+func (m *command) configureOCIHook(config *config) (*report, error) {
+	r := newReport(config)
+	original := readContents(config.hookFilePath)
+
+	hookFilePath := config.hookFilePath
+	if config.dryRun && hookFilePath != "" {
+		// For a dry-run we create the hook in a temporary directory to
+		// determine whether the existing hook would change.
+		dir, err := os.MkdirTemp("", "nvidia-ctk-configure-*")
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(dir)
+		hookFilePath = filepath.Join(dir, filepath.Base(config.hookFilePath))
+	}
+
+	err := ocihook.CreateHook(hookFilePath, config.nvidiaRuntime.hookPath)
 	if err != nil {
-		return fmt.Errorf("error creating OCI hook: %v", err)
+		return nil, fmt.Errorf("error creating OCI hook: %v", err)
 	}
-	return nil
+	if config.hookFilePath != "" {
+		// OCI hooks are loaded for each container and no restart is required.
+		r.addChange(config.hookFilePath, original, readContents(hookFilePath), "")
+	}
+	return r, nil
 }
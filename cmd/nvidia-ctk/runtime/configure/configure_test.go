@@ -0,0 +1,96 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package configure
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/manifest"
+)
+
+func TestConfigureConfigFileManifest(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description string
+		existing    string
+		dryRun      bool
+		expected    *manifest.Manifest
+	}{
+		{
+			description: "replaced default runtime and enabled CDI are recorded",
+			existing:    `{"default-runtime": "crun", "runtimes": {"crun": {"path": "/usr/bin/crun"}}}`,
+			expected: &manifest.Manifest{
+				EngineConfigs: []manifest.EngineConfig{
+					{RuntimeName: "nvidia", PreviousDefaultRuntime: "crun", EnabledCDI: true},
+				},
+			},
+		},
+		{
+			description: "CDI that is already enabled is not recorded",
+			existing:    `{"features": {"cdi": true}}`,
+			expected: &manifest.Manifest{
+				EngineConfigs: []manifest.EngineConfig{
+					{RuntimeName: "nvidia"},
+				},
+			},
+		},
+		{
+			description: "dry-run does not record changes",
+			dryRun:      true,
+			expected:    &manifest.Manifest{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			dir := t.TempDir()
+			configFilePath := filepath.Join(dir, "daemon.json")
+			if tc.existing != "" {
+				require.NoError(t, os.WriteFile(configFilePath, []byte(tc.existing), 0600))
+			}
+			cfg := &config{
+				dryRun:         tc.dryRun,
+				runtime:        "docker",
+				configFilePath: configFilePath,
+				configSource:   configSourceFile,
+				manifestPath:   filepath.Join(dir, "manifest.json"),
+			}
+			cfg.nvidiaRuntime.name = defaultNVIDIARuntimeName
+			cfg.nvidiaRuntime.path = defaultNVIDIARuntimeExecutable
+			cfg.nvidiaRuntime.setAsDefault = true
+			cfg.cdi.enabled = true
+
+			c := command{logger: logger}
+			_, err := c.configureConfigFile(cfg)
+			require.NoError(t, err)
+
+			for i := range tc.expected.EngineConfigs {
+				tc.expected.EngineConfigs[i].Path = configFilePath
+			}
+			m, err := manifest.Load(cfg.manifestPath)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, m)
+		})
+	}
+}
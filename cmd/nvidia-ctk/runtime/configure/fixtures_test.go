@@ -0,0 +1,101 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package configure
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+)
+
+var updateFixtures = flag.Bool("update-fixtures", false, "update the expected engine config fixtures")
+
+// TestConfigureConfigFileFixtures applies the configure command to the engine
+// configs in testdata/engine-configs/<runtime>/<fixture>/input and compares
+// the result to the corresponding expected file. A missing input file
+// represents a config that does not exist.
+// The expected files can be regenerated by running the test with
+// -update-fixtures.
+func TestConfigureConfigFileFixtures(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	fixturesRoot := filepath.Join(moduleRoot, "testdata", "engine-configs")
+
+	testCases := []struct {
+		runtime      string
+		fixture      string
+		setAsDefault bool
+		enableCDI    bool
+	}{
+		{runtime: "docker", fixture: "empty"},
+		{runtime: "docker", fixture: "snap", setAsDefault: true},
+		{runtime: "docker", fixture: "existing-runtimes", enableCDI: true},
+		{runtime: "containerd", fixture: "v1"},
+		{runtime: "containerd", fixture: "v2", setAsDefault: true},
+		{runtime: "containerd", fixture: "v3", enableCDI: true},
+		{runtime: "containerd", fixture: "k3s"},
+		{runtime: "crio", fixture: "default", setAsDefault: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.runtime+"/"+tc.fixture, func(t *testing.T) {
+			fixtureDir := filepath.Join(fixturesRoot, tc.runtime, tc.fixture)
+			configFilePath := filepath.Join(t.TempDir(), "config")
+
+			input, err := os.ReadFile(filepath.Join(fixtureDir, "input"))
+			if !os.IsNotExist(err) {
+				require.NoError(t, err)
+				require.NoError(t, os.WriteFile(configFilePath, input, 0600))
+			}
+
+			c := &config{
+				runtime:        tc.runtime,
+				configFilePath: configFilePath,
+				configSource:   configSourceFile,
+				mode:           "config-file",
+			}
+			c.nvidiaRuntime.name = defaultNVIDIARuntimeName
+			c.nvidiaRuntime.path = defaultNVIDIARuntimeExpecutablePath
+			c.nvidiaRuntime.setAsDefault = tc.setAsDefault
+			c.cdi.enabled = tc.enableCDI
+
+			m := command{logger: logger}
+			_, err = m.configureConfigFile(c)
+			require.NoError(t, err)
+
+			updated, err := os.ReadFile(configFilePath)
+			require.NoError(t, err)
+
+			expectedPath := filepath.Join(fixtureDir, "expected")
+			if *updateFixtures {
+				require.NoError(t, os.WriteFile(expectedPath, updated, 0644))
+			}
+			expected, err := os.ReadFile(expectedPath)
+			require.NoError(t, err)
+			require.Equal(t, string(expected), string(updated))
+		})
+	}
+}
@@ -0,0 +1,117 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package configure
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/changeset"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine"
+)
+
+// A report describes the changes made by the configure command in a form
+// that can be consumed by provisioning tools such as cloud-init or Ansible.
+// The changes are also included in the change-set format that is shared by
+// all nvidia-ctk commands that support a dry-run.
+type report struct {
+	Runtime string `json:"runtime"`
+	changeset.ChangeSet
+	ChangedFiles    []string `json:"changedFiles"`
+	RestartServices []string `json:"restartServices"`
+}
+
+func newReport(config *config) *report {
+	return &report{
+		Runtime:         config.runtime,
+		ChangeSet:       *changeset.New(config.dryRun),
+		ChangedFiles:    []string{},
+		RestartServices: []string{},
+	}
+}
+
+// addChange records a change to the specified file if the original and
+// updated contents differ. If a service is specified, this is recorded as
+// requiring a restart.
+func (r *report) addChange(path string, original []byte, updated []byte, service string) {
+	if bytes.Equal(original, updated) {
+		return
+	}
+	r.AddFile(path, original, updated)
+	r.ChangedFiles = append(r.ChangedFiles, path)
+	if service != "" {
+		r.RestartServices = append(r.RestartServices, service)
+	}
+}
+
+// restartService returns the service that must be restarted for changes to
+// the config of the specified runtime to take effect. Since podman reads its
+// config for each invocation, no restart is required in this case.
+func restartService(runtime string) string {
+	switch runtime {
+	case "nomad-docker":
+		return "nomad"
+	case "nomad-podman":
+		return ""
+	default:
+		return runtime
+	}
+}
+
+func (r *report) writeTo(w io.Writer) error {
+	output, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("unable to convert report to JSON: %v", err)
+	}
+	_, err = fmt.Fprintf(w, "%s\n", output)
+	return err
+}
+
+// readContents returns the contents of the specified file. If the file does
+// not exist or cannot be read, nil is returned.
+func readContents(path string) []byte {
+	if path == "" {
+		return nil
+	}
+	contents, _ := os.ReadFile(path)
+	return contents
+}
+
+// getContents returns the contents that would be written for the specified
+// config without modifying the config file.
+func getContents(cfg engine.Interface) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "nvidia-ctk-configure-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config")
+	// We create the file so that an empty config can be removed on save.
+	if err := os.WriteFile(path, nil, 0600); err != nil {
+		return nil, err
+	}
+	if _, err := cfg.Save(path); err != nil {
+		return nil, err
+	}
+	return readContents(path), nil
+}
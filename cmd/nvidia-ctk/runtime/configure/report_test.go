@@ -0,0 +1,125 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package configure
+
+import (
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/changeset"
+)
+
+func TestConfigureConfigFileReport(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description    string
+		dryRun         bool
+		runs           int
+		expectedReport *report
+		expectedExists bool
+	}{
+		{
+			description: "new config is reported as changed",
+			runs:        1,
+			expectedReport: &report{
+				Runtime: "docker",
+				ChangeSet: changeset.ChangeSet{
+					Changed: true,
+					Changes: []changeset.Change{
+						{Action: changeset.ActionCreate, Path: "daemon.json"},
+					},
+				},
+				ChangedFiles:    []string{"daemon.json"},
+				RestartServices: []string{"docker"},
+			},
+			expectedExists: true,
+		},
+		{
+			description: "repeated run is reported as unchanged",
+			runs:        2,
+			expectedReport: &report{
+				Runtime: "docker",
+				ChangeSet: changeset.ChangeSet{
+					Changes: []changeset.Change{},
+				},
+				ChangedFiles:    []string{},
+				RestartServices: []string{},
+			},
+			expectedExists: true,
+		},
+		{
+			description: "dry-run reports changes without writing config",
+			dryRun:      true,
+			runs:        1,
+			expectedReport: &report{
+				Runtime: "docker",
+				ChangeSet: changeset.ChangeSet{
+					DryRun:  true,
+					Changed: true,
+					Changes: []changeset.Change{
+						{Action: changeset.ActionCreate, Path: "daemon.json"},
+					},
+				},
+				ChangedFiles:    []string{"daemon.json"},
+				RestartServices: []string{"docker"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			dir := t.TempDir()
+			c := command{
+				logger: logger,
+			}
+			cfg := &config{
+				dryRun:         tc.dryRun,
+				output:         outputFormatJSON,
+				runtime:        "docker",
+				configFilePath: filepath.Join(dir, "daemon.json"),
+				configSource:   configSourceFile,
+			}
+			cfg.nvidiaRuntime.name = defaultNVIDIARuntimeName
+			cfg.nvidiaRuntime.path = defaultNVIDIARuntimeExecutable
+
+			var r *report
+			var err error
+			for i := 0; i < tc.runs; i++ {
+				r, err = c.configureConfigFile(cfg)
+				require.NoError(t, err)
+			}
+
+			for i, file := range tc.expectedReport.ChangedFiles {
+				tc.expectedReport.ChangedFiles[i] = filepath.Join(dir, file)
+			}
+			for i, change := range tc.expectedReport.Changes {
+				tc.expectedReport.Changes[i].Path = filepath.Join(dir, change.Path)
+			}
+			require.EqualValues(t, tc.expectedReport, r)
+			if tc.expectedExists {
+				require.FileExists(t, cfg.configFilePath)
+			} else {
+				require.NoFileExists(t, cfg.configFilePath)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package list
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/engines"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine"
+)
+
+const (
+	defaultNVIDIARuntimeName = "nvidia"
+
+	outputFormatTable = "table"
+	outputFormatJSON  = "json"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	output            string
+	nvidiaRuntimeName string
+}
+
+// A status describes how the NVIDIA Container Toolkit is configured on the
+// system.
+type status struct {
+	Toolkit toolkitStatus  `json:"toolkit"`
+	Engines []engineStatus `json:"engines"`
+}
+
+// A toolkitStatus describes the config of the NVIDIA Container Runtime.
+type toolkitStatus struct {
+	ConfigFile   string `json:"configFile"`
+	Mode         string `json:"mode"`
+	ResolvedMode string `json:"resolvedMode"`
+}
+
+// An engineStatus describes whether the NVIDIA Container Toolkit is
+// configured for a container engine.
+type engineStatus struct {
+	Name              string `json:"name"`
+	Detected          bool   `json:"detected"`
+	ConfigFile        string `json:"configFile"`
+	RuntimeConfigured bool   `json:"runtimeConfigured"`
+	RuntimePath       string `json:"runtimePath,omitempty"`
+	DefaultRuntime    string `json:"defaultRuntime,omitempty"`
+	CDIEnabled        bool   `json:"cdiEnabled"`
+	HookFile          string `json:"hookFile,omitempty"`
+}
+
+// NewCommand constructs a list command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name:  "list",
+		Usage: "List the detected container engines and whether the NVIDIA Container Toolkit is configured for each",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(&opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(os.Stdout, &opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "output",
+				Aliases:     []string{"o"},
+				Usage:       "the output format; one of [table, json]",
+				Value:       outputFormatTable,
+				Destination: &opts.output,
+			},
+			&cli.StringFlag{
+				Name:        "nvidia-runtime-name",
+				Usage:       "the name of the NVIDIA runtime in the container engine configs",
+				Value:       defaultNVIDIARuntimeName,
+				Destination: &opts.nvidiaRuntimeName,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(opts *options) error {
+	switch opts.output {
+	case outputFormatTable, outputFormatJSON:
+	default:
+		return fmt.Errorf("unrecognized output format: %v", opts.output)
+	}
+	return nil
+}
+
+func (m command) run(w io.Writer, opts *options) error {
+	s := &status{
+		Toolkit: m.getToolkitStatus(),
+	}
+	for _, e := range engines.Defaults {
+		s.Engines = append(s.Engines, m.getEngineStatus(e, opts.nvidiaRuntimeName))
+	}
+
+	if opts.output == outputFormatJSON {
+		output, err := json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to convert status to JSON: %v", err)
+		}
+		_, err = fmt.Fprintf(w, "%s\n", output)
+		return err
+	}
+	return s.writeTable(w)
+}
+
+// getToolkitStatus returns the mode of the NVIDIA Container Runtime as
+// configured and as resolved for a container without CDI device requests.
+func (m command) getToolkitStatus() toolkitStatus {
+	s := toolkitStatus{
+		ConfigFile: config.GetConfigFilePath(),
+	}
+	cfg, err := config.GetConfig()
+	if err != nil {
+		m.logger.Warningf("Failed to load NVIDIA Container Toolkit config: %v", err)
+		return s
+	}
+	s.Mode = cfg.NVIDIAContainerRuntimeConfig.Mode
+
+	s.ResolvedMode = string(info.NewRuntimeModeResolver(
+		info.WithLogger(m.logger),
+		info.WithImage(&image.CUDA{}),
+	).ResolveRuntimeMode(s.Mode))
+	return s
+}
+
+// getEngineStatus determines whether the specified engine is present on the
+// system and how the NVIDIA Container Toolkit is configured for it.
+func (m command) getEngineStatus(e engines.Engine, runtimeName string) engineStatus {
+	s := engineStatus{
+		Name:       e.Name,
+		ConfigFile: e.ConfigFilePath,
+	}
+	if _, err := exec.LookPath(e.Executable); err == nil {
+		s.Detected = true
+	}
+	if hookFiles := e.NVIDIAHookFiles(); len(hookFiles) > 0 {
+		s.HookFile = hookFiles[0]
+	}
+
+	if _, err := os.Stat(e.ConfigFilePath); err != nil {
+		return s
+	}
+	s.Detected = true
+
+	cfg, err := e.LoadConfig(m.logger)
+	if err != nil {
+		m.logger.Warningf("Failed to load %v config: %v", e.Name, err)
+		return s
+	}
+
+	if runtime, err := cfg.GetRuntimeConfig(runtimeName); err == nil && runtime != nil {
+		s.RuntimePath = runtime.GetBinaryPath()
+		s.RuntimeConfigured = s.RuntimePath != ""
+	}
+	s.DefaultRuntime = cfg.DefaultRuntime()
+	if checker, ok := cfg.(engine.CDIChecker); ok {
+		s.CDIEnabled = checker.IsCDIEnabled()
+	}
+	return s
+}
+
+func (s *status) writeTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ENGINE\tDETECTED\tNVIDIA RUNTIME\tDEFAULT RUNTIME\tCDI\tHOOK\tCONFIG")
+	for _, e := range s.Engines {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			e.Name,
+			strconv.FormatBool(e.Detected),
+			valueOrNone(e.RuntimePath),
+			valueOrNone(e.DefaultRuntime),
+			strconv.FormatBool(e.CDIEnabled),
+			valueOrNone(e.HookFile),
+			e.ConfigFile,
+		)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\nNVIDIA Container Toolkit config: %s (mode: %s, resolved mode: %s)\n",
+		s.Toolkit.ConfigFile,
+		valueOrNone(s.Toolkit.Mode),
+		valueOrNone(s.Toolkit.ResolvedMode),
+	)
+	return err
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
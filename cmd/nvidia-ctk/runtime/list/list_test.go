@@ -0,0 +1,117 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package list
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/engines"
+)
+
+func TestGetEngineStatus(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description    string
+		engine         string
+		config         string
+		hook           string
+		expectedStatus engineStatus
+	}{
+		{
+			description: "missing config is not detected",
+			engine:      "docker",
+			expectedStatus: engineStatus{
+				Name: "docker",
+			},
+		},
+		{
+			description: "docker with NVIDIA runtime and CDI",
+			engine:      "docker",
+			config:      `{"runtimes": {"nvidia": {"path": "/usr/bin/nvidia-container-runtime"}}, "default-runtime": "nvidia", "features": {"cdi": true}}`,
+			expectedStatus: engineStatus{
+				Name:              "docker",
+				Detected:          true,
+				RuntimeConfigured: true,
+				RuntimePath:       "/usr/bin/nvidia-container-runtime",
+				DefaultRuntime:    "nvidia",
+				CDIEnabled:        true,
+			},
+		},
+		{
+			description: "containerd without NVIDIA runtime",
+			engine:      "containerd",
+			config: `version = 2
+[plugins."io.containerd.grpc.v1.cri"]
+  enable_cdi = true
+`,
+			expectedStatus: engineStatus{
+				Name:       "containerd",
+				Detected:   true,
+				CDIEnabled: true,
+			},
+		},
+		{
+			description: "crio with OCI hook",
+			engine:      "crio",
+			config: `[crio.runtime.runtimes.nvidia]
+runtime_path = "/usr/bin/nvidia-container-runtime"
+`,
+			hook: `{"version": "1.0.0", "hook": {"path": "/usr/bin/nvidia-container-runtime-hook", "args": ["nvidia-container-runtime-hook", "prestart"]}}`,
+			expectedStatus: engineStatus{
+				Name:              "crio",
+				Detected:          true,
+				RuntimeConfigured: true,
+				RuntimePath:       "/usr/bin/nvidia-container-runtime",
+				CDIEnabled:        true,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			dir := t.TempDir()
+			hookDir := filepath.Join(dir, "hooks.d")
+			require.NoError(t, os.MkdirAll(hookDir, 0755))
+
+			e := engines.Engine{
+				Name:           tc.engine,
+				Executable:     "nvidia-ctk-test-missing-executable",
+				ConfigFilePath: filepath.Join(dir, "config"),
+				HookDirs:       []string{hookDir},
+			}
+			tc.expectedStatus.ConfigFile = e.ConfigFilePath
+			if tc.config != "" {
+				require.NoError(t, os.WriteFile(e.ConfigFilePath, []byte(tc.config), 0600))
+			}
+			if tc.hook != "" {
+				hookFile := filepath.Join(hookDir, "oci-nvidia-hook.json")
+				require.NoError(t, os.WriteFile(hookFile, []byte(tc.hook), 0600))
+				tc.expectedStatus.HookFile = hookFile
+			}
+
+			c := command{logger: logger}
+			require.EqualValues(t, tc.expectedStatus, c.getEngineStatus(e, defaultNVIDIARuntimeName))
+		})
+	}
+}
@@ -0,0 +1,518 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package setup
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	nvinfo "github.com/NVIDIA/go-nvlib/pkg/nvlib/info"
+	"github.com/urfave/cli/v3"
+	"sigs.k8s.io/yaml"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi/generate"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/runtime/configure"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/engines"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+const (
+	defaultCDIOutputFile   = "/var/run/cdi/nvidia.yaml"
+	defaultValidationImage = "ubuntu"
+)
+
+// modes are the NVIDIA Container Runtime modes that can be selected.
+var modes = []string{"auto", "cdi", "legacy", "csv"}
+
+type command struct {
+	logger         logger.Interface
+	configFilePath *string
+}
+
+type options struct {
+	nonInteractive bool
+	answersFile    string
+}
+
+// answers are the answers to the questions asked by the setup wizard. These
+// can be loaded from a file to run the setup non-interactively. Unset
+// answers are replaced by the recommended values.
+type answers struct {
+	// Engines are the names of the container engines to configure. If this
+	// is not set, all detected engines are configured.
+	Engines []string `json:"engines,omitempty"`
+	// Mode is the mode of the NVIDIA Container Runtime.
+	Mode string `json:"mode,omitempty"`
+	// SetAsDefault indicates whether the NVIDIA runtime is set as the
+	// default runtime of the configured engines.
+	SetAsDefault *bool `json:"setAsDefault,omitempty"`
+	// GenerateCDISpec indicates whether a CDI specification is generated.
+	GenerateCDISpec *bool `json:"generateCDISpec,omitempty"`
+	// RestartEngines indicates whether the configured engines are restarted
+	// to apply the updated configuration.
+	RestartEngines *bool `json:"restartEngines,omitempty"`
+	// Validate indicates whether a validation container is run.
+	Validate *bool `json:"validate,omitempty"`
+	// ValidationImage is the image of the validation container.
+	ValidationImage string `json:"validationImage,omitempty"`
+}
+
+// NewCommand constructs a setup command with the specified logger
+func NewCommand(logger logger.Interface, configFilePath *string) *cli.Command {
+	c := command{
+		logger:         logger,
+		configFilePath: configFilePath,
+	}
+	return c.build()
+}
+
+// build the setup command
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name:  "setup",
+		Usage: "Set up the NVIDIA Container Toolkit: detect the installed container engines, configure these for the recommended mode, generate a CDI specification, and run a validation container",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(&opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(ctx, &opts)
+		},
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "non-interactive",
+				Usage:       "Do not prompt for answers. The answers from the answers file or the recommended values are used instead.",
+				Destination: &opts.nonInteractive,
+			},
+			&cli.StringFlag{
+				Name:        "answers-file",
+				Usage:       "The path to a YAML or JSON file with answers to the setup questions. In interactive mode these are offered as defaults.",
+				Destination: &opts.answersFile,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(opts *options) error {
+	if opts.answersFile == "" {
+		return nil
+	}
+	if _, err := loadAnswers(opts.answersFile); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m command) run(ctx context.Context, opts *options) error {
+	a := &answers{}
+	if opts.answersFile != "" {
+		loaded, err := loadAnswers(opts.answersFile)
+		if err != nil {
+			return err
+		}
+		a = loaded
+	}
+
+	w := &wizard{
+		logger:          m.logger,
+		prompter:        newPrompter(os.Stdin, os.Stdout, opts.nonInteractive),
+		out:             os.Stdout,
+		engines:         detectEngines(engines.Defaults),
+		recommendedMode: m.recommendMode(),
+		configFilePath:  m.configFilePath,
+	}
+
+	actions, err := w.plan(a)
+	if err != nil {
+		return err
+	}
+	if len(actions) == 0 {
+		fmt.Fprintln(w.out, "Nothing to do.")
+		return nil
+	}
+
+	fmt.Fprintln(w.out, "\nThe following steps will be performed:")
+	for _, action := range actions {
+		fmt.Fprintf(w.out, "  %v\n", action)
+	}
+	fmt.Fprintln(w.out)
+	proceed, err := w.prompter.confirm("Proceed?", true)
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		return fmt.Errorf("setup aborted")
+	}
+
+	for _, action := range actions {
+		w.logger.Infof("Running %v", action)
+		if err := w.run(ctx, action); err != nil {
+			return fmt.Errorf("%v failed: %w", action, err)
+		}
+	}
+	fmt.Fprintln(w.out, "\nThe NVIDIA Container Toolkit has been set up successfully.")
+	return nil
+}
+
+// recommendMode returns the recommended mode of the NVIDIA Container Runtime
+// for the platform. CDI is recommended except on Tegra-based systems where the
+// CSV mode is required.
+func (m command) recommendMode() string {
+	platform := nvinfo.New(nvinfo.WithLogger(m.logger)).ResolvePlatform()
+	if platform == nvinfo.PlatformTegra {
+		return "csv"
+	}
+	return "cdi"
+}
+
+// loadAnswers loads the answers from the specified YAML or JSON file.
+func loadAnswers(path string) (*answers, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read answers file: %w", err)
+	}
+	a := &answers{}
+	if err := yaml.UnmarshalStrict(contents, a); err != nil {
+		return nil, fmt.Errorf("failed to parse answers file %v: %w", path, err)
+	}
+	if a.Mode != "" && !slices.Contains(modes, a.Mode) {
+		return nil, fmt.Errorf("unsupported mode %q in answers file; must be one of %v", a.Mode, modes)
+	}
+	for _, name := range a.Engines {
+		if !slices.ContainsFunc(engines.Defaults, func(e engines.Engine) bool { return e.Name == name }) {
+			return nil, fmt.Errorf("unsupported engine %q in answers file", name)
+		}
+	}
+	return a, nil
+}
+
+// detectEngines returns the engines that are installed on the system. An
+// engine is considered installed if its executable is found in the PATH or
+// its config file exists.
+func detectEngines(candidates []engines.Engine) []engines.Engine {
+	var detected []engines.Engine
+	for _, e := range candidates {
+		if _, err := exec.LookPath(e.Executable); err == nil {
+			detected = append(detected, e)
+			continue
+		}
+		if _, err := os.Stat(e.ConfigFilePath); err == nil {
+			detected = append(detected, e)
+		}
+	}
+	return detected
+}
+
+// An action is a single step of the setup. Each action is equivalent to
+// running the specified command.
+type action struct {
+	args []string
+	// subcommand is set for actions that run an nvidia-ctk command. In this
+	// case the args exclude the nvidia-ctk executable and the parent commands.
+	subcommand *cli.Command
+	// parents are the parent commands of the subcommand and are only used to
+	// describe the action.
+	parents []string
+}
+
+// String returns the command line that is equivalent to the action.
+func (a action) String() string {
+	if a.subcommand == nil {
+		return strings.Join(a.args, " ")
+	}
+	return strings.Join(append(append([]string{"nvidia-ctk"}, a.parents...), a.args...), " ")
+}
+
+type wizard struct {
+	logger          logger.Interface
+	prompter        *prompter
+	out             io.Writer
+	configFilePath  *string
+	engines         []engines.Engine
+	recommendedMode string
+}
+
+// plan asks the setup questions and returns the actions that are required
+// to set up the toolkit according to the answers.
+func (w *wizard) plan(a *answers) ([]action, error) {
+	if len(w.engines) == 0 && len(a.Engines) == 0 {
+		return nil, fmt.Errorf("no supported container engines detected; install one of docker, containerd, crio, or podman")
+	}
+	var detected []string
+	for _, e := range w.engines {
+		detected = append(detected, e.Name)
+	}
+	fmt.Fprintf(w.out, "Detected container engines: %v\n", strings.Join(detected, ", "))
+
+	selected, err := w.selectEngines(a)
+	if err != nil {
+		return nil, err
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no container engines selected")
+	}
+
+	mode, err := w.prompter.choose(fmt.Sprintf("NVIDIA Container Runtime mode (recommended: %v)", w.recommendedMode), modes, valueOr(a.Mode, w.recommendedMode))
+	if err != nil {
+		return nil, err
+	}
+
+	var setAsDefault bool
+	if slices.ContainsFunc(selected, usesNVIDIARuntime) {
+		setAsDefault, err = w.prompter.confirm("Set the NVIDIA runtime as the default runtime?", boolOr(a.SetAsDefault, false))
+		if err != nil {
+			return nil, err
+		}
+	}
+	generateCDISpec, err := w.prompter.confirm("Generate a CDI specification?", boolOr(a.GenerateCDISpec, true))
+	if err != nil {
+		return nil, err
+	}
+	var restart bool
+	if slices.ContainsFunc(selected, hasService) {
+		restart, err = w.prompter.confirm("Restart the configured container engines?", boolOr(a.RestartEngines, true))
+		if err != nil {
+			return nil, err
+		}
+	}
+	validate, err := w.prompter.confirm("Run a validation container?", boolOr(a.Validate, true))
+	if err != nil {
+		return nil, err
+	}
+	validationImage := valueOr(a.ValidationImage, defaultValidationImage)
+	if validate {
+		validationImage, err = w.prompter.ask("Validation image", validationImage)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var actions []action
+	for _, e := range selected {
+		args := []string{"configure", "--runtime=" + e.Name, "--config=" + e.ConfigFilePath}
+		if setAsDefault && usesNVIDIARuntime(e) {
+			args = append(args, "--set-as-default")
+		}
+		if mode == "cdi" {
+			args = append(args, "--cdi.enabled")
+		}
+		actions = append(actions, action{args: args, subcommand: configure.NewCommand(w.logger), parents: []string{"runtime"}})
+	}
+
+	configArgs := []string{"config", "--in-place", "--set", "nvidia-container-runtime.mode=" + mode}
+	if w.configFilePath != nil && *w.configFilePath != "" {
+		configArgs = append(configArgs, "--config-file="+*w.configFilePath)
+	}
+	actions = append(actions, action{args: configArgs, subcommand: config.NewCommand(w.logger)})
+
+	if generateCDISpec {
+		generateArgs := []string{"generate", "--output=" + defaultCDIOutputFile}
+		actions = append(actions, action{args: generateArgs, subcommand: generate.NewCommand(w.logger, w.configFilePath), parents: []string{"cdi"}})
+	}
+	if restart {
+		for _, e := range selected {
+			if hasService(e) {
+				actions = append(actions, action{args: []string{"systemctl", "restart", e.Name}})
+			}
+		}
+	}
+	if validate {
+		for _, e := range selected {
+			args := getValidationArgs(e, mode, validationImage)
+			if args == nil {
+				fmt.Fprintf(w.out, "Skipping validation container for %v; this is only supported for docker and podman\n", e.Name)
+				continue
+			}
+			actions = append(actions, action{args: args})
+		}
+	}
+	return actions, nil
+}
+
+// selectEngines returns the engines to configure. In non-interactive mode,
+// the engines specified in the answers are used if set. Otherwise the user is
+// asked to confirm each detected engine and the engines in the answers are
+// offered as defaults.
+func (w *wizard) selectEngines(a *answers) ([]engines.Engine, error) {
+	if w.prompter.nonInteractive && len(a.Engines) > 0 {
+		var selected []engines.Engine
+		for _, e := range engines.Defaults {
+			if slices.Contains(a.Engines, e.Name) {
+				selected = append(selected, e)
+			}
+		}
+		return selected, nil
+	}
+
+	var selected []engines.Engine
+	for _, e := range w.engines {
+		defaultValue := len(a.Engines) == 0 || slices.Contains(a.Engines, e.Name)
+		configure, err := w.prompter.confirm(fmt.Sprintf("Configure %v?", e.Name), defaultValue)
+		if err != nil {
+			return nil, err
+		}
+		if configure {
+			selected = append(selected, e)
+		}
+	}
+	return selected, nil
+}
+
+// run performs the specified action.
+func (w *wizard) run(ctx context.Context, a action) error {
+	if a.subcommand != nil {
+		return a.subcommand.Run(ctx, a.args)
+	}
+	cmd := exec.CommandContext(ctx, a.args[0], a.args[1:]...)
+	cmd.Stdout = w.out
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// getValidationArgs returns the command line used to run a validation
+// container that lists the GPUs using the specified engine. If running a
+// validation container is not supported for the engine, nil is returned.
+func getValidationArgs(e engines.Engine, mode string, image string) []string {
+	switch e.Name {
+	case "docker":
+		return []string{"docker", "run", "--rm", "--runtime=nvidia", "-e", "NVIDIA_VISIBLE_DEVICES=all", image, "nvidia-smi", "-L"}
+	case "podman":
+		if mode != "cdi" {
+			return []string{"podman", "run", "--rm", "-e", "NVIDIA_VISIBLE_DEVICES=all", image, "nvidia-smi", "-L"}
+		}
+		return []string{"podman", "run", "--rm", "--device=nvidia.com/gpu=all", image, "nvidia-smi", "-L"}
+	default:
+		return nil
+	}
+}
+
+// usesNVIDIARuntime checks whether the NVIDIA runtime is configured for the
+// engine. Podman uses CDI or OCI hooks instead.
+func usesNVIDIARuntime(e engines.Engine) bool {
+	return e.Name != "podman"
+}
+
+// hasService checks whether the engine runs as a daemon that must be
+// restarted to apply an updated config.
+func hasService(e engines.Engine) bool {
+	return e.Name != "podman"
+}
+
+func valueOr(value string, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+func boolOr(value *bool, defaultValue bool) bool {
+	if value == nil {
+		return defaultValue
+	}
+	return *value
+}
+
+// A prompter asks the setup questions. In non-interactive mode, the default
+// answer is returned for each question.
+type prompter struct {
+	in             *bufio.Reader
+	out            io.Writer
+	nonInteractive bool
+}
+
+func newPrompter(in io.Reader, out io.Writer, nonInteractive bool) *prompter {
+	return &prompter{
+		in:             bufio.NewReader(in),
+		out:            out,
+		nonInteractive: nonInteractive,
+	}
+}
+
+// ask asks the specified question and returns the answer. If no answer is
+// given, the default value is returned.
+func (p *prompter) ask(question string, defaultValue string) (string, error) {
+	if p.nonInteractive {
+		return defaultValue, nil
+	}
+	answer, err := p.readAnswer(fmt.Sprintf("%v [%v]: ", question, defaultValue))
+	if err != nil || answer == "" {
+		return defaultValue, err
+	}
+	return answer, nil
+}
+
+// confirm asks the specified yes/no question.
+func (p *prompter) confirm(question string, defaultValue bool) (bool, error) {
+	if p.nonInteractive {
+		return defaultValue, nil
+	}
+	choices := "y/N"
+	if defaultValue {
+		choices = "Y/n"
+	}
+	for {
+		answer, err := p.readAnswer(fmt.Sprintf("%v [%v]: ", question, choices))
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return defaultValue, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		fmt.Fprintln(p.out, "Please answer yes or no.")
+	}
+}
+
+// choose asks the user to select one of the specified choices.
+func (p *prompter) choose(question string, choices []string, defaultValue string) (string, error) {
+	for {
+		answer, err := p.ask(question+" ("+strings.Join(choices, "/")+")", defaultValue)
+		if err != nil {
+			return "", err
+		}
+		if slices.Contains(choices, answer) {
+			return answer, nil
+		}
+		fmt.Fprintf(p.out, "Please answer one of %v.\n", strings.Join(choices, ", "))
+	}
+}
+
+// readAnswer writes the prompt and reads a single line of input.
+func (p *prompter) readAnswer(prompt string) (string, error) {
+	fmt.Fprint(p.out, prompt)
+	line, err := p.in.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+		return "", fmt.Errorf("failed to read answer; use --non-interactive to run without prompts: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
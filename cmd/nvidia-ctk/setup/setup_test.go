@@ -0,0 +1,196 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package setup
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/engines"
+)
+
+func TestPlan(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	yes := true
+	no := false
+
+	docker := engines.Engine{Name: "docker", ConfigFilePath: "/etc/docker/daemon.json"}
+	podman := engines.Engine{Name: "podman", ConfigFilePath: "/etc/containers/containers.conf.d/99-nvidia.conf"}
+
+	testCases := []struct {
+		description     string
+		engines         []engines.Engine
+		answers         *answers
+		input           string
+		expectedError   bool
+		expectedActions []string
+	}{
+		{
+			description:   "no engines returns error",
+			answers:       &answers{},
+			expectedError: true,
+		},
+		{
+			description: "recommended values are used in non-interactive mode",
+			engines:     []engines.Engine{docker, podman},
+			answers:     &answers{},
+			expectedActions: []string{
+				"nvidia-ctk runtime configure --runtime=docker --config=/etc/docker/daemon.json --cdi.enabled",
+				"nvidia-ctk runtime configure --runtime=podman --config=/etc/containers/containers.conf.d/99-nvidia.conf --cdi.enabled",
+				"nvidia-ctk config --in-place --set nvidia-container-runtime.mode=cdi",
+				"nvidia-ctk cdi generate --output=/var/run/cdi/nvidia.yaml",
+				"systemctl restart docker",
+				"docker run --rm --runtime=nvidia -e NVIDIA_VISIBLE_DEVICES=all ubuntu nvidia-smi -L",
+				"podman run --rm --device=nvidia.com/gpu=all ubuntu nvidia-smi -L",
+			},
+		},
+		{
+			description: "answers are used in non-interactive mode",
+			engines:     []engines.Engine{docker, podman},
+			answers: &answers{
+				Engines:         []string{"docker"},
+				Mode:            "legacy",
+				SetAsDefault:    &yes,
+				GenerateCDISpec: &no,
+				RestartEngines:  &no,
+				ValidationImage: "nvcr.io/nvidia/cuda:12.4.1-base-ubuntu22.04",
+			},
+			expectedActions: []string{
+				"nvidia-ctk runtime configure --runtime=docker --config=/etc/docker/daemon.json --set-as-default",
+				"nvidia-ctk config --in-place --set nvidia-container-runtime.mode=legacy",
+				"docker run --rm --runtime=nvidia -e NVIDIA_VISIBLE_DEVICES=all nvcr.io/nvidia/cuda:12.4.1-base-ubuntu22.04 nvidia-smi -L",
+			},
+		},
+		{
+			description: "answers are read interactively",
+			engines:     []engines.Engine{docker, podman},
+			answers:     &answers{},
+			// Configure docker, skip podman, select an invalid and then the
+			// legacy mode, use the defaults for the remaining questions,
+			// except for the validation container.
+			input: "\nn\nfoo\nlegacy\n\nno\n\nn\n",
+			expectedActions: []string{
+				"nvidia-ctk runtime configure --runtime=docker --config=/etc/docker/daemon.json",
+				"nvidia-ctk config --in-place --set nvidia-container-runtime.mode=legacy",
+				"systemctl restart docker",
+			},
+		},
+		{
+			description: "answers are offered as defaults",
+			engines:     []engines.Engine{docker, podman},
+			answers: &answers{
+				Engines: []string{"podman"},
+				Mode:    "legacy",
+			},
+			input: "\n\n\n\nn\n",
+			expectedActions: []string{
+				"nvidia-ctk runtime configure --runtime=podman --config=/etc/containers/containers.conf.d/99-nvidia.conf",
+				"nvidia-ctk config --in-place --set nvidia-container-runtime.mode=legacy",
+				"nvidia-ctk cdi generate --output=/var/run/cdi/nvidia.yaml",
+			},
+		},
+		{
+			description:   "missing input returns error",
+			engines:       []engines.Engine{docker},
+			answers:       &answers{},
+			input:         "\n",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var out bytes.Buffer
+			w := &wizard{
+				logger:          logger,
+				prompter:        newPrompter(strings.NewReader(tc.input), &out, tc.input == ""),
+				out:             &out,
+				engines:         tc.engines,
+				recommendedMode: "cdi",
+			}
+
+			actions, err := w.plan(tc.answers)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var descriptions []string
+			for _, a := range actions {
+				descriptions = append(descriptions, a.String())
+			}
+			require.EqualValues(t, tc.expectedActions, descriptions)
+		})
+	}
+}
+
+func TestLoadAnswers(t *testing.T) {
+	testCases := []struct {
+		description     string
+		contents        string
+		expectedError   bool
+		expectedAnswers *answers
+	}{
+		{
+			description: "yaml answers are loaded",
+			contents:    "engines: [docker]\nmode: cdi\nvalidate: false\n",
+			expectedAnswers: &answers{
+				Engines:  []string{"docker"},
+				Mode:     "cdi",
+				Validate: new(bool),
+			},
+		},
+		{
+			description:   "unknown field returns error",
+			contents:      "engine: docker\n",
+			expectedError: true,
+		},
+		{
+			description:   "unsupported mode returns error",
+			contents:      `{"mode": "jit-cdi"}`,
+			expectedError: true,
+		},
+		{
+			description:   "unsupported engine returns error",
+			contents:      `{"engines": ["lxc"]}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "answers.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.contents), 0600))
+
+			a, err := loadAnswers(path)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedAnswers, a)
+		})
+	}
+}
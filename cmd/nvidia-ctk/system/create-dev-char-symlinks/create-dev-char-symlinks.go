@@ -24,6 +24,7 @@ import (
 
 	"github.com/urfave/cli/v3"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/changeset"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/system/nvdevices"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/system/nvmodules"
@@ -103,7 +104,7 @@ func (m command) build() *cli.Command {
 			},
 			&cli.BoolFlag{
 				Name:        "dry-run",
-				Usage:       "If set, the command will not create any symlinks.",
+				Usage:       "If set, the command will not create any symlinks. Instead, the changes that would be made are written to STDOUT as a JSON change set.",
 				Value:       false,
 				Destination: &cfg.dryRun,
 				Sources:     cli.EnvVars("DRY_RUN"),
@@ -133,11 +134,17 @@ func (m command) validateFlags(cfg *config) error {
 }
 
 func (m command) run(cfg *config) error {
+	var changes *changeset.ChangeSet
+	if cfg.dryRun {
+		changes = changeset.New(true)
+	}
+
 	l, err := NewSymlinkCreator(
 		WithLogger(m.logger),
 		WithDevCharPath(cfg.devCharPath),
 		WithDriverRoot(cfg.driverRoot),
 		WithDryRun(cfg.dryRun),
+		WithChangeSet(changes),
 		WithCreateAll(cfg.createAll),
 		WithLoadKernelModules(cfg.loadKernelModules),
 		WithCreateDeviceNodes(cfg.createDeviceNodes),
@@ -150,6 +157,11 @@ func (m command) run(cfg *config) error {
 	if err != nil {
 		return fmt.Errorf("failed to create links: %v", err)
 	}
+
+	if changes != nil {
+		_, err := changes.WriteTo(os.Stdout)
+		return err
+	}
 	return nil
 }
 
@@ -160,6 +172,7 @@ type linkCreator struct {
 	devRoot           string
 	devCharPath       string
 	dryRun            bool
+	changes           *changeset.ChangeSet
 	createAll         bool
 	createDeviceNodes bool
 	loadKernelModules bool
@@ -217,6 +230,7 @@ func (m linkCreator) setup() error {
 		modules := nvmodules.New(
 			nvmodules.WithLogger(m.logger),
 			nvmodules.WithDryRun(m.dryRun),
+			nvmodules.WithChangeSet(m.changes),
 			nvmodules.WithRoot(m.driverRoot),
 		)
 		if err := modules.LoadAll(); err != nil {
@@ -228,6 +242,7 @@ func (m linkCreator) setup() error {
 		devices, err := nvdevices.New(
 			nvdevices.WithLogger(m.logger),
 			nvdevices.WithDryRun(m.dryRun),
+			nvdevices.WithChangeSet(m.changes),
 			nvdevices.WithDevRoot(m.devRoot),
 		)
 		if err != nil {
@@ -269,6 +284,14 @@ func WithDryRun(dryRun bool) Option {
 	}
 }
 
+// WithChangeSet sets the change set in which the changes that would be made
+// are recorded for a dry-run.
+func WithChangeSet(changes *changeset.ChangeSet) Option {
+	return func(c *linkCreator) {
+		c.changes = changes
+	}
+}
+
 // WithLogger sets the logger.
 func WithLogger(logger logger.Interface) Option {
 	return func(c *linkCreator) {
@@ -317,6 +340,7 @@ func (m linkCreator) CreateLinks() error {
 
 		m.logger.Infof("Creating link %s => %s", linkPath, target)
 		if m.dryRun {
+			m.recordLink(linkPath, target)
 			continue
 		}
 
@@ -329,6 +353,22 @@ func (m linkCreator) CreateLinks() error {
 	return nil
 }
 
+// recordLink records the creation of the specified symlink in the change set.
+// Existing links are skipped since these cannot be created.
+func (m linkCreator) recordLink(linkPath string, target string) {
+	if m.changes == nil {
+		return
+	}
+	if _, err := os.Lstat(linkPath); !os.IsNotExist(err) {
+		return
+	}
+	m.changes.Add(changeset.Change{
+		Action:      changeset.ActionCreate,
+		Path:        linkPath,
+		Description: "symlink to " + target,
+	})
+}
+
 type deviceNode struct {
 	path  string
 	major uint32
@@ -338,3 +378,18 @@ type deviceNode struct {
 func (d deviceNode) devCharName() string {
 	return fmt.Sprintf("%d:%d", d.major, d.minor)
 }
+
+// Links returns the /dev/char symlinks for the NVIDIA device nodes that exist
+// in the specified dev root. The returned map is keyed by the path of each
+// link and contains the path of the device node that it targets.
+func Links(logger logger.Interface, devRoot string, devCharPath string) (map[string]string, error) {
+	deviceNodes, err := existing{logger, devRoot}.DeviceNodes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get device nodes: %v", err)
+	}
+	links := make(map[string]string)
+	for _, deviceNode := range deviceNodes {
+		links[filepath.Join(devCharPath, deviceNode.devCharName())] = deviceNode.path
+	}
+	return links, nil
+}
@@ -19,9 +19,12 @@ package createdevicenodes
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/urfave/cli/v3"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/changeset"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/system/nvdevices"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/system/nvmodules"
@@ -40,6 +43,13 @@ type options struct {
 	control bool
 
 	loadKernelModules bool
+
+	deviceNodes struct {
+		user      string
+		group     string
+		mode      string
+		ownership config.DeviceNodeOwnership
+	}
 }
 
 // NewCommand constructs a command sub-command with the specified logger
@@ -91,9 +101,24 @@ func (m command) build() *cli.Command {
 				Usage:       "load the NVIDIA Kernel Modules before creating devices nodes",
 				Destination: &opts.loadKernelModules,
 			},
+			&cli.StringFlag{
+				Name:        "device-node-user",
+				Usage:       "the user (name or UID) to set as the owner of created device nodes",
+				Destination: &opts.deviceNodes.user,
+			},
+			&cli.StringFlag{
+				Name:        "device-node-group",
+				Usage:       "the group (name or GID) to set for created device nodes",
+				Destination: &opts.deviceNodes.group,
+			},
+			&cli.StringFlag{
+				Name:        "device-node-mode",
+				Usage:       "the octal file mode for created device nodes. If not specified, 0666 is used",
+				Destination: &opts.deviceNodes.mode,
+			},
 			&cli.BoolFlag{
 				Name:        "dry-run",
-				Usage:       "if set, the command will not perform any operations",
+				Usage:       "if set, the command will not perform any operations. Instead, the changes that would be made are written to STDOUT as a JSON change set",
 				Value:       false,
 				Destination: &opts.dryRun,
 				Sources:     cli.EnvVars("DRY_RUN"),
@@ -109,14 +134,26 @@ func (m command) validateFlags(opts *options) error {
 		m.logger.Infof("Using dev-root %q", opts.root)
 		opts.devRoot = opts.root
 	}
+
+	ownership, err := config.ResolveDeviceNodeOwnership(opts.deviceNodes.user, opts.deviceNodes.group, opts.deviceNodes.mode)
+	if err != nil {
+		return err
+	}
+	opts.deviceNodes.ownership = ownership
 	return nil
 }
 
 func (m command) run(opts *options) error {
+	var changes *changeset.ChangeSet
+	if opts.dryRun {
+		changes = changeset.New(true)
+	}
+
 	if opts.loadKernelModules {
 		modules := nvmodules.New(
 			nvmodules.WithLogger(m.logger),
 			nvmodules.WithDryRun(opts.dryRun),
+			nvmodules.WithChangeSet(changes),
 			nvmodules.WithRoot(opts.root),
 		)
 		if err := modules.LoadAll(); err != nil {
@@ -128,7 +165,13 @@ func (m command) run(opts *options) error {
 		devices, err := nvdevices.New(
 			nvdevices.WithLogger(m.logger),
 			nvdevices.WithDryRun(opts.dryRun),
+			nvdevices.WithChangeSet(changes),
 			nvdevices.WithDevRoot(opts.devRoot),
+			nvdevices.WithDeviceNodeOwnership(
+				opts.deviceNodes.ownership.UID,
+				opts.deviceNodes.ownership.GID,
+				opts.deviceNodes.ownership.FileMode,
+			),
 		)
 		if err != nil {
 			return err
@@ -138,5 +181,10 @@ func (m command) run(opts *options) error {
 			return fmt.Errorf("failed to create NVIDIA control device nodes: %v", err)
 		}
 	}
+
+	if changes != nil {
+		_, err := changes.WriteTo(os.Stdout)
+		return err
+	}
 	return nil
 }
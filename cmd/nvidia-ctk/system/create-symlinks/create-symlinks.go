@@ -0,0 +1,376 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package symlinks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	devchar "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/system/create-dev-char-symlinks"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/changeset"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+)
+
+const (
+	defaultDevCharPath = "/dev/char"
+)
+
+// driverLibraryLinks lists the symlinks that are expected in the directory of
+// each versioned driver library. An empty target refers to the versioned
+// library itself. The links to the SONAME of a library (e.g. libcuda.so.1) are
+// normally created by ldconfig, while the others are installed by the driver
+// packages.
+var driverLibraryLinks = []struct {
+	library string
+	link    string
+	target  string
+}{
+	{library: "libcuda.so", link: "libcuda.so.1"},
+	{library: "libcuda.so", link: "libcuda.so", target: "libcuda.so.1"},
+	{library: "libnvidia-ml.so", link: "libnvidia-ml.so.1"},
+	{library: "libnvidia-ptxjitcompiler.so", link: "libnvidia-ptxjitcompiler.so.1"},
+	{library: "libnvidia-encode.so", link: "libnvidia-encode.so.1"},
+	{library: "libnvcuvid.so", link: "libnvcuvid.so.1"},
+	{library: "libnvidia-opticalflow.so", link: "libnvidia-opticalflow.so.1"},
+	{library: "libnvidia-opticalflow.so", link: "libnvidia-opticalflow.so", target: "libnvidia-opticalflow.so.1"},
+}
+
+// driverLibraryPatterns are the patterns of the names of symlinks in the
+// driver library directories that are removed by a scan if they are dangling.
+var driverLibraryPatterns = []string{
+	"libcuda.so*",
+	"libcudadebugger.so*",
+	"libnvcuvid.so*",
+	"libnvidia-*.so*",
+	"libnvoptix.so*",
+	"libEGL_nvidia.so*",
+	"libGLESv1_CM_nvidia.so*",
+	"libGLESv2_nvidia.so*",
+	"libGLX_nvidia.so*",
+}
+
+type command struct {
+	logger logger.Interface
+}
+
+type config struct {
+	driverRoot  string
+	devRoot     string
+	devCharPath string
+	scan        bool
+	dryRun      bool
+}
+
+// A link is a symlink that is expected to exist.
+type link struct {
+	path   string
+	target string
+}
+
+// NewCommand constructs a create-symlinks command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build the create-symlinks command
+func (m command) build() *cli.Command {
+	cfg := config{}
+
+	c := cli.Command{
+		Name:  "create-symlinks",
+		Usage: "Create the symlinks expected for the NVIDIA driver libraries and device nodes (e.g. libcuda.so and /dev/char entries)",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(&cfg)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(&cfg)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "driver-root",
+				Usage:       "The path to the driver root. The driver libraries are located relative to this path.",
+				Value:       "/",
+				Destination: &cfg.driverRoot,
+				Sources:     cli.EnvVars("NVIDIA_DRIVER_ROOT", "DRIVER_ROOT"),
+			},
+			&cli.StringFlag{
+				Name:        "dev-root",
+				Usage:       "The root path in which the NVIDIA device nodes are located. `DEV_ROOT`/dev is searched for NVIDIA device nodes.",
+				Value:       "/",
+				Destination: &cfg.devRoot,
+				Sources:     cli.EnvVars("NVIDIA_DEV_ROOT", "DEV_ROOT"),
+			},
+			&cli.StringFlag{
+				Name:        "dev-char-path",
+				Usage:       "The path at which the /dev/char symlinks are created.",
+				Value:       defaultDevCharPath,
+				Destination: &cfg.devCharPath,
+				Sources:     cli.EnvVars("DEV_CHAR_PATH"),
+			},
+			&cli.BoolFlag{
+				Name: "scan",
+				Usage: "Scan for existing symlinks that are dangling or point to the wrong target and repair these. " +
+					"Without this flag only missing symlinks are created. This is intended to be run periodically on long-lived hosts.",
+				Destination: &cfg.scan,
+				Sources:     cli.EnvVars("SCAN"),
+			},
+			&cli.BoolFlag{
+				Name:        "dry-run",
+				Usage:       "If set, no symlinks are modified. Instead, the changes that would be made are written to STDOUT as a JSON change set.",
+				Destination: &cfg.dryRun,
+				Sources:     cli.EnvVars("DRY_RUN"),
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(cfg *config) error {
+	if cfg.driverRoot == "" {
+		return fmt.Errorf("a driver root must be specified")
+	}
+	if cfg.devCharPath == "" {
+		return fmt.Errorf("a /dev/char path must be specified")
+	}
+	return nil
+}
+
+func (m command) run(cfg *config) error {
+	changes := changeset.New(cfg.dryRun)
+
+	links, libraryDirs, err := m.getDriverLibraryLinks(cfg.driverRoot)
+	if err != nil {
+		m.logger.Warningf("Skipping driver library symlinks: %v", err)
+	}
+	devCharLinks, err := devchar.Links(m.logger, cfg.devRoot, cfg.devCharPath)
+	if err != nil {
+		return err
+	}
+	for path, target := range devCharLinks {
+		links = append(links, link{path: path, target: target})
+	}
+	sort.Slice(links, func(i, j int) bool {
+		return links[i].path < links[j].path
+	})
+
+	var errs error
+	if cfg.scan {
+		expected := make(map[string]bool)
+		for _, l := range links {
+			expected[l.path] = true
+		}
+		for _, dir := range libraryDirs {
+			errs = errors.Join(errs, m.removeDanglingLinks(cfg, changes, dir, expected, isDriverLibraryLink))
+		}
+		errs = errors.Join(errs, m.removeDanglingLinks(cfg, changes, cfg.devCharPath, expected, isDeviceNodeLink))
+	}
+	for _, l := range links {
+		errs = errors.Join(errs, m.ensureLink(cfg, changes, l))
+	}
+
+	if cfg.dryRun {
+		if _, err := changes.WriteTo(os.Stdout); err != nil {
+			errs = errors.Join(errs, err)
+		}
+	} else if changes.Changed {
+		m.logger.Infof("Updated %d symlinks", len(changes.Changes))
+	}
+	return errs
+}
+
+// getDriverLibraryLinks returns the symlinks that are expected for the driver
+// libraries at the specified driver root. The directories that contain the
+// driver libraries are also returned.
+func (m command) getDriverLibraryLinks(driverRoot string) ([]link, []string, error) {
+	driver := root.New(
+		root.WithLogger(m.logger),
+		root.WithDriverRoot(driverRoot),
+	)
+	version, err := driver.Version()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var links []link
+	var dirs []string
+	for _, l := range driverLibraryLinks {
+		libraryPaths, err := driver.Libraries().Locate(l.library + "." + version)
+		if err != nil {
+			m.logger.Debugf("Skipping %v: %v", l.link, err)
+			continue
+		}
+		dir, library := filepath.Split(libraryPaths[0])
+		dir = filepath.Clean(dir)
+		target := l.target
+		if target == "" {
+			target = library
+		}
+		links = append(links, link{path: filepath.Join(dir, l.link), target: target})
+		if !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	return links, dirs, nil
+}
+
+// ensureLink creates the specified symlink if it does not exist. If scanning
+// is enabled, existing symlinks that do not resolve to the same file as the
+// expected target are replaced.
+func (m command) ensureLink(cfg *config, changes *changeset.ChangeSet, l link) error {
+	current, err := os.Readlink(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		m.logger.Infof("Creating link %v => %v", l.path, l.target)
+		changes.Add(changeset.Change{
+			Action:      changeset.ActionCreate,
+			Path:        l.path,
+			Description: "symlink to " + l.target,
+		})
+		if cfg.dryRun {
+			return nil
+		}
+		if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %v: %w", l.path, err)
+		}
+		return os.Symlink(l.target, l.path)
+	}
+	if err != nil {
+		m.logger.Warningf("Skipping %v: %v", l.path, err)
+		return nil
+	}
+	if isSameFile(l.path, resolveTarget(l)) {
+		return nil
+	}
+	if !cfg.scan {
+		m.logger.Warningf("%v links to %v instead of %v; run with --scan to repair", l.path, current, l.target)
+		return nil
+	}
+
+	m.logger.Infof("Repairing link %v => %v (was %v)", l.path, l.target, current)
+	changes.Add(changeset.Change{
+		Action:      changeset.ActionUpdate,
+		Path:        l.path,
+		From:        current,
+		To:          l.target,
+		Description: "symlink target",
+	})
+	if cfg.dryRun {
+		return nil
+	}
+	if err := os.Remove(l.path); err != nil {
+		return fmt.Errorf("failed to remove %v: %w", l.path, err)
+	}
+	return os.Symlink(l.target, l.path)
+}
+
+// removeDanglingLinks removes the dangling symlinks in the specified directory
+// that match the filter and are not expected. Expected symlinks are repaired
+// instead.
+func (m command) removeDanglingLinks(cfg *config, changes *changeset.ChangeSet, dir string, expected map[string]bool, filter func(name string, target string) bool) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %v: %w", dir, err)
+	}
+
+	var errs error
+	for _, entry := range entries {
+		if entry.Type()&os.ModeSymlink == 0 {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		if expected[path] {
+			continue
+		}
+		target, err := os.Readlink(path)
+		if err != nil || !filter(entry.Name(), target) {
+			continue
+		}
+		if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+
+		m.logger.Infof("Removing dangling link %v => %v", path, target)
+		changes.Add(changeset.Change{
+			Action:      changeset.ActionDelete,
+			Path:        path,
+			Description: "dangling symlink to " + target,
+		})
+		if cfg.dryRun {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to remove %v: %w", path, err))
+		}
+	}
+	return errs
+}
+
+// isDriverLibraryLink checks whether a symlink with the specified name refers
+// to a driver library.
+func isDriverLibraryLink(name string, _ string) bool {
+	for _, pattern := range driverLibraryPatterns {
+		if match, _ := filepath.Match(pattern, name); match {
+			return true
+		}
+	}
+	return false
+}
+
+// isDeviceNodeLink checks whether a symlink with the specified target refers
+// to an NVIDIA device node.
+func isDeviceNodeLink(_ string, target string) bool {
+	return strings.HasPrefix(filepath.Base(target), "nvidia")
+}
+
+// resolveTarget returns the path of the target of the specified link.
+// Relative targets are resolved relative to the directory of the link.
+func resolveTarget(l link) string {
+	if filepath.IsAbs(l.target) {
+		return l.target
+	}
+	return filepath.Join(filepath.Dir(l.path), l.target)
+}
+
+// isSameFile checks whether the specified paths resolve to the same file.
+// If either path cannot be resolved, false is returned.
+func isSameFile(path string, other string) bool {
+	pathInfo, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	otherInfo, err := os.Stat(other)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(pathInfo, otherInfo)
+}
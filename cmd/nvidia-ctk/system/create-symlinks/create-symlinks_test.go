@@ -0,0 +1,127 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package symlinks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateSymlinks(t *testing.T) {
+	t.Setenv("__NVCT_TESTING_DEVICES_ARE_FILES", "true")
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description   string
+		scan          bool
+		expectedLinks func(devRoot string) map[string]string
+	}{
+		{
+			description: "missing links are created",
+			expectedLinks: func(devRoot string) map[string]string {
+				return map[string]string{
+					"usr/lib64/libcuda.so":          "libcuda.so.1",
+					"usr/lib64/libcuda.so.1":        "libcuda.so.535.104.05",
+					"usr/lib64/libnvidia-ml.so.1":   "libnvidia-ml.so.550.54.14",
+					"usr/lib64/libnvidia-fbc.so.1":  "libnvidia-fbc.so.535.104.05",
+					"usr/lib64/libnvidia-cfg.so.1":  "libnvidia-cfg.so.550.54.14",
+					"dev/char/0:0":                  filepath.Join(devRoot, "dev/nvidia0"),
+					"dev/char/195:1":                "/dev/nvidia1",
+					"dev/char/10:200":               "/dev/net/tun",
+					"usr/lib64/libnvidia-unrelated": "missing",
+				}
+			},
+		},
+		{
+			description: "scan repairs links",
+			scan:        true,
+			expectedLinks: func(devRoot string) map[string]string {
+				return map[string]string{
+					"usr/lib64/libcuda.so":          "libcuda.so.1",
+					"usr/lib64/libcuda.so.1":        "libcuda.so.550.54.14",
+					"usr/lib64/libnvidia-ml.so.1":   "libnvidia-ml.so.550.54.14",
+					"usr/lib64/libnvidia-cfg.so.1":  "libnvidia-cfg.so.550.54.14",
+					"dev/char/0:0":                  filepath.Join(devRoot, "dev/nvidia0"),
+					"dev/char/10:200":               "/dev/net/tun",
+					"usr/lib64/libnvidia-unrelated": "missing",
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			root := t.TempDir()
+			devRoot := t.TempDir()
+
+			libDir := filepath.Join(root, "usr/lib64")
+			require.NoError(t, os.MkdirAll(libDir, 0755))
+			for _, library := range []string{"libcuda.so.550.54.14", "libnvidia-ml.so.550.54.14", "libnvidia-cfg.so.550.54.14"} {
+				require.NoError(t, os.WriteFile(filepath.Join(libDir, library), nil, 0644))
+			}
+			// The links left behind by a previous driver version.
+			require.NoError(t, os.Symlink("libcuda.so.535.104.05", filepath.Join(libDir, "libcuda.so.1")))
+			require.NoError(t, os.Symlink("libnvidia-fbc.so.535.104.05", filepath.Join(libDir, "libnvidia-fbc.so.1")))
+			// A working link that is not managed by the command.
+			require.NoError(t, os.Symlink("libnvidia-cfg.so.550.54.14", filepath.Join(libDir, "libnvidia-cfg.so.1")))
+			// A dangling link that does not refer to a driver library.
+			require.NoError(t, os.Symlink("missing", filepath.Join(libDir, "libnvidia-unrelated")))
+
+			require.NoError(t, os.MkdirAll(filepath.Join(devRoot, "dev"), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(devRoot, "dev/nvidia0"), nil, 0644))
+
+			devCharPath := filepath.Join(root, "dev/char")
+			require.NoError(t, os.MkdirAll(devCharPath, 0755))
+			require.NoError(t, os.Symlink("/dev/nvidia1", filepath.Join(devCharPath, "195:1")))
+			require.NoError(t, os.Symlink("/dev/net/tun", filepath.Join(devCharPath, "10:200")))
+
+			cfg := &config{
+				driverRoot:  root,
+				devRoot:     devRoot,
+				devCharPath: devCharPath,
+				scan:        tc.scan,
+			}
+			m := command{logger: logger}
+			require.NoError(t, m.validateFlags(cfg))
+			require.NoError(t, m.run(cfg))
+
+			links := make(map[string]string)
+			err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+				if err != nil || info.Mode()&os.ModeSymlink == 0 {
+					return err
+				}
+				target, err := os.Readlink(path)
+				if err != nil {
+					return err
+				}
+				relative, err := filepath.Rel(root, path)
+				if err != nil {
+					return err
+				}
+				links[relative] = target
+				return nil
+			})
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedLinks(devRoot), links)
+		})
+	}
+}
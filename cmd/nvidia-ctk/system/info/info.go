@@ -0,0 +1,92 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package info
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	nvinfo "github.com/NVIDIA/go-nvlib/pkg/nvlib/info"
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info/proc"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	driverRoot string
+}
+
+// NewCommand constructs an info command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name:  "info",
+		Usage: "Show information about the NVIDIA driver installation on the system",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(os.Stdout, &opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "driver-root",
+				Usage:       "the path to the driver root",
+				Value:       "/",
+				Destination: &opts.driverRoot,
+				Sources:     cli.EnvVars("NVIDIA_DRIVER_ROOT", "DRIVER_ROOT"),
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) run(w io.Writer, opts *options) error {
+	platform := nvinfo.New(
+		nvinfo.WithLogger(m.logger),
+		nvinfo.WithRoot(opts.driverRoot),
+	).ResolvePlatform()
+	fmt.Fprintf(w, "Platform: %v\n", platform)
+
+	kernelModule, err := proc.GetKernelModuleInfo("/")
+	if err != nil {
+		m.logger.Warningf("Failed to get kernel module information: %v", err)
+		fmt.Fprintf(w, "Kernel module: not loaded\n")
+		return nil
+	}
+	fmt.Fprintf(w, "Kernel module version: %v\n", kernelModule.Version)
+	fmt.Fprintf(w, "Kernel module flavor: %v\n", kernelModule.Flavor)
+	if kernelModule.Flavor == proc.KernelModuleFlavorOpen {
+		fmt.Fprintf(w, "GSP firmware required: true\n")
+	}
+	return nil
+}
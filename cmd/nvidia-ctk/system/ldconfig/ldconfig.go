@@ -0,0 +1,312 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package ldconfig
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/manifest"
+)
+
+const (
+	defaultDriverRoot   = "/run/nvidia/driver"
+	defaultLdsoconfFile = "/etc/ld.so.conf.d/nvidia-driver-root.conf"
+	defaultLdconfigPath = "/sbin/ldconfig"
+)
+
+// driverLibraries are the libraries used to determine the library directories
+// of the driver root.
+var driverLibraries = []string{
+	"libcuda.so.1",
+	"libnvidia-ml.so.1",
+}
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	driverRoot   string
+	hostRoot     string
+	ldsoconfFile string
+	ldconfigPath string
+	manifestPath string
+	remove       bool
+	dryRun       bool
+}
+
+// NewCommand constructs an ldconfig command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name:  "ldconfig",
+		Usage: "Update the host ldcache to include the driver libraries from a driver root such as one provided by a driver container",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(&opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(&opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "driver-root",
+				Usage:       "the path to the driver root on the host",
+				Value:       defaultDriverRoot,
+				Destination: &opts.driverRoot,
+				Sources:     cli.EnvVars("NVIDIA_DRIVER_ROOT", "DRIVER_ROOT"),
+			},
+			&cli.StringFlag{
+				Name:        "host-root",
+				Usage:       "the path at which the host root filesystem is available. This is used when running in a container with the host root mounted",
+				Value:       "/",
+				Destination: &opts.hostRoot,
+				Sources:     cli.EnvVars("HOST_ROOT"),
+			},
+			&cli.StringFlag{
+				Name:        "ldsoconf-file",
+				Usage:       "the path of the ld.so.conf.d file on the host that references the driver library directories",
+				Value:       defaultLdsoconfFile,
+				Destination: &opts.ldsoconfFile,
+			},
+			&cli.StringFlag{
+				Name:        "ldconfig-path",
+				Usage:       "the path to the ldconfig executable on the host",
+				Value:       defaultLdconfigPath,
+				Destination: &opts.ldconfigPath,
+			},
+			&cli.StringFlag{
+				Name:        "manifest-path",
+				Usage:       "the path of the manifest on the host in which the ld.so.conf.d file is recorded so that it can be removed by nvidia-ctk uninstall",
+				Value:       manifest.DefaultPath,
+				Destination: &opts.manifestPath,
+				Hidden:      true,
+			},
+			&cli.BoolFlag{
+				Name:        "remove",
+				Usage:       "remove the ld.so.conf.d file and update the ldcache. This is intended to be run when the driver root is removed",
+				Destination: &opts.remove,
+			},
+			&cli.BoolFlag{
+				Name:        "dry-run",
+				Usage:       "if set, the command will not perform any operations",
+				Destination: &opts.dryRun,
+				Sources:     cli.EnvVars("DRY_RUN"),
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(opts *options) error {
+	for name, path := range map[string]string{
+		"driver root":   opts.driverRoot,
+		"host root":     opts.hostRoot,
+		"ldsoconf file": opts.ldsoconfFile,
+		"ldconfig path": opts.ldconfigPath,
+	} {
+		if !filepath.IsAbs(path) {
+			return fmt.Errorf("the %v %q is not an absolute path", name, path)
+		}
+	}
+	if filepath.Clean(opts.driverRoot) == "/" && !opts.remove {
+		return fmt.Errorf("the driver root must not be the host root")
+	}
+	if !strings.HasSuffix(opts.ldsoconfFile, ".conf") {
+		return fmt.Errorf("the ldsoconf file %q must have a .conf extension to be included by ld.so.conf", opts.ldsoconfFile)
+	}
+	return nil
+}
+
+func (m command) run(opts *options) error {
+	updated, err := m.updateLdsoconfFile(opts)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		m.logger.Infof("The ld.so.conf.d file %v is up to date", opts.ldsoconfFile)
+		return nil
+	}
+	if opts.dryRun {
+		return nil
+	}
+	return m.runLdconfig(opts)
+}
+
+// updateLdsoconfFile creates, updates, or removes the ld.so.conf.d file
+// referencing the driver library directories. Whether the file was changed is
+// returned.
+func (m command) updateLdsoconfFile(opts *options) (bool, error) {
+	path := filepath.Join(opts.hostRoot, opts.ldsoconfFile)
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("failed to read %v: %w", opts.ldsoconfFile, err)
+	}
+
+	if opts.remove {
+		if existing == nil {
+			return false, nil
+		}
+		m.logger.Infof("Removing %v", opts.ldsoconfFile)
+		if opts.dryRun {
+			return true, nil
+		}
+		if err := os.Remove(path); err != nil {
+			return false, err
+		}
+		m.recordLdsoconfFile(opts)
+		return true, nil
+	}
+
+	dirs, err := m.getLibraryDirs(opts)
+	if err != nil {
+		return false, err
+	}
+	contents := []byte(strings.Join(dirs, "\n") + "\n")
+	if bytes.Equal(existing, contents) {
+		return false, nil
+	}
+
+	m.logger.Infof("Writing %v referencing %v", opts.ldsoconfFile, dirs)
+	if opts.dryRun {
+		return true, nil
+	}
+	if err := writeFileAtomic(path, contents); err != nil {
+		return false, err
+	}
+	m.recordLdsoconfFile(opts)
+	return true, nil
+}
+
+// recordLdsoconfFile records the ld.so.conf.d file in the manifest so that it
+// is removed when the NVIDIA Container Toolkit is uninstalled. Since the file
+// has already been updated, a failure is only logged.
+func (m command) recordLdsoconfFile(opts *options) {
+	if opts.manifestPath == "" {
+		return
+	}
+	manifestPath := filepath.Join(opts.hostRoot, opts.manifestPath)
+	err := manifest.Update(manifestPath, func(mf *manifest.Manifest) {
+		if opts.remove {
+			mf.RemoveLdsoconfFile(opts.ldsoconfFile)
+		} else {
+			mf.AddLdsoconfFile(opts.ldsoconfFile)
+		}
+	})
+	if err != nil {
+		m.logger.Warningf("Failed to record %v in %v: %v", opts.ldsoconfFile, opts.manifestPath, err)
+	}
+}
+
+// getLibraryDirs returns the directories containing the driver libraries in
+// the driver root. The directories are returned as paths on the host.
+func (m command) getLibraryDirs(opts *options) ([]string, error) {
+	driver := root.New(
+		root.WithLogger(m.logger),
+		root.WithDriverRoot(filepath.Join(opts.hostRoot, opts.driverRoot)),
+	)
+
+	seen := make(map[string]bool)
+	var dirs []string
+	for _, library := range driverLibraries {
+		candidates, err := driver.Libraries().Locate(library)
+		if err != nil {
+			m.logger.Warningf("Failed to locate %v in %v: %v", library, opts.driverRoot, err)
+			continue
+		}
+		relative, err := filepath.Rel(opts.hostRoot, filepath.Dir(candidates[0]))
+		if err != nil || strings.HasPrefix(relative, "..") {
+			return nil, fmt.Errorf("the library directory for %v is not on the host root", library)
+		}
+		dir := filepath.Join("/", relative)
+		if seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		dirs = append(dirs, dir)
+	}
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("no driver libraries found in %v", opts.driverRoot)
+	}
+	return dirs, nil
+}
+
+// runLdconfig updates the host ldcache. If a host root is specified, the
+// ldconfig executable of the host is run with the host root as its root.
+func (m command) runLdconfig(opts *options) error {
+	ldconfigPath := filepath.Join(opts.hostRoot, opts.ldconfigPath)
+	var args []string
+	if filepath.Clean(opts.hostRoot) != "/" {
+		args = append(args, "-r", opts.hostRoot)
+	}
+
+	m.logger.Infof("Running %v %v", ldconfigPath, strings.Join(args, " "))
+	//nolint:gosec // The ldconfig path is specified by the user running the command.
+	cmd := exec.Command(ldconfigPath, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to run ldconfig: %w", err)
+	}
+	return nil
+}
+
+// writeFileAtomic writes the contents to the specified path by renaming a
+// temporary file so that ldconfig never reads a partially written file.
+func writeFileAtomic(path string, contents []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %v: %w", dir, err)
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if _, err := tmp.Write(contents); err != nil {
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		return fmt.Errorf("failed to set permissions: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
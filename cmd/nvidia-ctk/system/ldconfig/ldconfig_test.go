@@ -0,0 +1,90 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package ldconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/manifest"
+)
+
+func TestUpdateLdsoconfFile(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	hostRoot := t.TempDir()
+
+	driverRoot := filepath.Join(hostRoot, "run", "nvidia", "driver")
+	for _, library := range []string{"usr/lib64/libcuda.so.1", "usr/lib64/libnvidia-ml.so.1"} {
+		path := filepath.Join(driverRoot, library)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, nil, 0600))
+	}
+
+	opts := &options{
+		driverRoot:   "/run/nvidia/driver",
+		hostRoot:     hostRoot,
+		ldsoconfFile: defaultLdsoconfFile,
+		manifestPath: manifest.DefaultPath,
+	}
+	c := command{logger: logger}
+
+	updated, err := c.updateLdsoconfFile(opts)
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	m, err := manifest.Load(filepath.Join(hostRoot, manifest.DefaultPath))
+	require.NoError(t, err)
+	require.Equal(t, []string{defaultLdsoconfFile}, m.LdsoconfFiles)
+
+	contents, err := os.ReadFile(filepath.Join(hostRoot, defaultLdsoconfFile))
+	require.NoError(t, err)
+	require.Equal(t, "/run/nvidia/driver/usr/lib64\n", string(contents))
+
+	updated, err = c.updateLdsoconfFile(opts)
+	require.NoError(t, err)
+	require.False(t, updated, "an up to date file is not rewritten")
+
+	opts.remove = true
+	updated, err = c.updateLdsoconfFile(opts)
+	require.NoError(t, err)
+	require.True(t, updated)
+	require.NoFileExists(t, filepath.Join(hostRoot, defaultLdsoconfFile))
+
+	m, err = manifest.Load(filepath.Join(hostRoot, manifest.DefaultPath))
+	require.NoError(t, err)
+	require.Empty(t, m.LdsoconfFiles)
+}
+
+func TestUpdateLdsoconfFileNoLibraries(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	opts := &options{
+		driverRoot:   "/run/nvidia/driver",
+		hostRoot:     t.TempDir(),
+		ldsoconfFile: defaultLdsoconfFile,
+	}
+	c := command{logger: logger}
+
+	_, err := c.updateLdsoconfFile(opts)
+	require.Error(t, err)
+	require.NoFileExists(t, filepath.Join(opts.hostRoot, defaultLdsoconfFile))
+}
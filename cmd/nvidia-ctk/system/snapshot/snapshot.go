@@ -0,0 +1,176 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/snapshot"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	output     string
+	driverRoot string
+	devRoot    string
+
+	// nvmllib is only used for testing.
+	nvmllib nvml.Interface
+}
+
+// NewCommand constructs a snapshot command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name:  "snapshot",
+		Usage: "Record the state of the NVIDIA driver and GPUs on the system. The snapshot can be used to generate CDI specifications using 'nvidia-ctk cdi generate --from-snapshot'",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(&opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(&opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "output",
+				Aliases:     []string{"o"},
+				Usage:       "the file to write the snapshot to. If this is not specified, the snapshot is written to STDOUT",
+				Destination: &opts.output,
+			},
+			&cli.StringFlag{
+				Name:        "driver-root",
+				Usage:       "the path to the driver root",
+				Value:       "/",
+				Destination: &opts.driverRoot,
+				Sources:     cli.EnvVars("NVIDIA_DRIVER_ROOT", "DRIVER_ROOT"),
+			},
+			&cli.StringFlag{
+				Name:        "dev-root",
+				Usage:       "specify the root where `/dev` is located. If this is not specified, the driver root is assumed.",
+				Destination: &opts.devRoot,
+				Sources:     cli.EnvVars("NVIDIA_DEV_ROOT", "DEV_ROOT"),
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(opts *options) error {
+	if opts.devRoot == "" {
+		opts.devRoot = opts.driverRoot
+	}
+	return nil
+}
+
+func (m command) run(opts *options) error {
+	driver := root.New(
+		root.WithLogger(m.logger),
+		root.WithDriverRoot(opts.driverRoot),
+	)
+
+	nvmllib := opts.nvmllib
+	if nvmllib == nil {
+		var nvmlOpts []nvml.LibraryOption
+		if candidates, err := driver.Libraries().Locate("libnvidia-ml.so.1"); err == nil {
+			nvmlOpts = append(nvmlOpts, nvml.WithLibraryPath(candidates[0]))
+		}
+		nvmllib = nvml.New(nvmlOpts...)
+	}
+
+	s, err := snapshot.FromNVML(nvmllib)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot: %w", err)
+	}
+	s.DeviceNodes = m.getDeviceNodes(opts.devRoot)
+	s.DriverFiles = m.getDriverFiles(driver, s.DriverVersion)
+
+	var w io.Writer = os.Stdout
+	if opts.output != "" {
+		f, err := os.Create(opts.output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if _, err := s.WriteTo(w); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// getDeviceNodes returns the NVIDIA device nodes present in the specified
+// device root. These are recorded for diagnostic purposes only.
+func (m command) getDeviceNodes(devRoot string) []string {
+	var deviceNodes []string
+	for _, pattern := range []string{"/dev/nvidia*", "/dev/nvidia-caps/*", "/dev/nvidia-caps-imex-channels/*"} {
+		matches, err := filepath.Glob(filepath.Join(devRoot, pattern))
+		if err != nil {
+			m.logger.Warningf("Failed to locate device nodes matching %v: %v", pattern, err)
+			continue
+		}
+		for _, match := range matches {
+			if info, err := os.Stat(match); err != nil || info.IsDir() {
+				continue
+			}
+			deviceNodes = append(deviceNodes, match)
+		}
+	}
+	sort.Strings(deviceNodes)
+	return deviceNodes
+}
+
+// getDriverFiles returns the driver libraries with the specified version that
+// are present in the driver root. These are recorded for diagnostic purposes
+// only.
+func (m command) getDriverFiles(driver *root.Driver, version string) []string {
+	var driverFiles []string
+	for _, pattern := range []string{"libcuda.so." + version, "libnvidia-*.so." + version} {
+		candidates, err := driver.Libraries().Locate(pattern)
+		if err != nil {
+			m.logger.Debugf("Failed to locate %v: %v", pattern, err)
+			continue
+		}
+		driverFiles = append(driverFiles, candidates...)
+	}
+	sort.Strings(driverFiles)
+	return driverFiles
+}
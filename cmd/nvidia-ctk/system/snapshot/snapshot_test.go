@@ -0,0 +1,88 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package snapshot
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/snapshot"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+)
+
+func TestSnapshot(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	server := dgxa100.New()
+	server.SystemGetDriverVersionFunc = func() (string, nvml.Return) {
+		return "999.88.77", nvml.SUCCESS
+	}
+	server.SystemGetConfComputeStateFunc = func() (nvml.ConfComputeSystemState, nvml.Return) {
+		return nvml.ConfComputeSystemState{}, nvml.ERROR_NOT_SUPPORTED
+	}
+	for _, d := range server.Devices {
+		(d.(*dgxa100.Device)).GetGspFirmwareModeFunc = func() (bool, bool, nvml.Return) {
+			return false, false, nvml.ERROR_NOT_SUPPORTED
+		}
+		(d.(*dgxa100.Device)).GetPersistenceModeFunc = func() (nvml.EnableState, nvml.Return) {
+			return 0, nvml.ERROR_NOT_SUPPORTED
+		}
+	}
+
+	opts := &options{
+		output:     filepath.Join(t.TempDir(), "snapshot.json"),
+		driverRoot: driverRoot,
+		nvmllib:    server,
+	}
+	c := command{
+		logger: logger,
+	}
+	require.NoError(t, c.validateFlags(opts))
+	require.Equal(t, driverRoot, opts.devRoot)
+	require.NoError(t, c.run(opts))
+
+	s, err := snapshot.Load(opts.output)
+	require.NoError(t, err)
+	require.Equal(t, "999.88.77", s.DriverVersion)
+	require.Len(t, s.Devices, len(server.Devices))
+	require.EqualValues(t,
+		[]string{
+			filepath.Join(driverRoot, "dev/nvidia-caps-imex-channels/channel0"),
+			filepath.Join(driverRoot, "dev/nvidia-caps-imex-channels/channel1"),
+			filepath.Join(driverRoot, "dev/nvidia-caps-imex-channels/channel2047"),
+			filepath.Join(driverRoot, "dev/nvidia0"),
+			filepath.Join(driverRoot, "dev/nvidiactl"),
+		},
+		s.DeviceNodes,
+	)
+	require.EqualValues(t,
+		[]string{
+			filepath.Join(driverRoot, "lib/x86_64-linux-gnu/libcuda.so.999.88.77"),
+		},
+		s.DriverFiles,
+	)
+}
@@ -21,6 +21,12 @@ import (
 
 	devchar "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/system/create-dev-char-symlinks"
 	devicenodes "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/system/create-device-nodes"
+	symlinks "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/system/create-symlinks"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/system/info"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/system/ldconfig"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/system/snapshot"
+	waitfordriver "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/system/wait-for-driver"
+	watchdevicehealth "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/system/watch-device-health"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 )
 
@@ -44,6 +50,12 @@ func (m command) build() *cli.Command {
 		Commands: []*cli.Command{
 			devchar.NewCommand(m.logger),
 			devicenodes.NewCommand(m.logger),
+			symlinks.NewCommand(m.logger),
+			info.NewCommand(m.logger),
+			ldconfig.NewCommand(m.logger),
+			snapshot.NewCommand(m.logger),
+			waitfordriver.NewCommand(m.logger),
+			watchdevicehealth.NewCommand(m.logger),
 		},
 	}
 
@@ -0,0 +1,221 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package waitfordriver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info/proc"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/messages"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	driverRoot string
+	devRoot    string
+	timeout    time.Duration
+	interval   time.Duration
+}
+
+// NewCommand constructs a wait-for-driver command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name:  "wait-for-driver",
+		Usage: "Wait until the NVIDIA kernel module, device nodes, and NVML are ready. This is intended to be run before a container engine is started (e.g. as an ExecStartPre= command)",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(&opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(ctx, &opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "driver-root",
+				Usage:       "the path to the driver root",
+				Value:       "/",
+				Destination: &opts.driverRoot,
+				Sources:     cli.EnvVars("NVIDIA_DRIVER_ROOT", "DRIVER_ROOT"),
+			},
+			&cli.StringFlag{
+				Name:        "dev-root",
+				Usage:       "specify the root where `/dev` is located. If this is not specified, the driver root is assumed.",
+				Destination: &opts.devRoot,
+				Sources:     cli.EnvVars("NVIDIA_DEV_ROOT", "DEV_ROOT"),
+			},
+			&cli.DurationFlag{
+				Name:        "timeout",
+				Usage:       "the maximum time to wait for the driver to be ready. A value of 0 waits indefinitely",
+				Value:       5 * time.Minute,
+				Destination: &opts.timeout,
+			},
+			&cli.DurationFlag{
+				Name:        "interval",
+				Usage:       "the interval at which the readiness of the driver is checked",
+				Value:       time.Second,
+				Destination: &opts.interval,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(opts *options) error {
+	if opts.devRoot == "" {
+		opts.devRoot = opts.driverRoot
+	}
+	if opts.timeout < 0 {
+		return fmt.Errorf("the timeout must not be negative")
+	}
+	if opts.interval <= 0 {
+		return fmt.Errorf("the interval must be positive")
+	}
+	return nil
+}
+
+func (m command) run(ctx context.Context, opts *options) error {
+	driver := root.New(
+		root.WithLogger(m.logger),
+		root.WithDriverRoot(opts.driverRoot),
+	)
+	var nvmlOpts []nvml.LibraryOption
+	if candidates, err := driver.Libraries().Locate("libnvidia-ml.so.1"); err == nil {
+		nvmlOpts = append(nvmlOpts, nvml.WithLibraryPath(candidates[0]))
+	}
+
+	c := &checker{
+		logger:   m.logger,
+		procRoot: "/",
+		devRoot:  opts.devRoot,
+		nvmllib:  nvml.New(nvmlOpts...),
+	}
+
+	if opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.timeout)
+		defer cancel()
+	}
+	return c.wait(ctx, opts.interval)
+}
+
+// A checker checks whether the NVIDIA driver is ready to be used by
+// containers.
+type checker struct {
+	logger   logger.Interface
+	procRoot string
+	devRoot  string
+	nvmllib  nvml.Interface
+}
+
+// wait blocks until the driver is ready or the context is done. The readiness
+// of the driver is checked at the specified interval.
+func (c *checker) wait(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := c.check()
+		if err == nil {
+			c.logger.Infof("The NVIDIA driver is ready")
+			return nil
+		}
+		c.logger.Debugf("The NVIDIA driver is not ready: %v", err)
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return messages.Wrap(err, messages.DriverNotReady, nil)
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// check returns an error describing the first readiness condition that is not
+// met.
+func (c *checker) check() error {
+	if _, err := proc.GetKernelModuleInfo(c.procRoot); err != nil {
+		return fmt.Errorf("kernel module not loaded: %w", err)
+	}
+	if err := c.checkDeviceNodes(); err != nil {
+		return err
+	}
+	return c.checkNVML()
+}
+
+// checkDeviceNodes checks that the control device node and at least one GPU
+// device node exist.
+func (c *checker) checkDeviceNodes() error {
+	control := filepath.Join(c.devRoot, "dev", "nvidiactl")
+	if _, err := os.Stat(control); err != nil {
+		return fmt.Errorf("control device node not present: %w", err)
+	}
+	gpus, err := filepath.Glob(filepath.Join(c.devRoot, "dev", "nvidia[0-9]*"))
+	if err != nil {
+		return err
+	}
+	if len(gpus) == 0 {
+		return fmt.Errorf("no GPU device nodes present")
+	}
+	return nil
+}
+
+// checkNVML checks that NVML can be initialized and that GPUs are reported.
+func (c *checker) checkNVML() error {
+	if ret := c.nvmllib.Init(); ret != nvml.SUCCESS {
+		return fmt.Errorf("failed to initialize NVML: %v", ret)
+	}
+	defer func() {
+		if ret := c.nvmllib.Shutdown(); ret != nvml.SUCCESS {
+			c.logger.Warningf("Failed to shutdown NVML: %v", ret)
+		}
+	}()
+
+	count, ret := c.nvmllib.DeviceGetCount()
+	if ret != nvml.SUCCESS {
+		return fmt.Errorf("failed to get device count: %v", ret)
+	}
+	if count == 0 {
+		return fmt.Errorf("no GPUs reported by NVML")
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package waitfordriver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description   string
+		kernelModule  bool
+		deviceNodes   []string
+		initReturn    nvml.Return
+		deviceCount   int
+		expectedError bool
+	}{
+		{
+			description:   "kernel module not loaded",
+			deviceNodes:   []string{"nvidiactl", "nvidia0"},
+			deviceCount:   1,
+			expectedError: true,
+		},
+		{
+			description:   "missing control device node",
+			kernelModule:  true,
+			deviceNodes:   []string{"nvidia0"},
+			deviceCount:   1,
+			expectedError: true,
+		},
+		{
+			description:   "missing GPU device nodes",
+			kernelModule:  true,
+			deviceNodes:   []string{"nvidiactl"},
+			deviceCount:   1,
+			expectedError: true,
+		},
+		{
+			description:   "NVML not ready",
+			kernelModule:  true,
+			deviceNodes:   []string{"nvidiactl", "nvidia0"},
+			initReturn:    nvml.ERROR_DRIVER_NOT_LOADED,
+			expectedError: true,
+		},
+		{
+			description:   "no GPUs reported by NVML",
+			kernelModule:  true,
+			deviceNodes:   []string{"nvidiactl", "nvidia0"},
+			expectedError: true,
+		},
+		{
+			description:  "driver is ready",
+			kernelModule: true,
+			deviceNodes:  []string{"nvidiactl", "nvidia0"},
+			deviceCount:  1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c := newTestChecker(t, tc.kernelModule, tc.deviceNodes, tc.initReturn, tc.deviceCount)
+			c.logger = logger
+
+			err := c.check()
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestWaitTimeout(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	c := newTestChecker(t, false, nil, nvml.SUCCESS, 1)
+	c.logger = logger
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := c.wait(ctx, 10*time.Millisecond)
+	require.ErrorContains(t, err, "timed out waiting for the NVIDIA driver")
+}
+
+func newTestChecker(t *testing.T, kernelModule bool, deviceNodes []string, initReturn nvml.Return, deviceCount int) *checker {
+	procRoot := t.TempDir()
+	devRoot := t.TempDir()
+
+	if kernelModule {
+		versionFile := filepath.Join(procRoot, "proc", "driver", "nvidia", "version")
+		require.NoError(t, os.MkdirAll(filepath.Dir(versionFile), 0755))
+		require.NoError(t, os.WriteFile(versionFile, []byte("NVRM version: NVIDIA UNIX x86_64 Kernel Module  550.54.14  Thu Feb 22 01:44:30 UTC 2024\n"), 0600))
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(devRoot, "dev"), 0755))
+	for _, node := range deviceNodes {
+		require.NoError(t, os.WriteFile(filepath.Join(devRoot, "dev", node), nil, 0600))
+	}
+
+	return &checker{
+		procRoot: procRoot,
+		devRoot:  devRoot,
+		nvmllib: &mock.Interface{
+			InitFunc: func() nvml.Return {
+				return initReturn
+			},
+			ShutdownFunc: func() nvml.Return {
+				return nvml.SUCCESS
+			},
+			DeviceGetCountFunc: func() (int, nvml.Return) {
+				return deviceCount, nvml.SUCCESS
+			},
+		},
+	}
+}
@@ -0,0 +1,124 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package watchdevicehealth
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/health"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	driverRoot  string
+	policy      string
+	ignoredXIDs []uint64
+}
+
+// NewCommand constructs a watch-device-health command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name: "watch-device-health",
+		Usage: "Watch for critical NVML events (e.g. XID errors) and record the health of each GPU in " + health.DefaultDir + ". " +
+			"If the expose-device-health feature is enabled, this directory is mounted into GPU containers at " + health.ContainerDir,
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(&opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(ctx, &opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "driver-root",
+				Usage:       "the path to the driver root",
+				Value:       "/",
+				Destination: &opts.driverRoot,
+				Sources:     cli.EnvVars("NVIDIA_DRIVER_ROOT", "DRIVER_ROOT"),
+			},
+			&cli.StringFlag{
+				Name: "policy",
+				Usage: "the action to take when a GPU becomes unhealthy. One of [none, sigterm]. " +
+					"If sigterm is specified, a SIGTERM is sent to the processes running on the GPU",
+				Value:       string(health.PolicyNone),
+				Destination: &opts.policy,
+				Sources:     cli.EnvVars("NVIDIA_CTK_DEVICE_HEALTH_POLICY"),
+			},
+			&cli.Uint64SliceFlag{
+				Name:        "ignored-xid",
+				Usage:       "an XID that does not affect the health of a GPU. These are typically caused by applications",
+				Value:       health.DefaultIgnoredXIDs,
+				Destination: &opts.ignoredXIDs,
+				Sources:     cli.EnvVars("NVIDIA_CTK_DEVICE_HEALTH_IGNORED_XIDS"),
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(opts *options) error {
+	switch health.Policy(opts.policy) {
+	case health.PolicyNone, health.PolicySIGTERM:
+	default:
+		return fmt.Errorf("invalid --policy value: %v", opts.policy)
+	}
+	return nil
+}
+
+func (m command) run(ctx context.Context, opts *options) error {
+	driver := root.New(
+		root.WithLogger(m.logger),
+		root.WithDriverRoot(opts.driverRoot),
+	)
+	var nvmlOpts []nvml.LibraryOption
+	if candidates, err := driver.Libraries().Locate("libnvidia-ml.so.1"); err == nil {
+		nvmlOpts = append(nvmlOpts, nvml.WithLibraryPath(candidates[0]))
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	w := health.NewWatcher(
+		health.WithLogger(m.logger),
+		health.WithNvmlLib(nvml.New(nvmlOpts...)),
+		health.WithPolicy(health.Policy(opts.policy)),
+		health.WithIgnoredXIDs(opts.ignoredXIDs...),
+	)
+	return w.Run(ctx)
+}
@@ -0,0 +1,483 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package uninstall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/engines"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/manifest"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine"
+)
+
+const (
+	defaultNVIDIARuntimeName = "nvidia"
+	defaultToolkitRoot       = "/usr/local/nvidia/toolkit"
+	defaultSystemdUnitDir    = "/etc/systemd/system"
+	defaultLdconfigPath      = "/sbin/ldconfig"
+)
+
+var (
+	// systemdUnits are the systemd units installed by the NVIDIA Container
+	// Toolkit packages. The units are disabled so that the CDI specs are not
+	// regenerated, but the unit files are left to the package manager.
+	systemdUnits = []string{
+		"nvidia-cdi-refresh.path",
+		"nvidia-cdi-refresh.service",
+		"nvidia-ctk-bootstrap.service",
+	}
+	cdiSpecDirs = []string{"/etc/cdi", "/var/run/cdi"}
+)
+
+type command struct {
+	logger         logger.Interface
+	configFilePath *string
+}
+
+type options struct {
+	dryRun            bool
+	nvidiaRuntimeName string
+	toolkitRoot       string
+	manifestPath      string
+}
+
+// An uninstaller determines the actions required to remove the NVIDIA
+// Container Toolkit from a host. Only artifacts created by the NVIDIA
+// Container Toolkit are removed; files installed by a package are left to the
+// package manager.
+type uninstaller struct {
+	logger            logger.Interface
+	nvidiaRuntimeName string
+
+	engines        []engines.Engine
+	systemdUnitDir string
+	cdiSpecDirs    []string
+	// manifest records the changes made by nvidia-ctk that are reverted.
+	manifest     *manifest.Manifest
+	manifestPath string
+	// toolkitRoot is the directory into which nvidia-ctk-installer installs
+	// the NVIDIA Container Toolkit.
+	toolkitRoot string
+	// files are the files to remove once the engine configs have been
+	// updated. Directories are never removed.
+	files []string
+	// configDir is removed once the files have been removed if it is empty.
+	configDir string
+
+	systemctl func(...string) error
+	ldconfig  func() error
+}
+
+// An action is a single step in removing the NVIDIA Container Toolkit.
+type action struct {
+	description string
+	apply       func() error
+}
+
+// NewCommand constructs an uninstall command with the specified logger
+func NewCommand(logger logger.Interface, configFilePath *string) *cli.Command {
+	c := command{
+		logger:         logger,
+		configFilePath: configFilePath,
+	}
+	return c.build()
+}
+
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name:  "uninstall",
+		Usage: "Revert the changes made by the NVIDIA Container Toolkit to container engine configs and the host, and remove the generated CDI specifications and state files",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(&opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(&opts)
+		},
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "dry-run",
+				Usage:       "list the changes that would be made without applying them",
+				Destination: &opts.dryRun,
+			},
+			&cli.StringFlag{
+				Name:        "nvidia-runtime-name",
+				Usage:       "the name of the NVIDIA runtime to remove from the container engine configs",
+				Value:       defaultNVIDIARuntimeName,
+				Destination: &opts.nvidiaRuntimeName,
+			},
+			&cli.StringFlag{
+				Name:        "toolkit-root",
+				Usage:       "the directory into which nvidia-ctk-installer installs the NVIDIA Container Toolkit",
+				Value:       defaultToolkitRoot,
+				Destination: &opts.toolkitRoot,
+			},
+			&cli.StringFlag{
+				Name:        "manifest-path",
+				Usage:       "the path of the manifest in which the changes to revert are recorded",
+				Value:       manifest.DefaultPath,
+				Destination: &opts.manifestPath,
+				Hidden:      true,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(opts *options) error {
+	if opts.toolkitRoot != "" && !filepath.IsAbs(opts.toolkitRoot) {
+		return fmt.Errorf("the toolkit root %q is not an absolute path", opts.toolkitRoot)
+	}
+	if !filepath.IsAbs(opts.manifestPath) {
+		return fmt.Errorf("the manifest path %q is not an absolute path", opts.manifestPath)
+	}
+	return nil
+}
+
+func (m command) run(opts *options) error {
+	configFilePath := *m.configFilePath
+	if configFilePath == "" {
+		configFilePath = config.GetConfigFilePath()
+	}
+
+	mf, err := manifest.Load(opts.manifestPath)
+	if err != nil {
+		return err
+	}
+
+	files := m.getConfiguredStateFiles(configFilePath)
+	files = append(files, configFilePath)
+
+	u := &uninstaller{
+		logger:            m.logger,
+		nvidiaRuntimeName: opts.nvidiaRuntimeName,
+		engines:           engines.Defaults,
+		systemdUnitDir:    defaultSystemdUnitDir,
+		cdiSpecDirs:       cdiSpecDirs,
+		manifest:          mf,
+		manifestPath:      opts.manifestPath,
+		toolkitRoot:       opts.toolkitRoot,
+		files:             files,
+		systemctl:         systemctl,
+		ldconfig:          ldconfig,
+	}
+	// The config directory is only removed if it is specific to the NVIDIA
+	// Container Toolkit.
+	if configDir := filepath.Dir(configFilePath); filepath.Base(configDir) == filepath.Dir(config.RelativeFilePath) {
+		u.configDir = configDir
+	}
+
+	actions := u.getActions()
+	if len(actions) == 0 {
+		m.logger.Infof("No NVIDIA Container Toolkit components found")
+		return nil
+	}
+
+	var errs error
+	for _, a := range actions {
+		if opts.dryRun {
+			m.logger.Infof("Would %v", a.description)
+			continue
+		}
+		m.logger.Infof("Running: %v", a.description)
+		if err := a.apply(); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to %v: %w", a.description, err))
+		}
+	}
+	return errs
+}
+
+// getConfiguredStateFiles returns the state files that are configured in the
+// NVIDIA Container Toolkit config file.
+func (m command) getConfiguredStateFiles(configFilePath string) []string {
+	cfgToml, err := config.New(config.WithConfigFile(configFilePath))
+	if err != nil {
+		m.logger.Warningf("Failed to load config: %v", err)
+		return nil
+	}
+	cfg, err := cfgToml.Config()
+	if err != nil {
+		m.logger.Warningf("Failed to load config: %v", err)
+		return nil
+	}
+
+	var files []string
+	for _, path := range []string{
+		cfg.NVIDIAContainerRuntimeConfig.AuditLogFilePath,
+		cfg.NVIDIAContainerRuntimeConfig.AllocationLedgerPath,
+	} {
+		if path != "" {
+			files = append(files, path)
+		}
+	}
+	return files
+}
+
+// getActions returns the actions required to remove the NVIDIA Container
+// Toolkit. Only actions for components that are present are returned. The
+// systemd units are disabled first so that the CDI specs are not regenerated
+// and the engine configs are updated before the files are removed.
+func (u *uninstaller) getActions() []action {
+	var actions []action
+	actions = append(actions, u.getSystemdActions()...)
+	actions = append(actions, u.getEngineActions()...)
+	actions = append(actions, u.getCDISpecActions()...)
+	actions = append(actions, u.getLdsoconfActions()...)
+	actions = append(actions, u.getToolkitRootActions()...)
+	removed := make(map[string]bool)
+	for _, file := range u.files {
+		// The lock files used to serialize writes are also removed.
+		for _, path := range []string{file, file + ".lock"} {
+			if a := u.removeFileAction(path); a != nil {
+				actions = append(actions, *a)
+				removed[path] = true
+			}
+		}
+	}
+	if u.configDir != "" && isEmptyAfterRemoval(u.configDir, removed) {
+		actions = append(actions, action{
+			description: fmt.Sprintf("remove %v", u.configDir),
+			apply: func() error {
+				return os.Remove(u.configDir)
+			},
+		})
+	}
+	if u.manifestPath != "" {
+		for _, path := range []string{u.manifestPath, u.manifestPath + ".lock"} {
+			if a := u.removeFileAction(path); a != nil {
+				actions = append(actions, *a)
+			}
+		}
+	}
+	return actions
+}
+
+func (u *uninstaller) getSystemdActions() []action {
+	var actions []action
+	for _, unit := range systemdUnits {
+		unitFile := filepath.Join(u.systemdUnitDir, unit)
+		if _, err := os.Stat(unitFile); err != nil {
+			continue
+		}
+		if err := u.systemctl("is-enabled", "--quiet", unit); err != nil {
+			continue
+		}
+		actions = append(actions, action{
+			description: fmt.Sprintf("disable systemd unit %v", unit),
+			apply: func() error {
+				return u.systemctl("disable", "--now", unit)
+			},
+		})
+	}
+	return actions
+}
+
+func (u *uninstaller) getEngineActions() []action {
+	var actions []action
+	for _, e := range u.engines {
+		for _, hookFile := range e.NVIDIAHookFiles() {
+			if a := u.removeFileAction(hookFile); a != nil {
+				actions = append(actions, *a)
+			}
+		}
+
+		if _, err := os.Stat(e.ConfigFilePath); err != nil {
+			continue
+		}
+		cfg, err := e.LoadConfig(u.logger)
+		if err != nil {
+			u.logger.Warningf("Failed to load %v config: %v", e.Name, err)
+			continue
+		}
+		if a := u.getEngineConfigAction(e, cfg); a != nil {
+			actions = append(actions, *a)
+		}
+	}
+	return actions
+}
+
+// getEngineConfigAction returns the action to remove the NVIDIA runtime from
+// the specified engine config and to revert the other changes to the config
+// that are recorded in the manifest.
+func (u *uninstaller) getEngineConfigAction(e engines.Engine, cfg engine.Interface) *action {
+	runtime, err := cfg.GetRuntimeConfig(u.nvidiaRuntimeName)
+	hasRuntime := err == nil && runtime != nil && runtime.GetBinaryPath() != ""
+
+	changes := u.manifest.GetEngineConfig(e.ConfigFilePath, u.nvidiaRuntimeName)
+	if changes == nil {
+		changes = &manifest.EngineConfig{}
+	}
+	disabler, canDisableCDI := cfg.(engine.CDIDisabler)
+	revertCDI := changes.EnabledCDI && canDisableCDI && isCDIEnabled(cfg)
+	if !hasRuntime && !revertCDI {
+		return nil
+	}
+
+	configFilePath := e.ConfigFilePath
+	return &action{
+		description: fmt.Sprintf("remove the %v runtime from the %v config %v", u.nvidiaRuntimeName, e.Name, configFilePath),
+		apply: func() error {
+			// The previous default runtime is only restored if the NVIDIA
+			// runtime is still the default.
+			restoreDefault := changes.PreviousDefaultRuntime != "" && cfg.DefaultRuntime() == u.nvidiaRuntimeName
+			if err := cfg.RemoveRuntime(u.nvidiaRuntimeName); err != nil {
+				return err
+			}
+			if setter, ok := cfg.(engine.DefaultRuntimeSetter); ok && restoreDefault {
+				u.logger.Infof("Restoring the default runtime %v in %v", changes.PreviousDefaultRuntime, configFilePath)
+				setter.SetDefaultRuntime(changes.PreviousDefaultRuntime)
+			}
+			if revertCDI {
+				u.logger.Infof("Disabling CDI in %v", configFilePath)
+				disabler.DisableCDI()
+			}
+			n, err := cfg.Save(configFilePath)
+			if err != nil {
+				return err
+			}
+			if n == 0 {
+				u.logger.Infof("Removed empty config from %v", configFilePath)
+			}
+			return nil
+		},
+	}
+}
+
+// getCDISpecActions returns the actions to remove the CDI specifications
+// generated for NVIDIA devices.
+func (u *uninstaller) getCDISpecActions() []action {
+	var actions []action
+	for _, dir := range u.cdiSpecDirs {
+		for _, pattern := range []string{"nvidia*.yaml", "nvidia*.json", "nvidia*.lock"} {
+			specFiles, _ := filepath.Glob(filepath.Join(dir, pattern))
+			for _, specFile := range specFiles {
+				if a := u.removeFileAction(specFile); a != nil {
+					actions = append(actions, *a)
+				}
+			}
+		}
+	}
+	return actions
+}
+
+// getLdsoconfActions returns the actions to remove the ld.so.conf.d files
+// written by nvidia-ctk system ldconfig and to update the ldcache.
+func (u *uninstaller) getLdsoconfActions() []action {
+	var actions []action
+	for _, ldsoconfFile := range u.manifest.LdsoconfFiles {
+		if a := u.removeFileAction(ldsoconfFile); a != nil {
+			actions = append(actions, *a)
+		}
+	}
+	if len(actions) > 0 {
+		actions = append(actions, action{
+			description: "update the ldcache",
+			apply:       u.ldconfig,
+		})
+	}
+	return actions
+}
+
+// getToolkitRootActions returns the action to remove the toolkit root. Since
+// the path is user-specified, the directory is only removed if it contains the
+// config written by nvidia-ctk-installer.
+func (u *uninstaller) getToolkitRootActions() []action {
+	if u.toolkitRoot == "" {
+		return nil
+	}
+	if _, err := os.Stat(u.toolkitRoot); err != nil {
+		return nil
+	}
+	installerConfig := filepath.Join(u.toolkitRoot, ".config", "nvidia-container-runtime", "config.toml")
+	if _, err := os.Stat(installerConfig); err != nil {
+		u.logger.Warningf("Not removing %v since it was not created by nvidia-ctk-installer", u.toolkitRoot)
+		return nil
+	}
+	return []action{
+		{
+			description: fmt.Sprintf("remove %v", u.toolkitRoot),
+			apply: func() error {
+				return os.RemoveAll(u.toolkitRoot)
+			},
+		},
+	}
+}
+
+// removeFileAction returns the action to remove the specified file. If the
+// file does not exist, nil is returned. Directories are not removed.
+func (u *uninstaller) removeFileAction(path string) *action {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return nil
+	}
+	if info.IsDir() {
+		u.logger.Warningf("Not removing %v since it is a directory", path)
+		return nil
+	}
+	return &action{
+		description: fmt.Sprintf("remove %v", path),
+		apply: func() error {
+			return os.Remove(path)
+		},
+	}
+}
+
+// isEmptyAfterRemoval returns whether the specified directory exists and
+// only contains files that are removed.
+func isEmptyAfterRemoval(dir string, removed map[string]bool) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if !removed[filepath.Join(dir, entry.Name())] {
+			return false
+		}
+	}
+	return true
+}
+
+func isCDIEnabled(cfg engine.Interface) bool {
+	checker, ok := cfg.(engine.CDIChecker)
+	return ok && checker.IsCDIEnabled()
+}
+
+func systemctl(args ...string) error {
+	cmd := exec.Command("systemctl", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+func ldconfig() error {
+	cmd := exec.Command(defaultLdconfigPath)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
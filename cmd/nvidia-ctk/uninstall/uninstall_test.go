@@ -0,0 +1,163 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package uninstall
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/engines"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/manifest"
+)
+
+func TestUninstall(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	root := t.TempDir()
+
+	dockerConfig := filepath.Join(root, "etc", "docker", "daemon.json")
+	hookDir := filepath.Join(root, "usr", "share", "containers", "oci", "hooks.d")
+	unitDir := filepath.Join(root, "etc", "systemd", "system")
+	cdiDir := filepath.Join(root, "etc", "cdi")
+	ldsoconfFile := filepath.Join(root, "etc", "ld.so.conf.d", "nvidia-driver-root.conf")
+	toolkitRoot := filepath.Join(root, "usr", "local", "nvidia", "toolkit")
+	configDir := filepath.Join(root, "etc", "nvidia-container-runtime")
+	configFile := filepath.Join(configDir, "config.toml")
+	auditLog := filepath.Join(root, "var", "log", "nvidia-container-runtime-audit.log")
+	logDir := filepath.Join(root, "var", "log")
+	manifestPath := filepath.Join(root, "var", "lib", "nvidia-container-toolkit", "manifest.json")
+
+	files := map[string]string{
+		dockerConfig: `{"runtimes": {"nvidia": {"path": "nvidia-container-runtime"}, "crun": {"path": "/usr/bin/crun"}}, "default-runtime": "nvidia", "features": {"cdi": true}}`,
+		filepath.Join(hookDir, "oci-nvidia-hook.json"):                                   `{"hook": {"path": "/usr/bin/nvidia-container-runtime-hook"}}`,
+		filepath.Join(hookDir, "other-hook.json"):                                        `{"hook": {"path": "/usr/bin/other-hook"}}`,
+		filepath.Join(unitDir, "nvidia-cdi-refresh.service"):                             "",
+		filepath.Join(cdiDir, "nvidia.yaml"):                                             "",
+		filepath.Join(cdiDir, "other.yaml"):                                              "",
+		ldsoconfFile:                                                                     "",
+		filepath.Join(toolkitRoot, ".config", "nvidia-container-runtime", "config.toml"): "",
+		filepath.Join(toolkitRoot, "nvidia-ctk"):                                         "",
+		configFile:                                                                       "",
+		filepath.Join(configDir, "user.toml"):                                            "",
+		auditLog:                                                                         "",
+		filepath.Join(logDir, "syslog"):                                                  "",
+	}
+	for path, contents := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+	}
+	require.NoError(t, manifest.Update(manifestPath, func(m *manifest.Manifest) {
+		m.AddEngineConfig(manifest.EngineConfig{Path: dockerConfig, RuntimeName: "nvidia", PreviousDefaultRuntime: "crun", EnabledCDI: true})
+		m.AddLdsoconfFile(ldsoconfFile)
+	}))
+	mf, err := manifest.Load(manifestPath)
+	require.NoError(t, err)
+
+	var systemctlCalls [][]string
+	enabled := map[string]bool{"nvidia-cdi-refresh.service": true}
+	ldconfigCalls := 0
+	u := &uninstaller{
+		logger:            logger,
+		nvidiaRuntimeName: "nvidia",
+		engines: []engines.Engine{
+			{Name: "docker", ConfigFilePath: dockerConfig},
+			{Name: "podman", ConfigFilePath: filepath.Join(root, "missing.conf"), HookDirs: []string{hookDir}},
+		},
+		systemdUnitDir: unitDir,
+		cdiSpecDirs:    []string{cdiDir},
+		manifest:       mf,
+		manifestPath:   manifestPath,
+		toolkitRoot:    toolkitRoot,
+		// A misconfigured state file that refers to a directory is not
+		// removed.
+		files:     []string{configFile, auditLog, logDir},
+		configDir: configDir,
+		systemctl: func(args ...string) error {
+			switch args[0] {
+			case "is-enabled":
+				if !enabled[args[2]] {
+					return errors.New("disabled")
+				}
+			case "disable":
+				enabled[args[2]] = false
+			}
+			systemctlCalls = append(systemctlCalls, args)
+			return nil
+		},
+		ldconfig: func() error {
+			ldconfigCalls++
+			return nil
+		},
+	}
+
+	actions := u.getActions()
+	require.Len(t, actions, 11)
+	for _, a := range actions {
+		require.NoError(t, a.apply())
+	}
+
+	require.EqualValues(t, [][]string{
+		{"is-enabled", "--quiet", "nvidia-cdi-refresh.service"},
+		{"disable", "--now", "nvidia-cdi-refresh.service"},
+	}, systemctlCalls)
+	require.Equal(t, 1, ldconfigCalls)
+
+	for _, removed := range []string{
+		filepath.Join(hookDir, "oci-nvidia-hook.json"),
+		filepath.Join(cdiDir, "nvidia.yaml"),
+		ldsoconfFile,
+		toolkitRoot,
+		configFile,
+		auditLog,
+		manifestPath,
+	} {
+		require.NoFileExists(t, removed)
+	}
+	for _, kept := range []string{
+		filepath.Join(unitDir, "nvidia-cdi-refresh.service"),
+		filepath.Join(hookDir, "other-hook.json"),
+		filepath.Join(cdiDir, "other.yaml"),
+		filepath.Join(configDir, "user.toml"),
+		filepath.Join(logDir, "syslog"),
+	} {
+		require.FileExists(t, kept)
+	}
+
+	contents, err := os.ReadFile(dockerConfig)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"default-runtime": "crun", "runtimes": {"crun": {"path": "/usr/bin/crun"}}}`, string(contents))
+
+	u.manifest = &manifest.Manifest{}
+	require.Empty(t, u.getActions())
+}
+
+func TestToolkitRootNotCreatedByInstaller(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	toolkitRoot := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(toolkitRoot, "data"), nil, 0600))
+
+	u := &uninstaller{
+		logger:      logger,
+		toolkitRoot: toolkitRoot,
+	}
+	require.Empty(t, u.getToolkitRootActions())
+}
@@ -0,0 +1,180 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/sirupsen/logrus"
+	cli "github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info"
+)
+
+// options defines the options that can be set for the CLI through environment
+// variables or command line flags
+type options struct {
+	// listGPUs indicates whether only the list of GPUs is printed
+	listGPUs bool
+	// libraryPath is the path of the NVML library to load
+	libraryPath string
+}
+
+func main() {
+	logger := logrus.New()
+
+	opts := options{}
+
+	// Create the top-level CLI
+	c := cli.Command{
+		Name:    "nvidia-smi-lite",
+		Usage:   "Print basic information about the NVIDIA GPUs in a container where nvidia-smi is not available",
+		Version: info.GetVersionString(),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			var nvmlOpts []nvml.LibraryOption
+			if opts.libraryPath != "" {
+				nvmlOpts = append(nvmlOpts, nvml.WithLibraryPath(opts.libraryPath))
+			}
+			return run(cmd.Root().Writer, nvml.New(nvmlOpts...), opts.listGPUs)
+		},
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "list-gpus",
+				Aliases:     []string{"L"},
+				Usage:       "Only list the GPUs in the system",
+				Destination: &opts.listGPUs,
+			},
+			&cli.StringFlag{
+				Name:        "nvml-library-path",
+				Usage:       "Specify the path of the NVML library to load. If not specified, libnvidia-ml.so.1 is loaded from the library search path",
+				Destination: &opts.libraryPath,
+				Sources:     cli.EnvVars("NVIDIA_SMI_LITE_NVML_LIBRARY_PATH"),
+			},
+		},
+	}
+
+	err := c.Run(context.Background(), os.Args)
+	if err != nil {
+		logger.Errorf("%v", err)
+		os.Exit(1)
+	}
+}
+
+// run prints the driver and CUDA versions as well as the properties of each
+// GPU queried using the specified NVML library. If listGPUs is specified, only
+// the name and UUID of each GPU is printed (as for nvidia-smi -L).
+func run(w io.Writer, nvmllib nvml.Interface, listGPUs bool) error {
+	if ret := nvmllib.Init(); ret != nvml.SUCCESS {
+		return fmt.Errorf("failed to initialize NVML: %v", ret)
+	}
+	defer func() {
+		_ = nvmllib.Shutdown()
+	}()
+
+	if !listGPUs {
+		if err := printVersions(w, nvmllib); err != nil {
+			return err
+		}
+	}
+
+	count, ret := nvmllib.DeviceGetCount()
+	if ret != nvml.SUCCESS {
+		return fmt.Errorf("failed to get device count: %v", ret)
+	}
+	for i := 0; i < count; i++ {
+		device, ret := nvmllib.DeviceGetHandleByIndex(i)
+		if ret != nvml.SUCCESS {
+			return fmt.Errorf("failed to get device %d: %v", i, ret)
+		}
+		if err := printDevice(w, i, device, listGPUs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printVersions(w io.Writer, nvmllib nvml.Interface) error {
+	driverVersion, ret := nvmllib.SystemGetDriverVersion()
+	if ret != nvml.SUCCESS {
+		return fmt.Errorf("failed to get driver version: %v", ret)
+	}
+	cudaDriverVersion, ret := nvmllib.SystemGetCudaDriverVersion()
+	if ret != nvml.SUCCESS {
+		return fmt.Errorf("failed to get CUDA driver version: %v", ret)
+	}
+	fmt.Fprintf(w, "Driver Version: %v\n", driverVersion)
+	fmt.Fprintf(w, "CUDA Version:   %d.%d\n", cudaDriverVersion/1000, (cudaDriverVersion%1000)/10)
+	return nil
+}
+
+// printDevice prints the properties of the specified GPU. Optional properties
+// that cannot be queried (e.g. the temperature in a MIG-enabled GPU) are
+// reported as N/A.
+func printDevice(w io.Writer, index int, device nvml.Device, listGPUs bool) error {
+	name, ret := device.GetName()
+	if ret != nvml.SUCCESS {
+		return fmt.Errorf("failed to get name of device %d: %v", index, ret)
+	}
+	uuid, ret := device.GetUUID()
+	if ret != nvml.SUCCESS {
+		return fmt.Errorf("failed to get UUID of device %d: %v", index, ret)
+	}
+	fmt.Fprintf(w, "GPU %d: %v (UUID: %v)\n", index, name, uuid)
+	if listGPUs {
+		return nil
+	}
+
+	busID := "N/A"
+	if pciInfo, ret := device.GetPciInfo(); ret == nvml.SUCCESS {
+		busID = pciBusID(pciInfo)
+	}
+	memory := "N/A"
+	if memoryInfo, ret := device.GetMemoryInfo(); ret == nvml.SUCCESS {
+		memory = fmt.Sprintf("%dMiB / %dMiB", memoryInfo.Used/(1024*1024), memoryInfo.Total/(1024*1024))
+	}
+	utilization := "N/A"
+	if rates, ret := device.GetUtilizationRates(); ret == nvml.SUCCESS {
+		utilization = fmt.Sprintf("%d%%", rates.Gpu)
+	}
+	temperature := "N/A"
+	if t, ret := device.GetTemperature(nvml.TEMPERATURE_GPU); ret == nvml.SUCCESS {
+		temperature = fmt.Sprintf("%dC", t)
+	}
+
+	fmt.Fprintf(w, "  Bus ID:      %v\n", busID)
+	fmt.Fprintf(w, "  Memory:      %v\n", memory)
+	fmt.Fprintf(w, "  Utilization: %v\n", utilization)
+	fmt.Fprintf(w, "  Temperature: %v\n", temperature)
+	return nil
+}
+
+// pciBusID returns the PCI bus ID of a device as a string.
+func pciBusID(pciInfo nvml.PciInfo) string {
+	var bytes []byte
+	for _, b := range pciInfo.BusId {
+		if b == 0 {
+			break
+		}
+		bytes = append(bytes, byte(b))
+	}
+	return string(bytes)
+}
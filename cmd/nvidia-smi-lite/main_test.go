@@ -0,0 +1,106 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	var busID [32]int8
+	for i, b := range []byte("00000000:07:00.0") {
+		busID[i] = int8(b)
+	}
+	device := &mock.Device{
+		GetNameFunc: func() (string, nvml.Return) {
+			return "NVIDIA A100-SXM4-40GB", nvml.SUCCESS
+		},
+		GetUUIDFunc: func() (string, nvml.Return) {
+			return "GPU-0", nvml.SUCCESS
+		},
+		GetPciInfoFunc: func() (nvml.PciInfo, nvml.Return) {
+			return nvml.PciInfo{BusId: busID}, nvml.SUCCESS
+		},
+		GetMemoryInfoFunc: func() (nvml.Memory, nvml.Return) {
+			return nvml.Memory{Total: 40960 * 1024 * 1024, Used: 1024 * 1024 * 1024}, nvml.SUCCESS
+		},
+		GetUtilizationRatesFunc: func() (nvml.Utilization, nvml.Return) {
+			return nvml.Utilization{Gpu: 12}, nvml.SUCCESS
+		},
+		GetTemperatureFunc: func(nvml.TemperatureSensors) (uint32, nvml.Return) {
+			return 0, nvml.ERROR_NOT_SUPPORTED
+		},
+	}
+	nvmllib := &mock.Interface{
+		InitFunc: func() nvml.Return {
+			return nvml.SUCCESS
+		},
+		ShutdownFunc: func() nvml.Return {
+			return nvml.SUCCESS
+		},
+		SystemGetDriverVersionFunc: func() (string, nvml.Return) {
+			return "550.54.15", nvml.SUCCESS
+		},
+		SystemGetCudaDriverVersionFunc: func() (int, nvml.Return) {
+			return 12040, nvml.SUCCESS
+		},
+		DeviceGetCountFunc: func() (int, nvml.Return) {
+			return 1, nvml.SUCCESS
+		},
+		DeviceGetHandleByIndexFunc: func(int) (nvml.Device, nvml.Return) {
+			return device, nvml.SUCCESS
+		},
+	}
+
+	testCases := []struct {
+		description    string
+		listGPUs       bool
+		expectedOutput string
+	}{
+		{
+			description: "device properties are printed",
+			expectedOutput: `Driver Version: 550.54.15
+CUDA Version:   12.4
+GPU 0: NVIDIA A100-SXM4-40GB (UUID: GPU-0)
+  Bus ID:      00000000:07:00.0
+  Memory:      1024MiB / 40960MiB
+  Utilization: 12%
+  Temperature: N/A
+`,
+		},
+		{
+			description:    "list GPUs only",
+			listGPUs:       true,
+			expectedOutput: "GPU 0: NVIDIA A100-SXM4-40GB (UUID: GPU-0)\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			err := run(output, nvmllib, tc.listGPUs)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedOutput, output.String())
+		})
+	}
+}
@@ -0,0 +1,186 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package audit implements a log of the containers that were modified by the
+// NVIDIA Container Runtime. Each entry is written as a single line of JSON so
+// that the log can be appended to by concurrent runtime invocations and
+// processed by standard tooling.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+const (
+	// EventCreate is recorded when a container is created.
+	EventCreate = "create"
+	// EventComputeModeSet is recorded when the compute mode of the GPUs of a
+	// container is set.
+	EventComputeModeSet = "compute-mode-set"
+	// EventComputeModeRestore is recorded when the original compute mode of
+	// the GPUs of a container is restored.
+	EventComputeModeRestore = "compute-mode-restore"
+	// EventGPULimitsSet is recorded when power or clock limits are applied to
+	// the GPUs of a container.
+	EventGPULimitsSet = "gpu-limits-set"
+	// EventGPULimitsRestore is recorded when the limits applied to the GPUs
+	// of a container are reverted.
+	EventGPULimitsRestore = "gpu-limits-restore"
+)
+
+// An Entry represents a single record in the audit log.
+type Entry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Event     string    `json:"event"`
+	Bundle    string    `json:"bundle,omitempty"`
+	Mode      string    `json:"mode,omitempty"`
+	// Env contains the NVIDIA_* environment variables of the container.
+	Env []string `json:"env,omitempty"`
+	// Devices contains the UUIDs of the GPUs affected by the event.
+	Devices     []string `json:"devices,omitempty"`
+	ComputeMode string   `json:"computeMode,omitempty"`
+	// PowerLimit is the power limit in watts applied to the GPUs.
+	PowerLimit string `json:"powerLimit,omitempty"`
+	// Clocks is the range of GPU clocks in MHz that the GPUs are locked to.
+	Clocks string `json:"clocks,omitempty"`
+	// GPUs contains a snapshot of the utilization of the GPUs of the
+	// container at the time that the container was created.
+	GPUs []GPUSnapshot `json:"gpus,omitempty"`
+}
+
+// NewCreateEntry returns an entry for the creation of the container with the
+// specified bundle and OCI spec using the specified runtime mode.
+func NewCreateEntry(bundle string, mode string, spec *specs.Spec) *Entry {
+	e := &Entry{
+		Timestamp: time.Now().UTC(),
+		Event:     EventCreate,
+		Bundle:    bundle,
+		Mode:      mode,
+	}
+	if spec != nil && spec.Process != nil {
+		for _, env := range spec.Process.Env {
+			if strings.HasPrefix(env, "NVIDIA_") {
+				e.Env = append(e.Env, env)
+			}
+		}
+	}
+	return e
+}
+
+// NewComputeModeEntry returns an entry for a change to the compute mode of the
+// specified GPUs of the container with the specified bundle.
+func NewComputeModeEntry(event string, bundle string, computeMode string, devices []string) *Entry {
+	return &Entry{
+		Timestamp:   time.Now().UTC(),
+		Event:       event,
+		Bundle:      bundle,
+		Devices:     devices,
+		ComputeMode: computeMode,
+	}
+}
+
+// NewGPULimitsEntry returns an entry for a change to the power or clock limits
+// of the specified GPUs of the container with the specified bundle.
+func NewGPULimitsEntry(event string, bundle string, powerLimit string, clocks string, devices []string) *Entry {
+	return &Entry{
+		Timestamp:  time.Now().UTC(),
+		Event:      event,
+		Bundle:     bundle,
+		Devices:    devices,
+		PowerLimit: powerLimit,
+		Clocks:     clocks,
+	}
+}
+
+// LookupEnv returns the value of the specified environment variable for the
+// entry.
+func (e *Entry) LookupEnv(name string) (string, bool) {
+	for _, env := range e.Env {
+		key, value, _ := strings.Cut(env, "=")
+		if key == name {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// Append writes the specified entry to the audit log at the specified path.
+// The file is created if it does not exist.
+func Append(path string, e *Entry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	return nil
+}
+
+// Record appends the specified entry to the audit log at the specified path
+// if one is configured. Since the recorded change has already been made,
+// failures are logged instead of being returned.
+func Record(logger logger.Interface, path string, e *Entry) {
+	if path == "" {
+		return
+	}
+	if err := Append(path, e); err != nil {
+		logger.Warningf("Failed to record %v in audit log: %v", e.Event, err)
+	}
+}
+
+// Read returns the entries in the audit log at the specified path.
+// An error is returned if an entry cannot be parsed.
+func Read(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("failed to parse audit entry on line %d: %w", line, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,76 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package audit
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	spec := &specs.Spec{
+		Process: &specs.Process{
+			Env: []string{"PATH=/usr/bin", "NVIDIA_VISIBLE_DEVICES=all", "NVIDIA_REQUIRE_CUDA=cuda>=12.0"},
+		},
+	}
+	require.NoError(t, Append(path, NewCreateEntry("/run/bundle/1", "legacy", spec)))
+	require.NoError(t, Append(path, NewCreateEntry("/run/bundle/2", "cdi", nil)))
+
+	entries, err := Read(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	require.Equal(t, EventCreate, entries[0].Event)
+	require.Equal(t, "/run/bundle/1", entries[0].Bundle)
+	require.Equal(t, "legacy", entries[0].Mode)
+	require.EqualValues(t, []string{"NVIDIA_VISIBLE_DEVICES=all", "NVIDIA_REQUIRE_CUDA=cuda>=12.0"}, entries[0].Env)
+	require.False(t, entries[0].Timestamp.IsZero())
+
+	value, ok := entries[0].LookupEnv("NVIDIA_VISIBLE_DEVICES")
+	require.True(t, ok)
+	require.Equal(t, "all", value)
+	_, ok = entries[0].LookupEnv("PATH")
+	require.False(t, ok)
+
+	require.Equal(t, "/run/bundle/2", entries[1].Bundle)
+	require.Empty(t, entries[1].Env)
+}
+
+func TestRecord(t *testing.T) {
+	logger, hook := testlog.NewNullLogger()
+
+	Record(logger, "", NewComputeModeEntry(EventComputeModeSet, "/bundle", "DEFAULT", nil))
+	require.Empty(t, hook.AllEntries())
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	Record(logger, path, NewComputeModeEntry(EventComputeModeSet, "/bundle", "DEFAULT", []string{"GPU-0"}))
+	entries, err := Read(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, EventComputeModeSet, entries[0].Event)
+
+	Record(logger, filepath.Join(t.TempDir(), "missing", "audit.log"), NewComputeModeEntry(EventComputeModeSet, "/bundle", "DEFAULT", nil))
+	require.Len(t, hook.AllEntries(), 1)
+	require.Contains(t, hook.LastEntry().Message, EventComputeModeSet)
+}
@@ -0,0 +1,90 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package audit
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"tags.cncf.io/container-device-interface/pkg/parser"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+)
+
+// A GPUSnapshot records the utilization of a GPU at the time that a
+// container is created. Values that could not be determined are omitted.
+type GPUSnapshot struct {
+	Index string `json:"index"`
+	UUID  string `json:"uuid"`
+	// Utilization is the GPU utilization in percent.
+	Utilization *uint32 `json:"utilization,omitempty"`
+	// MemoryUsed is the used device memory in bytes.
+	MemoryUsed *uint64 `json:"memoryUsed,omitempty"`
+}
+
+// GetGPUSnapshots returns snapshots of the GPUs listed by the specified
+// lister that are selected by the specified device requests.
+func GetGPUSnapshots(gpuLister image.GPULister, devices ...string) ([]GPUSnapshot, error) {
+	if len(devices) == 0 {
+		return nil, nil
+	}
+	gpus, err := gpuLister.GPUs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list GPUs: %w", err)
+	}
+	return NewGPUSnapshots(gpus, devices...), nil
+}
+
+// NewGPUSnapshots returns snapshots of the specified GPUs that are selected
+// by the specified device requests. GPUs are selected by index or UUID or by
+// the value 'all'. MIG devices selected by index (e.g. 0:1) select the parent
+// GPU since utilization is not reported per MIG device.
+func NewGPUSnapshots(gpus []image.GPU, devices ...string) []GPUSnapshot {
+	requested := make(map[string]bool)
+	for _, device := range devices {
+		if parser.IsQualifiedName(device) {
+			_, _, device, _ = parser.ParseQualifiedName(device)
+		}
+		if gpuIndex, _, isMig := strings.Cut(device, ":"); isMig {
+			device = gpuIndex
+		}
+		requested[device] = true
+	}
+
+	var snapshots []GPUSnapshot
+	for _, gpu := range gpus {
+		if !requested["all"] && !requested[gpu.Index] && !requested[gpu.UUID] {
+			continue
+		}
+		snapshot := GPUSnapshot{
+			Index: gpu.Index,
+			UUID:  gpu.UUID,
+		}
+		if gpu.Utilization != math.MaxUint32 {
+			utilization := gpu.Utilization
+			snapshot.Utilization = &utilization
+		}
+		if gpu.MemoryUsed != math.MaxUint64 {
+			memoryUsed := gpu.MemoryUsed
+			snapshot.MemoryUsed = &memoryUsed
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots
+}
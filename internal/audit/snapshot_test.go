@@ -0,0 +1,75 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package audit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+)
+
+func TestNewGPUSnapshots(t *testing.T) {
+	gpus := []image.GPU{
+		{Index: "0", UUID: "GPU-0", Utilization: 75, MemoryUsed: 1024},
+		{Index: "1", UUID: "GPU-1", Utilization: math.MaxUint32, MemoryUsed: math.MaxUint64},
+	}
+	utilization := uint32(75)
+	memoryUsed := uint64(1024)
+
+	testCases := []struct {
+		description       string
+		devices           []string
+		expectedSnapshots []GPUSnapshot
+	}{
+		{
+			description: "all GPUs are selected",
+			devices:     []string{"all"},
+			expectedSnapshots: []GPUSnapshot{
+				{Index: "0", UUID: "GPU-0", Utilization: &utilization, MemoryUsed: &memoryUsed},
+				{Index: "1", UUID: "GPU-1"},
+			},
+		},
+		{
+			description: "GPU is selected by UUID",
+			devices:     []string{"GPU-1"},
+			expectedSnapshots: []GPUSnapshot{
+				{Index: "1", UUID: "GPU-1"},
+			},
+		},
+		{
+			description: "CDI device and MIG device select GPU once",
+			devices:     []string{"nvidia.com/gpu=0", "0:1"},
+			expectedSnapshots: []GPUSnapshot{
+				{Index: "0", UUID: "GPU-0", Utilization: &utilization, MemoryUsed: &memoryUsed},
+			},
+		},
+		{
+			description: "no matching devices",
+			devices:     []string{"void"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.EqualValues(t, tc.expectedSnapshots, NewGPUSnapshots(gpus, tc.devices...))
+		})
+	}
+}
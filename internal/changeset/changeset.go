@@ -0,0 +1,109 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package changeset defines a machine-readable description of the changes
+// made (or, for a dry-run, that would be made) by a command. This allows
+// commands to be used from the "check" modes of configuration management
+// tools.
+package changeset
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// An Action describes how a path is changed.
+type Action string
+
+const (
+	// ActionCreate indicates that a path is created.
+	ActionCreate = Action("create")
+	// ActionUpdate indicates that an existing path is modified.
+	ActionUpdate = Action("update")
+	// ActionDelete indicates that an existing path is removed.
+	ActionDelete = Action("delete")
+	// ActionRun indicates that the executable at the path is run.
+	ActionRun = Action("run")
+)
+
+// A Change describes a single change to a path. For changes to individual
+// options of a config file, the key of the option and its original and
+// updated values are included.
+type Change struct {
+	Action      Action `json:"action"`
+	Path        string `json:"path"`
+	Key         string `json:"key,omitempty"`
+	From        any    `json:"from,omitempty"`
+	To          any    `json:"to,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// A ChangeSet is the list of changes made by a command.
+type ChangeSet struct {
+	DryRun  bool     `json:"dryRun"`
+	Changed bool     `json:"changed"`
+	Changes []Change `json:"changes"`
+}
+
+// New creates an empty change set.
+func New(dryRun bool) *ChangeSet {
+	return &ChangeSet{
+		DryRun:  dryRun,
+		Changes: []Change{},
+	}
+}
+
+// Add records the specified changes.
+func (c *ChangeSet) Add(changes ...Change) {
+	if len(changes) == 0 {
+		return
+	}
+	c.Changed = true
+	c.Changes = append(c.Changes, changes...)
+}
+
+// AddFile records the change to the file at the specified path given its
+// original and updated contents. A nil value indicates that the file does not
+// exist. If the contents are equal, no change is recorded.
+func (c *ChangeSet) AddFile(path string, original []byte, updated []byte) {
+	change := Change{Path: path}
+	switch {
+	case original == nil && updated == nil:
+		return
+	case original == nil:
+		change.Action = ActionCreate
+	case updated == nil:
+		change.Action = ActionDelete
+	case bytes.Equal(original, updated):
+		return
+	default:
+		change.Action = ActionUpdate
+	}
+	c.Add(change)
+}
+
+// WriteTo writes the change set as JSON to the specified writer.
+func (c *ChangeSet) WriteTo(w io.Writer) (int64, error) {
+	output, err := json.Marshal(c)
+	if err != nil {
+		return 0, fmt.Errorf("unable to convert change set to JSON: %v", err)
+	}
+	n, err := fmt.Fprintf(w, "%s\n", output)
+	return int64(n), err
+}
@@ -0,0 +1,86 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package changeset
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddFile(t *testing.T) {
+	testCases := []struct {
+		description     string
+		original        []byte
+		updated         []byte
+		expectedChanges []Change
+	}{
+		{
+			description:     "missing file is not changed",
+			expectedChanges: []Change{},
+		},
+		{
+			description: "new file is created",
+			updated:     []byte("contents"),
+			expectedChanges: []Change{
+				{Action: ActionCreate, Path: "/etc/config"},
+			},
+		},
+		{
+			description: "modified file is updated",
+			original:    []byte("original"),
+			updated:     []byte("updated"),
+			expectedChanges: []Change{
+				{Action: ActionUpdate, Path: "/etc/config"},
+			},
+		},
+		{
+			description:     "unmodified file is not changed",
+			original:        []byte("contents"),
+			updated:         []byte("contents"),
+			expectedChanges: []Change{},
+		},
+		{
+			description: "removed file is deleted",
+			original:    []byte("contents"),
+			expectedChanges: []Change{
+				{Action: ActionDelete, Path: "/etc/config"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c := New(true)
+			c.AddFile("/etc/config", tc.original, tc.updated)
+			require.EqualValues(t, tc.expectedChanges, c.Changes)
+			require.Equal(t, len(tc.expectedChanges) > 0, c.Changed)
+		})
+	}
+}
+
+func TestWriteTo(t *testing.T) {
+	c := New(true)
+	c.Add(Change{Action: ActionUpdate, Path: "/etc/config.toml", Key: "mode", From: "auto", To: "cdi"})
+
+	var buf bytes.Buffer
+	_, err := c.WriteTo(&buf)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"dryRun":true,"changed":true,"changes":[{"action":"update","path":"/etc/config.toml","key":"mode","from":"auto","to":"cdi"}]}`, buf.String())
+}
@@ -44,6 +44,12 @@ type ContainerCLIConfig struct {
 	// is required, the features.allow-ldconfig-from-container feature gate must
 	// be enabled explicitly.
 	Ldconfig ldconfigPath `toml:"ldconfig"`
+	// LibrarySearchPaths specifies the (host) paths that are searched for
+	// driver libraries. If set, these are used instead of the standard library
+	// paths and the ldcache and allow for driver installations in non-standard
+	// locations such as /run/opengl-driver/lib on NixOS.
+	// This is not exposed in the config if not set.
+	LibrarySearchPaths []string `toml:"library-search-paths,omitempty"`
 }
 
 // NormalizeLDConfigPath returns the resolved path of the configured LDConfig binary.
@@ -53,6 +59,16 @@ func (c *ContainerCLIConfig) NormalizeLDConfigPath() string {
 	return string(c.Ldconfig.normalize())
 }
 
+// HostLDConfigPath returns the normalized path of the configured ldconfig
+// binary on the host without the '@' prefix. If a container path is
+// configured, an empty string is returned.
+func (c *ContainerCLIConfig) HostLDConfigPath() string {
+	if !c.Ldconfig.isHostRelative() {
+		return ""
+	}
+	return strings.TrimPrefix(c.NormalizeLDConfigPath(), "@")
+}
+
 // An ldconfigPath is used to represent the path to ldconfig.
 type ldconfigPath string
 
@@ -81,3 +81,34 @@ func TestNormalizeLDConfigPath(t *testing.T) {
 		})
 	}
 }
+
+func TestHostLDConfigPath(t *testing.T) {
+	testCases := []struct {
+		description string
+		ldconfig    ldconfigPath
+		expected    string
+	}{
+		{
+			description: "empty returns empty",
+		},
+		{
+			description: "container path returns empty",
+			ldconfig:    "/sbin/ldconfig",
+		},
+		{
+			description: "host path is returned without prefix",
+			ldconfig:    "@/run/current-system/sw/bin/ldconfig",
+			expected:    "/run/current-system/sw/bin/ldconfig",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c := ContainerCLIConfig{
+				Ldconfig: tc.ldconfig,
+			}
+
+			require.Equal(t, tc.expected, c.HostLDConfigPath())
+		})
+	}
+}
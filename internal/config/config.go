@@ -18,8 +18,10 @@ package config
 
 import (
 	"bufio"
+	"encoding/json"
 	"errors"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 
@@ -70,8 +72,15 @@ type Config struct {
 	NVIDIAContainerRuntimeConfig     RuntimeConfig      `toml:"nvidia-container-runtime"`
 	NVIDIAContainerRuntimeHookConfig RuntimeHookConfig  `toml:"nvidia-container-runtime-hook"`
 
+	// DeviceNodes defines the ownership and file mode of device nodes created
+	// by the NVIDIA Container Toolkit.
+	DeviceNodes deviceNodesConfig `toml:"device-nodes,omitempty"`
+
 	// Features allows for finer control over optional features.
 	Features features `toml:"features,omitempty"`
+
+	// Telemetry configures opt-in usage reporting.
+	Telemetry telemetryConfig `toml:"telemetry,omitempty"`
 }
 
 // GetConfigFilePath returns the path to the config file for the configured system
@@ -139,12 +148,30 @@ func GetDefault() (*Config, error) {
 	return &d, nil
 }
 
+// DeepCopy returns a copy of the config that shares no state with the
+// original. This is required where a config is reused for multiple containers
+// since processing a container updates the config (e.g. the resolved mode).
+func (c *Config) DeepCopy() (*Config, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	var copied Config
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil, err
+	}
+	return &copied, nil
+}
+
 // assertValid checks for a valid config.
 func (c *Config) assertValid() error {
 	err := c.NVIDIAContainerCLIConfig.Ldconfig.assertValid(c.Features.AllowLDConfigFromContainer.IsEnabled())
 	if err != nil {
 		return errors.Join(err, errInvalidConfig)
 	}
+	if _, err := c.DeviceNodes.Resolve(); err != nil {
+		return errors.Join(err, errInvalidConfig)
+	}
 	return nil
 }
 
@@ -152,6 +179,13 @@ func (c *Config) assertValid() error {
 var getLdConfigPath = getLdConfigPathStub
 
 func getLdConfigPathStub() ldconfigPath {
+	if _, err := os.Stat("/sbin/ldconfig"); err != nil {
+		// On non-FHS distributions (e.g. NixOS or Guix) ldconfig is not
+		// present at /sbin/ldconfig and we fall back to the one in the PATH.
+		if path, err := exec.LookPath("ldconfig"); err == nil {
+			return ldconfigPath("@" + path).normalize()
+		}
+	}
 	return ldconfigPath("@/sbin/ldconfig").normalize()
 }
 
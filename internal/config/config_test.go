@@ -446,6 +446,28 @@ func TestAssertValid(t *testing.T) {
 	}
 }
 
+func TestDeepCopy(t *testing.T) {
+	defer setGetLdConfigPathForTest()()
+
+	original, err := GetDefault()
+	require.NoError(t, err)
+	original.Features.AllowLDConfigFromContainer = ptr(feature(true))
+
+	copied, err := original.DeepCopy()
+	require.NoError(t, err)
+	require.EqualValues(t, original, copied)
+
+	copied.NVIDIAContainerRuntimeConfig.Mode = "legacy"
+	copied.NVIDIAContainerRuntimeConfig.Modes.CDI.AnnotationPrefixes = nil
+	copied.NVIDIAContainerRuntimeConfig.Runtimes[0] = "youki"
+	*copied.Features.AllowLDConfigFromContainer = false
+
+	require.Equal(t, "auto", original.NVIDIAContainerRuntimeConfig.Mode)
+	require.EqualValues(t, []string{"cdi.k8s.io/"}, original.NVIDIAContainerRuntimeConfig.Modes.CDI.AnnotationPrefixes)
+	require.Equal(t, "runc", original.NVIDIAContainerRuntimeConfig.Runtimes[0])
+	require.True(t, original.Features.AllowLDConfigFromContainer.IsEnabled())
+}
+
 // setGetDistIDsLikeForTest overrides the distribution IDs that would normally be read from the /etc/os-release file.
 func setGetDistIDLikeForTest(ids []string) func() {
 	if ids == nil {
@@ -0,0 +1,122 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"strconv"
+)
+
+// deviceNodesConfig defines the ownership and file mode to use for device
+// nodes created or injected by the NVIDIA Container Toolkit.
+// If a field is not set, the existing behaviour (matching the host device node)
+// is retained.
+type deviceNodesConfig struct {
+	// User is the owner of created device nodes. This can be a user name or a
+	// numeric UID. Names are resolved on the host.
+	User string `toml:"user,omitempty"`
+	// Group is the group of created device nodes. This can be a group name
+	// (e.g. video) or a numeric GID. Names are resolved on the host.
+	Group string `toml:"group,omitempty"`
+	// Mode is the octal file mode of created device nodes (e.g. 0660).
+	Mode string `toml:"mode,omitempty"`
+}
+
+// DeviceNodeOwnership represents the resolved ownership and file mode for
+// device nodes. Nil values indicate that the corresponding property should
+// not be modified.
+type DeviceNodeOwnership struct {
+	UID      *uint32
+	GID      *uint32
+	FileMode *os.FileMode
+}
+
+// IsEmpty returns true if no properties are set.
+func (o DeviceNodeOwnership) IsEmpty() bool {
+	return o.UID == nil && o.GID == nil && o.FileMode == nil
+}
+
+// Resolve returns the ownership and file mode defined by the config.
+func (c deviceNodesConfig) Resolve() (DeviceNodeOwnership, error) {
+	return ResolveDeviceNodeOwnership(c.User, c.Group, c.Mode)
+}
+
+// ResolveDeviceNodeOwnership converts the specified user, group, and mode
+// strings to a DeviceNodeOwnership. Empty values are ignored.
+func ResolveDeviceNodeOwnership(usr string, group string, mode string) (DeviceNodeOwnership, error) {
+	var o DeviceNodeOwnership
+	if usr != "" {
+		uid, err := resolveID(usr, func(name string) (string, error) {
+			u, err := user.Lookup(name)
+			if err != nil {
+				return "", err
+			}
+			return u.Uid, nil
+		})
+		if err != nil {
+			return o, fmt.Errorf("invalid device node user %q: %w", usr, err)
+		}
+		o.UID = &uid
+	}
+	if group != "" {
+		gid, err := ResolveGroupID(group)
+		if err != nil {
+			return o, fmt.Errorf("invalid device node group %q: %w", group, err)
+		}
+		o.GID = &gid
+	}
+	if mode != "" {
+		m, err := strconv.ParseUint(mode, 8, 32)
+		if err != nil || m > 0777 {
+			return o, fmt.Errorf("invalid device node mode %q: must be an octal value not exceeding 0777", mode)
+		}
+		fileMode := os.FileMode(m)
+		o.FileMode = &fileMode
+	}
+	return o, nil
+}
+
+// ResolveGroupID returns the GID for the specified group name or numeric GID.
+func ResolveGroupID(group string) (uint32, error) {
+	return resolveID(group, func(name string) (string, error) {
+		g, err := user.LookupGroup(name)
+		if err != nil {
+			return "", err
+		}
+		return g.Gid, nil
+	})
+}
+
+// resolveID returns the numeric ID for the specified value.
+// If the value is not numeric, the specified lookup function is used.
+func resolveID(value string, lookup func(string) (string, error)) (uint32, error) {
+	if id, err := strconv.ParseUint(value, 10, 32); err == nil {
+		return uint32(id), nil
+	}
+	resolved, err := lookup(value)
+	if err != nil {
+		return 0, err
+	}
+	id, err := strconv.ParseUint(resolved, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(id), nil
+}
@@ -0,0 +1,119 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package engines
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine/containerd"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine/crio"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine/docker"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine/podman"
+)
+
+// An Engine describes where a container engine and its config are expected
+// to be found on the host.
+type Engine struct {
+	Name           string
+	Executable     string
+	ConfigFilePath string
+	// HookDirs are the directories from which OCI hooks are loaded. This is
+	// only applicable to engines that support OCI hooks.
+	HookDirs []string
+}
+
+var ociHookDirs = []string{"/usr/share/containers/oci/hooks.d", "/etc/containers/oci/hooks.d"}
+
+// Defaults are the container engines supported by the NVIDIA Container
+// Toolkit with the config file locations used by nvidia-ctk runtime configure.
+var Defaults = []Engine{
+	{
+		Name:           "docker",
+		Executable:     "dockerd",
+		ConfigFilePath: "/etc/docker/daemon.json",
+	},
+	{
+		Name:           "containerd",
+		Executable:     "containerd",
+		ConfigFilePath: "/etc/containerd/config.toml",
+	},
+	{
+		Name:           "crio",
+		Executable:     "crio",
+		ConfigFilePath: "/etc/crio/crio.conf",
+		HookDirs:       ociHookDirs,
+	},
+	{
+		Name:           "podman",
+		Executable:     "podman",
+		ConfigFilePath: "/etc/containers/containers.conf.d/99-nvidia.conf",
+		HookDirs:       ociHookDirs,
+	},
+}
+
+// LoadConfig loads the config of the engine from its config file.
+func (e Engine) LoadConfig(logger logger.Interface) (engine.Interface, error) {
+	switch e.Name {
+	case "containerd":
+		return containerd.New(
+			containerd.WithLogger(logger),
+			containerd.WithPath(e.ConfigFilePath),
+		)
+	case "crio":
+		return crio.New(
+			crio.WithLogger(logger),
+			crio.WithPath(e.ConfigFilePath),
+		)
+	case "docker":
+		return docker.New(
+			docker.WithLogger(logger),
+			docker.WithPath(e.ConfigFilePath),
+		)
+	case "podman":
+		return podman.New(
+			podman.WithLogger(logger),
+			podman.WithPath(e.ConfigFilePath),
+		)
+	default:
+		return nil, fmt.Errorf("unrecognized engine %v", e.Name)
+	}
+}
+
+// NVIDIAHookFiles returns the OCI hook files in the hook directories of the
+// engine that invoke the NVIDIA Container Runtime Hook.
+func (e Engine) NVIDIAHookFiles() []string {
+	var nvidiaHookFiles []string
+	for _, dir := range e.HookDirs {
+		hookFiles, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+		for _, hookFile := range hookFiles {
+			contents, err := os.ReadFile(hookFile)
+			if err != nil {
+				continue
+			}
+			if strings.Contains(string(contents), "nvidia-container-runtime-hook") {
+				nvidiaHookFiles = append(nvidiaHookFiles, hookFile)
+			}
+		}
+	}
+	return nvidiaHookFiles
+}
@@ -21,6 +21,19 @@ type features struct {
 	// AllowCUDACompatLibsFromContainer allows CUDA compat libs from a container
 	// to override certain driver library mounts from the host.
 	AllowCUDACompatLibsFromContainer *feature `toml:"allow-cuda-compat-libs-from-container,omitempty"`
+	// AllowComputeModeHook allows containers to request that the compute mode
+	// of their GPUs is set for the lifetime of the container using the
+	// NVIDIA_COMPUTE_MODE envvar.
+	AllowComputeModeHook *feature `toml:"allow-compute-mode-hook,omitempty"`
+	// AllowGPULimitsHook allows containers to request power and clock limits
+	// for their GPUs using the NVIDIA_GPU_POWER_LIMIT and NVIDIA_GPU_CLOCKS
+	// envvars or the corresponding annotations.
+	AllowGPULimitsHook *feature `toml:"allow-gpu-limits-hook,omitempty"`
+	// AllowGPUMemoryHook allows containers to request a guaranteed amount of
+	// GPU memory and a percentage of the GPU threads using the
+	// NVIDIA_GPU_MEMORY and NVIDIA_GPU_THREAD_PERCENTAGE envvars or the
+	// corresponding annotations.
+	AllowGPUMemoryHook *feature `toml:"allow-gpu-memory-hook,omitempty"`
 	// AllowLDConfigFromContainer allows non-host ldconfig paths to be used.
 	// If this feature flag is not set to 'true' only host-rooted config paths
 	// (i.e. paths starting with an '@' are considered valid)
@@ -34,6 +47,14 @@ type features struct {
 	// DisableImexChannelCreation ensures that the implicit creation of
 	// requested IMEX channels is skipped when invoking the nvidia-container-cli.
 	DisableImexChannelCreation *feature `toml:"disable-imex-channel-creation,omitempty"`
+	// ExposeDeviceHealth enables the mounting of the device health directory
+	// that is maintained by `nvidia-ctk system watch-device-health` into
+	// containers that request GPUs.
+	ExposeDeviceHealth *feature `toml:"expose-device-health,omitempty"`
+	// InjectDeviceMetadata enables the injection of a file describing the
+	// mapping between the devices in a container and the devices on the host
+	// at /run/nvidia/devices.json.
+	InjectDeviceMetadata *feature `toml:"inject-device-metadata,omitempty"`
 	// IgnoreImexChannelRequests configures the NVIDIA Container Toolkit to
 	// ignore IMEX channel requests through the NVIDIA_IMEX_CHANNELS envvar or
 	// volume mounts.
@@ -42,6 +63,16 @@ type features struct {
 	// possibly bypassing other checks by an orchestration system such as
 	// kubernetes.
 	IgnoreImexChannelRequests *feature `toml:"ignore-imex-channel-requests,omitempty"`
+	// RecordGPUUtilization records a snapshot of the utilization and used
+	// memory of the GPUs of a container at the time that the container is
+	// created. The snapshot is added to the audit log (if configured) and as
+	// an annotation to the container.
+	RecordGPUUtilization *feature `toml:"record-gpu-utilization,omitempty"`
+	// ValidateContainer enables a final createContainer hook that checks
+	// that the NVIDIA device nodes are accessible and that the driver
+	// libraries are resolvable and match the driver version in containers
+	// that request GPUs. Container creation fails if this is not the case.
+	ValidateContainer *feature `toml:"validate-container,omitempty"`
 }
 
 type feature bool
@@ -0,0 +1,126 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package image
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+	"tags.cncf.io/container-device-interface/pkg/parser"
+)
+
+// DeviceAliases maps friendly names to the set of devices that they refer to.
+// An alias can be used instead of a device ID in a device request (e.g.
+// NVIDIA_VISIBLE_DEVICES=training-gpus) and as the device name in a fully
+// qualified CDI device name (e.g. nvidia.com/gpu=training-gpus).
+type DeviceAliases map[string][]string
+
+// LoadDeviceAliases loads the device aliases from the specified file. The file
+// is a TOML file mapping each alias to a list of devices:
+//
+//	training-gpus = ["GPU-8a1f5f4d-...", "GPU-3e2c8d41-..."]
+//	display = ["GPU-c1a5e3b2-..."]
+func LoadDeviceAliases(path string) (DeviceAliases, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read device aliases: %w", err)
+	}
+
+	aliases := make(DeviceAliases)
+	if err := toml.Unmarshal(contents, &aliases); err != nil {
+		return nil, fmt.Errorf("failed to parse device aliases from %v: %w", path, err)
+	}
+	if err := aliases.validate(); err != nil {
+		return nil, fmt.Errorf("invalid device aliases in %v: %w", path, err)
+	}
+	return aliases, nil
+}
+
+// validate checks that the aliases can be distinguished from device IDs and
+// from the special values of a device request.
+func (a DeviceAliases) validate() error {
+	for alias, devices := range a {
+		switch {
+		case alias == "all" || alias == "none" || alias == "void":
+			return fmt.Errorf("alias %q is reserved", alias)
+		case strings.HasPrefix(alias, deviceCountPrefix) || strings.HasPrefix(alias, migProfileRequestPrefix):
+			return fmt.Errorf("alias %q cannot start with %q or %q", alias, deviceCountPrefix, migProfileRequestPrefix)
+		case strings.HasPrefix(alias, "GPU-") || strings.HasPrefix(alias, "MIG-"):
+			return fmt.Errorf("alias %q cannot be a device UUID", alias)
+		case isIndex(alias):
+			return fmt.Errorf("alias %q cannot be a device index", alias)
+		case isDeviceExclusion(alias) || strings.ContainsAny(alias, ",:="):
+			return fmt.Errorf("alias %q contains invalid characters", alias)
+		}
+		if err := parser.ValidateDeviceName(alias); err != nil {
+			return fmt.Errorf("invalid alias %q: %w", alias, err)
+		}
+		if len(devices) == 0 {
+			return fmt.Errorf("alias %q does not refer to any devices", alias)
+		}
+	}
+	return nil
+}
+
+func isIndex(alias string) bool {
+	_, err := strconv.Atoi(alias)
+	return err == nil
+}
+
+// expandAliases replaces the aliases in the specified devices with the
+// devices that they refer to. Exclusions and fully qualified CDI device names
+// are also expanded. Duplicate devices are removed.
+func (i CUDA) expandAliases(devices []string) []string {
+	if len(i.deviceAliases) == 0 {
+		return devices
+	}
+
+	var expanded []string
+	seen := make(map[string]bool)
+	add := func(device string) {
+		if seen[device] {
+			return
+		}
+		seen[device] = true
+		expanded = append(expanded, device)
+	}
+
+	for _, device := range devices {
+		prefix, name := "", device
+		if isDeviceExclusion(device) {
+			prefix, name = device[:1], device[1:]
+		}
+		if vendor, class, deviceName, err := parser.ParseQualifiedName(name); err == nil {
+			prefix, name = prefix+parser.QualifiedName(vendor, class, ""), deviceName
+		}
+
+		aliased, ok := i.deviceAliases[name]
+		if !ok {
+			add(device)
+			continue
+		}
+		i.logger.Debugf("Expanding device alias %q to %v", name, aliased)
+		for _, d := range aliased {
+			add(prefix + d)
+		}
+	}
+	return expanded
+}
@@ -0,0 +1,170 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package image
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDeviceAliases(t *testing.T) {
+	testCases := []struct {
+		description     string
+		contents        string
+		expectedError   bool
+		expectedAliases DeviceAliases
+	}{
+		{
+			description: "valid aliases are loaded",
+			contents:    "training-gpus = [\"GPU-1\", \"GPU-2\"]\ndisplay = [\"0\"]\n",
+			expectedAliases: DeviceAliases{
+				"training-gpus": {"GPU-1", "GPU-2"},
+				"display":       {"0"},
+			},
+		},
+		{
+			description:   "reserved alias returns error",
+			contents:      "all = [\"GPU-1\"]\n",
+			expectedError: true,
+		},
+		{
+			description:   "index alias returns error",
+			contents:      "\"1\" = [\"GPU-1\"]\n",
+			expectedError: true,
+		},
+		{
+			description:   "uuid alias returns error",
+			contents:      "GPU-2 = [\"GPU-1\"]\n",
+			expectedError: true,
+		},
+		{
+			description:   "count alias returns error",
+			contents:      "\"count:2\" = [\"GPU-1\"]\n",
+			expectedError: true,
+		},
+		{
+			description:   "empty alias returns error",
+			contents:      "training-gpus = []\n",
+			expectedError: true,
+		},
+		{
+			description:   "invalid toml returns error",
+			contents:      "training-gpus = \n",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "device-aliases.toml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.contents), 0600))
+
+			aliases, err := LoadDeviceAliases(path)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedAliases, aliases)
+		})
+	}
+}
+
+func TestVisibleDevicesWithAliases(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	aliases := DeviceAliases{
+		"training-gpus": {"GPU-1", "GPU-2"},
+		"display":       {"GPU-3"},
+	}
+	gpuLister := &fakeGPULister{
+		gpus: []GPU{
+			{Index: "0", UUID: "GPU-1"},
+			{Index: "1", UUID: "GPU-2"},
+			{Index: "2", UUID: "GPU-3"},
+		},
+	}
+
+	testCases := []struct {
+		description     string
+		env             []string
+		annotations     map[string]string
+		mounts          []specs.Mount
+		expectedDevices []string
+	}{
+		{
+			description:     "alias in envvar is expanded",
+			env:             []string{"NVIDIA_VISIBLE_DEVICES=training-gpus"},
+			expectedDevices: []string{"GPU-1", "GPU-2"},
+		},
+		{
+			description:     "aliases are combined with devices and deduplicated",
+			env:             []string{"NVIDIA_VISIBLE_DEVICES=display,GPU-1,training-gpus"},
+			expectedDevices: []string{"GPU-3", "GPU-1", "GPU-2"},
+		},
+		{
+			description:     "excluded alias is expanded",
+			env:             []string{"NVIDIA_VISIBLE_DEVICES=all,-display"},
+			expectedDevices: []string{"0", "1"},
+		},
+		{
+			description:     "unknown names are not modified",
+			env:             []string{"NVIDIA_VISIBLE_DEVICES=inference-gpus"},
+			expectedDevices: []string{"inference-gpus"},
+		},
+		{
+			description: "alias in qualified CDI annotation is expanded",
+			annotations: map[string]string{
+				"cdi.k8s.io/devices": "nvidia.com/gpu=training-gpus,nvidia.com/gpu=all",
+			},
+			expectedDevices: []string{"nvidia.com/gpu=GPU-1", "nvidia.com/gpu=GPU-2", "nvidia.com/gpu=all"},
+		},
+		{
+			description: "alias in volume mount is expanded",
+			mounts: []specs.Mount{
+				{
+					Source:      "/dev/null",
+					Destination: filepath.Join(DeviceListAsVolumeMountsRoot, "display"),
+				},
+			},
+			expectedDevices: []string{"GPU-3"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			i, err := New(
+				WithLogger(logger),
+				WithEnv(tc.env),
+				WithAnnotations(tc.annotations),
+				WithAnnotationsPrefixes([]string{"cdi.k8s.io/"}),
+				WithMounts(tc.mounts),
+				WithAcceptDeviceListAsVolumeMounts(true),
+				WithDeviceAliases(aliases),
+				WithGPULister(gpuLister),
+			)
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedDevices, i.VisibleDevices())
+		})
+	}
+}
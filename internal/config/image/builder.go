@@ -50,6 +50,11 @@ func New(opt ...Option) (CUDA, error) {
 	if b.logger == nil {
 		b.logger = logger.New()
 	}
+	switch b.deviceRequestPrecedence {
+	case "", DeviceRequestPrecedenceAnnotations, DeviceRequestPrecedenceEnvvar:
+	default:
+		return CUDA{}, fmt.Errorf("invalid device request precedence %q", b.deviceRequestPrecedence)
+	}
 	if b.env == nil {
 		b.env = make(map[string]string)
 	}
@@ -63,6 +68,14 @@ func (b builder) build() (CUDA, error) {
 		b.env[EnvVarNvidiaDisableRequire] = "true"
 	}
 
+	devices, ok, err := b.visibleDevicesFromFile()
+	if err != nil {
+		return CUDA{}, err
+	}
+	if ok {
+		b.env[EnvVarNvidiaVisibleDevices] = devices
+	}
+
 	return b.CUDA, nil
 }
 
@@ -94,6 +107,32 @@ func WithAnnotationsPrefixes(annotationsPrefixes []string) Option {
 	}
 }
 
+// WithDefaultDriverVersion sets the driver version that is used if the
+// container does not request a specific driver version.
+func WithDefaultDriverVersion(defaultDriverVersion string) Option {
+	return func(b *builder) error {
+		b.defaultDriverVersion = defaultDriverVersion
+		return nil
+	}
+}
+
+// WithDeviceAliases sets the aliases that are expanded in device requests.
+func WithDeviceAliases(deviceAliases DeviceAliases) Option {
+	return func(b *builder) error {
+		b.deviceAliases = deviceAliases
+		return nil
+	}
+}
+
+// WithDeviceRequestPrecedence sets which device requests are used if devices
+// are requested using both CDI annotations and environment variables.
+func WithDeviceRequestPrecedence(deviceRequestPrecedence DeviceRequestPrecedence) Option {
+	return func(b *builder) error {
+		b.deviceRequestPrecedence = deviceRequestPrecedence
+		return nil
+	}
+}
+
 // WithDisableRequire sets the disable require option.
 func WithDisableRequire(disableRequire bool) Option {
 	return func(b *builder) error {
@@ -127,6 +166,15 @@ func WithEnvMap(env map[string]string) Option {
 	}
 }
 
+// WithGPULister sets the lister used to resolve device requests that exclude
+// specific devices.
+func WithGPULister(gpuLister GPULister) Option {
+	return func(b *builder) error {
+		b.gpuLister = gpuLister
+		return nil
+	}
+}
+
 // WithLogger sets the logger to use when creating the CUDA image.
 func WithLogger(logger logger.Interface) Option {
 	return func(b *builder) error {
@@ -37,6 +37,35 @@ const (
 	volumeMountDevicePrefixImex = "imex/"
 )
 
+// A GPUSharingStrategy defines how the GPUs of a container are shared with
+// other containers.
+type GPUSharingStrategy string
+
+const (
+	// GPUSharingStrategyTimeSlicing indicates that the GPUs are time-sliced
+	// between containers.
+	GPUSharingStrategyTimeSlicing = GPUSharingStrategy("time-slicing")
+	// GPUSharingStrategyMPS indicates that the GPUs are shared between
+	// containers using the CUDA Multi-Process Service (MPS).
+	GPUSharingStrategyMPS = GPUSharingStrategy("mps")
+)
+
+// A DeviceRequestPrecedence defines which device requests are used if devices
+// are requested using both CDI annotations and environment variables. This is
+// the case for the allocation responses of the Kubernetes device plugin if
+// both the envvar and cdi-annotations device list strategies are enabled.
+type DeviceRequestPrecedence string
+
+const (
+	// DeviceRequestPrecedenceAnnotations uses the devices requested in CDI
+	// annotations, ignoring those requested using environment variables.
+	DeviceRequestPrecedenceAnnotations = DeviceRequestPrecedence("annotations")
+	// DeviceRequestPrecedenceEnvvar uses the devices requested using
+	// environment variables (if these are accepted for the container),
+	// ignoring those requested in CDI annotations.
+	DeviceRequestPrecedenceEnvvar = DeviceRequestPrecedence("envvar")
+)
+
 // CUDA represents a CUDA image that can be used for GPU computing. This wraps
 // a map of environment variable to values that can be used to perform lookups
 // such as requirements.
@@ -52,6 +81,11 @@ type CUDA struct {
 	acceptDeviceListAsVolumeMounts bool
 	acceptEnvvarUnprivileged       bool
 	preferredVisibleDeviceEnvVars  []string
+	deviceAliases                  DeviceAliases
+	defaultDriverVersion           string
+	deviceRequestPrecedence        DeviceRequestPrecedence
+
+	gpuLister GPULister
 }
 
 // NewCUDAImageFromSpec creates a CUDA image from the input OCI runtime spec.
@@ -94,6 +128,83 @@ func (i CUDA) HasEnvvar(key string) bool {
 	return exists
 }
 
+// DriverVersion returns the driver version requested for the container.
+// The driver version annotation takes precedence over the NVIDIA_DRIVER_VERSION
+// environment variable. If no version is requested, the default driver version
+// is returned. This is empty unless set by WithDefaultDriverVersion.
+func (i CUDA) DriverVersion() string {
+	if version := i.annotationOrEnv(AnnotationDriverVersion, EnvVarNvidiaDriverVersion); version != "" {
+		return version
+	}
+	return i.defaultDriverVersion
+}
+
+// GPUPowerLimit returns the power limit in watts requested for the GPUs of the
+// container. The annotation takes precedence over the NVIDIA_GPU_POWER_LIMIT
+// environment variable.
+func (i CUDA) GPUPowerLimit() string {
+	return i.annotationOrEnv(AnnotationGPUPowerLimit, EnvVarNvidiaGPUPowerLimit)
+}
+
+// GPUClocks returns the range of GPU clocks requested for the GPUs of the
+// container. The annotation takes precedence over the NVIDIA_GPU_CLOCKS
+// environment variable.
+func (i CUDA) GPUClocks() string {
+	return i.annotationOrEnv(AnnotationGPUClocks, EnvVarNvidiaGPUClocks)
+}
+
+// GPUMemory returns the amount of memory requested for each GPU of the
+// container. The annotation takes precedence over the NVIDIA_GPU_MEMORY
+// environment variable.
+func (i CUDA) GPUMemory() string {
+	return i.annotationOrEnv(AnnotationGPUMemory, EnvVarNvidiaGPUMemory)
+}
+
+// GPUThreadPercentage returns the percentage of the threads of each GPU
+// requested for the container. The annotation takes precedence over the
+// NVIDIA_GPU_THREAD_PERCENTAGE environment variable.
+func (i CUDA) GPUThreadPercentage() string {
+	return i.annotationOrEnv(AnnotationGPUThreadPercentage, EnvVarNvidiaGPUThreadPercentage)
+}
+
+func (i CUDA) annotationOrEnv(annotation string, envVar string) string {
+	if value := strings.TrimSpace(i.annotations[annotation]); value != "" {
+		return value
+	}
+	return strings.TrimSpace(i.env[envVar])
+}
+
+// GPUSharingStrategy returns the strategy used to share the GPUs of the
+// container with other containers. An empty strategy indicates that the GPUs
+// are not explicitly shared.
+func (i CUDA) GPUSharingStrategy() (GPUSharingStrategy, error) {
+	strategy := GPUSharingStrategy(strings.TrimSpace(i.annotations[AnnotationGPUSharingStrategy]))
+	switch strategy {
+	case "", GPUSharingStrategyTimeSlicing, GPUSharingStrategyMPS:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("invalid GPU sharing strategy %q", strategy)
+	}
+}
+
+// MPSActiveThreadPercentage returns the percentage of the GPU threads that
+// should be available to a container using MPS.
+func (i CUDA) MPSActiveThreadPercentage() string {
+	return strings.TrimSpace(i.annotations[AnnotationMPSActiveThreadPercentage])
+}
+
+// RequestsExclusiveDevices returns whether the devices requested by the image
+// should not be shared with other containers requesting exclusive devices.
+// Containers that are marked as using shared GPUs never request exclusive
+// devices.
+func (i CUDA) RequestsExclusiveDevices() bool {
+	if strategy, err := i.GPUSharingStrategy(); err != nil || strategy != "" {
+		return false
+	}
+	exclusive, _ := strconv.ParseBool(i.env[EnvVarNvidiaExclusiveDevices])
+	return exclusive
+}
+
 // IsLegacy returns whether the associated CUDA image is a "legacy" image. An
 // image is considered legacy if it has a CUDA_VERSION environment variable defined
 // and no NVIDIA_REQUIRE_CUDA environment variable defined.
@@ -155,7 +266,7 @@ func (i CUDA) devicesFromEnvvars(envVars ...string) []string {
 			isSet = true
 			for _, d := range strings.Split(devs, ",") {
 				trimmed := strings.TrimSpace(d)
-				if len(trimmed) == 0 {
+				if len(trimmed) == 0 || isDeviceExclusion(trimmed) {
 					continue
 				}
 				devices = append(devices, trimmed)
@@ -254,16 +365,67 @@ func (i CUDA) visibleEnvVars() []string {
 // otherwise device requests through environment variables are considered.
 // In cases where environment variable requests required privileged containers,
 // such devices requests are ignored.
+//
+// If devices are requested in CDI annotations as well as through environment
+// variables, the configured DeviceRequestPrecedence determines which requests
+// are used and a warning is logged if the requested devices differ.
 func (i CUDA) VisibleDevices() []string {
-	// If annotation device requests are present, these are preferred.
-	annotationDeviceRequests := i.cdiDeviceRequestsFromAnnotations()
-	if len(annotationDeviceRequests) > 0 {
+	annotationDeviceRequests := i.expandAliases(i.cdiDeviceRequestsFromAnnotations())
+	if len(annotationDeviceRequests) == 0 {
+		return i.visibleDevicesFromMountsOrEnvVar()
+	}
+
+	envVarDeviceRequests := i.acceptedVisibleDevicesFromEnvVar()
+	if len(envVarDeviceRequests) == 0 {
 		return annotationDeviceRequests
 	}
 
+	switch i.deviceRequestPrecedence {
+	case DeviceRequestPrecedenceEnvvar:
+		i.warnOnDeviceRequestMismatch(envVarDeviceRequests, annotationDeviceRequests, "CDI annotations")
+		return envVarDeviceRequests
+	default:
+		i.warnOnDeviceRequestMismatch(annotationDeviceRequests, envVarDeviceRequests, "environment variables")
+		return annotationDeviceRequests
+	}
+}
+
+// warnOnDeviceRequestMismatch logs a warning if the ignored device requests
+// refer to different devices than the used device requests. Since CUDA base
+// images set NVIDIA_VISIBLE_DEVICES=all, ignored requests for all devices are
+// not considered a mismatch.
+func (i CUDA) warnOnDeviceRequestMismatch(used []string, ignored []string, ignoredSource string) {
+	if len(ignored) == 1 && ignored[0] == "all" {
+		return
+	}
+	if slices.Equal(deviceRequestNames(used), deviceRequestNames(ignored)) {
+		return
+	}
+	i.logger.Warningf("Ignoring devices %v requested by %v that differ from requested devices %v", ignored, ignoredSource, used)
+}
+
+// deviceRequestNames returns the sorted, unique device names for the specified
+// requests. For fully-qualified CDI device names, the vendor and class are
+// removed so that requests such as nvidia.com/gpu=GPU-0 and GPU-0 are
+// considered equal.
+func deviceRequestNames(requests []string) []string {
+	var names []string
+	for _, request := range requests {
+		if _, _, name, err := parser.ParseQualifiedName(request); err == nil {
+			request = name
+		}
+		names = append(names, request)
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// visibleDevicesFromMountsOrEnvVar returns the devices requested as volume
+// mounts (if enabled) or through environment variables.
+func (i CUDA) visibleDevicesFromMountsOrEnvVar() []string {
 	// If enabled, try and get the device list from volume mounts first
 	if i.acceptDeviceListAsVolumeMounts {
-		volumeMountDeviceRequests := i.visibleDevicesFromMounts()
+		volumeMountDeviceRequests := i.expandAliases(i.visibleDevicesFromMounts())
 		if len(volumeMountDeviceRequests) > 0 {
 			return volumeMountDeviceRequests
 		}
@@ -277,7 +439,7 @@ func (i CUDA) VisibleDevices() []string {
 
 	// If the container is privileged, or environment variable requests are
 	// allowed for unprivileged containers, these devices are returned.
-	if i.isPrivileged || i.acceptEnvvarUnprivileged {
+	if i.acceptsEnvvarDeviceRequests() {
 		return envVarDeviceRequests
 	}
 
@@ -290,6 +452,22 @@ func (i CUDA) VisibleDevices() []string {
 	return nil
 }
 
+// acceptedVisibleDevicesFromEnvVar returns the devices requested through
+// environment variables if these requests are accepted for the container.
+func (i CUDA) acceptedVisibleDevicesFromEnvVar() []string {
+	if !i.acceptsEnvvarDeviceRequests() {
+		return nil
+	}
+	return i.visibleDevicesFromEnvVar()
+}
+
+// acceptsEnvvarDeviceRequests returns true if devices requested through
+// environment variables are accepted. This is the case for privileged
+// containers or if envvar requests are allowed for unprivileged containers.
+func (i CUDA) acceptsEnvvarDeviceRequests() bool {
+	return i.isPrivileged || i.acceptEnvvarUnprivileged
+}
+
 // cdiDeviceRequestsFromAnnotations returns a list of devices specified in the
 // annotations.
 // Keys starting with the specified prefixes are considered and expected to
@@ -328,7 +506,25 @@ func (i CUDA) cdiDeviceRequestsFromAnnotations() []string {
 // NVIDIA_VISIBLE_DEVICES environment variable is used.
 func (i CUDA) visibleDevicesFromEnvVar() []string {
 	envVars := i.visibleEnvVars()
-	return i.devicesFromEnvvars(envVars...)
+	devices := i.expandAliases(i.devicesFromEnvvars(envVars...))
+	return i.resolveDevices(devices, i.expandAliases(i.excludedDevicesFromEnvvars(envVars...)))
+}
+
+// excludedDevicesFromEnvvars returns the devices excluded by the image through
+// the specified environment variables. The exclusion prefix is removed from
+// the returned device IDs.
+func (i CUDA) excludedDevicesFromEnvvars(envVars ...string) []string {
+	var excluded []string
+	for _, envVar := range envVars {
+		for _, d := range strings.Split(i.env[envVar], ",") {
+			trimmed := strings.TrimSpace(d)
+			if !isDeviceExclusion(trimmed) {
+				continue
+			}
+			excluded = append(excluded, trimmed[1:])
+		}
+	}
+	return excluded
 }
 
 // visibleDevicesFromMounts returns the set of visible devices requested as mounts.
@@ -432,3 +628,28 @@ func (i CUDA) ImexChannelsFromMounts() []string {
 	}
 	return channels
 }
+
+// ImexChannels returns the list of IMEX channels requested for the container.
+// If enabled, channels requested as volume mounts take precedence over those
+// requested through the NVIDIA_IMEX_CHANNELS environment variable. As is the
+// case for devices, requests through the environment variable are only
+// accepted for privileged containers or if envvar requests are accepted for
+// unprivileged containers.
+func (i CUDA) ImexChannels() []string {
+	if i.acceptDeviceListAsVolumeMounts {
+		if channels := i.ImexChannelsFromMounts(); len(channels) > 0 {
+			return channels
+		}
+	}
+
+	channels := i.ImexChannelsFromEnvVar()
+	if len(channels) == 0 {
+		return nil
+	}
+	if i.acceptsEnvvarDeviceRequests() {
+		return channels
+	}
+
+	i.logger.Warningf("Ignoring IMEX channels requested by environment variable %v in unprivileged container", EnvVarNvidiaImexChannels)
+	return nil
+}
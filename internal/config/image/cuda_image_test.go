@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/sirupsen/logrus"
 	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/require"
 )
@@ -692,6 +693,103 @@ func TestVisibleDevices(t *testing.T) {
 	}
 }
 
+func TestVisibleDevicesDeviceRequestPrecedence(t *testing.T) {
+	annotations := map[string]string{
+		"cdi.k8s.io/nvidia-device-plugin_GPU-0": "nvidia.com/gpu=GPU-0",
+	}
+
+	testCases := []struct {
+		description     string
+		precedence      DeviceRequestPrecedence
+		envvarDevices   string
+		privileged      bool
+		expectedDevices []string
+		expectedError   bool
+		expectedWarning bool
+	}{
+		{
+			description:     "annotations are used by default",
+			envvarDevices:   "GPU-1",
+			privileged:      true,
+			expectedDevices: []string{"nvidia.com/gpu=GPU-0"},
+			expectedWarning: true,
+		},
+		{
+			description:     "matching requests do not warn",
+			precedence:      DeviceRequestPrecedenceAnnotations,
+			envvarDevices:   "GPU-0",
+			privileged:      true,
+			expectedDevices: []string{"nvidia.com/gpu=GPU-0"},
+		},
+		{
+			description:     "image default of all does not warn",
+			precedence:      DeviceRequestPrecedenceAnnotations,
+			envvarDevices:   "all",
+			privileged:      true,
+			expectedDevices: []string{"nvidia.com/gpu=GPU-0"},
+		},
+		{
+			description:     "envvar precedence uses envvar devices",
+			precedence:      DeviceRequestPrecedenceEnvvar,
+			envvarDevices:   "GPU-1",
+			privileged:      true,
+			expectedDevices: []string{"GPU-1"},
+			expectedWarning: true,
+		},
+		{
+			description:     "envvar precedence uses annotations for unprivileged containers",
+			precedence:      DeviceRequestPrecedenceEnvvar,
+			envvarDevices:   "GPU-1",
+			expectedDevices: []string{"nvidia.com/gpu=GPU-0"},
+		},
+		{
+			description:     "envvar precedence uses annotations if no envvar is set",
+			precedence:      DeviceRequestPrecedenceEnvvar,
+			privileged:      true,
+			expectedDevices: []string{"nvidia.com/gpu=GPU-0"},
+		},
+		{
+			description:   "invalid precedence returns error",
+			precedence:    DeviceRequestPrecedence("invalid"),
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			logger, hook := testlog.NewNullLogger()
+			env := make(map[string]string)
+			if tc.envvarDevices != "" {
+				env[EnvVarNvidiaVisibleDevices] = tc.envvarDevices
+			}
+
+			image, err := New(
+				WithLogger(logger),
+				WithEnvMap(env),
+				WithAnnotations(annotations),
+				WithAnnotationsPrefixes([]string{"cdi.k8s.io/"}),
+				WithPrivileged(tc.privileged),
+				WithAcceptEnvvarUnprivileged(false),
+				WithDeviceRequestPrecedence(tc.precedence),
+			)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedDevices, image.VisibleDevices())
+
+			var warnings int
+			for _, entry := range hook.AllEntries() {
+				if entry.Level == logrus.WarnLevel {
+					warnings++
+				}
+			}
+			require.Equal(t, tc.expectedWarning, warnings > 0)
+		})
+	}
+}
+
 func TestImexChannelsFromEnvVar(t *testing.T) {
 	testCases := []struct {
 		description string
@@ -723,6 +821,73 @@ func TestImexChannelsFromEnvVar(t *testing.T) {
 	}
 }
 
+func TestImexChannels(t *testing.T) {
+	testCases := []struct {
+		description string
+		options     []Option
+		expected    []string
+	}{
+		{
+			description: "envvar requests are accepted for privileged containers",
+			options: []Option{
+				WithEnv([]string{"NVIDIA_IMEX_CHANNELS=0,1"}),
+				WithPrivileged(true),
+			},
+			expected: []string{"0", "1"},
+		},
+		{
+			description: "envvar requests are ignored for unprivileged containers",
+			options: []Option{
+				WithEnv([]string{"NVIDIA_IMEX_CHANNELS=0,1"}),
+				WithAcceptEnvvarUnprivileged(false),
+			},
+		},
+		{
+			description: "envvar requests are accepted for unprivileged containers if enabled",
+			options: []Option{
+				WithEnv([]string{"NVIDIA_IMEX_CHANNELS=0,1"}),
+				WithAcceptEnvvarUnprivileged(true),
+			},
+			expected: []string{"0", "1"},
+		},
+		{
+			description: "mount requests take precedence",
+			options: []Option{
+				WithEnv([]string{"NVIDIA_IMEX_CHANNELS=0,1"}),
+				WithPrivileged(true),
+				WithAcceptDeviceListAsVolumeMounts(true),
+				WithMounts([]specs.Mount{
+					{
+						Source:      "/dev/null",
+						Destination: "/var/run/nvidia-container-devices/imex/2",
+					},
+				}),
+			},
+			expected: []string{"2"},
+		},
+		{
+			description: "mount requests are ignored if not enabled",
+			options: []Option{
+				WithMounts([]specs.Mount{
+					{
+						Source:      "/dev/null",
+						Destination: "/var/run/nvidia-container-devices/imex/2",
+					},
+				}),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			i, err := New(tc.options...)
+			require.NoError(t, err)
+
+			require.EqualValues(t, tc.expected, i.ImexChannels())
+		})
+	}
+}
+
 func TestCDIDeviceRequestsFromAnnotations(t *testing.T) {
 	testCases := []struct {
 		description     string
@@ -790,6 +955,88 @@ func TestCDIDeviceRequestsFromAnnotations(t *testing.T) {
 	}
 }
 
+func TestDriverVersion(t *testing.T) {
+	testCases := []struct {
+		description     string
+		env             []string
+		annotations     map[string]string
+		expectedVersion string
+	}{
+		{
+			description: "no driver version requested",
+		},
+		{
+			description:     "driver version from envvar",
+			env:             []string{"NVIDIA_DRIVER_VERSION=535.161.08"},
+			expectedVersion: "535.161.08",
+		},
+		{
+			description: "annotation takes precedence",
+			env:         []string{"NVIDIA_DRIVER_VERSION=535.161.08"},
+			annotations: map[string]string{
+				"nvidia.com/driver-version": "550.54.14",
+			},
+			expectedVersion: "550.54.14",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			image, err := New(
+				WithEnv(tc.env),
+				WithAnnotations(tc.annotations),
+			)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.expectedVersion, image.DriverVersion())
+		})
+	}
+}
+
+func TestRequestsExclusiveDevices(t *testing.T) {
+	testCases := []struct {
+		description       string
+		env               []string
+		annotations       map[string]string
+		expectedExclusive bool
+	}{
+		{
+			description: "exclusive devices not requested",
+		},
+		{
+			description:       "exclusive devices requested",
+			env:               []string{"NVIDIA_EXCLUSIVE_DEVICES=true"},
+			expectedExclusive: true,
+		},
+		{
+			description: "time-sliced container is not exclusive",
+			env:         []string{"NVIDIA_EXCLUSIVE_DEVICES=true"},
+			annotations: map[string]string{
+				"nvidia.com/gpu-sharing-strategy": "time-slicing",
+			},
+		},
+		{
+			description: "invalid sharing strategy is not exclusive",
+			env:         []string{"NVIDIA_EXCLUSIVE_DEVICES=true"},
+			annotations: map[string]string{
+				"nvidia.com/gpu-sharing-strategy": "invalid",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			image, err := New(
+				WithEnv(tc.env),
+				WithAnnotations(tc.annotations),
+			)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.expectedExclusive, image.RequestsExclusiveDevices())
+		})
+	}
+}
+
 func makeTestMounts(paths ...string) []specs.Mount {
 	var mounts []specs.Mount
 	for _, path := range paths {
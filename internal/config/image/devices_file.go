@@ -0,0 +1,103 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package image
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// visibleDevicesFromFile returns the device request read from the file
+// specified by the NVIDIA_VISIBLE_DEVICES_FILE environment variable.
+// The path refers to a path in the container and is resolved against the bind
+// mounts of the container. This allows an orchestrator that is unable to set
+// the environment of a container when its spec is created to provide the
+// device request as a mounted file instead.
+// The devices in the file may be separated by commas or newlines.
+func (i CUDA) visibleDevicesFromFile() (string, bool, error) {
+	path, ok := i.env[EnvVarNvidiaVisibleDevicesFile]
+	if !ok || path == "" {
+		return "", false, nil
+	}
+
+	root, rel, err := i.resolveMountedPath(path)
+	if err != nil {
+		return "", false, err
+	}
+
+	contents, err := readFileInRoot(root, rel)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read device request from %v: %w", path, err)
+	}
+
+	var devices []string
+	for _, line := range strings.Split(string(contents), "\n") {
+		for _, d := range strings.Split(line, ",") {
+			trimmed := strings.TrimSpace(d)
+			if trimmed == "" {
+				continue
+			}
+			devices = append(devices, trimmed)
+		}
+	}
+	return strings.Join(devices, ","), true, nil
+}
+
+// resolveMountedPath returns the source of the bind mount containing the
+// specified container path and the path relative to this source. The bind
+// mount with the longest destination containing the path is used. Since the
+// contents of the mount are controlled by the container, the relative path
+// must be resolved in the source of the mount (see readFileInRoot).
+func (i CUDA) resolveMountedPath(path string) (string, string, error) {
+	if !filepath.IsAbs(path) {
+		return "", "", fmt.Errorf("path %q is not absolute", path)
+	}
+	path = filepath.Clean(path)
+
+	var match *specs.Mount
+	for _, m := range i.mounts {
+		if !isBindMount(m) {
+			continue
+		}
+		destination := filepath.Clean(m.Destination)
+		rel, err := filepath.Rel(destination, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+			continue
+		}
+		if match == nil || len(destination) > len(filepath.Clean(match.Destination)) {
+			match = &m
+		}
+	}
+	if match == nil {
+		return "", "", fmt.Errorf("path %q is not a bind mount in the container", path)
+	}
+
+	rel, _ := filepath.Rel(filepath.Clean(match.Destination), path)
+	return match.Source, rel, nil
+}
+
+func isBindMount(m specs.Mount) bool {
+	if m.Type == "bind" {
+		return true
+	}
+	return slices.Contains(m.Options, "bind") || slices.Contains(m.Options, "rbind")
+}
@@ -0,0 +1,69 @@
+//go:build linux
+
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package image
+
+import (
+	"fmt"
+	"io"
+
+	securejoin "github.com/cyphar/filepath-securejoin"
+	"golang.org/x/sys/unix"
+)
+
+// maxDevicesFileSize is the maximum size of the file containing a device
+// request.
+const maxDevicesFileSize = 64 * 1024
+
+// readFileInRoot reads the regular file at the specified path relative to the
+// specified root. Symlinks are resolved as if the root were the root of the
+// filesystem so that a file outside the root cannot be read.
+func readFileInRoot(root string, path string) ([]byte, error) {
+	handle, err := securejoin.OpenInRoot(root, path)
+	if err != nil {
+		return nil, err
+	}
+	defer handle.Close()
+
+	info, err := handle.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("not a regular file")
+	}
+	if info.Size() > maxDevicesFileSize {
+		return nil, fmt.Errorf("file exceeds the maximum size of %d bytes", maxDevicesFileSize)
+	}
+
+	f, err := securejoin.Reopen(handle, unix.O_RDONLY)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	contents, err := io.ReadAll(io.LimitReader(f, maxDevicesFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(contents) > maxDevicesFileSize {
+		return nil, fmt.Errorf("file exceeds the maximum size of %d bytes", maxDevicesFileSize)
+	}
+	return contents, nil
+}
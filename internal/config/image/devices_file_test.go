@@ -0,0 +1,200 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package image
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVisibleDevicesFromFile(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description string
+		env         []string
+		contents    string
+		// setup replaces the device request file in the mount source
+		// with other contents if set. The specified secret is a file
+		// outside the mount that must not be read.
+		setup           func(t *testing.T, source string, secret string)
+		mountType       string
+		mountOptions    []string
+		privileged      bool
+		rejectEnvvar    bool
+		expectedError   bool
+		expectedDevices []string
+	}{
+		{
+			description:     "file is not used if unset",
+			env:             []string{"NVIDIA_VISIBLE_DEVICES=0"},
+			contents:        "1",
+			mountType:       "bind",
+			expectedDevices: []string{"0"},
+		},
+		{
+			description:     "file takes precedence over envvar",
+			env:             []string{"NVIDIA_VISIBLE_DEVICES=0", "NVIDIA_VISIBLE_DEVICES_FILE=/run/allocations/ctr"},
+			contents:        "1,2\n",
+			mountType:       "bind",
+			expectedDevices: []string{"1", "2"},
+		},
+		{
+			description:     "devices can be separated by newlines",
+			env:             []string{"NVIDIA_VISIBLE_DEVICES_FILE=/run/allocations/ctr"},
+			contents:        "GPU-1\nGPU-2\n\n",
+			mountOptions:    []string{"rbind", "ro"},
+			expectedDevices: []string{"GPU-1", "GPU-2"},
+		},
+		{
+			description:     "empty file requests no devices",
+			env:             []string{"NVIDIA_VISIBLE_DEVICES=all", "NVIDIA_VISIBLE_DEVICES_FILE=/run/allocations/ctr"},
+			mountType:       "bind",
+			expectedDevices: nil,
+		},
+		{
+			description:   "path that is not mounted returns error",
+			env:           []string{"NVIDIA_VISIBLE_DEVICES_FILE=/etc/allocation"},
+			contents:      "0",
+			mountType:     "bind",
+			expectedError: true,
+		},
+		{
+			description:   "path in non-bind mount returns error",
+			env:           []string{"NVIDIA_VISIBLE_DEVICES_FILE=/run/allocations/ctr"},
+			contents:      "0",
+			mountType:     "tmpfs",
+			expectedError: true,
+		},
+		{
+			description:   "relative path returns error",
+			env:           []string{"NVIDIA_VISIBLE_DEVICES_FILE=run/allocations/ctr"},
+			contents:      "0",
+			mountType:     "bind",
+			expectedError: true,
+		},
+		{
+			description:     "file is ignored for unprivileged container if envvars are rejected",
+			env:             []string{"NVIDIA_VISIBLE_DEVICES_FILE=/run/allocations/ctr"},
+			contents:        "0",
+			mountType:       "bind",
+			rejectEnvvar:    true,
+			expectedDevices: nil,
+		},
+		{
+			description:     "file is used for privileged container if envvars are rejected",
+			env:             []string{"NVIDIA_VISIBLE_DEVICES_FILE=/run/allocations/ctr"},
+			contents:        "0",
+			mountType:       "bind",
+			privileged:      true,
+			rejectEnvvar:    true,
+			expectedDevices: []string{"0"},
+		},
+		{
+			description: "symlink within the mount is followed",
+			env:         []string{"NVIDIA_VISIBLE_DEVICES_FILE=/run/allocations/ctr"},
+			setup: func(t *testing.T, source string, _ string) {
+				require.NoError(t, os.WriteFile(filepath.Join(source, "allocation"), []byte("0"), 0600))
+				require.NoError(t, os.Symlink("allocation", filepath.Join(source, "ctr")))
+			},
+			mountType:       "bind",
+			expectedDevices: []string{"0"},
+		},
+		{
+			description: "absolute symlink escaping the mount returns error",
+			env:         []string{"NVIDIA_VISIBLE_DEVICES_FILE=/run/allocations/ctr"},
+			setup: func(t *testing.T, source string, secret string) {
+				require.NoError(t, os.Symlink(secret, filepath.Join(source, "ctr")))
+			},
+			mountType:     "bind",
+			expectedError: true,
+		},
+		{
+			description: "relative symlink escaping the mount returns error",
+			env:         []string{"NVIDIA_VISIBLE_DEVICES_FILE=/run/allocations/ctr"},
+			setup: func(t *testing.T, source string, secret string) {
+				target, err := filepath.Rel(source, secret)
+				require.NoError(t, err)
+				require.NoError(t, os.Symlink(target, filepath.Join(source, "ctr")))
+			},
+			mountType:     "bind",
+			expectedError: true,
+		},
+		{
+			description: "directory returns error",
+			env:         []string{"NVIDIA_VISIBLE_DEVICES_FILE=/run/allocations/ctr"},
+			setup: func(t *testing.T, source string, _ string) {
+				require.NoError(t, os.Mkdir(filepath.Join(source, "ctr"), 0755))
+			},
+			mountType:     "bind",
+			expectedError: true,
+		},
+		{
+			description: "file exceeding the maximum size returns error",
+			env:         []string{"NVIDIA_VISIBLE_DEVICES_FILE=/run/allocations/ctr"},
+			setup: func(t *testing.T, source string, _ string) {
+				contents := strings.Repeat("0,", maxDevicesFileSize)
+				require.NoError(t, os.WriteFile(filepath.Join(source, "ctr"), []byte(contents), 0600))
+			},
+			mountType:     "bind",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			secret := filepath.Join(t.TempDir(), "secret")
+			require.NoError(t, os.WriteFile(secret, []byte("secret-contents"), 0600))
+
+			allocations := t.TempDir()
+			if tc.setup != nil {
+				tc.setup(t, allocations, secret)
+			} else {
+				require.NoError(t, os.WriteFile(filepath.Join(allocations, "ctr"), []byte(tc.contents), 0600))
+			}
+
+			i, err := New(
+				WithLogger(logger),
+				WithEnv(tc.env),
+				WithMounts([]specs.Mount{
+					{
+						Source:      allocations,
+						Destination: "/run/allocations",
+						Type:        tc.mountType,
+						Options:     tc.mountOptions,
+					},
+				}),
+				WithPrivileged(tc.privileged),
+				WithAcceptEnvvarUnprivileged(!tc.rejectEnvvar),
+			)
+			if tc.expectedError {
+				require.Error(t, err)
+				require.NotContains(t, err.Error(), "secret-contents")
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedDevices, i.VisibleDevices())
+		})
+	}
+}
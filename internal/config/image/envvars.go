@@ -17,15 +17,50 @@
 package image
 
 const (
-	EnvVarCudaVersion              = "CUDA_VERSION"
-	EnvVarNvidiaDisableRequire     = "NVIDIA_DISABLE_REQUIRE"
-	EnvVarNvidiaDriverCapabilities = "NVIDIA_DRIVER_CAPABILITIES"
-	EnvVarNvidiaImexChannels       = "NVIDIA_IMEX_CHANNELS"
-	EnvVarNvidiaMigConfigDevices   = "NVIDIA_MIG_CONFIG_DEVICES"
-	EnvVarNvidiaMigMonitorDevices  = "NVIDIA_MIG_MONITOR_DEVICES"
-	EnvVarNvidiaRequireCuda        = NvidiaRequirePrefix + "CUDA"
-	EnvVarNvidiaRequireJetpack     = NvidiaRequirePrefix + "JETPACK"
-	EnvVarNvidiaVisibleDevices     = "NVIDIA_VISIBLE_DEVICES"
+	EnvVarCudaVersion               = "CUDA_VERSION"
+	EnvVarNvidiaComputeMode         = "NVIDIA_COMPUTE_MODE"
+	EnvVarNvidiaDeviceOrder         = "NVIDIA_DEVICE_ORDER"
+	EnvVarNvidiaDisableRequire      = "NVIDIA_DISABLE_REQUIRE"
+	EnvVarNvidiaDriverCapabilities  = "NVIDIA_DRIVER_CAPABILITIES"
+	EnvVarNvidiaDriverVersion       = "NVIDIA_DRIVER_VERSION"
+	EnvVarNvidiaExclusiveDevices    = "NVIDIA_EXCLUSIVE_DEVICES"
+	EnvVarNvidiaGPUClocks           = "NVIDIA_GPU_CLOCKS"
+	EnvVarNvidiaGPUMemory           = "NVIDIA_GPU_MEMORY"
+	EnvVarNvidiaGPUNUMANodes        = "NVIDIA_GPU_NUMA_NODES"
+	EnvVarNvidiaGPUPowerLimit       = "NVIDIA_GPU_POWER_LIMIT"
+	EnvVarNvidiaGPUThreadPercentage = "NVIDIA_GPU_THREAD_PERCENTAGE"
+	EnvVarNvidiaGPUTopology         = "NVIDIA_GPU_TOPOLOGY"
+	EnvVarNvidiaImexChannels        = "NVIDIA_IMEX_CHANNELS"
+	EnvVarNvidiaMigConfigDevices    = "NVIDIA_MIG_CONFIG_DEVICES"
+	EnvVarNvidiaMigMonitorDevices   = "NVIDIA_MIG_MONITOR_DEVICES"
+	EnvVarNvidiaRequireCuda         = NvidiaRequirePrefix + "CUDA"
+	EnvVarNvidiaRequireJetpack      = NvidiaRequirePrefix + "JETPACK"
+	EnvVarNvidiaVisibleDevices      = "NVIDIA_VISIBLE_DEVICES"
+	EnvVarNvidiaVisibleDevicesFile  = "NVIDIA_VISIBLE_DEVICES_FILE"
 
 	NvidiaRequirePrefix = "NVIDIA_REQUIRE_"
+
+	// AnnotationDriverVersion is the annotation used to request a specific
+	// driver version for a container.
+	AnnotationDriverVersion = "nvidia.com/driver-version"
+	// AnnotationGPUPowerLimit is the annotation used to request a power limit
+	// in watts for the GPUs of a container.
+	AnnotationGPUPowerLimit = "nvidia.com/gpu-power-limit"
+	// AnnotationGPUClocks is the annotation used to request a range of GPU
+	// clocks in MHz for the GPUs of a container.
+	AnnotationGPUClocks = "nvidia.com/gpu-clocks"
+	// AnnotationGPUMemory is the annotation used to request an amount of
+	// memory for each GPU of a container.
+	AnnotationGPUMemory = "nvidia.com/gpu-memory"
+	// AnnotationGPUThreadPercentage is the annotation used to request the
+	// percentage of the threads of each GPU that are available to a
+	// container.
+	AnnotationGPUThreadPercentage = "nvidia.com/gpu-thread-percentage"
+	// AnnotationGPUSharingStrategy is the annotation used to mark a container
+	// as using GPUs that are shared with other containers. This matches the
+	// sharing strategies of the NVIDIA Kubernetes device plugin.
+	AnnotationGPUSharingStrategy = "nvidia.com/gpu-sharing-strategy"
+	// AnnotationMPSActiveThreadPercentage is the annotation used to limit the
+	// percentage of the GPU threads available to a container that uses MPS.
+	AnnotationMPSActiveThreadPercentage = "nvidia.com/mps-active-thread-percentage"
 )
@@ -0,0 +1,145 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package image
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	migProfileRequestPrefix = "mig:"
+)
+
+// A MIGDevice identifies a MIG device that is configured on the system.
+type MIGDevice struct {
+	// Index is the index of the MIG device in the form <GPU index>:<MIG index>.
+	Index string
+	UUID  string
+	// Profile is the name of the MIG profile of the device (e.g. 1g.10gb).
+	Profile string
+	// InUse indicates whether processes are running on the MIG device.
+	InUse bool
+}
+
+// A MIGDeviceLister lists the MIG devices that are configured on the system.
+// A GPULister that also implements this interface is used to resolve requests
+// for MIG devices by profile.
+type MIGDeviceLister interface {
+	MIGDevices() ([]MIGDevice, error)
+}
+
+// resolveMIGProfileRequests resolves requests of the form mig:<profile>:<N>
+// to the indices of N MIG devices with the specified profile that are not in
+// use. If <N> is omitted, a single MIG device is selected. Excluded MIG devices
+// are matched by index or UUID. If the MIG devices cannot be listed or there
+// are not enough free MIG devices, the request is returned as is so that it
+// fails when the devices are injected.
+func (i CUDA) resolveMIGProfileRequests(requested []string, excluded []string) []string {
+	if !hasMIGProfileRequest(requested) {
+		return requested
+	}
+
+	lister, ok := i.gpuLister.(MIGDeviceLister)
+	if !ok {
+		i.logger.Warningf("Unable to list MIG devices to resolve device request %v", requested)
+		return requested
+	}
+	migs, err := lister.MIGDevices()
+	if err != nil {
+		i.logger.Warningf("Failed to list MIG devices to resolve device request %v: %v", requested, err)
+		return requested
+	}
+
+	isExcluded := make(map[string]bool)
+	for _, device := range excluded {
+		isExcluded[device] = true
+	}
+	selected := make(map[string]bool)
+
+	var devices []string
+	for _, device := range requested {
+		profile, count, ok := i.parseMIGProfileRequest(device)
+		if !ok {
+			devices = append(devices, device)
+			continue
+		}
+
+		var candidates []string
+		for _, mig := range migs {
+			if len(candidates) == count {
+				break
+			}
+			if mig.Profile != profile || mig.InUse || selected[mig.Index] || isExcluded[mig.Index] || isExcluded[mig.UUID] {
+				continue
+			}
+			candidates = append(candidates, mig.Index)
+		}
+		if len(candidates) < count {
+			i.logger.Warningf("Requested %d MIG devices with profile %v but only %d are available", count, profile, len(candidates))
+			devices = append(devices, device)
+			continue
+		}
+		for _, candidate := range candidates {
+			selected[candidate] = true
+		}
+		devices = append(devices, candidates...)
+	}
+	return devices
+}
+
+// parseMIGProfileRequest returns the profile and number of devices for a
+// request of the form mig:<profile>[:<N>].
+func (i CUDA) parseMIGProfileRequest(device string) (string, int, bool) {
+	if !strings.HasPrefix(device, migProfileRequestPrefix) {
+		return "", 0, false
+	}
+	profile, countString, hasCount := strings.Cut(strings.TrimPrefix(device, migProfileRequestPrefix), ":")
+	if profile == "" {
+		i.logger.Warningf("Invalid MIG profile request %q", device)
+		return "", 0, false
+	}
+	if !hasCount {
+		return profile, 1, true
+	}
+	count, err := strconv.Atoi(countString)
+	if err != nil || count <= 0 {
+		i.logger.Warningf("Invalid MIG profile request %q", device)
+		return "", 0, false
+	}
+	return profile, count, true
+}
+
+func hasMIGProfileRequest(requested []string) bool {
+	for _, device := range requested {
+		if strings.HasPrefix(device, migProfileRequestPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindMIGDevice returns the MIG device with the specified index or UUID.
+func FindMIGDevice(migs []MIGDevice, id string) *MIGDevice {
+	for _, mig := range migs {
+		if mig.Index == id || mig.UUID == id {
+			return &mig
+		}
+	}
+	return nil
+}
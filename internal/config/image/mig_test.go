@@ -0,0 +1,111 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package image
+
+import (
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeMIGDeviceLister struct {
+	fakeGPULister
+	migs []MIGDevice
+}
+
+func (f *fakeMIGDeviceLister) MIGDevices() ([]MIGDevice, error) {
+	return f.migs, f.err
+}
+
+func TestResolveMIGProfileRequests(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	lister := &fakeMIGDeviceLister{
+		migs: []MIGDevice{
+			{Index: "0:0", UUID: "MIG-0-0", Profile: "3g.40gb"},
+			{Index: "0:1", UUID: "MIG-0-1", Profile: "1g.10gb", InUse: true},
+			{Index: "0:2", UUID: "MIG-0-2", Profile: "1g.10gb"},
+			{Index: "1:0", UUID: "MIG-1-0", Profile: "1g.10gb"},
+			{Index: "1:1", UUID: "MIG-1-1", Profile: "1g.10gb"},
+		},
+	}
+
+	testCases := []struct {
+		description     string
+		visibleDevices  string
+		gpuLister       GPULister
+		expectedDevices []string
+	}{
+		{
+			description:     "free devices with profile are selected",
+			visibleDevices:  "mig:1g.10gb:2",
+			gpuLister:       lister,
+			expectedDevices: []string{"0:2", "1:0"},
+		},
+		{
+			description:     "count defaults to one",
+			visibleDevices:  "mig:3g.40gb",
+			gpuLister:       lister,
+			expectedDevices: []string{"0:0"},
+		},
+		{
+			description:     "excluded devices are skipped",
+			visibleDevices:  "mig:1g.10gb:2,-MIG-0-2",
+			gpuLister:       lister,
+			expectedDevices: []string{"1:0", "1:1"},
+		},
+		{
+			description:     "multiple requests select distinct devices",
+			visibleDevices:  "mig:1g.10gb,mig:1g.10gb:2,0",
+			gpuLister:       lister,
+			expectedDevices: []string{"0:2", "1:0", "1:1", "0"},
+		},
+		{
+			description:     "insufficient free devices is unresolved",
+			visibleDevices:  "mig:1g.10gb:4",
+			gpuLister:       lister,
+			expectedDevices: []string{"mig:1g.10gb:4"},
+		},
+		{
+			description:     "invalid count is unresolved",
+			visibleDevices:  "mig:1g.10gb:zero",
+			gpuLister:       lister,
+			expectedDevices: []string{"mig:1g.10gb:zero"},
+		},
+		{
+			description:     "lister without MIG support is unresolved",
+			visibleDevices:  "mig:1g.10gb",
+			gpuLister:       &fakeGPULister{},
+			expectedDevices: []string{"mig:1g.10gb"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			image, err := New(
+				WithLogger(logger),
+				WithEnvMap(map[string]string{
+					EnvVarNvidiaVisibleDevices: tc.visibleDevices,
+				}),
+				WithGPULister(tc.gpuLister),
+			)
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedDevices, image.VisibleDevices())
+		})
+	}
+}
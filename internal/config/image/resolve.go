@@ -0,0 +1,305 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package image
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	deviceCountPrefix = "count:"
+)
+
+// A GPU identifies a full GPU that is available on the system.
+type GPU struct {
+	Index string
+	UUID  string
+	// Utilization is the GPU utilization in percent.
+	Utilization uint32
+	// MemoryUsed is the used device memory in bytes.
+	MemoryUsed uint64
+	// NUMANode is the NUMA node that the GPU is attached to. A negative value
+	// indicates that the NUMA node is unknown.
+	NUMANode int
+	// NVLinkPeers are the indices of the GPUs that the GPU is connected to
+	// using NVLink.
+	NVLinkPeers []string
+}
+
+// A GPULister lists the GPUs that are available on the system. This is used
+// to resolve device requests that exclude specific devices or that request a
+// number of devices.
+type GPULister interface {
+	GPUs() ([]GPU, error)
+}
+
+// isDeviceExclusion checks whether the specified device request excludes a
+// device. Exclusions are specified by prefixing the device ID with '-' or '!'
+// (e.g. all,-0 or all,!GPU-<uuid>).
+func isDeviceExclusion(device string) bool {
+	return strings.HasPrefix(device, "-") || strings.HasPrefix(device, "!")
+}
+
+// resolveDevices resolves the requested devices against the GPUs on the
+// system.
+//
+// Excluded devices are removed from the requested devices and are matched
+// by index or UUID. If all devices are requested, the request is resolved to
+// the indices of the GPUs that are not excluded. If the GPUs cannot be listed
+// in this case, no devices are returned to ensure that an excluded device is
+// never made available.
+//
+// Requests for MIG devices by profile are resolved first as described for
+// resolveMIGProfileRequests.
+//
+// A request of the form count:N is resolved to the indices of N GPUs that are
+// not excluded. GPUs in the same NVLink or NUMA domain are preferred and GPUs
+// with the lowest utilization and memory usage are selected within a domain.
+// If the GPUs cannot be listed, the first N indices that are not excluded are
+// selected.
+func (i CUDA) resolveDevices(requested []string, excluded []string) []string {
+	requested = i.resolveMIGProfileRequests(requested, excluded)
+
+	count, isCount := i.getDeviceCount(requested)
+	if len(requested) == 0 || requested[0] == "" || (!isCount && len(excluded) == 0) {
+		return requested
+	}
+
+	var gpus []GPU
+	if i.gpuLister != nil {
+		var err error
+		gpus, err = i.gpuLister.GPUs()
+		if err != nil {
+			i.logger.Warningf("Failed to list GPUs to resolve device request %v: %v", requested, err)
+			gpus = nil
+		}
+	}
+
+	isExcluded := make(map[string]bool)
+	for _, device := range excluded {
+		isExcluded[device] = true
+	}
+	matches := func(gpu GPU) bool {
+		return isExcluded[gpu.Index] || isExcluded[gpu.UUID]
+	}
+
+	switch {
+	case isCount:
+		return i.selectDevices(requested, count, gpus, isExcluded, matches)
+	case requested[0] == "all":
+		if gpus == nil {
+			i.logger.Warningf("Unable to resolve excluded devices %v; ignoring request for all devices", excluded)
+			return nil
+		}
+		var devices []string
+		for _, gpu := range gpus {
+			if matches(gpu) {
+				continue
+			}
+			devices = append(devices, gpu.Index)
+		}
+		return devices
+	}
+
+	var devices []string
+	for _, device := range requested {
+		if isExcluded[device] {
+			continue
+		}
+		if gpu := FindGPU(gpus, device); gpu != nil && matches(*gpu) {
+			continue
+		}
+		devices = append(devices, device)
+	}
+	return devices
+}
+
+// ResolvedEnvvarDevices returns the devices requested through environment
+// variables resolved against the GPUs on the system, as well as the
+// environment variables that make up the request. The boolean return value
+// indicates whether the resolved devices depend on the state of the system,
+// which is the case for count:N requests, requests for MIG devices by profile,
+// and requests that exclude devices. In this case, the request must be
+// resolved once and the resolved devices used for all subsequent processing of
+// the container so that the same devices are injected, allocated, and audited.
+// If the container does not accept device requests through environment
+// variables, no devices are returned.
+func (i CUDA) ResolvedEnvvarDevices() ([]string, []string, bool) {
+	if !i.acceptsEnvvarDeviceRequests() {
+		return nil, nil, false
+	}
+	envVars := i.visibleEnvVars()
+	requested := i.expandAliases(i.devicesFromEnvvars(envVars...))
+	resolved := i.visibleDevicesFromEnvVar()
+	if len(i.excludedDevicesFromEnvvars(envVars...)) == 0 && slices.Equal(requested, resolved) {
+		return resolved, envVars, false
+	}
+	return resolved, envVars, true
+}
+
+// getDeviceCount returns the number of devices requested if the requested
+// devices are of the form count:N. If an invalid count request is found, it is
+// returned as is so that the request fails when the devices are injected.
+func (i CUDA) getDeviceCount(requested []string) (int, bool) {
+	if len(requested) != 1 || !strings.HasPrefix(requested[0], deviceCountPrefix) {
+		return 0, false
+	}
+	count, err := strconv.Atoi(strings.TrimPrefix(requested[0], deviceCountPrefix))
+	if err != nil || count <= 0 {
+		i.logger.Warningf("Invalid device count request %q", requested[0])
+		return 0, false
+	}
+	return count, true
+}
+
+// selectDevices selects the specified number of devices from the GPUs on the
+// system that are not excluded. If there are not enough GPUs available, the
+// request is returned as is so that it fails when the devices are injected.
+func (i CUDA) selectDevices(requested []string, count int, gpus []GPU, isExcluded map[string]bool, matches func(GPU) bool) []string {
+	var devices []string
+	if gpus == nil {
+		for index := 0; len(devices) < count; index++ {
+			if isExcluded[strconv.Itoa(index)] {
+				continue
+			}
+			devices = append(devices, strconv.Itoa(index))
+		}
+		i.logger.Warningf("Unable to determine GPU usage; selecting devices %v", devices)
+		return devices
+	}
+
+	var candidates []GPU
+	for _, gpu := range gpus {
+		if matches(gpu) {
+			continue
+		}
+		candidates = append(candidates, gpu)
+	}
+	if len(candidates) < count {
+		i.logger.Warningf("Requested %d devices but only %d are available", count, len(candidates))
+		return requested
+	}
+
+	// The GPUs are listed in index order which is preserved for GPUs with the
+	// same usage.
+	sort.SliceStable(candidates, func(a, b int) bool {
+		if candidates[a].Utilization != candidates[b].Utilization {
+			return candidates[a].Utilization < candidates[b].Utilization
+		}
+		return candidates[a].MemoryUsed < candidates[b].MemoryUsed
+	})
+	for _, gpu := range selectByTopology(candidates, count) {
+		devices = append(devices, gpu.Index)
+	}
+	return devices
+}
+
+// VisibleGPUs returns the GPUs that are visible to the container in the order
+// in which they are requested. A request for all devices returns all GPUs in
+// index order. If the GPUs cannot be listed or a visible device is not a full
+// GPU (e.g. a MIG device), nil is returned.
+func (i CUDA) VisibleGPUs() []GPU {
+	if i.gpuLister == nil {
+		return nil
+	}
+	gpus, err := i.gpuLister.GPUs()
+	if err != nil {
+		return nil
+	}
+
+	var visible []GPU
+	for _, device := range i.VisibleDevices() {
+		if device == "all" {
+			visible = append(visible, gpus...)
+			continue
+		}
+		gpu := FindGPU(gpus, device)
+		if gpu == nil {
+			return nil
+		}
+		visible = append(visible, *gpu)
+	}
+	return visible
+}
+
+// A DeviceDetails describes a GPU or MIG device that is visible to a
+// container.
+type DeviceDetails struct {
+	// HostIndex is the index of the device on the host. For MIG devices this
+	// has the form <GPU index>:<MIG index>.
+	HostIndex string
+	UUID      string
+	// ParentUUID is the UUID of the parent GPU of a MIG device.
+	ParentUUID string
+}
+
+// VisibleDeviceDetails returns the details of the GPUs and MIG devices that
+// are visible to the container in the order in which they are requested. If
+// the devices cannot be listed or a visible device is not known, nil is
+// returned.
+func (i CUDA) VisibleDeviceDetails() []DeviceDetails {
+	if i.gpuLister == nil {
+		return nil
+	}
+	gpus, err := i.gpuLister.GPUs()
+	if err != nil {
+		return nil
+	}
+	var migs []MIGDevice
+	if lister, ok := i.gpuLister.(MIGDeviceLister); ok {
+		migs, _ = lister.MIGDevices()
+	}
+
+	var details []DeviceDetails
+	for _, device := range i.VisibleDevices() {
+		if device == "all" {
+			for _, gpu := range gpus {
+				details = append(details, DeviceDetails{HostIndex: gpu.Index, UUID: gpu.UUID})
+			}
+			continue
+		}
+		if gpu := FindGPU(gpus, device); gpu != nil {
+			details = append(details, DeviceDetails{HostIndex: gpu.Index, UUID: gpu.UUID})
+			continue
+		}
+		mig := FindMIGDevice(migs, device)
+		if mig == nil {
+			return nil
+		}
+		d := DeviceDetails{HostIndex: mig.Index, UUID: mig.UUID}
+		parentIndex, _, _ := strings.Cut(mig.Index, ":")
+		if parent := FindGPU(gpus, parentIndex); parent != nil {
+			d.ParentUUID = parent.UUID
+		}
+		details = append(details, d)
+	}
+	return details
+}
+
+// FindGPU returns the GPU with the specified index or UUID.
+func FindGPU(gpus []GPU, id string) *GPU {
+	for _, gpu := range gpus {
+		if gpu.Index == id || gpu.UUID == id {
+			return &gpu
+		}
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package image
+
+import (
+	"fmt"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeGPULister struct {
+	gpus []GPU
+	err  error
+}
+
+func (f *fakeGPULister) GPUs() ([]GPU, error) {
+	return f.gpus, f.err
+}
+
+func TestResolveVisibleDevices(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	gpus := []GPU{
+		{Index: "0", UUID: "GPU-0"},
+		{Index: "1", UUID: "GPU-1"},
+		{Index: "2", UUID: "GPU-2"},
+	}
+	usedGPUs := []GPU{
+		{Index: "0", UUID: "GPU-0", Utilization: 10, MemoryUsed: 2048},
+		{Index: "1", UUID: "GPU-1", Utilization: 10, MemoryUsed: 4096},
+		{Index: "2", UUID: "GPU-2", Utilization: 0, MemoryUsed: 8192},
+	}
+
+	testCases := []struct {
+		description     string
+		visibleDevices  string
+		gpuLister       GPULister
+		expectedDevices []string
+	}{
+		{
+			description:     "no exclusions returns all",
+			visibleDevices:  "all",
+			gpuLister:       &fakeGPULister{gpus: gpus},
+			expectedDevices: []string{"all"},
+		},
+		{
+			description:     "all with excluded index",
+			visibleDevices:  "all,-0",
+			gpuLister:       &fakeGPULister{gpus: gpus},
+			expectedDevices: []string{"1", "2"},
+		},
+		{
+			description:     "all with excluded UUID",
+			visibleDevices:  "all,!GPU-1",
+			gpuLister:       &fakeGPULister{gpus: gpus},
+			expectedDevices: []string{"0", "2"},
+		},
+		{
+			description:     "explicit devices with excluded UUID matched by index",
+			visibleDevices:  "0,1, !GPU-1",
+			gpuLister:       &fakeGPULister{gpus: gpus},
+			expectedDevices: []string{"0"},
+		},
+		{
+			description:     "explicit devices without lister",
+			visibleDevices:  "GPU-0,GPU-2,-GPU-2",
+			expectedDevices: []string{"GPU-0"},
+		},
+		{
+			description:    "all excluded returns nil",
+			visibleDevices: "all,-0,-1,-2",
+			gpuLister:      &fakeGPULister{gpus: gpus},
+		},
+		{
+			description:    "all without lister returns nil",
+			visibleDevices: "all,-0",
+		},
+		{
+			description:    "lister error returns nil",
+			visibleDevices: "all,-0",
+			gpuLister:      &fakeGPULister{err: fmt.Errorf("nvml error")},
+		},
+		{
+			description:     "count selects least used devices",
+			visibleDevices:  "count:2",
+			gpuLister:       &fakeGPULister{gpus: usedGPUs},
+			expectedDevices: []string{"2", "0"},
+		},
+		{
+			description:     "count with exclusion",
+			visibleDevices:  "count:2,-2",
+			gpuLister:       &fakeGPULister{gpus: usedGPUs},
+			expectedDevices: []string{"0", "1"},
+		},
+		{
+			description:     "count with equal usage uses index order",
+			visibleDevices:  "count:2",
+			gpuLister:       &fakeGPULister{gpus: gpus},
+			expectedDevices: []string{"0", "1"},
+		},
+		{
+			description:     "count without lister selects first indices",
+			visibleDevices:  "count:2,-0",
+			expectedDevices: []string{"1", "2"},
+		},
+		{
+			description:     "count larger than available devices is unresolved",
+			visibleDevices:  "count:4",
+			gpuLister:       &fakeGPULister{gpus: gpus},
+			expectedDevices: []string{"count:4"},
+		},
+		{
+			description:     "invalid count is unresolved",
+			visibleDevices:  "count:zero",
+			gpuLister:       &fakeGPULister{gpus: gpus},
+			expectedDevices: []string{"count:zero"},
+		},
+		{
+			description:     "none is not affected by exclusions",
+			visibleDevices:  "none,-0",
+			gpuLister:       &fakeGPULister{gpus: gpus},
+			expectedDevices: []string{""},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			image, err := New(
+				WithLogger(logger),
+				WithEnvMap(map[string]string{
+					EnvVarNvidiaVisibleDevices: tc.visibleDevices,
+				}),
+				WithGPULister(tc.gpuLister),
+			)
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedDevices, image.VisibleDevices())
+		})
+	}
+}
+
+func TestResolvedEnvvarDevices(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	gpus := []GPU{
+		{Index: "0", UUID: "GPU-0"},
+		{Index: "1", UUID: "GPU-1"},
+	}
+
+	testCases := []struct {
+		description        string
+		env                map[string]string
+		expectedDevices    []string
+		expectedEnvVars    []string
+		expectedIsDynamic  bool
+		rejectUnprivileged bool
+	}{
+		{
+			description:     "explicit devices are not dynamic",
+			env:             map[string]string{EnvVarNvidiaVisibleDevices: "1"},
+			expectedDevices: []string{"1"},
+			expectedEnvVars: []string{EnvVarNvidiaVisibleDevices},
+		},
+		{
+			description:     "all is not dynamic",
+			env:             map[string]string{EnvVarNvidiaVisibleDevices: "all"},
+			expectedDevices: []string{"all"},
+			expectedEnvVars: []string{EnvVarNvidiaVisibleDevices},
+		},
+		{
+			description:       "count is dynamic",
+			env:               map[string]string{EnvVarNvidiaVisibleDevices: "count:1"},
+			expectedDevices:   []string{"0"},
+			expectedEnvVars:   []string{EnvVarNvidiaVisibleDevices},
+			expectedIsDynamic: true,
+		},
+		{
+			description:       "exclusion is dynamic",
+			env:               map[string]string{EnvVarNvidiaVisibleDevices: "all,-0"},
+			expectedDevices:   []string{"1"},
+			expectedEnvVars:   []string{EnvVarNvidiaVisibleDevices},
+			expectedIsDynamic: true,
+		},
+		{
+			description:        "unprivileged request is ignored",
+			env:                map[string]string{EnvVarNvidiaVisibleDevices: "count:1"},
+			rejectUnprivileged: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			image, err := New(
+				WithLogger(logger),
+				WithEnvMap(tc.env),
+				WithGPULister(&fakeGPULister{gpus: gpus}),
+				WithAcceptEnvvarUnprivileged(!tc.rejectUnprivileged),
+			)
+			require.NoError(t, err)
+			devices, envVars, isDynamic := image.ResolvedEnvvarDevices()
+			require.EqualValues(t, tc.expectedDevices, devices)
+			require.EqualValues(t, tc.expectedEnvVars, envVars)
+			require.Equal(t, tc.expectedIsDynamic, isDynamic)
+		})
+	}
+}
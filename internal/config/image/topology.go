@@ -0,0 +1,165 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package image
+
+import (
+	"sort"
+)
+
+// A TopologyDomain describes the closest interconnect shared by a set of GPUs.
+type TopologyDomain string
+
+const (
+	// TopologyDomainNVLink indicates that the GPUs are connected using NVLink.
+	TopologyDomainNVLink = TopologyDomain("nvlink")
+	// TopologyDomainNUMA indicates that the GPUs are attached to the same NUMA
+	// node.
+	TopologyDomainNUMA = TopologyDomain("numa")
+	// TopologyDomainSystem indicates that the GPUs only share the system
+	// interconnect.
+	TopologyDomainSystem = TopologyDomain("system")
+)
+
+// A GPUTopology describes the topology of the GPUs selected for a container.
+type GPUTopology struct {
+	Domain    TopologyDomain
+	NUMANodes []int
+}
+
+// SelectedGPUTopology returns the topology of the GPUs that were selected for
+// a count-based device request. If the container does not request a number of
+// devices or if the topology of the selected GPUs is not known, nil is
+// returned.
+func (i CUDA) SelectedGPUTopology() *GPUTopology {
+	if _, isCount := i.getDeviceCount(i.devicesFromEnvvars(i.visibleEnvVars()...)); !isCount || i.gpuLister == nil {
+		return nil
+	}
+	selected := i.VisibleGPUs()
+	if len(selected) == 0 {
+		return nil
+	}
+	return getGPUTopology(selected)
+}
+
+// selectByTopology selects the specified number of GPUs from the candidates.
+// The candidates are expected to be sorted by usage. GPUs that are connected
+// using NVLink are preferred, followed by GPUs on the same NUMA node. If the
+// requested number of GPUs does not fit into a single domain, the least used
+// GPUs are selected.
+func selectByTopology(candidates []GPU, count int) []GPU {
+	if count == 1 {
+		return candidates[:1]
+	}
+	for _, domains := range [][][]GPU{nvlinkDomains(candidates), numaDomains(candidates)} {
+		for _, domain := range domains {
+			if len(domain) >= count {
+				return domain[:count]
+			}
+		}
+	}
+	return candidates[:count]
+}
+
+// nvlinkDomains groups the specified GPUs by the sets of GPUs that are
+// connected using NVLink. The order of the GPUs is preserved within a domain
+// and the domains are ordered by their first GPU.
+func nvlinkDomains(gpus []GPU) [][]GPU {
+	position := make(map[string]int)
+	for p, gpu := range gpus {
+		position[gpu.Index] = p
+	}
+
+	visited := make(map[int]bool)
+	var domains [][]GPU
+	for p := range gpus {
+		if visited[p] {
+			continue
+		}
+		visited[p] = true
+		members := []int{p}
+		for queue := []int{p}; len(queue) > 0; queue = queue[1:] {
+			for _, peer := range gpus[queue[0]].NVLinkPeers {
+				q, ok := position[peer]
+				if !ok || visited[q] {
+					continue
+				}
+				visited[q] = true
+				members = append(members, q)
+				queue = append(queue, q)
+			}
+		}
+		sort.Ints(members)
+
+		var domain []GPU
+		for _, m := range members {
+			domain = append(domain, gpus[m])
+		}
+		domains = append(domains, domain)
+	}
+	return domains
+}
+
+// numaDomains groups the specified GPUs by NUMA node. GPUs with an unknown
+// NUMA node are not included in any domain.
+func numaDomains(gpus []GPU) [][]GPU {
+	domain := make(map[int]int)
+	var domains [][]GPU
+	for _, gpu := range gpus {
+		if gpu.NUMANode < 0 {
+			continue
+		}
+		d, ok := domain[gpu.NUMANode]
+		if !ok {
+			d = len(domains)
+			domain[gpu.NUMANode] = d
+			domains = append(domains, nil)
+		}
+		domains[d] = append(domains[d], gpu)
+	}
+	return domains
+}
+
+// getGPUTopology returns the closest domain shared by the specified GPUs as
+// well as the NUMA nodes that the GPUs are attached to.
+func getGPUTopology(gpus []GPU) *GPUTopology {
+	t := &GPUTopology{
+		Domain: TopologyDomainSystem,
+	}
+
+	seen := make(map[int]bool)
+	unknownNUMANode := false
+	for _, gpu := range gpus {
+		if gpu.NUMANode < 0 {
+			unknownNUMANode = true
+			continue
+		}
+		if !seen[gpu.NUMANode] {
+			seen[gpu.NUMANode] = true
+			t.NUMANodes = append(t.NUMANodes, gpu.NUMANode)
+		}
+	}
+	sort.Ints(t.NUMANodes)
+
+	switch {
+	case len(nvlinkDomains(gpus)) == 1 && len(gpus) > 1:
+		t.Domain = TopologyDomainNVLink
+	case len(t.NUMANodes) == 1 && !unknownNUMANode:
+		t.Domain = TopologyDomainNUMA
+	}
+	return t
+}
@@ -0,0 +1,99 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package image
+
+import (
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectedGPUTopology(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	gpus := []GPU{
+		{Index: "0", UUID: "GPU-0", Utilization: 5, NUMANode: 0, NVLinkPeers: []string{"1"}},
+		{Index: "1", UUID: "GPU-1", Utilization: 20, NUMANode: 0, NVLinkPeers: []string{"0"}},
+		{Index: "2", UUID: "GPU-2", Utilization: 0, NUMANode: 1},
+		{Index: "3", UUID: "GPU-3", Utilization: 10, NUMANode: 1},
+	}
+
+	testCases := []struct {
+		description      string
+		visibleDevices   string
+		expectedDevices  []string
+		expectedTopology *GPUTopology
+	}{
+		{
+			description:     "NVLink connected GPUs are preferred",
+			visibleDevices:  "count:2",
+			expectedDevices: []string{"0", "1"},
+			expectedTopology: &GPUTopology{
+				Domain:    TopologyDomainNVLink,
+				NUMANodes: []int{0},
+			},
+		},
+		{
+			description:     "GPUs on the same NUMA node are preferred",
+			visibleDevices:  "count:2,-0",
+			expectedDevices: []string{"2", "3"},
+			expectedTopology: &GPUTopology{
+				Domain:    TopologyDomainNUMA,
+				NUMANodes: []int{1},
+			},
+		},
+		{
+			description:     "least used GPUs are selected across domains",
+			visibleDevices:  "count:3",
+			expectedDevices: []string{"2", "0", "3"},
+			expectedTopology: &GPUTopology{
+				Domain:    TopologyDomainSystem,
+				NUMANodes: []int{0, 1},
+			},
+		},
+		{
+			description:     "single GPU selects least used",
+			visibleDevices:  "count:1",
+			expectedDevices: []string{"2"},
+			expectedTopology: &GPUTopology{
+				Domain:    TopologyDomainNUMA,
+				NUMANodes: []int{1},
+			},
+		},
+		{
+			description:     "no topology for explicit devices",
+			visibleDevices:  "0,1",
+			expectedDevices: []string{"0", "1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			image, err := New(
+				WithLogger(logger),
+				WithEnvMap(map[string]string{
+					EnvVarNvidiaVisibleDevices: tc.visibleDevices,
+				}),
+				WithGPULister(&fakeGPULister{gpus: gpus}),
+			)
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedDevices, image.VisibleDevices())
+			require.EqualValues(t, tc.expectedTopology, image.SelectedGPUTopology())
+		})
+	}
+}
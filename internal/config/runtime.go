@@ -16,6 +16,8 @@
 
 package config
 
+import "github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+
 // RuntimeConfig stores the config options for the NVIDIA Container Runtime
 type RuntimeConfig struct {
 	DebugFilePath string `toml:"debug"`
@@ -25,6 +27,42 @@ type RuntimeConfig struct {
 	Runtimes []string    `toml:"runtimes"`
 	Mode     string      `toml:"mode"`
 	Modes    modesConfig `toml:"modes"`
+	// AuditLogFilePath is the path of a file to which an entry is appended for
+	// each container created by the NVIDIA Container Runtime. If this is not
+	// set, no audit log is written.
+	AuditLogFilePath string `toml:"audit-log,omitempty"`
+	// AllocationLedgerPath is the path of the ledger used to ensure that GPUs
+	// requested exclusively (NVIDIA_EXCLUSIVE_DEVICES=true) are not made
+	// available to more than one such container. If this is not set,
+	// exclusive requests are not enforced.
+	AllocationLedgerPath string `toml:"allocation-ledger,omitempty"`
+	// DeviceAliasesPath is the path of a file mapping friendly names to sets
+	// of devices. These names can be used instead of device IDs in device
+	// requests. If this is not set, no aliases are defined.
+	DeviceAliasesPath string `toml:"device-aliases,omitempty"`
+	// DeviceOrder sets the default order in which CUDA enumerates the GPUs
+	// in a container. This can be overridden per container using the
+	// NVIDIA_DEVICE_ORDER envvar. If this is not set, the CUDA default is used.
+	DeviceOrder deviceOrder `toml:"device-order,omitempty"`
+	// MPSPipeDirectory is the pipe directory of the CUDA MPS control daemon on
+	// the host. This is mounted into containers that are marked as using MPS
+	// for GPU sharing. If this is not set, /tmp/nvidia-mps is used.
+	MPSPipeDirectory string `toml:"mps-pipe-directory,omitempty"`
+	// DriverVersionMismatch controls how containers that request devices are
+	// handled if the version of the user-space driver libraries does not
+	// match the version of the loaded kernel module, as is the case while the
+	// driver is upgraded in place. If this is not set, a warning is logged.
+	DriverVersionMismatch driverVersionMismatch `toml:"driver-version-mismatch,omitempty"`
+	// EnvSanitization controls how the library search path envvars set in a
+	// container (e.g. LD_LIBRARY_PATH) are handled if these conflict with the
+	// injected driver libraries. If this is not set, the envvars are not
+	// modified.
+	EnvSanitization envSanitization `toml:"env-sanitization,omitempty"`
+	// Plugins lists the paths of executables that are invoked to add
+	// site-specific container edits for containers that request GPUs. The
+	// plugins are also invoked when generating CDI specifications. See the
+	// pkg/plugin package for a description of the protocol.
+	Plugins []string `toml:"plugins,omitempty"`
 }
 
 // modesConfig defines (optional) per-mode configs
@@ -41,6 +79,20 @@ type cdiModeConfig struct {
 	DefaultKind string `toml:"default-kind"`
 	// AnnotationPrefixes sets the allowed prefixes for CDI annotation-based device injection
 	AnnotationPrefixes []string `toml:"annotation-prefixes"`
+	// DeviceRequestPrecedence determines whether the devices requested in CDI
+	// annotations or those requested in NVIDIA_VISIBLE_DEVICES are used if
+	// both are present. One of annotations (the default) or envvar. A warning
+	// is logged if the requested devices differ.
+	DeviceRequestPrecedence image.DeviceRequestPrecedence `toml:"device-request-precedence,omitempty"`
+	// MinimalDriverFiles indicates whether only the CUDA driver library and
+	// NVML are injected for automatically generated CDI devices (e.g.
+	// runtime.nvidia.com/gpu=all). The driver binaries such as nvidia-smi are
+	// not injected.
+	MinimalDriverFiles bool `toml:"minimal-driver-files,omitempty"`
+	// NvidiaSMILitePath is the path of the nvidia-smi-lite executable that is
+	// injected for automatically generated CDI devices. If this is not set,
+	// nvidia-smi-lite is not injected.
+	NvidiaSMILitePath string `toml:"nvidia-smi-lite-path,omitempty"`
 }
 
 type csvModeConfig struct {
@@ -53,6 +105,51 @@ type legacyModeConfig struct {
 	CUDACompatMode cudaCompatMode `toml:"cuda-compat-mode,omitempty"`
 }
 
+type deviceOrder string
+
+const (
+	// DeviceOrderHostIndex orders the GPUs in a container by their index on
+	// the host. CUDA_VISIBLE_DEVICES is set to the UUIDs of the GPUs so that
+	// the CUDA index of a GPU in the container matches the relative order of
+	// the host indices.
+	DeviceOrderHostIndex = deviceOrder("host-index")
+	// DeviceOrderPCI orders the GPUs in a container by PCI bus ID by setting
+	// CUDA_DEVICE_ORDER=PCI_BUS_ID.
+	DeviceOrderPCI = deviceOrder("pci")
+	// DeviceOrderFastestFirst orders the GPUs in a container with the fastest
+	// GPU first by setting CUDA_DEVICE_ORDER=FASTEST_FIRST.
+	DeviceOrderFastestFirst = deviceOrder("fastest-first")
+)
+
+type envSanitization string
+
+const (
+	// EnvSanitizationDisabled leaves the envvars of a container unchanged.
+	EnvSanitizationDisabled = envSanitization("disabled")
+	// EnvSanitizationNormalize removes the entries that refer to driver
+	// libraries included in the container image from the library search path
+	// envvars. The remaining entries are kept.
+	EnvSanitizationNormalize = envSanitization("normalize")
+	// EnvSanitizationStrip removes library search path envvars that refer to
+	// driver libraries included in the container image entirely.
+	EnvSanitizationStrip = envSanitization("strip")
+)
+
+type driverVersionMismatch string
+
+const (
+	// DriverVersionMismatchWarn logs a warning and injects the driver as is.
+	DriverVersionMismatchWarn = driverVersionMismatch("warn")
+	// DriverVersionMismatchBlock fails the creation of containers that
+	// request devices.
+	DriverVersionMismatchBlock = driverVersionMismatch("block")
+	// DriverVersionMismatchCompat injects the user-space driver libraries
+	// matching the kernel module if these are installed in a versioned
+	// directory (e.g. /usr/lib/nvidia-550). Container creation fails
+	// otherwise.
+	DriverVersionMismatchCompat = driverVersionMismatch("compat")
+)
+
 type cudaCompatMode string
 
 const (
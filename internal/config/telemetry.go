@@ -0,0 +1,33 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package config
+
+// telemetryConfig controls the opt-in usage reporting of the NVIDIA Container
+// Toolkit. Reporting is disabled by default.
+type telemetryConfig struct {
+	// Enabled indicates whether anonymized usage reports are sent.
+	Enabled bool `toml:"enabled,omitempty"`
+	// Endpoint is the URL to which usage reports are posted.
+	Endpoint string `toml:"endpoint,omitempty"`
+}
+
+// IsEnabled returns true if usage reporting has been explicitly enabled and
+// an endpoint is configured.
+func (c telemetryConfig) IsEnabled() bool {
+	return c.Enabled && c.Endpoint != ""
+}
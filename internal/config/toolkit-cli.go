@@ -19,4 +19,13 @@ package config
 // CTKConfig stores the config options for the NVIDIA Container Toolkit CLI (nvidia-ctk)
 type CTKConfig struct {
 	Path string `toml:"path"`
+	// CDIOutputDir specifies a CDI spec directory that is shared with other
+	// vendors to which generated CDI specs are written. Specs are named by
+	// vendor and class and specs for other vendors are not modified.
+	// This is not exposed in the config if not set.
+	CDIOutputDir string `toml:"cdi-output-dir,omitempty"`
+	// CDIDisabledHooks specifies the hooks that are not included in generated
+	// CDI specs (e.g. update-ldcache if the ldcache in containers is managed
+	// separately). This is not exposed in the config if not set.
+	CDIDisabledHooks []string `toml:"cdi-disabled-hooks,omitempty"`
 }
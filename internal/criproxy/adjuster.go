@@ -0,0 +1,254 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package criproxy
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/pkg/parser"
+	cdispecs "tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+)
+
+// An Adjuster adds the CDI devices requested for a container to the
+// CreateContainer requests intercepted by a CRI proxy between the kubelet and
+// the CRI socket of the container engine. The devices are requested by a
+// device plugin (or DRA driver) as the result of the resource requests of a
+// pod and are resolved using the CDI specs on the node. This allows the
+// devices to be injected on engines that cannot be configured to support CDI
+// or the NVIDIA Container Runtime.
+type Adjuster struct {
+	logger logger.Interface
+	cfg    *config.Config
+	cache  *cdi.Cache
+
+	gpuLister image.GPULister
+}
+
+// New creates an adjuster with the specified options.
+func New(opts ...Option) (*Adjuster, error) {
+	a := &Adjuster{}
+	for _, opt := range opts {
+		opt(a)
+	}
+	if a.logger == nil {
+		a.logger = logger.New()
+	}
+	if a.cfg == nil {
+		cfg, err := config.GetDefault()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get default config: %w", err)
+		}
+		a.cfg = cfg
+	}
+
+	cache, err := cdi.NewCache(
+		cdi.WithAutoRefresh(false),
+		cdi.WithSpecDirs(a.cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.SpecDirs...),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CDI registry: %w", err)
+	}
+	a.cache = cache
+
+	if a.gpuLister == nil {
+		driver := root.New(
+			root.WithLogger(a.logger),
+			root.WithDriverRoot(a.cfg.NVIDIAContainerCLIConfig.Root),
+		)
+		a.gpuLister = info.NewGPULister(a.logger, driver)
+	}
+
+	return a, nil
+}
+
+// Adjust adds the device nodes, mounts, and environment variables for the
+// CDI devices requested in the specified container config. Devices are
+// requested using the CDIDevices field, CDI annotations, or the
+// NVIDIA_VISIBLE_DEVICES environment variable. Devices that are requested in
+// the environment without a CDI kind are skipped if they cannot be resolved,
+// while other unresolvable devices are an error. Since the CDIDevices field may
+// not be supported by the container engine, it is cleared once the devices
+// have been added. CDI hooks cannot be expressed in a CRI container config
+// and are skipped.
+func (a *Adjuster) Adjust(c *ContainerConfig) error {
+	if c == nil {
+		return nil
+	}
+
+	devices, err := a.getDeviceRequests(c)
+	if err != nil {
+		return err
+	}
+	if len(devices) == 0 {
+		return nil
+	}
+
+	// The CDI specs are refreshed for each request to pick up specs that
+	// were generated after the proxy was started.
+	if err := a.cache.Refresh(); err != nil {
+		a.logger.Warningf("Errors refreshing CDI registry: %v", err)
+	}
+
+	var edits []*cdispecs.ContainerEdits
+	specEdits := make(map[*cdi.Spec]bool)
+	for _, request := range devices {
+		device := a.cache.GetDevice(request.name)
+		if device == nil && request.isDefaultKind {
+			// The environment of a container may request devices that are
+			// not managed by the proxy (e.g. if it is set in the image). We
+			// therefore only fail for devices that were explicitly requested
+			// as CDI devices.
+			a.logger.Warningf("Ignoring unresolvable device %v", request.name)
+			continue
+		}
+		if device == nil {
+			return fmt.Errorf("unresolvable CDI device %v", request.name)
+		}
+		if spec := device.GetSpec(); !specEdits[spec] {
+			specEdits[spec] = true
+			edits = append(edits, &spec.ContainerEdits)
+		}
+		edits = append(edits, &device.ContainerEdits)
+	}
+
+	a.apply(c, edits...)
+	c.CDIDevices = nil
+
+	return nil
+}
+
+// A deviceRequest is a request for a fully-qualified CDI device.
+type deviceRequest struct {
+	name string
+	// isDefaultKind indicates that the device was requested in the
+	// environment without a kind and was qualified using the default kind.
+	isDefaultKind bool
+}
+
+// getDeviceRequests returns the fully-qualified CDI device names requested
+// for the container.
+func (a *Adjuster) getDeviceRequests(c *ContainerConfig) ([]deviceRequest, error) {
+	env := make(map[string]string)
+	for _, kv := range c.Envs {
+		env[kv.Key] = kv.Value
+	}
+
+	i, err := image.New(
+		image.WithLogger(a.logger),
+		image.WithEnvMap(env),
+		image.WithAnnotations(c.Annotations),
+		image.WithAnnotationsPrefixes(a.cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.AnnotationPrefixes),
+		image.WithDeviceRequestPrecedence(a.cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.DeviceRequestPrecedence),
+		image.WithAcceptEnvvarUnprivileged(a.cfg.AcceptEnvvarUnprivileged),
+		image.WithPrivileged(c.Privileged),
+		image.WithGPULister(a.gpuLister),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct container image: %w", err)
+	}
+
+	var devices []deviceRequest
+	add := func(request deviceRequest) {
+		if slices.ContainsFunc(devices, func(d deviceRequest) bool { return d.name == request.name }) {
+			return
+		}
+		devices = append(devices, request)
+	}
+	for _, name := range c.CDIDevices {
+		if !parser.IsQualifiedName(name) {
+			name = fmt.Sprintf("%s=%s", a.cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.DefaultKind, name)
+		}
+		add(deviceRequest{name: name})
+	}
+	for _, name := range i.VisibleDevices() {
+		if name == "" {
+			continue
+		}
+		if parser.IsQualifiedName(name) {
+			add(deviceRequest{name: name})
+			continue
+		}
+		add(deviceRequest{
+			name:          fmt.Sprintf("%s=%s", a.cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.DefaultKind, name),
+			isDefaultKind: true,
+		})
+	}
+	return devices, nil
+}
+
+// apply adds the specified CDI container edits to the container config.
+func (a *Adjuster) apply(c *ContainerConfig, edits ...*cdispecs.ContainerEdits) {
+	for _, e := range edits {
+		for _, env := range e.Env {
+			c.setEnv(env)
+		}
+		for _, dn := range e.DeviceNodes {
+			if slices.ContainsFunc(c.Devices, func(existing Device) bool { return existing.ContainerPath == dn.Path }) {
+				continue
+			}
+			hostPath := dn.HostPath
+			if hostPath == "" {
+				hostPath = dn.Path
+			}
+			permissions := dn.Permissions
+			if permissions == "" {
+				permissions = "rwm"
+			}
+			c.Devices = append(c.Devices, Device{
+				ContainerPath: dn.Path,
+				HostPath:      hostPath,
+				Permissions:   permissions,
+			})
+		}
+		for _, m := range e.Mounts {
+			if slices.ContainsFunc(c.Mounts, func(existing Mount) bool { return existing.ContainerPath == m.ContainerPath }) {
+				continue
+			}
+			c.Mounts = append(c.Mounts, Mount{
+				ContainerPath: m.ContainerPath,
+				HostPath:      m.HostPath,
+				Readonly:      slices.Contains(m.Options, "ro"),
+			})
+		}
+		for _, h := range e.Hooks {
+			a.logger.Warningf("Skipping %v hook %v %v; hooks cannot be added through the CRI", h.HookName, h.Path, h.Args)
+		}
+	}
+}
+
+// setEnv sets the specified KEY=VALUE environment variable, replacing an
+// existing value for the same key.
+func (c *ContainerConfig) setEnv(e string) {
+	key, value, _ := strings.Cut(e, "=")
+	for i := range c.Envs {
+		if c.Envs[i].Key == key {
+			c.Envs[i].Value = value
+			return
+		}
+	}
+	c.Envs = append(c.Envs, KeyValue{Key: key, Value: value})
+}
@@ -0,0 +1,164 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package criproxy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+)
+
+const testSpec = `---
+cdiVersion: 0.5.0
+kind: nvidia.com/gpu
+devices:
+- name: "0"
+  containerEdits:
+    deviceNodes:
+    - path: /dev/nvidia0
+      hostPath: /driver/root/dev/nvidia0
+containerEdits:
+  env:
+  - NVIDIA_VISIBLE_DEVICES=void
+  deviceNodes:
+  - path: /dev/nvidiactl
+  mounts:
+  - hostPath: /usr/lib/libcuda.so.1
+    containerPath: /usr/lib/libcuda.so.1
+    options: ["ro", "nosuid", "nodev", "bind"]
+  hooks:
+  - hookName: createContainer
+    path: /usr/bin/nvidia-cdi-hook
+    args: ["nvidia-cdi-hook", "update-ldcache"]
+`
+
+func TestAdjust(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	specDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "nvidia.yaml"), []byte(testSpec), 0600))
+
+	cfg, err := config.GetDefault()
+	require.NoError(t, err)
+	cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.SpecDirs = []string{specDir}
+
+	adjusted := &ContainerConfig{
+		Envs: []KeyValue{
+			{Key: "NVIDIA_VISIBLE_DEVICES", Value: "void"},
+		},
+		Devices: []Device{
+			{ContainerPath: "/dev/nvidiactl", HostPath: "/dev/nvidiactl", Permissions: "rwm"},
+			{ContainerPath: "/dev/nvidia0", HostPath: "/driver/root/dev/nvidia0", Permissions: "rwm"},
+		},
+		Mounts: []Mount{
+			{ContainerPath: "/usr/lib/libcuda.so.1", HostPath: "/usr/lib/libcuda.so.1", Readonly: true},
+		},
+	}
+
+	testCases := []struct {
+		description    string
+		config         *ContainerConfig
+		expectedError  bool
+		expectedConfig *ContainerConfig
+	}{
+		{
+			description:    "no requested devices is a no-op",
+			config:         &ContainerConfig{Envs: []KeyValue{{Key: "FOO", Value: "bar"}}},
+			expectedConfig: &ContainerConfig{Envs: []KeyValue{{Key: "FOO", Value: "bar"}}},
+		},
+		{
+			description: "devices requested by envvar are added",
+			config: &ContainerConfig{
+				Envs: []KeyValue{{Key: "NVIDIA_VISIBLE_DEVICES", Value: "0"}},
+			},
+			expectedConfig: adjusted,
+		},
+		{
+			description: "CDI devices are added and cleared",
+			config: &ContainerConfig{
+				CDIDevices: []string{"nvidia.com/gpu=0"},
+			},
+			expectedConfig: adjusted,
+		},
+		{
+			description: "devices requested by annotation are added",
+			config: &ContainerConfig{
+				Annotations: map[string]string{"cdi.k8s.io/gpu": "nvidia.com/gpu=0"},
+			},
+			expectedConfig: &ContainerConfig{
+				Annotations: map[string]string{"cdi.k8s.io/gpu": "nvidia.com/gpu=0"},
+				Envs:        adjusted.Envs,
+				Devices:     adjusted.Devices,
+				Mounts:      adjusted.Mounts,
+			},
+		},
+		{
+			description: "unresolvable device returns error",
+			config: &ContainerConfig{
+				CDIDevices: []string{"nvidia.com/gpu=1"},
+			},
+			expectedError: true,
+		},
+		{
+			description: "unresolvable qualified envvar device returns error",
+			config: &ContainerConfig{
+				Envs: []KeyValue{{Key: "NVIDIA_VISIBLE_DEVICES", Value: "nvidia.com/gpu=1"}},
+			},
+			expectedError: true,
+		},
+		{
+			description: "unresolvable unqualified envvar device is skipped",
+			config: &ContainerConfig{
+				Envs: []KeyValue{{Key: "NVIDIA_VISIBLE_DEVICES", Value: "0,GPU-unknown"}},
+			},
+			expectedConfig: adjusted,
+		},
+		{
+			description: "only unresolvable unqualified envvar devices is a no-op",
+			config: &ContainerConfig{
+				Envs: []KeyValue{{Key: "NVIDIA_VISIBLE_DEVICES", Value: "all"}},
+			},
+			expectedConfig: &ContainerConfig{
+				Envs: []KeyValue{{Key: "NVIDIA_VISIBLE_DEVICES", Value: "all"}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			a, err := New(
+				WithLogger(logger),
+				WithConfig(cfg),
+			)
+			require.NoError(t, err)
+
+			err = a.Adjust(tc.config)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedConfig, tc.config)
+		})
+	}
+}
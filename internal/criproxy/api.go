@@ -0,0 +1,55 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package criproxy implements the adjustment of the CRI CreateContainer
+// requests intercepted by a CRI proxy to inject the requested devices. The
+// proxy that serves the CRI API and forwards requests to the container engine
+// is not part of this package; it requires the k8s.io/cri-api and gRPC
+// modules.
+package criproxy
+
+// A ContainerConfig represents the subset of the CRI ContainerConfig that is
+// inspected and adjusted when intercepting CreateContainer requests. The
+// fields map to the fields of the same name in the CRI API.
+type ContainerConfig struct {
+	Annotations map[string]string
+	Envs        []KeyValue
+	Devices     []Device
+	Mounts      []Mount
+	CDIDevices  []string
+	Privileged  bool
+}
+
+// A KeyValue represents an environment variable in a CRI container config.
+type KeyValue struct {
+	Key   string
+	Value string
+}
+
+// A Device represents a device node in a CRI container config.
+type Device struct {
+	ContainerPath string
+	HostPath      string
+	Permissions   string
+}
+
+// A Mount represents a mount in a CRI container config.
+type Mount struct {
+	ContainerPath string
+	HostPath      string
+	Readonly      bool
+}
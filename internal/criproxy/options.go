@@ -0,0 +1,42 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package criproxy
+
+import (
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+// Option is a function that configures an Adjuster.
+type Option func(*Adjuster)
+
+// WithLogger sets the logger for the adjuster.
+func WithLogger(logger logger.Interface) Option {
+	return func(a *Adjuster) {
+		a.logger = logger
+	}
+}
+
+// WithConfig sets the NVIDIA Container Toolkit config for the adjuster.
+// This determines the CDI spec directories, the default kind, and how
+// devices are requested.
+func WithConfig(cfg *config.Config) Option {
+	return func(a *Adjuster) {
+		a.cfg = cfg
+	}
+}
@@ -0,0 +1,65 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package daemon
+
+import (
+	"github.com/opencontainers/runtime-spec/specs-go"
+	cdispecs "tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvdra"
+)
+
+const (
+	// DefaultSocketPath is the default path of the unix socket that the
+	// daemon listens on.
+	DefaultSocketPath = "/run/nvidia-container-toolkit/nvidia-ctk.sock"
+
+	// ServiceName is the fully-qualified name of the gRPC service defined in
+	// injector.proto.
+	ServiceName = "nvidia.container.toolkit.v1.Injector"
+)
+
+// A ModifySpecRequest is a request to modify an OCI spec as the NVIDIA
+// Container Runtime would.
+type ModifySpecRequest struct {
+	Spec *specs.Spec
+}
+
+// A ModifySpecResponse contains the modified OCI spec.
+type ModifySpecResponse struct {
+	Spec *specs.Spec
+}
+
+// A ListDevicesRequest is a request to list the devices on the node.
+type ListDevicesRequest struct{}
+
+// A ListDevicesResponse contains the devices on the node.
+type ListDevicesResponse struct {
+	Devices []nvdra.Device
+}
+
+// A GenerateSpecRequest is a request to generate a CDI spec for the
+// specified devices. If no devices are specified, all devices are included.
+type GenerateSpecRequest struct {
+	DeviceIDs []string
+}
+
+// A GenerateSpecResponse contains the generated CDI spec.
+type GenerateSpecResponse struct {
+	Spec *cdispecs.Spec
+}
@@ -0,0 +1,153 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	cdispecs "tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvdra"
+)
+
+// A Client is a gRPC client for the Injector service.
+type Client struct {
+	transport *http.Transport
+	client    *http.Client
+}
+
+// Dial connects to the daemon listening on the specified unix socket.
+func Dial(socketPath string) (*Client, error) {
+	dial := func(ctx context.Context, _ string, _ string) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, "unix", socketPath)
+	}
+	// A connection is established so that an error is returned if the daemon
+	// is not running.
+	conn, err := dial(context.Background(), "", "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %v: %w", socketPath, err)
+	}
+	conn.Close()
+
+	var protocols http.Protocols
+	protocols.SetUnencryptedHTTP2(true)
+	transport := &http.Transport{
+		DialContext: dial,
+		Protocols:   &protocols,
+	}
+	return &Client{
+		transport: transport,
+		client:    &http.Client{Transport: transport},
+	}, nil
+}
+
+// Close closes the connections to the daemon.
+func (c *Client) Close() error {
+	c.transport.CloseIdleConnections()
+	return nil
+}
+
+// ModifySpec returns the specified OCI spec as modified by the daemon.
+func (c *Client) ModifySpec(ctx context.Context, spec *specs.Spec) (*specs.Spec, error) {
+	var response ModifySpecResponse
+	if err := c.invoke(ctx, "ModifySpec", &ModifySpecRequest{Spec: spec}, &response); err != nil {
+		return nil, err
+	}
+	return response.Spec, nil
+}
+
+// ListDevices returns the devices on the node.
+func (c *Client) ListDevices(ctx context.Context) ([]nvdra.Device, error) {
+	var response ListDevicesResponse
+	if err := c.invoke(ctx, "ListDevices", &ListDevicesRequest{}, &response); err != nil {
+		return nil, err
+	}
+	return response.Devices, nil
+}
+
+// GenerateSpec returns a CDI spec for the specified devices.
+func (c *Client) GenerateSpec(ctx context.Context, ids ...string) (*cdispecs.Spec, error) {
+	var response GenerateSpecResponse
+	if err := c.invoke(ctx, "GenerateSpec", &GenerateSpecRequest{DeviceIDs: ids}, &response); err != nil {
+		return nil, err
+	}
+	return response.Spec, nil
+}
+
+// invoke calls the specified unary method of the Injector service. If the
+// call does not succeed, an *Error is returned.
+func (c *Client) invoke(ctx context.Context, name string, request message, response message) error {
+	data, err := request.marshal()
+	if err != nil {
+		return err
+	}
+	var body bytes.Buffer
+	if err := writeMessage(&body, data); err != nil {
+		return err
+	}
+
+	// The host is ignored since the connection is made to the unix socket.
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://localhost"+methodPath(name), &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", grpcContentType)
+	req.Header.Set("TE", "trailers")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call %v: %w", name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errorf(CodeInternal, "unexpected HTTP status %v", resp.Status)
+	}
+
+	data, err = readMessage(resp.Body)
+	if err != nil {
+		return err
+	}
+	// The status is sent in the trailers once the body has been read or in
+	// the headers if no message is sent.
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		return errorf(CodeInternal, "failed to read response: %v", err)
+	}
+	status := resp.Trailer
+	if status.Get("Grpc-Status") == "" {
+		status = resp.Header
+	}
+	code, err := strconv.ParseUint(status.Get("Grpc-Status"), 10, 32)
+	if err != nil {
+		return errorf(CodeInternal, "invalid status %q", status.Get("Grpc-Status"))
+	}
+	if Code(code) != CodeOK {
+		return &Error{Code: Code(code), Message: decodeStatusMessage(status.Get("Grpc-Message"))}
+	}
+	if data == nil {
+		return errorf(CodeInternal, "no response message")
+	}
+	return response.unmarshal(data)
+}
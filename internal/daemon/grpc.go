@@ -0,0 +1,242 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package daemon
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+// The Injector service is served using the gRPC protocol over HTTP/2 without
+// TLS so that it can be used by any gRPC client with the service definition in
+// injector.proto. Only unary methods without compression are supported.
+
+const (
+	grpcContentType = "application/grpc"
+	// maxMessageSize is the maximum size of a request or response message.
+	maxMessageSize = 16 << 20
+)
+
+// A Code is a gRPC status code.
+type Code uint32
+
+// The gRPC status codes that are returned by the daemon.
+const (
+	CodeOK                = Code(0)
+	CodeUnknown           = Code(2)
+	CodeInvalidArgument   = Code(3)
+	CodeResourceExhausted = Code(8)
+	CodeUnimplemented     = Code(12)
+	CodeInternal          = Code(13)
+)
+
+// An Error is a gRPC error status.
+type Error struct {
+	Code    Code
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("rpc error: code = %d desc = %s", e.Code, e.Message)
+}
+
+// errorf returns an error with the specified code and formatted message.
+func errorf(code Code, format string, args ...any) error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// toError returns the gRPC status of the specified error. Errors that do not
+// carry a status are reported as unknown as is done by other gRPC servers.
+func toError(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return &Error{Code: CodeUnknown, Message: err.Error()}
+}
+
+// A method handles the encoded request of a unary method and returns the
+// response.
+type method func(ctx context.Context, request []byte) (message, error)
+
+// unary returns a method that decodes the request and calls the specified
+// function.
+func unary[T any, R interface {
+	*T
+	message
+}](f func(context.Context, R) (message, error)) method {
+	return func(ctx context.Context, data []byte) (message, error) {
+		request := R(new(T))
+		if err := request.unmarshal(data); err != nil {
+			return nil, errorf(CodeInvalidArgument, "failed to parse request: %v", err)
+		}
+		return f(ctx, request)
+	}
+}
+
+// grpcHandler serves the methods of the Injector service.
+type grpcHandler struct {
+	logger  logger.Interface
+	methods map[string]method
+}
+
+func newGRPCHandler(logger logger.Interface, s *service) http.Handler {
+	return &grpcHandler{
+		logger: logger,
+		methods: map[string]method{
+			methodPath("ModifySpec"): unary(func(ctx context.Context, r *ModifySpecRequest) (message, error) {
+				return s.ModifySpec(ctx, r)
+			}),
+			methodPath("ListDevices"): unary(func(ctx context.Context, r *ListDevicesRequest) (message, error) {
+				return s.ListDevices(ctx, r)
+			}),
+			methodPath("GenerateSpec"): unary(func(ctx context.Context, r *GenerateSpecRequest) (message, error) {
+				return s.GenerateSpec(ctx, r)
+			}),
+		},
+	}
+}
+
+// methodPath returns the HTTP path of the specified method of the Injector
+// service.
+func methodPath(name string) string {
+	return "/" + ServiceName + "/" + name
+}
+
+func (h *grpcHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), grpcContentType) {
+		http.Error(w, "unsupported content type", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	response, err := h.handle(r)
+	if err != nil {
+		h.logger.Debugf("Request %v failed: %v", r.URL.Path, err)
+		writeStatus(w, toError(err))
+		return
+	}
+	data, err := response.marshal()
+	if err != nil {
+		writeStatus(w, toError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", grpcContentType)
+	w.Header().Set("Trailer", "Grpc-Status, Grpc-Message")
+	w.WriteHeader(http.StatusOK)
+	if err := writeMessage(w, data); err != nil {
+		h.logger.Warningf("Failed to write response for %v: %v", r.URL.Path, err)
+		return
+	}
+	w.Header().Set("Grpc-Status", strconv.Itoa(int(CodeOK)))
+}
+
+func (h *grpcHandler) handle(r *http.Request) (message, error) {
+	m, ok := h.methods[r.URL.Path]
+	if !ok {
+		return nil, errorf(CodeUnimplemented, "unknown method %v", r.URL.Path)
+	}
+	if encoding := r.Header.Get("Grpc-Encoding"); encoding != "" && encoding != "identity" {
+		return nil, errorf(CodeUnimplemented, "unsupported encoding %v", encoding)
+	}
+	request, err := readMessage(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	return m(r.Context(), request)
+}
+
+// writeStatus writes an error status as a response without a message. The
+// status is sent in the headers of the response.
+func writeStatus(w http.ResponseWriter, e *Error) {
+	w.Header().Set("Content-Type", grpcContentType)
+	w.Header().Set("Grpc-Status", strconv.Itoa(int(e.Code)))
+	w.Header().Set("Grpc-Message", encodeStatusMessage(e.Message))
+	w.WriteHeader(http.StatusOK)
+}
+
+// readMessage reads a length-prefixed message. Compressed messages are not
+// supported. If no message is sent, nil is returned.
+func readMessage(r io.Reader) ([]byte, error) {
+	var prefix [5]byte
+	if _, err := io.ReadFull(r, prefix[:]); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, errorf(CodeInternal, "failed to read message: %v", err)
+	}
+	if prefix[0] != 0 {
+		return nil, errorf(CodeUnimplemented, "compressed messages are not supported")
+	}
+	size := binary.BigEndian.Uint32(prefix[1:])
+	if size > maxMessageSize {
+		return nil, errorf(CodeResourceExhausted, "message of %d bytes exceeds the maximum of %d bytes", size, maxMessageSize)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, errorf(CodeInternal, "failed to read message: %v", err)
+	}
+	return data, nil
+}
+
+// writeMessage writes a length-prefixed uncompressed message.
+func writeMessage(w io.Writer, data []byte) error {
+	var prefix [5]byte
+	binary.BigEndian.PutUint32(prefix[1:], uint32(len(data)))
+	if _, err := w.Write(prefix[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// encodeStatusMessage percent-encodes a status message as required for the
+// grpc-message header.
+func encodeStatusMessage(message string) string {
+	var b strings.Builder
+	for i := 0; i < len(message); i++ {
+		c := message[i]
+		if c < ' ' || c > '~' || c == '%' {
+			fmt.Fprintf(&b, "%%%02X", c)
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+func decodeStatusMessage(message string) string {
+	decoded, err := url.PathUnescape(message)
+	if err != nil {
+		return message
+	}
+	return decoded
+}
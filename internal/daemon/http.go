@@ -0,0 +1,130 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package daemon
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	nvcdispec "github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+)
+
+// newHTTPHandler returns a handler for the read-only HTTP endpoint.
+// The following paths are served:
+//
+//	/v1/cdi/spec: the generated CDI spec. Devices can be selected using the
+//	  device query parameter (default: all).
+//	/v1/devices: the device inventory.
+//
+// The format query parameter selects either json (default) or yaml output.
+func newHTTPHandler(logger logger.Interface, s *service) http.Handler {
+	h := &httpHandler{
+		logger:  logger,
+		service: s,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/cdi/spec", h.getSpec)
+	mux.HandleFunc("GET /v1/devices", h.getDevices)
+	return mux
+}
+
+type httpHandler struct {
+	logger  logger.Interface
+	service *service
+}
+
+func (h *httpHandler) getSpec(w http.ResponseWriter, r *http.Request) {
+	format, ok := getFormat(w, r)
+	if !ok {
+		return
+	}
+
+	response, err := h.service.GenerateSpec(r.Context(), &GenerateSpecRequest{DeviceIDs: r.URL.Query()["device"]})
+	if err != nil {
+		h.logger.Warningf("Failed to generate CDI spec: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	spec, err := nvcdispec.New(
+		nvcdispec.WithRawSpec(response.Spec),
+		nvcdispec.WithFormat(format),
+	)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType(format))
+	if _, err := spec.WriteTo(w); err != nil {
+		h.logger.Warningf("Failed to write CDI spec: %v", err)
+	}
+}
+
+func (h *httpHandler) getDevices(w http.ResponseWriter, r *http.Request) {
+	format, ok := getFormat(w, r)
+	if !ok {
+		return
+	}
+
+	response, err := h.service.ListDevices(r.Context(), &ListDevicesRequest{})
+	if err != nil {
+		h.logger.Warningf("Failed to list devices: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var data []byte
+	switch format {
+	case nvcdispec.FormatYAML:
+		data, err = yaml.Marshal(response.Devices)
+	default:
+		data, err = json.MarshalIndent(response.Devices, "", "  ")
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType(format))
+	if _, err := w.Write(data); err != nil {
+		h.logger.Warningf("Failed to write devices: %v", err)
+	}
+}
+
+// getFormat returns the output format requested for the specified request.
+// If the format is invalid, an error response is written.
+func getFormat(w http.ResponseWriter, r *http.Request) (string, bool) {
+	switch format := r.URL.Query().Get("format"); format {
+	case "", nvcdispec.FormatJSON:
+		return nvcdispec.FormatJSON, true
+	case nvcdispec.FormatYAML:
+		return nvcdispec.FormatYAML, true
+	default:
+		http.Error(w, "unsupported format "+format, http.StatusBadRequest)
+		return "", false
+	}
+}
+
+func contentType(format string) string {
+	if format == nvcdispec.FormatYAML {
+		return "application/yaml"
+	}
+	return "application/json"
+}
@@ -0,0 +1,133 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package daemon
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+	cdispecs "tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvdra"
+)
+
+type fakeCDILib struct {
+	nvcdi.Interface
+}
+
+func (f *fakeCDILib) GetSpec(ids ...string) (spec.Interface, error) {
+	var devices []cdispecs.Device
+	for _, id := range ids {
+		devices = append(devices, cdispecs.Device{
+			Name: id,
+			ContainerEdits: cdispecs.ContainerEdits{
+				DeviceNodes: []*cdispecs.DeviceNode{{Path: "/dev/nvidia" + id}},
+			},
+		})
+	}
+	return spec.New(
+		spec.WithVendor("nvidia.com"),
+		spec.WithClass("gpu"),
+		spec.WithDeviceSpecs(devices),
+	)
+}
+
+func TestHTTPHandler(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	handler := newHTTPHandler(logger, &service{
+		logger: logger,
+		devicelib: &fakeDeviceLib{
+			devices: []nvdra.Device{
+				{Name: "gpu-0", UUID: "GPU-0", Type: nvdra.DeviceTypeGPU},
+			},
+		},
+		cdilib: &fakeCDILib{},
+	})
+
+	testCases := []struct {
+		description    string
+		method         string
+		target         string
+		expectedStatus int
+		validate       func(*testing.T, []byte)
+	}{
+		{
+			description:    "spec is returned as JSON",
+			method:         http.MethodGet,
+			target:         "/v1/cdi/spec?device=0&device=1",
+			expectedStatus: http.StatusOK,
+			validate: func(t *testing.T, body []byte) {
+				var s cdispecs.Spec
+				require.NoError(t, json.Unmarshal(body, &s))
+				require.Equal(t, "nvidia.com/gpu", s.Kind)
+				require.Len(t, s.Devices, 2)
+			},
+		},
+		{
+			description:    "devices are returned as YAML",
+			method:         http.MethodGet,
+			target:         "/v1/devices?format=yaml",
+			expectedStatus: http.StatusOK,
+			validate: func(t *testing.T, body []byte) {
+				var devices []nvdra.Device
+				require.NoError(t, yaml.Unmarshal(body, &devices))
+				require.EqualValues(t, []nvdra.Device{{Name: "gpu-0", UUID: "GPU-0", Type: nvdra.DeviceTypeGPU}}, devices)
+			},
+		},
+		{
+			description:    "invalid format is rejected",
+			method:         http.MethodGet,
+			target:         "/v1/devices?format=xml",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			description:    "endpoint is read-only",
+			method:         http.MethodPost,
+			target:         "/v1/devices",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(tc.method, tc.target, nil))
+
+			require.Equal(t, tc.expectedStatus, w.Code)
+			if tc.validate != nil {
+				tc.validate(t, w.Body.Bytes())
+			}
+		})
+	}
+}
+
+func TestListenHTTP(t *testing.T) {
+	_, err := listenHTTP("0.0.0.0:0")
+	require.Error(t, err)
+
+	listener, err := listenHTTP("127.0.0.1:0")
+	require.NoError(t, err)
+	require.NoError(t, listener.Close())
+}
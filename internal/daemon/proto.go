@@ -0,0 +1,533 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package daemon
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	cdispecs "tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvdra"
+)
+
+// The messages defined in injector.proto are encoded using the protobuf wire
+// format. Since only a small number of messages are exchanged, the encoding is
+// implemented here instead of depending on generated code.
+
+// A message is a request or response of the Injector service.
+type message interface {
+	marshal() ([]byte, error)
+	unmarshal([]byte) error
+}
+
+const (
+	wireVarint = 0
+	wireI64    = 1
+	wireBytes  = 2
+	wireI32    = 5
+)
+
+var errTruncated = errors.New("truncated message")
+
+// An encoder appends fields to a protobuf message.
+type encoder struct {
+	buf []byte
+}
+
+func (e *encoder) tag(field int, wireType int) {
+	e.buf = binary.AppendUvarint(e.buf, uint64(field)<<3|uint64(wireType))
+}
+
+func (e *encoder) varint(field int, v uint64) {
+	e.tag(field, wireVarint)
+	e.buf = binary.AppendUvarint(e.buf, v)
+}
+
+func (e *encoder) bytes(field int, b []byte) {
+	e.tag(field, wireBytes)
+	e.buf = binary.AppendUvarint(e.buf, uint64(len(b)))
+	e.buf = append(e.buf, b...)
+}
+
+// The following functions omit fields with zero values as is done for proto3
+// fields without explicit presence.
+
+func (e *encoder) optionalString(field int, s string) {
+	if s != "" {
+		e.bytes(field, []byte(s))
+	}
+}
+
+func (e *encoder) optionalInt64(field int, v int64) {
+	if v != 0 {
+		e.varint(field, uint64(v))
+	}
+}
+
+func (e *encoder) optionalBool(field int, v bool) {
+	if v {
+		e.varint(field, 1)
+	}
+}
+
+// A decoder reads the fields of a protobuf message.
+type decoder struct {
+	buf []byte
+	// wireType is the wire type of the current field.
+	wireType int
+}
+
+// next returns the number of the next field. Zero is returned once all
+// fields have been read.
+func (d *decoder) next() (int, error) {
+	if len(d.buf) == 0 {
+		return 0, nil
+	}
+	key, err := d.uvarint()
+	if err != nil {
+		return 0, err
+	}
+	field := int(key >> 3)
+	if field <= 0 {
+		return 0, fmt.Errorf("invalid field number %d", field)
+	}
+	d.wireType = int(key & 7)
+	return field, nil
+}
+
+func (d *decoder) uvarint() (uint64, error) {
+	v, n := binary.Uvarint(d.buf)
+	if n <= 0 {
+		return 0, errTruncated
+	}
+	d.buf = d.buf[n:]
+	return v, nil
+}
+
+func (d *decoder) varint() (uint64, error) {
+	if d.wireType != wireVarint {
+		return 0, fmt.Errorf("unexpected wire type %d for varint field", d.wireType)
+	}
+	return d.uvarint()
+}
+
+func (d *decoder) bytes() ([]byte, error) {
+	if d.wireType != wireBytes {
+		return nil, fmt.Errorf("unexpected wire type %d for length-delimited field", d.wireType)
+	}
+	n, err := d.uvarint()
+	if err != nil {
+		return nil, err
+	}
+	if n > uint64(len(d.buf)) {
+		return nil, errTruncated
+	}
+	b := d.buf[:n]
+	d.buf = d.buf[n:]
+	return b, nil
+}
+
+func (d *decoder) string() (string, error) {
+	b, err := d.bytes()
+	return string(b), err
+}
+
+func (d *decoder) int64() (int64, error) {
+	v, err := d.varint()
+	return int64(v), err
+}
+
+func (d *decoder) bool() (bool, error) {
+	v, err := d.varint()
+	return v != 0, err
+}
+
+// skip skips a field that is not known so that messages from newer clients
+// can be read.
+func (d *decoder) skip() error {
+	var n int
+	switch d.wireType {
+	case wireVarint:
+		_, err := d.uvarint()
+		return err
+	case wireBytes:
+		_, err := d.bytes()
+		return err
+	case wireI64:
+		n = 8
+	case wireI32:
+		n = 4
+	default:
+		return fmt.Errorf("unsupported wire type %d", d.wireType)
+	}
+	if len(d.buf) < n {
+		return errTruncated
+	}
+	d.buf = d.buf[n:]
+	return nil
+}
+
+// decode calls the specified function for each field of the message.
+func decode(data []byte, f func(d *decoder, field int) error) error {
+	d := &decoder{buf: data}
+	for {
+		field, err := d.next()
+		if err != nil {
+			return err
+		}
+		if field == 0 {
+			return nil
+		}
+		if err := f(d, field); err != nil {
+			return err
+		}
+	}
+}
+
+func (r *ModifySpecRequest) marshal() ([]byte, error) {
+	return marshalSpec(r.Spec)
+}
+
+func (r *ModifySpecRequest) unmarshal(data []byte) error {
+	spec, err := unmarshalSpec(data)
+	r.Spec = spec
+	return err
+}
+
+func (r *ModifySpecResponse) marshal() ([]byte, error) {
+	return marshalSpec(r.Spec)
+}
+
+func (r *ModifySpecResponse) unmarshal(data []byte) error {
+	spec, err := unmarshalSpec(data)
+	r.Spec = spec
+	return err
+}
+
+// marshalSpec encodes a message with the JSON-encoded OCI spec as field 1.
+func marshalSpec(spec *specs.Spec) ([]byte, error) {
+	e := &encoder{}
+	if spec != nil {
+		contents, err := json.Marshal(spec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal OCI spec: %w", err)
+		}
+		e.bytes(1, contents)
+	}
+	return e.buf, nil
+}
+
+func unmarshalSpec(data []byte) (*specs.Spec, error) {
+	var spec *specs.Spec
+	err := decode(data, func(d *decoder, field int) error {
+		if field != 1 {
+			return d.skip()
+		}
+		contents, err := d.bytes()
+		if err != nil {
+			return err
+		}
+		spec = &specs.Spec{}
+		if err := json.Unmarshal(contents, spec); err != nil {
+			return fmt.Errorf("failed to parse OCI spec: %w", err)
+		}
+		return nil
+	})
+	return spec, err
+}
+
+func (r *ListDevicesRequest) marshal() ([]byte, error) {
+	return nil, nil
+}
+
+func (r *ListDevicesRequest) unmarshal(data []byte) error {
+	return decode(data, func(d *decoder, _ int) error {
+		return d.skip()
+	})
+}
+
+func (r *ListDevicesResponse) marshal() ([]byte, error) {
+	e := &encoder{}
+	for _, device := range r.Devices {
+		e.bytes(1, marshalDevice(device))
+	}
+	return e.buf, nil
+}
+
+func (r *ListDevicesResponse) unmarshal(data []byte) error {
+	return decode(data, func(d *decoder, field int) error {
+		if field != 1 {
+			return d.skip()
+		}
+		contents, err := d.bytes()
+		if err != nil {
+			return err
+		}
+		device, err := unmarshalDevice(contents)
+		if err != nil {
+			return fmt.Errorf("failed to parse device: %w", err)
+		}
+		r.Devices = append(r.Devices, device)
+		return nil
+	})
+}
+
+func marshalDevice(device nvdra.Device) []byte {
+	e := &encoder{}
+	e.optionalString(1, device.Name)
+	e.optionalString(2, device.UUID)
+	e.optionalString(3, string(device.Type))
+	// Map entries are sorted so that the encoding is deterministic.
+	for _, name := range slices.Sorted(maps.Keys(device.Attributes)) {
+		entry := &encoder{}
+		entry.optionalString(1, name)
+		entry.bytes(2, marshalAttribute(device.Attributes[name]))
+		e.bytes(4, entry.buf)
+	}
+	for _, name := range slices.Sorted(maps.Keys(device.Capacity)) {
+		entry := &encoder{}
+		entry.optionalString(1, name)
+		entry.optionalInt64(2, device.Capacity[name])
+		e.bytes(5, entry.buf)
+	}
+	health := &encoder{}
+	health.optionalBool(1, device.Health.Healthy)
+	health.optionalString(2, device.Health.Reason)
+	e.bytes(6, health.buf)
+	return e.buf
+}
+
+func unmarshalDevice(data []byte) (nvdra.Device, error) {
+	var device nvdra.Device
+	err := decode(data, func(d *decoder, field int) error {
+		var err error
+		switch field {
+		case 1:
+			device.Name, err = d.string()
+		case 2:
+			device.UUID, err = d.string()
+		case 3:
+			var deviceType string
+			deviceType, err = d.string()
+			device.Type = nvdra.DeviceType(deviceType)
+		case 4:
+			var name string
+			var attribute nvdra.Attribute
+			name, attribute, err = unmarshalAttributeEntry(d)
+			if err == nil {
+				if device.Attributes == nil {
+					device.Attributes = make(map[string]nvdra.Attribute)
+				}
+				device.Attributes[name] = attribute
+			}
+		case 5:
+			var name string
+			var value int64
+			name, value, err = unmarshalCapacityEntry(d)
+			if err == nil {
+				if device.Capacity == nil {
+					device.Capacity = make(map[string]int64)
+				}
+				device.Capacity[name] = value
+			}
+		case 6:
+			var contents []byte
+			contents, err = d.bytes()
+			if err == nil {
+				device.Health, err = unmarshalHealth(contents)
+			}
+		default:
+			err = d.skip()
+		}
+		return err
+	})
+	return device, err
+}
+
+func marshalAttribute(attribute nvdra.Attribute) []byte {
+	// The fields are part of a oneof and are encoded even if they have a zero
+	// value.
+	e := &encoder{}
+	switch {
+	case attribute.IntValue != nil:
+		e.varint(1, uint64(*attribute.IntValue))
+	case attribute.BoolValue != nil:
+		var v uint64
+		if *attribute.BoolValue {
+			v = 1
+		}
+		e.varint(2, v)
+	case attribute.StringValue != nil:
+		e.bytes(3, []byte(*attribute.StringValue))
+	case attribute.VersionValue != nil:
+		e.bytes(4, []byte(*attribute.VersionValue))
+	}
+	return e.buf
+}
+
+func unmarshalAttributeEntry(d *decoder) (string, nvdra.Attribute, error) {
+	var name string
+	var attribute nvdra.Attribute
+	contents, err := d.bytes()
+	if err != nil {
+		return "", attribute, err
+	}
+	err = decode(contents, func(d *decoder, field int) error {
+		switch field {
+		case 1:
+			var err error
+			name, err = d.string()
+			return err
+		case 2:
+			value, err := d.bytes()
+			if err != nil {
+				return err
+			}
+			attribute, err = unmarshalAttribute(value)
+			return err
+		default:
+			return d.skip()
+		}
+	})
+	return name, attribute, err
+}
+
+func unmarshalAttribute(data []byte) (nvdra.Attribute, error) {
+	var attribute nvdra.Attribute
+	err := decode(data, func(d *decoder, field int) error {
+		// Only the last field of a oneof that is read is retained.
+		attribute = nvdra.Attribute{}
+		switch field {
+		case 1:
+			v, err := d.int64()
+			attribute.IntValue = &v
+			return err
+		case 2:
+			v, err := d.bool()
+			attribute.BoolValue = &v
+			return err
+		case 3:
+			v, err := d.string()
+			attribute.StringValue = &v
+			return err
+		case 4:
+			v, err := d.string()
+			attribute.VersionValue = &v
+			return err
+		default:
+			return d.skip()
+		}
+	})
+	return attribute, err
+}
+
+func unmarshalCapacityEntry(d *decoder) (string, int64, error) {
+	var name string
+	var value int64
+	contents, err := d.bytes()
+	if err != nil {
+		return "", 0, err
+	}
+	err = decode(contents, func(d *decoder, field int) error {
+		var err error
+		switch field {
+		case 1:
+			name, err = d.string()
+		case 2:
+			value, err = d.int64()
+		default:
+			err = d.skip()
+		}
+		return err
+	})
+	return name, value, err
+}
+
+func unmarshalHealth(data []byte) (nvdra.Health, error) {
+	var health nvdra.Health
+	err := decode(data, func(d *decoder, field int) error {
+		var err error
+		switch field {
+		case 1:
+			health.Healthy, err = d.bool()
+		case 2:
+			health.Reason, err = d.string()
+		default:
+			err = d.skip()
+		}
+		return err
+	})
+	return health, err
+}
+
+func (r *GenerateSpecRequest) marshal() ([]byte, error) {
+	e := &encoder{}
+	for _, id := range r.DeviceIDs {
+		e.bytes(1, []byte(id))
+	}
+	return e.buf, nil
+}
+
+func (r *GenerateSpecRequest) unmarshal(data []byte) error {
+	return decode(data, func(d *decoder, field int) error {
+		if field != 1 {
+			return d.skip()
+		}
+		id, err := d.string()
+		if err != nil {
+			return err
+		}
+		r.DeviceIDs = append(r.DeviceIDs, id)
+		return nil
+	})
+}
+
+func (r *GenerateSpecResponse) marshal() ([]byte, error) {
+	e := &encoder{}
+	if r.Spec != nil {
+		contents, err := json.Marshal(r.Spec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal CDI spec: %w", err)
+		}
+		e.bytes(1, contents)
+	}
+	return e.buf, nil
+}
+
+func (r *GenerateSpecResponse) unmarshal(data []byte) error {
+	return decode(data, func(d *decoder, field int) error {
+		if field != 1 {
+			return d.skip()
+		}
+		contents, err := d.bytes()
+		if err != nil {
+			return err
+		}
+		r.Spec = &cdispecs.Spec{}
+		if err := json.Unmarshal(contents, r.Spec); err != nil {
+			return fmt.Errorf("failed to parse CDI spec: %w", err)
+		}
+		return nil
+	})
+}
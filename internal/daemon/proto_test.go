@@ -0,0 +1,118 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package daemon
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvdra"
+)
+
+func TestMessageEncoding(t *testing.T) {
+	testCases := []struct {
+		description string
+		message     message
+		empty       message
+		expected    []byte
+	}{
+		{
+			description: "repeated strings",
+			message:     &GenerateSpecRequest{DeviceIDs: []string{"0", "GPU-1"}},
+			empty:       &GenerateSpecRequest{},
+			expected:    []byte{0x0a, 0x01, '0', 0x0a, 0x05, 'G', 'P', 'U', '-', '1'},
+		},
+		{
+			description: "OCI spec as JSON",
+			message:     &ModifySpecRequest{Spec: &specs.Spec{Version: "1.2.0"}},
+			empty:       &ModifySpecRequest{},
+			expected:    append([]byte{0x0a, 0x16}, `{"ociVersion":"1.2.0"}`...),
+		},
+		{
+			description: "empty message",
+			message:     &ListDevicesRequest{},
+			empty:       &ListDevicesRequest{},
+			expected:    nil,
+		},
+		{
+			description: "devices with attributes and capacity",
+			message: &ListDevicesResponse{
+				Devices: []nvdra.Device{
+					{
+						Name: "gpu-0",
+						UUID: "GPU-0",
+						Type: nvdra.DeviceTypeGPU,
+						Attributes: map[string]nvdra.Attribute{
+							"index":         {IntValue: ptr[int64](0)},
+							"mig":           {BoolValue: ptr(false)},
+							"productName":   {StringValue: ptr("Mock NVIDIA A100-SXM4-40GB")},
+							"driverVersion": {VersionValue: ptr("550.54.15")},
+						},
+						Capacity: map[string]int64{
+							"memory": 42949672960,
+						},
+						Health: nvdra.Health{Healthy: true},
+					},
+					{
+						Name:   "mig-0-1",
+						Type:   nvdra.DeviceTypeMIG,
+						Health: nvdra.Health{Reason: "XID 79"},
+					},
+				},
+			},
+			empty: &ListDevicesResponse{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			data, err := tc.message.marshal()
+			require.NoError(t, err)
+			if tc.expected != nil {
+				require.Equal(t, tc.expected, data)
+			}
+
+			require.NoError(t, tc.empty.unmarshal(data))
+			require.Equal(t, tc.message, tc.empty)
+		})
+	}
+}
+
+func TestMessageDecodingSkipsUnknownFields(t *testing.T) {
+	data := []byte{
+		// field 2 (varint): 150
+		0x10, 0x96, 0x01,
+		// field 1 (bytes): "0"
+		0x0a, 0x01, '0',
+		// field 3 (fixed64)
+		0x19, 0, 0, 0, 0, 0, 0, 0, 0,
+	}
+
+	var request GenerateSpecRequest
+	require.NoError(t, request.unmarshal(data))
+	require.Equal(t, []string{"0"}, request.DeviceIDs)
+
+	require.Error(t, request.unmarshal([]byte{0x0a, 0x05, 'G'}), "a truncated field is rejected")
+	require.Error(t, request.unmarshal([]byte{0x08, 0x01}), "an unexpected wire type is rejected")
+}
+
+func ptr[T any](x T) *T {
+	return &x
+}
@@ -0,0 +1,249 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvdra"
+)
+
+// A Server serves the injection API on a unix socket.
+// The API is exposed as the gRPC Injector service defined in injector.proto.
+type Server struct {
+	logger     logger.Interface
+	socketPath string
+	nvmllib    nvml.Interface
+	service    *service
+
+	// httpAddress is the optional address of the read-only HTTP endpoint.
+	httpAddress string
+}
+
+// Option is a function that configures a Server.
+type Option func(*Server)
+
+// WithLogger sets the logger for the server.
+func WithLogger(logger logger.Interface) Option {
+	return func(s *Server) {
+		s.logger = logger
+	}
+}
+
+// WithSocketPath sets the path of the unix socket for the server.
+func WithSocketPath(socketPath string) Option {
+	return func(s *Server) {
+		s.socketPath = socketPath
+	}
+}
+
+// WithHTTPAddress sets the address of the read-only HTTP endpoint.
+// This is either a unix socket specified as unix://PATH or a loopback
+// address such as 127.0.0.1:8080.
+func WithHTTPAddress(address string) Option {
+	return func(s *Server) {
+		s.httpAddress = address
+	}
+}
+
+// WithNvmlLib sets the NVML library used by the server.
+func WithNvmlLib(nvmllib nvml.Interface) Option {
+	return func(s *Server) {
+		s.nvmllib = nvmllib
+	}
+}
+
+// NewServer creates a server for the specified config.
+func NewServer(cfg *config.Config, opts ...Option) (*Server, error) {
+	s := &Server{}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.logger == nil {
+		s.logger = logger.New()
+	}
+	if s.socketPath == "" {
+		s.socketPath = DefaultSocketPath
+	}
+
+	driver := root.New(
+		root.WithLogger(s.logger),
+		root.WithDriverRoot(cfg.NVIDIAContainerCLIConfig.Root),
+	)
+	if s.nvmllib == nil {
+		var nvmlOpts []nvml.LibraryOption
+		if candidates, err := driver.Libraries().Locate("libnvidia-ml.so.1"); err == nil {
+			nvmlOpts = append(nvmlOpts, nvml.WithLibraryPath(candidates[0]))
+		}
+		s.nvmllib = nvml.New(nvmlOpts...)
+	}
+
+	cdiOptions := []nvcdi.Option{
+		nvcdi.WithLogger(s.logger),
+		nvcdi.WithNvmlLib(s.nvmllib),
+		nvcdi.WithDriverRoot(cfg.NVIDIAContainerCLIConfig.Root),
+		nvcdi.WithNVIDIACDIHookPath(cfg.NVIDIACTKConfig.Path),
+		nvcdi.WithLibrarySearchPaths(cfg.NVIDIAContainerCLIConfig.LibrarySearchPaths),
+		nvcdi.WithLdconfigPath(cfg.NVIDIAContainerCLIConfig.HostLDConfigPath()),
+	}
+	cdilib, err := nvcdi.New(cdiOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CDI library: %w", err)
+	}
+	devicelib, err := nvdra.New(
+		nvdra.WithLogger(s.logger),
+		nvdra.WithNvmlLib(s.nvmllib),
+		nvdra.WithCDIOptions(cdiOptions...),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create device library: %w", err)
+	}
+
+	s.service = &service{
+		logger:    s.logger,
+		cfg:       cfg,
+		driver:    driver,
+		devicelib: devicelib,
+		cdilib:    cdilib,
+	}
+	return s, nil
+}
+
+// Serve listens on the configured socket and serves requests until the
+// specified context is cancelled.
+// NVML is kept initialized while serving so that the library does not need to
+// be loaded for each request.
+func (s *Server) Serve(ctx context.Context) error {
+	if r := s.nvmllib.Init(); r != nvml.SUCCESS {
+		s.logger.Warningf("Failed to initialize NVML: %v", r)
+	} else {
+		defer func() {
+			_ = s.nvmllib.Shutdown()
+		}()
+	}
+
+	listener, err := listenUnix(s.socketPath)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(s.socketPath)
+	s.logger.Infof("Listening on %v", s.socketPath)
+
+	if s.httpAddress != "" {
+		httpListener, err := listenHTTP(s.httpAddress)
+		if err != nil {
+			listener.Close()
+			return err
+		}
+		if socketPath, ok := strings.CutPrefix(s.httpAddress, "unix://"); ok {
+			defer os.Remove(socketPath)
+		}
+		s.logger.Infof("Serving read-only HTTP endpoint on %v", s.httpAddress)
+
+		httpServer := &http.Server{
+			Handler:           newHTTPHandler(s.logger, s.service),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			if err := httpServer.Serve(httpListener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				s.logger.Errorf("HTTP endpoint failed: %v", err)
+			}
+		}()
+		defer httpServer.Close()
+	}
+
+	return s.serve(ctx, listener)
+}
+
+// listenUnix listens on the unix socket at the specified path. Only
+// privileged clients are allowed to access the socket.
+func listenUnix(socketPath string) (net.Listener, error) {
+	if err := os.MkdirAll(filepath.Dir(socketPath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create socket directory: %w", err)
+	}
+	if err := os.Remove(socketPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to remove existing socket: %w", err)
+	}
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %v: %w", socketPath, err)
+	}
+	if err := os.Chmod(socketPath, 0600); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to set socket permissions: %w", err)
+	}
+	return listener, nil
+}
+
+// listenHTTP listens on the specified address for the HTTP endpoint.
+// Since the endpoint is not authenticated, only unix sockets and loopback
+// addresses are supported.
+func listenHTTP(address string) (net.Listener, error) {
+	if socketPath, ok := strings.CutPrefix(address, "unix://"); ok {
+		return listenUnix(socketPath)
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid HTTP address %q: %w", address, err)
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return nil, fmt.Errorf("invalid HTTP address %q: only loopback addresses are supported", address)
+	}
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %v: %w", address, err)
+	}
+	return listener, nil
+}
+
+// serve serves the gRPC service on the specified listener until the specified
+// context is cancelled. gRPC clients connect using HTTP/2 with prior knowledge
+// since TLS is not used on the unix socket.
+func (s *Server) serve(ctx context.Context, listener net.Listener) error {
+	var protocols http.Protocols
+	protocols.SetUnencryptedHTTP2(true)
+	server := &http.Server{
+		Handler:           newGRPCHandler(s.logger, s.service),
+		Protocols:         &protocols,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+
+	err := server.Serve(listener)
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
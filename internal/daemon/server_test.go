@@ -0,0 +1,117 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package daemon
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvdra"
+)
+
+type fakeDeviceLib struct {
+	nvdra.Interface
+	devices []nvdra.Device
+}
+
+func (f *fakeDeviceLib) GetDevices() ([]nvdra.Device, error) {
+	return f.devices, nil
+}
+
+func TestServer(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	socketPath := filepath.Join(t.TempDir(), "nvidia-ctk.sock")
+
+	s := &Server{
+		logger:     logger,
+		socketPath: socketPath,
+		nvmllib:    dgxa100.New(),
+		service: &service{
+			logger: logger,
+			cfg: &config.Config{
+				NVIDIAContainerRuntimeConfig: config.RuntimeConfig{
+					Mode: "legacy",
+				},
+				NVIDIAContainerRuntimeHookConfig: config.RuntimeHookConfig{
+					Path: "/usr/bin/nvidia-container-runtime-hook",
+				},
+			},
+			driver: root.New(root.WithDriverRoot("/nvidia/driver/root")),
+			devicelib: &fakeDeviceLib{
+				devices: []nvdra.Device{
+					{Name: "gpu-0", UUID: "GPU-0", Type: nvdra.DeviceTypeGPU},
+				},
+			},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		errs <- s.Serve(ctx)
+	}()
+
+	var client *Client
+	require.Eventually(t, func() bool {
+		var err error
+		client, err = Dial(socketPath)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	defer client.Close()
+
+	spec, err := client.ModifySpec(ctx, &specs.Spec{
+		Process: &specs.Process{
+			Env: []string{"NVIDIA_VISIBLE_DEVICES=all"},
+		},
+	})
+	require.NoError(t, err)
+	require.EqualValues(t,
+		&specs.Hooks{
+			Prestart: []specs.Hook{
+				{
+					Path: "/usr/bin/nvidia-container-runtime-hook",
+					Args: []string{"nvidia-container-runtime-hook", "prestart"},
+				},
+			},
+		},
+		spec.Hooks,
+	)
+
+	_, err = client.ModifySpec(ctx, nil)
+	require.Equal(t, &Error{Code: CodeInvalidArgument, Message: "an OCI spec is required"}, err)
+
+	err = client.invoke(ctx, "Unknown", &ListDevicesRequest{}, &ListDevicesResponse{})
+	require.Equal(t, &Error{Code: CodeUnimplemented, Message: "unknown method /nvidia.container.toolkit.v1.Injector/Unknown"}, err)
+
+	devices, err := client.ListDevices(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, []nvdra.Device{{Name: "gpu-0", UUID: "GPU-0", Type: nvdra.DeviceTypeGPU}}, devices)
+
+	cancel()
+	require.NoError(t, <-errs)
+	require.NoFileExists(t, socketPath)
+}
@@ -0,0 +1,90 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/runtime"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvdra"
+)
+
+// A service implements the methods of the Injector service.
+// The libraries used for discovery are constructed once and reused across
+// requests. Requests are serialized since the underlying libraries are not
+// safe for concurrent use.
+type service struct {
+	sync.Mutex
+	logger logger.Interface
+	cfg    *config.Config
+	driver *root.Driver
+
+	devicelib nvdra.Interface
+	cdilib    nvcdi.Interface
+}
+
+// ModifySpec applies the modifications of the NVIDIA Container Runtime to
+// the specified OCI spec.
+func (s *service) ModifySpec(_ context.Context, request *ModifySpecRequest) (*ModifySpecResponse, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if request.Spec == nil {
+		return nil, errorf(CodeInvalidArgument, "an OCI spec is required")
+	}
+	// The config is updated while the spec is modified and is copied so that
+	// the state of one request does not affect subsequent requests.
+	cfg, err := s.cfg.DeepCopy()
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy config: %w", err)
+	}
+	if err := runtime.ModifySpec(s.logger, cfg, s.driver, request.Spec); err != nil {
+		return nil, err
+	}
+	return &ModifySpecResponse{Spec: request.Spec}, nil
+}
+
+// ListDevices returns the devices on the node.
+func (s *service) ListDevices(_ context.Context, _ *ListDevicesRequest) (*ListDevicesResponse, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	devices, err := s.devicelib.GetDevices()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get devices: %w", err)
+	}
+	return &ListDevicesResponse{Devices: devices}, nil
+}
+
+// GenerateSpec generates a CDI spec for the requested devices.
+func (s *service) GenerateSpec(_ context.Context, request *GenerateSpecRequest) (*GenerateSpecResponse, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	spec, err := s.cdilib.GetSpec(request.DeviceIDs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate CDI spec: %w", err)
+	}
+	return &GenerateSpecResponse{Spec: spec.Raw()}, nil
+}
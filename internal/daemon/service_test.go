@@ -0,0 +1,91 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package daemon
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+)
+
+func TestServiceModifySpecDoesNotShareConfig(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	specDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "example.yaml"), []byte(`cdiVersion: 0.5.0
+kind: example.com/device
+devices:
+- name: dev0
+  containerEdits:
+    env:
+    - EXAMPLE_DEVICE=dev0
+`), 0600))
+
+	cfg := &config.Config{
+		NVIDIAContainerRuntimeConfig: config.RuntimeConfig{
+			Mode: "auto",
+		},
+		NVIDIAContainerRuntimeHookConfig: config.RuntimeHookConfig{
+			Path: "/usr/bin/nvidia-container-runtime-hook",
+		},
+	}
+	cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.SpecDirs = []string{specDir}
+	cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.DefaultKind = "nvidia.com/gpu"
+	cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.AnnotationPrefixes = []string{"cdi.k8s.io/"}
+	s := &service{
+		logger: logger,
+		cfg:    cfg,
+		driver: root.New(root.WithDriverRoot("/nvidia/driver/root")),
+	}
+
+	// A container that requests no devices resolves to the jit-cdi mode.
+	jitCDI, err := s.ModifySpec(context.Background(), &ModifySpecRequest{
+		Spec: &specs.Spec{
+			Process: &specs.Process{
+				Env: []string{"NVIDIA_VISIBLE_DEVICES=void"},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, []string{"NVIDIA_VISIBLE_DEVICES=void"}, jitCDI.Spec.Process.Env)
+
+	// A request for a CDI device in an annotation resolves to the cdi mode.
+	// This requires that neither the mode nor the annotation prefixes of the
+	// previous request are retained.
+	cdi, err := s.ModifySpec(context.Background(), &ModifySpecRequest{
+		Spec: &specs.Spec{
+			Annotations: map[string]string{
+				"cdi.k8s.io/example": "example.com/device=dev0",
+			},
+			Process: &specs.Process{},
+		},
+	})
+	require.NoError(t, err)
+	require.Contains(t, cdi.Spec.Process.Env, "EXAMPLE_DEVICE=dev0")
+
+	require.Equal(t, "auto", cfg.NVIDIAContainerRuntimeConfig.Mode)
+	require.EqualValues(t, []string{"cdi.k8s.io/"}, cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.AnnotationPrefixes)
+}
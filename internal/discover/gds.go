@@ -19,6 +19,7 @@ package discover
 import (
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
 )
 
 type gdsDeviceDiscoverer struct {
@@ -26,10 +27,38 @@ type gdsDeviceDiscoverer struct {
 	logger  logger.Interface
 	devices Discover
 	mounts  Discover
+	hooks   Discover
 }
 
 // NewGDSDiscoverer creates a discoverer for GPUDirect Storage devices and mounts.
 func NewGDSDiscoverer(logger logger.Interface, driverRoot string, devRoot string) (Discover, error) {
+	return newGDSDiscoverer(logger, driverRoot, devRoot, None{}, None{}), nil
+}
+
+// NewGDSDiscovererWithLibraries creates a discoverer for GPUDirect Storage
+// devices and mounts that also includes the cuFile libraries (libcufile and
+// libcufile_rdma) located in the driver root. The hooks required to update
+// the ldcache in the container for these libraries are also included.
+func NewGDSDiscovererWithLibraries(logger logger.Interface, driver *root.Driver, devRoot string, hookCreator HookCreator, ldconfigPath string) (Discover, error) {
+	libraries := NewMounts(
+		logger,
+		driver.Libraries(),
+		driver.Root,
+		[]string{
+			"libcufile.so.*",
+			"libcufile_rdma.so.*",
+		},
+	)
+
+	ldcacheUpdateHook, err := NewLDCacheUpdateHook(logger, libraries, hookCreator, ldconfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return newGDSDiscoverer(logger, driver.Root, devRoot, libraries, ldcacheUpdateHook), nil
+}
+
+func newGDSDiscoverer(logger logger.Interface, driverRoot string, devRoot string, libraries Discover, hooks Discover) *gdsDeviceDiscoverer {
 	devices := NewCharDeviceDiscoverer(
 		logger,
 		devRoot,
@@ -53,13 +82,12 @@ func NewGDSDiscoverer(logger logger.Interface, driverRoot string, devRoot string
 		[]string{"/etc/cufile.json"},
 	)
 
-	d := gdsDeviceDiscoverer{
+	return &gdsDeviceDiscoverer{
 		logger:  logger,
 		devices: devices,
-		mounts:  Merge(udev, cufile),
+		mounts:  Merge(udev, cufile, libraries),
+		hooks:   hooks,
 	}
-
-	return &d, nil
 }
 
 // Devices discovers the nvidia-fs device nodes for use with GPUDirect Storage
@@ -70,11 +98,25 @@ func (d *gdsDeviceDiscoverer) Devices() ([]Device, error) {
 // Mounts discovers the required mounts for GPUDirect Storage.
 // If no devices are discovered the discovered mounts are empty
 func (d *gdsDeviceDiscoverer) Mounts() ([]Mount, error) {
-	devices, err := d.Devices()
-	if err != nil || len(devices) == 0 {
+	if !d.hasDevices() {
 		d.logger.Debugf("No nvidia-fs devices detected; skipping detection of mounts")
 		return nil, nil
 	}
 
 	return d.mounts.Mounts()
 }
+
+// Hooks returns the hooks required for GPUDirect Storage.
+// If no devices are discovered the discovered hooks are empty
+func (d *gdsDeviceDiscoverer) Hooks() ([]Hook, error) {
+	if !d.hasDevices() {
+		return nil, nil
+	}
+
+	return d.hooks.Hooks()
+}
+
+func (d *gdsDeviceDiscoverer) hasDevices() bool {
+	devices, err := d.Devices()
+	return err == nil && len(devices) > 0
+}
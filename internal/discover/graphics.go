@@ -96,6 +96,8 @@ func newVulkanConfigsDiscover(logger logger.Interface, driver *root.Driver) Disc
 		required = append(required, "vulkan/icd.d/nvidia_icd.x86_64.json")
 	case "arm64":
 		required = append(required, "vulkan/icd.d/nvidia_icd.aarch64.json")
+	case "ppc64le":
+		required = append(required, "vulkan/icd.d/nvidia_icd.ppc64le.json")
 	}
 	return &mountsToContainerPath{
 		logger:        logger,
@@ -17,10 +17,14 @@
 package discover
 
 import (
+	"path/filepath"
 	"testing"
 
 	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
 )
 
 func TestGraphicsLibrariesDiscoverer(t *testing.T) {
@@ -163,3 +167,55 @@ func TestGraphicsLibrariesDiscoverer(t *testing.T) {
 		})
 	}
 }
+
+func TestGraphicsLibrariesDiscovererMultiarch(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		rootFs          string
+		expectedLibRoot string
+	}{
+		{
+			rootFs:          "rootfs-no-cache-aarch64",
+			expectedLibRoot: "/usr/lib/aarch64-linux-gnu/nvidia/current",
+		},
+		{
+			rootFs:          "rootfs-no-cache-ppc64le",
+			expectedLibRoot: "/usr/lib/powerpc64le-linux-gnu",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.rootFs, func(t *testing.T) {
+			driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", tc.rootFs)
+			driver := root.New(
+				root.WithLogger(logger),
+				root.WithDriverRoot(driverRoot),
+			)
+
+			libRoot, version := getCUDALibRootAndVersionPattern(logger, driver)
+			require.Equal(t, tc.expectedLibRoot, libRoot)
+			require.Equal(t, "999.88.77", version)
+
+			d := newGraphicsLibrariesDiscoverer(logger, driver, NewHookCreator())
+			mounts, err := d.Mounts()
+			require.NoError(t, err)
+
+			expectedPath := filepath.Join(tc.expectedLibRoot, "libnvidia-egl-gbm.so.1.1.2")
+			require.Contains(t, mounts, Mount{
+				HostPath: filepath.Join(driverRoot, expectedPath),
+				Path:     expectedPath,
+				Options: []string{
+					"ro",
+					"nosuid",
+					"nodev",
+					"rbind",
+					"rprivate",
+				},
+			})
+		})
+	}
+}
@@ -33,6 +33,15 @@ const (
 	// A ChmodHook is used to set the file mode of the specified paths.
 	// Deprecated: The chmod hook is deprecated and will be removed in a future release.
 	ChmodHook = HookName("chmod")
+	// A CleanupHook is used to release the resources allocated to a container
+	// and to revert the changes made to its GPUs on exit.
+	CleanupHook = HookName("cleanup")
+	// A ComputeModeHook is used to set the compute mode of the GPUs of a
+	// container. The original compute mode is restored by the CleanupHook.
+	ComputeModeHook = HookName("compute-mode")
+	// A CreateDeviceMetadataHook is used to create a file in the container
+	// that describes the mapping between container and host devices.
+	CreateDeviceMetadataHook = HookName("create-device-metadata")
 	// A CreateSymlinksHook is used to create symlinks in the container.
 	CreateSymlinksHook = HookName("create-symlinks")
 	// DisableDeviceNodeModificationHook refers to the hook used to ensure that
@@ -40,6 +49,12 @@ const (
 	// container.
 	// Added in v1.17.8
 	DisableDeviceNodeModificationHook = HookName("disable-device-node-modification")
+	// A GPULimitsHook is used to apply power and clock limits to the GPUs of a
+	// container. The limits are reverted by the CleanupHook.
+	GPULimitsHook = HookName("gpu-limits")
+	// A GPUMemoryHook is used to check that the memory requested for the GPUs
+	// of a container is available before the container is started.
+	GPUMemoryHook = HookName("gpu-memory")
 	// An EnableCudaCompatHook is used to enabled CUDA Forward Compatibility.
 	// Added in v1.17.5
 	EnableCudaCompatHook = HookName("enable-cuda-compat")
@@ -49,6 +64,9 @@ const (
 	// A CreateSonameSymlinksHook is the hook used to ensure that soname symlinks
 	// for injected libraries exist in the container.
 	CreateSonameSymlinksHook = HookName("create-soname-symlinks")
+	// A ValidateContainerHook is used to check that the injected driver is
+	// usable in the container before the container is started.
+	ValidateContainerHook = HookName("validate-container")
 
 	defaultNvidiaCDIHookPath = "/usr/bin/nvidia-cdi-hook"
 )
@@ -88,6 +106,7 @@ type cdiHookCreator struct {
 
 	fixedArgs    []string
 	debugLogging bool
+	env          []string
 }
 
 // An allDisabledHookCreator is a HookCreator that does not create any hooks.
@@ -120,6 +139,14 @@ func WithNVIDIACDIHookPath(nvidiaCDIHookPath string) Option {
 	}
 }
 
+// WithEnv sets additional environment variables that are set for all hooks
+// created by the CDI hook creator.
+func WithEnv(env ...string) Option {
+	return func(c *cdiHookCreator) {
+		c.env = append(c.env, env...)
+	}
+}
+
 func NewHookCreator(opts ...Option) HookCreator {
 	cdiHookCreator := &cdiHookCreator{
 		nvidiaCDIHookPath: defaultNvidiaCDIHookPath,
@@ -149,7 +176,7 @@ func (c cdiHookCreator) Create(name HookName, args ...string) *Hook {
 		Lifecycle: cdi.CreateContainerHook,
 		Path:      c.nvidiaCDIHookPath,
 		Args:      append(c.requiredArgs(name), c.transformArgs(name, args...)...),
-		Env:       []string{fmt.Sprintf("NVIDIA_CTK_DEBUG=%v", c.debugLogging)},
+		Env:       append([]string{fmt.Sprintf("NVIDIA_CTK_DEBUG=%v", c.debugLogging)}, c.env...),
 	}
 }
 
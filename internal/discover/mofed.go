@@ -16,7 +16,19 @@
 
 package discover
 
-import "github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+import (
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+)
+
+type mofedDiscoverer struct {
+	None
+	logger  logger.Interface
+	devices Discover
+	mounts  Discover
+	hooks   Discover
+}
 
 // NewMOFEDDiscoverer creates a discoverer for MOFED devices.
 func NewMOFEDDiscoverer(logger logger.Interface, devRoot string) (Discover, error) {
@@ -31,3 +43,89 @@ func NewMOFEDDiscoverer(logger logger.Interface, devRoot string) (Discover, erro
 
 	return devices, nil
 }
+
+// NewMOFEDDiscovererWithLibraries creates a discoverer for the InfiniBand
+// device nodes and the user-space RDMA libraries (ibverbs, rdmacm, and the
+// Mellanox providers) installed in the driver root by MOFED or rdma-core. The
+// libibverbs provider configuration is also included as well as the hooks
+// required to update the ldcache in the container. The mounts and hooks are
+// only included if InfiniBand devices are present.
+func NewMOFEDDiscovererWithLibraries(logger logger.Interface, driver *root.Driver, devRoot string, hookCreator HookCreator, ldconfigPath string) (Discover, error) {
+	devices := NewCharDeviceDiscoverer(
+		logger,
+		devRoot,
+		[]string{
+			"/dev/infiniband/uverbs*",
+			"/dev/infiniband/rdma_cm",
+			"/dev/infiniband/umad*",
+			"/dev/infiniband/issm*",
+		},
+	)
+
+	libraries := NewMounts(
+		logger,
+		driver.Libraries(),
+		driver.Root,
+		[]string{
+			"libibverbs.so.*",
+			"librdmacm.so.*",
+			"libibumad.so.*",
+			"libmlx4.so.*",
+			"libmlx5.so.*",
+			"libibverbs/libmlx4-rdmav*.so",
+			"libibverbs/libmlx5-rdmav*.so",
+		},
+	)
+
+	config := NewMounts(
+		logger,
+		lookup.NewDirectoryLocator(lookup.WithLogger(logger), lookup.WithRoot(driver.Root)),
+		driver.Root,
+		[]string{"/etc/libibverbs.d"},
+	)
+
+	ldcacheUpdateHook, err := NewLDCacheUpdateHook(logger, libraries, hookCreator, ldconfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	d := mofedDiscoverer{
+		logger:  logger,
+		devices: devices,
+		mounts:  Merge(libraries, config),
+		hooks:   ldcacheUpdateHook,
+	}
+
+	return &d, nil
+}
+
+// Devices discovers the InfiniBand device nodes.
+func (d *mofedDiscoverer) Devices() ([]Device, error) {
+	return d.devices.Devices()
+}
+
+// Mounts discovers the RDMA libraries and configuration.
+// If no devices are discovered the discovered mounts are empty
+func (d *mofedDiscoverer) Mounts() ([]Mount, error) {
+	if !d.hasDevices() {
+		d.logger.Debugf("No InfiniBand devices detected; skipping detection of mounts")
+		return nil, nil
+	}
+
+	return d.mounts.Mounts()
+}
+
+// Hooks returns the hooks required for the RDMA libraries.
+// If no devices are discovered the discovered hooks are empty
+func (d *mofedDiscoverer) Hooks() ([]Hook, error) {
+	if !d.hasDevices() {
+		return nil, nil
+	}
+
+	return d.hooks.Hooks()
+}
+
+func (d *mofedDiscoverer) hasDevices() bool {
+	devices, err := d.Devices()
+	return err == nil && len(devices) > 0
+}
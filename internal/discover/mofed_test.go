@@ -0,0 +1,137 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+)
+
+func TestMOFEDDiscovererWithLibraries(t *testing.T) {
+	t.Setenv("__NVCT_TESTING_DEVICES_ARE_FILES", "true")
+	logger, _ := testlog.NewNullLogger()
+	hookCreator := NewHookCreator(WithNVIDIACDIHookPath(testNvidiaCDIHookPath))
+
+	testCases := []struct {
+		description     string
+		devices         []string
+		files           []string
+		directories     []string
+		expectedDevices []string
+		expectedMounts  []string
+		expectedHooks   []Hook
+	}{
+		{
+			description: "no devices returns no mounts or hooks",
+			files: []string{
+				"/usr/lib64/libibverbs.so.1.14.50.0",
+			},
+		},
+		{
+			description: "libraries and config are included with devices",
+			devices:     []string{"rdma_cm", "umad0", "uverbs0"},
+			files: []string{
+				"/usr/lib64/libibverbs.so.1.14.50.0",
+				"/usr/lib64/librdmacm.so.1.3.50.0",
+				"/usr/lib64/libmlx5.so.1.24.50.0",
+				"/usr/lib64/libibverbs/libmlx5-rdmav34.so",
+				"/usr/lib64/libcuda.so.1",
+			},
+			directories: []string{
+				"/etc/libibverbs.d",
+			},
+			expectedDevices: []string{
+				"/dev/infiniband/uverbs0",
+				"/dev/infiniband/rdma_cm",
+				"/dev/infiniband/umad0",
+			},
+			expectedMounts: []string{
+				"/usr/lib64/libibverbs.so.1.14.50.0",
+				"/usr/lib64/librdmacm.so.1.3.50.0",
+				"/usr/lib64/libmlx5.so.1.24.50.0",
+				"/usr/lib64/libibverbs/libmlx5-rdmav34.so",
+				"/etc/libibverbs.d",
+			},
+			expectedHooks: []Hook{
+				{
+					Lifecycle: "createContainer",
+					Path:      testNvidiaCDIHookPath,
+					Args:      []string{"nvidia-cdi-hook", "create-soname-symlinks", "--folder", "/usr/lib64", "--folder", "/usr/lib64/libibverbs"},
+					Env:       []string{"NVIDIA_CTK_DEBUG=false"},
+				},
+				{
+					Lifecycle: "createContainer",
+					Path:      testNvidiaCDIHookPath,
+					Args:      []string{"nvidia-cdi-hook", "update-ldcache", "--folder", "/usr/lib64", "--folder", "/usr/lib64/libibverbs"},
+					Env:       []string{"NVIDIA_CTK_DEBUG=false"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			driverRoot := t.TempDir()
+			require.NoError(t, os.MkdirAll(filepath.Join(driverRoot, "dev", "infiniband"), 0755))
+			for _, device := range tc.devices {
+				require.NoError(t, os.WriteFile(filepath.Join(driverRoot, "dev", "infiniband", device), nil, 0600))
+			}
+			for _, file := range tc.files {
+				require.NoError(t, os.MkdirAll(filepath.Join(driverRoot, filepath.Dir(file)), 0755))
+				require.NoError(t, os.WriteFile(filepath.Join(driverRoot, file), nil, 0600))
+			}
+			for _, directory := range tc.directories {
+				require.NoError(t, os.MkdirAll(filepath.Join(driverRoot, directory), 0755))
+			}
+
+			driver := root.New(
+				root.WithLogger(logger),
+				root.WithDriverRoot(driverRoot),
+			)
+			d, err := NewMOFEDDiscovererWithLibraries(logger, driver, driverRoot, hookCreator, "")
+			require.NoError(t, err)
+
+			devices, err := d.Devices()
+			require.NoError(t, err)
+			var devicePaths []string
+			for _, device := range devices {
+				devicePaths = append(devicePaths, device.Path)
+			}
+			require.EqualValues(t, tc.expectedDevices, devicePaths)
+
+			mounts, err := d.Mounts()
+			require.NoError(t, err)
+			var mountPaths []string
+			for _, mount := range mounts {
+				require.Equal(t, filepath.Join(driverRoot, mount.Path), mount.HostPath)
+				mountPaths = append(mountPaths, mount.Path)
+			}
+			require.EqualValues(t, tc.expectedMounts, mountPaths)
+
+			hooks, err := d.Hooks()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedHooks, hooks)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/plugin"
+)
+
+// pluginDiscoverer returns the container edits generated by an external
+// plugin. The plugin is invoked at most once and its response is reused for
+// all queries.
+type pluginDiscoverer struct {
+	logger  logger.Interface
+	path    string
+	request *plugin.Request
+
+	once     sync.Once
+	response *plugin.Response
+	err      error
+}
+
+var _ Discover = (*pluginDiscoverer)(nil)
+
+// NewPluginDiscoverer creates a discoverer for the devices, mounts, hooks,
+// and environment variables returned by the plugin at the specified path.
+func NewPluginDiscoverer(logger logger.Interface, path string, request *plugin.Request) Discover {
+	return &pluginDiscoverer{
+		logger:  logger,
+		path:    path,
+		request: request,
+	}
+}
+
+// NewPluginDiscoverers creates a merged discoverer for the specified plugins.
+func NewPluginDiscoverers(logger logger.Interface, paths []string, request *plugin.Request) Discover {
+	var discoverers []Discover
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		discoverers = append(discoverers, NewPluginDiscoverer(logger, path, request))
+	}
+	return Merge(discoverers...)
+}
+
+func (d *pluginDiscoverer) getResponse() (*plugin.Response, error) {
+	d.once.Do(func() {
+		d.logger.Debugf("Invoking plugin %v", d.path)
+		d.response, d.err = plugin.Exec(context.Background(), d.path, plugin.DefaultTimeout, d.request)
+	})
+	return d.response, d.err
+}
+
+// Devices returns the device nodes returned by the plugin.
+func (d *pluginDiscoverer) Devices() ([]Device, error) {
+	response, err := d.getResponse()
+	if err != nil {
+		return nil, err
+	}
+	var devices []Device
+	for _, node := range response.ContainerEdits.DeviceNodes {
+		if node == nil {
+			continue
+		}
+		hostPath := node.HostPath
+		if hostPath == "" {
+			hostPath = node.Path
+		}
+		devices = append(devices, Device{
+			HostPath: hostPath,
+			Path:     node.Path,
+		})
+	}
+	return devices, nil
+}
+
+// EnvVars returns the environment variables returned by the plugin.
+func (d *pluginDiscoverer) EnvVars() ([]EnvVar, error) {
+	response, err := d.getResponse()
+	if err != nil {
+		return nil, err
+	}
+	var envVars []EnvVar
+	for _, env := range response.ContainerEdits.Env {
+		name, value, _ := strings.Cut(env, "=")
+		envVars = append(envVars, EnvVar{
+			Name:  name,
+			Value: value,
+		})
+	}
+	return envVars, nil
+}
+
+// Mounts returns the mounts returned by the plugin.
+func (d *pluginDiscoverer) Mounts() ([]Mount, error) {
+	response, err := d.getResponse()
+	if err != nil {
+		return nil, err
+	}
+	var mounts []Mount
+	for _, m := range response.ContainerEdits.Mounts {
+		if m == nil {
+			continue
+		}
+		mounts = append(mounts, Mount{
+			HostPath: m.HostPath,
+			Path:     m.ContainerPath,
+			Options:  m.Options,
+		})
+	}
+	return mounts, nil
+}
+
+// Hooks returns the hooks returned by the plugin.
+func (d *pluginDiscoverer) Hooks() ([]Hook, error) {
+	response, err := d.getResponse()
+	if err != nil {
+		return nil, err
+	}
+	var hooks []Hook
+	for _, h := range response.ContainerEdits.Hooks {
+		if h == nil {
+			continue
+		}
+		hooks = append(hooks, Hook{
+			Lifecycle: h.HookName,
+			Path:      h.Path,
+			Args:      h.Args,
+			Env:       h.Env,
+		})
+	}
+	return hooks, nil
+}
@@ -0,0 +1,156 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+const (
+	defaultSysfsRoot = "/sys"
+	// unknownNUMANode is reported by sysfs if a device is not associated with
+	// a specific NUMA node.
+	unknownNUMANode = -1
+)
+
+type gpuDirectRDMA struct {
+	None
+	logger    logger.Interface
+	sysfsRoot string
+	devices   Discover
+}
+
+// NewGPUDirectRDMADiscoverer creates a discoverer for the prerequisites of
+// GPUDirect RDMA using the nvidia-peermem kernel module. This includes the
+// RDMA connection manager device node. Note that InfiniBand uverbs devices
+// are not included.
+func NewGPUDirectRDMADiscoverer(logger logger.Interface, devRoot string) (Discover, error) {
+	return newGPUDirectRDMADiscoverer(logger, devRoot, defaultSysfsRoot), nil
+}
+
+func newGPUDirectRDMADiscoverer(logger logger.Interface, devRoot string, sysfsRoot string) *gpuDirectRDMA {
+	return &gpuDirectRDMA{
+		logger:    logger,
+		sysfsRoot: sysfsRoot,
+		devices: NewCharDeviceDiscoverer(
+			logger,
+			devRoot,
+			[]string{"/dev/infiniband/rdma_cm"},
+		),
+	}
+}
+
+// Devices returns the device nodes required for GPUDirect RDMA.
+// A warning is logged if the nvidia-peermem kernel module is not loaded since
+// GPU memory cannot be registered with the RDMA stack in this case.
+func (d *gpuDirectRDMA) Devices() ([]Device, error) {
+	if _, err := os.Stat(filepath.Join(d.sysfsRoot, "module", "nvidia_peermem")); err != nil {
+		d.logger.Warningf("The nvidia-peermem kernel module is not loaded; GPUDirect RDMA will not be available")
+	}
+	return d.devices.Devices()
+}
+
+type colocatedUverbs struct {
+	None
+	logger    logger.Interface
+	sysfsRoot string
+	pciBusIDs []string
+	devices   Discover
+}
+
+// NewColocatedUverbsDiscoverer creates a discoverer for the InfiniBand uverbs
+// devices that are co-located with the GPUs with the specified PCI bus IDs.
+// A uverbs device is considered co-located if its associated PCI device is on
+// the same NUMA node as one of the GPUs. If the NUMA node of either device is
+// unknown, the uverbs device is included.
+func NewColocatedUverbsDiscoverer(logger logger.Interface, devRoot string, pciBusIDs ...string) Discover {
+	return newColocatedUverbsDiscoverer(logger, devRoot, defaultSysfsRoot, pciBusIDs...)
+}
+
+func newColocatedUverbsDiscoverer(logger logger.Interface, devRoot string, sysfsRoot string, pciBusIDs ...string) *colocatedUverbs {
+	return &colocatedUverbs{
+		logger:    logger,
+		sysfsRoot: sysfsRoot,
+		pciBusIDs: pciBusIDs,
+		devices: NewCharDeviceDiscoverer(
+			logger,
+			devRoot,
+			[]string{"/dev/infiniband/uverbs*"},
+		),
+	}
+}
+
+// Devices returns the uverbs device nodes that are co-located with the
+// specified GPUs.
+func (d *colocatedUverbs) Devices() ([]Device, error) {
+	if len(d.pciBusIDs) == 0 {
+		return nil, nil
+	}
+
+	candidates, err := d.devices.Devices()
+	if err != nil {
+		return nil, err
+	}
+
+	gpuNUMANodes := make(map[int]bool)
+	for _, pciBusID := range d.pciBusIDs {
+		node := d.getNUMANode(filepath.Join(d.sysfsRoot, "bus", "pci", "devices", normalizePCIBusID(pciBusID)))
+		gpuNUMANodes[node] = true
+	}
+
+	var devices []Device
+	for _, candidate := range candidates {
+		node := d.getNUMANode(filepath.Join(d.sysfsRoot, "class", "infiniband_verbs", filepath.Base(candidate.Path), "device"))
+		if node != unknownNUMANode && !gpuNUMANodes[node] && !gpuNUMANodes[unknownNUMANode] {
+			d.logger.Debugf("Skipping %v on NUMA node %d", candidate.Path, node)
+			continue
+		}
+		devices = append(devices, candidate)
+	}
+	return devices, nil
+}
+
+// getNUMANode returns the NUMA node of the specified sysfs device.
+// If this cannot be determined, the NUMA node is reported as unknown.
+func (d *colocatedUverbs) getNUMANode(devicePath string) int {
+	contents, err := os.ReadFile(filepath.Join(devicePath, "numa_node"))
+	if err != nil {
+		d.logger.Debugf("Failed to read NUMA node for %v: %v", devicePath, err)
+		return unknownNUMANode
+	}
+	node, err := strconv.Atoi(strings.TrimSpace(string(contents)))
+	if err != nil {
+		return unknownNUMANode
+	}
+	return node
+}
+
+// normalizePCIBusID converts a PCI bus ID as reported by NVML (e.g.
+// 00000000:3B:00.0) to the format used in sysfs (e.g. 0000:3b:00.0).
+func normalizePCIBusID(pciBusID string) string {
+	id := strings.ToLower(pciBusID)
+	if domain, rest, found := strings.Cut(id, ":"); found && len(domain) > 4 {
+		id = domain[len(domain)-4:] + ":" + rest
+	}
+	return id
+}
@@ -0,0 +1,116 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColocatedUverbs(t *testing.T) {
+	t.Setenv("__NVCT_TESTING_DEVICES_ARE_FILES", "true")
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description     string
+		pciBusIDs       []string
+		gpuNUMANodes    map[string]string
+		uverbsNUMANodes map[string]string
+		expectedDevices []string
+	}{
+		{
+			description: "no GPUs returns no devices",
+			uverbsNUMANodes: map[string]string{
+				"uverbs0": "0",
+			},
+		},
+		{
+			description: "devices on same NUMA node are returned",
+			pciBusIDs:   []string{"00000000:3B:00.0"},
+			gpuNUMANodes: map[string]string{
+				"0000:3b:00.0": "1",
+			},
+			uverbsNUMANodes: map[string]string{
+				"uverbs0": "0",
+				"uverbs1": "1",
+			},
+			expectedDevices: []string{"/dev/infiniband/uverbs1"},
+		},
+		{
+			description: "devices with unknown NUMA node are returned",
+			pciBusIDs:   []string{"0000:3b:00.0"},
+			gpuNUMANodes: map[string]string{
+				"0000:3b:00.0": "1",
+			},
+			uverbsNUMANodes: map[string]string{
+				"uverbs0": "0",
+				"uverbs1": "-1",
+			},
+			expectedDevices: []string{"/dev/infiniband/uverbs1"},
+		},
+		{
+			description: "all devices returned for GPU with unknown NUMA node",
+			pciBusIDs:   []string{"0000:3b:00.0"},
+			gpuNUMANodes: map[string]string{
+				"0000:3b:00.0": "-1",
+			},
+			uverbsNUMANodes: map[string]string{
+				"uverbs0": "0",
+				"uverbs1": "1",
+			},
+			expectedDevices: []string{"/dev/infiniband/uverbs0", "/dev/infiniband/uverbs1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			devRoot := t.TempDir()
+			sysfsRoot := t.TempDir()
+
+			require.NoError(t, os.MkdirAll(filepath.Join(devRoot, "dev", "infiniband"), 0755))
+			for name, node := range tc.uverbsNUMANodes {
+				require.NoError(t, os.WriteFile(filepath.Join(devRoot, "dev", "infiniband", name), nil, 0600))
+				writeNUMANode(t, filepath.Join(sysfsRoot, "class", "infiniband_verbs", name, "device"), node)
+			}
+			for address, node := range tc.gpuNUMANodes {
+				writeNUMANode(t, filepath.Join(sysfsRoot, "bus", "pci", "devices", address), node)
+			}
+
+			d := newColocatedUverbsDiscoverer(logger, devRoot, sysfsRoot, tc.pciBusIDs...)
+
+			devices, err := d.Devices()
+			require.NoError(t, err)
+
+			var paths []string
+			for _, device := range devices {
+				require.Equal(t, filepath.Join(devRoot, device.Path), device.HostPath)
+				paths = append(paths, device.Path)
+			}
+			require.EqualValues(t, tc.expectedDevices, paths)
+		})
+	}
+}
+
+func writeNUMANode(t *testing.T, devicePath string, node string) {
+	require.NoError(t, os.MkdirAll(devicePath, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(devicePath, "numa_node"), []byte(node+"\n"), 0600))
+}
@@ -0,0 +1,70 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package failinject allows tests to inject controlled failures into the
+// runtime path of the NVIDIA Container Toolkit so that error handling and error
+// codes can be exercised deterministically. Failures are only injected if
+// they are explicitly requested through the __NVCT_TESTING_INJECT_FAILURES
+// environment variable. This is intended for use in e2e and integration tests
+// only and is not a supported configuration.
+package failinject
+
+import (
+	"os"
+	"strings"
+)
+
+const (
+	// EnvVar is the environment variable used to request injected failures.
+	// The value is a comma-separated list of failures.
+	EnvVar = "__NVCT_TESTING_INJECT_FAILURES"
+)
+
+// A Failure identifies a failure that can be injected.
+type Failure string
+
+const (
+	// NVMLInit causes the initialization of NVML to fail.
+	NVMLInit = Failure("nvml-init")
+	// MissingDeviceNode causes the host device nodes of injected NVIDIA
+	// devices to be reported as missing.
+	MissingDeviceNode = Failure("missing-device-node")
+	// HookTimeout causes the nvidia-cdi-hook to block until it is killed so
+	// that the hook timeout of the low-level runtime is triggered.
+	HookTimeout = Failure("hook-timeout")
+)
+
+// Enabled returns true if the specified failure has been requested.
+func Enabled(f Failure) bool {
+	for _, requested := range strings.Split(os.Getenv(EnvVar), ",") {
+		if Failure(strings.TrimSpace(requested)) == f {
+			return true
+		}
+	}
+	return false
+}
+
+// Env returns the environment entry that propagates the requested failures
+// to processes that do not inherit the environment of the current process
+// (e.g. OCI hooks). If no failures were requested, nil is returned.
+func Env() []string {
+	value := os.Getenv(EnvVar)
+	if value == "" {
+		return nil
+	}
+	return []string{EnvVar + "=" + value}
+}
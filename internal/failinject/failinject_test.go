@@ -0,0 +1,70 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package failinject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnabled(t *testing.T) {
+	testCases := []struct {
+		description string
+		value       string
+		failure     Failure
+		expected    bool
+	}{
+		{
+			description: "unset envvar enables nothing",
+			failure:     NVMLInit,
+		},
+		{
+			description: "single failure is enabled",
+			value:       "nvml-init",
+			failure:     NVMLInit,
+			expected:    true,
+		},
+		{
+			description: "failure in list is enabled",
+			value:       "missing-device-node, hook-timeout",
+			failure:     HookTimeout,
+			expected:    true,
+		},
+		{
+			description: "other failure is not enabled",
+			value:       "missing-device-node",
+			failure:     NVMLInit,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			t.Setenv(EnvVar, tc.value)
+			require.Equal(t, tc.expected, Enabled(tc.failure))
+		})
+	}
+}
+
+func TestEnv(t *testing.T) {
+	t.Setenv(EnvVar, "")
+	require.Nil(t, Env())
+
+	t.Setenv(EnvVar, "hook-timeout")
+	require.Equal(t, []string{"__NVCT_TESTING_INJECT_FAILURES=hook-timeout"}, Env())
+}
@@ -0,0 +1,47 @@
+//go:build !windows
+
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package failinject
+
+import (
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+)
+
+// NVML returns an NVML library that fails to initialize if the NVMLInit
+// failure has been requested. Otherwise the specified library is returned.
+func NVML(lib nvml.Interface) nvml.Interface {
+	if !Enabled(NVMLInit) {
+		return lib
+	}
+	return &failingNVML{Interface: lib}
+}
+
+type failingNVML struct {
+	nvml.Interface
+}
+
+// Init always returns an error for a failingNVML.
+func (l *failingNVML) Init() nvml.Return {
+	return nvml.ERROR_LIBRARY_NOT_FOUND
+}
+
+// InitWithFlags always returns an error for a failingNVML.
+func (l *failingNVML) InitWithFlags(uint32) nvml.Return {
+	return nvml.ERROR_LIBRARY_NOT_FOUND
+}
@@ -0,0 +1,43 @@
+//go:build !windows
+
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package failinject
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNVML(t *testing.T) {
+	lib := &mock.Interface{
+		InitFunc: func() nvml.Return {
+			return nvml.SUCCESS
+		},
+	}
+
+	t.Setenv(EnvVar, "")
+	require.Equal(t, nvml.SUCCESS, NVML(lib).Init())
+
+	t.Setenv(EnvVar, "nvml-init")
+	require.Equal(t, nvml.ERROR_LIBRARY_NOT_FOUND, NVML(lib).Init())
+	require.Equal(t, nvml.ERROR_LIBRARY_NOT_FOUND, NVML(lib).InitWithFlags(0))
+}
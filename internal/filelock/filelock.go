@@ -0,0 +1,110 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package filelock implements advisory locking for files that are written by
+// more than one NVIDIA Container Toolkit component. Since files such as
+// container engine configs and CDI specifications may be replaced, a lock is
+// held on an associated lock file instead of on the file itself.
+package filelock
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// A Lock represents an exclusive lock for a file.
+type Lock struct {
+	file     *os.File
+	lockPath string
+}
+
+// held records the lock files for which a lock is held by the current
+// process.
+var held = struct {
+	sync.Mutex
+	lockPaths map[string]int
+}{
+	lockPaths: make(map[string]int),
+}
+
+// Acquire acquires an exclusive lock for the specified path. The call blocks
+// until the lock can be acquired. The lock must be released by calling Unlock.
+func Acquire(path string) (*Lock, error) {
+	lockPath := path + ".lock"
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory for lock file: %w", err)
+	}
+	f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+	if err := lockFile(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to lock %v: %w", path, err)
+	}
+
+	held.Lock()
+	defer held.Unlock()
+	held.lockPaths[lockPath]++
+
+	return &Lock{file: f, lockPath: lockPath}, nil
+}
+
+// IsHeld returns whether a lock for the specified path is held by the current
+// process. This allows a file that is written while the caller holds the lock
+// (e.g. to load, update, and save a config) to be written without acquiring
+// the lock again, which would block.
+func IsHeld(path string) bool {
+	held.Lock()
+	defer held.Unlock()
+	return held.lockPaths[path+".lock"] > 0
+}
+
+// Unlock releases the lock.
+func (l *Lock) Unlock() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+	defer func() {
+		l.file.Close()
+		l.file = nil
+
+		held.Lock()
+		defer held.Unlock()
+		if held.lockPaths[l.lockPath]--; held.lockPaths[l.lockPath] <= 0 {
+			delete(held.lockPaths, l.lockPath)
+		}
+	}()
+	return unlockFile(l.file)
+}
+
+// WithLock runs the specified function while holding an exclusive lock for
+// the specified path.
+func WithLock(path string, f func() error) (rerr error) {
+	l, err := Acquire(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := l.Unlock(); err != nil && rerr == nil {
+			rerr = fmt.Errorf("failed to unlock %v: %w", path, err)
+		}
+	}()
+	return f()
+}
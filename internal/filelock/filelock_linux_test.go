@@ -0,0 +1,104 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package filelock
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcquire(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config", "config.toml")
+
+	l, err := Acquire(path)
+	require.NoError(t, err)
+	require.FileExists(t, path+".lock")
+
+	acquired := make(chan struct{})
+	go func() {
+		other, err := Acquire(path)
+		if err == nil {
+			_ = other.Unlock()
+		}
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("lock acquired while held")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	require.NoError(t, l.Unlock())
+	select {
+	case <-acquired:
+	case <-time.After(5 * time.Second):
+		t.Fatal("lock not acquired after release")
+	}
+
+	// Unlocking a released lock is a no-op.
+	require.NoError(t, l.Unlock())
+}
+
+func TestIsHeld(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	require.False(t, IsHeld(path))
+
+	l, err := Acquire(path)
+	require.NoError(t, err)
+	require.True(t, IsHeld(path))
+	require.False(t, IsHeld(path+".other"))
+
+	require.NoError(t, l.Unlock())
+	require.False(t, IsHeld(path))
+
+	// Unlocking a released lock does not affect other locks for the path.
+	l, err = Acquire(path)
+	require.NoError(t, err)
+	other := &Lock{}
+	require.NoError(t, other.Unlock())
+	require.True(t, IsHeld(path))
+	require.NoError(t, l.Unlock())
+	require.NoError(t, l.Unlock())
+	require.False(t, IsHeld(path))
+}
+
+func TestWithLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nvidia.yaml")
+
+	err := WithLock(path, func() error {
+		return os.WriteFile(path, []byte("cdiVersion: 0.5.0\n"), 0600)
+	})
+	require.NoError(t, err)
+	require.FileExists(t, path)
+
+	expectedErr := os.ErrInvalid
+	err = WithLock(path, func() error {
+		return expectedErr
+	})
+	require.ErrorIs(t, err, expectedErr)
+
+	// The lock is released after the function returns.
+	l, err := Acquire(path)
+	require.NoError(t, err)
+	require.NoError(t, l.Unlock())
+}
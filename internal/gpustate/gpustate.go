@@ -0,0 +1,178 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package gpustate implements the helpers shared by the hooks that change the
+// settings of the GPUs of a container for the lifetime of the container. The
+// original settings are recorded in a state file so that they can be restored
+// when the container exits.
+package gpustate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+)
+
+// computeModes maps the supported compute mode names to the NVML compute
+// modes. The deprecated EXCLUSIVE_THREAD mode is not supported.
+var computeModes = map[string]nvml.ComputeMode{
+	"DEFAULT":           nvml.COMPUTEMODE_DEFAULT,
+	"EXCLUSIVE_PROCESS": nvml.COMPUTEMODE_EXCLUSIVE_PROCESS,
+	"PROHIBITED":        nvml.COMPUTEMODE_PROHIBITED,
+}
+
+// ParseComputeMode returns the NVML compute mode with the specified name.
+func ParseComputeMode(name string) (nvml.ComputeMode, error) {
+	mode, ok := computeModes[name]
+	if !ok {
+		return 0, fmt.Errorf("invalid compute mode %q", name)
+	}
+	return mode, nil
+}
+
+// ParsePowerLimit parses a power limit in watts and returns it in milliwatts
+// as expected by NVML.
+func ParsePowerLimit(value string) (uint32, error) {
+	watts, err := strconv.ParseUint(value, 10, 32)
+	if err != nil || watts == 0 || watts > 1<<32/1000 {
+		return 0, fmt.Errorf("invalid power limit %q", value)
+	}
+	return uint32(watts * 1000), nil
+}
+
+// ParseClocks parses a range of GPU clocks in MHz of the form <min>-<max>. A
+// single value locks the clocks to that value.
+func ParseClocks(value string) (uint32, uint32, error) {
+	minValue, maxValue, isRange := strings.Cut(value, "-")
+	if !isRange {
+		maxValue = minValue
+	}
+	minClock, err := strconv.ParseUint(minValue, 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid clocks %q", value)
+	}
+	maxClock, err := strconv.ParseUint(maxValue, 10, 32)
+	if err != nil || maxClock < minClock || maxClock == 0 {
+		return 0, 0, fmt.Errorf("invalid clocks %q", value)
+	}
+	return uint32(minClock), uint32(maxClock), nil
+}
+
+// ParseMemory parses an amount of GPU memory and returns it in MiB. The value
+// may have an M (MiB) or G (GiB) suffix and is interpreted as MiB otherwise.
+func ParseMemory(value string) (uint64, error) {
+	number := strings.TrimSuffix(strings.TrimSuffix(strings.ToUpper(value), "B"), "I")
+	multiplier := uint64(1)
+	switch {
+	case strings.HasSuffix(number, "G"):
+		multiplier = 1024
+		number = strings.TrimSuffix(number, "G")
+	case strings.HasSuffix(number, "M"):
+		number = strings.TrimSuffix(number, "M")
+	}
+	mib, err := strconv.ParseUint(number, 10, 32)
+	if err != nil || mib == 0 {
+		return 0, fmt.Errorf("invalid GPU memory %q", value)
+	}
+	return mib * multiplier, nil
+}
+
+// ParseThreadPercentage parses the percentage of the threads of a GPU that
+// are available to a container.
+func ParseThreadPercentage(value string) (uint32, error) {
+	percentage, err := strconv.ParseUint(value, 10, 32)
+	if err != nil || percentage == 0 || percentage > 100 {
+		return 0, fmt.Errorf("invalid thread percentage %q", value)
+	}
+	return uint32(percentage), nil
+}
+
+// Devices returns the GPUs with the specified indices or UUIDs. The value
+// 'all' selects all GPUs. MIG devices are skipped since their settings are
+// those of the parent GPU.
+func Devices(nvmllib nvml.Interface, ids ...string) ([]nvml.Device, error) {
+	var devices []nvml.Device
+	for _, id := range ids {
+		switch {
+		case id == "all":
+			count, ret := nvmllib.DeviceGetCount()
+			if ret != nvml.SUCCESS {
+				return nil, fmt.Errorf("failed to get device count: %v", ret)
+			}
+			for i := 0; i < count; i++ {
+				device, ret := nvmllib.DeviceGetHandleByIndex(i)
+				if ret != nvml.SUCCESS {
+					return nil, fmt.Errorf("failed to get device %d: %v", i, ret)
+				}
+				devices = append(devices, device)
+			}
+		case strings.Contains(id, ":") || strings.HasPrefix(id, "MIG-"):
+			continue
+		case strings.HasPrefix(id, "GPU-"):
+			device, ret := nvmllib.DeviceGetHandleByUUID(id)
+			if ret != nvml.SUCCESS {
+				return nil, fmt.Errorf("failed to get device %v: %v", id, ret)
+			}
+			devices = append(devices, device)
+		default:
+			index, err := strconv.Atoi(id)
+			if err != nil {
+				return nil, fmt.Errorf("invalid device %q", id)
+			}
+			device, ret := nvmllib.DeviceGetHandleByIndex(index)
+			if ret != nvml.SUCCESS {
+				return nil, fmt.Errorf("failed to get device %d: %v", index, ret)
+			}
+			devices = append(devices, device)
+		}
+	}
+	return devices, nil
+}
+
+// Save writes the specified state to the file at path. The parent directory
+// is created if required.
+func Save(path string, state any) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	return nil
+}
+
+// Load reads the state from the file at path. If the file does not exist, an
+// error satisfying errors.Is(err, os.ErrNotExist) is returned.
+func Load(path string, state any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return fmt.Errorf("failed to parse state: %w", err)
+	}
+	return nil
+}
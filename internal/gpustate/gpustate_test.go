@@ -0,0 +1,132 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package gpustate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClocks(t *testing.T) {
+	testCases := []struct {
+		value         string
+		expectedMin   uint32
+		expectedMax   uint32
+		expectedError bool
+	}{
+		{value: "1400", expectedMin: 1400, expectedMax: 1400},
+		{value: "1000-1400", expectedMin: 1000, expectedMax: 1400},
+		{value: "1400-1000", expectedError: true},
+		{value: "0", expectedError: true},
+		{value: "fast", expectedError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			minClock, maxClock, err := ParseClocks(tc.value)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedMin, minClock)
+			require.Equal(t, tc.expectedMax, maxClock)
+		})
+	}
+}
+
+func TestParsePowerLimit(t *testing.T) {
+	testCases := []struct {
+		value         string
+		expectedLimit uint32
+		expectedError bool
+	}{
+		{value: "250", expectedLimit: 250000},
+		{value: "0", expectedError: true},
+		{value: "-1", expectedError: true},
+		{value: "250W", expectedError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			limit, err := ParsePowerLimit(tc.value)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedLimit, limit)
+		})
+	}
+}
+
+func TestParseMemory(t *testing.T) {
+	testCases := []struct {
+		value          string
+		expectedMemory uint64
+		expectedError  bool
+	}{
+		{value: "8192", expectedMemory: 8192},
+		{value: "8192M", expectedMemory: 8192},
+		{value: "8192MiB", expectedMemory: 8192},
+		{value: "8G", expectedMemory: 8192},
+		{value: "8gb", expectedMemory: 8192},
+		{value: "0", expectedError: true},
+		{value: "8T", expectedError: true},
+		{value: "-1G", expectedError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			memory, err := ParseMemory(tc.value)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedMemory, memory)
+		})
+	}
+}
+
+func TestParseThreadPercentage(t *testing.T) {
+	testCases := []struct {
+		value              string
+		expectedPercentage uint32
+		expectedError      bool
+	}{
+		{value: "50", expectedPercentage: 50},
+		{value: "100", expectedPercentage: 100},
+		{value: "0", expectedError: true},
+		{value: "101", expectedError: true},
+		{value: "50%", expectedError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			percentage, err := ParseThreadPercentage(tc.value)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedPercentage, percentage)
+		})
+	}
+}
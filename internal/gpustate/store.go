@@ -0,0 +1,203 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package gpustate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/filelock"
+)
+
+// A Store records the original settings of the GPUs that were changed for
+// containers. The settings are recorded per GPU so that a GPU that is shared
+// by multiple containers is only restored once the last of these exits. The
+// store is locked while it is open to serialize concurrent hook invocations.
+type Store struct {
+	dir  string
+	lock *filelock.Lock
+}
+
+// A Record holds the original settings of a GPU, the settings that were
+// applied, and the IDs of the containers that use the applied settings.
+type Record struct {
+	Original   json.RawMessage `json:"original"`
+	Applied    json.RawMessage `json:"applied"`
+	Containers []string        `json:"containers"`
+}
+
+// OpenStore opens the store in the specified directory. The call blocks until
+// no other process has the store open. The store must be closed by calling
+// Close.
+func OpenStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create state directory: %w", err)
+	}
+	lock, err := filelock.Acquire(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{dir: dir, lock: lock}, nil
+}
+
+// Close releases the lock on the store.
+func (s *Store) Close() error {
+	return s.lock.Unlock()
+}
+
+// Claim records that the specified container uses the applied settings for
+// the GPU with the specified UUID.
+// If no settings were applied to the GPU, a record with the specified
+// original settings is written and true is returned to indicate that the
+// caller must apply the settings. The record is written before the settings
+// are applied so that these can be reverted even if the caller fails part way.
+// If the same settings were already applied for another container, the
+// container is added to the users of the GPU and false is returned.
+// An error is returned if different settings were applied to the GPU.
+func (s *Store) Claim(uuid string, containerID string, original any, applied any) (bool, error) {
+	appliedData, err := json.Marshal(applied)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal applied settings: %w", err)
+	}
+
+	r, err := s.get(uuid)
+	if err != nil {
+		return false, err
+	}
+	if r != nil {
+		if !bytes.Equal(r.Applied, appliedData) {
+			return false, fmt.Errorf("GPU %v was changed to different settings for container(s) %v", uuid, strings.Join(r.Containers, ", "))
+		}
+		if !slices.Contains(r.Containers, containerID) {
+			r.Containers = append(r.Containers, containerID)
+		}
+		return false, s.put(uuid, r)
+	}
+
+	originalData, err := json.Marshal(original)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal original settings: %w", err)
+	}
+	r = &Record{
+		Original:   originalData,
+		Applied:    appliedData,
+		Containers: []string{containerID},
+	}
+	if err := s.put(uuid, r); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Claimed returns the UUIDs of the GPUs that were claimed by the specified
+// container.
+func (s *Store) Claimed(containerID string) ([]string, error) {
+	return claimed(s.dir, containerID)
+}
+
+// Release removes the specified container from the users of the GPU with the
+// specified UUID. If the container is the last user of the GPU, the record is
+// returned unchanged so that the caller can restore the original settings and
+// then Delete the record. Otherwise nil is returned.
+func (s *Store) Release(uuid string, containerID string) (*Record, error) {
+	r, err := s.get(uuid)
+	if err != nil || r == nil {
+		return nil, err
+	}
+	remaining := slices.DeleteFunc(slices.Clone(r.Containers), func(id string) bool {
+		return id == containerID
+	})
+	if len(remaining) == 0 {
+		return r, nil
+	}
+	r.Containers = remaining
+	return nil, s.put(uuid, r)
+}
+
+// Delete removes the record for the GPU with the specified UUID.
+func (s *Store) Delete(uuid string) error {
+	err := os.Remove(s.path(uuid))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove state of %v: %w", uuid, err)
+	}
+	return nil
+}
+
+// GetOriginal unmarshals the original settings of the record into v.
+func (r *Record) GetOriginal(v any) error {
+	if err := json.Unmarshal(r.Original, v); err != nil {
+		return fmt.Errorf("failed to parse original settings: %w", err)
+	}
+	return nil
+}
+
+// HasClaims returns whether the specified container claimed any GPUs in the
+// store in the specified directory. The store is not locked so that callers
+// can check whether any changes must be reverted before opening the store.
+func HasClaims(dir string, containerID string) bool {
+	uuids, err := claimed(dir, containerID)
+	return err == nil && len(uuids) > 0
+}
+
+func claimed(dir string, containerID string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var uuids []string
+	for _, path := range paths {
+		var r Record
+		err := Load(path, &r)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %v: %w", path, err)
+		}
+		if slices.Contains(r.Containers, containerID) {
+			uuids = append(uuids, strings.TrimSuffix(filepath.Base(path), ".json"))
+		}
+	}
+	return uuids, nil
+}
+
+func (s *Store) get(uuid string) (*Record, error) {
+	var r Record
+	err := Load(s.path(uuid), &r)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load state of %v: %w", uuid, err)
+	}
+	return &r, nil
+}
+
+func (s *Store) put(uuid string, r *Record) error {
+	return Save(s.path(uuid), r)
+}
+
+func (s *Store) path(uuid string) string {
+	return filepath.Join(s.dir, uuid+".json")
+}
@@ -0,0 +1,63 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package gpustate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := OpenStore(dir)
+	require.NoError(t, err)
+	defer store.Close()
+
+	claimed, err := store.Claim("GPU-0", "first", "DEFAULT", "EXCLUSIVE_PROCESS")
+	require.NoError(t, err)
+	require.True(t, claimed)
+	require.True(t, HasClaims(dir, "first"))
+
+	claimed, err = store.Claim("GPU-0", "second", "EXCLUSIVE_PROCESS", "EXCLUSIVE_PROCESS")
+	require.NoError(t, err)
+	require.False(t, claimed)
+
+	_, err = store.Claim("GPU-0", "third", "EXCLUSIVE_PROCESS", "PROHIBITED")
+	require.Error(t, err)
+	require.False(t, HasClaims(dir, "third"))
+
+	uuids, err := store.Claimed("second")
+	require.NoError(t, err)
+	require.Equal(t, []string{"GPU-0"}, uuids)
+
+	record, err := store.Release("GPU-0", "first")
+	require.NoError(t, err)
+	require.Nil(t, record)
+	require.False(t, HasClaims(dir, "first"))
+
+	record, err = store.Release("GPU-0", "second")
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	var original string
+	require.NoError(t, record.GetOriginal(&original))
+	require.Equal(t, "DEFAULT", original)
+
+	require.NoError(t, store.Delete("GPU-0"))
+	require.False(t, HasClaims(dir, "second"))
+}
@@ -0,0 +1,118 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package health implements the reporting of GPU health to containers. A
+// host-side watcher records the health of each GPU in a status file in a
+// shared directory. This directory is mounted into containers so that
+// workloads can detect that one of their GPUs has become unusable (e.g. due to
+// a double-bit ECC error or the GPU falling off the bus).
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// DefaultDir is the directory on the host where the health status files
+	// are written.
+	DefaultDir = "/var/run/nvidia-container-toolkit/health"
+	// ContainerDir is the path at which the health directory is mounted in
+	// containers.
+	ContainerDir = "/run/nvidia/health"
+	// EnvVarHealthDir is the envvar used to expose the ContainerDir to
+	// workloads.
+	EnvVarHealthDir = "NVIDIA_DEVICE_HEALTH_DIR"
+)
+
+// A State describes the health of a device.
+type State string
+
+const (
+	// StateHealthy indicates that no critical errors have been reported for a
+	// device.
+	StateHealthy = State("healthy")
+	// StateUnhealthy indicates that a critical error was reported for a device
+	// and that it should no longer be used.
+	StateUnhealthy = State("unhealthy")
+)
+
+// A Status is the content of the health status file of a device.
+type Status struct {
+	UUID      string    `json:"uuid"`
+	State     State     `json:"state"`
+	Reason    string    `json:"reason,omitempty"`
+	XID       uint64    `json:"xid,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// WriteStatus writes the status of a device to the file <UUID>.json in the
+// specified directory. The file is replaced atomically so that readers never
+// see a partially written status. Note that since the file is replaced, the
+// directory and not the file must be mounted into containers for updates to
+// be visible.
+func WriteStatus(dir string, status *Status) error {
+	if status.UUID == "" {
+		return fmt.Errorf("a device UUID is required")
+	}
+	contents, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal status: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create health directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".tmp-"+status.UUID+"-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(contents, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write status: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to set permissions: %w", err)
+	}
+	return os.Rename(tmp.Name(), statusPath(dir, status.UUID))
+}
+
+// ReadStatus reads the status of the device with the specified UUID from the
+// specified directory.
+func ReadStatus(dir string, uuid string) (*Status, error) {
+	contents, err := os.ReadFile(statusPath(dir, uuid))
+	if err != nil {
+		return nil, err
+	}
+	var status Status
+	if err := json.Unmarshal(contents, &status); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal status: %w", err)
+	}
+	return &status, nil
+}
+
+func statusPath(dir string, uuid string) string {
+	return filepath.Join(dir, uuid+".json")
+}
@@ -0,0 +1,55 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package health
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteStatus(t *testing.T) {
+	dir := t.TempDir()
+	timestamp := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	require.Error(t, WriteStatus(dir, &Status{State: StateHealthy}))
+
+	require.NoError(t, WriteStatus(dir, &Status{UUID: "GPU-0", State: StateHealthy, Timestamp: timestamp}))
+	require.NoError(t, WriteStatus(dir, &Status{UUID: "GPU-0", State: StateUnhealthy, Reason: "GPU has fallen off the bus (XID 79)", XID: 79, Timestamp: timestamp}))
+
+	status, err := ReadStatus(dir, "GPU-0")
+	require.NoError(t, err)
+	require.EqualValues(t, &Status{
+		UUID:      "GPU-0",
+		State:     StateUnhealthy,
+		Reason:    "GPU has fallen off the bus (XID 79)",
+		XID:       79,
+		Timestamp: timestamp,
+	}, status)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "temporary files should be removed")
+	require.Equal(t, "GPU-0.json", entries[0].Name())
+
+	info, err := entries[0].Info()
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0644), info.Mode().Perm())
+}
@@ -0,0 +1,385 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+// A Policy defines the action that is taken for the processes running on a
+// device when the device becomes unhealthy.
+type Policy string
+
+const (
+	// PolicyNone only updates the health status of the device.
+	PolicyNone = Policy("none")
+	// PolicySIGTERM additionally sends a SIGTERM to the processes running on
+	// the device.
+	PolicySIGTERM = Policy("sigterm")
+)
+
+const (
+	// eventTypes are the NVML event types that are monitored.
+	eventTypes = nvml.EventTypeXidCriticalError | nvml.EventTypeDoubleBitEccError
+
+	xidDoubleBitECC    = 48
+	xidFallenOffTheBus = 79
+
+	// waitTimeout is the timeout in milliseconds for a single wait on the
+	// NVML event set. This bounds the time taken to react to cancellation.
+	waitTimeout = 5000
+)
+
+// DefaultIgnoredXIDs are the XIDs that are caused by applications and do not
+// indicate an issue with the device itself. These match the XIDs that are
+// ignored by the NVIDIA Kubernetes device plugin.
+var DefaultIgnoredXIDs = []uint64{
+	13,  // Graphics Engine Exception
+	31,  // GPU memory page fault
+	43,  // GPU stopped processing
+	45,  // Preemptive cleanup, due to previous errors
+	68,  // Video processor exception
+	109, // Context Switch Timeout Error
+}
+
+// A Watcher monitors the NVML events of all devices and updates their health
+// status in the configured directory.
+type Watcher struct {
+	logger      logger.Interface
+	nvmllib     nvml.Interface
+	dir         string
+	policy      Policy
+	ignoredXIDs map[uint64]bool
+	procRoot    string
+	// kill is used to signal processes and can be overridden in tests.
+	kill func(int, syscall.Signal) error
+	now  func() time.Time
+}
+
+// A device stores the properties of a monitored device that are required once
+// it has encountered an error, since these cannot necessarily be queried from
+// a failed device.
+type device struct {
+	nvml.Device
+	uuid  string
+	minor int
+}
+
+// An Option is used to configure a Watcher.
+type Option func(*Watcher)
+
+// WithLogger sets the logger for the watcher.
+func WithLogger(logger logger.Interface) Option {
+	return func(w *Watcher) {
+		w.logger = logger
+	}
+}
+
+// WithNvmlLib sets the NVML library used to receive events.
+func WithNvmlLib(nvmllib nvml.Interface) Option {
+	return func(w *Watcher) {
+		w.nvmllib = nvmllib
+	}
+}
+
+// WithDir sets the directory where the health status files are written.
+func WithDir(dir string) Option {
+	return func(w *Watcher) {
+		w.dir = dir
+	}
+}
+
+// WithPolicy sets the policy that is applied when a device becomes unhealthy.
+func WithPolicy(policy Policy) Option {
+	return func(w *Watcher) {
+		w.policy = policy
+	}
+}
+
+// WithIgnoredXIDs sets the XIDs that do not affect the health of a device.
+func WithIgnoredXIDs(xids ...uint64) Option {
+	return func(w *Watcher) {
+		w.ignoredXIDs = make(map[uint64]bool)
+		for _, xid := range xids {
+			w.ignoredXIDs[xid] = true
+		}
+	}
+}
+
+// NewWatcher creates a health watcher with the specified options.
+func NewWatcher(opts ...Option) *Watcher {
+	w := &Watcher{
+		dir:      DefaultDir,
+		policy:   PolicyNone,
+		procRoot: "/proc",
+		kill:     syscall.Kill,
+		now:      time.Now,
+	}
+	WithIgnoredXIDs(DefaultIgnoredXIDs...)(w)
+	for _, opt := range opts {
+		opt(w)
+	}
+	if w.logger == nil {
+		w.logger = logger.New()
+	}
+	if w.nvmllib == nil {
+		w.nvmllib = nvml.New()
+	}
+	return w
+}
+
+// Run initializes the health status of all devices and updates it as events
+// are received until the context is cancelled.
+func (w *Watcher) Run(ctx context.Context) error {
+	if ret := w.nvmllib.Init(); ret != nvml.SUCCESS {
+		return fmt.Errorf("failed to initialize NVML: %v", ret)
+	}
+	defer func() {
+		_ = w.nvmllib.Shutdown()
+	}()
+
+	set, ret := w.nvmllib.EventSetCreate()
+	if ret != nvml.SUCCESS {
+		return fmt.Errorf("failed to create event set: %v", ret)
+	}
+	defer func() {
+		_ = set.Free()
+	}()
+
+	devices, err := w.register(set)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		event, ret := set.Wait(waitTimeout)
+		if ret == nvml.ERROR_TIMEOUT {
+			continue
+		}
+		if ret != nvml.SUCCESS {
+			w.logger.Warningf("Failed to wait for events: %v", ret)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+		d, ok := devices[event.Device]
+		if !ok {
+			w.logger.Warningf("Ignoring event for unknown device: %+v", event)
+			continue
+		}
+		if err := w.handleEvent(d, event); err != nil {
+			w.logger.Warningf("Failed to handle event for %v: %v", d.uuid, err)
+		}
+	}
+}
+
+// register registers all devices with the specified event set and marks them
+// as healthy.
+func (w *Watcher) register(set nvml.EventSet) (map[nvml.Device]*device, error) {
+	count, ret := w.nvmllib.DeviceGetCount()
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get device count: %v", ret)
+	}
+
+	devices := make(map[nvml.Device]*device)
+	for i := 0; i < count; i++ {
+		handle, ret := w.nvmllib.DeviceGetHandleByIndex(i)
+		if ret != nvml.SUCCESS {
+			return nil, fmt.Errorf("failed to get device %d: %v", i, ret)
+		}
+		uuid, ret := handle.GetUUID()
+		if ret != nvml.SUCCESS {
+			return nil, fmt.Errorf("failed to get UUID of device %d: %v", i, ret)
+		}
+		minor, ret := handle.GetMinorNumber()
+		if ret != nvml.SUCCESS {
+			minor = -1
+		}
+		devices[handle] = &device{Device: handle, uuid: uuid, minor: minor}
+
+		status := &Status{
+			UUID:      uuid,
+			State:     StateHealthy,
+			Timestamp: w.now(),
+		}
+		if err := WriteStatus(w.dir, status); err != nil {
+			return nil, fmt.Errorf("failed to write status for %v: %w", uuid, err)
+		}
+
+		supported, ret := handle.GetSupportedEventTypes()
+		if ret != nvml.SUCCESS {
+			w.logger.Warningf("Unable to determine supported events for %v; health will not be monitored: %v", uuid, ret)
+			continue
+		}
+		if ret := handle.RegisterEvents(eventTypes&supported, set); ret != nvml.SUCCESS {
+			w.logger.Warningf("Failed to register events for %v; health will not be monitored: %v", uuid, ret)
+			continue
+		}
+		w.logger.Infof("Monitoring health of %v", uuid)
+	}
+	return devices, nil
+}
+
+// handleEvent updates the health status of a device if the specified event
+// indicates a critical error and applies the configured policy.
+func (w *Watcher) handleEvent(d *device, event nvml.EventData) error {
+	reason := w.getUnhealthyReason(event)
+	if reason == "" {
+		w.logger.Debugf("Ignoring event for %v: %+v", d.uuid, event)
+		return nil
+	}
+	w.logger.Warningf("Marking %v as unhealthy: %v", d.uuid, reason)
+
+	status := &Status{
+		UUID:      d.uuid,
+		State:     StateUnhealthy,
+		Reason:    reason,
+		Timestamp: w.now(),
+	}
+	if event.EventType == nvml.EventTypeXidCriticalError {
+		status.XID = event.EventData
+	}
+	if err := WriteStatus(w.dir, status); err != nil {
+		return err
+	}
+
+	if w.policy != PolicySIGTERM {
+		return nil
+	}
+	return w.terminateProcesses(d)
+}
+
+// getUnhealthyReason returns a description of the critical error described by
+// the event. If the event does not affect the health of the device, an empty
+// string is returned.
+func (w *Watcher) getUnhealthyReason(event nvml.EventData) string {
+	switch event.EventType {
+	case nvml.EventTypeDoubleBitEccError:
+		return "double-bit ECC error"
+	case nvml.EventTypeXidCriticalError:
+		xid := event.EventData
+		if w.ignoredXIDs[xid] {
+			return ""
+		}
+		switch xid {
+		case xidDoubleBitECC:
+			return "double-bit ECC error (XID 48)"
+		case xidFallenOffTheBus:
+			return "GPU has fallen off the bus (XID 79)"
+		}
+		return fmt.Sprintf("critical XID error %d", xid)
+	}
+	return ""
+}
+
+// terminateProcesses sends a SIGTERM to the processes running on the
+// specified device. The processes are queried using NVML and, if this fails
+// (e.g. because the GPU has fallen off the bus), by searching for processes
+// that have the device node open.
+func (w *Watcher) terminateProcesses(d *device) error {
+	pids, err := w.getProcesses(d)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for _, pid := range pids {
+		w.logger.Infof("Sending SIGTERM to process %d on %v", pid, d.uuid)
+		if err := w.kill(pid, syscall.SIGTERM); err != nil && !errors.Is(err, syscall.ESRCH) {
+			errs = errors.Join(errs, fmt.Errorf("failed to signal process %d: %w", pid, err))
+		}
+	}
+	return errs
+}
+
+// getProcesses returns the unique IDs of the processes running on the
+// specified device.
+func (w *Watcher) getProcesses(d *device) ([]int, error) {
+	compute, ret := d.GetComputeRunningProcesses()
+	if ret != nvml.SUCCESS {
+		w.logger.Warningf("Failed to get processes for %v from NVML: %v", d.uuid, ret)
+		if d.minor < 0 {
+			return nil, fmt.Errorf("unable to determine processes for %v", d.uuid)
+		}
+		return getProcessesWithOpenFile(w.procRoot, fmt.Sprintf("/dev/nvidia%d", d.minor))
+	}
+	processes := compute
+	if graphics, ret := d.GetGraphicsRunningProcesses(); ret == nvml.SUCCESS {
+		processes = append(processes, graphics...)
+	}
+
+	var pids []int
+	seen := make(map[uint32]bool)
+	for _, p := range processes {
+		if p.Pid == 0 || seen[p.Pid] {
+			continue
+		}
+		seen[p.Pid] = true
+		pids = append(pids, int(p.Pid))
+	}
+	return pids, nil
+}
+
+// getProcessesWithOpenFile returns the IDs of the processes that have the
+// specified file open.
+func getProcessesWithOpenFile(procRoot string, path string) ([]int, error) {
+	entries, err := os.ReadDir(procRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	var pids []int
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		fds, err := os.ReadDir(filepath.Join(procRoot, entry.Name(), "fd"))
+		if err != nil {
+			continue
+		}
+		for _, fd := range fds {
+			target, err := os.Readlink(filepath.Join(procRoot, entry.Name(), "fd", fd.Name()))
+			if err == nil && target == path {
+				pids = append(pids, pid)
+				break
+			}
+		}
+	}
+	return pids, nil
+}
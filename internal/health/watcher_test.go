@@ -0,0 +1,140 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package health
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleEvent(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	timestamp := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		description     string
+		policy          Policy
+		event           nvml.EventData
+		expectedStatus  *Status
+		expectedSignals []int
+	}{
+		{
+			description: "application XID is ignored",
+			policy:      PolicySIGTERM,
+			event:       nvml.EventData{EventType: nvml.EventTypeXidCriticalError, EventData: 13},
+			expectedStatus: &Status{
+				UUID:      "GPU-0",
+				State:     StateHealthy,
+				Timestamp: timestamp,
+			},
+		},
+		{
+			description: "fallen off the bus marks device as unhealthy",
+			policy:      PolicyNone,
+			event:       nvml.EventData{EventType: nvml.EventTypeXidCriticalError, EventData: 79},
+			expectedStatus: &Status{
+				UUID:      "GPU-0",
+				State:     StateUnhealthy,
+				Reason:    "GPU has fallen off the bus (XID 79)",
+				XID:       79,
+				Timestamp: timestamp,
+			},
+		},
+		{
+			description: "double-bit ECC error signals processes",
+			policy:      PolicySIGTERM,
+			event:       nvml.EventData{EventType: nvml.EventTypeDoubleBitEccError},
+			expectedStatus: &Status{
+				UUID:      "GPU-0",
+				State:     StateUnhealthy,
+				Reason:    "double-bit ECC error",
+				Timestamp: timestamp,
+			},
+			expectedSignals: []int{100, 200},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			dir := t.TempDir()
+			var signalled []int
+			w := NewWatcher(
+				WithLogger(logger),
+				WithNvmlLib(&mock.Interface{}),
+				WithDir(dir),
+				WithPolicy(tc.policy),
+			)
+			w.now = func() time.Time { return timestamp }
+			w.kill = func(pid int, sig syscall.Signal) error {
+				require.Equal(t, syscall.SIGTERM, sig)
+				signalled = append(signalled, pid)
+				return nil
+			}
+
+			d := &device{
+				Device: &mock.Device{
+					GetComputeRunningProcessesFunc: func() ([]nvml.ProcessInfo, nvml.Return) {
+						return []nvml.ProcessInfo{{Pid: 100}, {Pid: 200}}, nvml.SUCCESS
+					},
+					GetGraphicsRunningProcessesFunc: func() ([]nvml.ProcessInfo, nvml.Return) {
+						return []nvml.ProcessInfo{{Pid: 200}}, nvml.SUCCESS
+					},
+				},
+				uuid:  "GPU-0",
+				minor: 0,
+			}
+			require.NoError(t, WriteStatus(dir, &Status{UUID: "GPU-0", State: StateHealthy, Timestamp: timestamp}))
+
+			require.NoError(t, w.handleEvent(d, tc.event))
+
+			status, err := ReadStatus(dir, "GPU-0")
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedStatus, status)
+			require.EqualValues(t, tc.expectedSignals, signalled)
+		})
+	}
+}
+
+func TestGetProcessesWithOpenFile(t *testing.T) {
+	procRoot := t.TempDir()
+	openFiles := map[string][]string{
+		"1":    {"/dev/null"},
+		"42":   {"/dev/nvidiactl", "/dev/nvidia1"},
+		"43":   {"/dev/nvidia1", "/dev/nvidia1"},
+		"self": {"/dev/nvidia1"},
+	}
+	for pid, files := range openFiles {
+		fdDir := filepath.Join(procRoot, pid, "fd")
+		require.NoError(t, os.MkdirAll(fdDir, 0755))
+		for i, file := range files {
+			require.NoError(t, os.Symlink(file, filepath.Join(fdDir, string(rune('0'+i)))))
+		}
+	}
+
+	pids, err := getProcessesWithOpenFile(procRoot, "/dev/nvidia1")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []int{42, 43}, pids)
+}
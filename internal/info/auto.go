@@ -1,3 +1,5 @@
+//go:build !windows
+
 /**
 # Copyright (c) 2022, NVIDIA CORPORATION.  All rights reserved.
 #
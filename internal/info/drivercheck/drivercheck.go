@@ -0,0 +1,211 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package drivercheck detects conditions that prevent the NVIDIA driver from
+// being used so that a targeted diagnostic can be reported instead of a
+// generic error such as "nvml: driver not loaded".
+package drivercheck
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info/proc"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/messages"
+)
+
+// ExitCode is the exit code used when a driver conflict is detected. This
+// allows callers to distinguish a misconfigured host from other failures.
+const ExitCode = 3
+
+// Reason identifies the detected driver conflict.
+type Reason string
+
+const (
+	// ReasonNouveauBound indicates that the nouveau driver is bound to NVIDIA
+	// devices.
+	ReasonNouveauBound = Reason("NouveauBound")
+	// ReasonNouveauLoaded indicates that the nouveau module is loaded and the
+	// NVIDIA kernel module is not.
+	ReasonNouveauLoaded = Reason("NouveauLoaded")
+	// ReasonModuleNotLoaded indicates that the NVIDIA kernel module is not
+	// loaded.
+	ReasonModuleNotLoaded = Reason("ModuleNotLoaded")
+	// ReasonModuleNotInitialized indicates that the NVIDIA kernel module is
+	// loaded but failed to initialize.
+	ReasonModuleNotInitialized = Reason("ModuleNotInitialized")
+	// ReasonVersionMismatch indicates that the version of the user-space
+	// driver libraries does not match the version of the loaded kernel
+	// module.
+	ReasonVersionMismatch = Reason("VersionMismatch")
+)
+
+// messageIDs maps each reason to the ID of the corresponding message in the
+// message catalog.
+var messageIDs = map[Reason]messages.ID{
+	ReasonNouveauBound:         messages.NouveauBound,
+	ReasonNouveauLoaded:        messages.NouveauLoaded,
+	ReasonModuleNotLoaded:      messages.ModuleNotLoaded,
+	ReasonModuleNotInitialized: messages.ModuleNotInitialized,
+	ReasonVersionMismatch:      messages.DriverVersionMismatch,
+}
+
+// An Error describes a detected driver conflict.
+type Error struct {
+	Reason     Reason
+	Diagnostic string
+	// Err is the original error (if any) that triggered the check.
+	Err error
+}
+
+// Error returns the original error followed by the diagnostic.
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("%v (%v, %v)", e.Diagnostic, e.Reason, e.MessageID())
+	}
+	return fmt.Sprintf("%v: %v (%v, %v)", e.Err, e.Diagnostic, e.Reason, e.MessageID())
+}
+
+// Unwrap returns the original error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// MessageID returns the ID of the message for the detected conflict.
+func (e *Error) MessageID() messages.ID {
+	return messageIDs[e.Reason]
+}
+
+// ExitCode returns the exit code for a driver conflict.
+func (e *Error) ExitCode() int {
+	return ExitCode
+}
+
+// Check checks for driver conflicts on the host with the specified root.
+// If a conflict is detected an *Error wrapping the specified error is
+// returned, otherwise nil is returned. The check is only performed if the
+// specified error indicates that NVML could not be initialized because the
+// driver is not loaded. Since the driver is not provided by a kernel module
+// on WSL and Tegra-based systems, no check is performed on these systems.
+func Check(root string, err error) error {
+	if !errors.Is(err, nvml.ERROR_DRIVER_NOT_LOADED) {
+		return nil
+	}
+	if isWSL(root) || isTegra(root) {
+		return nil
+	}
+
+	if nouveauDevices := getNouveauBoundDevices(root); len(nouveauDevices) > 0 {
+		return &Error{
+			Reason:     ReasonNouveauBound,
+			Diagnostic: messages.Render(messages.NouveauBound, messages.Data{"Devices": strings.Join(nouveauDevices, ", ")}),
+			Err:        err,
+		}
+	}
+
+	if !exists(filepath.Join(root, "/sys/module/nvidia")) {
+		if exists(filepath.Join(root, "/sys/module/nouveau")) {
+			return &Error{
+				Reason:     ReasonNouveauLoaded,
+				Diagnostic: messages.Render(messages.NouveauLoaded, nil),
+				Err:        err,
+			}
+		}
+		return &Error{
+			Reason:     ReasonModuleNotLoaded,
+			Diagnostic: messages.Render(messages.ModuleNotLoaded, nil),
+			Err:        err,
+		}
+	}
+
+	if !exists(filepath.Join(root, "/proc/driver/nvidia/version")) {
+		return &Error{
+			Reason:     ReasonModuleNotInitialized,
+			Diagnostic: messages.Render(messages.ModuleNotInitialized, nil),
+			Err:        err,
+		}
+	}
+
+	return nil
+}
+
+// CheckVersion checks whether the specified version of the user-space driver
+// libraries matches the version of the NVIDIA kernel module loaded for the
+// specified root. If this is not the case, an *Error with
+// ReasonVersionMismatch is returned. If the version of the kernel module
+// cannot be determined, nil is returned.
+func CheckVersion(root string, libraryVersion string) error {
+	info, err := proc.GetKernelModuleInfo(root)
+	if err != nil || info.Version == "" || info.Version == libraryVersion {
+		return nil
+	}
+	return &Error{
+		Reason: ReasonVersionMismatch,
+		Diagnostic: messages.Render(messages.DriverVersionMismatch, messages.Data{
+			"LibraryVersion":      libraryVersion,
+			"KernelModuleVersion": info.Version,
+		}),
+	}
+}
+
+// getNouveauBoundDevices returns the PCI addresses of the devices that are
+// bound to the nouveau driver.
+func getNouveauBoundDevices(root string) []string {
+	entries, err := os.ReadDir(filepath.Join(root, "/sys/bus/pci/drivers/nouveau"))
+	if err != nil {
+		return nil
+	}
+	var devices []string
+	for _, entry := range entries {
+		// Bound devices are represented by symlinks named by PCI address
+		// (e.g. 0000:01:00.0). Other entries such as bind or module are
+		// skipped.
+		if !strings.Contains(entry.Name(), ":") {
+			continue
+		}
+		devices = append(devices, entry.Name())
+	}
+	return devices
+}
+
+// isWSL returns whether the host with the specified root is a WSL system.
+func isWSL(root string) bool {
+	return exists(filepath.Join(root, "/dev/dxg"))
+}
+
+// isTegra returns whether the host with the specified root is a Tegra-based
+// system.
+func isTegra(root string) bool {
+	if exists(filepath.Join(root, "/etc/nv_tegra_release")) {
+		return true
+	}
+	family, err := os.ReadFile(filepath.Join(root, "/sys/devices/soc0/family"))
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(string(family))), "tegra")
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
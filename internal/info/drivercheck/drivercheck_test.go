@@ -0,0 +1,193 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package drivercheck
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/messages"
+)
+
+func TestCheck(t *testing.T) {
+	testCases := []struct {
+		description    string
+		paths          []string
+		contents       map[string]string
+		err            error
+		expectedReason Reason
+	}{
+		{
+			description: "loaded and initialized module has no conflict",
+			paths: []string{
+				"/sys/module/nvidia/",
+				"/proc/driver/nvidia/version",
+			},
+		},
+		{
+			description: "nouveau bound to device",
+			paths: []string{
+				"/sys/module/nouveau/",
+				"/sys/bus/pci/drivers/nouveau/bind",
+				"/sys/bus/pci/drivers/nouveau/0000:01:00.0",
+			},
+			expectedReason: ReasonNouveauBound,
+		},
+		{
+			description: "nouveau loaded without NVIDIA module",
+			paths: []string{
+				"/sys/module/nouveau/",
+				"/sys/bus/pci/drivers/nouveau/bind",
+			},
+			expectedReason: ReasonNouveauLoaded,
+		},
+		{
+			description:    "module not loaded",
+			expectedReason: ReasonModuleNotLoaded,
+		},
+		{
+			description: "module not initialized",
+			paths: []string{
+				"/sys/module/nvidia/",
+			},
+			expectedReason: ReasonModuleNotInitialized,
+		},
+		{
+			description: "other error is not checked",
+			err:         errors.New("invalid runtime mode"),
+		},
+		{
+			description: "other nvml error is not checked",
+			err:         fmt.Errorf("failed to initialize nvml: %w", nvml.ERROR_LIBRARY_NOT_FOUND),
+		},
+		{
+			description: "wsl is not checked",
+			paths: []string{
+				"/dev/dxg",
+			},
+		},
+		{
+			description: "tegra release is not checked",
+			paths: []string{
+				"/etc/nv_tegra_release",
+			},
+		},
+		{
+			description: "tegra soc family is not checked",
+			contents: map[string]string{
+				"/sys/devices/soc0/family": "Tegra\n",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			root := t.TempDir()
+			// Paths with a trailing slash are created as directories.
+			for _, path := range tc.paths {
+				if strings.HasSuffix(path, "/") {
+					require.NoError(t, os.MkdirAll(filepath.Join(root, path), 0755))
+					continue
+				}
+				require.NoError(t, os.MkdirAll(filepath.Join(root, filepath.Dir(path)), 0755))
+				require.NoError(t, os.WriteFile(filepath.Join(root, path), nil, 0600))
+			}
+			for path, contents := range tc.contents {
+				require.NoError(t, os.MkdirAll(filepath.Join(root, filepath.Dir(path)), 0755))
+				require.NoError(t, os.WriteFile(filepath.Join(root, path), []byte(contents), 0600))
+			}
+
+			original := tc.err
+			if original == nil {
+				original = messages.Wrap(nvml.ERROR_DRIVER_NOT_LOADED, messages.NVMLInitFailed, nil)
+			}
+			err := Check(root, original)
+			if tc.expectedReason == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			var driverErr *Error
+			require.ErrorAs(t, err, &driverErr)
+			require.Equal(t, tc.expectedReason, driverErr.Reason)
+			require.Equal(t, ExitCode, driverErr.ExitCode())
+			id, ok := messages.GetID(err)
+			require.True(t, ok)
+			require.Equal(t, messageIDs[tc.expectedReason], id)
+			require.ErrorIs(t, err, original)
+			require.True(t, strings.HasPrefix(err.Error(), original.Error()))
+		})
+	}
+}
+
+func TestCheckVersion(t *testing.T) {
+	testCases := []struct {
+		description    string
+		versionFile    string
+		libraryVersion string
+		expectedError  bool
+	}{
+		{
+			description:    "matching versions",
+			versionFile:    "NVRM version: NVIDIA UNIX x86_64 Kernel Module  550.54.14  Thu Feb 22 01:44:30 UTC 2024\n",
+			libraryVersion: "550.54.14",
+		},
+		{
+			description:    "mismatched versions",
+			versionFile:    "NVRM version: NVIDIA UNIX Open Kernel Module for x86_64  550.54.14  Release Build  (dvs-builder@U16-I3-B03-4-3)  Thu Feb 22 01:25:25 UTC 2024\n",
+			libraryVersion: "550.90.07",
+			expectedError:  true,
+		},
+		{
+			description:    "missing version file is not a mismatch",
+			libraryVersion: "550.90.07",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			root := t.TempDir()
+			if tc.versionFile != "" {
+				path := filepath.Join(root, "/proc/driver/nvidia/version")
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+				require.NoError(t, os.WriteFile(path, []byte(tc.versionFile), 0644))
+			}
+
+			err := CheckVersion(root, tc.libraryVersion)
+			if !tc.expectedError {
+				require.NoError(t, err)
+				return
+			}
+
+			var driverErr *Error
+			require.ErrorAs(t, err, &driverErr)
+			require.Equal(t, ReasonVersionMismatch, driverErr.Reason)
+			require.Contains(t, err.Error(), tc.libraryVersion)
+			id, ok := messages.GetID(err)
+			require.True(t, ok)
+			require.Equal(t, messages.DriverVersionMismatch, id)
+		})
+	}
+}
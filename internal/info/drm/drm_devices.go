@@ -18,7 +18,13 @@ package drm
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
+)
+
+const (
+	nvidiaPCIVendorID = "0x10de"
 )
 
 // GetDeviceNodesByBusID returns the DRM devices associated with the specified PCI bus ID
@@ -37,3 +43,14 @@ func GetDeviceNodesByBusID(busID string) ([]string, error) {
 
 	return drmDeviceNodes, nil
 }
+
+// IsNVIDIADeviceNode checks whether the specified DRM device node (e.g.
+// /dev/dri/card1) is associated with an NVIDIA PCI device. This allows the DRM
+// device nodes of GPUs from other vendors to be ignored.
+func IsNVIDIADeviceNode(path string) bool {
+	vendor, err := os.ReadFile(filepath.Join("/sys/class/drm", filepath.Base(path), "device", "vendor"))
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(vendor)) == nvidiaPCIVendorID
+}
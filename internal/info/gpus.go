@@ -0,0 +1,206 @@
+//go:build !windows
+
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package info
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/failinject"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/messages"
+)
+
+type gpuLister struct {
+	logger  logger.Interface
+	driver  *root.Driver
+	nvmllib nvml.Interface
+
+	once sync.Once
+	gpus []image.GPU
+	err  error
+
+	migOnce sync.Once
+	migs    []image.MIGDevice
+	migErr  error
+}
+
+var _ image.GPULister = (*gpuLister)(nil)
+var _ image.MIGDeviceLister = (*gpuLister)(nil)
+
+// NewGPULister creates a lister for the GPUs on the system using the NVML
+// library of the specified driver. The lister also lists the MIG devices on
+// the system. NVML is only loaded when the devices are first listed and the
+// result is reused for subsequent calls.
+func NewGPULister(logger logger.Interface, driver *root.Driver) image.GPULister {
+	return &gpuLister{
+		logger: logger,
+		driver: driver,
+	}
+}
+
+// GPUs returns the index, UUID, usage, and topology of each GPU on the system.
+func (l *gpuLister) GPUs() ([]image.GPU, error) {
+	l.once.Do(func() {
+		l.gpus, l.err = l.getGPUs()
+	})
+	return l.gpus, l.err
+}
+
+// MIGDevices returns the index, UUID, profile, and usage of each MIG device
+// on the system.
+func (l *gpuLister) MIGDevices() ([]image.MIGDevice, error) {
+	l.migOnce.Do(func() {
+		l.migs, l.migErr = l.getMIGDevices()
+	})
+	return l.migs, l.migErr
+}
+
+// initNVML initializes the NVML library and returns a function to shut it
+// down again.
+func (l *gpuLister) initNVML() (func(), error) {
+	if l.nvmllib == nil {
+		var nvmlOpts []nvml.LibraryOption
+		if candidates, err := l.driver.Libraries().Locate("libnvidia-ml.so.1"); err == nil {
+			nvmlOpts = append(nvmlOpts, nvml.WithLibraryPath(candidates[0]))
+		}
+		l.nvmllib = failinject.NVML(nvml.New(nvmlOpts...))
+	}
+	if ret := l.nvmllib.Init(); ret != nvml.SUCCESS {
+		return nil, messages.Wrap(ret, messages.NVMLInitFailed, nil)
+	}
+	return func() {
+		if ret := l.nvmllib.Shutdown(); ret != nvml.SUCCESS {
+			l.logger.Warningf("Failed to shutdown NVML: %v", ret)
+		}
+	}, nil
+}
+
+func (l *gpuLister) getMIGDevices() ([]image.MIGDevice, error) {
+	shutdown, err := l.initNVML()
+	if err != nil {
+		return nil, err
+	}
+	defer shutdown()
+
+	var migs []image.MIGDevice
+	err = device.New(l.nvmllib).VisitMigDevices(func(gpuIndex int, _ device.Device, migIndex int, mig device.MigDevice) error {
+		uuid, ret := mig.GetUUID()
+		if ret != nvml.SUCCESS {
+			return fmt.Errorf("failed to get UUID of MIG device %d:%d: %v", gpuIndex, migIndex, ret)
+		}
+		profile, err := mig.GetProfile()
+		if err != nil {
+			return fmt.Errorf("failed to get profile of MIG device %d:%d: %w", gpuIndex, migIndex, err)
+		}
+		// MIG devices for which the running processes cannot be determined
+		// are considered to be in use.
+		inUse := true
+		if processes, ret := mig.GetComputeRunningProcesses(); ret == nvml.SUCCESS {
+			inUse = len(processes) > 0
+		} else {
+			l.logger.Debugf("Failed to get processes of MIG device %d:%d: %v", gpuIndex, migIndex, ret)
+		}
+		migs = append(migs, image.MIGDevice{
+			Index:   fmt.Sprintf("%d:%d", gpuIndex, migIndex),
+			UUID:    uuid,
+			Profile: profile.String(),
+			InUse:   inUse,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return migs, nil
+}
+
+func (l *gpuLister) getGPUs() ([]image.GPU, error) {
+	shutdown, err := l.initNVML()
+	if err != nil {
+		return nil, err
+	}
+	defer shutdown()
+
+	count, ret := l.nvmllib.DeviceGetCount()
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get device count: %v", ret)
+	}
+
+	var devices []nvml.Device
+	var gpus []image.GPU
+	for i := 0; i < count; i++ {
+		device, ret := l.nvmllib.DeviceGetHandleByIndex(i)
+		if ret != nvml.SUCCESS {
+			return nil, fmt.Errorf("failed to get device %d: %v", i, ret)
+		}
+		uuid, ret := device.GetUUID()
+		if ret != nvml.SUCCESS {
+			return nil, fmt.Errorf("failed to get UUID of device %d: %v", i, ret)
+		}
+		gpu := image.GPU{
+			Index:       strconv.Itoa(i),
+			UUID:        uuid,
+			Utilization: math.MaxUint32,
+			MemoryUsed:  math.MaxUint64,
+			NUMANode:    -1,
+		}
+		// GPUs for which the usage cannot be determined are considered to be
+		// fully used.
+		if utilization, ret := device.GetUtilizationRates(); ret == nvml.SUCCESS {
+			gpu.Utilization = utilization.Gpu
+		} else {
+			l.logger.Debugf("Failed to get utilization of device %d: %v", i, ret)
+		}
+		if memory, ret := device.GetMemoryInfo(); ret == nvml.SUCCESS {
+			gpu.MemoryUsed = memory.Used
+		} else {
+			l.logger.Debugf("Failed to get memory info of device %d: %v", i, ret)
+		}
+		if node, ret := device.GetNumaNodeId(); ret == nvml.SUCCESS {
+			gpu.NUMANode = node
+		} else {
+			l.logger.Debugf("Failed to get NUMA node of device %d: %v", i, ret)
+		}
+		devices = append(devices, device)
+		gpus = append(gpus, gpu)
+	}
+
+	for i := range devices {
+		for j := range devices {
+			if i == j {
+				continue
+			}
+			status, ret := devices[i].GetP2PStatus(devices[j], nvml.P2P_CAPS_INDEX_NVLINK)
+			if ret != nvml.SUCCESS || status != nvml.P2P_STATUS_OK {
+				continue
+			}
+			gpus[i].NVLinkPeers = append(gpus[i].NVLinkPeers, gpus[j].Index)
+		}
+	}
+	return gpus, nil
+}
@@ -0,0 +1,96 @@
+//go:build !windows
+
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package info
+
+import (
+	"math"
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+)
+
+func TestGPULister(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	devices := make([]*mock.Device, 2)
+	for n := range devices {
+		uuid := []string{"GPU-aaaa", "GPU-bbbb"}[n]
+		devices[n] = &mock.Device{
+			GetUUIDFunc: func() (string, nvml.Return) {
+				return uuid, nvml.SUCCESS
+			},
+			GetUtilizationRatesFunc: func() (nvml.Utilization, nvml.Return) {
+				if n == 1 {
+					return nvml.Utilization{}, nvml.ERROR_NOT_SUPPORTED
+				}
+				return nvml.Utilization{Gpu: 30}, nvml.SUCCESS
+			},
+			GetMemoryInfoFunc: func() (nvml.Memory, nvml.Return) {
+				return nvml.Memory{Used: uint64(n+1) * 1024}, nvml.SUCCESS
+			},
+			GetNumaNodeIdFunc: func() (int, nvml.Return) {
+				if n == 1 {
+					return 0, nvml.ERROR_NOT_SUPPORTED
+				}
+				return 1, nvml.SUCCESS
+			},
+			GetP2PStatusFunc: func(peer nvml.Device, index nvml.GpuP2PCapsIndex) (nvml.GpuP2PStatus, nvml.Return) {
+				return nvml.P2P_STATUS_OK, nvml.SUCCESS
+			},
+		}
+	}
+
+	var initCalls int
+	nvmllib := &mock.Interface{
+		InitFunc: func() nvml.Return {
+			initCalls++
+			return nvml.SUCCESS
+		},
+		ShutdownFunc: func() nvml.Return {
+			return nvml.SUCCESS
+		},
+		DeviceGetCountFunc: func() (int, nvml.Return) {
+			return 2, nvml.SUCCESS
+		},
+		DeviceGetHandleByIndexFunc: func(n int) (nvml.Device, nvml.Return) {
+			return devices[n], nvml.SUCCESS
+		},
+	}
+
+	l := &gpuLister{
+		logger:  logger,
+		nvmllib: nvmllib,
+	}
+
+	for i := 0; i < 2; i++ {
+		gpus, err := l.GPUs()
+		require.NoError(t, err)
+		require.EqualValues(t, []image.GPU{
+			{Index: "0", UUID: "GPU-aaaa", Utilization: 30, MemoryUsed: 1024, NUMANode: 1, NVLinkPeers: []string{"1"}},
+			{Index: "1", UUID: "GPU-bbbb", Utilization: math.MaxUint32, MemoryUsed: 2048, NUMANode: -1, NVLinkPeers: []string{"0"}},
+		}, gpus)
+	}
+	require.Equal(t, 1, initCalls)
+}
@@ -0,0 +1,94 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package proc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// KernelModuleFlavor represents the flavor of the loaded NVIDIA GPU kernel module.
+type KernelModuleFlavor string
+
+const (
+	// KernelModuleFlavorOpen indicates that the open GPU kernel module is loaded.
+	// The open kernel module requires the GSP firmware for the driver version.
+	KernelModuleFlavorOpen = KernelModuleFlavor("open")
+	// KernelModuleFlavorProprietary indicates that the proprietary GPU kernel module is loaded.
+	KernelModuleFlavorProprietary = KernelModuleFlavor("proprietary")
+)
+
+// KernelModuleInfo stores the information for the loaded NVIDIA GPU kernel module.
+type KernelModuleInfo struct {
+	Flavor  KernelModuleFlavor
+	Version string
+}
+
+var kernelModuleVersionRegexp = regexp.MustCompile(`Kernel Module(?:\s+for\s+\S+)?\s+(\S+)`)
+
+// GetKernelModuleInfo returns the information for the NVIDIA GPU kernel module
+// loaded for the specified root. An error is returned if the module is not
+// loaded.
+func GetKernelModuleInfo(root string) (*KernelModuleInfo, error) {
+	path := filepath.Join(root, "/proc/driver/nvidia/version")
+	versionFile, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %v: %w", path, err)
+	}
+	defer versionFile.Close()
+
+	return kernelModuleInfoFrom(versionFile)
+}
+
+// kernelModuleInfoFrom parses a KernelModuleInfo struct from the specified reader.
+// The version file for the open kernel module has the following structure:
+// $ cat /proc/driver/nvidia/version
+// NVRM version: NVIDIA UNIX Open Kernel Module for x86_64  550.54.14  Release Build  (dvs-builder@U16-I3-B03-4-3)  Thu Feb 22 01:25:25 UTC 2024
+// GCC version:  gcc version 12.3.0 (Ubuntu 12.3.0-1ubuntu1~22.04)
+//
+// whereas for the proprietary kernel module the first line is:
+// NVRM version: NVIDIA UNIX x86_64 Kernel Module  550.54.14  Thu Feb 22 01:44:30 UTC 2024
+func kernelModuleInfoFrom(reader io.Reader) (*KernelModuleInfo, error) {
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "NVRM version:") {
+			continue
+		}
+
+		info := KernelModuleInfo{
+			Flavor: KernelModuleFlavorProprietary,
+		}
+		if strings.Contains(line, "Open Kernel Module") {
+			info.Flavor = KernelModuleFlavorOpen
+		}
+		if matches := kernelModuleVersionRegexp.FindStringSubmatch(line); len(matches) == 2 {
+			info.Version = matches[1]
+		}
+		return &info, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("NVRM version not found")
+}
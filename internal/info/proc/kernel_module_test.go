@@ -0,0 +1,72 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package proc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKernelModuleInfoFrom(t *testing.T) {
+	testCases := []struct {
+		description   string
+		contents      string
+		expectedInfo  *KernelModuleInfo
+		expectedError bool
+	}{
+		{
+			description: "open kernel module",
+			contents: `NVRM version: NVIDIA UNIX Open Kernel Module for x86_64  550.54.14  Release Build  (dvs-builder@U16-I3-B03-4-3)  Thu Feb 22 01:25:25 UTC 2024
+GCC version:  gcc version 12.3.0 (Ubuntu 12.3.0-1ubuntu1~22.04)
+`,
+			expectedInfo: &KernelModuleInfo{
+				Flavor:  KernelModuleFlavorOpen,
+				Version: "550.54.14",
+			},
+		},
+		{
+			description: "proprietary kernel module",
+			contents: `NVRM version: NVIDIA UNIX x86_64 Kernel Module  550.54.14  Thu Feb 22 01:44:30 UTC 2024
+GCC version:  gcc version 12.3.0 (Ubuntu 12.3.0-1ubuntu1~22.04)
+`,
+			expectedInfo: &KernelModuleInfo{
+				Flavor:  KernelModuleFlavorProprietary,
+				Version: "550.54.14",
+			},
+		},
+		{
+			description:   "missing NVRM version",
+			contents:      "GCC version:  gcc version 12.3.0\n",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			info, err := kernelModuleInfoFrom(strings.NewReader(tc.contents))
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedInfo, info)
+		})
+	}
+}
@@ -16,7 +16,26 @@
 
 package info
 
-import "strings"
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	cdispecs "tags.cncf.io/container-device-interface/specs-go"
+)
+
+const (
+	// minimumDriverVersion is the minimum NVIDIA driver version that is
+	// supported by the NVIDIA Container Toolkit.
+	minimumDriverVersion = "418.81.07"
+	// libnvidiaContainerMaxVersion is the (exclusive) upper bound for the
+	// libnvidia-container version that can be used with this version of the
+	// NVIDIA Container Toolkit. The exact version is required by the packages.
+	libnvidiaContainerMaxVersion = "2.0.0"
+)
 
 // version must be set by go build's -X main.version= option in the Makefile.
 var version = "unknown"
@@ -25,6 +44,10 @@ var version = "unknown"
 // and will be populated by the Makefile
 var gitCommit = ""
 
+// buildDate is the date that the binary was built and will be populated by
+// the Makefile
+var buildDate = ""
+
 // GetVersionParts returns the different version components
 func GetVersionParts() []string {
 	v := []string{version}
@@ -41,3 +64,75 @@ func GetVersionString(more ...string) string {
 	v := append(GetVersionParts(), more...)
 	return strings.Join(v, "\n")
 }
+
+// A Version represents the version and compatibility information of a
+// component of the NVIDIA Container Toolkit.
+type Version struct {
+	Name                 string   `json:"name"`
+	Version              string   `json:"version"`
+	GitCommit            string   `json:"gitCommit,omitempty"`
+	BuildDate            string   `json:"buildDate,omitempty"`
+	GoVersion            string   `json:"goVersion"`
+	OCISpecVersion       string   `json:"ociSpecVersion"`
+	CDISpecVersions      []string `json:"cdiSpecVersions"`
+	MinimumDriverVersion string   `json:"minimumDriverVersion"`
+	// LibNvidiaContainerVersion describes the libnvidia-container versions
+	// that are expected to be installed alongside this version.
+	LibNvidiaContainerVersion string `json:"libnvidiaContainerVersion"`
+}
+
+// GetVersion returns the version information for the named component.
+func GetVersion(name string) Version {
+	return Version{
+		Name:                      name,
+		Version:                   version,
+		GitCommit:                 gitCommit,
+		BuildDate:                 buildDate,
+		GoVersion:                 runtime.Version(),
+		OCISpecVersion:            specs.Version,
+		CDISpecVersions:           getCDISpecVersions(),
+		MinimumDriverVersion:      minimumDriverVersion,
+		LibNvidiaContainerVersion: fmt.Sprintf("=%v, <%v", version, libnvidiaContainerMaxVersion),
+	}
+}
+
+// WriteVersion writes the version of the named component to the specified
+// writer in the requested format. The text format matches the version output
+// of the CLIs and includes the specified additional version parts.
+func WriteVersion(w io.Writer, name string, format string, more ...string) error {
+	switch format {
+	case "", "text":
+		_, err := fmt.Fprintf(w, "%v version %v\n", name, GetVersionString(more...))
+		return err
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(GetVersion(name)); err != nil {
+			return fmt.Errorf("failed to write version: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported version output format %q; one of [text, json]", format)
+	}
+}
+
+// getCDISpecVersions returns the CDI specification versions that are
+// supported by the vendored CDI package. A version is considered supported
+// if a CDI specification with that version passes validation.
+func getCDISpecVersions() []string {
+	var versions []string
+	for major := 0; major <= 1; major++ {
+		for minor := 0; minor <= 9; minor++ {
+			v := fmt.Sprintf("%d.%d.0", major, minor)
+			if err := cdispecs.ValidateVersion(&cdispecs.Spec{Version: v}); err != nil {
+				continue
+			}
+			versions = append(versions, v)
+			if v == cdispecs.CurrentVersion {
+				return versions
+			}
+		}
+	}
+	return versions
+}
@@ -0,0 +1,81 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package info
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteVersion(t *testing.T) {
+	testCases := []struct {
+		description    string
+		format         string
+		more           []string
+		expectedError  bool
+		expectedOutput string
+	}{
+		{
+			description:    "empty format is text",
+			expectedOutput: "test-component version unknown\n",
+		},
+		{
+			description:    "text includes additional parts",
+			format:         "text",
+			more:           []string{"spec: 1.2.1"},
+			expectedOutput: "test-component version unknown\nspec: 1.2.1\n",
+		},
+		{
+			description:   "unsupported format returns error",
+			format:        "yaml",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			err := WriteVersion(output, "test-component", tc.format, tc.more...)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedOutput, output.String())
+		})
+	}
+}
+
+func TestWriteVersionJSON(t *testing.T) {
+	output := &bytes.Buffer{}
+	require.NoError(t, WriteVersion(output, "test-component", "json"))
+
+	var v Version
+	require.NoError(t, json.Unmarshal(output.Bytes(), &v))
+
+	require.Equal(t, "test-component", v.Name)
+	require.Equal(t, "unknown", v.Version)
+	require.Equal(t, minimumDriverVersion, v.MinimumDriverVersion)
+	require.Equal(t, "=unknown, <2.0.0", v.LibNvidiaContainerVersion)
+	require.Contains(t, v.CDISpecVersions, "0.3.0")
+	require.Contains(t, v.CDISpecVersions, "1.0.0")
+	require.NotContains(t, v.CDISpecVersions, "0.2.0")
+}
@@ -24,7 +24,6 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
-	"syscall"
 	"unsafe"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
@@ -115,8 +114,7 @@ func New(logger logger.Interface, root string) (LDCache, error) {
 	if err != nil {
 		return nil, err
 	}
-	d, err := syscall.Mmap(int(f.Fd()), 0, int(fi.Size()),
-		syscall.PROT_READ, syscall.MAP_PRIVATE)
+	d, err := mmap(f, int(fi.Size()))
 	if err != nil {
 		return nil, err
 	}
@@ -131,7 +129,7 @@ func New(logger logger.Interface, root string) (LDCache, error) {
 }
 
 func (c *ldcache) Close() error {
-	return syscall.Munmap(c.data)
+	return munmap(c.data)
 }
 
 func (c *ldcache) Magic() string {
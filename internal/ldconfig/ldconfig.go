@@ -25,6 +25,7 @@ import (
 	"strings"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 )
 
 const (
@@ -37,6 +38,7 @@ const (
 )
 
 type Ldconfig struct {
+	logger       logger.Interface
 	ldconfigPath string
 	inRoot       string
 }
@@ -55,8 +57,9 @@ func NewRunner(id string, ldconfigPath string, containerRoot string, additionala
 
 // New creates an Ldconfig struct that is used to perform operations on the
 // ldcache and libraries in a particular root (e.g. a container).
-func New(ldconfigPath string, inRoot string) (*Ldconfig, error) {
+func New(logger logger.Interface, ldconfigPath string, inRoot string) (*Ldconfig, error) {
 	l := &Ldconfig{
+		logger:       logger,
 		ldconfigPath: ldconfigPath,
 		inRoot:       inRoot,
 	}
@@ -135,7 +138,7 @@ func (l *Ldconfig) prepareRoot() (string, error) {
 
 	// We mount the host ldconfig before we pivot root since host paths are not
 	// visible after the pivot root operation.
-	ldconfigPath, err := mountLdConfig(l.ldconfigPath, l.inRoot)
+	ldconfigPath, err := mountLdConfig(l.logger, l.ldconfigPath, l.inRoot)
 	if err != nil {
 		return "", fmt.Errorf("error mounting host ldconfig: %w", err)
 	}
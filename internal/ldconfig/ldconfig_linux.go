@@ -33,6 +33,9 @@ import (
 
 	"github.com/opencontainers/runc/libcontainer/utils"
 	"golang.org/x/sys/unix"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/rootfs"
 )
 
 // pivotRoot will call pivot_root such that rootfs becomes the new root
@@ -102,7 +105,7 @@ func pivotRoot(rootfs string) error {
 // mountLdConfig mounts the host ldconfig to the mount namespace of the hook.
 // We use WithProcfd to perform the mount operations to ensure that the changes
 // are persisted across the pivot root.
-func mountLdConfig(hostLdconfigPath string, containerRootDirPath string) (string, error) {
+func mountLdConfig(logger logger.Interface, hostLdconfigPath string, containerRootDirPath string) (string, error) {
 	hostLdconfigInfo, err := os.Stat(hostLdconfigPath)
 	if err != nil {
 		return "", fmt.Errorf("error reading host ldconfig: %w", err)
@@ -110,7 +113,7 @@ func mountLdConfig(hostLdconfigPath string, containerRootDirPath string) (string
 
 	hookScratchDirPath := "/var/run/nvidia-ctk-hook"
 	ldconfigPath := filepath.Join(hookScratchDirPath, "ldconfig")
-	if err := utils.MkdirAllInRoot(containerRootDirPath, hookScratchDirPath, 0755); err != nil {
+	if err := mkdirAllInRoot(logger, containerRootDirPath, hookScratchDirPath); err != nil {
 		return "", fmt.Errorf("error creating hook scratch folder: %w", err)
 	}
 
@@ -136,6 +139,21 @@ func mountLdConfig(hostLdconfigPath string, containerRootDirPath string) (string
 	return ldconfigPath, nil
 }
 
+// mkdirAllInRoot creates the specified directory in the container root.
+// If the container root is read-only, a writable overlay is first mounted over
+// the closest existing parent. Since this is called in the mount namespace of
+// the hook, the overlay is not visible in the container.
+func mkdirAllInRoot(logger logger.Interface, containerRootDirPath string, path string) error {
+	err := utils.MkdirAllInRoot(containerRootDirPath, path, 0755)
+	if !errors.Is(err, unix.EROFS) {
+		return err
+	}
+	if err := rootfs.EnsureWritable(logger, containerRootDirPath, path); err != nil {
+		return err
+	}
+	return utils.MkdirAllInRoot(containerRootDirPath, path, 0755)
+}
+
 func createFileInRoot(containerRootDirPath string, destinationPath string, mode os.FileMode) (string, error) {
 	dest, err := securejoin.SecureJoin(containerRootDirPath, destinationPath)
 	if err != nil {
@@ -22,13 +22,15 @@ package ldconfig
 import (
 	"fmt"
 	"os/exec"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 )
 
 func pivotRoot(newroot string) error {
 	return fmt.Errorf("not supported")
 }
 
-func mountLdConfig(hostLdconfigPath string, containerRootDirPath string) (string, error) {
+func mountLdConfig(_ logger.Interface, hostLdconfigPath string, containerRootDirPath string) (string, error) {
 	return "", fmt.Errorf("not supported")
 }
 
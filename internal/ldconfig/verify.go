@@ -0,0 +1,156 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package ldconfig
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+)
+
+// DefaultAllowedPaths defines the path patterns at which a host ldconfig
+// binary is trusted by default. The patterns are matched against the path
+// with all symlinks resolved.
+var DefaultAllowedPaths = []string{
+	"/sbin/ldconfig",
+	"/sbin/ldconfig.real",
+	"/usr/sbin/ldconfig",
+	"/usr/sbin/ldconfig.real",
+	"/bin/ldconfig",
+	"/usr/bin/ldconfig",
+	"/usr/bin/ldconfig.real",
+	"/nix/store/*/bin/ldconfig",
+	"/gnu/store/*/sbin/ldconfig",
+}
+
+type verifier struct {
+	allowedPaths  []string
+	allowedHashes []string
+	checkOwner    func(os.FileInfo) error
+}
+
+// A VerifyOption is used to configure the verification of the host ldconfig
+// binary.
+type VerifyOption func(*verifier)
+
+// WithAllowedPaths adds the specified path patterns to the paths at which an
+// ldconfig binary is trusted.
+func WithAllowedPaths(patterns ...string) VerifyOption {
+	return func(v *verifier) {
+		v.allowedPaths = append(v.allowedPaths, patterns...)
+	}
+}
+
+// WithAllowedSHA256 sets the SHA256 digests that a trusted ldconfig binary
+// must match. If no digests are specified, the contents of the binary are not
+// checked.
+func WithAllowedSHA256(digests ...string) VerifyOption {
+	return func(v *verifier) {
+		for _, digest := range digests {
+			digest = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(digest)), "sha256:")
+			if digest == "" {
+				continue
+			}
+			v.allowedHashes = append(v.allowedHashes, digest)
+		}
+	}
+}
+
+// Verify checks that the specified host ldconfig binary can be trusted before
+// it is executed in a container's namespaces. An error is returned if:
+//   - the resolved binary is not at one of the allowed paths,
+//   - the binary is not a regular file,
+//   - the binary is not owned by root or is writable by other users, or
+//   - SHA256 digests are configured and the binary matches none of them.
+func Verify(ldconfigPath string, opts ...VerifyOption) error {
+	v := &verifier{
+		allowedPaths: append([]string{}, DefaultAllowedPaths...),
+		checkOwner:   checkOwner,
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v.verify(strings.TrimPrefix(config.NormalizeLDConfigPath("@"+ldconfigPath), "@"))
+}
+
+func (v *verifier) verify(ldconfigPath string) error {
+	resolved, err := filepath.EvalSymlinks(ldconfigPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve ldconfig path %v: %w", ldconfigPath, err)
+	}
+	if !v.isAllowedPath(resolved) {
+		return fmt.Errorf("ldconfig binary %v (resolved from %v) is not at an allowed path", resolved, ldconfigPath)
+	}
+
+	info, err := os.Lstat(resolved)
+	if err != nil {
+		return fmt.Errorf("failed to stat ldconfig binary %v: %w", resolved, err)
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("ldconfig binary %v is not a regular file", resolved)
+	}
+	if info.Mode().Perm()&0022 != 0 {
+		return fmt.Errorf("ldconfig binary %v is writable by non-owners (mode %v)", resolved, info.Mode().Perm())
+	}
+	if err := v.checkOwner(info); err != nil {
+		return fmt.Errorf("ldconfig binary %v: %w", resolved, err)
+	}
+
+	if len(v.allowedHashes) == 0 {
+		return nil
+	}
+	digest, err := sha256sum(resolved)
+	if err != nil {
+		return fmt.Errorf("failed to compute digest of ldconfig binary %v: %w", resolved, err)
+	}
+	for _, allowed := range v.allowedHashes {
+		if digest == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("ldconfig binary %v has unexpected SHA256 digest %v", resolved, digest)
+}
+
+func (v *verifier) isAllowedPath(path string) bool {
+	for _, pattern := range v.allowedPaths {
+		if match, _ := filepath.Match(pattern, path); match {
+			return true
+		}
+	}
+	return false
+}
+
+func sha256sum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
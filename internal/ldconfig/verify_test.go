@@ -0,0 +1,120 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package ldconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	// The SHA256 digest of the contents "ldconfig".
+	const digest = "f1f528048774e7554251a7ab4e3aa23d2f21e1d4ead4ff147c974b558179552f"
+
+	testCases := []struct {
+		description   string
+		name          string
+		mode          os.FileMode
+		symlink       string
+		allowedPaths  []string
+		allowedHashes []string
+		expectedError bool
+	}{
+		{
+			description:  "binary at allowed path is trusted",
+			name:         "ldconfig",
+			mode:         0755,
+			allowedPaths: []string{"ldconfig"},
+		},
+		{
+			description:   "binary at other path is not trusted",
+			name:          "ldconfig",
+			mode:          0755,
+			allowedPaths:  []string{"ldconfig.real"},
+			expectedError: true,
+		},
+		{
+			description:   "symlink to binary at other path is not trusted",
+			name:          "evil",
+			mode:          0755,
+			symlink:       "ldconfig",
+			allowedPaths:  []string{"ldconfig"},
+			expectedError: true,
+		},
+		{
+			description:   "group-writable binary is not trusted",
+			name:          "ldconfig",
+			mode:          0775,
+			allowedPaths:  []string{"ldconfig"},
+			expectedError: true,
+		},
+		{
+			description:   "binary with matching digest is trusted",
+			name:          "ldconfig",
+			mode:          0755,
+			allowedPaths:  []string{"ldconfig"},
+			allowedHashes: []string{"sha256:0000", "SHA256:" + digest},
+		},
+		{
+			description:   "binary with unexpected digest is not trusted",
+			name:          "ldconfig",
+			mode:          0755,
+			allowedPaths:  []string{"ldconfig"},
+			allowedHashes: []string{"0000"},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			dir, err := filepath.EvalSymlinks(t.TempDir())
+			require.NoError(t, err)
+
+			binary := filepath.Join(dir, tc.name)
+			require.NoError(t, os.WriteFile(binary, []byte("ldconfig"), 0600))
+			require.NoError(t, os.Chmod(binary, tc.mode))
+
+			ldconfigPath := binary
+			if tc.symlink != "" {
+				ldconfigPath = filepath.Join(dir, tc.symlink)
+				require.NoError(t, os.Symlink(binary, ldconfigPath))
+			}
+
+			var allowedPaths []string
+			for _, p := range tc.allowedPaths {
+				allowedPaths = append(allowedPaths, filepath.Join(dir, p))
+			}
+
+			v := &verifier{
+				checkOwner: func(os.FileInfo) error { return nil },
+			}
+			WithAllowedPaths(allowedPaths...)(v)
+			WithAllowedSHA256(tc.allowedHashes...)(v)
+
+			err = v.verify(ldconfigPath)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
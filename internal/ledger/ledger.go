@@ -0,0 +1,223 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package ledger implements a node-local record of the devices that are
+// allocated exclusively to containers. The ledger is stored as a JSON file
+// and all access is serialized using an exclusive lock on an associated lock
+// file so that concurrent runtime invocations never allocate the same device
+// to more than one container.
+package ledger
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+// An Entry records the devices allocated to a single container.
+type Entry struct {
+	Bundle  string    `json:"bundle"`
+	PIDFile string    `json:"pidFile,omitempty"`
+	Devices []string  `json:"devices"`
+	Created time.Time `json:"created"`
+}
+
+type contents struct {
+	Entries []Entry `json:"entries"`
+}
+
+// A Ledger is an open, locked allocation ledger.
+type Ledger struct {
+	logger   logger.Interface
+	path     string
+	lock     *os.File
+	contents contents
+	modified bool
+}
+
+// Open opens and locks the ledger at the specified path. The call blocks until
+// the lock can be acquired. Entries for containers that no longer exist are
+// removed. The ledger must be closed to persist changes and release the
+// lock.
+func Open(logger logger.Interface, path string) (*Ledger, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create ledger directory: %w", err)
+	}
+	lock, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ledger lock file: %w", err)
+	}
+	if err := lockFile(lock); err != nil {
+		lock.Close()
+		return nil, fmt.Errorf("failed to lock ledger: %w", err)
+	}
+
+	l := &Ledger{
+		logger: logger,
+		path:   path,
+		lock:   lock,
+	}
+	if err := l.load(); err != nil {
+		l.release()
+		return nil, err
+	}
+	l.reap()
+	return l, nil
+}
+
+func (l *Ledger) load() error {
+	data, err := os.ReadFile(l.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read ledger: %w", err)
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, &l.contents); err != nil {
+		return fmt.Errorf("failed to parse ledger: %w", err)
+	}
+	return nil
+}
+
+// reap removes the entries for containers that have exited. A container is
+// considered to have exited if its bundle no longer exists or if the process
+// referenced by its PID file is no longer running.
+func (l *Ledger) reap() {
+	var entries []Entry
+	for _, e := range l.contents.Entries {
+		if isStale(e) {
+			l.logger.Debugf("Releasing devices %v allocated to %v", e.Devices, e.Bundle)
+			l.modified = true
+			continue
+		}
+		entries = append(entries, e)
+	}
+	l.contents.Entries = entries
+}
+
+func isStale(e Entry) bool {
+	if _, err := os.Stat(e.Bundle); os.IsNotExist(err) {
+		return true
+	}
+	if e.PIDFile == "" {
+		return false
+	}
+	data, err := os.ReadFile(e.PIDFile)
+	if err != nil {
+		// The PID file is only written once the container has been created.
+		return false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return false
+	}
+	return !processExists(pid)
+}
+
+// Allocated returns the allocated devices and the bundle of the container
+// that each device is allocated to.
+func (l *Ledger) Allocated() map[string]string {
+	allocated := make(map[string]string)
+	for _, e := range l.contents.Entries {
+		for _, device := range e.Devices {
+			allocated[device] = e.Bundle
+		}
+	}
+	return allocated
+}
+
+// Allocate records the specified devices as allocated to the container with
+// the specified bundle. An error is returned if any of the devices are
+// allocated to another container. Allocating devices to a container that
+// already has an entry replaces that entry.
+func (l *Ledger) Allocate(bundle string, pidFile string, devices ...string) error {
+	allocated := l.Allocated()
+	for _, device := range devices {
+		if owner, ok := allocated[device]; ok && owner != bundle {
+			return fmt.Errorf("device %v is exclusively allocated to the container with bundle %v", device, owner)
+		}
+	}
+
+	var entries []Entry
+	for _, e := range l.contents.Entries {
+		if e.Bundle != bundle {
+			entries = append(entries, e)
+		}
+	}
+	l.contents.Entries = append(entries, Entry{
+		Bundle:  bundle,
+		PIDFile: pidFile,
+		Devices: devices,
+		Created: time.Now().UTC(),
+	})
+	l.modified = true
+	return nil
+}
+
+// Release removes the entry for the container with the specified bundle. The
+// devices that were allocated to the container are returned.
+func (l *Ledger) Release(bundle string) []string {
+	var released []string
+	var entries []Entry
+	for _, e := range l.contents.Entries {
+		if e.Bundle != bundle {
+			entries = append(entries, e)
+			continue
+		}
+		released = append(released, e.Devices...)
+		l.modified = true
+	}
+	l.contents.Entries = entries
+	return released
+}
+
+// Close writes the ledger if it was modified and releases the lock.
+func (l *Ledger) Close() error {
+	defer l.release()
+	if !l.modified {
+		return nil
+	}
+
+	data, err := json.Marshal(l.contents)
+	if err != nil {
+		return fmt.Errorf("failed to marshal ledger: %w", err)
+	}
+	// We write to a temporary file and rename it to ensure that the ledger is
+	// never left partially written.
+	tmp := l.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write ledger: %w", err)
+	}
+	if err := os.Rename(tmp, l.path); err != nil {
+		return fmt.Errorf("failed to write ledger: %w", err)
+	}
+	return nil
+}
+
+func (l *Ledger) release() {
+	_ = unlockFile(l.lock)
+	_ = l.lock.Close()
+}
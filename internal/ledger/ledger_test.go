@@ -0,0 +1,77 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package ledger
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLedger(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	path := filepath.Join(t.TempDir(), "nvidia-container-toolkit", "allocations.json")
+
+	running := t.TempDir()
+	runningPIDFile := filepath.Join(running, "init.pid")
+	require.NoError(t, os.WriteFile(runningPIDFile, []byte(strconv.Itoa(os.Getpid())), 0600))
+
+	exited := t.TempDir()
+	exitedPIDFile := filepath.Join(exited, "init.pid")
+	// PIDs are limited to 2^22 on Linux, so this PID cannot be in use.
+	require.NoError(t, os.WriteFile(exitedPIDFile, []byte("99999999"), 0600))
+
+	removed := filepath.Join(t.TempDir(), "removed")
+	require.NoError(t, os.Mkdir(removed, 0755))
+
+	l, err := Open(logger, path)
+	require.NoError(t, err)
+	require.NoError(t, l.Allocate(running, runningPIDFile, "GPU-0"))
+	require.NoError(t, l.Allocate(exited, exitedPIDFile, "GPU-1"))
+	require.NoError(t, l.Allocate(removed, "", "GPU-2"))
+	require.Error(t, l.Allocate(t.TempDir(), "", "GPU-1"))
+	require.NoError(t, l.Close())
+
+	require.NoError(t, os.RemoveAll(removed))
+
+	l, err = Open(logger, path)
+	require.NoError(t, err)
+	require.EqualValues(t, map[string]string{"GPU-0": running}, l.Allocated())
+
+	// Reallocating devices to the same container replaces its entry.
+	require.NoError(t, l.Allocate(running, runningPIDFile, "GPU-0", "GPU-1"))
+	require.NoError(t, l.Close())
+
+	l, err = Open(logger, path)
+	require.NoError(t, err)
+	require.EqualValues(t, map[string]string{"GPU-0": running, "GPU-1": running}, l.Allocated())
+
+	// Releasing a container removes its entry even if it is still running.
+	require.EqualValues(t, []string{"GPU-0", "GPU-1"}, l.Release(running))
+	require.Empty(t, l.Release(running))
+	require.NoError(t, l.Close())
+
+	l, err = Open(logger, path)
+	require.NoError(t, err)
+	require.Empty(t, l.Allocated())
+	require.NoError(t, l.Close())
+}
@@ -0,0 +1,147 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package logger
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// A NonBlockingWriter writes to an underlying writer from a background
+// goroutine. Writes are queued in a bounded buffer and never block the caller.
+// If the buffer is full, for example because the underlying writer is a file on
+// a stalled NFS mount, the data is dropped and counted instead.
+type NonBlockingWriter struct {
+	w       io.Writer
+	entries chan nonBlockingEntry
+	dropped atomic.Uint64
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// A nonBlockingEntry is either data to be written or a marker that is closed
+// once all preceding data has been written.
+type nonBlockingEntry struct {
+	data    []byte
+	flushed chan struct{}
+}
+
+var _ io.Writer = (*NonBlockingWriter)(nil)
+
+// NewNonBlockingWriter creates a writer that buffers at most size writes for
+// the specified writer.
+func NewNonBlockingWriter(w io.Writer, size int) *NonBlockingWriter {
+	if size < 1 {
+		size = 1
+	}
+	n := &NonBlockingWriter{
+		w:       w,
+		entries: make(chan nonBlockingEntry, size),
+	}
+	go n.run()
+	return n
+}
+
+func (n *NonBlockingWriter) run() {
+	for entry := range n.entries {
+		if entry.flushed != nil {
+			close(entry.flushed)
+			continue
+		}
+		// Errors cannot be reported to the caller since the write has already
+		// returned.
+		_, _ = n.w.Write(entry.data)
+	}
+}
+
+// Write queues a copy of the specified data to be written. The data is dropped
+// if the buffer is full or the writer has been closed. Write always reports
+// that all data was written.
+func (n *NonBlockingWriter) Write(p []byte) (int, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	if n.closed {
+		n.dropped.Add(1)
+		return len(p), nil
+	}
+
+	data := make([]byte, len(p))
+	copy(data, p)
+	select {
+	case n.entries <- nonBlockingEntry{data: data}:
+	default:
+		n.dropped.Add(1)
+	}
+	return len(p), nil
+}
+
+// Dropped returns the number of writes that were dropped.
+func (n *NonBlockingWriter) Dropped() uint64 {
+	return n.dropped.Load()
+}
+
+// Flush waits until all data queued before the call has been written to the
+// underlying writer or the timeout expires. It returns false on timeout.
+func (n *NonBlockingWriter) Flush(timeout time.Duration) bool {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	if n.closed {
+		return true
+	}
+	return n.flush(timeout)
+}
+
+func (n *NonBlockingWriter) flush(timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	marker := nonBlockingEntry{flushed: make(chan struct{})}
+	select {
+	case n.entries <- marker:
+	case <-timer.C:
+		return false
+	}
+
+	select {
+	case <-marker.flushed:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// Close flushes the queued data with the specified timeout and stops the
+// background goroutine once the remaining data has been written. Subsequent
+// writes are dropped. Close does not close the underlying writer.
+func (n *NonBlockingWriter) Close(timeout time.Duration) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.closed {
+		return true
+	}
+	flushed := n.flush(timeout)
+	n.closed = true
+	close(n.entries)
+	return flushed
+}
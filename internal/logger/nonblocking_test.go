@@ -0,0 +1,103 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package logger
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// blockingWriter blocks all writes until it is released.
+type blockingWriter struct {
+	release chan struct{}
+	mu      sync.Mutex
+	buf     bytes.Buffer
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	<-w.release
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *blockingWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+func TestNonBlockingWriter(t *testing.T) {
+	t.Run("writes are forwarded", func(t *testing.T) {
+		w := &blockingWriter{release: make(chan struct{})}
+		close(w.release)
+
+		n := NewNonBlockingWriter(w, 10)
+		for _, line := range []string{"one\n", "two\n", "three\n"} {
+			_, err := n.Write([]byte(line))
+			require.NoError(t, err)
+		}
+		require.True(t, n.Flush(time.Second))
+		require.Equal(t, "one\ntwo\nthree\n", w.String())
+		require.EqualValues(t, 0, n.Dropped())
+		require.True(t, n.Close(time.Second))
+	})
+
+	t.Run("writes do not block on a stalled writer", func(t *testing.T) {
+		w := &blockingWriter{release: make(chan struct{})}
+
+		n := NewNonBlockingWriter(w, 2)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < 10; i++ {
+				_, _ = n.Write([]byte("line\n"))
+			}
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "write blocked")
+		}
+
+		// One write may be in progress with at most two writes buffered.
+		require.GreaterOrEqual(t, n.Dropped(), uint64(7))
+		require.False(t, n.Flush(10*time.Millisecond))
+
+		close(w.release)
+		require.True(t, n.Flush(time.Second))
+		require.EqualValues(t, 10-n.Dropped(), bytes.Count([]byte(w.String()), []byte("\n")))
+	})
+
+	t.Run("writes after close are dropped", func(t *testing.T) {
+		w := &blockingWriter{release: make(chan struct{})}
+		close(w.release)
+
+		n := NewNonBlockingWriter(w, 10)
+		require.True(t, n.Close(time.Second))
+
+		_, err := n.Write([]byte("line\n"))
+		require.NoError(t, err)
+		require.EqualValues(t, 1, n.Dropped())
+		require.Empty(t, w.String())
+	})
+}
@@ -35,13 +35,17 @@ func NewLibraryLocator(opts ...Option) Locator {
 			"/usr/lib64",
 			"/usr/lib/x86_64-linux-gnu",
 			"/usr/lib/aarch64-linux-gnu",
+			"/usr/lib/powerpc64le-linux-gnu",
 			"/usr/lib/x86_64-linux-gnu/nvidia/current",
 			"/usr/lib/aarch64-linux-gnu/nvidia/current",
+			"/usr/lib/powerpc64le-linux-gnu/nvidia/current",
 			"/lib64",
 			"/lib/x86_64-linux-gnu",
 			"/lib/aarch64-linux-gnu",
+			"/lib/powerpc64le-linux-gnu",
 			"/lib/x86_64-linux-gnu/nvidia/current",
 			"/lib/aarch64-linux-gnu/nvidia/current",
+			"/lib/powerpc64le-linux-gnu/nvidia/current",
 		}...),
 	)
 	// We construct a symlink locator for expected library locations.
@@ -49,6 +49,16 @@ func TestDriverLibrariesLocate(t *testing.T) {
 			inputs:   []string{"libcuda.so.1", "libcuda.so.*", "libcuda.so.*.*", "libcuda.so.999.88.77"},
 			expected: "/usr/lib64/libcuda.so.999.88.77",
 		},
+		{
+			rootFs:   "rootfs-no-cache-aarch64",
+			inputs:   []string{"libcuda.so.1", "libcuda.so.*", "libcuda.so.*.*", "libcuda.so.999.88.77"},
+			expected: "/usr/lib/aarch64-linux-gnu/nvidia/current/libcuda.so.999.88.77",
+		},
+		{
+			rootFs:   "rootfs-no-cache-ppc64le",
+			inputs:   []string{"libcuda.so.1", "libcuda.so.*", "libcuda.so.*.*", "libcuda.so.999.88.77"},
+			expected: "/usr/lib/powerpc64le-linux-gnu/libcuda.so.999.88.77",
+		},
 		{
 			rootFs:   "rootfs-1",
 			inputs:   []string{"libcuda.so.1", "libcuda.so.*", "libcuda.so.*.*", "libcuda.so.999.88.77"},
@@ -0,0 +1,143 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package root
+
+import (
+	"cmp"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// versionedLibraryDirPatterns defines the (glob) patterns for directories in
+// which the user-space components of a specific driver version are installed.
+// This allows for multiple driver versions to be installed concurrently, for
+// example /usr/lib/nvidia-535 and /usr/lib/nvidia-550.
+var versionedLibraryDirPatterns = []string{
+	"/usr/lib/nvidia-*",
+	"/usr/lib64/nvidia-*",
+	"/usr/lib/x86_64-linux-gnu/nvidia-*",
+	"/usr/lib/aarch64-linux-gnu/nvidia-*",
+	"/usr/lib/powerpc64le-linux-gnu/nvidia-*",
+}
+
+// A DriverVersion represents the user-space components of a driver version
+// installed in a dedicated directory.
+type DriverVersion struct {
+	// Version is the full driver version (e.g. 550.54.14).
+	Version string
+	// LibraryPath is the path to the directory containing the driver libraries.
+	// This is a path on the host and includes the driver root.
+	LibraryPath string
+}
+
+// InstalledVersions returns the driver versions that are installed in
+// versioned directories in the driver root. The versions are determined from
+// the libcuda.so.VERSION libraries in these directories and are sorted by
+// version in ascending order.
+func (r *Driver) InstalledVersions() ([]DriverVersion, error) {
+	var versions []DriverVersion
+	seen := make(map[string]bool)
+	for _, pattern := range versionedLibraryDirPatterns {
+		libcudaPattern := filepath.Join(r.Root, pattern, "libcuda.so.*.*")
+		candidates, err := filepath.Glob(libcudaPattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search for %v: %w", libcudaPattern, err)
+		}
+		for _, candidate := range candidates {
+			version := strings.TrimPrefix(filepath.Base(candidate), "libcuda.so.")
+			if seen[version] {
+				r.logger.Warningf("Ignoring duplicate driver version %v at %v", version, candidate)
+				continue
+			}
+			seen[version] = true
+			versions = append(versions, DriverVersion{
+				Version:     version,
+				LibraryPath: filepath.Dir(candidate),
+			})
+		}
+	}
+
+	slices.SortFunc(versions, func(a, b DriverVersion) int {
+		return compareVersions(a.Version, b.Version)
+	})
+	return versions, nil
+}
+
+// compareVersions compares two driver versions such as 535.161.08. The
+// dot-separated components are compared numerically so that 550.9 is ordered
+// before 550.54. Components that are not numeric are compared as strings.
+func compareVersions(a string, b string) int {
+	aComponents := strings.Split(a, ".")
+	bComponents := strings.Split(b, ".")
+	for i := 0; i < len(aComponents) && i < len(bComponents); i++ {
+		aValue, aErr := strconv.ParseUint(aComponents[i], 10, 64)
+		bValue, bErr := strconv.ParseUint(bComponents[i], 10, 64)
+		var c int
+		if aErr == nil && bErr == nil {
+			c = cmp.Compare(aValue, bValue)
+		} else {
+			c = strings.Compare(aComponents[i], bComponents[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(aComponents), len(bComponents))
+}
+
+// ForVersion returns a copy of the driver root where the libraries for the
+// specified driver version are searched for first. If no version is
+// specified, the driver root is returned unchanged. If the requested version
+// is not installed in a versioned directory, an error is returned.
+func (r *Driver) ForVersion(version string) (*Driver, error) {
+	if version == "" {
+		return r, nil
+	}
+	versions, err := r.InstalledVersions()
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range versions {
+		if v.Version != version {
+			continue
+		}
+		r.logger.Infof("Using driver version %v from %v", version, v.LibraryPath)
+		d := *r
+		d.librarySearchPaths = append([]string{v.LibraryPath}, r.librarySearchPaths...)
+		return &d, nil
+	}
+	return nil, fmt.Errorf("driver version %v is not installed in a versioned directory", version)
+}
+
+// Version returns the version of the user-space driver components at the
+// driver root. This is determined from the libcuda.so.VERSION library that is
+// located first.
+func (r *Driver) Version() (string, error) {
+	libcudaPaths, err := r.Libraries().Locate("libcuda.so.*.*")
+	if err != nil {
+		return "", fmt.Errorf("failed to locate libcuda.so: %w", err)
+	}
+	version := strings.TrimPrefix(filepath.Base(libcudaPaths[0]), "libcuda.so.")
+	if version == "" {
+		return "", fmt.Errorf("failed to determine driver version from %v", libcudaPaths[0])
+	}
+	return version, nil
+}
@@ -0,0 +1,175 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package root
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstalledVersions(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description      string
+		libraries        []string
+		expectedVersions []DriverVersion
+	}{
+		{
+			description: "no versioned directories",
+			libraries:   []string{"/usr/lib64/libcuda.so.550.54.14"},
+		},
+		{
+			description: "multiple versions are sorted",
+			libraries: []string{
+				"/usr/lib/nvidia-550/libcuda.so.550.54.14",
+				"/usr/lib/nvidia-550/libcuda.so.1",
+				"/usr/lib64/nvidia-535/libcuda.so.535.161.08",
+			},
+			expectedVersions: []DriverVersion{
+				{Version: "535.161.08", LibraryPath: "/usr/lib64/nvidia-535"},
+				{Version: "550.54.14", LibraryPath: "/usr/lib/nvidia-550"},
+			},
+		},
+		{
+			description: "versions are sorted numerically",
+			libraries: []string{
+				"/usr/lib/nvidia-1000/libcuda.so.1000.1.2",
+				"/usr/lib/nvidia-550/libcuda.so.550.54.14",
+				"/usr/lib/nvidia-550-beta/libcuda.so.550.9.1",
+			},
+			expectedVersions: []DriverVersion{
+				{Version: "550.9.1", LibraryPath: "/usr/lib/nvidia-550-beta"},
+				{Version: "550.54.14", LibraryPath: "/usr/lib/nvidia-550"},
+				{Version: "1000.1.2", LibraryPath: "/usr/lib/nvidia-1000"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			driverRoot := t.TempDir()
+			for _, library := range tc.libraries {
+				path := filepath.Join(driverRoot, library)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+				require.NoError(t, os.WriteFile(path, nil, 0644))
+			}
+
+			driver := New(
+				WithLogger(logger),
+				WithDriverRoot(driverRoot),
+			)
+
+			versions, err := driver.InstalledVersions()
+			require.NoError(t, err)
+
+			var expectedVersions []DriverVersion
+			for _, v := range tc.expectedVersions {
+				v.LibraryPath = filepath.Join(driverRoot, v.LibraryPath)
+				expectedVersions = append(expectedVersions, v)
+			}
+			require.EqualValues(t, expectedVersions, versions)
+
+			for _, v := range expectedVersions {
+				d, err := driver.ForVersion(v.Version)
+				require.NoError(t, err)
+				candidates, err := d.Libraries().Locate("libcuda.so." + v.Version)
+				require.NoError(t, err)
+				require.EqualValues(t, []string{filepath.Join(v.LibraryPath, "libcuda.so."+v.Version)}, candidates)
+			}
+
+			d, err := driver.ForVersion("")
+			require.NoError(t, err)
+			require.Same(t, driver, d, "the driver root is unchanged if no version is requested")
+
+			_, err = driver.ForVersion("999.99.99")
+			require.Error(t, err, "a version that is not installed is rejected")
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	testCases := []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{a: "550.54.14", b: "550.54.14", expected: 0},
+		{a: "550.9.1", b: "550.54.14", expected: -1},
+		{a: "1000.1.2", b: "550.54.14", expected: 1},
+		{a: "535.161.08", b: "535.161.7", expected: 1},
+		{a: "550.54", b: "550.54.14", expected: -1},
+		{a: "550.54.beta", b: "550.54.14", expected: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.a+" "+tc.b, func(t *testing.T) {
+			require.Equal(t, tc.expected, compareVersions(tc.a, tc.b))
+		})
+	}
+}
+
+func TestVersion(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description     string
+		libraries       []string
+		expectedError   bool
+		expectedVersion string
+	}{
+		{
+			description:     "version is determined from libcuda",
+			libraries:       []string{"/usr/lib64/libcuda.so.1", "/usr/lib64/libcuda.so.550.54.14"},
+			expectedVersion: "550.54.14",
+		},
+		{
+			description:   "missing libcuda returns error",
+			libraries:     []string{"/usr/lib64/libnvidia-ml.so.550.54.14"},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			driverRoot := t.TempDir()
+			for _, library := range tc.libraries {
+				path := filepath.Join(driverRoot, library)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+				require.NoError(t, os.WriteFile(path, nil, 0644))
+			}
+
+			driver := New(
+				WithLogger(logger),
+				WithDriverRoot(driverRoot),
+			)
+
+			version, err := driver.Version()
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedVersion, version)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package manifest records the changes that nvidia-ctk makes to the host so
+// that these can be reverted when the NVIDIA Container Toolkit is uninstalled.
+package manifest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/filelock"
+)
+
+// DefaultPath is the path of the manifest on the host.
+const DefaultPath = "/var/lib/nvidia-container-toolkit/manifest.json"
+
+// A Manifest lists the changes made to the host.
+type Manifest struct {
+	// EngineConfigs are the container engine configs updated by
+	// nvidia-ctk runtime configure.
+	EngineConfigs []EngineConfig `json:"engineConfigs,omitempty"`
+	// LdsoconfFiles are the ld.so.conf.d files written by
+	// nvidia-ctk system ldconfig.
+	LdsoconfFiles []string `json:"ldsoconfFiles,omitempty"`
+}
+
+// An EngineConfig records the changes made to a container engine config in
+// addition to adding the NVIDIA runtime.
+type EngineConfig struct {
+	Path        string `json:"path"`
+	RuntimeName string `json:"runtimeName"`
+	// PreviousDefaultRuntime is the default runtime that was replaced when
+	// the NVIDIA runtime was set as the default.
+	PreviousDefaultRuntime string `json:"previousDefaultRuntime,omitempty"`
+	// EnabledCDI indicates that the use of CDI devices was enabled.
+	EnabledCDI bool `json:"enabledCDI,omitempty"`
+}
+
+// Load reads the manifest at the specified path. If the file does not exist,
+// an empty manifest is returned.
+func Load(path string) (*Manifest, error) {
+	m := &Manifest{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %v: %w", path, err)
+	}
+	return m, nil
+}
+
+// Update applies the specified changes to the manifest at the specified path.
+// The manifest is locked while it is updated so that changes made by
+// concurrent invocations are not lost.
+func Update(path string, update func(*Manifest)) error {
+	return filelock.WithLock(path, func() error {
+		m, err := Load(path)
+		if err != nil {
+			return err
+		}
+		update(m)
+
+		data, err := json.MarshalIndent(m, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal manifest: %w", err)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create manifest directory: %w", err)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return fmt.Errorf("failed to write manifest: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetEngineConfig returns the recorded changes for the specified runtime in
+// the engine config at the specified path. If no changes were recorded, nil
+// is returned.
+func (m *Manifest) GetEngineConfig(path string, runtimeName string) *EngineConfig {
+	for i, e := range m.EngineConfigs {
+		if e.Path == path && e.RuntimeName == runtimeName {
+			return &m.EngineConfigs[i]
+		}
+	}
+	return nil
+}
+
+// AddEngineConfig records the changes made to an engine config. If changes
+// were already recorded for the config, the original state of the config is
+// retained: the previous default runtime is not replaced and CDI remains
+// marked as enabled.
+func (m *Manifest) AddEngineConfig(e EngineConfig) {
+	existing := m.GetEngineConfig(e.Path, e.RuntimeName)
+	if existing == nil {
+		m.EngineConfigs = append(m.EngineConfigs, e)
+		return
+	}
+	if existing.PreviousDefaultRuntime == "" {
+		existing.PreviousDefaultRuntime = e.PreviousDefaultRuntime
+	}
+	existing.EnabledCDI = existing.EnabledCDI || e.EnabledCDI
+}
+
+// AddLdsoconfFile records that the specified ld.so.conf.d file was written.
+func (m *Manifest) AddLdsoconfFile(path string) {
+	if slices.Contains(m.LdsoconfFiles, path) {
+		return
+	}
+	m.LdsoconfFiles = append(m.LdsoconfFiles, path)
+}
+
+// RemoveLdsoconfFile records that the specified ld.so.conf.d file was
+// removed.
+func (m *Manifest) RemoveLdsoconfFile(path string) {
+	m.LdsoconfFiles = slices.DeleteFunc(m.LdsoconfFiles, func(p string) bool {
+		return p == path
+	})
+}
@@ -0,0 +1,60 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package manifest
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.json")
+
+	m, err := Load(path)
+	require.NoError(t, err)
+	require.Equal(t, &Manifest{}, m)
+
+	require.NoError(t, Update(path, func(m *Manifest) {
+		m.AddEngineConfig(EngineConfig{Path: "/etc/docker/daemon.json", RuntimeName: "nvidia", PreviousDefaultRuntime: "crun"})
+		m.AddLdsoconfFile("/etc/ld.so.conf.d/nvidia-driver-root.conf")
+	}))
+	require.NoError(t, Update(path, func(m *Manifest) {
+		// A repeated configure does not overwrite the original state.
+		m.AddEngineConfig(EngineConfig{Path: "/etc/docker/daemon.json", RuntimeName: "nvidia", PreviousDefaultRuntime: "nvidia", EnabledCDI: true})
+		m.AddLdsoconfFile("/etc/ld.so.conf.d/nvidia-driver-root.conf")
+	}))
+
+	m, err = Load(path)
+	require.NoError(t, err)
+	require.Equal(t, &Manifest{
+		EngineConfigs: []EngineConfig{
+			{Path: "/etc/docker/daemon.json", RuntimeName: "nvidia", PreviousDefaultRuntime: "crun", EnabledCDI: true},
+		},
+		LdsoconfFiles: []string{"/etc/ld.so.conf.d/nvidia-driver-root.conf"},
+	}, m)
+	require.Nil(t, m.GetEngineConfig("/etc/containerd/config.toml", "nvidia"))
+
+	require.NoError(t, Update(path, func(m *Manifest) {
+		m.RemoveLdsoconfFile("/etc/ld.so.conf.d/nvidia-driver-root.conf")
+	}))
+	m, err = Load(path)
+	require.NoError(t, err)
+	require.Empty(t, m.LdsoconfFiles)
+}
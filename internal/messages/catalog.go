@@ -0,0 +1,171 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package messages
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	// catalogFileEnvVar sets the path of a catalog file that overrides the
+	// built-in messages.
+	catalogFileEnvVar = "NVIDIA_CTK_MESSAGE_CATALOG"
+	// defaultCatalogDir is the folder that is searched for a catalog matching
+	// the current locale (e.g. de.json or pt_BR.json).
+	defaultCatalogDir = "/usr/share/nvidia-container-toolkit/messages"
+
+	troubleshootingURL = "https://docs.nvidia.com/datacenter/cloud-native/container-toolkit/latest/troubleshooting.html"
+)
+
+// An Entry defines the message for a specific ID. The message is a
+// text/template that is rendered with the data of the error.
+type Entry struct {
+	Message     string `json:"message,omitempty"`
+	Remediation string `json:"remediation,omitempty"`
+}
+
+type catalog map[ID]Entry
+
+// defaultCatalog defines the built-in (English) messages.
+var defaultCatalog = catalog{
+	ConfigLoadFailed: {
+		Message: "failed to load the NVIDIA Container Toolkit config{{if .Path}} from {{.Path}}{{end}}",
+	},
+	UnsupportedRuntime: {
+		Message: "unrecognized runtime '{{.Runtime}}'",
+	},
+	LowLevelRuntimeNotFound: {
+		Message: "no runtime binary found from candidate list: {{.Candidates}}",
+	},
+	NVMLInitFailed: {
+		Message: "failed to initialize NVML",
+	},
+	DriverNotReady: {
+		Message: "timed out waiting for the NVIDIA driver",
+	},
+	DeviceNodeNotFound: {
+		Message: "the device node {{.Path}} does not exist on the host; check that the NVIDIA driver is loaded and regenerate the CDI specification",
+	},
+	NouveauBound: {
+		Message: "the nouveau driver is bound to {{.Devices}}; disable nouveau (e.g. modprobe.blacklist=nouveau) and load the NVIDIA kernel module",
+	},
+	NouveauLoaded: {
+		Message: "the nouveau kernel module is loaded instead of the NVIDIA kernel module; disable nouveau (e.g. modprobe.blacklist=nouveau) and load the NVIDIA kernel module",
+	},
+	ModuleNotLoaded: {
+		Message: "the NVIDIA kernel module is not loaded; load it with 'modprobe nvidia' and check the kernel log for errors",
+	},
+	ModuleNotInitialized: {
+		Message: "the NVIDIA kernel module is loaded but failed to initialize; check the kernel log for errors (e.g. 'dmesg | grep NVRM')",
+	},
+	DriverVersionMismatch: {
+		Message: "the NVIDIA user-space driver version {{.LibraryVersion}} does not match the loaded kernel module version {{.KernelModuleVersion}}; a driver upgrade is likely in progress and the node must be rebooted or the NVIDIA kernel module reloaded to complete it",
+	},
+}
+
+var (
+	loadOnce sync.Once
+	loaded   catalog
+)
+
+// getCatalog returns the catalog for the current process. This is loaded
+// once and combines the built-in messages with any overrides.
+func getCatalog() catalog {
+	loadOnce.Do(func() {
+		loaded = loadCatalog(os.Getenv(catalogFileEnvVar), defaultCatalogDir, getLocales())
+	})
+	return loaded
+}
+
+// loadCatalog returns the built-in catalog with the entries from the first
+// catalog file that exists overlayed. If a path is explicitly specified,
+// this is used. Otherwise the catalog dir is searched for a file matching
+// one of the specified locales.
+func loadCatalog(path string, dir string, locales []string) catalog {
+	candidates := []string{path}
+	if path == "" {
+		candidates = nil
+		for _, locale := range locales {
+			candidates = append(candidates, filepath.Join(dir, locale+".json"))
+		}
+	}
+
+	c := make(catalog)
+	for id, entry := range defaultCatalog {
+		c[id] = entry
+	}
+	for _, candidate := range candidates {
+		contents, err := os.ReadFile(candidate)
+		if err != nil {
+			continue
+		}
+		var overrides catalog
+		if err := json.Unmarshal(contents, &overrides); err != nil {
+			continue
+		}
+		for id, entry := range overrides {
+			existing := c[id]
+			if entry.Message != "" {
+				existing.Message = entry.Message
+			}
+			if entry.Remediation != "" {
+				existing.Remediation = entry.Remediation
+			}
+			c[id] = existing
+		}
+		break
+	}
+	return c
+}
+
+// get returns the entry for the specified ID. If no remediation link is
+// defined, the troubleshooting guide is returned.
+func (c catalog) get(id ID) Entry {
+	entry := c[id]
+	if entry.Remediation == "" {
+		entry.Remediation = troubleshootingURL + "#" + strings.ToLower(string(id))
+	}
+	return entry
+}
+
+// getLocales returns the candidate locales for the current process based on
+// the standard locale envvars. For a locale such as pt_BR.UTF-8, both pt_BR
+// and pt are returned.
+func getLocales() []string {
+	var locale string
+	for _, envvar := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if locale = os.Getenv(envvar); locale != "" {
+			break
+		}
+	}
+	locale, _, _ = strings.Cut(locale, ".")
+	locale, _, _ = strings.Cut(locale, "@")
+	if locale == "" || locale == "C" || locale == "POSIX" {
+		return nil
+	}
+
+	locales := []string{locale}
+	if language, _, found := strings.Cut(locale, "_"); found {
+		locales = append(locales, language)
+	}
+	return locales
+}
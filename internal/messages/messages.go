@@ -0,0 +1,168 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package messages provides a catalog of user-facing error messages. Each
+// message has a stable ID and a link to remediation steps so that errors can
+// be mapped to knowledge-base entries programmatically. The message texts
+// can be translated or overridden by providing a catalog file.
+package messages
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"text/template"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+// An ID identifies a message in the catalog. IDs are stable across releases
+// and are included in the rendered error messages.
+type ID string
+
+const (
+	// ConfigLoadFailed indicates that the toolkit config file could not be
+	// loaded.
+	ConfigLoadFailed = ID("NVCT-0001")
+	// UnsupportedRuntime indicates that a container engine is not supported
+	// by nvidia-ctk runtime configure.
+	UnsupportedRuntime = ID("NVCT-0002")
+	// LowLevelRuntimeNotFound indicates that none of the configured low-level
+	// runtimes could be found.
+	LowLevelRuntimeNotFound = ID("NVCT-0003")
+	// NVMLInitFailed indicates that NVML could not be initialized.
+	NVMLInitFailed = ID("NVCT-0004")
+	// DriverNotReady indicates that the NVIDIA driver did not become ready
+	// within the specified timeout.
+	DriverNotReady = ID("NVCT-0005")
+	// DeviceNodeNotFound indicates that the host device node of a device
+	// that is injected into a container does not exist.
+	DeviceNodeNotFound = ID("NVCT-0006")
+	// NouveauBound indicates that the nouveau driver is bound to NVIDIA
+	// devices.
+	NouveauBound = ID("NVCT-0101")
+	// NouveauLoaded indicates that the nouveau module is loaded instead of
+	// the NVIDIA kernel module.
+	NouveauLoaded = ID("NVCT-0102")
+	// ModuleNotLoaded indicates that the NVIDIA kernel module is not loaded.
+	ModuleNotLoaded = ID("NVCT-0103")
+	// ModuleNotInitialized indicates that the NVIDIA kernel module is loaded
+	// but failed to initialize.
+	ModuleNotInitialized = ID("NVCT-0104")
+	// DriverVersionMismatch indicates that the version of the user-space
+	// driver libraries does not match the version of the loaded kernel
+	// module.
+	DriverVersionMismatch = ID("NVCT-0105")
+)
+
+// Data holds the values that are substituted into a message template.
+type Data map[string]any
+
+// An Identifier is implemented by errors that are associated with a message
+// in the catalog.
+type Identifier interface {
+	MessageID() ID
+}
+
+// An Error is a user-facing error with a message from the catalog.
+type Error struct {
+	ID   ID
+	Data Data
+	// Err is the underlying error (if any).
+	Err error
+}
+
+var _ Identifier = (*Error)(nil)
+
+// New creates an error for the message with the specified ID.
+func New(id ID, data Data) *Error {
+	return &Error{ID: id, Data: data}
+}
+
+// Wrap creates an error for the message with the specified ID that wraps the
+// specified error.
+func Wrap(err error, id ID, data Data) *Error {
+	return &Error{ID: id, Data: data, Err: err}
+}
+
+// Error returns the rendered message including its ID and the underlying
+// error.
+func (e *Error) Error() string {
+	message := Render(e.ID, e.Data)
+	if e.Err == nil {
+		return fmt.Sprintf("%v (%v)", message, e.ID)
+	}
+	return fmt.Sprintf("%v (%v): %v", message, e.ID, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// MessageID returns the ID of the message.
+func (e *Error) MessageID() ID {
+	return e.ID
+}
+
+// GetID returns the message ID of the first error in the chain of the
+// specified error that is associated with a message in the catalog.
+func GetID(err error) (ID, bool) {
+	var identifier Identifier
+	if !errors.As(err, &identifier) {
+		return "", false
+	}
+	return identifier.MessageID(), true
+}
+
+// Remediation returns the link to the remediation steps for the specified
+// error. If the error is not associated with a message in the catalog, an
+// empty string is returned.
+func Remediation(err error) string {
+	id, ok := GetID(err)
+	if !ok {
+		return ""
+	}
+	return getCatalog().get(id).Remediation
+}
+
+// Render renders the message with the specified ID using the specified data.
+// If the template cannot be rendered, the unrendered template is returned.
+func Render(id ID, data Data) string {
+	text := getCatalog().get(id).Message
+	if text == "" {
+		return string(id)
+	}
+	t, err := template.New(string(id)).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return text
+	}
+	var b bytes.Buffer
+	if err := t.Execute(&b, data); err != nil {
+		return text
+	}
+	return b.String()
+}
+
+// Log logs the specified error. If the error is associated with a message in
+// the catalog, the link to the remediation steps is also logged.
+func Log(logger logger.Interface, err error) {
+	logger.Errorf("%v", err)
+	if remediation := Remediation(err); remediation != "" {
+		logger.Errorf("See %v for possible remediation steps", remediation)
+	}
+}
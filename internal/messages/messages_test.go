@@ -0,0 +1,161 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package messages
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestError(t *testing.T) {
+	testCases := []struct {
+		description         string
+		err                 error
+		expectedMessage     string
+		expectedID          ID
+		expectedRemediation string
+	}{
+		{
+			description:         "message is rendered with data",
+			err:                 New(UnsupportedRuntime, Data{"Runtime": "foo"}),
+			expectedMessage:     "unrecognized runtime 'foo' (NVCT-0002)",
+			expectedID:          UnsupportedRuntime,
+			expectedRemediation: troubleshootingURL + "#nvct-0002",
+		},
+		{
+			description:         "wrapped error is included",
+			err:                 Wrap(errors.New("ERROR_LIBRARY_NOT_FOUND"), NVMLInitFailed, nil),
+			expectedMessage:     "failed to initialize NVML (NVCT-0004): ERROR_LIBRARY_NOT_FOUND",
+			expectedID:          NVMLInitFailed,
+			expectedRemediation: troubleshootingURL + "#nvct-0004",
+		},
+		{
+			description:         "ID is found in error chain",
+			err:                 fmt.Errorf("failed to generate CDI spec: %w", Wrap(errors.New("not found"), NVMLInitFailed, nil)),
+			expectedMessage:     "failed to generate CDI spec: failed to initialize NVML (NVCT-0004): not found",
+			expectedID:          NVMLInitFailed,
+			expectedRemediation: troubleshootingURL + "#nvct-0004",
+		},
+		{
+			description:     "other errors have no ID",
+			err:             errors.New("some error"),
+			expectedMessage: "some error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.EqualError(t, tc.err, tc.expectedMessage)
+
+			id, ok := GetID(tc.err)
+			require.Equal(t, tc.expectedID, id)
+			require.Equal(t, tc.expectedID != "", ok)
+			require.Equal(t, tc.expectedRemediation, Remediation(tc.err))
+		})
+	}
+}
+
+func TestLoadCatalog(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "de.json"), []byte(`{"NVCT-0002": {"message": "unbekannte Laufzeit '{{.Runtime}}'"}}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "override.json"), []byte(`{"NVCT-0004": {"remediation": "https://kb.example.com/nvml"}}`), 0600))
+
+	testCases := []struct {
+		description         string
+		path                string
+		locales             []string
+		id                  ID
+		expectedMessage     string
+		expectedRemediation string
+	}{
+		{
+			description:         "built-in message is used by default",
+			id:                  UnsupportedRuntime,
+			expectedMessage:     "unrecognized runtime '{{.Runtime}}'",
+			expectedRemediation: troubleshootingURL + "#nvct-0002",
+		},
+		{
+			description:         "language catalog is used for locale",
+			locales:             []string{"de_DE", "de"},
+			id:                  UnsupportedRuntime,
+			expectedMessage:     "unbekannte Laufzeit '{{.Runtime}}'",
+			expectedRemediation: troubleshootingURL + "#nvct-0002",
+		},
+		{
+			description:         "explicit catalog overrides remediation",
+			path:                filepath.Join(dir, "override.json"),
+			locales:             []string{"de"},
+			id:                  NVMLInitFailed,
+			expectedMessage:     "failed to initialize NVML",
+			expectedRemediation: "https://kb.example.com/nvml",
+		},
+		{
+			description:         "missing catalog falls back to built-in messages",
+			locales:             []string{"fr_FR", "fr"},
+			id:                  UnsupportedRuntime,
+			expectedMessage:     "unrecognized runtime '{{.Runtime}}'",
+			expectedRemediation: troubleshootingURL + "#nvct-0002",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c := loadCatalog(tc.path, dir, tc.locales)
+			entry := c.get(tc.id)
+			require.Equal(t, tc.expectedMessage, entry.Message)
+			require.Equal(t, tc.expectedRemediation, entry.Remediation)
+		})
+	}
+}
+
+func TestGetLocales(t *testing.T) {
+	testCases := []struct {
+		description     string
+		lang            string
+		expectedLocales []string
+	}{
+		{
+			description: "C locale returns no locales",
+			lang:        "C.UTF-8",
+		},
+		{
+			description:     "language and region are returned",
+			lang:            "pt_BR.UTF-8",
+			expectedLocales: []string{"pt_BR", "pt"},
+		},
+		{
+			description:     "modifier is ignored",
+			lang:            "de_DE@euro",
+			expectedLocales: []string{"de_DE", "de"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			t.Setenv("LC_ALL", "")
+			t.Setenv("LC_MESSAGES", "")
+			t.Setenv("LANG", tc.lang)
+			require.EqualValues(t, tc.expectedLocales, getLocales())
+		})
+	}
+}
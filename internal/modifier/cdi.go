@@ -20,11 +20,14 @@ import (
 	"fmt"
 	"strings"
 
+	cdiapi "tags.cncf.io/container-device-interface/pkg/cdi"
 	"tags.cncf.io/container-device-interface/pkg/parser"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/failinject"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/modifier/cdi"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
@@ -35,20 +38,38 @@ const (
 	automaticDeviceClass  = "gpu"
 	automaticDeviceKind   = automaticDeviceVendor + "/" + automaticDeviceClass
 	automaticDevicePrefix = automaticDeviceKind + "="
+
+	automaticImexChannelKind   = automaticDeviceVendor + "/" + nvcdi.ClassImexChannel
+	automaticImexChannelPrefix = automaticImexChannelKind + "="
 )
 
 // NewCDIModifier creates an OCI spec modifier that determines the modifications to make based on the
 // CDI specifications available on the system. The NVIDIA_VISIBLE_DEVICES environment variable is
-// used to select the devices to include.
-func NewCDIModifier(logger logger.Interface, cfg *config.Config, image image.CUDA, isJitCDI bool) (oci.SpecModifier, error) {
+// used to select the devices to include. IMEX channels requested using the NVIDIA_IMEX_CHANNELS
+// environment variable (or as volume mounts) are included as devices of the imex-channel class.
+func NewCDIModifier(logger logger.Interface, cfg *config.Config, image image.CUDA, driver *root.Driver, isJitCDI bool) (oci.SpecModifier, error) {
 	defaultKind := cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.DefaultKind
+	driverVersion := image.DriverVersion()
+	if driverVersion != "" && !isJitCDI {
+		versionedKind, err := getVersionedKind(logger, cfg, driver, defaultKind, driverVersion)
+		if err != nil {
+			return nil, err
+		}
+		defaultKind = versionedKind
+	}
 	if isJitCDI {
 		defaultKind = automaticDeviceKind
 	}
+	var imexChannelKind string
+	if !cfg.Features.IgnoreImexChannelRequests.IsEnabled() {
+		vendor, _ := parser.ParseQualifier(defaultKind)
+		imexChannelKind = vendor + "/" + nvcdi.ClassImexChannel
+	}
 	deviceRequestor := newCDIDeviceRequestor(
 		logger,
 		image,
 		defaultKind,
+		imexChannelKind,
 	)
 	devices := deviceRequestor.DeviceRequests()
 	if len(devices) == 0 {
@@ -62,7 +83,7 @@ func NewCDIModifier(logger logger.Interface, cfg *config.Config, image image.CUD
 		return nil, fmt.Errorf("requesting a CDI device with vendor 'runtime.nvidia.com' is not supported when requesting other CDI devices")
 	}
 	if len(automaticDevices) > 0 {
-		automaticModifier, err := newAutomaticCDISpecModifier(logger, cfg, automaticDevices)
+		automaticModifier, err := newAutomaticCDISpecModifier(logger, cfg, automaticDevices, driverVersion)
 		if err == nil {
 			return automaticModifier, nil
 		}
@@ -77,6 +98,60 @@ func NewCDIModifier(logger logger.Interface, cfg *config.Config, image image.CUD
 	)
 }
 
+// getVersionedKind returns the CDI kind to use for devices of the specified
+// kind for a requested driver version. Specs for a specific driver version are
+// generated with a versioned class (see nvidia-ctk cdi generate
+// --driver-version). Since the driver version was explicitly requested, an
+// error is returned if the driver version is not installed and no spec for the
+// versioned kind exists instead of injecting a different driver version.
+func getVersionedKind(logger logger.Interface, cfg *config.Config, driver *root.Driver, kind string, driverVersion string) (string, error) {
+	versionedKind := kind + "-" + driverVersion
+	if !isDriverVersionInstalled(logger, driver, driverVersion) && !hasSpecForKind(logger, cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.SpecDirs, versionedKind) {
+		return "", fmt.Errorf("driver version %v was requested but is not installed and no CDI spec for kind %v was found", driverVersion, versionedKind)
+	}
+	logger.Infof("Using CDI kind %v for requested driver version %v", versionedKind, driverVersion)
+	return versionedKind, nil
+}
+
+// isDriverVersionInstalled checks whether the specified driver version is
+// installed in a versioned directory in the driver root.
+func isDriverVersionInstalled(logger logger.Interface, driver *root.Driver, driverVersion string) bool {
+	if driver == nil {
+		return false
+	}
+	versions, err := driver.InstalledVersions()
+	if err != nil {
+		logger.Warningf("Failed to determine installed driver versions: %v", err)
+		return false
+	}
+	for _, v := range versions {
+		if v.Version == driverVersion {
+			return true
+		}
+	}
+	return false
+}
+
+// hasSpecForKind checks whether a CDI spec for the specified kind exists in
+// one of the specified spec directories.
+func hasSpecForKind(logger logger.Interface, specDirs []string, kind string) bool {
+	vendor, class := parser.ParseQualifier(kind)
+	cache, err := cdiapi.NewCache(
+		cdiapi.WithAutoRefresh(false),
+		cdiapi.WithSpecDirs(specDirs...),
+	)
+	if err != nil {
+		logger.Warningf("Failed to load CDI specs: %v", err)
+		return false
+	}
+	for _, spec := range cache.GetVendorSpecs(vendor) {
+		if spec.GetClass() == class {
+			return true
+		}
+	}
+	return false
+}
+
 type deviceRequestor interface {
 	DeviceRequests() []string
 }
@@ -85,13 +160,17 @@ type cdiDeviceRequestor struct {
 	image       image.CUDA
 	logger      logger.Interface
 	defaultKind string
+	// imexChannelKind is the CDI kind used to request the IMEX channels for
+	// the container. If this is empty, IMEX channel requests are ignored.
+	imexChannelKind string
 }
 
-func newCDIDeviceRequestor(logger logger.Interface, image image.CUDA, defaultKind string) deviceRequestor {
+func newCDIDeviceRequestor(logger logger.Interface, image image.CUDA, defaultKind string, imexChannelKind string) deviceRequestor {
 	c := &cdiDeviceRequestor{
-		logger:      logger,
-		image:       image,
-		defaultKind: defaultKind,
+		logger:          logger,
+		image:           image,
+		defaultKind:     defaultKind,
+		imexChannelKind: imexChannelKind,
 	}
 	return withUniqueDevices(c)
 }
@@ -101,24 +180,54 @@ func (c *cdiDeviceRequestor) DeviceRequests() []string {
 		return nil
 	}
 	var devices []string
-	for _, name := range c.image.VisibleDevices() {
-		if !parser.IsQualifiedName(name) {
-			name = fmt.Sprintf("%s=%s", c.defaultKind, name)
+	for _, request := range c.image.VisibleDevices() {
+		for _, name := range splitCombinedDeviceRequest(request) {
+			if !parser.IsQualifiedName(name) {
+				name = fmt.Sprintf("%s=%s", c.defaultKind, name)
+			}
+			devices = append(devices, name)
+		}
+	}
+
+	if c.imexChannelKind != "" {
+		for _, channel := range c.image.ImexChannels() {
+			devices = append(devices, fmt.Sprintf("%s=%s", c.imexChannelKind, channel))
 		}
-		devices = append(devices, name)
 	}
 
 	return devices
 }
 
+// splitCombinedDeviceRequest splits a request for a combination of devices of
+// the same kind (e.g. nvidia.com/gpu=0+graphics) into a request for each of
+// the devices (e.g. nvidia.com/gpu=0 and nvidia.com/gpu=graphics). This allows
+// a device to be requested together with the capability devices in a
+// generated CDI specification.
+func splitCombinedDeviceRequest(request string) []string {
+	prefix, names := "", request
+	if i := strings.LastIndex(request, "="); i >= 0 {
+		prefix, names = request[:i+1], request[i+1:]
+	}
+
+	var devices []string
+	for _, name := range strings.Split(names, "+") {
+		if name == "" {
+			continue
+		}
+		devices = append(devices, prefix+name)
+	}
+	return devices
+}
+
 // filterAutomaticDevices searches for "automatic" device names in the input slice.
 // "Automatic" devices are a well-defined list of CDI device names which, when requested,
 // trigger the generation of a CDI spec at runtime. This removes the need to generate a
-// CDI spec on the system a-priori as well as keep it up-to-date.
+// CDI spec on the system a-priori as well as keep it up-to-date. This includes
+// requests for IMEX channels with the automatic vendor.
 func filterAutomaticDevices(devices []string) []string {
 	var automatic []string
 	for _, device := range devices {
-		if !strings.HasPrefix(device, automaticDevicePrefix) {
+		if !strings.HasPrefix(device, automaticDevicePrefix) && !strings.HasPrefix(device, automaticImexChannelPrefix) {
 			continue
 		}
 		automatic = append(automatic, device)
@@ -126,20 +235,51 @@ func filterAutomaticDevices(devices []string) []string {
 	return automatic
 }
 
-func newAutomaticCDISpecModifier(logger logger.Interface, cfg *config.Config, devices []string) (oci.SpecModifier, error) {
+func newAutomaticCDISpecModifier(logger logger.Interface, cfg *config.Config, devices []string, driverVersion string) (oci.SpecModifier, error) {
 	logger.Debugf("Generating in-memory CDI specs for devices %v", devices)
 
 	var identifiers []string
+	var imexChannels []string
 	for _, device := range devices {
+		if channel, ok := strings.CutPrefix(device, automaticImexChannelPrefix); ok {
+			imexChannels = append(imexChannels, channel)
+			continue
+		}
 		identifiers = append(identifiers, strings.TrimPrefix(device, automaticDevicePrefix))
 	}
 
+	imexChannelModifier, err := newAutomaticImexChannelModifier(logger, cfg, imexChannels)
+	if err != nil {
+		return nil, err
+	}
+	if len(identifiers) == 0 {
+		return imexChannelModifier, nil
+	}
+
+	deviceNodeOwnership, err := cfg.DeviceNodes.Resolve()
+	if err != nil {
+		return nil, err
+	}
+
 	cdilib, err := nvcdi.New(
 		nvcdi.WithLogger(logger),
+		nvcdi.WithDeviceNodeOwnership(
+			deviceNodeOwnership.UID,
+			deviceNodeOwnership.GID,
+			deviceNodeOwnership.FileMode,
+		),
 		nvcdi.WithNVIDIACDIHookPath(cfg.NVIDIACTKConfig.Path),
 		nvcdi.WithDriverRoot(cfg.NVIDIAContainerCLIConfig.Root),
+		nvcdi.WithLdconfigPath(cfg.NVIDIAContainerCLIConfig.HostLDConfigPath()),
+		nvcdi.WithLibrarySearchPaths(cfg.NVIDIAContainerCLIConfig.LibrarySearchPaths),
+		nvcdi.WithDriverVersion(driverVersion),
 		nvcdi.WithVendor(automaticDeviceVendor),
 		nvcdi.WithClass(automaticDeviceClass),
+		nvcdi.WithMinimalDriverFiles(cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.MinimalDriverFiles),
+		nvcdi.WithNvidiaSMILitePath(cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.NvidiaSMILitePath),
+		// Requested failures are only propagated to the hooks of specs
+		// that are generated for a specific container.
+		nvcdi.WithHookEnv(failinject.Env()...),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to construct CDI library: %w", err)
@@ -157,7 +297,36 @@ func newAutomaticCDISpecModifier(logger logger.Interface, cfg *config.Config, de
 		return nil, fmt.Errorf("failed to construct CDI modifier: %w", err)
 	}
 
-	return cdiDeviceRequestor, nil
+	return Merge(cdiDeviceRequestor, imexChannelModifier), nil
+}
+
+// newAutomaticImexChannelModifier generates an in-memory CDI spec for the
+// specified IMEX channels and returns a modifier that injects these channels.
+// If no channels are specified, no modifier is returned.
+func newAutomaticImexChannelModifier(logger logger.Interface, cfg *config.Config, channels []string) (oci.SpecModifier, error) {
+	if len(channels) == 0 {
+		return nil, nil
+	}
+
+	cdilib, err := nvcdi.New(
+		nvcdi.WithLogger(logger),
+		nvcdi.WithMode(nvcdi.ModeImex),
+		nvcdi.WithDriverRoot(cfg.NVIDIAContainerCLIConfig.Root),
+		nvcdi.WithVendor(automaticDeviceVendor),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct CDI library for IMEX channels: %w", err)
+	}
+
+	spec, err := cdilib.GetSpec(channels...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate CDI spec for IMEX channels: %w", err)
+	}
+
+	return cdi.New(
+		cdi.WithLogger(logger),
+		cdi.WithSpec(spec.Raw()),
+	)
 }
 
 type deduplicatedDeviceRequestor struct {
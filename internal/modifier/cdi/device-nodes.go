@@ -0,0 +1,43 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package cdi
+
+import (
+	"strings"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/failinject"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/messages"
+)
+
+// checkInjectedDeviceNodes returns an error for the first NVIDIA device node
+// in the specified OCI spec if the MissingDeviceNode failure is injected.
+// This allows the handling of missing device nodes to be tested without
+// removing the device nodes from the host.
+func checkInjectedDeviceNodes(spec *specs.Spec) error {
+	if !failinject.Enabled(failinject.MissingDeviceNode) || spec == nil || spec.Linux == nil {
+		return nil
+	}
+	for _, device := range spec.Linux.Devices {
+		if strings.HasPrefix(device.Path, "/dev/nvidia") {
+			return messages.New(messages.DeviceNodeNotFound, messages.Data{"Path": device.Path})
+		}
+	}
+	return nil
+}
@@ -62,5 +62,5 @@ func (m fromRegistry) Modify(spec *specs.Spec) error {
 		return fmt.Errorf("failed to inject CDI devices: %v", err)
 	}
 
-	return nil
+	return checkInjectedDeviceNodes(spec)
 }
@@ -44,5 +44,9 @@ func (m fromCDISpec) Modify(spec *specs.Spec) error {
 		}
 	}
 
-	return m.cdiSpec.ApplyEdits(spec)
+	if err := m.cdiSpec.ApplyEdits(spec); err != nil {
+		return err
+	}
+
+	return checkInjectedDeviceNodes(spec)
 }
@@ -17,13 +17,17 @@
 package modifier
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/opencontainers/runtime-spec/specs-go"
 	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/require"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
 )
 
 func TestDeviceRequests(t *testing.T) {
@@ -70,6 +74,18 @@ func TestDeviceRequests(t *testing.T) {
 			},
 			expectedDevices: []string{"nvidia.com/gpu=0", "example.com/class=device"},
 		},
+		{
+			description: "combined cdi devices from envvar are split",
+			input: cdiDeviceRequestor{
+				defaultKind: "nvidia.com/gpu",
+			},
+			spec: &specs.Spec{
+				Process: &specs.Process{
+					Env: []string{"NVIDIA_VISIBLE_DEVICES=0+graphics,nvidia.com/gpu=1+compute+utility"},
+				},
+			},
+			expectedDevices: []string{"nvidia.com/gpu=0", "nvidia.com/gpu=graphics", "nvidia.com/gpu=1", "nvidia.com/gpu=compute", "nvidia.com/gpu=utility"},
+		},
 		{
 			description: "cdi devices from envvar with default kind",
 			input: cdiDeviceRequestor{
@@ -149,6 +165,53 @@ func TestDeviceRequests(t *testing.T) {
 			},
 			expectedDevices: []string{"nvidia.com/gpu=example.com/device"},
 		},
+		{
+			description: "imex channels from envvar are requested",
+			input: cdiDeviceRequestor{
+				defaultKind:     "nvidia.com/gpu",
+				imexChannelKind: "nvidia.com/imex-channel",
+			},
+			spec: &specs.Spec{
+				Process: &specs.Process{
+					Env: []string{
+						"NVIDIA_VISIBLE_DEVICES=0",
+						"NVIDIA_IMEX_CHANNELS=0,2",
+					},
+				},
+			},
+			expectedDevices: []string{"nvidia.com/gpu=0", "nvidia.com/imex-channel=0", "nvidia.com/imex-channel=2"},
+		},
+		{
+			description: "imex channels from mounts are requested",
+			input: cdiDeviceRequestor{
+				defaultKind:     "nvidia.com/gpu",
+				imexChannelKind: "nvidia.com/imex-channel",
+			},
+			spec: &specs.Spec{
+				Mounts: []specs.Mount{
+					{
+						Destination: "/var/run/nvidia-container-devices/imex/1",
+						Source:      "/dev/null",
+					},
+				},
+			},
+			expectedDevices: []string{"nvidia.com/imex-channel=1"},
+		},
+		{
+			description: "imex channels are ignored without a kind",
+			input: cdiDeviceRequestor{
+				defaultKind: "nvidia.com/gpu",
+			},
+			spec: &specs.Spec{
+				Process: &specs.Process{
+					Env: []string{
+						"NVIDIA_VISIBLE_DEVICES=0",
+						"NVIDIA_IMEX_CHANNELS=0",
+					},
+				},
+			},
+			expectedDevices: []string{"nvidia.com/gpu=0"},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -170,3 +233,86 @@ func TestDeviceRequests(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterAutomaticDevices(t *testing.T) {
+	testCases := []struct {
+		description string
+		devices     []string
+		expected    []string
+	}{
+		{
+			description: "no automatic devices",
+			devices:     []string{"nvidia.com/gpu=0", "nvidia.com/imex-channel=0"},
+		},
+		{
+			description: "automatic devices and imex channels are returned",
+			devices:     []string{"runtime.nvidia.com/gpu=0", "runtime.nvidia.com/imex-channel=1", "nvidia.com/gpu=1"},
+			expected:    []string{"runtime.nvidia.com/gpu=0", "runtime.nvidia.com/imex-channel=1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.EqualValues(t, tc.expected, filterAutomaticDevices(tc.devices))
+		})
+	}
+}
+
+func TestGetVersionedKind(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description   string
+		libraries     []string
+		specs         map[string]string
+		expectedKind  string
+		expectedError bool
+	}{
+		{
+			description:  "installed driver version uses versioned kind",
+			libraries:    []string{"/usr/lib/nvidia-550/libcuda.so.550.54.14"},
+			expectedKind: "nvidia.com/gpu-550.54.14",
+		},
+		{
+			description: "spec for versioned kind uses versioned kind",
+			specs: map[string]string{
+				"nvidia-550.yaml": "cdiVersion: 0.6.0\nkind: nvidia.com/gpu-550.54.14\ndevices:\n- name: \"0\"\n  containerEdits:\n    env: [\"FOO=bar\"]\n",
+			},
+			expectedKind: "nvidia.com/gpu-550.54.14",
+		},
+		{
+			description: "other installed version returns an error",
+			libraries:   []string{"/usr/lib/nvidia-535/libcuda.so.535.161.08"},
+			specs: map[string]string{
+				"nvidia.yaml": "cdiVersion: 0.5.0\nkind: nvidia.com/gpu\ndevices:\n- name: \"0\"\n  containerEdits:\n    env: [\"FOO=bar\"]\n",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			driverRoot := t.TempDir()
+			for _, library := range tc.libraries {
+				require.NoError(t, os.MkdirAll(filepath.Join(driverRoot, filepath.Dir(library)), 0755))
+				require.NoError(t, os.WriteFile(filepath.Join(driverRoot, library), nil, 0600))
+			}
+			specDir := t.TempDir()
+			for name, contents := range tc.specs {
+				require.NoError(t, os.WriteFile(filepath.Join(specDir, name), []byte(contents), 0600))
+			}
+
+			cfg := &config.Config{}
+			cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.SpecDirs = []string{specDir}
+			driver := root.New(root.WithLogger(logger), root.WithDriverRoot(driverRoot))
+
+			kind, err := getVersionedKind(logger, cfg, driver, "nvidia.com/gpu", "550.54.14")
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedKind, kind)
+		})
+	}
+}
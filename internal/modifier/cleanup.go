@@ -0,0 +1,114 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"slices"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+type cleanupModifier struct {
+	logger           logger.Interface
+	hookCreator      discover.HookCreator
+	allocationLedger string
+	auditLogArgs     []string
+}
+
+// NewCleanupModifier creates a modifier that adds a poststop hook to clean up
+// after a container exits. The hook releases the devices of the container
+// from the allocation ledger and reverts the changes made to the compute mode
+// and limits of its GPUs.
+//
+// The hook is only added if it is required. Since this is determined from the
+// hooks that are already present in the spec, this modifier must be applied
+// after the compute-mode and gpu-limits modifiers.
+func NewCleanupModifier(logger logger.Interface, cfg *config.Config, container image.CUDA, hookCreator discover.HookCreator) (oci.SpecModifier, error) {
+	if len(container.VisibleDevices()) == 0 {
+		return nil, nil
+	}
+
+	m := &cleanupModifier{
+		logger:       logger,
+		hookCreator:  hookCreator,
+		auditLogArgs: auditLogArgs(cfg),
+	}
+	if container.RequestsExclusiveDevices() {
+		m.allocationLedger = cfg.NVIDIAContainerRuntimeConfig.AllocationLedgerPath
+	}
+	return m, nil
+}
+
+// Modify adds the cleanup hook to the spec if this is required.
+func (m *cleanupModifier) Modify(spec *specs.Spec) error {
+	var args []string
+	if m.allocationLedger != "" {
+		args = append(args, "--allocation-ledger", m.allocationLedger)
+	}
+	if len(args) == 0 && !m.changesGPUSettings(spec) {
+		return nil
+	}
+
+	hook := m.hookCreator.Create(discover.CleanupHook, append(args, m.auditLogArgs...)...)
+	if hook == nil {
+		return nil
+	}
+	hook.Lifecycle = cdi.PoststopHook
+
+	if spec.Hooks != nil {
+		for _, existing := range spec.Hooks.Poststop {
+			if existing.Path == hook.Path && slices.Equal(existing.Args, hook.Args) {
+				m.logger.Debugf("Skipping existing cleanup hook")
+				return nil
+			}
+		}
+	}
+
+	d, err := NewModifierFromDiscoverer(m.logger, hook)
+	if err != nil {
+		return err
+	}
+	return d.Modify(spec)
+}
+
+// changesGPUSettings checks whether the spec includes createRuntime hooks that
+// change the compute mode or the limits of the GPUs of the container.
+func (m *cleanupModifier) changesGPUSettings(spec *specs.Spec) bool {
+	if spec.Hooks == nil {
+		return false
+	}
+	for _, name := range []discover.HookName{discover.ComputeModeHook, discover.GPULimitsHook} {
+		ref := m.hookCreator.Create(name)
+		if ref == nil {
+			continue
+		}
+		for _, hook := range spec.Hooks.CreateRuntime {
+			if hook.Path == ref.Path && len(hook.Args) >= len(ref.Args) && slices.Equal(hook.Args[:len(ref.Args)], ref.Args) {
+				return true
+			}
+		}
+	}
+	return false
+}
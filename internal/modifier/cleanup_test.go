@@ -0,0 +1,140 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+)
+
+func TestCleanupModifier(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	computeModeHook := specs.Hook{
+		Path: "/usr/bin/nvidia-cdi-hook",
+		Args: []string{"nvidia-cdi-hook", "compute-mode", "--mode", "EXCLUSIVE_PROCESS", "--device", "0"},
+	}
+	cfg := &config.Config{
+		NVIDIAContainerRuntimeConfig: config.RuntimeConfig{
+			AllocationLedgerPath: "/run/nvidia-container-toolkit/allocations.json",
+			AuditLogFilePath:     "/var/log/nvidia-container-toolkit/audit.log",
+		},
+	}
+
+	testCases := []struct {
+		description   string
+		envmap        map[string]string
+		disabledHooks []discover.HookName
+		spec          *specs.Spec
+		expectedSpec  *specs.Spec
+	}{
+		{
+			description:  "no devices makes no modification",
+			envmap:       map[string]string{"NVIDIA_EXCLUSIVE_DEVICES": "true"},
+			spec:         &specs.Spec{},
+			expectedSpec: &specs.Spec{},
+		},
+		{
+			description:  "no changes makes no modification",
+			envmap:       map[string]string{"NVIDIA_VISIBLE_DEVICES": "0"},
+			spec:         &specs.Spec{},
+			expectedSpec: &specs.Spec{},
+		},
+		{
+			description: "exclusive devices are released",
+			envmap: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES":   "0",
+				"NVIDIA_EXCLUSIVE_DEVICES": "true",
+			},
+			spec: &specs.Spec{},
+			expectedSpec: &specs.Spec{
+				Hooks: &specs.Hooks{
+					Poststop: []specs.Hook{
+						{
+							Path: "/usr/bin/nvidia-cdi-hook",
+							Args: []string{"nvidia-cdi-hook", "cleanup",
+								"--allocation-ledger", "/run/nvidia-container-toolkit/allocations.json",
+								"--audit-log", "/var/log/nvidia-container-toolkit/audit.log",
+							},
+							Env: []string{"NVIDIA_CTK_DEBUG=false"},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "changed GPU settings are restored",
+			envmap:      map[string]string{"NVIDIA_VISIBLE_DEVICES": "0"},
+			spec: &specs.Spec{
+				Hooks: &specs.Hooks{
+					CreateRuntime: []specs.Hook{computeModeHook},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				Hooks: &specs.Hooks{
+					CreateRuntime: []specs.Hook{computeModeHook},
+					Poststop: []specs.Hook{
+						{
+							Path: "/usr/bin/nvidia-cdi-hook",
+							Args: []string{"nvidia-cdi-hook", "cleanup",
+								"--audit-log", "/var/log/nvidia-container-toolkit/audit.log",
+							},
+							Env: []string{"NVIDIA_CTK_DEBUG=false"},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "disabled hook makes no modification",
+			envmap: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES":   "0",
+				"NVIDIA_EXCLUSIVE_DEVICES": "true",
+			},
+			disabledHooks: []discover.HookName{discover.CleanupHook},
+			spec:          &specs.Spec{},
+			expectedSpec:  &specs.Spec{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			container, err := image.New(
+				image.WithEnvMap(tc.envmap),
+			)
+			require.NoError(t, err)
+
+			m, err := NewCleanupModifier(logger, cfg, container, discover.NewHookCreator(discover.WithDisabledHooks(tc.disabledHooks...)))
+			require.NoError(t, err)
+
+			require.NoError(t, Merge(m).Modify(tc.spec))
+			require.EqualValues(t, tc.expectedSpec, tc.spec)
+
+			// Applying the modifier again does not add a second hook.
+			require.NoError(t, Merge(m).Modify(tc.spec))
+			require.EqualValues(t, tc.expectedSpec, tc.spec)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"fmt"
+
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/pkg/parser"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/gpustate"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+// NewComputeModeModifier creates a modifier that sets the compute mode
+// requested through the NVIDIA_COMPUTE_MODE envvar for the GPUs of the
+// container. The compute mode is set by a createRuntime hook and the original
+// compute mode is restored by the cleanup hook (see NewCleanupModifier).
+//
+// Since the compute mode of a GPU affects all its users, the modification is
+// only made if the allow-compute-mode-hook feature is enabled and the
+// container is privileged.
+func NewComputeModeModifier(logger logger.Interface, cfg *config.Config, container image.CUDA, hookCreator discover.HookCreator) (oci.SpecModifier, error) {
+	mode := container.Getenv(image.EnvVarNvidiaComputeMode)
+	if mode == "" {
+		return nil, nil
+	}
+	if !cfg.Features.AllowComputeModeHook.IsEnabled() {
+		logger.Warningf("Ignoring %v=%v; the allow-compute-mode-hook feature is not enabled", image.EnvVarNvidiaComputeMode, mode)
+		return nil, nil
+	}
+	if !container.IsPrivileged() {
+		logger.Warningf("Ignoring %v=%v in unprivileged container", image.EnvVarNvidiaComputeMode, mode)
+		return nil, nil
+	}
+	if _, err := gpustate.ParseComputeMode(mode); err != nil {
+		return nil, fmt.Errorf("unsupported compute mode: %w", err)
+	}
+
+	devices := container.VisibleDevices()
+	if len(devices) == 0 {
+		return nil, nil
+	}
+
+	commonArgs := auditLogArgs(cfg)
+	setArgs := append([]string{"--mode", mode}, commonArgs...)
+	setArgs = append(setArgs, deviceArgs(devices)...)
+	set := hookCreator.Create(discover.ComputeModeHook, setArgs...)
+	// The changes are reverted by the cleanup hook when the container exits.
+	// We therefore only make them if this hook is also available.
+	if set == nil || hookCreator.Create(discover.CleanupHook) == nil {
+		return nil, nil
+	}
+	set.Lifecycle = cdi.CreateRuntimeHook
+
+	return NewModifierFromDiscoverer(logger, set)
+}
+
+// auditLogArgs returns the arguments that configure a hook to record changes
+// in the audit log of the NVIDIA Container Runtime.
+func auditLogArgs(cfg *config.Config) []string {
+	if auditLog := cfg.NVIDIAContainerRuntimeConfig.AuditLogFilePath; auditLog != "" {
+		return []string{"--audit-log", auditLog}
+	}
+	return nil
+}
+
+// deviceArgs returns the arguments that select the specified devices for a
+// hook. Fully-qualified CDI device names are reduced to the device name.
+func deviceArgs(devices []string) []string {
+	var args []string
+	for _, device := range devices {
+		if parser.IsQualifiedName(device) {
+			_, _, device, _ = parser.ParseQualifiedName(device)
+		}
+		args = append(args, "--device", device)
+	}
+	return args
+}
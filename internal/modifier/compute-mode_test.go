@@ -0,0 +1,129 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+)
+
+func TestComputeModeModifier(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	configFile := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(configFile, []byte("[features]\nallow-compute-mode-hook = true\n"), 0600))
+	toml, err := config.New(config.WithConfigFile(configFile))
+	require.NoError(t, err)
+	enabled, err := toml.Config()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		description   string
+		cfg           *config.Config
+		envmap        map[string]string
+		privileged    bool
+		disabledHooks []discover.HookName
+		expectedError bool
+		expectedHooks *specs.Hooks
+	}{
+		{
+			description: "feature not enabled makes no modification",
+			cfg:         &config.Config{},
+			envmap: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES": "0",
+				"NVIDIA_COMPUTE_MODE":    "EXCLUSIVE_PROCESS",
+			},
+			privileged: true,
+		},
+		{
+			description: "unprivileged container makes no modification",
+			cfg:         enabled,
+			envmap: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES": "0",
+				"NVIDIA_COMPUTE_MODE":    "EXCLUSIVE_PROCESS",
+			},
+		},
+		{
+			description: "invalid mode returns error",
+			cfg:         enabled,
+			envmap: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES": "0",
+				"NVIDIA_COMPUTE_MODE":    "EXCLUSIVE_THREAD",
+			},
+			privileged:    true,
+			expectedError: true,
+		},
+		{
+			description: "disabled cleanup hook makes no modification",
+			cfg:         enabled,
+			envmap: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES": "0",
+				"NVIDIA_COMPUTE_MODE":    "EXCLUSIVE_PROCESS",
+			},
+			privileged:    true,
+			disabledHooks: []discover.HookName{discover.CleanupHook},
+		},
+		{
+			description: "set hook is added",
+			cfg:         enabled,
+			envmap: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES": "0,GPU-1",
+				"NVIDIA_COMPUTE_MODE":    "EXCLUSIVE_PROCESS",
+			},
+			privileged: true,
+			expectedHooks: &specs.Hooks{
+				CreateRuntime: []specs.Hook{
+					{
+						Path: "/usr/bin/nvidia-cdi-hook",
+						Args: []string{"nvidia-cdi-hook", "compute-mode", "--mode", "EXCLUSIVE_PROCESS", "--device", "0", "--device", "GPU-1"},
+						Env:  []string{"NVIDIA_CTK_DEBUG=false"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			container, err := image.New(
+				image.WithEnvMap(tc.envmap),
+				image.WithPrivileged(tc.privileged),
+			)
+			require.NoError(t, err)
+
+			m, err := NewComputeModeModifier(logger, tc.cfg, container, discover.NewHookCreator(discover.WithDisabledHooks(tc.disabledHooks...)))
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			spec := &specs.Spec{}
+			require.NoError(t, Merge(m).Modify(spec))
+			require.EqualValues(t, tc.expectedHooks, spec.Hooks)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+type containerValidationModifier struct {
+	hook *discover.Hook
+}
+
+var _ oci.SpecModifier = (*containerValidationModifier)(nil)
+
+// NewContainerValidationModifier creates a modifier that adds a hook to check
+// that the injected driver is usable in containers that request devices. The
+// libraries that are checked depend on the requested driver capabilities. The
+// hook is only added if the validate-container feature is enabled.
+func NewContainerValidationModifier(logger logger.Interface, cfg *config.Config, container image.CUDA, hookCreator discover.HookCreator) oci.SpecModifier {
+	if !cfg.Features.ValidateContainer.IsEnabled() {
+		return nil
+	}
+	if len(container.VisibleDevices()) == 0 {
+		return nil
+	}
+
+	var args []string
+	capabilities := image.DefaultDriverCapabilities
+	if container.HasEnvvar(image.EnvVarNvidiaDriverCapabilities) {
+		capabilities = container.GetDriverCapabilities()
+	}
+	if capabilities.Has(image.DriverCapabilityCompute) {
+		args = append(args, "--library=libcuda.so.1")
+	}
+	if capabilities.Has(image.DriverCapabilityUtility) {
+		args = append(args, "--library=libnvidia-ml.so.1")
+	}
+
+	hook := hookCreator.Create(discover.ValidateContainerHook, args...)
+	if hook == nil {
+		logger.Debugf("The %v hook is disabled", discover.ValidateContainerHook)
+		return nil
+	}
+	return &containerValidationModifier{hook: hook}
+}
+
+// Modify appends the validation hook to the createContainer hooks. Since the
+// hook checks the result of the other hooks (e.g. the update of the ldcache),
+// it is run last.
+func (m *containerValidationModifier) Modify(spec *specs.Spec) error {
+	if spec.Hooks == nil {
+		spec.Hooks = &specs.Hooks{}
+	}
+	spec.Hooks.CreateContainer = append(spec.Hooks.CreateContainer, specs.Hook{
+		Path: m.hook.Path,
+		Args: m.hook.Args,
+		Env:  m.hook.Env,
+	})
+	return nil
+}
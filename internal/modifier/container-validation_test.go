@@ -0,0 +1,123 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+)
+
+func TestContainerValidationModifier(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	configFile := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(configFile, []byte("[features]\nvalidate-container = true\n"), 0600))
+	toml, err := config.New(config.WithConfigFile(configFile))
+	require.NoError(t, err)
+	enabled, err := toml.Config()
+	require.NoError(t, err)
+
+	existingHook := specs.Hook{Path: "/usr/bin/nvidia-cdi-hook", Args: []string{"nvidia-cdi-hook", "update-ldcache"}}
+
+	testCases := []struct {
+		description   string
+		cfg           *config.Config
+		envmap        map[string]string
+		expectedHooks *specs.Hooks
+	}{
+		{
+			description: "feature not enabled makes no modification",
+			cfg:         &config.Config{},
+			envmap: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES": "0",
+			},
+			expectedHooks: &specs.Hooks{
+				CreateContainer: []specs.Hook{existingHook},
+			},
+		},
+		{
+			description: "no devices makes no modification",
+			cfg:         enabled,
+			expectedHooks: &specs.Hooks{
+				CreateContainer: []specs.Hook{existingHook},
+			},
+		},
+		{
+			description: "hook is added last for default capabilities",
+			cfg:         enabled,
+			envmap: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES": "0",
+			},
+			expectedHooks: &specs.Hooks{
+				CreateContainer: []specs.Hook{
+					existingHook,
+					{
+						Path: "/usr/bin/nvidia-cdi-hook",
+						Args: []string{"nvidia-cdi-hook", "validate-container", "--library=libcuda.so.1", "--library=libnvidia-ml.so.1"},
+						Env:  []string{"NVIDIA_CTK_DEBUG=false"},
+					},
+				},
+			},
+		},
+		{
+			description: "libraries depend on capabilities",
+			cfg:         enabled,
+			envmap: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES":     "0",
+				"NVIDIA_DRIVER_CAPABILITIES": "graphics",
+			},
+			expectedHooks: &specs.Hooks{
+				CreateContainer: []specs.Hook{
+					existingHook,
+					{
+						Path: "/usr/bin/nvidia-cdi-hook",
+						Args: []string{"nvidia-cdi-hook", "validate-container"},
+						Env:  []string{"NVIDIA_CTK_DEBUG=false"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			container, err := image.New(
+				image.WithEnvMap(tc.envmap),
+			)
+			require.NoError(t, err)
+
+			m := NewContainerValidationModifier(logger, tc.cfg, container, discover.NewHookCreator())
+
+			spec := &specs.Spec{
+				Hooks: &specs.Hooks{
+					CreateContainer: []specs.Hook{existingHook},
+				},
+			}
+			require.NoError(t, Merge(m).Modify(spec))
+			require.EqualValues(t, tc.expectedHooks, spec.Hooks)
+		})
+	}
+}
@@ -55,6 +55,8 @@ func NewCSVModifier(logger logger.Interface, cfg *config.Config, container image
 	cdilib, err := nvcdi.New(
 		nvcdi.WithLogger(logger),
 		nvcdi.WithDriverRoot(cfg.NVIDIAContainerCLIConfig.Root),
+		nvcdi.WithLdconfigPath(cfg.NVIDIAContainerCLIConfig.HostLDConfigPath()),
+		nvcdi.WithLibrarySearchPaths(cfg.NVIDIAContainerCLIConfig.LibrarySearchPaths),
 		nvcdi.WithNVIDIACDIHookPath(cfg.NVIDIACTKConfig.Path),
 		nvcdi.WithMode(nvcdi.ModeCSV),
 		nvcdi.WithCSVFiles(csvFiles),
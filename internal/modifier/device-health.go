@@ -0,0 +1,88 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"os"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/health"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+type deviceHealthModifier struct {
+	logger    logger.Interface
+	healthDir string
+}
+
+var _ oci.SpecModifier = (*deviceHealthModifier)(nil)
+
+// NewDeviceHealthModifier creates a modifier that mounts the device health
+// directory into containers that request devices if the expose-device-health
+// feature is enabled. The directory itself (and not the individual status
+// files) is mounted since the files are replaced when the status of a device
+// changes. If the directory does not exist (e.g. because the health watcher is
+// not running), nil is returned.
+func NewDeviceHealthModifier(logger logger.Interface, cfg *config.Config, container image.CUDA) oci.SpecModifier {
+	return newDeviceHealthModifier(logger, cfg, container, health.DefaultDir)
+}
+
+func newDeviceHealthModifier(logger logger.Interface, cfg *config.Config, container image.CUDA, healthDir string) oci.SpecModifier {
+	if !cfg.Features.ExposeDeviceHealth.IsEnabled() {
+		return nil
+	}
+	if len(container.VisibleDevices()) == 0 {
+		return nil
+	}
+	if info, err := os.Stat(healthDir); err != nil || !info.IsDir() {
+		logger.Warningf("Device health directory %v does not exist; is the health watcher running?", healthDir)
+		return nil
+	}
+	return &deviceHealthModifier{
+		logger:    logger,
+		healthDir: healthDir,
+	}
+}
+
+// Modify adds a read-only mount for the device health directory and sets the
+// envvar that points to it.
+func (m *deviceHealthModifier) Modify(spec *specs.Spec) error {
+	for _, mount := range spec.Mounts {
+		if mount.Destination == health.ContainerDir {
+			m.logger.Debugf("Skipping existing mount for %v", health.ContainerDir)
+			return nil
+		}
+	}
+	if spec.Process == nil {
+		spec.Process = &specs.Process{}
+	}
+
+	m.logger.Debugf("Mounting device health directory %v at %v", m.healthDir, health.ContainerDir)
+	spec.Mounts = append(spec.Mounts, specs.Mount{
+		Source:      m.healthDir,
+		Destination: health.ContainerDir,
+		Type:        "bind",
+		Options:     []string{"ro", "nosuid", "nodev", "noexec", "rbind", "rprivate"},
+	})
+	spec.Process.Env = append(spec.Process.Env, health.EnvVarHealthDir+"="+health.ContainerDir)
+	return nil
+}
@@ -0,0 +1,126 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+)
+
+func TestDeviceHealthModifier(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	configFile := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(configFile, []byte("[features]\nexpose-device-health = true\n"), 0600))
+	toml, err := config.New(config.WithConfigFile(configFile))
+	require.NoError(t, err)
+	enabled, err := toml.Config()
+	require.NoError(t, err)
+
+	healthDir := t.TempDir()
+
+	testCases := []struct {
+		description  string
+		cfg          *config.Config
+		healthDir    string
+		envmap       map[string]string
+		spec         *specs.Spec
+		expectedSpec *specs.Spec
+	}{
+		{
+			description:  "feature not enabled makes no modification",
+			cfg:          &config.Config{},
+			healthDir:    healthDir,
+			envmap:       map[string]string{"NVIDIA_VISIBLE_DEVICES": "0"},
+			spec:         &specs.Spec{},
+			expectedSpec: &specs.Spec{},
+		},
+		{
+			description:  "no devices makes no modification",
+			cfg:          enabled,
+			healthDir:    healthDir,
+			envmap:       map[string]string{"NVIDIA_VISIBLE_DEVICES": "void"},
+			spec:         &specs.Spec{},
+			expectedSpec: &specs.Spec{},
+		},
+		{
+			description:  "missing health directory makes no modification",
+			cfg:          enabled,
+			healthDir:    filepath.Join(healthDir, "missing"),
+			envmap:       map[string]string{"NVIDIA_VISIBLE_DEVICES": "0"},
+			spec:         &specs.Spec{},
+			expectedSpec: &specs.Spec{},
+		},
+		{
+			description: "health directory is mounted",
+			cfg:         enabled,
+			healthDir:   healthDir,
+			envmap:      map[string]string{"NVIDIA_VISIBLE_DEVICES": "0"},
+			spec:        &specs.Spec{},
+			expectedSpec: &specs.Spec{
+				Process: &specs.Process{
+					Env: []string{"NVIDIA_DEVICE_HEALTH_DIR=/run/nvidia/health"},
+				},
+				Mounts: []specs.Mount{
+					{
+						Source:      healthDir,
+						Destination: "/run/nvidia/health",
+						Type:        "bind",
+						Options:     []string{"ro", "nosuid", "nodev", "noexec", "rbind", "rprivate"},
+					},
+				},
+			},
+		},
+		{
+			description: "existing mount is not replaced",
+			cfg:         enabled,
+			healthDir:   healthDir,
+			envmap:      map[string]string{"NVIDIA_VISIBLE_DEVICES": "0"},
+			spec: &specs.Spec{
+				Mounts: []specs.Mount{
+					{Source: "/custom", Destination: "/run/nvidia/health"},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				Mounts: []specs.Mount{
+					{Source: "/custom", Destination: "/run/nvidia/health"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			container, err := image.New(
+				image.WithEnvMap(tc.envmap),
+			)
+			require.NoError(t, err)
+
+			m := newDeviceHealthModifier(logger, tc.cfg, container, tc.healthDir)
+			require.NoError(t, Merge(m).Modify(tc.spec))
+			require.EqualValues(t, tc.expectedSpec, tc.spec)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+// deviceMetadata is the content of the device metadata file that is created
+// in the container.
+type deviceMetadata struct {
+	Devices []deviceMetadataEntry `json:"devices"`
+}
+
+// A deviceMetadataEntry describes the mapping of a device in the container to
+// the device on the host. The index is the index of the device as reported by
+// NVML (e.g. nvidia-smi) in the container.
+type deviceMetadataEntry struct {
+	Index      string `json:"index"`
+	HostIndex  string `json:"hostIndex"`
+	UUID       string `json:"uuid"`
+	ParentUUID string `json:"parentUUID,omitempty"`
+}
+
+// NewDeviceMetadataModifier creates a modifier that injects a file describing
+// the mapping between the device indices in the container and the device
+// indices and UUIDs on the host. Since this requires the devices to be listed
+// using NVML, the file is only injected if the inject-device-metadata feature
+// is enabled. If the visible devices cannot be resolved, no modification is
+// made.
+func NewDeviceMetadataModifier(logger logger.Interface, cfg *config.Config, container image.CUDA, hookCreator discover.HookCreator) (oci.SpecModifier, error) {
+	if !cfg.Features.InjectDeviceMetadata.IsEnabled() {
+		return nil, nil
+	}
+	if len(container.VisibleDevices()) == 0 {
+		return nil, nil
+	}
+	details := container.VisibleDeviceDetails()
+	if len(details) == 0 {
+		logger.Debugf("Unable to resolve visible devices; skipping device metadata")
+		return nil, nil
+	}
+
+	contents, err := json.Marshal(getDeviceMetadata(details))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal device metadata: %w", err)
+	}
+
+	hook := hookCreator.Create(discover.CreateDeviceMetadataHook, "--metadata", string(contents))
+	if hook == nil {
+		return nil, nil
+	}
+	return NewModifierFromDiscoverer(logger, hook)
+}
+
+// getDeviceMetadata determines the indices of the specified devices in the
+// container. Since NVML enumerates GPUs in PCI bus order, the GPUs are
+// numbered in the order of their host indices. MIG devices are numbered in
+// the order of their host MIG indices under their parent GPU.
+func getDeviceMetadata(details []image.DeviceDetails) *deviceMetadata {
+	seen := make(map[string]bool)
+	var unique []image.DeviceDetails
+	for _, d := range details {
+		if seen[d.UUID] {
+			continue
+		}
+		seen[d.UUID] = true
+		unique = append(unique, d)
+	}
+
+	sort.SliceStable(unique, func(a, b int) bool {
+		gpuA, migA := splitHostIndex(unique[a].HostIndex)
+		gpuB, migB := splitHostIndex(unique[b].HostIndex)
+		if gpuA != gpuB {
+			return gpuA < gpuB
+		}
+		return migA < migB
+	})
+
+	gpuIndices := make(map[int]int)
+	migCounts := make(map[int]int)
+	metadata := &deviceMetadata{}
+	for _, d := range unique {
+		gpu, mig := splitHostIndex(d.HostIndex)
+		index, ok := gpuIndices[gpu]
+		if !ok {
+			index = len(gpuIndices)
+			gpuIndices[gpu] = index
+		}
+		entry := deviceMetadataEntry{
+			Index:      strconv.Itoa(index),
+			HostIndex:  d.HostIndex,
+			UUID:       d.UUID,
+			ParentUUID: d.ParentUUID,
+		}
+		if mig >= 0 {
+			entry.Index = fmt.Sprintf("%d:%d", index, migCounts[gpu])
+			migCounts[gpu]++
+		}
+		metadata.Devices = append(metadata.Devices, entry)
+	}
+	return metadata
+}
+
+// splitHostIndex returns the GPU and MIG index of the specified host index.
+// For full GPUs a MIG index of -1 is returned.
+func splitHostIndex(hostIndex string) (int, int) {
+	gpuIndex, migIndex, isMIG := strings.Cut(hostIndex, ":")
+	gpu, _ := strconv.Atoi(gpuIndex)
+	if !isMIG {
+		return gpu, -1
+	}
+	mig, _ := strconv.Atoi(migIndex)
+	return gpu, mig
+}
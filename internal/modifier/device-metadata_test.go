@@ -0,0 +1,70 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+)
+
+func TestGetDeviceMetadata(t *testing.T) {
+	testCases := []struct {
+		description      string
+		details          []image.DeviceDetails
+		expectedMetadata *deviceMetadata
+	}{
+		{
+			description: "GPUs are numbered in host index order",
+			details: []image.DeviceDetails{
+				{HostIndex: "3", UUID: "GPU-3"},
+				{HostIndex: "1", UUID: "GPU-1"},
+				{HostIndex: "3", UUID: "GPU-3"},
+			},
+			expectedMetadata: &deviceMetadata{
+				Devices: []deviceMetadataEntry{
+					{Index: "0", HostIndex: "1", UUID: "GPU-1"},
+					{Index: "1", HostIndex: "3", UUID: "GPU-3"},
+				},
+			},
+		},
+		{
+			description: "MIG devices are numbered under their parent",
+			details: []image.DeviceDetails{
+				{HostIndex: "2:4", UUID: "MIG-2-4", ParentUUID: "GPU-2"},
+				{HostIndex: "0", UUID: "GPU-0"},
+				{HostIndex: "2:1", UUID: "MIG-2-1", ParentUUID: "GPU-2"},
+			},
+			expectedMetadata: &deviceMetadata{
+				Devices: []deviceMetadataEntry{
+					{Index: "0", HostIndex: "0", UUID: "GPU-0"},
+					{Index: "1:0", HostIndex: "2:1", UUID: "MIG-2-1", ParentUUID: "GPU-2"},
+					{Index: "1:1", HostIndex: "2:4", UUID: "MIG-2-4", ParentUUID: "GPU-2"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.EqualValues(t, tc.expectedMetadata, getDeviceMetadata(tc.details))
+		})
+	}
+}
@@ -0,0 +1,103 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"strings"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info/drm"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+const (
+	nvidiaDeviceNodePrefix = "/dev/nvidia"
+	drmDeviceNodePrefix    = "/dev/dri/"
+)
+
+type deviceNodeOwnership struct {
+	logger    logger.Interface
+	ownership config.DeviceNodeOwnership
+	// isNVIDIADRMDevice checks whether a DRM device node belongs to an NVIDIA
+	// GPU. This is required since DRM device nodes for GPUs from other
+	// vendors may also be present in the spec.
+	isNVIDIADRMDevice func(string) bool
+}
+
+var _ oci.SpecModifier = (*deviceNodeOwnership)(nil)
+
+// NewDeviceNodeOwnershipModifier creates a modifier that applies the configured
+// device node ownership and file mode to the NVIDIA device nodes in the OCI
+// spec. This ensures that the policy is applied consistently regardless of
+// which mode injected the device nodes.
+// If no policy is configured, nil is returned.
+func NewDeviceNodeOwnershipModifier(logger logger.Interface, cfg *config.Config) (oci.SpecModifier, error) {
+	ownership, err := cfg.DeviceNodes.Resolve()
+	if err != nil {
+		return nil, err
+	}
+	if ownership.IsEmpty() {
+		return nil, nil
+	}
+	m := deviceNodeOwnership{
+		logger:            logger,
+		ownership:         ownership,
+		isNVIDIADRMDevice: drm.IsNVIDIADeviceNode,
+	}
+	return &m, nil
+}
+
+// Modify sets the owner, group, and file mode of NVIDIA device nodes.
+func (m deviceNodeOwnership) Modify(spec *specs.Spec) error {
+	if spec == nil || spec.Linux == nil {
+		return nil
+	}
+
+	for i, device := range spec.Linux.Devices {
+		if !m.isNVIDIADeviceNode(device.Path) {
+			continue
+		}
+		m.logger.Debugf("Applying device node policy to %v", device.Path)
+		if m.ownership.UID != nil {
+			uid := *m.ownership.UID
+			spec.Linux.Devices[i].UID = &uid
+		}
+		if m.ownership.GID != nil {
+			gid := *m.ownership.GID
+			spec.Linux.Devices[i].GID = &gid
+		}
+		if m.ownership.FileMode != nil {
+			fileMode := *m.ownership.FileMode
+			spec.Linux.Devices[i].FileMode = &fileMode
+		}
+	}
+	return nil
+}
+
+func (m deviceNodeOwnership) isNVIDIADeviceNode(path string) bool {
+	switch {
+	case strings.HasPrefix(path, nvidiaDeviceNodePrefix):
+		return true
+	case strings.HasPrefix(path, drmDeviceNodePrefix):
+		return m.isNVIDIADRMDevice(path)
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"os"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+)
+
+func TestDeviceNodeOwnershipModifier(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	gid := uint32(44)
+	mode := os.FileMode(0660)
+
+	testCases := []struct {
+		description   string
+		cfg           *config.Config
+		spec          *specs.Spec
+		expectedNil   bool
+		expectedError bool
+		expectedSpec  *specs.Spec
+	}{
+		{
+			description: "no policy returns nil modifier",
+			cfg:         &config.Config{},
+			expectedNil: true,
+		},
+		{
+			description: "invalid mode is an error",
+			cfg: func() *config.Config {
+				c := &config.Config{}
+				c.DeviceNodes.Mode = "rw"
+				return c
+			}(),
+			expectedError: true,
+		},
+		{
+			description: "only NVIDIA device nodes are modified",
+			cfg: func() *config.Config {
+				c := &config.Config{}
+				c.DeviceNodes.Group = "44"
+				c.DeviceNodes.Mode = "0660"
+				return c
+			}(),
+			spec: &specs.Spec{
+				Linux: &specs.Linux{
+					Devices: []specs.LinuxDevice{
+						{Path: "/dev/nvidia0"},
+						{Path: "/dev/dri/card0"},
+						{Path: "/dev/dri/card1"},
+						{Path: "/dev/fuse"},
+					},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				Linux: &specs.Linux{
+					Devices: []specs.LinuxDevice{
+						{Path: "/dev/nvidia0", GID: &gid, FileMode: &mode},
+						{Path: "/dev/dri/card0"},
+						{Path: "/dev/dri/card1", GID: &gid, FileMode: &mode},
+						{Path: "/dev/fuse"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			m, err := NewDeviceNodeOwnershipModifier(logger, tc.cfg)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expectedNil {
+				require.Nil(t, m)
+				return
+			}
+			// We only consider card1 to be an NVIDIA DRM device.
+			m.(*deviceNodeOwnership).isNVIDIADRMDevice = func(path string) bool {
+				return path == "/dev/dri/card1"
+			}
+			require.NoError(t, m.Modify(tc.spec))
+			require.EqualValues(t, tc.expectedSpec, tc.spec)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+const (
+	cudaDeviceOrderEnvVar    = "CUDA_DEVICE_ORDER"
+	cudaVisibleDevicesEnvVar = "CUDA_VISIBLE_DEVICES"
+)
+
+type deviceOrderModifier struct {
+	logger logger.Interface
+	env    []string
+}
+
+var _ oci.SpecModifier = (*deviceOrderModifier)(nil)
+
+// NewDeviceOrderModifier creates a modifier that controls the order in which
+// CUDA enumerates the GPUs in the container. The order is read from the
+// NVIDIA_DEVICE_ORDER envvar and defaults to the device-order config option.
+// If no order is specified, nil is returned.
+func NewDeviceOrderModifier(logger logger.Interface, cfg *config.Config, container image.CUDA) (oci.SpecModifier, error) {
+	order := container.Getenv(image.EnvVarNvidiaDeviceOrder)
+	if order == "" {
+		order = string(cfg.NVIDIAContainerRuntimeConfig.DeviceOrder)
+	}
+	if order == "" {
+		return nil, nil
+	}
+	if len(container.VisibleDevices()) == 0 {
+		return nil, nil
+	}
+
+	var env []string
+	switch order {
+	case string(config.DeviceOrderFastestFirst):
+		env = append(env, cudaDeviceOrderEnvVar+"=FASTEST_FIRST")
+	case string(config.DeviceOrderPCI):
+		env = append(env, cudaDeviceOrderEnvVar+"=PCI_BUS_ID")
+	case string(config.DeviceOrderHostIndex):
+		env = append(env, cudaDeviceOrderEnvVar+"=PCI_BUS_ID")
+		gpus := container.VisibleGPUs()
+		if len(gpus) == 0 {
+			logger.Warningf("Unable to determine the host indices of the requested devices; only setting %v", cudaDeviceOrderEnvVar)
+			break
+		}
+		sort.SliceStable(gpus, func(a, b int) bool {
+			indexA, _ := strconv.Atoi(gpus[a].Index)
+			indexB, _ := strconv.Atoi(gpus[b].Index)
+			return indexA < indexB
+		})
+		var uuids []string
+		for _, gpu := range gpus {
+			uuids = append(uuids, gpu.UUID)
+		}
+		env = append(env, cudaVisibleDevicesEnvVar+"="+strings.Join(uuids, ","))
+	default:
+		return nil, fmt.Errorf("invalid device order %q", order)
+	}
+
+	m := &deviceOrderModifier{
+		logger: logger,
+		env:    env,
+	}
+	return m, nil
+}
+
+// Modify adds the CUDA device ordering environment variables to the spec.
+// Variables that are already set in the spec are not overridden.
+func (m *deviceOrderModifier) Modify(spec *specs.Spec) error {
+	if spec.Process == nil {
+		spec.Process = &specs.Process{}
+	}
+
+	existing := make(map[string]bool)
+	for _, env := range spec.Process.Env {
+		key, _, _ := strings.Cut(env, "=")
+		existing[key] = true
+	}
+	for _, env := range m.env {
+		key, _, _ := strings.Cut(env, "=")
+		if existing[key] {
+			m.logger.Warningf("Not overriding %v set in the container", key)
+			continue
+		}
+		spec.Process.Env = append(spec.Process.Env, env)
+	}
+	return nil
+}
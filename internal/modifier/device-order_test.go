@@ -0,0 +1,128 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+)
+
+func TestDeviceOrderModifier(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	gpus := fakeGPULister{
+		{Index: "0", UUID: "GPU-0"},
+		{Index: "1", UUID: "GPU-1"},
+		{Index: "2", UUID: "GPU-2"},
+	}
+	pciOrder := &config.Config{}
+	pciOrder.NVIDIAContainerRuntimeConfig.DeviceOrder = config.DeviceOrderPCI
+
+	testCases := []struct {
+		description   string
+		cfg           *config.Config
+		env           map[string]string
+		existingEnv   []string
+		expectedError bool
+		expectedEnv   []string
+	}{
+		{
+			description: "no order makes no modification",
+			env: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES": "2,0",
+			},
+		},
+		{
+			description: "config sets pci order",
+			cfg:         pciOrder,
+			env: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES": "2,0",
+			},
+			expectedEnv: []string{"CUDA_DEVICE_ORDER=PCI_BUS_ID"},
+		},
+		{
+			description: "envvar overrides config",
+			cfg:         pciOrder,
+			env: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES": "2,0",
+				"NVIDIA_DEVICE_ORDER":    "fastest-first",
+			},
+			expectedEnv: []string{"CUDA_DEVICE_ORDER=FASTEST_FIRST"},
+		},
+		{
+			description: "host index order sets visible devices",
+			env: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES": "2,GPU-0",
+				"NVIDIA_DEVICE_ORDER":    "host-index",
+			},
+			expectedEnv: []string{"CUDA_DEVICE_ORDER=PCI_BUS_ID", "CUDA_VISIBLE_DEVICES=GPU-0,GPU-2"},
+		},
+		{
+			description: "existing envvars are not overridden",
+			env: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES": "all",
+				"NVIDIA_DEVICE_ORDER":    "host-index",
+			},
+			existingEnv: []string{"CUDA_VISIBLE_DEVICES=1"},
+			expectedEnv: []string{"CUDA_VISIBLE_DEVICES=1", "CUDA_DEVICE_ORDER=PCI_BUS_ID"},
+		},
+		{
+			description: "invalid order returns error",
+			env: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES": "all",
+				"NVIDIA_DEVICE_ORDER":    "random",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cfg := tc.cfg
+			if cfg == nil {
+				cfg = &config.Config{}
+			}
+
+			container, err := image.New(
+				image.WithEnvMap(tc.env),
+				image.WithGPULister(gpus),
+			)
+			require.NoError(t, err)
+
+			m, err := NewDeviceOrderModifier(logger, cfg, container)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			spec := &specs.Spec{
+				Process: &specs.Process{
+					Env: tc.existingEnv,
+				},
+			}
+			require.NoError(t, Merge(m).Modify(spec))
+			require.EqualValues(t, tc.expectedEnv, spec.Process.Env)
+		})
+	}
+}
@@ -0,0 +1,228 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+const (
+	ldLibraryPathEnvVar = "LD_LIBRARY_PATH"
+	ldPreloadEnvVar     = "LD_PRELOAD"
+)
+
+// driverLibraryPatterns match the user-space driver libraries that must match
+// the version of the kernel module on the host.
+var driverLibraryPatterns = []string{
+	"libcuda.so*",
+	"libnvidia-ml.so*",
+	"libnvidia-ptxjitcompiler.so*",
+	"libnvidia-nvvm.so*",
+}
+
+// systemLibraryDirs are the directories into which the driver libraries are
+// injected. Driver libraries that are included in the image in these
+// directories are replaced when the container is created, so entries for
+// these directories do not conflict.
+var systemLibraryDirs = map[string]bool{
+	"/lib":                           true,
+	"/lib64":                         true,
+	"/usr/lib":                       true,
+	"/usr/lib64":                     true,
+	"/lib/x86_64-linux-gnu":          true,
+	"/lib/aarch64-linux-gnu":         true,
+	"/lib/powerpc64le-linux-gnu":     true,
+	"/usr/lib/x86_64-linux-gnu":      true,
+	"/usr/lib/aarch64-linux-gnu":     true,
+	"/usr/lib/powerpc64le-linux-gnu": true,
+}
+
+type envSanitizationModifier struct {
+	logger    logger.Interface
+	strip     bool
+	bundleDir string
+}
+
+var _ oci.SpecModifier = (*envSanitizationModifier)(nil)
+
+// NewEnvSanitizationModifier creates a modifier that removes references to
+// driver libraries included in the container image from the library search
+// path envvars of a container that requests devices. This prevents, for
+// example, a stale LD_LIBRARY_PATH pointing at a driver that was baked into
+// the image from taking precedence over the injected driver libraries.
+// The bundle directory is used to resolve a relative container root. If the
+// env-sanitization config option is not set, nil is returned.
+func NewEnvSanitizationModifier(logger logger.Interface, cfg *config.Config, container image.CUDA, bundleDir string) (oci.SpecModifier, error) {
+	var strip bool
+	switch mode := cfg.NVIDIAContainerRuntimeConfig.EnvSanitization; mode {
+	case "", config.EnvSanitizationDisabled:
+		return nil, nil
+	case config.EnvSanitizationNormalize:
+	case config.EnvSanitizationStrip:
+		strip = true
+	default:
+		return nil, fmt.Errorf("invalid env sanitization mode %q", mode)
+	}
+	if len(container.VisibleDevices()) == 0 {
+		return nil, nil
+	}
+
+	m := &envSanitizationModifier{
+		logger:    logger,
+		strip:     strip,
+		bundleDir: bundleDir,
+	}
+	return m, nil
+}
+
+// Modify removes the conflicting entries from the LD_LIBRARY_PATH and
+// LD_PRELOAD envvars in the spec. An LD_LIBRARY_PATH entry conflicts if the
+// directory in the container root contains driver libraries and an
+// LD_PRELOAD entry conflicts if it refers to a driver library. In strip mode
+// an envvar with conflicting entries is removed entirely.
+func (m *envSanitizationModifier) Modify(spec *specs.Spec) error {
+	if spec.Process == nil {
+		return nil
+	}
+
+	containerRoot := m.getContainerRoot(spec)
+	injected := make(map[string]bool)
+	for _, mount := range spec.Mounts {
+		injected[filepath.Clean(mount.Destination)] = true
+	}
+
+	var env []string
+	for _, e := range spec.Process.Env {
+		key, value, _ := strings.Cut(e, "=")
+
+		var entries []string
+		var isConflicting func(string) bool
+		switch key {
+		case ldLibraryPathEnvVar:
+			if containerRoot == "" {
+				m.logger.Debugf("Unable to determine container root; not checking %v", key)
+				env = append(env, e)
+				continue
+			}
+			entries = strings.Split(value, ":")
+			isConflicting = func(dir string) bool {
+				return m.containsDriverLibraries(containerRoot, dir, injected)
+			}
+		case ldPreloadEnvVar:
+			entries = strings.FieldsFunc(value, func(r rune) bool { return r == ':' || r == ' ' })
+			isConflicting = func(path string) bool {
+				return isConflictingPreload(path, injected)
+			}
+		default:
+			env = append(env, e)
+			continue
+		}
+
+		var sanitized []string
+		for _, entry := range entries {
+			if isConflicting(entry) {
+				m.logger.Infof("Removing %v from %v since it conflicts with the injected driver libraries", entry, key)
+				continue
+			}
+			sanitized = append(sanitized, entry)
+		}
+		switch {
+		case len(sanitized) == len(entries):
+			env = append(env, e)
+		case m.strip || len(sanitized) == 0:
+			m.logger.Infof("Removing %v from the container environment", key)
+		default:
+			env = append(env, key+"="+strings.Join(sanitized, ":"))
+		}
+	}
+	spec.Process.Env = env
+
+	return nil
+}
+
+// getContainerRoot returns the path of the container root on the host.
+// If this cannot be determined, an empty string is returned.
+func (m *envSanitizationModifier) getContainerRoot(spec *specs.Spec) string {
+	if spec.Root == nil || spec.Root.Path == "" {
+		return ""
+	}
+	if filepath.IsAbs(spec.Root.Path) {
+		return spec.Root.Path
+	}
+	if m.bundleDir == "" {
+		return ""
+	}
+	return filepath.Join(m.bundleDir, spec.Root.Path)
+}
+
+// containsDriverLibraries checks whether the specified container directory
+// contains driver libraries that are not replaced by injected mounts.
+func (m *envSanitizationModifier) containsDriverLibraries(containerRoot string, dir string, injected map[string]bool) bool {
+	if !filepath.IsAbs(dir) {
+		return false
+	}
+	dir = filepath.Clean(dir)
+	if systemLibraryDirs[dir] {
+		return false
+	}
+	for _, pattern := range driverLibraryPatterns {
+		matches, _ := filepath.Glob(filepath.Join(containerRoot, dir, pattern))
+		for _, match := range matches {
+			if injected[filepath.Join(dir, filepath.Base(match))] {
+				continue
+			}
+			m.logger.Debugf("Found driver library %v in container", filepath.Join(dir, filepath.Base(match)))
+			return true
+		}
+	}
+	return false
+}
+
+// isConflictingPreload checks whether the specified path refers to
+// a driver library in the container that is not replaced by the injected
+// driver libraries. Library names without a path are resolved by the dynamic
+// linker and do not conflict.
+func isConflictingPreload(path string, injected map[string]bool) bool {
+	if !filepath.IsAbs(path) {
+		return false
+	}
+	path = filepath.Clean(path)
+	if injected[path] || systemLibraryDirs[filepath.Dir(path)] {
+		return false
+	}
+	return isDriverLibrary(path)
+}
+
+// isDriverLibrary checks whether the specified path refers to a driver library.
+func isDriverLibrary(path string) bool {
+	for _, pattern := range driverLibraryPatterns {
+		if match, _ := filepath.Match(pattern, filepath.Base(path)); match {
+			return true
+		}
+	}
+	return false
+}
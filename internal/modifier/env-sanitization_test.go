@@ -0,0 +1,172 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+)
+
+func TestEnvSanitizationModifier(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	bundleDir := t.TempDir()
+	rootfs := filepath.Join(bundleDir, "rootfs")
+	for _, file := range []string{
+		"/opt/driver/lib/libcuda.so.1",
+		"/opt/driver/lib/libfoo.so",
+		"/usr/lib/x86_64-linux-gnu/libcuda.so.1",
+		"/opt/app/lib/libapp.so",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(rootfs, filepath.Dir(file)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(rootfs, file), nil, 0600))
+	}
+
+	normalize := &config.Config{}
+	normalize.NVIDIAContainerRuntimeConfig.EnvSanitization = config.EnvSanitizationNormalize
+	strip := &config.Config{}
+	strip.NVIDIAContainerRuntimeConfig.EnvSanitization = config.EnvSanitizationStrip
+	invalid := &config.Config{}
+	invalid.NVIDIAContainerRuntimeConfig.EnvSanitization = "random"
+
+	testCases := []struct {
+		description      string
+		cfg              *config.Config
+		env              map[string]string
+		existingEnv      []string
+		mounts           []specs.Mount
+		expectedError    bool
+		expectedModifier bool
+		expectedEnv      []string
+	}{
+		{
+			description: "disabled makes no modification",
+			env: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES": "all",
+			},
+		},
+		{
+			description: "no devices makes no modification",
+			cfg:         normalize,
+		},
+		{
+			description: "invalid mode returns error",
+			cfg:         invalid,
+			env: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES": "all",
+			},
+			expectedError: true,
+		},
+		{
+			description: "normalize removes conflicting entries",
+			cfg:         normalize,
+			env: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES": "all",
+			},
+			existingEnv: []string{
+				"FOO=bar",
+				"LD_LIBRARY_PATH=/opt/app/lib:/opt/driver/lib:/usr/lib/x86_64-linux-gnu:/does/not/exist",
+				"LD_PRELOAD=/opt/driver/lib/libcuda.so.1 /opt/app/lib/libapp.so",
+			},
+			expectedModifier: true,
+			expectedEnv: []string{
+				"FOO=bar",
+				"LD_LIBRARY_PATH=/opt/app/lib:/usr/lib/x86_64-linux-gnu:/does/not/exist",
+				"LD_PRELOAD=/opt/app/lib/libapp.so",
+			},
+		},
+		{
+			description: "strip removes conflicting envvars",
+			cfg:         strip,
+			env: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES": "all",
+			},
+			existingEnv: []string{
+				"FOO=bar",
+				"LD_LIBRARY_PATH=/opt/app/lib:/opt/driver/lib",
+				"LD_PRELOAD=/opt/app/lib/libapp.so",
+			},
+			expectedModifier: true,
+			expectedEnv: []string{
+				"FOO=bar",
+				"LD_PRELOAD=/opt/app/lib/libapp.so",
+			},
+		},
+		{
+			description: "injected libraries do not conflict",
+			cfg:         normalize,
+			env: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES": "all",
+			},
+			existingEnv: []string{
+				"LD_LIBRARY_PATH=/opt/driver/lib",
+				"LD_PRELOAD=/opt/driver/lib/libcuda.so.1",
+			},
+			mounts: []specs.Mount{
+				{Destination: "/opt/driver/lib/libcuda.so.1", Source: "/usr/lib/x86_64-linux-gnu/libcuda.so.1"},
+			},
+			expectedModifier: true,
+			expectedEnv: []string{
+				"LD_LIBRARY_PATH=/opt/driver/lib",
+				"LD_PRELOAD=/opt/driver/lib/libcuda.so.1",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cfg := tc.cfg
+			if cfg == nil {
+				cfg = &config.Config{}
+			}
+
+			container, err := image.New(
+				image.WithEnvMap(tc.env),
+			)
+			require.NoError(t, err)
+
+			m, err := NewEnvSanitizationModifier(logger, cfg, container, bundleDir)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedModifier, m != nil)
+
+			spec := &specs.Spec{
+				Root: &specs.Root{
+					Path: "rootfs",
+				},
+				Process: &specs.Process{
+					Env: tc.existingEnv,
+				},
+				Mounts: tc.mounts,
+			}
+			require.NoError(t, Merge(m).Modify(spec))
+			require.EqualValues(t, tc.expectedEnv, spec.Process.Env)
+		})
+	}
+}
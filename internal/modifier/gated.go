@@ -34,6 +34,7 @@ import (
 //	NVIDIA_MOFED=enabled
 //	NVIDIA_NVSWITCH=enabled
 //	NVIDIA_GDRCOPY=enabled
+//	NVIDIA_GPUDIRECT_RDMA=enabled
 //
 // If not devices are selected, no changes are made.
 func NewFeatureGatedModifier(logger logger.Interface, cfg *config.Config, image image.CUDA, driver *root.Driver, hookCreator discover.HookCreator) (oci.SpecModifier, error) {
@@ -79,6 +80,14 @@ func NewFeatureGatedModifier(logger logger.Interface, cfg *config.Config, image
 		discoverers = append(discoverers, d)
 	}
 
+	if image.Getenv("NVIDIA_GPUDIRECT_RDMA") == "enabled" {
+		d, err := discover.NewGPUDirectRDMADiscoverer(logger, devRoot)
+		if err != nil {
+			return nil, fmt.Errorf("failed to construct discoverer for GPUDirect RDMA devices: %w", err)
+		}
+		discoverers = append(discoverers, d)
+	}
+
 	// If the feature flag has explicitly been toggled, we don't make any modification.
 	if !cfg.Features.DisableCUDACompatLibHook.IsEnabled() {
 		cudaCompatDiscoverer, err := getCudaCompatModeDiscoverer(logger, cfg, driver, hookCreator)
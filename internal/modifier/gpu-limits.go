@@ -0,0 +1,86 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"fmt"
+
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/gpustate"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+// NewGPULimitsModifier creates a modifier that applies the power limit and
+// GPU clocks requested through the NVIDIA_GPU_POWER_LIMIT and
+// NVIDIA_GPU_CLOCKS envvars (or the corresponding annotations) to the GPUs of
+// the container. The limits are applied by a createRuntime hook and reverted
+// by the cleanup hook (see NewCleanupModifier).
+//
+// As for the compute mode, the modification is only made if the
+// allow-gpu-limits-hook feature is enabled and the container is privileged.
+func NewGPULimitsModifier(logger logger.Interface, cfg *config.Config, container image.CUDA, hookCreator discover.HookCreator) (oci.SpecModifier, error) {
+	powerLimit := container.GPUPowerLimit()
+	clocks := container.GPUClocks()
+	if powerLimit == "" && clocks == "" {
+		return nil, nil
+	}
+	if !cfg.Features.AllowGPULimitsHook.IsEnabled() {
+		logger.Warningf("Ignoring requested GPU limits; the allow-gpu-limits-hook feature is not enabled")
+		return nil, nil
+	}
+	if !container.IsPrivileged() {
+		logger.Warningf("Ignoring requested GPU limits in unprivileged container")
+		return nil, nil
+	}
+
+	devices := container.VisibleDevices()
+	if len(devices) == 0 {
+		return nil, nil
+	}
+
+	commonArgs := auditLogArgs(cfg)
+	setArgs := append([]string{}, commonArgs...)
+	if powerLimit != "" {
+		if _, err := gpustate.ParsePowerLimit(powerLimit); err != nil {
+			return nil, fmt.Errorf("unsupported GPU limits: %w", err)
+		}
+		setArgs = append(setArgs, "--power-limit", powerLimit)
+	}
+	if clocks != "" {
+		if _, _, err := gpustate.ParseClocks(clocks); err != nil {
+			return nil, fmt.Errorf("unsupported GPU limits: %w", err)
+		}
+		setArgs = append(setArgs, "--clocks", clocks)
+	}
+	setArgs = append(setArgs, deviceArgs(devices)...)
+
+	set := hookCreator.Create(discover.GPULimitsHook, setArgs...)
+	// The changes are reverted by the cleanup hook when the container exits.
+	// We therefore only make them if this hook is also available.
+	if set == nil || hookCreator.Create(discover.CleanupHook) == nil {
+		return nil, nil
+	}
+	set.Lifecycle = cdi.CreateRuntimeHook
+
+	return NewModifierFromDiscoverer(logger, set)
+}
@@ -0,0 +1,112 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+)
+
+func TestGPULimitsModifier(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	configFile := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(configFile, []byte("[features]\nallow-gpu-limits-hook = true\n"), 0600))
+	toml, err := config.New(config.WithConfigFile(configFile))
+	require.NoError(t, err)
+	enabled, err := toml.Config()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		description   string
+		cfg           *config.Config
+		envmap        map[string]string
+		annotations   map[string]string
+		expectedError bool
+		expectedHooks *specs.Hooks
+	}{
+		{
+			description: "feature not enabled makes no modification",
+			cfg:         &config.Config{},
+			envmap: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES": "0",
+				"NVIDIA_GPU_POWER_LIMIT": "200",
+			},
+		},
+		{
+			description: "invalid clocks returns error",
+			cfg:         enabled,
+			envmap: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES": "0",
+				"NVIDIA_GPU_CLOCKS":      "1400-1000",
+			},
+			expectedError: true,
+		},
+		{
+			description: "annotations take precedence over envvars",
+			cfg:         enabled,
+			envmap: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES": "0",
+				"NVIDIA_GPU_POWER_LIMIT": "200",
+			},
+			annotations: map[string]string{
+				"nvidia.com/gpu-power-limit": "250",
+				"nvidia.com/gpu-clocks":      "1000-1400",
+			},
+			expectedHooks: &specs.Hooks{
+				CreateRuntime: []specs.Hook{
+					{
+						Path: "/usr/bin/nvidia-cdi-hook",
+						Args: []string{"nvidia-cdi-hook", "gpu-limits", "--power-limit", "250", "--clocks", "1000-1400", "--device", "0"},
+						Env:  []string{"NVIDIA_CTK_DEBUG=false"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			container, err := image.New(
+				image.WithEnvMap(tc.envmap),
+				image.WithAnnotations(tc.annotations),
+				image.WithPrivileged(true),
+			)
+			require.NoError(t, err)
+
+			m, err := NewGPULimitsModifier(logger, tc.cfg, container, discover.NewHookCreator())
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			spec := &specs.Spec{}
+			require.NoError(t, Merge(m).Modify(spec))
+			require.EqualValues(t, tc.expectedHooks, spec.Hooks)
+		})
+	}
+}
@@ -0,0 +1,139 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/gpustate"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+const (
+	mpsPinnedDeviceMemLimitEnvVar   = "CUDA_MPS_PINNED_DEVICE_MEM_LIMIT"
+	mpsActiveThreadPercentageEnvVar = "CUDA_MPS_ACTIVE_THREAD_PERCENTAGE"
+)
+
+type mpsLimitsModifier struct {
+	logger logger.Interface
+	env    []string
+}
+
+var _ oci.SpecModifier = (*mpsLimitsModifier)(nil)
+
+// NewGPUMemoryModifier creates a modifier that applies the GPU memory and
+// thread percentage requested through the NVIDIA_GPU_MEMORY and
+// NVIDIA_GPU_THREAD_PERCENTAGE envvars (or the corresponding annotations) to
+// the GPUs of the container.
+//
+// The limits are pinned for MPS clients in the container by setting the
+// corresponding CUDA_MPS_* envvars. If GPU memory is requested, a
+// createRuntime hook is also added that refuses to start the container if
+// the selected MIG devices are too small or if the selected GPUs do not have
+// the requested amount of free memory.
+//
+// The modification is only made if the allow-gpu-memory-hook feature is
+// enabled.
+func NewGPUMemoryModifier(logger logger.Interface, cfg *config.Config, container image.CUDA, hookCreator discover.HookCreator) (oci.SpecModifier, error) {
+	memory := container.GPUMemory()
+	threadPercentage := container.GPUThreadPercentage()
+	if memory == "" && threadPercentage == "" {
+		return nil, nil
+	}
+	if !cfg.Features.AllowGPUMemoryHook.IsEnabled() {
+		logger.Warningf("Ignoring requested GPU memory; the allow-gpu-memory-hook feature is not enabled")
+		return nil, nil
+	}
+
+	devices := container.VisibleDevices()
+	if len(devices) == 0 {
+		return nil, nil
+	}
+
+	var modifiers List
+	mpsLimits := &mpsLimitsModifier{logger: logger}
+	if memory != "" {
+		mib, err := gpustate.ParseMemory(memory)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported GPU memory request: %w", err)
+		}
+
+		args := append([]string{"--memory", strconv.FormatUint(mib, 10)}, deviceArgs(devices)...)
+		check := hookCreator.Create(discover.GPUMemoryHook, args...)
+		if check != nil {
+			check.Lifecycle = cdi.CreateRuntimeHook
+			hookModifier, err := NewModifierFromDiscoverer(logger, check)
+			if err != nil {
+				return nil, err
+			}
+			modifiers = append(modifiers, hookModifier)
+		}
+
+		if limit := getPinnedDeviceMemLimit(devices, mib); limit != "" {
+			mpsLimits.env = append(mpsLimits.env, mpsPinnedDeviceMemLimitEnvVar+"="+limit)
+		} else {
+			logger.Warningf("Not pinning the MPS memory limit for a request of all GPUs; only the available memory is checked")
+		}
+	}
+	if threadPercentage != "" {
+		percentage, err := gpustate.ParseThreadPercentage(threadPercentage)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported GPU memory request: %w", err)
+		}
+		mpsLimits.env = append(mpsLimits.env, fmt.Sprintf("%s=%d", mpsActiveThreadPercentageEnvVar, percentage))
+	}
+	if len(mpsLimits.env) > 0 {
+		modifiers = append(modifiers, mpsLimits)
+	}
+
+	return modifiers, nil
+}
+
+// getPinnedDeviceMemLimit returns the value of the MPS memory limit for the
+// specified devices. Since the limit is specified per device ordinal in the
+// container, an empty string is returned if the number of devices is not
+// known.
+func getPinnedDeviceMemLimit(devices []string, mib uint64) string {
+	var limits []string
+	for i, device := range devices {
+		if device == "all" {
+			return ""
+		}
+		limits = append(limits, fmt.Sprintf("%d=%dM", i, mib))
+	}
+	return strings.Join(limits, ",")
+}
+
+// Modify adds the MPS envvars to the spec.
+func (m *mpsLimitsModifier) Modify(spec *specs.Spec) error {
+	if spec.Process == nil {
+		spec.Process = &specs.Process{}
+	}
+	m.logger.Debugf("Adding MPS limits %v", m.env)
+	spec.Process.Env = append(spec.Process.Env, m.env...)
+	return nil
+}
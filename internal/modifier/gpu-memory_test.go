@@ -0,0 +1,139 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+)
+
+func TestGPUMemoryModifier(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	configFile := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(configFile, []byte("[features]\nallow-gpu-memory-hook = true\n"), 0600))
+	toml, err := config.New(config.WithConfigFile(configFile))
+	require.NoError(t, err)
+	enabled, err := toml.Config()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		description   string
+		cfg           *config.Config
+		envmap        map[string]string
+		annotations   map[string]string
+		expectedError bool
+		expectedSpec  *specs.Spec
+	}{
+		{
+			description: "feature not enabled makes no modification",
+			cfg:         &config.Config{},
+			envmap: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES": "0",
+				"NVIDIA_GPU_MEMORY":      "8G",
+			},
+			expectedSpec: &specs.Spec{},
+		},
+		{
+			description: "invalid thread percentage returns error",
+			cfg:         enabled,
+			envmap: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES":       "0",
+				"NVIDIA_GPU_THREAD_PERCENTAGE": "200",
+			},
+			expectedError: true,
+		},
+		{
+			description: "memory request adds hook and MPS limits",
+			cfg:         enabled,
+			envmap: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES":       "0,MIG-1",
+				"NVIDIA_GPU_THREAD_PERCENTAGE": "50",
+			},
+			annotations: map[string]string{
+				"nvidia.com/gpu-memory": "8G",
+			},
+			expectedSpec: &specs.Spec{
+				Process: &specs.Process{
+					Env: []string{
+						"CUDA_MPS_PINNED_DEVICE_MEM_LIMIT=0=8192M,1=8192M",
+						"CUDA_MPS_ACTIVE_THREAD_PERCENTAGE=50",
+					},
+				},
+				Hooks: &specs.Hooks{
+					CreateRuntime: []specs.Hook{
+						{
+							Path: "/usr/bin/nvidia-cdi-hook",
+							Args: []string{"nvidia-cdi-hook", "gpu-memory", "--memory", "8192", "--device", "0", "--device", "MIG-1"},
+							Env:  []string{"NVIDIA_CTK_DEBUG=false"},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "memory limit is not pinned for all devices",
+			cfg:         enabled,
+			envmap: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES": "all",
+				"NVIDIA_GPU_MEMORY":      "1024",
+			},
+			expectedSpec: &specs.Spec{
+				Hooks: &specs.Hooks{
+					CreateRuntime: []specs.Hook{
+						{
+							Path: "/usr/bin/nvidia-cdi-hook",
+							Args: []string{"nvidia-cdi-hook", "gpu-memory", "--memory", "1024", "--device", "all"},
+							Env:  []string{"NVIDIA_CTK_DEBUG=false"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			container, err := image.New(
+				image.WithEnvMap(tc.envmap),
+				image.WithAnnotations(tc.annotations),
+				image.WithPrivileged(true),
+			)
+			require.NoError(t, err)
+
+			m, err := NewGPUMemoryModifier(logger, tc.cfg, container, discover.NewHookCreator())
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			spec := &specs.Spec{}
+			require.NoError(t, Merge(m).Modify(spec))
+			require.EqualValues(t, tc.expectedSpec, spec)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+const (
+	defaultMPSPipeDirectory = "/tmp/nvidia-mps"
+
+	cudaMPSPipeDirectoryEnvVar          = "CUDA_MPS_PIPE_DIRECTORY"
+	cudaMPSActiveThreadPercentageEnvVar = "CUDA_MPS_ACTIVE_THREAD_PERCENTAGE"
+)
+
+type mpsModifier struct {
+	logger        logger.Interface
+	pipeDirectory string
+	env           []string
+}
+
+var _ oci.SpecModifier = (*mpsModifier)(nil)
+
+// NewGPUSharingModifier creates a modifier for containers that are marked as
+// using shared GPUs. Such containers are not considered for exclusive device
+// allocation. If the MPS sharing strategy is requested, the pipe directory of
+// the MPS control daemon is mounted into the container and the CUDA MPS
+// environment variables are set. For other strategies, nil is returned.
+func NewGPUSharingModifier(logger logger.Interface, cfg *config.Config, container image.CUDA) (oci.SpecModifier, error) {
+	strategy, err := container.GPUSharingStrategy()
+	if err != nil {
+		return nil, err
+	}
+	if strategy == "" {
+		return nil, nil
+	}
+	if len(container.VisibleDevices()) == 0 {
+		return nil, nil
+	}
+	if exclusive, _ := strconv.ParseBool(container.Getenv(image.EnvVarNvidiaExclusiveDevices)); exclusive {
+		logger.Warningf("Ignoring exclusive device request for container using GPU sharing strategy %v", strategy)
+	}
+	if strategy != image.GPUSharingStrategyMPS {
+		return nil, nil
+	}
+
+	pipeDirectory := cfg.NVIDIAContainerRuntimeConfig.MPSPipeDirectory
+	if pipeDirectory == "" {
+		pipeDirectory = defaultMPSPipeDirectory
+	}
+	if _, err := os.Stat(pipeDirectory); err != nil {
+		logger.Warningf("Not configuring MPS; failed to stat MPS pipe directory %v: %v", pipeDirectory, err)
+		return nil, nil
+	}
+
+	env := []string{cudaMPSPipeDirectoryEnvVar + "=" + pipeDirectory}
+	if percentage := container.MPSActiveThreadPercentage(); percentage != "" {
+		value, err := strconv.Atoi(percentage)
+		if err != nil || value < 1 || value > 100 {
+			return nil, fmt.Errorf("invalid MPS active thread percentage %q", percentage)
+		}
+		env = append(env, cudaMPSActiveThreadPercentageEnvVar+"="+percentage)
+	}
+
+	m := &mpsModifier{
+		logger:        logger,
+		pipeDirectory: pipeDirectory,
+		env:           env,
+	}
+	return m, nil
+}
+
+// Modify mounts the MPS pipe directory into the container and sets the CUDA
+// MPS environment variables. Mounts and variables that already exist in the
+// spec are not overridden.
+func (m *mpsModifier) Modify(spec *specs.Spec) error {
+	if spec.Process == nil {
+		spec.Process = &specs.Process{}
+	}
+
+	existing := make(map[string]bool)
+	for _, env := range spec.Process.Env {
+		key, _, _ := strings.Cut(env, "=")
+		existing[key] = true
+	}
+	for _, env := range m.env {
+		key, _, _ := strings.Cut(env, "=")
+		if existing[key] {
+			m.logger.Warningf("Not overriding %v set in the container", key)
+			continue
+		}
+		spec.Process.Env = append(spec.Process.Env, env)
+	}
+
+	for _, mount := range spec.Mounts {
+		if mount.Destination == m.pipeDirectory {
+			m.logger.Debugf("MPS pipe directory %v is already mounted", m.pipeDirectory)
+			return nil
+		}
+	}
+	spec.Mounts = append(spec.Mounts, specs.Mount{
+		Source:      m.pipeDirectory,
+		Destination: m.pipeDirectory,
+		Type:        "bind",
+		Options:     []string{"nosuid", "nodev", "noexec", "bind"},
+	})
+	return nil
+}
@@ -0,0 +1,136 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+)
+
+func TestGPUSharingModifier(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	pipeDirectory := t.TempDir()
+
+	cfg := &config.Config{}
+	cfg.NVIDIAContainerRuntimeConfig.MPSPipeDirectory = pipeDirectory
+
+	testCases := []struct {
+		description    string
+		annotations    map[string]string
+		existingMounts []specs.Mount
+		expectedError  bool
+		expectedNil    bool
+		expectedEnv    []string
+		expectedMounts []specs.Mount
+	}{
+		{
+			description: "no sharing strategy makes no modification",
+			expectedNil: true,
+		},
+		{
+			description: "time-slicing makes no modification",
+			annotations: map[string]string{
+				"nvidia.com/gpu-sharing-strategy": "time-slicing",
+			},
+			expectedNil: true,
+		},
+		{
+			description: "invalid sharing strategy returns error",
+			annotations: map[string]string{
+				"nvidia.com/gpu-sharing-strategy": "invalid",
+			},
+			expectedError: true,
+		},
+		{
+			description: "mps mounts pipe directory",
+			annotations: map[string]string{
+				"nvidia.com/gpu-sharing-strategy":         "mps",
+				"nvidia.com/mps-active-thread-percentage": "50",
+			},
+			expectedEnv: []string{
+				"CUDA_MPS_PIPE_DIRECTORY=" + pipeDirectory,
+				"CUDA_MPS_ACTIVE_THREAD_PERCENTAGE=50",
+			},
+			expectedMounts: []specs.Mount{
+				{
+					Source:      pipeDirectory,
+					Destination: pipeDirectory,
+					Type:        "bind",
+					Options:     []string{"nosuid", "nodev", "noexec", "bind"},
+				},
+			},
+		},
+		{
+			description: "existing pipe directory mount is not duplicated",
+			annotations: map[string]string{
+				"nvidia.com/gpu-sharing-strategy": "mps",
+			},
+			existingMounts: []specs.Mount{
+				{Source: pipeDirectory, Destination: pipeDirectory},
+			},
+			expectedEnv: []string{
+				"CUDA_MPS_PIPE_DIRECTORY=" + pipeDirectory,
+			},
+			expectedMounts: []specs.Mount{
+				{Source: pipeDirectory, Destination: pipeDirectory},
+			},
+		},
+		{
+			description: "invalid active thread percentage returns error",
+			annotations: map[string]string{
+				"nvidia.com/gpu-sharing-strategy":         "mps",
+				"nvidia.com/mps-active-thread-percentage": "150",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			container, err := image.New(
+				image.WithEnvMap(map[string]string{"NVIDIA_VISIBLE_DEVICES": "all"}),
+				image.WithAnnotations(tc.annotations),
+			)
+			require.NoError(t, err)
+
+			m, err := NewGPUSharingModifier(logger, cfg, container)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expectedNil {
+				require.Nil(t, m)
+				return
+			}
+
+			spec := &specs.Spec{
+				Mounts: tc.existingMounts,
+			}
+			require.NoError(t, m.Modify(spec))
+			require.EqualValues(t, tc.expectedEnv, spec.Process.Env)
+			require.EqualValues(t, tc.expectedMounts, spec.Mounts)
+		})
+	}
+}
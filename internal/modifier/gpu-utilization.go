@@ -0,0 +1,82 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/audit"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+const (
+	// gpuUtilizationAnnotation is the container annotation that holds the
+	// snapshot of the utilization of the GPUs of the container.
+	gpuUtilizationAnnotation = "nvidia.com/gpu-utilization-snapshot"
+)
+
+type gpuUtilizationModifier struct {
+	logger    logger.Interface
+	snapshots []audit.GPUSnapshot
+}
+
+var _ oci.SpecModifier = (*gpuUtilizationModifier)(nil)
+
+// NewGPUUtilizationModifier creates a modifier that records a snapshot of the
+// utilization and used memory of the GPUs of the container as the
+// nvidia.com/gpu-utilization-snapshot annotation if the
+// record-gpu-utilization feature is enabled. Since the snapshot is only
+// informational, a failure to query the GPUs is logged and no modification is
+// made.
+func NewGPUUtilizationModifier(logger logger.Interface, cfg *config.Config, container image.CUDA, gpuLister image.GPULister) oci.SpecModifier {
+	if !cfg.Features.RecordGPUUtilization.IsEnabled() {
+		return nil
+	}
+	snapshots, err := audit.GetGPUSnapshots(gpuLister, container.VisibleDevices()...)
+	if err != nil {
+		logger.Warningf("Failed to record GPU utilization: %v", err)
+		return nil
+	}
+	if len(snapshots) == 0 {
+		return nil
+	}
+	return &gpuUtilizationModifier{
+		logger:    logger,
+		snapshots: snapshots,
+	}
+}
+
+// Modify adds the snapshot annotation to the spec.
+func (m *gpuUtilizationModifier) Modify(spec *specs.Spec) error {
+	data, err := json.Marshal(m.snapshots)
+	if err != nil {
+		return fmt.Errorf("failed to marshal GPU utilization snapshot: %w", err)
+	}
+	if spec.Annotations == nil {
+		spec.Annotations = make(map[string]string)
+	}
+	m.logger.Debugf("Adding GPU utilization snapshot %s", data)
+	spec.Annotations[gpuUtilizationAnnotation] = string(data)
+	return nil
+}
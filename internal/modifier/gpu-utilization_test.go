@@ -0,0 +1,90 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+)
+
+func TestGPUUtilizationModifier(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	gpus := fakeGPULister{
+		{Index: "0", UUID: "GPU-0", Utilization: 40, MemoryUsed: 2048},
+		{Index: "1", UUID: "GPU-1", Utilization: 0, MemoryUsed: 0},
+	}
+	configFile := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(configFile, []byte("[features]\nrecord-gpu-utilization = true\n"), 0600))
+	toml, err := config.New(config.WithConfigFile(configFile))
+	require.NoError(t, err)
+	enabled, err := toml.Config()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		description         string
+		cfg                 *config.Config
+		envmap              map[string]string
+		expectedAnnotations map[string]string
+	}{
+		{
+			description: "feature not enabled makes no modification",
+			cfg:         &config.Config{},
+			envmap: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES": "all",
+			},
+		},
+		{
+			description: "no devices makes no modification",
+			cfg:         enabled,
+		},
+		{
+			description: "snapshot of requested GPUs is added",
+			cfg:         enabled,
+			envmap: map[string]string{
+				"NVIDIA_VISIBLE_DEVICES": "0",
+			},
+			expectedAnnotations: map[string]string{
+				"nvidia.com/gpu-utilization-snapshot": `[{"index":"0","uuid":"GPU-0","utilization":40,"memoryUsed":2048}]`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			container, err := image.New(
+				image.WithEnvMap(tc.envmap),
+				image.WithPrivileged(true),
+			)
+			require.NoError(t, err)
+
+			m := NewGPUUtilizationModifier(logger, tc.cfg, container, gpus)
+
+			spec := &specs.Spec{}
+			require.NoError(t, Merge(m).Modify(spec))
+			require.EqualValues(t, tc.expectedAnnotations, spec.Annotations)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"strings"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+type pinnedDevices struct {
+	logger  logger.Interface
+	envVars []string
+	devices string
+}
+
+var _ oci.SpecModifier = (*pinnedDevices)(nil)
+
+// NewPinnedDevicesModifier creates a modifier that replaces the device request
+// in the specified environment variables with the specified resolved devices.
+// This ensures that a request that depends on the state of the system (e.g.
+// count:N) is resolved only once and that the same devices are used by all
+// components that process the container, including the NVIDIA Container
+// Runtime Hook in legacy mode. Since its contents are included in the resolved
+// devices, NVIDIA_VISIBLE_DEVICES_FILE is removed.
+func NewPinnedDevicesModifier(logger logger.Interface, envVars []string, devices []string) oci.SpecModifier {
+	pinned := strings.Join(devices, ",")
+	if pinned == "" {
+		pinned = "void"
+	}
+	return &pinnedDevices{
+		logger:  logger,
+		envVars: envVars,
+		devices: pinned,
+	}
+}
+
+// Modify sets the environment variables of the device request to the resolved
+// devices.
+func (m *pinnedDevices) Modify(spec *specs.Spec) error {
+	if spec.Process == nil {
+		spec.Process = &specs.Process{}
+	}
+
+	pinned := make(map[string]bool)
+	for _, envVar := range m.envVars {
+		pinned[envVar] = true
+	}
+
+	m.logger.Debugf("Setting %v to %v", m.envVars, m.devices)
+	var env []string
+	for _, e := range spec.Process.Env {
+		key, _, _ := strings.Cut(e, "=")
+		if pinned[key] || key == image.EnvVarNvidiaVisibleDevicesFile {
+			continue
+		}
+		env = append(env, e)
+	}
+	for _, envVar := range m.envVars {
+		env = append(env, envVar+"="+m.devices)
+	}
+	spec.Process.Env = env
+	return nil
+}
@@ -0,0 +1,77 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPinnedDevicesModifier(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description string
+		envVars     []string
+		devices     []string
+		env         []string
+		expectedEnv []string
+	}{
+		{
+			description: "request is replaced",
+			envVars:     []string{"NVIDIA_VISIBLE_DEVICES"},
+			devices:     []string{"1", "0"},
+			env:         []string{"NVIDIA_VISIBLE_DEVICES=count:2", "PATH=/usr/bin"},
+			expectedEnv: []string{"PATH=/usr/bin", "NVIDIA_VISIBLE_DEVICES=1,0"},
+		},
+		{
+			description: "devices file is removed",
+			envVars:     []string{"NVIDIA_VISIBLE_DEVICES"},
+			devices:     []string{"0"},
+			env:         []string{"NVIDIA_VISIBLE_DEVICES=count:1", "NVIDIA_VISIBLE_DEVICES_FILE=/run/devices"},
+			expectedEnv: []string{"NVIDIA_VISIBLE_DEVICES=0"},
+		},
+		{
+			description: "all request envvars are replaced",
+			envVars:     []string{"DOCKER_RESOURCE_GPUS", "NVIDIA_VISIBLE_DEVICES"},
+			devices:     []string{"0"},
+			env:         []string{"DOCKER_RESOURCE_GPUS=count:1", "NVIDIA_VISIBLE_DEVICES=all"},
+			expectedEnv: []string{"DOCKER_RESOURCE_GPUS=0", "NVIDIA_VISIBLE_DEVICES=0"},
+		},
+		{
+			description: "no devices sets void",
+			envVars:     []string{"NVIDIA_VISIBLE_DEVICES"},
+			env:         []string{"NVIDIA_VISIBLE_DEVICES=all,-0"},
+			expectedEnv: []string{"NVIDIA_VISIBLE_DEVICES=void"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			spec := &specs.Spec{
+				Process: &specs.Process{Env: tc.env},
+			}
+			m := NewPinnedDevicesModifier(logger, tc.envVars, tc.devices)
+			require.NoError(t, m.Modify(spec))
+			require.EqualValues(t, tc.expectedEnv, spec.Process.Env)
+		})
+	}
+}
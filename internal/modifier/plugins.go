@@ -0,0 +1,80 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"fmt"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/edits"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/plugin"
+)
+
+type pluginsModifier struct {
+	logger     logger.Interface
+	plugins    []string
+	driverRoot string
+	devices    []string
+}
+
+var _ oci.SpecModifier = (*pluginsModifier)(nil)
+
+// NewPluginsModifier creates a modifier that invokes the configured plugins
+// and applies the container edits that they return. The plugins are only
+// invoked for containers that request devices. If no plugins are configured,
+// nil is returned.
+func NewPluginsModifier(logger logger.Interface, cfg *config.Config, container image.CUDA, driver *root.Driver) oci.SpecModifier {
+	if len(cfg.NVIDIAContainerRuntimeConfig.Plugins) == 0 {
+		return nil
+	}
+	devices := container.VisibleDevices()
+	if len(devices) == 0 {
+		return nil
+	}
+	return &pluginsModifier{
+		logger:     logger,
+		plugins:    cfg.NVIDIAContainerRuntimeConfig.Plugins,
+		driverRoot: driver.Root,
+		devices:    devices,
+	}
+}
+
+// Modify invokes the plugins with the current spec and applies the returned
+// edits.
+func (m *pluginsModifier) Modify(spec *specs.Spec) error {
+	request := &plugin.Request{
+		Pipeline:   plugin.PipelineRuntime,
+		DriverRoot: m.driverRoot,
+		Devices:    m.devices,
+		Spec:       spec,
+	}
+	d := discover.NewPluginDiscoverers(m.logger, m.plugins, request)
+
+	specEdits, err := edits.NewSpecEdits(m.logger, d)
+	if err != nil {
+		return fmt.Errorf("failed to get edits from plugins: %w", err)
+	}
+	return specEdits.Modify(spec)
+}
@@ -0,0 +1,97 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+)
+
+func TestPluginsModifier(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	driver := root.New(root.WithDriverRoot("/driver-root"))
+
+	testCases := []struct {
+		description    string
+		script         string
+		visibleDevices string
+		expectedError  bool
+		expectedSpec   *specs.Spec
+	}{
+		{
+			description:    "no devices does not invoke plugin",
+			script:         `exit 1`,
+			visibleDevices: "void",
+			expectedSpec:   &specs.Spec{},
+		},
+		{
+			description:    "plugin edits are applied",
+			script:         `grep -q '"devices":\["0"\]' && echo '{"apiVersion": "v1", "containerEdits": {"env": ["SIDECAR=enabled"]}}'`,
+			visibleDevices: "0",
+			expectedSpec: &specs.Spec{
+				Process: &specs.Process{
+					Env: []string{"SIDECAR=enabled"},
+				},
+			},
+		},
+		{
+			description:    "plugin failure returns error",
+			script:         `exit 1`,
+			visibleDevices: "0",
+			expectedError:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "plugin")
+			require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+tc.script+"\n"), 0755))
+
+			cfg := &config.Config{
+				NVIDIAContainerRuntimeConfig: config.RuntimeConfig{
+					Plugins: []string{path},
+				},
+			}
+			container, err := image.New(
+				image.WithLogger(logger),
+				image.WithEnvMap(map[string]string{
+					image.EnvVarNvidiaVisibleDevices: tc.visibleDevices,
+				}),
+			)
+			require.NoError(t, err)
+
+			spec := &specs.Spec{}
+			err = Merge(NewPluginsModifier(logger, cfg, container, driver)).Modify(spec)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedSpec, spec)
+		})
+	}
+}
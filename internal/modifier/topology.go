@@ -0,0 +1,73 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+type topologyModifier struct {
+	logger   logger.Interface
+	topology *image.GPUTopology
+}
+
+var _ oci.SpecModifier = (*topologyModifier)(nil)
+
+// NewTopologyModifier creates a modifier that exposes the topology of the GPUs
+// selected for a count-based device request to the container. The closest
+// shared interconnect is set in NVIDIA_GPU_TOPOLOGY and the NUMA nodes of the
+// GPUs are set in NVIDIA_GPU_NUMA_NODES. If the container does not request a
+// number of devices, nil is returned.
+func NewTopologyModifier(logger logger.Interface, container image.CUDA) oci.SpecModifier {
+	topology := container.SelectedGPUTopology()
+	if topology == nil {
+		return nil
+	}
+	return &topologyModifier{
+		logger:   logger,
+		topology: topology,
+	}
+}
+
+// Modify adds the topology environment variables to the spec.
+func (m *topologyModifier) Modify(spec *specs.Spec) error {
+	if spec.Process == nil {
+		spec.Process = &specs.Process{}
+	}
+
+	env := []string{
+		image.EnvVarNvidiaGPUTopology + "=" + string(m.topology.Domain),
+	}
+	if len(m.topology.NUMANodes) > 0 {
+		var nodes []string
+		for _, node := range m.topology.NUMANodes {
+			nodes = append(nodes, strconv.Itoa(node))
+		}
+		env = append(env, image.EnvVarNvidiaGPUNUMANodes+"="+strings.Join(nodes, ","))
+	}
+	m.logger.Debugf("Adding GPU topology environment variables %v", env)
+	spec.Process.Env = append(spec.Process.Env, env...)
+	return nil
+}
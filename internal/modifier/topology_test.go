@@ -0,0 +1,84 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+)
+
+type fakeGPULister []image.GPU
+
+func (f fakeGPULister) GPUs() ([]image.GPU, error) {
+	return f, nil
+}
+
+func TestTopologyModifier(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	gpus := fakeGPULister{
+		{Index: "0", UUID: "GPU-0", NUMANode: 0, NVLinkPeers: []string{"1"}},
+		{Index: "1", UUID: "GPU-1", NUMANode: 0, NVLinkPeers: []string{"0"}},
+	}
+
+	testCases := []struct {
+		description    string
+		visibleDevices string
+		expectedSpec   *specs.Spec
+	}{
+		{
+			description:    "explicit devices do not modify spec",
+			visibleDevices: "0,1",
+			expectedSpec:   &specs.Spec{},
+		},
+		{
+			description:    "count request adds topology",
+			visibleDevices: "count:2",
+			expectedSpec: &specs.Spec{
+				Process: &specs.Process{
+					Env: []string{
+						"NVIDIA_GPU_TOPOLOGY=nvlink",
+						"NVIDIA_GPU_NUMA_NODES=0",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			container, err := image.New(
+				image.WithLogger(logger),
+				image.WithEnvMap(map[string]string{
+					image.EnvVarNvidiaVisibleDevices: tc.visibleDevices,
+				}),
+				image.WithGPULister(gpus),
+			)
+			require.NoError(t, err)
+
+			spec := &specs.Spec{}
+			err = Merge(NewTopologyModifier(logger, container)).Modify(spec)
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedSpec, spec)
+		})
+	}
+}
@@ -44,34 +44,56 @@ func GetBundleDir(args []string) (string, error) {
 // -b{{SEP}}BUNDLE_PATH
 // where {{SEP}} is either ' ' or '='
 func GetBundleDirFromArgs(args []string) (string, error) {
-	var bundleDir string
+	bundleDir, err := getFlagValue(args, IsBundleFlag)
+	if err != nil {
+		return "", fmt.Errorf("bundle option requires an argument")
+	}
+	return bundleDir, nil
+}
+
+// GetPIDFileFromArgs checks the specified slice of strings (argv) for a
+// 'pid-file' flag as allowed by runc. As is the case for the bundle flag, the
+// value may be separated from the flag by either ' ' or '='. An empty string
+// is returned if no PID file is specified.
+func GetPIDFileFromArgs(args []string) (string, error) {
+	pidFile, err := getFlagValue(args, isPIDFileFlag)
+	if err != nil {
+		return "", fmt.Errorf("pid-file option requires an argument")
+	}
+	return pidFile, nil
+}
+
+// getFlagValue returns the value of the last flag in args that matches the
+// specified function.
+func getFlagValue(args []string, isFlag func(string) bool) (string, error) {
+	var value string
 
 	for i := 0; i < len(args); i++ {
 		param := args[i]
 
 		parts := strings.SplitN(param, "=", 2)
-		if !IsBundleFlag(parts[0]) {
+		if !isFlag(parts[0]) {
 			continue
 		}
 
-		// The flag has the format --bundle=/path
+		// The flag has the format --flag=value
 		if len(parts) == 2 {
-			bundleDir = parts[1]
+			value = parts[1]
 			continue
 		}
 
-		// The flag has the format --bundle /path
+		// The flag has the format --flag value
 		if i+1 < len(args) {
-			bundleDir = args[i+1]
+			value = args[i+1]
 			i++
 			continue
 		}
 
-		// --bundle / -b was the last element of args
-		return "", fmt.Errorf("bundle option requires an argument")
+		// The flag was the last element of args
+		return "", fmt.Errorf("missing argument")
 	}
 
-	return bundleDir, nil
+	return value, nil
 }
 
 // GetSpecFilePath returns the expected path to the OCI specification file for the given
@@ -92,6 +114,10 @@ func IsBundleFlag(arg string) bool {
 	return trimmed == "b" || trimmed == "bundle"
 }
 
+func isPIDFileFlag(arg string) bool {
+	return arg == "--pid-file" || arg == "-pid-file"
+}
+
 // HasCreateSubcommand checks the supplied arguments for a 'create' subcommand
 func HasCreateSubcommand(args []string) bool {
 	var previousWasBundle bool
@@ -126,6 +126,47 @@ func TestGetBundleDir(t *testing.T) {
 	}
 }
 
+func TestGetPIDFileFromArgs(t *testing.T) {
+	testCases := []struct {
+		description     string
+		argv            []string
+		expectedPIDFile string
+		expectedError   bool
+	}{
+		{
+			description: "no pid file",
+			argv:        []string{"create", "--bundle", "/foo/bar", "id"},
+		},
+		{
+			description:     "separate value",
+			argv:            []string{"create", "--bundle", "/foo/bar", "--pid-file", "/foo/bar/init.pid", "id"},
+			expectedPIDFile: "/foo/bar/init.pid",
+		},
+		{
+			description:     "joined value",
+			argv:            []string{"create", "-pid-file=/foo/bar/init.pid", "id"},
+			expectedPIDFile: "/foo/bar/init.pid",
+		},
+		{
+			description:   "missing value",
+			argv:          []string{"create", "--pid-file"},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			pidFile, err := GetPIDFileFromArgs(tc.argv)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedPIDFile, pidFile)
+		})
+	}
+}
+
 func TestGetSpecFilePathAppendsFilename(t *testing.T) {
 	testCases := []struct {
 		bundleDir string
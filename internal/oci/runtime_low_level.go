@@ -21,6 +21,7 @@ import (
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/messages"
 )
 
 // NewLowLevelRuntime creates a Runtime that wraps a low-level runtime executable.
@@ -29,7 +30,7 @@ import (
 func NewLowLevelRuntime(logger logger.Interface, candidates []string) (Runtime, error) {
 	runtimePath, err := findRuntime(logger, candidates)
 	if err != nil {
-		return nil, fmt.Errorf("error locating runtime: %v", err)
+		return nil, fmt.Errorf("error locating runtime: %w", err)
 	}
 	return NewRuntimeForPath(logger, runtimePath)
 }
@@ -51,5 +52,5 @@ func findRuntime(logger logger.Interface, candidates []string) (string, error) {
 		}
 	}
 
-	return "", fmt.Errorf("no runtime binary found from candidate list: %v", candidates)
+	return "", messages.New(messages.LowLevelRuntimeNotFound, messages.Data{"Candidates": candidates})
 }
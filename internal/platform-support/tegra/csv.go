@@ -28,13 +28,20 @@ import (
 // newDiscovererFromCSVFiles creates a discoverer for the specified CSV files. A logger is also supplied.
 // The constructed discoverer is comprised of a list, with each element in the list being associated with a
 // single CSV files.
+// If no mount specs are found in the CSV files, these are generated from the installed BSP instead.
 func (o tegraOptions) newDiscovererFromCSVFiles() (discover.Discover, error) {
 	if len(o.csvFiles) == 0 {
 		o.logger.Warningf("No CSV files specified")
-		return discover.None{}, nil
 	}
 
 	targetsByType := getTargetsFromCSVFiles(o.logger, o.csvFiles)
+	if len(targetsByType) == 0 {
+		o.logger.Infof("No mount specs found in CSV files; using installed BSP")
+		targetsByType = getTargetsFromBSP(o.logger, o.driverRoot)
+	}
+	if len(targetsByType) == 0 {
+		return discover.None{}, nil
+	}
 
 	devices := discover.NewCharDeviceDiscoverer(
 		o.logger,
@@ -100,6 +107,38 @@ var getTargetsFromCSVFiles = func(logger logger.Interface, files []string) map[c
 			targetsByType[t.Type] = append(targetsByType[t.Type], t.Path)
 		}
 	}
+	if len(targetsByType) == 0 {
+		return nil
+	}
+	// The device nodes required to access the iGPU are always included since
+	// these are not consistently listed in the CSV files across BSP releases.
+	// Duplicate device nodes are removed when these are discovered.
+	targetsByType[csv.MountSpecDev] = append(targetsByType[csv.MountSpecDev], csv.BSPDeviceNodes...)
+	return targetsByType
+}
+
+// getTargetsFromBSP returns the list of mount specs generated from the BSP
+// installed at the specified root. These are aggregated by mount spec type.
+// TODO: We use a function variable here to allow this to be overridden for testing.
+var getTargetsFromBSP = func(logger logger.Interface, root string) map[csv.MountSpecType][]string {
+	targets, err := csv.NewBSPParser(logger, root).Parse()
+	if err != nil {
+		logger.Warningf("Failed to generate mount specs from BSP: %v", err)
+		return nil
+	}
+	// If no libraries are found, we assume that the BSP is not installed.
+	var hasLibraries bool
+	targetsByType := make(map[csv.MountSpecType][]string)
+	for _, t := range targets {
+		if t.Type == csv.MountSpecLib {
+			hasLibraries = true
+		}
+		targetsByType[t.Type] = append(targetsByType[t.Type], t.Path)
+	}
+	if !hasLibraries {
+		logger.Warningf("No BSP libraries found in %v", csv.BSPLibraryDirs)
+		return nil
+	}
 	return targetsByType
 }
 
@@ -0,0 +1,109 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package csv
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+// BSPLibraryDirs defines the directories in which the libraries installed by
+// the Jetson Board Support Package (BSP) are located.
+// The nvidia folder is used from JetPack 6 (L4T r36) onwards, with the tegra
+// and tegra-egl folders used by earlier releases.
+var BSPLibraryDirs = []string{
+	"/usr/lib/aarch64-linux-gnu/nvidia",
+	"/usr/lib/aarch64-linux-gnu/tegra",
+	"/usr/lib/aarch64-linux-gnu/tegra-egl",
+}
+
+// BSPDeviceNodes defines the (glob) patterns for the device nodes required
+// to access the integrated GPU on Tegra-based systems.
+var BSPDeviceNodes = []string{
+	"/dev/nvmap",
+	"/dev/nvhost-*",
+	"/dev/nvgpu/igpu0/*",
+}
+
+type bsp struct {
+	logger logger.Interface
+	root   string
+}
+
+// NewBSPParser creates a parser that generates MountSpecs from the installed
+// Jetson BSP. This is used to generate mount specifications on systems where
+// the CSV files are not available.
+func NewBSPParser(logger logger.Interface, root string) Parser {
+	p := bsp{
+		logger: logger,
+		root:   root,
+	}
+	return &p
+}
+
+// Parse returns the list of MountSpecs for the installed BSP.
+// Device nodes are always included while libraries and symlinks are
+// determined from the contents of the BSP library directories.
+func (p bsp) Parse() ([]*MountSpec, error) {
+	var targets []*MountSpec
+	for _, device := range BSPDeviceNodes {
+		targets = append(targets, &MountSpec{Type: MountSpecDev, Path: device})
+	}
+
+	for _, dir := range BSPLibraryDirs {
+		specs, err := p.mountSpecsFromDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, specs...)
+	}
+
+	return targets, nil
+}
+
+// mountSpecsFromDir returns the lib and sym MountSpecs for the contents of the
+// specified directory. The directory is not traversed recursively.
+func (p bsp) mountSpecsFromDir(dir string) ([]*MountSpec, error) {
+	contents, err := os.ReadDir(filepath.Join(p.root, dir))
+	if errors.Is(err, os.ErrNotExist) {
+		p.logger.Debugf("Skipping missing BSP directory %v", dir)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the contents of %v: %w", dir, err)
+	}
+
+	var targets []*MountSpec
+	for _, c := range contents {
+		var mountType MountSpecType
+		switch {
+		case c.Type()&os.ModeSymlink != 0:
+			mountType = MountSpecSym
+		case c.Type().IsRegular():
+			mountType = MountSpecLib
+		default:
+			continue
+		}
+		targets = append(targets, &MountSpec{Type: mountType, Path: filepath.Join(dir, c.Name())})
+	}
+	return targets, nil
+}
@@ -0,0 +1,87 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package csv
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBSPParser(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	expectedDevices := []*MountSpec{
+		{Type: MountSpecDev, Path: "/dev/nvmap"},
+		{Type: MountSpecDev, Path: "/dev/nvhost-*"},
+		{Type: MountSpecDev, Path: "/dev/nvgpu/igpu0/*"},
+	}
+
+	testCases := []struct {
+		description string
+		files       map[string]string
+		dirs        []string
+		expected    []*MountSpec
+	}{
+		{
+			description: "no BSP returns device nodes only",
+			expected:    expectedDevices,
+		},
+		{
+			description: "libraries and symlinks are included",
+			files: map[string]string{
+				"/usr/lib/aarch64-linux-gnu/nvidia/libcuda.so.1.1": "",
+				"/usr/lib/aarch64-linux-gnu/nvidia/libcuda.so":     "libcuda.so.1.1",
+				"/usr/lib/aarch64-linux-gnu/tegra/libnvos.so":      "",
+			},
+			dirs: []string{
+				"/usr/lib/aarch64-linux-gnu/nvidia/subdir",
+			},
+			expected: append(expectedDevices,
+				&MountSpec{Type: MountSpecSym, Path: "/usr/lib/aarch64-linux-gnu/nvidia/libcuda.so"},
+				&MountSpec{Type: MountSpecLib, Path: "/usr/lib/aarch64-linux-gnu/nvidia/libcuda.so.1.1"},
+				&MountSpec{Type: MountSpecLib, Path: "/usr/lib/aarch64-linux-gnu/tegra/libnvos.so"},
+			),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			root := t.TempDir()
+			for _, dir := range tc.dirs {
+				require.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0755))
+			}
+			for file, target := range tc.files {
+				path := filepath.Join(root, file)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+				if target != "" {
+					require.NoError(t, os.Symlink(target, path))
+					continue
+				}
+				require.NoError(t, os.WriteFile(path, nil, 0644))
+			}
+
+			specs, err := NewBSPParser(logger, root).Parse()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expected, specs)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package csv
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// A Severity indicates how serious a lint issue is.
+type Severity string
+
+const (
+	// SeverityError indicates an issue that prevents an entry from being
+	// applied as intended.
+	SeverityError = Severity("error")
+	// SeverityWarning indicates an issue that may be expected on some
+	// systems (e.g. a file that is not installed in a specific BSP release).
+	SeverityWarning = Severity("warning")
+)
+
+// An Issue describes a problem with an entry in a CSV file.
+type Issue struct {
+	File     string
+	Line     int
+	Severity Severity
+	Message  string
+}
+
+// String returns the issue in the form FILE:LINE: SEVERITY: MESSAGE.
+func (i Issue) String() string {
+	return fmt.Sprintf("%s:%d: %s: %s", i.File, i.Line, i.Severity, i.Message)
+}
+
+// A Linter checks CSV files for malformed entries and entries that do not
+// match the files on the system.
+type Linter struct {
+	// root is the root at which the paths in the CSV files are checked.
+	root string
+	// seen maps each entry to the location at which it was first defined.
+	seen map[MountSpec]string
+}
+
+// NewLinter creates a linter that checks paths relative to the specified root.
+func NewLinter(root string) *Linter {
+	return &Linter{
+		root: root,
+		seen: make(map[MountSpec]string),
+	}
+}
+
+// LintFile checks the specified CSV file. Entries that are duplicated across
+// files checked by the same linter are also reported.
+func (l *Linter) LintFile(filename string) ([]Issue, error) {
+	reader, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %v for reading: %w", filename, err)
+	}
+	defer reader.Close()
+
+	return l.lint(filename, reader)
+}
+
+func (l *Linter) lint(filename string, reader io.Reader) ([]Issue, error) {
+	var issues []Issue
+	addIssue := func(line int, severity Severity, format string, args ...any) {
+		issues = append(issues, Issue{
+			File:     filename,
+			Line:     line,
+			Severity: severity,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	scanner := bufio.NewScanner(reader)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		spec, err := NewMountSpecFromLine(line)
+		if err != nil {
+			addIssue(lineNumber, SeverityError, "%v; each entry must be of the form TYPE, PATH where TYPE is one of dev, dir, lib, or sym", err)
+			continue
+		}
+
+		location := fmt.Sprintf("%s:%d", filename, lineNumber)
+		if first, ok := l.seen[*spec]; ok {
+			addIssue(lineNumber, SeverityWarning, "duplicate entry %v, %v (first defined at %v)", spec.Type, spec.Path, first)
+			continue
+		}
+		l.seen[*spec] = location
+
+		if !filepath.IsAbs(spec.Path) && spec.Type != MountSpecDev {
+			addIssue(lineNumber, SeverityError, "path %v is not absolute", spec.Path)
+			continue
+		}
+
+		matches, err := filepath.Glob(filepath.Join(l.root, l.devPath(spec)))
+		if err != nil {
+			addIssue(lineNumber, SeverityError, "invalid path pattern %v: %v", spec.Path, err)
+			continue
+		}
+		if len(matches) == 0 {
+			addIssue(lineNumber, SeverityWarning, "path %v does not exist", spec.Path)
+			continue
+		}
+		for _, match := range matches {
+			if err := checkType(spec.Type, match); err != nil {
+				addIssue(lineNumber, SeverityError, "%v: %v", l.relative(match), err)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return issues, fmt.Errorf("failed to read %v: %w", filename, err)
+	}
+	return issues, nil
+}
+
+// devPath returns the path at which an entry is expected. Device nodes may be
+// specified relative to /dev.
+func (l *Linter) devPath(spec *MountSpec) string {
+	if spec.Type == MountSpecDev && !filepath.IsAbs(spec.Path) {
+		return filepath.Join("/dev", spec.Path)
+	}
+	return spec.Path
+}
+
+func (l *Linter) relative(path string) string {
+	rel, err := filepath.Rel(l.root, path)
+	if err != nil {
+		return path
+	}
+	return filepath.Join("/", rel)
+}
+
+// checkType checks whether the file at the specified path matches the
+// specified mount type.
+func checkType(mountType MountSpecType, path string) error {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return err
+	}
+	mode := info.Mode()
+	switch mountType {
+	case MountSpecDev:
+		// TODO: We should have a better way to inject this logic than this envvar.
+		if os.Getenv("__NVCT_TESTING_DEVICES_ARE_FILES") == "true" && mode.IsRegular() {
+			return nil
+		}
+		if mode&os.ModeCharDevice == 0 {
+			return fmt.Errorf("expected a character device for type dev")
+		}
+	case MountSpecDir:
+		if !mode.IsDir() {
+			return fmt.Errorf("expected a directory for type dir")
+		}
+	case MountSpecSym:
+		if mode&os.ModeSymlink == 0 {
+			return fmt.Errorf("expected a symlink for type sym")
+		}
+	case MountSpecLib:
+		// Libraries are located following symlinks.
+		resolved, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("failed to resolve: %w", err)
+		}
+		if !resolved.Mode().IsRegular() {
+			return fmt.Errorf("expected a regular file for type lib")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package csv
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinter(t *testing.T) {
+	t.Setenv("__NVCT_TESTING_DEVICES_ARE_FILES", "true")
+
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "dev"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "dev", "nvmap"), nil, 0600))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "usr", "lib", "nvidia"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "usr", "lib", "nvidia", "libcuda.so.1.1"), nil, 0600))
+	require.NoError(t, os.Symlink("libcuda.so.1.1", filepath.Join(root, "usr", "lib", "nvidia", "libcuda.so")))
+
+	testCases := []struct {
+		description    string
+		contents       string
+		expectedIssues []string
+	}{
+		{
+			description: "valid entries have no issues",
+			contents: strings.Join([]string{
+				"# comment",
+				"dev, /dev/nvmap",
+				"dev, nvmap",
+				"",
+				"dir, /usr/lib/nvidia",
+				"lib, /usr/lib/nvidia/libcuda.so.1.1",
+				"lib, /usr/lib/nvidia/libcuda.so",
+				"sym, /usr/lib/nvidia/libcuda.so",
+				"lib, /usr/lib/nvidia/libcuda.so.*",
+			}, "\n"),
+		},
+		{
+			description: "malformed entries are errors",
+			contents: strings.Join([]string{
+				"dir",
+				"file, /usr/lib/nvidia",
+				"lib, usr/lib/nvidia/libcuda.so.1.1",
+			}, "\n"),
+			expectedIssues: []string{
+				"test.csv:1: error: failed to parse line: dir; each entry must be of the form TYPE, PATH where TYPE is one of dev, dir, lib, or sym",
+				"test.csv:2: error: unexpected mount type: file; each entry must be of the form TYPE, PATH where TYPE is one of dev, dir, lib, or sym",
+				"test.csv:3: error: path usr/lib/nvidia/libcuda.so.1.1 is not absolute",
+			},
+		},
+		{
+			description: "type mismatches are errors",
+			contents: strings.Join([]string{
+				"lib, /usr/lib/nvidia",
+				"sym, /usr/lib/nvidia/libcuda.so.1.1",
+				"dir, /usr/lib/nvidia/libcuda.so.*",
+			}, "\n"),
+			expectedIssues: []string{
+				"test.csv:1: error: /usr/lib/nvidia: expected a regular file for type lib",
+				"test.csv:2: error: /usr/lib/nvidia/libcuda.so.1.1: expected a symlink for type sym",
+				"test.csv:3: error: /usr/lib/nvidia/libcuda.so.1.1: expected a directory for type dir",
+			},
+		},
+		{
+			description: "missing and duplicate entries are warnings",
+			contents: strings.Join([]string{
+				"lib, /usr/lib/nvidia/libmissing.so",
+				"dir, /usr/lib/nvidia",
+				"dir, /usr/lib/nvidia",
+			}, "\n"),
+			expectedIssues: []string{
+				"test.csv:1: warning: path /usr/lib/nvidia/libmissing.so does not exist",
+				"test.csv:3: warning: duplicate entry dir, /usr/lib/nvidia (first defined at test.csv:2)",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			issues, err := NewLinter(root).lint("test.csv", strings.NewReader(tc.contents))
+			require.NoError(t, err)
+
+			var messages []string
+			for _, issue := range issues {
+				messages = append(messages, issue.String())
+			}
+			require.EqualValues(t, tc.expectedIssues, messages)
+		})
+	}
+}
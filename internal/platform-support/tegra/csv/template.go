@@ -0,0 +1,152 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package csv
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+// MountSpecAuto is used in templates to infer the mount type of each match
+// from the file at the root.
+const MountSpecAuto = MountSpecType("auto")
+
+type template struct {
+	logger   logger.Interface
+	root     string
+	filename string
+}
+
+// NewTemplateParser creates a parser that generates MountSpecs from a
+// template. Each line in a template is of the form [TYPE,] PATTERN where
+// PATTERN is a glob pattern that is expanded at the specified root. If TYPE
+// is omitted or set to auto, the type of each match is inferred from the
+// file. Empty lines and lines starting with # are ignored.
+func NewTemplateParser(logger logger.Interface, root string, filename string) Parser {
+	p := template{
+		logger:   logger,
+		root:     root,
+		filename: filename,
+	}
+	return &p
+}
+
+// Parse expands the entries of the template.
+func (p template) Parse() ([]*MountSpec, error) {
+	reader, err := os.Open(p.filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %v for reading: %w", p.filename, err)
+	}
+	defer reader.Close()
+
+	return p.parseFromReader(reader)
+}
+
+func (p template) parseFromReader(reader io.Reader) ([]*MountSpec, error) {
+	var targets []*MountSpec
+	seen := make(map[MountSpec]bool)
+
+	scanner := bufio.NewScanner(reader)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		mountType, pattern := MountSpecAuto, line
+		if t, path, found := strings.Cut(line, ","); found {
+			mountType, pattern = MountSpecType(strings.TrimSpace(t)), strings.TrimSpace(path)
+		}
+		switch mountType {
+		case MountSpecAuto, MountSpecDev, MountSpecDir, MountSpecLib, MountSpecSym:
+		default:
+			return nil, fmt.Errorf("%v:%d: unexpected mount type: %v", p.filename, lineNumber, mountType)
+		}
+		if mountType == MountSpecDev && !filepath.IsAbs(pattern) {
+			pattern = filepath.Join("/dev", pattern)
+		}
+		if !filepath.IsAbs(pattern) {
+			return nil, fmt.Errorf("%v:%d: pattern %v is not absolute", p.filename, lineNumber, pattern)
+		}
+
+		matches, err := filepath.Glob(filepath.Join(p.root, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("%v:%d: invalid pattern %v: %w", p.filename, lineNumber, pattern, err)
+		}
+		if len(matches) == 0 {
+			p.logger.Warningf("No matches found for %v", pattern)
+			continue
+		}
+		for _, match := range matches {
+			path := filepath.Join("/", strings.TrimPrefix(match, p.root))
+			t := mountType
+			if t == MountSpecAuto {
+				t, err = inferMountType(match)
+				if err != nil {
+					p.logger.Warningf("Skipping %v: %v", path, err)
+					continue
+				}
+			}
+			spec := MountSpec{Type: t, Path: path}
+			if seen[spec] {
+				continue
+			}
+			seen[spec] = true
+			targets = append(targets, &spec)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %v: %w", p.filename, err)
+	}
+	return targets, nil
+}
+
+// inferMountType returns the mount type for the file at the specified path.
+func inferMountType(path string) (MountSpecType, error) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return "", err
+	}
+	mode := info.Mode()
+	switch {
+	case mode&os.ModeSymlink != 0:
+		return MountSpecSym, nil
+	case mode.IsDir():
+		return MountSpecDir, nil
+	case mode&os.ModeCharDevice != 0:
+		return MountSpecDev, nil
+	case mode.IsRegular():
+		return MountSpecLib, nil
+	}
+	return "", fmt.Errorf("unsupported file type %v", mode.Type())
+}
+
+// WriteMountSpecs writes the specified MountSpecs in CSV format.
+func WriteMountSpecs(w io.Writer, specs []*MountSpec) error {
+	for _, spec := range specs {
+		if _, err := fmt.Fprintf(w, "%s, %s\n", spec.Type, spec.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package csv
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateParser(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	root := t.TempDir()
+	libDir := filepath.Join(root, "usr", "lib", "aarch64-linux-gnu", "nvidia")
+	require.NoError(t, os.MkdirAll(libDir, 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "dev"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(libDir, "libcuda.so.1.1"), nil, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(libDir, "libnvrm_gpu.so"), nil, 0600))
+	require.NoError(t, os.Symlink("libcuda.so.1.1", filepath.Join(libDir, "libcuda.so")))
+
+	testCases := []struct {
+		description    string
+		template       string
+		expectedError  bool
+		expectedOutput string
+	}{
+		{
+			description: "types are inferred",
+			template: strings.Join([]string{
+				"# the BSP libraries",
+				"/usr/lib/aarch64-linux-gnu/nvidia/lib*.so*",
+				"auto, /usr/lib/aarch64-linux-gnu/nvidia",
+			}, "\n"),
+			expectedOutput: strings.Join([]string{
+				"sym, /usr/lib/aarch64-linux-gnu/nvidia/libcuda.so",
+				"lib, /usr/lib/aarch64-linux-gnu/nvidia/libcuda.so.1.1",
+				"lib, /usr/lib/aarch64-linux-gnu/nvidia/libnvrm_gpu.so",
+				"dir, /usr/lib/aarch64-linux-gnu/nvidia",
+				"",
+			}, "\n"),
+		},
+		{
+			description: "explicit types are used and duplicates removed",
+			template: strings.Join([]string{
+				"lib, /usr/lib/aarch64-linux-gnu/nvidia/libcuda.so*",
+				"lib, /usr/lib/aarch64-linux-gnu/nvidia/libcuda.so",
+				"dev, nvhost-*",
+			}, "\n"),
+			expectedOutput: strings.Join([]string{
+				"lib, /usr/lib/aarch64-linux-gnu/nvidia/libcuda.so",
+				"lib, /usr/lib/aarch64-linux-gnu/nvidia/libcuda.so.1.1",
+				"",
+			}, "\n"),
+		},
+		{
+			description:   "invalid type is an error",
+			template:      "file, /usr/lib",
+			expectedError: true,
+		},
+		{
+			description:   "relative pattern is an error",
+			template:      "lib, usr/lib/libcuda.so",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			p := template{
+				logger:   logger,
+				root:     root,
+				filename: "template.csv",
+			}
+			specs, err := p.parseFromReader(strings.NewReader(tc.template))
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			output := &bytes.Buffer{}
+			require.NoError(t, WriteMountSpecs(output, specs))
+			require.Equal(t, tc.expectedOutput, output.String())
+		})
+	}
+}
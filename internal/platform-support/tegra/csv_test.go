@@ -35,6 +35,7 @@ func TestDiscovererFromCSVFiles(t *testing.T) {
 	testCases := []struct {
 		description         string
 		moutSpecs           map[csv.MountSpecType][]string
+		bspMountSpecs       map[csv.MountSpecType][]string
 		ignorePatterns      []string
 		symlinkLocator      lookup.Locator
 		symlinkChainLocator lookup.Locator
@@ -181,12 +182,31 @@ func TestDiscovererFromCSVFiles(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "BSP mount specs are used if no CSV mount specs are found",
+			bspMountSpecs: map[csv.MountSpecType][]string{
+				"lib": {"/usr/lib/aarch64-linux-gnu/nvidia/libnvos.so"},
+			},
+			symlinkLocator: &lookup.LocatorMock{
+				LocateFunc: func(path string) ([]string, error) {
+					return []string{path}, nil
+				},
+			},
+			expectedMounts: []discover.Mount{
+				{
+					Path:     "/usr/lib/aarch64-linux-gnu/nvidia/libnvos.so",
+					HostPath: "/usr/lib/aarch64-linux-gnu/nvidia/libnvos.so",
+					Options:  []string{"ro", "nosuid", "nodev", "rbind", "rprivate"},
+				},
+			},
+		},
 	}
 
 	hookCreator := discover.NewHookCreator()
 	for _, tc := range testCases {
 		t.Run(tc.description, func(t *testing.T) {
 			defer setGetTargetsFromCSVFiles(tc.moutSpecs)()
+			defer setGetTargetsFromBSP(tc.bspMountSpecs)()
 
 			o := tegraOptions{
 				logger:              logger,
@@ -223,3 +243,14 @@ func setGetTargetsFromCSVFiles(ovverride map[csv.MountSpecType][]string) func()
 		getTargetsFromCSVFiles = original
 	}
 }
+
+func setGetTargetsFromBSP(ovverride map[csv.MountSpecType][]string) func() {
+	original := getTargetsFromBSP
+	getTargetsFromBSP = func(logger logger.Interface, root string) map[csv.MountSpecType][]string {
+		return ovverride
+	}
+
+	return func() {
+		getTargetsFromBSP = original
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/symlinks"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/platform-support/tegra/csv"
 )
 
 type tegraOptions struct {
@@ -60,7 +61,7 @@ func New(opts ...Option) (discover.Discover, error) {
 		o.symlinkLocator = lookup.NewSymlinkLocator(
 			lookup.WithLogger(o.logger),
 			lookup.WithRoot(o.driverRoot),
-			lookup.WithSearchPaths(append(o.librarySearchPaths, "/")...),
+			lookup.WithSearchPaths(o.getLibrarySearchPaths()...),
 		)
 	}
 
@@ -104,6 +105,16 @@ func New(opts ...Option) (discover.Discover, error) {
 	return d, nil
 }
 
+// getLibrarySearchPaths returns the paths to search for libraries and symlinks.
+// The BSP library directories are searched after the explicitly requested
+// paths to ensure that tegra-specific libraries are found.
+func (o tegraOptions) getLibrarySearchPaths() []string {
+	var searchPaths []string
+	searchPaths = append(searchPaths, o.librarySearchPaths...)
+	searchPaths = append(searchPaths, csv.BSPLibraryDirs...)
+	return append(searchPaths, "/")
+}
+
 // WithLogger sets the logger for the discoverer.
 func WithLogger(logger logger.Interface) Option {
 	return func(o *tegraOptions) {
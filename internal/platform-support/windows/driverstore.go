@@ -0,0 +1,150 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package windows implements the discovery of the NVIDIA driver on Windows
+// hosts and the generation of CDI container edits for Windows containers
+// (including HostProcess containers). The discovery logic does not depend on
+// Windows APIs so that it can be exercised on any platform.
+package windows
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+const (
+	// DefaultDriverStorePath is the default location of the driver store on
+	// a Windows host.
+	DefaultDriverStorePath = `C:\Windows\System32\DriverStore\FileRepository`
+
+	// HostDriverStorePath is the path at which the driver store of the host
+	// is made available in Windows containers.
+	HostDriverStorePath = `C:\Windows\System32\HostDriverStore\FileRepository`
+
+	// DisplayAdapterInterfaceClass is the device interface class GUID of
+	// display adapters. Assigning this class to a process-isolated Windows
+	// container makes the GPUs of the host available to the container.
+	DisplayAdapterInterfaceClass = "class/5B45201D-F2F2-4F3B-85BB-30FF1F953599"
+
+	// driverPackagePattern matches the directories of NVIDIA display driver
+	// packages in the driver store (e.g. nv_dispi.inf_amd64_<hash>).
+	driverPackagePattern = "nv*.inf_amd64_*"
+)
+
+// requiredDriverFiles are the files that identify a driver package as one
+// that provides the CUDA user-mode driver.
+var requiredDriverFiles = []string{
+	"nvcuda64.dll",
+	"nvml.dll",
+}
+
+// A DriverPackage represents an NVIDIA driver package in the driver store.
+type DriverPackage struct {
+	// Path is the path of the package directory on the host.
+	Path string
+}
+
+// Name returns the name of the driver package directory. Both Windows and
+// forward-slash separators are handled so that paths are processed
+// consistently on all platforms.
+func (p DriverPackage) Name() string {
+	return p.Path[strings.LastIndexAny(p.Path, `\/`)+1:]
+}
+
+// ContainerPath returns the path at which the driver package is available in
+// a Windows container.
+func (p DriverPackage) ContainerPath() string {
+	return HostDriverStorePath + `\` + p.Name()
+}
+
+// FindDriverPackages returns the NVIDIA driver packages in the specified
+// driver store that contain the CUDA user-mode driver. If more than one
+// version of the driver is staged, the most recently modified package is
+// returned first.
+func FindDriverPackages(logger logger.Interface, driverStorePath string) ([]DriverPackage, error) {
+	candidates, err := filepath.Glob(filepath.Join(driverStorePath, driverPackagePattern))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search driver store: %w", err)
+	}
+
+	type candidate struct {
+		DriverPackage
+		modified int64
+	}
+	var packages []candidate
+	for _, path := range candidates {
+		info, err := os.Stat(path)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		if missing := getMissingFile(path); missing != "" {
+			logger.Debugf("Skipping driver package %v: %v not found", path, missing)
+			continue
+		}
+		packages = append(packages, candidate{DriverPackage{Path: path}, info.ModTime().UnixNano()})
+	}
+	if len(packages) == 0 {
+		return nil, fmt.Errorf("no NVIDIA driver packages found in %v", driverStorePath)
+	}
+
+	sort.SliceStable(packages, func(i, j int) bool {
+		return packages[i].modified > packages[j].modified
+	})
+
+	var result []DriverPackage
+	for _, p := range packages {
+		result = append(result, p.DriverPackage)
+	}
+	return result, nil
+}
+
+func getMissingFile(dir string) string {
+	for _, file := range requiredDriverFiles {
+		if _, err := os.Stat(filepath.Join(dir, file)); err != nil {
+			return file
+		}
+	}
+	return ""
+}
+
+// GetContainerEdits returns the CDI container edits that make the specified
+// driver package available in a Windows container. The display adapter
+// interface class is requested as a device and the driver package is mounted
+// read-only at the location where the driver store of the host is expected.
+func GetContainerEdits(p DriverPackage) specs.ContainerEdits {
+	return specs.ContainerEdits{
+		DeviceNodes: []*specs.DeviceNode{
+			{
+				Path: DisplayAdapterInterfaceClass,
+			},
+		},
+		Mounts: []*specs.Mount{
+			{
+				HostPath:      p.Path,
+				ContainerPath: p.ContainerPath(),
+				Options:       []string{"ro"},
+			},
+		},
+	}
+}
@@ -0,0 +1,122 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package windows
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+func TestFindDriverPackages(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description      string
+		packages         map[string][]string
+		expectedPackages []string
+		expectedError    bool
+	}{
+		{
+			description:   "empty driver store returns error",
+			expectedError: true,
+		},
+		{
+			description: "packages without the CUDA driver are skipped",
+			packages: map[string][]string{
+				"nvhdci.inf_amd64_0123":   {"nvhda64v.sys"},
+				"nv_dispi.inf_amd64_4567": {"nvcuda64.dll", "nvml.dll"},
+				"other.inf_amd64_89ab":    {"nvcuda64.dll", "nvml.dll"},
+			},
+			expectedPackages: []string{"nv_dispi.inf_amd64_4567"},
+		},
+		{
+			description: "most recent package is returned first",
+			packages: map[string][]string{
+				"nv_dispi.inf_amd64_0000": {"nvcuda64.dll", "nvml.dll"},
+				"nv_dispi.inf_amd64_1111": {"nvcuda64.dll", "nvml.dll"},
+			},
+			expectedPackages: []string{"nv_dispi.inf_amd64_1111", "nv_dispi.inf_amd64_0000"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			driverStore := t.TempDir()
+			// Packages are created with increasing modification times in
+			// lexical order.
+			modified := time.Now().Add(-time.Hour)
+			for _, name := range sortedKeys(tc.packages) {
+				dir := filepath.Join(driverStore, name)
+				require.NoError(t, os.MkdirAll(dir, 0755))
+				for _, file := range tc.packages[name] {
+					require.NoError(t, os.WriteFile(filepath.Join(dir, file), nil, 0600))
+				}
+				require.NoError(t, os.Chtimes(dir, modified, modified))
+				modified = modified.Add(time.Minute)
+			}
+
+			packages, err := FindDriverPackages(logger, driverStore)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var names []string
+			for _, p := range packages {
+				require.Equal(t, filepath.Join(driverStore, p.Name()), p.Path)
+				names = append(names, p.Name())
+			}
+			require.EqualValues(t, tc.expectedPackages, names)
+		})
+	}
+}
+
+func TestGetContainerEdits(t *testing.T) {
+	p := DriverPackage{Path: `C:\Windows\System32\DriverStore\FileRepository\nv_dispi.inf_amd64_4567`}
+
+	expected := specs.ContainerEdits{
+		DeviceNodes: []*specs.DeviceNode{
+			{Path: "class/5B45201D-F2F2-4F3B-85BB-30FF1F953599"},
+		},
+		Mounts: []*specs.Mount{
+			{
+				HostPath:      p.Path,
+				ContainerPath: `C:\Windows\System32\HostDriverStore\FileRepository\nv_dispi.inf_amd64_4567`,
+				Options:       []string{"ro"},
+			},
+		},
+	}
+	require.EqualValues(t, expected, GetContainerEdits(p))
+}
+
+func sortedKeys(m map[string][]string) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,85 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package rootfs provides utilities for working with container root
+// filesystems from within OCI hooks.
+package rootfs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	securejoin "github.com/cyphar/filepath-securejoin"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+// EnsureWritable ensures that the specified path in the container root can be
+// written to.
+// If the path (or its closest existing parent) is on a read-only filesystem, a
+// tmpfs-backed overlay is mounted over the existing directory so that files
+// generated by hooks (e.g. ld.so.conf.d fragments or symlinks) do not cause
+// container creation to fail. Existing submounts of the directory are
+// preserved.
+// If the path is already writable, no changes are made.
+func EnsureWritable(logger logger.Interface, containerRoot string, path string) error {
+	target, err := existingDirInRoot(containerRoot, path)
+	if err != nil {
+		return err
+	}
+
+	readOnly, err := isReadOnly(target)
+	if err != nil {
+		return fmt.Errorf("failed to check whether %v is read-only: %w", target, err)
+	}
+	if !readOnly {
+		return nil
+	}
+
+	logger.Infof("Mounting writable overlay over read-only path %v", target)
+	if err := mountWritableOverlay(target); err != nil {
+		return fmt.Errorf("failed to mount writable overlay over %v: %w", target, err)
+	}
+	return nil
+}
+
+// existingDirInRoot returns the closest existing directory to the specified
+// path in the container root. The returned path is always in the container
+// root.
+func existingDirInRoot(containerRoot string, path string) (string, error) {
+	resolved, err := securejoin.SecureJoin(containerRoot, path)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %v in %v: %w", path, containerRoot, err)
+	}
+
+	root := filepath.Clean(containerRoot)
+	for current := resolved; ; current = filepath.Dir(current) {
+		info, err := os.Stat(current)
+		if err == nil && info.IsDir() {
+			return current, nil
+		}
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+		if current == root || !strings.HasPrefix(current, root) {
+			return "", fmt.Errorf("no existing directory found for %v in %v", path, containerRoot)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package rootfs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// isReadOnly checks whether the filesystem containing the specified path is
+// mounted read-only.
+func isReadOnly(path string) (bool, error) {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(path, &stat); err != nil {
+		return false, err
+	}
+	return stat.Flags&unix.ST_RDONLY != 0, nil
+}
+
+// mountWritableOverlay mounts an overlay filesystem over the specified
+// directory using the directory itself as the lower layer and a tmpfs as the
+// upper layer.
+// Since the submounts of the lower directory are not visible through the
+// overlay, these are opened before the overlay is mounted and bind mounted over
+// the overlay afterwards.
+func mountWritableOverlay(target string) error {
+	if strings.ContainsAny(target, ",:") {
+		return fmt.Errorf("unsupported characters in path %q", target)
+	}
+
+	submounts, err := getSubmounts(target)
+	if err != nil {
+		return fmt.Errorf("failed to get submounts: %w", err)
+	}
+	var fds []*os.File
+	defer func() {
+		for _, fd := range fds {
+			_ = fd.Close()
+		}
+	}()
+	for _, submount := range submounts {
+		fd, err := os.OpenFile(submount, unix.O_PATH|unix.O_CLOEXEC, 0)
+		if err != nil {
+			return fmt.Errorf("failed to open submount %v: %w", submount, err)
+		}
+		fds = append(fds, fd)
+	}
+
+	scratch, err := os.MkdirTemp("", "nvidia-ctk-rootfs-")
+	if err != nil {
+		return fmt.Errorf("failed to create scratch directory: %w", err)
+	}
+	defer os.Remove(scratch)
+
+	if err := unix.Mount("tmpfs", scratch, "tmpfs", unix.MS_NODEV|unix.MS_NOSUID, "mode=0755"); err != nil {
+		return fmt.Errorf("failed to mount tmpfs: %w", err)
+	}
+	// The overlay holds a reference to the upper and work directories, so the
+	// scratch mount can be detached once the overlay has been mounted.
+	defer func() {
+		_ = unix.Unmount(scratch, unix.MNT_DETACH)
+	}()
+
+	upper := filepath.Join(scratch, "upper")
+	work := filepath.Join(scratch, "work")
+	for _, dir := range []string{upper, work} {
+		if err := os.Mkdir(dir, 0755); err != nil {
+			return err
+		}
+	}
+	info, err := os.Stat(target)
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(upper, info.Mode().Perm()); err != nil {
+		return err
+	}
+
+	options := fmt.Sprintf("lowerdir=%s,upperdir=%s,workdir=%s", target, upper, work)
+	if err := unix.Mount("overlay", target, "overlay", 0, options); err != nil {
+		return fmt.Errorf("failed to mount overlay: %w", err)
+	}
+
+	for i, fd := range fds {
+		source := "/proc/self/fd/" + strconv.Itoa(int(fd.Fd()))
+		if err := unix.Mount(source, submounts[i], "", unix.MS_BIND|unix.MS_REC, ""); err != nil {
+			return fmt.Errorf("failed to restore submount %v: %w", submounts[i], err)
+		}
+	}
+
+	return nil
+}
+
+// getSubmounts returns the top-level mounts below the specified path as read
+// from /proc/self/mountinfo. Nested submounts are not returned since these are
+// included when the top-level mount is recursively bind mounted.
+func getSubmounts(path string) ([]string, error) {
+	mountinfo, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return nil, err
+	}
+	defer mountinfo.Close()
+
+	return parseSubmounts(mountinfo, path)
+}
+
+func parseSubmounts(mountinfo io.Reader, path string) ([]string, error) {
+	prefix := filepath.Clean(path) + "/"
+
+	var candidates []string
+	scanner := bufio.NewScanner(mountinfo)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+		mountpoint := unescapeMountinfo(fields[4])
+		if strings.HasPrefix(mountpoint, prefix) {
+			candidates = append(candidates, mountpoint)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Strings(candidates)
+	var submounts []string
+	for _, candidate := range candidates {
+		if isBelowAny(candidate, submounts) {
+			continue
+		}
+		submounts = append(submounts, candidate)
+	}
+	return submounts, nil
+}
+
+// isBelowAny checks whether the specified path is equal to or below any of the
+// specified parents.
+func isBelowAny(path string, parents []string) bool {
+	for _, parent := range parents {
+		if path == parent || strings.HasPrefix(path, parent+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// unescapeMountinfo replaces the octal escapes used for whitespace and
+// backslashes in /proc/self/mountinfo.
+func unescapeMountinfo(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+4 <= len(s) {
+			if v, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(v))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
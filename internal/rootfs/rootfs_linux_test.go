@@ -0,0 +1,48 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package rootfs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSubmounts(t *testing.T) {
+	mountinfo := `22 1 0:21 / / rw,relatime - overlay overlay rw
+23 22 0:22 / /rootfs/etc ro,relatime - overlay overlay ro
+24 23 0:23 /hosts /rootfs/etc/hosts rw,relatime - ext4 /dev/sda1 rw
+25 23 0:23 /resolv.conf /rootfs/etc/resolv.conf rw,relatime - ext4 /dev/sda1 rw
+26 23 0:24 / /rootfs/etc/secrets rw,relatime - tmpfs tmpfs rw
+27 26 0:25 / /rootfs/etc/secrets/nested rw,relatime - tmpfs tmpfs rw
+28 23 0:26 / /rootfs/etc/with\040space rw,relatime - tmpfs tmpfs rw
+29 22 0:27 / /rootfs/etcetera rw,relatime - tmpfs tmpfs rw
+`
+	submounts, err := parseSubmounts(strings.NewReader(mountinfo), "/rootfs/etc")
+	require.NoError(t, err)
+	require.Equal(t,
+		[]string{
+			"/rootfs/etc/hosts",
+			"/rootfs/etc/resolv.conf",
+			"/rootfs/etc/secrets",
+			"/rootfs/etc/with space",
+		},
+		submounts,
+	)
+}
@@ -0,0 +1,73 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package rootfs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExistingDirInRoot(t *testing.T) {
+	containerRoot := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(containerRoot, "etc/ld.so.conf.d"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(containerRoot, "etc/ld.so.cache"), nil, 0644))
+	require.NoError(t, os.Symlink("/etc", filepath.Join(containerRoot, "etc-link")))
+
+	testCases := []struct {
+		description string
+		path        string
+		expected    string
+	}{
+		{
+			description: "existing directory is returned",
+			path:        "/etc/ld.so.conf.d",
+			expected:    "/etc/ld.so.conf.d",
+		},
+		{
+			description: "parent of file is returned",
+			path:        "/etc/ld.so.cache",
+			expected:    "/etc",
+		},
+		{
+			description: "closest existing parent is returned",
+			path:        "/usr/lib/x86_64-linux-gnu",
+			expected:    "/",
+		},
+		{
+			description: "symlinks are resolved in root",
+			path:        "/etc-link/ld.so.conf.d",
+			expected:    "/etc/ld.so.conf.d",
+		},
+		{
+			description: "path outside root is resolved in root",
+			path:        "/../../etc",
+			expected:    "/etc",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			dir, err := existingDirInRoot(containerRoot, tc.path)
+			require.NoError(t, err)
+			require.Equal(t, filepath.Join(containerRoot, tc.expected), dir)
+		})
+	}
+}
@@ -0,0 +1,205 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package runtime
+
+import (
+	"fmt"
+	"strings"
+
+	"tags.cncf.io/container-device-interface/pkg/parser"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/ledger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+// An exclusiveAllocator records the devices of a container that requests
+// exclusive devices in the allocation ledger. It also acts as a GPU lister
+// that omits the GPUs that are allocated to other containers so that requests
+// such as count:N select unallocated GPUs. Similarly, MIG devices that are
+// allocated to other containers are reported as in use.
+type exclusiveAllocator struct {
+	logger    logger.Interface
+	ledger    *ledger.Ledger
+	gpuLister image.GPULister
+	bundle    string
+	pidFile   string
+}
+
+var _ image.GPULister = (*exclusiveAllocator)(nil)
+var _ image.MIGDeviceLister = (*exclusiveAllocator)(nil)
+
+// newExclusiveAllocator opens the allocation ledger if it is configured and
+// the container requests exclusive devices. The ledger remains locked until
+// the allocator is closed. A nil allocator is returned if no exclusive
+// allocation is required.
+func newExclusiveAllocator(logger logger.Interface, cfg *config.Config, argv []string, ociSpec oci.Spec, gpuLister image.GPULister) (*exclusiveAllocator, error) {
+	path := cfg.NVIDIAContainerRuntimeConfig.AllocationLedgerPath
+	if path == "" {
+		return nil, nil
+	}
+
+	rawSpec, err := ociSpec.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OCI spec: %w", err)
+	}
+	i, err := image.NewCUDAImageFromSpec(rawSpec, image.WithLogger(logger))
+	if err != nil {
+		return nil, err
+	}
+	if !i.RequestsExclusiveDevices() {
+		return nil, nil
+	}
+
+	bundle, err := oci.GetBundleDir(argv)
+	if err != nil {
+		return nil, err
+	}
+	pidFile, err := oci.GetPIDFileFromArgs(argv)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := ledger.Open(logger, path)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &exclusiveAllocator{
+		logger:    logger,
+		ledger:    l,
+		gpuLister: gpuLister,
+		bundle:    bundle,
+		pidFile:   pidFile,
+	}
+	return a, nil
+}
+
+// GPUs returns the GPUs that are not allocated to other containers.
+func (a *exclusiveAllocator) GPUs() ([]image.GPU, error) {
+	gpus, err := a.gpuLister.GPUs()
+	if err != nil {
+		return nil, err
+	}
+	allocated := a.ledger.Allocated()
+
+	var available []image.GPU
+	for _, gpu := range gpus {
+		if owner, ok := allocated[gpu.UUID]; ok && owner != a.bundle {
+			a.logger.Debugf("Skipping GPU %v allocated to %v", gpu.UUID, owner)
+			continue
+		}
+		available = append(available, gpu)
+	}
+	return available, nil
+}
+
+// MIGDevices returns the MIG devices on the system. MIG devices that are
+// allocated to other containers are marked as in use.
+func (a *exclusiveAllocator) MIGDevices() ([]image.MIGDevice, error) {
+	migs, err := a.migDevices()
+	if err != nil {
+		return nil, err
+	}
+	allocated := a.ledger.Allocated()
+
+	var devices []image.MIGDevice
+	for _, mig := range migs {
+		if owner, ok := allocated[mig.UUID]; ok && owner != a.bundle {
+			mig.InUse = true
+		}
+		devices = append(devices, mig)
+	}
+	return devices, nil
+}
+
+func (a *exclusiveAllocator) migDevices() ([]image.MIGDevice, error) {
+	lister, ok := a.gpuLister.(image.MIGDeviceLister)
+	if !ok {
+		return nil, fmt.Errorf("listing MIG devices is not supported")
+	}
+	return lister.MIGDevices()
+}
+
+// Allocate records the specified devices as allocated to the container.
+// Devices are recorded by UUID where possible so that requests by index and
+// by UUID for the same GPU or MIG device are detected as conflicting.
+func (a *exclusiveAllocator) Allocate(devices ...string) error {
+	gpus, err := a.gpuLister.GPUs()
+	if err != nil {
+		a.logger.Warningf("Failed to list GPUs; recording requested devices as is: %v", err)
+	}
+	var migs []image.MIGDevice
+	if hasMIGDevice(devices) {
+		migs, err = a.migDevices()
+		if err != nil {
+			a.logger.Warningf("Failed to list MIG devices; recording requested devices as is: %v", err)
+		}
+	}
+
+	var ids []string
+	for _, device := range devices {
+		if parser.IsQualifiedName(device) {
+			_, _, name, err := parser.ParseQualifiedName(device)
+			if err == nil {
+				device = name
+			}
+		}
+		if device == "all" {
+			for _, gpu := range gpus {
+				ids = append(ids, gpu.UUID)
+			}
+			continue
+		}
+		if gpu := image.FindGPU(gpus, device); gpu != nil {
+			ids = append(ids, gpu.UUID)
+			continue
+		}
+		if mig := image.FindMIGDevice(migs, device); mig != nil {
+			ids = append(ids, mig.UUID)
+			continue
+		}
+		ids = append(ids, device)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := a.ledger.Allocate(a.bundle, a.pidFile, ids...); err != nil {
+		return fmt.Errorf("failed to allocate exclusive devices: %w", err)
+	}
+	return nil
+}
+
+// Close persists the ledger and releases its lock.
+func (a *exclusiveAllocator) Close() error {
+	return a.ledger.Close()
+}
+
+// hasMIGDevice checks whether any of the specified devices refers to a MIG
+// device by index (<GPU index>:<MIG index>) or by UUID.
+func hasMIGDevice(devices []string) bool {
+	for _, device := range devices {
+		if strings.Contains(device, ":") || strings.HasPrefix(device, "MIG-") {
+			return true
+		}
+	}
+	return false
+}
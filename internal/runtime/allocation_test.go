@@ -0,0 +1,77 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/ledger"
+)
+
+type fakeGPULister []image.GPU
+
+func (f fakeGPULister) GPUs() ([]image.GPU, error) {
+	return f, nil
+}
+
+func TestExclusiveAllocator(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	gpus := fakeGPULister{
+		{Index: "0", UUID: "GPU-0"},
+		{Index: "1", UUID: "GPU-1"},
+	}
+
+	path := filepath.Join(t.TempDir(), "allocations.json")
+	first := t.TempDir()
+	second := t.TempDir()
+
+	newAllocator := func(bundle string) *exclusiveAllocator {
+		l, err := ledger.Open(logger, path)
+		require.NoError(t, err)
+		return &exclusiveAllocator{
+			logger:    logger,
+			ledger:    l,
+			gpuLister: gpus,
+			bundle:    bundle,
+		}
+	}
+
+	a := newAllocator(first)
+	require.NoError(t, a.Allocate("nvidia.com/gpu=0"))
+	require.NoError(t, a.Close())
+
+	b := newAllocator(second)
+	available, err := b.GPUs()
+	require.NoError(t, err)
+	require.EqualValues(t, []image.GPU{{Index: "1", UUID: "GPU-1"}}, available)
+	require.Error(t, b.Allocate("GPU-0"))
+	require.NoError(t, b.Allocate("1"))
+	require.NoError(t, b.Close())
+
+	require.NoError(t, os.RemoveAll(first))
+
+	c := newAllocator(second)
+	require.EqualValues(t, map[string]string{"GPU-1": second}, c.ledger.Allocated())
+	require.NoError(t, c.Close())
+}
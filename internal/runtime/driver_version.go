@@ -0,0 +1,110 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package runtime
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info/drivercheck"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info/proc"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+)
+
+// checkDriverVersion checks whether the user-space driver that would be
+// injected into the container matches the NVIDIA kernel module loaded for the
+// specified host root. A mismatch is expected while the driver is upgraded in
+// place and is handled as configured by the driver-version-mismatch option.
+//
+// If the user-space driver libraries matching the kernel module are to be
+// injected instead, their version is returned. Otherwise an empty string is
+// returned.
+func checkDriverVersion(logger logger.Interface, cfg *config.Config, hostRoot string, driver *root.Driver, mode info.RuntimeMode, container *image.CUDA) (string, error) {
+	policy := cfg.NVIDIAContainerRuntimeConfig.DriverVersionMismatch
+	switch policy {
+	case "", config.DriverVersionMismatchWarn, config.DriverVersionMismatchBlock, config.DriverVersionMismatchCompat:
+	default:
+		return "", fmt.Errorf("invalid driver version mismatch policy %q", policy)
+	}
+
+	if len(container.VisibleDevices()) == 0 {
+		return "", nil
+	}
+
+	requestedVersion := container.DriverVersion()
+	libraryVersion := requestedVersion
+	if libraryVersion == "" {
+		var err error
+		libraryVersion, err = driver.Version()
+		if err != nil {
+			logger.Debugf("Skipping driver version check: %v", err)
+			return "", nil
+		}
+	}
+
+	mismatch := drivercheck.CheckVersion(hostRoot, libraryVersion)
+	if mismatch == nil {
+		return "", nil
+	}
+
+	switch policy {
+	case config.DriverVersionMismatchBlock:
+		return "", mismatch
+	case config.DriverVersionMismatchCompat:
+		version, err := getCompatDriverVersion(hostRoot, driver, mode, requestedVersion)
+		if err != nil {
+			return "", errors.Join(mismatch, err)
+		}
+		logger.Warningf("%v; using driver version %v", mismatch, version)
+		return version, nil
+	default:
+		logger.Warningf("%v", mismatch)
+		return "", nil
+	}
+}
+
+// getCompatDriverVersion returns the version of the loaded kernel module if
+// the user-space driver libraries for this version are installed in a
+// versioned directory at the driver root.
+func getCompatDriverVersion(hostRoot string, driver *root.Driver, mode info.RuntimeMode, requestedVersion string) (string, error) {
+	if mode == info.LegacyRuntimeMode {
+		return "", fmt.Errorf("selecting the driver version is not supported in %v mode", mode)
+	}
+	if requestedVersion != "" {
+		return "", fmt.Errorf("driver version %v was explicitly requested", requestedVersion)
+	}
+
+	kernelModule, err := proc.GetKernelModuleInfo(hostRoot)
+	if err != nil {
+		return "", err
+	}
+	versions, err := driver.InstalledVersions()
+	if err != nil {
+		return "", err
+	}
+	for _, v := range versions {
+		if v.Version == kernelModule.Version {
+			return v.Version, nil
+		}
+	}
+	return "", fmt.Errorf("the user-space driver for version %v is not installed", kernelModule.Version)
+}
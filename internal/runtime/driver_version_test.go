@@ -0,0 +1,162 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+)
+
+func TestCheckDriverVersion(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description     string
+		policy          string
+		mode            info.RuntimeMode
+		kernelVersion   string
+		libraries       []string
+		env             []string
+		expectedError   bool
+		expectedVersion string
+	}{
+		{
+			description:   "matching versions",
+			policy:        "block",
+			kernelVersion: "550.54.14",
+			libraries:     []string{"/usr/lib64/libcuda.so.550.54.14"},
+			env:           []string{"NVIDIA_VISIBLE_DEVICES=all"},
+		},
+		{
+			description:   "mismatch is ignored if no devices are requested",
+			policy:        "block",
+			kernelVersion: "550.54.14",
+			libraries:     []string{"/usr/lib64/libcuda.so.550.90.07"},
+		},
+		{
+			description:   "mismatch is a warning by default",
+			kernelVersion: "550.54.14",
+			libraries:     []string{"/usr/lib64/libcuda.so.550.90.07"},
+			env:           []string{"NVIDIA_VISIBLE_DEVICES=all"},
+		},
+		{
+			description:   "mismatch is blocked",
+			policy:        "block",
+			kernelVersion: "550.54.14",
+			libraries:     []string{"/usr/lib64/libcuda.so.550.90.07"},
+			env:           []string{"NVIDIA_VISIBLE_DEVICES=all"},
+			expectedError: true,
+		},
+		{
+			description:   "requested driver version is checked",
+			policy:        "block",
+			kernelVersion: "550.54.14",
+			libraries:     []string{"/usr/lib64/libcuda.so.550.54.14"},
+			env:           []string{"NVIDIA_VISIBLE_DEVICES=all", "NVIDIA_DRIVER_VERSION=535.161.08"},
+			expectedError: true,
+		},
+		{
+			description:     "compat selects installed kernel module version",
+			policy:          "compat",
+			mode:            info.JitCDIRuntimeMode,
+			kernelVersion:   "550.54.14",
+			libraries:       []string{"/usr/lib64/libcuda.so.550.90.07", "/usr/lib/nvidia-550/libcuda.so.550.54.14"},
+			env:             []string{"NVIDIA_VISIBLE_DEVICES=all"},
+			expectedVersion: "550.54.14",
+		},
+		{
+			description:   "compat fails if kernel module version is not installed",
+			policy:        "compat",
+			mode:          info.JitCDIRuntimeMode,
+			kernelVersion: "550.54.14",
+			libraries:     []string{"/usr/lib64/libcuda.so.550.90.07"},
+			env:           []string{"NVIDIA_VISIBLE_DEVICES=all"},
+			expectedError: true,
+		},
+		{
+			description:   "compat fails in legacy mode",
+			policy:        "compat",
+			mode:          info.LegacyRuntimeMode,
+			kernelVersion: "550.54.14",
+			libraries:     []string{"/usr/lib64/libcuda.so.550.90.07", "/usr/lib/nvidia-550/libcuda.so.550.54.14"},
+			env:           []string{"NVIDIA_VISIBLE_DEVICES=all"},
+			expectedError: true,
+		},
+		{
+			description:   "invalid policy returns error",
+			policy:        "ignore",
+			kernelVersion: "550.54.14",
+			libraries:     []string{"/usr/lib64/libcuda.so.550.54.14"},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			hostRoot := t.TempDir()
+			versionFile := filepath.Join(hostRoot, "/proc/driver/nvidia/version")
+			require.NoError(t, os.MkdirAll(filepath.Dir(versionFile), 0755))
+			require.NoError(t, os.WriteFile(versionFile, []byte("NVRM version: NVIDIA UNIX x86_64 Kernel Module  "+tc.kernelVersion+"  Thu Feb 22 01:44:30 UTC 2024\n"), 0644))
+
+			driverRoot := t.TempDir()
+			for _, library := range tc.libraries {
+				path := filepath.Join(driverRoot, library)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+				require.NoError(t, os.WriteFile(path, nil, 0644))
+			}
+			driver := root.New(
+				root.WithLogger(logger),
+				root.WithDriverRoot(driverRoot),
+			)
+
+			container, err := image.New(
+				image.WithLogger(logger),
+				image.WithEnv(tc.env),
+			)
+			require.NoError(t, err)
+
+			configFile := filepath.Join(t.TempDir(), "config.toml")
+			var contents string
+			if tc.policy != "" {
+				contents = "[nvidia-container-runtime]\ndriver-version-mismatch = \"" + tc.policy + "\"\n"
+			}
+			require.NoError(t, os.WriteFile(configFile, []byte(contents), 0600))
+			toml, err := config.New(config.WithConfigFile(configFile))
+			require.NoError(t, err)
+			cfg, err := toml.Config()
+			require.NoError(t, err)
+
+			version, err := checkDriverVersion(logger, cfg, hostRoot, driver, tc.mode, &container)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedVersion, version)
+		})
+	}
+}
@@ -25,17 +25,35 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 )
 
-// Logger adds a way to manage output to a log file to a logrus.Logger
+const (
+	// logBufferSize is the number of log entries that are buffered while the
+	// log files are not writable. Further entries are dropped.
+	logBufferSize = 1024
+	// logFlushTimeout is the maximum time for which the buffered log entries
+	// are flushed before the low-level runtime is exec'd or the logger is
+	// reset. This bounds the delay introduced by a stalled log destination.
+	logFlushTimeout = 100 * time.Millisecond
+)
+
+// Logger adds a way to manage output to a log file to a logrus.Logger.
+// Log entries are written to the log files in the background so that a slow
+// log destination (e.g. an NFS-mounted /var/log) does not delay the creation
+// of a container.
 type Logger struct {
 	logger.Interface
 	previousLogger logger.Interface
 	logFiles       []*os.File
+	output         *logger.NonBlockingWriter
+	// reportedDrops is the number of dropped log entries that have already
+	// been reported.
+	reportedDrops uint64
 }
 
 // NewLogger creates an empty logger
@@ -105,24 +123,52 @@ func (l *Logger) Update(filename string, logLevel string, argv []string) {
 		newLogger.SetFormatter(new(logrus.JSONFormatter))
 	}
 
+	var output *logger.NonBlockingWriter
 	switch len(logFiles) {
 	case 0:
 		newLogger.SetOutput(io.Discard)
 	case 1:
-		newLogger.SetOutput(logFiles[0])
+		output = logger.NewNonBlockingWriter(logFiles[0], logBufferSize)
 	default:
 		var writers []io.Writer
 		for _, f := range logFiles {
 			writers = append(writers, f)
 		}
-		newLogger.SetOutput(io.MultiWriter(writers...))
+		output = logger.NewNonBlockingWriter(io.MultiWriter(writers...), logBufferSize)
+	}
+	if output != nil {
+		newLogger.SetOutput(output)
 	}
 
 	*l = Logger{
 		Interface:      newLogger,
 		previousLogger: l.Interface,
 		logFiles:       logFiles,
+		output:         output,
+	}
+}
+
+// Flush waits for the buffered log entries to be written to the log files.
+// Since this is bounded by a timeout, entries may still be lost if the log
+// destination is stalled. This must be called before the process is replaced
+// by exec'ing the low-level runtime.
+func (l *Logger) Flush() {
+	if l.output == nil {
+		return
 	}
+	l.reportDrops()
+	l.output.Flush(logFlushTimeout)
+}
+
+// reportDrops logs a warning if log entries were dropped since the last
+// report.
+func (l *Logger) reportDrops() {
+	dropped := l.output.Dropped()
+	if dropped == l.reportedDrops {
+		return
+	}
+	l.Warningf("Dropped %d log entries since the log file could not be written in time", dropped-l.reportedDrops)
+	l.reportedDrops = dropped
 }
 
 // Reset closes the log file (if any) and resets the logger output to what it
@@ -136,8 +182,15 @@ func (l *Logger) Reset() error {
 		l.Interface = previous
 		l.previousLogger = nil
 		l.logFiles = nil
+		l.output = nil
+		l.reportedDrops = 0
 	}()
 
+	if l.output != nil {
+		l.reportDrops()
+		l.output.Close(logFlushTimeout)
+	}
+
 	var errs []error
 	for _, f := range l.logFiles {
 		err := f.Close()
@@ -17,6 +17,8 @@
 package runtime
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/sirupsen/logrus"
@@ -34,3 +36,24 @@ func TestLogger(t *testing.T) {
 	lp := l.previousLogger.(*logrus.Logger)
 	require.Equal(t, logrus.InfoLevel, lp.Level)
 }
+
+func TestLoggerWritesToLogFile(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "nvidia-container-runtime.log")
+
+	l := NewLogger()
+	l.Update(logFile, "info", nil)
+	l.Infof("before flush")
+	l.Flush()
+
+	contents, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	require.Contains(t, string(contents), "before flush")
+
+	l.Infof("before reset")
+	require.NoError(t, l.Reset())
+
+	contents, err = os.ReadFile(logFile)
+	require.NoError(t, err)
+	require.Contains(t, string(contents), "before reset")
+	require.NotContains(t, string(contents), "Dropped")
+}
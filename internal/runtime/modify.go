@@ -0,0 +1,49 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package runtime
+
+import (
+	"fmt"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+// ModifySpec applies the same modifications as the NVIDIA Container Runtime
+// to the specified OCI spec in place.
+func ModifySpec(logger logger.Interface, cfg *config.Config, driver *root.Driver, spec *specs.Spec) error {
+	gpuLister := info.NewGPULister(logger, driver)
+	ociSpec, err := pinResolvedDevices(logger, cfg, oci.NewMemorySpec(spec), gpuLister, false)
+	if err != nil {
+		return fmt.Errorf("failed to resolve requested devices: %w", err)
+	}
+
+	specModifier, err := newSpecModifier(logger, cfg, ociSpec, "", driver, gpuLister)
+	if err != nil {
+		return fmt.Errorf("failed to construct OCI spec modifier: %w", err)
+	}
+	if err := ociSpec.Modify(specModifier); err != nil {
+		return fmt.Errorf("failed to modify OCI spec: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+)
+
+func TestModifySpec(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	driver := root.New(
+		root.WithDriverRoot("/nvidia/driver/root"),
+	)
+
+	prestartHook := specs.Hook{
+		Path: "/usr/bin/nvidia-container-runtime-hook",
+		Args: []string{"nvidia-container-runtime-hook", "prestart"},
+	}
+
+	testCases := []struct {
+		description   string
+		spec          *specs.Spec
+		expectedHooks []specs.Hook
+	}{
+		{
+			description: "legacy mode adds prestart hook",
+			spec: &specs.Spec{
+				Process: &specs.Process{
+					Env: []string{"NVIDIA_VISIBLE_DEVICES=all"},
+				},
+			},
+			expectedHooks: []specs.Hook{prestartHook},
+		},
+		{
+			description: "existing hook is not added again",
+			spec: &specs.Spec{
+				Process: &specs.Process{
+					Env: []string{"NVIDIA_VISIBLE_DEVICES=all"},
+				},
+				Hooks: &specs.Hooks{
+					Prestart: []specs.Hook{prestartHook},
+				},
+			},
+			expectedHooks: []specs.Hook{prestartHook},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cfg := &config.Config{
+				NVIDIAContainerRuntimeConfig: config.RuntimeConfig{
+					Mode: "legacy",
+				},
+				NVIDIAContainerRuntimeHookConfig: config.RuntimeHookConfig{
+					Path: "/usr/bin/nvidia-container-runtime-hook",
+				},
+			}
+			err := ModifySpec(logger, cfg, driver, tc.spec)
+			require.NoError(t, err)
+			require.NotNil(t, tc.spec.Hooks)
+			require.EqualValues(t, tc.expectedHooks, tc.spec.Hooks.Prestart)
+		})
+	}
+}
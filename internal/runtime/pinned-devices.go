@@ -0,0 +1,87 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package runtime
+
+import (
+	"fmt"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/modifier"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+// A pinnedDevicesSpec is an OCI spec where the device request is replaced by
+// the resolved devices whenever the spec is loaded. Since the modified spec is
+// flushed, the resolved devices are also seen by the NVIDIA Container Runtime
+// Hook.
+type pinnedDevicesSpec struct {
+	oci.Spec
+	modifier oci.SpecModifier
+}
+
+// Load loads the wrapped spec and replaces the device request.
+func (s *pinnedDevicesSpec) Load() (*specs.Spec, error) {
+	spec, err := s.Spec.Load()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.modifier.Modify(spec); err != nil {
+		return nil, fmt.Errorf("failed to pin resolved devices: %w", err)
+	}
+	return spec, nil
+}
+
+// pinResolvedDevices resolves the devices requested by the container once and
+// returns a spec in which the request is replaced by the resolved devices if
+// these depend on the state of the system (e.g. count:N requests). If
+// expandAll is set, a request for all devices is also replaced by the GPUs
+// returned by the specified lister. This is required if the lister excludes
+// GPUs, as is the case when exclusive devices are allocated.
+func pinResolvedDevices(logger logger.Interface, cfg *config.Config, ociSpec oci.Spec, gpuLister image.GPULister, expandAll bool) (oci.Spec, error) {
+	_, container, err := initRuntimeModeAndImage(logger, cfg, ociSpec, gpuLister)
+	if err != nil {
+		return nil, err
+	}
+
+	devices, envVars, isDynamic := container.ResolvedEnvvarDevices()
+	if !isDynamic && expandAll && len(devices) == 1 && devices[0] == "all" {
+		gpus, err := gpuLister.GPUs()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list GPUs to resolve request for all devices: %w", err)
+		}
+		devices = nil
+		for _, gpu := range gpus {
+			devices = append(devices, gpu.Index)
+		}
+		isDynamic = true
+	}
+	if !isDynamic {
+		return ociSpec, nil
+	}
+
+	logger.Infof("Resolved device request to %v", devices)
+	s := &pinnedDevicesSpec{
+		Spec:     ociSpec,
+		modifier: modifier.NewPinnedDevicesModifier(logger, envVars, devices),
+	}
+	return s, nil
+}
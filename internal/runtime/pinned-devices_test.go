@@ -0,0 +1,170 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package runtime
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/ledger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+func TestPinResolvedDevices(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	gpus := fakeGPULister{
+		{Index: "0", UUID: "GPU-0"},
+		{Index: "1", UUID: "GPU-1"},
+	}
+
+	testCases := []struct {
+		description string
+		env         []string
+		expandAll   bool
+		expectedEnv []string
+	}{
+		{
+			description: "explicit devices are not modified",
+			env:         []string{"NVIDIA_VISIBLE_DEVICES=1"},
+			expectedEnv: []string{"NVIDIA_VISIBLE_DEVICES=1"},
+		},
+		{
+			description: "all is not modified",
+			env:         []string{"NVIDIA_VISIBLE_DEVICES=all"},
+			expectedEnv: []string{"NVIDIA_VISIBLE_DEVICES=all"},
+		},
+		{
+			description: "count is pinned",
+			env:         []string{"PATH=/usr/bin", "NVIDIA_VISIBLE_DEVICES=count:1"},
+			expectedEnv: []string{"PATH=/usr/bin", "NVIDIA_VISIBLE_DEVICES=0"},
+		},
+		{
+			description: "exclusion is pinned",
+			env:         []string{"NVIDIA_VISIBLE_DEVICES=all,-0"},
+			expectedEnv: []string{"NVIDIA_VISIBLE_DEVICES=1"},
+		},
+		{
+			description: "all is pinned when expanded",
+			env:         []string{"NVIDIA_VISIBLE_DEVICES=all"},
+			expandAll:   true,
+			expectedEnv: []string{"NVIDIA_VISIBLE_DEVICES=0,1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cfg := &config.Config{
+				AcceptEnvvarUnprivileged: true,
+				NVIDIAContainerRuntimeConfig: config.RuntimeConfig{
+					Mode: "legacy",
+				},
+			}
+			ociSpec := oci.NewMemorySpec(&specs.Spec{
+				Process: &specs.Process{Env: tc.env},
+			})
+
+			pinned, err := pinResolvedDevices(logger, cfg, ociSpec, gpus, tc.expandAll)
+			require.NoError(t, err)
+
+			spec, err := pinned.Load()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedEnv, spec.Process.Env)
+		})
+	}
+}
+
+// TestPinResolvedDevicesLegacyMode checks that the devices allocated to a
+// container in legacy mode are the devices that are seen by the NVIDIA
+// Container Runtime Hook.
+func TestPinResolvedDevicesLegacyMode(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	driver := root.New(
+		root.WithDriverRoot("/nvidia/driver/root"),
+	)
+	gpus := fakeGPULister{
+		{Index: "0", UUID: "GPU-0"},
+		{Index: "1", UUID: "GPU-1"},
+	}
+	path := filepath.Join(t.TempDir(), "allocations.json")
+
+	createContainer := func(request string) []string {
+		cfg := &config.Config{
+			AcceptEnvvarUnprivileged: true,
+			NVIDIAContainerRuntimeConfig: config.RuntimeConfig{
+				Mode: "legacy",
+			},
+			NVIDIAContainerRuntimeHookConfig: config.RuntimeHookConfig{
+				Path: "/usr/bin/nvidia-container-runtime-hook",
+			},
+		}
+		bundle := t.TempDir()
+		l, err := ledger.Open(logger, path)
+		require.NoError(t, err)
+		allocator := &exclusiveAllocator{
+			logger:    logger,
+			ledger:    l,
+			gpuLister: gpus,
+			bundle:    bundle,
+		}
+		defer func() {
+			require.NoError(t, allocator.Close())
+		}()
+
+		ociSpec := oci.NewMemorySpec(&specs.Spec{
+			Process: &specs.Process{
+				Env: []string{
+					"NVIDIA_VISIBLE_DEVICES=" + request,
+					"NVIDIA_EXCLUSIVE_DEVICES=true",
+				},
+			},
+		})
+		ociSpec, err = pinResolvedDevices(logger, cfg, ociSpec, allocator, true)
+		require.NoError(t, err)
+
+		specModifier, err := newSpecModifier(logger, cfg, ociSpec, bundle, driver, allocator)
+		require.NoError(t, err)
+
+		_, container, err := initRuntimeModeAndImage(logger, cfg, ociSpec, allocator)
+		require.NoError(t, err)
+		require.NoError(t, allocator.Allocate(container.VisibleDevices()...))
+
+		spec, err := ociSpec.Load()
+		require.NoError(t, err)
+		require.NoError(t, specModifier.Modify(spec))
+		require.NotNil(t, spec.Hooks)
+		require.Len(t, spec.Hooks.Prestart, 1)
+		require.Equal(t, "/usr/bin/nvidia-container-runtime-hook", spec.Hooks.Prestart[0].Path)
+
+		return spec.Process.Env
+	}
+
+	require.EqualValues(t,
+		[]string{"NVIDIA_EXCLUSIVE_DEVICES=true", "NVIDIA_VISIBLE_DEVICES=0"},
+		createContainer("count:1"),
+	)
+	require.EqualValues(t,
+		[]string{"NVIDIA_EXCLUSIVE_DEVICES=true", "NVIDIA_VISIBLE_DEVICES=1"},
+		createContainer("all"),
+	)
+}
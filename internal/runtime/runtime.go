@@ -19,14 +19,17 @@ package runtime
 import (
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/opencontainers/runtime-spec/specs-go"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/info"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info/drivercheck"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/messages"
 )
 
 // Run is an entry point that allows for idiomatic handling of errors
@@ -34,18 +37,23 @@ import (
 func (r rt) Run(argv []string) (rerr error) {
 	defer func() {
 		if rerr != nil {
-			r.logger.Errorf("%v", rerr)
+			messages.Log(r.logger, rerr)
 		}
 	}()
 
 	printVersion := hasVersionFlag(argv)
 	if printVersion {
+		// If JSON output is requested, we only output the version of the NVIDIA
+		// Container Runtime to ensure that the output can be parsed.
+		if format := getVersionOutputFormat(argv); format != "" && format != "text" {
+			return info.WriteVersion(os.Stdout, "NVIDIA Container Runtime", format)
+		}
 		fmt.Printf("%v version %v\n", "NVIDIA Container Runtime", info.GetVersionString(fmt.Sprintf("spec: %v", specs.Version)))
 	}
 
 	cfg, err := config.GetConfig()
 	if err != nil {
-		return fmt.Errorf("error loading config: %v", err)
+		return messages.Wrap(err, messages.ConfigLoadFailed, nil)
 	}
 	r.logger.Update(
 		cfg.NVIDIAContainerRuntimeConfig.DebugFilePath,
@@ -54,7 +62,7 @@ func (r rt) Run(argv []string) (rerr error) {
 	)
 	defer func() {
 		if rerr != nil {
-			r.logger.Errorf("%v", rerr)
+			messages.Log(r.logger, rerr)
 		}
 		if err := r.logger.Reset(); err != nil {
 			rerr = errors.Join(rerr, fmt.Errorf("failed to reset logger: %v", err))
@@ -81,8 +89,17 @@ func (r rt) Run(argv []string) (rerr error) {
 	r.logger.Tracef("Command line arguments: %v", argv)
 	runtime, err := newNVIDIAContainerRuntime(r.logger, cfg, argv, driver)
 	if err != nil {
-		return fmt.Errorf("failed to create NVIDIA Container Runtime: %v", err)
+		err = fmt.Errorf("failed to create NVIDIA Container Runtime: %w", err)
+		// If NVML could not be initialized because the driver is not loaded,
+		// we check for common driver conflicts (e.g. nouveau being bound to
+		// the device) to add a targeted diagnostic to the error.
+		if driverErr := drivercheck.Check("/", err); driverErr != nil {
+			err = driverErr
+		}
+		r.reportUsage(cfg, argv, err)
+		return err
 	}
+	r.reportUsage(cfg, argv, nil)
 
 	if printVersion {
 		fmt.Print("\n")
@@ -114,3 +131,24 @@ func hasVersionFlag(args []string) bool {
 
 	return false
 }
+
+// getVersionOutputFormat returns the value of the --output flag that is used
+// to select the output format for --version.
+func getVersionOutputFormat(args []string) string {
+	for i := 0; i < len(args); i++ {
+		param := args[i]
+
+		parts := strings.SplitN(param, "=", 2)
+		if strings.TrimLeft(parts[0], "-") != "output" || parts[0] == "output" {
+			continue
+		}
+		if len(parts) == 2 {
+			return parts[1]
+		}
+		if i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+
+	return ""
+}
@@ -19,9 +19,11 @@ package runtime
 import (
 	"fmt"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/audit"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/failinject"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/info"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
@@ -37,6 +39,9 @@ func newNVIDIAContainerRuntime(logger logger.Interface, cfg *config.Config, argv
 	}
 
 	logger.Tracef("Using low-level runtime %v", lowLevelRuntime.String())
+	if f, ok := logger.(flusher); ok {
+		lowLevelRuntime = &flushingRuntime{Runtime: lowLevelRuntime, flusher: f}
+	}
 	if !oci.HasCreateSubcommand(argv) {
 		logger.Tracef("Skipping modifier for non-create subcommand")
 		return lowLevelRuntime, nil
@@ -47,9 +52,49 @@ func newNVIDIAContainerRuntime(logger logger.Interface, cfg *config.Config, argv
 		return nil, fmt.Errorf("error constructing OCI specification: %v", err)
 	}
 
-	specModifier, err := newSpecModifier(logger, cfg, ociSpec, driver)
+	var gpuLister image.GPULister = info.NewGPULister(logger, driver)
+	allocator, err := newExclusiveAllocator(logger, cfg, argv, ociSpec, gpuLister)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct exclusive device allocator: %w", err)
+	}
+	if allocator != nil {
+		defer func() {
+			if err := allocator.Close(); err != nil {
+				logger.Warningf("Failed to update allocation ledger: %v", err)
+			}
+		}()
+		gpuLister = allocator
+	}
+
+	ociSpec, err = pinResolvedDevices(logger, cfg, ociSpec, gpuLister, allocator != nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve requested devices: %w", err)
+	}
+
+	bundleDir, err := oci.GetBundleDir(argv)
+	if err != nil {
+		return nil, fmt.Errorf("error getting bundle directory: %v", err)
+	}
+
+	specModifier, err := newSpecModifier(logger, cfg, ociSpec, bundleDir, driver, gpuLister)
 	if err != nil {
-		return nil, fmt.Errorf("failed to construct OCI spec modifier: %v", err)
+		return nil, fmt.Errorf("failed to construct OCI spec modifier: %w", err)
+	}
+
+	if allocator != nil {
+		_, image, err := initRuntimeModeAndImage(logger, cfg, ociSpec, gpuLister)
+		if err != nil {
+			return nil, err
+		}
+		if err := allocator.Allocate(image.VisibleDevices()...); err != nil {
+			return nil, err
+		}
+	}
+
+	if auditLog := cfg.NVIDIAContainerRuntimeConfig.AuditLogFilePath; auditLog != "" {
+		if err := recordCreate(logger, auditLog, cfg, argv, ociSpec, gpuLister); err != nil {
+			logger.Warningf("Failed to record container creation in audit log: %v", err)
+		}
 	}
 
 	// Create the wrapping runtime with the specified modifier.
@@ -63,19 +108,86 @@ func newNVIDIAContainerRuntime(logger logger.Interface, cfg *config.Config, argv
 	return r, nil
 }
 
+// A flusher flushes buffered log entries.
+type flusher interface {
+	Flush()
+}
+
+// A flushingRuntime flushes the log entries that are buffered by the logger
+// before exec'ing the wrapped runtime since these would otherwise be lost when
+// the process is replaced.
+type flushingRuntime struct {
+	oci.Runtime
+	flusher
+}
+
+func (r *flushingRuntime) Exec(args []string) error {
+	r.Flush()
+	return r.Runtime.Exec(args)
+}
+
+// recordCreate appends an entry for the container being created to the
+// specified audit log. Since the runtime mode has already been resolved, the
+// config reflects the mode that is applied to the container. If the
+// record-gpu-utilization feature is enabled, a snapshot of the utilization of
+// the GPUs of the container is included.
+func recordCreate(logger logger.Interface, auditLog string, cfg *config.Config, argv []string, ociSpec oci.Spec, gpuLister image.GPULister) error {
+	bundleDir, err := oci.GetBundleDir(argv)
+	if err != nil {
+		return err
+	}
+	rawSpec, err := ociSpec.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load OCI spec: %w", err)
+	}
+	entry := audit.NewCreateEntry(bundleDir, cfg.NVIDIAContainerRuntimeConfig.Mode, rawSpec)
+	if cfg.Features.RecordGPUUtilization.IsEnabled() {
+		_, container, err := initRuntimeModeAndImage(logger, cfg, ociSpec, gpuLister)
+		if err != nil {
+			return err
+		}
+		entry.GPUs, err = audit.GetGPUSnapshots(gpuLister, container.VisibleDevices()...)
+		if err != nil {
+			logger.Warningf("Failed to record GPU utilization: %v", err)
+		}
+	}
+	return audit.Append(auditLog, entry)
+}
+
 // newSpecModifier is a factory method that creates constructs an OCI spec modifer based on the provided config.
-func newSpecModifier(logger logger.Interface, cfg *config.Config, ociSpec oci.Spec, driver *root.Driver) (oci.SpecModifier, error) {
-	mode, image, err := initRuntimeModeAndImage(logger, cfg, ociSpec)
+// The bundle directory is used to resolve a relative container root and may be empty if this is not known.
+func newSpecModifier(logger logger.Interface, cfg *config.Config, ociSpec oci.Spec, bundleDir string, driver *root.Driver, gpuLister image.GPULister) (oci.SpecModifier, error) {
+	mode, container, err := initRuntimeModeAndImage(logger, cfg, ociSpec, gpuLister)
+	if err != nil {
+		return nil, err
+	}
+
+	driverVersion, err := checkDriverVersion(logger, cfg, "/", driver, mode, container)
 	if err != nil {
 		return nil, err
 	}
+	if driverVersion != "" {
+		// The image is reconstructed so that the selected driver version is
+		// also used for the CDI devices that are injected.
+		driver, err = driver.ForVersion(driverVersion)
+		if err != nil {
+			return nil, err
+		}
+		mode, container, err = initRuntimeModeAndImage(logger, cfg, ociSpec, gpuLister, image.WithDefaultDriverVersion(driverVersion))
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	modeModifier, err := newModeModifier(logger, mode, cfg, *image)
+	modeModifier, err := newModeModifier(logger, mode, cfg, *container, driver)
 	if err != nil {
 		return nil, err
 	}
 
-	hookCreator := discover.NewHookCreator(discover.WithNVIDIACDIHookPath(cfg.NVIDIACTKConfig.Path))
+	hookCreator := discover.NewHookCreator(
+		discover.WithNVIDIACDIHookPath(cfg.NVIDIACTKConfig.Path),
+		discover.WithEnv(failinject.Env()...),
+	)
 	var modifiers modifier.List
 	for _, modifierType := range supportedModifierTypes(mode) {
 		switch modifierType {
@@ -84,31 +196,95 @@ func newSpecModifier(logger logger.Interface, cfg *config.Config, ociSpec oci.Sp
 		case "nvidia-hook-remover":
 			modifiers = append(modifiers, modifier.NewNvidiaContainerRuntimeHookRemover(logger))
 		case "graphics":
-			graphicsModifier, err := modifier.NewGraphicsModifier(logger, cfg, *image, driver, hookCreator)
+			graphicsModifier, err := modifier.NewGraphicsModifier(logger, cfg, *container, driver, hookCreator)
 			if err != nil {
 				return nil, err
 			}
 			modifiers = append(modifiers, graphicsModifier)
 		case "feature-gated":
-			featureGatedModifier, err := modifier.NewFeatureGatedModifier(logger, cfg, *image, driver, hookCreator)
+			featureGatedModifier, err := modifier.NewFeatureGatedModifier(logger, cfg, *container, driver, hookCreator)
 			if err != nil {
 				return nil, err
 			}
 			modifiers = append(modifiers, featureGatedModifier)
+		case "compute-mode":
+			computeModeModifier, err := modifier.NewComputeModeModifier(logger, cfg, *container, hookCreator)
+			if err != nil {
+				return nil, err
+			}
+			modifiers = append(modifiers, computeModeModifier)
+		case "gpu-limits":
+			gpuLimitsModifier, err := modifier.NewGPULimitsModifier(logger, cfg, *container, hookCreator)
+			if err != nil {
+				return nil, err
+			}
+			modifiers = append(modifiers, gpuLimitsModifier)
+		case "gpu-memory":
+			gpuMemoryModifier, err := modifier.NewGPUMemoryModifier(logger, cfg, *container, hookCreator)
+			if err != nil {
+				return nil, err
+			}
+			modifiers = append(modifiers, gpuMemoryModifier)
+		case "cleanup":
+			cleanupModifier, err := modifier.NewCleanupModifier(logger, cfg, *container, hookCreator)
+			if err != nil {
+				return nil, err
+			}
+			modifiers = append(modifiers, cleanupModifier)
+		case "gpu-sharing":
+			gpuSharingModifier, err := modifier.NewGPUSharingModifier(logger, cfg, *container)
+			if err != nil {
+				return nil, err
+			}
+			modifiers = append(modifiers, gpuSharingModifier)
+		case "device-order":
+			deviceOrderModifier, err := modifier.NewDeviceOrderModifier(logger, cfg, *container)
+			if err != nil {
+				return nil, err
+			}
+			modifiers = append(modifiers, deviceOrderModifier)
+		case "device-metadata":
+			deviceMetadataModifier, err := modifier.NewDeviceMetadataModifier(logger, cfg, *container, hookCreator)
+			if err != nil {
+				return nil, err
+			}
+			modifiers = append(modifiers, deviceMetadataModifier)
+		case "device-health":
+			modifiers = append(modifiers, modifier.NewDeviceHealthModifier(logger, cfg, *container))
+		case "topology":
+			modifiers = append(modifiers, modifier.NewTopologyModifier(logger, *container))
+		case "gpu-utilization":
+			modifiers = append(modifiers, modifier.NewGPUUtilizationModifier(logger, cfg, *container, gpuLister))
+		case "plugins":
+			modifiers = append(modifiers, modifier.NewPluginsModifier(logger, cfg, *container, driver))
+		case "env-sanitization":
+			envSanitizationModifier, err := modifier.NewEnvSanitizationModifier(logger, cfg, *container, bundleDir)
+			if err != nil {
+				return nil, err
+			}
+			modifiers = append(modifiers, envSanitizationModifier)
+		case "device-node-ownership":
+			deviceNodeOwnershipModifier, err := modifier.NewDeviceNodeOwnershipModifier(logger, cfg)
+			if err != nil {
+				return nil, err
+			}
+			modifiers = append(modifiers, deviceNodeOwnershipModifier)
+		case "container-validation":
+			modifiers = append(modifiers, modifier.NewContainerValidationModifier(logger, cfg, *container, hookCreator))
 		}
 	}
 
 	return modifiers, nil
 }
 
-func newModeModifier(logger logger.Interface, mode info.RuntimeMode, cfg *config.Config, image image.CUDA) (oci.SpecModifier, error) {
+func newModeModifier(logger logger.Interface, mode info.RuntimeMode, cfg *config.Config, image image.CUDA, driver *root.Driver) (oci.SpecModifier, error) {
 	switch mode {
 	case info.LegacyRuntimeMode:
 		return modifier.NewStableRuntimeModifier(logger, cfg.NVIDIAContainerRuntimeHookConfig.Path), nil
 	case info.CSVRuntimeMode:
 		return modifier.NewCSVModifier(logger, cfg, image)
 	case info.CDIRuntimeMode, info.JitCDIRuntimeMode:
-		return modifier.NewCDIModifier(logger, cfg, image, mode == info.JitCDIRuntimeMode)
+		return modifier.NewCDIModifier(logger, cfg, image, driver, mode == info.JitCDIRuntimeMode)
 	}
 
 	return nil, fmt.Errorf("invalid runtime mode: %v", cfg.NVIDIAContainerRuntimeConfig.Mode)
@@ -119,18 +295,32 @@ func newModeModifier(logger logger.Interface, mode info.RuntimeMode, cfg *config
 // The image is also used to determine the runtime mode to apply.
 // If a non-CDI mode is detected we ensure that the image does not process
 // annotation devices.
-func initRuntimeModeAndImage(logger logger.Interface, cfg *config.Config, ociSpec oci.Spec) (info.RuntimeMode, *image.CUDA, error) {
+func initRuntimeModeAndImage(logger logger.Interface, cfg *config.Config, ociSpec oci.Spec, gpuLister image.GPULister, opts ...image.Option) (info.RuntimeMode, *image.CUDA, error) {
 	rawSpec, err := ociSpec.Load()
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to load OCI spec: %v", err)
 	}
 
-	image, err := image.NewCUDAImageFromSpec(
-		rawSpec,
+	var deviceAliases image.DeviceAliases
+	if path := cfg.NVIDIAContainerRuntimeConfig.DeviceAliasesPath; path != "" {
+		deviceAliases, err = image.LoadDeviceAliases(path)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	imageOpts := []image.Option{
 		image.WithLogger(logger),
+		image.WithDeviceAliases(deviceAliases),
 		image.WithAcceptDeviceListAsVolumeMounts(cfg.AcceptDeviceListAsVolumeMounts),
 		image.WithAcceptEnvvarUnprivileged(cfg.AcceptEnvvarUnprivileged),
 		image.WithAnnotationsPrefixes(cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.AnnotationPrefixes),
+		image.WithDeviceRequestPrecedence(cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.DeviceRequestPrecedence),
+		image.WithGPULister(gpuLister),
+	}
+	image, err := image.NewCUDAImageFromSpec(
+		rawSpec,
+		append(imageOpts, opts...)...,
 	)
 	if err != nil {
 		return "", nil, err
@@ -154,7 +344,7 @@ func initRuntimeModeAndImage(logger logger.Interface, cfg *config.Config, ociSpe
 	// the mode resolution.
 	cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.AnnotationPrefixes = nil
 
-	return initRuntimeModeAndImage(logger, cfg, ociSpec)
+	return initRuntimeModeAndImage(logger, cfg, ociSpec, gpuLister, opts...)
 }
 
 // supportedModifierTypes returns the modifiers supported for a specific runtime mode.
@@ -162,11 +352,11 @@ func supportedModifierTypes(mode info.RuntimeMode) []string {
 	switch mode {
 	case info.CDIRuntimeMode, info.JitCDIRuntimeMode:
 		// For CDI mode we make no additional modifications.
-		return []string{"nvidia-hook-remover", "mode"}
+		return []string{"nvidia-hook-remover", "mode", "gpu-sharing", "device-order", "device-metadata", "device-health", "topology", "gpu-utilization", "compute-mode", "gpu-limits", "gpu-memory", "cleanup", "plugins", "env-sanitization", "device-node-ownership", "container-validation"}
 	case info.CSVRuntimeMode:
 		// For CSV mode we support mode and feature-gated modification.
-		return []string{"nvidia-hook-remover", "feature-gated", "mode"}
+		return []string{"nvidia-hook-remover", "feature-gated", "mode", "gpu-sharing", "device-order", "device-metadata", "device-health", "topology", "gpu-utilization", "compute-mode", "gpu-limits", "gpu-memory", "cleanup", "plugins", "env-sanitization", "device-node-ownership"}
 	default:
-		return []string{"feature-gated", "graphics", "mode"}
+		return []string{"feature-gated", "graphics", "mode", "gpu-sharing", "device-order", "device-metadata", "device-health", "topology", "gpu-utilization", "compute-mode", "gpu-limits", "gpu-memory", "cleanup", "plugins", "env-sanitization", "device-node-ownership", "container-validation"}
 	}
 }
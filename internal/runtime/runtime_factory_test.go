@@ -28,6 +28,7 @@ import (
 	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/require"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/audit"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
@@ -167,6 +168,42 @@ func TestFactoryMethod(t *testing.T) {
 	}
 }
 
+func TestFactoryMethodRecordsAuditEntry(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	driver := root.New(
+		root.WithDriverRoot("/nvidia/driver/root"),
+	)
+	auditLog := filepath.Join(t.TempDir(), "audit.log")
+
+	cfg := &config.Config{
+		NVIDIAContainerRuntimeConfig: config.RuntimeConfig{
+			Runtimes:         []string{"runc"},
+			Mode:             "legacy",
+			AuditLogFilePath: auditLog,
+		},
+	}
+
+	bundleDir := t.TempDir()
+	specFile, err := os.Create(filepath.Join(bundleDir, "config.json"))
+	require.NoError(t, err)
+	require.NoError(t, json.NewEncoder(specFile).Encode(&specs.Spec{
+		Process: &specs.Process{
+			Env: []string{"PATH=/usr/bin", "NVIDIA_VISIBLE_DEVICES=all"},
+		},
+	}))
+
+	_, err = newNVIDIAContainerRuntime(logger, cfg, []string{"--bundle", bundleDir, "create"}, driver)
+	require.NoError(t, err)
+
+	entries, err := audit.Read(auditLog)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, audit.EventCreate, entries[0].Event)
+	require.Equal(t, bundleDir, entries[0].Bundle)
+	require.Equal(t, "legacy", entries[0].Mode)
+	require.EqualValues(t, []string{"NVIDIA_VISIBLE_DEVICES=all"}, entries[0].Env)
+}
+
 func TestNewSpecModifier(t *testing.T) {
 	logger, _ := testlog.NewNullLogger()
 	driver := root.New(
@@ -335,7 +372,7 @@ func TestNewSpecModifier(t *testing.T) {
 					return tc.spec, nil
 				},
 			}
-			m, err := newSpecModifier(logger, tc.config, spec, driver)
+			m, err := newSpecModifier(logger, tc.config, spec, "", driver, nil)
 			require.NoError(t, err)
 
 			err = m.Modify(tc.spec)
@@ -0,0 +1,57 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetVersionOutputFormat(t *testing.T) {
+	testCases := []struct {
+		description    string
+		args           []string
+		expectedFormat string
+	}{
+		{
+			description: "no output flag",
+			args:        []string{"nvidia-container-runtime", "--version"},
+		},
+		{
+			description:    "output flag with equals",
+			args:           []string{"nvidia-container-runtime", "--version", "--output=json"},
+			expectedFormat: "json",
+		},
+		{
+			description:    "output flag with separate value",
+			args:           []string{"nvidia-container-runtime", "--output", "json", "--version"},
+			expectedFormat: "json",
+		},
+		{
+			description: "output argument is not a flag",
+			args:        []string{"nvidia-container-runtime", "output", "--version"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expectedFormat, getVersionOutputFormat(tc.args))
+		})
+	}
+}
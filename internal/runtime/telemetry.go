@@ -0,0 +1,48 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package runtime
+
+import (
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/telemetry"
+)
+
+const telemetryComponent = "nvidia-container-runtime"
+
+// reportUsage sends an anonymized usage report for a container create
+// operation if usage reporting has been enabled in the config. Failures to
+// send the report are only logged since reporting must not affect the
+// creation of the container.
+func (r rt) reportUsage(cfg *config.Config, argv []string, err error) {
+	if !cfg.Telemetry.IsEnabled() || !oci.HasCreateSubcommand(argv) {
+		return
+	}
+
+	var spec *specs.Spec
+	if ociSpec, specErr := oci.NewSpec(r.logger, argv); specErr == nil {
+		spec, _ = ociSpec.Load()
+	}
+
+	report := telemetry.NewCreateReport(telemetryComponent, cfg.NVIDIAContainerRuntimeConfig.Mode, spec, err)
+	if err := telemetry.Send(cfg.Telemetry.Endpoint, report); err != nil {
+		r.logger.Debugf("Failed to send usage report: %v", err)
+	}
+}
@@ -0,0 +1,233 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package snapshot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock"
+)
+
+// NVML returns an implementation of the NVML interface that reports the state
+// recorded in the snapshot. Only the functions required to generate CDI
+// specifications are implemented. Properties that were not recorded are
+// reported as not supported.
+func (s *Snapshot) NVML() nvml.Interface {
+	var devices []nvml.Device
+	devicesByUUID := make(map[string]nvml.Device)
+	for _, d := range s.Devices {
+		device, migDevices := d.nvml()
+		devices = append(devices, device)
+		devicesByUUID[d.UUID] = device
+		for i, m := range d.MigDevices {
+			devicesByUUID[m.UUID] = migDevices[i]
+		}
+	}
+
+	return &mock.Interface{
+		InitFunc: func() nvml.Return {
+			return nvml.SUCCESS
+		},
+		ShutdownFunc: func() nvml.Return {
+			return nvml.SUCCESS
+		},
+		ExtensionsFunc: func() nvml.ExtendedInterface {
+			return &mock.ExtendedInterface{
+				LookupSymbolFunc: func(string) error {
+					return fmt.Errorf("symbol lookup is not supported for snapshots")
+				},
+			}
+		},
+		SystemGetDriverVersionFunc: func() (string, nvml.Return) {
+			return s.DriverVersion, nvml.SUCCESS
+		},
+		SystemGetCudaDriverVersionFunc: func() (int, nvml.Return) {
+			if s.CUDADriverVersion == 0 {
+				return 0, nvml.ERROR_NOT_SUPPORTED
+			}
+			return s.CUDADriverVersion, nvml.SUCCESS
+		},
+		SystemGetConfComputeStateFunc: func() (nvml.ConfComputeSystemState, nvml.Return) {
+			if s.ConfComputeState == nil {
+				return nvml.ConfComputeSystemState{}, nvml.ERROR_NOT_SUPPORTED
+			}
+			return *s.ConfComputeState, nvml.SUCCESS
+		},
+		DeviceGetCountFunc: func() (int, nvml.Return) {
+			return len(devices), nvml.SUCCESS
+		},
+		DeviceGetHandleByIndexFunc: func(index int) (nvml.Device, nvml.Return) {
+			if index < 0 || index >= len(devices) {
+				return nil, nvml.ERROR_INVALID_ARGUMENT
+			}
+			return devices[index], nvml.SUCCESS
+		},
+		DeviceGetHandleByUUIDFunc: func(uuid string) (nvml.Device, nvml.Return) {
+			device, ok := devicesByUUID[uuid]
+			if !ok {
+				return nil, nvml.ERROR_NOT_FOUND
+			}
+			return device, nvml.SUCCESS
+		},
+		DeviceGetHandleByPciBusIdFunc: func(busID string) (nvml.Device, nvml.Return) {
+			for i, d := range s.Devices {
+				if strings.EqualFold(d.PCI.BusID, busID) {
+					return devices[i], nvml.SUCCESS
+				}
+			}
+			return nil, nvml.ERROR_NOT_FOUND
+		},
+		EventSetCreateFunc: func() (nvml.EventSet, nvml.Return) {
+			return nil, nvml.ERROR_NOT_SUPPORTED
+		},
+	}
+}
+
+// nvml returns a mock NVML device that reports the recorded properties of the
+// device. The mock MIG devices of the device are also returned.
+func (d Device) nvml() (*mock.Device, []*mock.Device) {
+	device := &mock.Device{
+		GetIndexFunc: func() (int, nvml.Return) {
+			return d.Index, nvml.SUCCESS
+		},
+		GetUUIDFunc: func() (string, nvml.Return) {
+			return d.UUID, nvml.SUCCESS
+		},
+		GetNameFunc: func() (string, nvml.Return) {
+			return d.Name, nvml.SUCCESS
+		},
+		GetMinorNumberFunc: func() (int, nvml.Return) {
+			return d.Minor, nvml.SUCCESS
+		},
+		GetPciInfoFunc: func() (nvml.PciInfo, nvml.Return) {
+			return d.PCI.nvml(), nvml.SUCCESS
+		},
+		GetBrandFunc: func() (nvml.BrandType, nvml.Return) {
+			return d.Brand, nvml.SUCCESS
+		},
+		GetArchitectureFunc: func() (nvml.DeviceArchitecture, nvml.Return) {
+			return d.Architecture, nvml.SUCCESS
+		},
+		GetCudaComputeCapabilityFunc: func() (int, int, nvml.Return) {
+			var major, minor int
+			if _, err := fmt.Sscanf(d.CudaComputeCapability, "%d.%d", &major, &minor); err != nil {
+				return 0, 0, nvml.ERROR_NOT_SUPPORTED
+			}
+			return major, minor, nvml.SUCCESS
+		},
+		GetGspFirmwareModeFunc: func() (bool, bool, nvml.Return) {
+			if d.GSPFirmwareMode == nil {
+				return false, false, nvml.ERROR_NOT_SUPPORTED
+			}
+			return *d.GSPFirmwareMode, *d.GSPFirmwareMode, nvml.SUCCESS
+		},
+		GetPersistenceModeFunc: func() (nvml.EnableState, nvml.Return) {
+			if d.PersistenceMode == nil {
+				return nvml.FEATURE_DISABLED, nvml.ERROR_NOT_SUPPORTED
+			}
+			if *d.PersistenceMode {
+				return nvml.FEATURE_ENABLED, nvml.SUCCESS
+			}
+			return nvml.FEATURE_DISABLED, nvml.SUCCESS
+		},
+		GetMigModeFunc: func() (int, int, nvml.Return) {
+			if d.MigMode == nil {
+				return 0, 0, nvml.ERROR_NOT_SUPPORTED
+			}
+			return *d.MigMode, *d.MigMode, nvml.SUCCESS
+		},
+		IsMigDeviceHandleFunc: func() (bool, nvml.Return) {
+			return false, nvml.SUCCESS
+		},
+		// The memory info is not recorded. This means that MIG profiles
+		// cannot be determined for the MIG devices of the device.
+		GetMemoryInfoFunc: func() (nvml.Memory, nvml.Return) {
+			return nvml.Memory{}, nvml.ERROR_NOT_SUPPORTED
+		},
+	}
+
+	var migDevices []*mock.Device
+	for _, m := range d.MigDevices {
+		migDevices = append(migDevices, m.nvml(device))
+	}
+	device.GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+		if d.MigMode == nil {
+			return 0, nvml.ERROR_NOT_SUPPORTED
+		}
+		count := 0
+		for _, m := range d.MigDevices {
+			count = max(count, m.Index+1)
+		}
+		return count, nvml.SUCCESS
+	}
+	device.GetMigDeviceHandleByIndexFunc = func(index int) (nvml.Device, nvml.Return) {
+		for i, m := range d.MigDevices {
+			if m.Index == index {
+				return migDevices[i], nvml.SUCCESS
+			}
+		}
+		return nil, nvml.ERROR_NOT_FOUND
+	}
+
+	return device, migDevices
+}
+
+// nvml returns a mock NVML device that reports the recorded properties of the
+// MIG device with the specified parent.
+func (m MigDevice) nvml(parent *mock.Device) *mock.Device {
+	return &mock.Device{
+		GetUUIDFunc: func() (string, nvml.Return) {
+			return m.UUID, nvml.SUCCESS
+		},
+		GetGpuInstanceIdFunc: func() (int, nvml.Return) {
+			return m.GPUInstanceID, nvml.SUCCESS
+		},
+		GetComputeInstanceIdFunc: func() (int, nvml.Return) {
+			return m.ComputeInstanceID, nvml.SUCCESS
+		},
+		IsMigDeviceHandleFunc: func() (bool, nvml.Return) {
+			return true, nvml.SUCCESS
+		},
+		GetDeviceHandleFromMigDeviceHandleFunc: func() (nvml.Device, nvml.Return) {
+			return parent, nvml.SUCCESS
+		},
+		GetAttributesFunc: func() (nvml.DeviceAttributes, nvml.Return) {
+			return nvml.DeviceAttributes{}, nvml.ERROR_NOT_SUPPORTED
+		},
+	}
+}
+
+// nvml returns the NVML PCI info for the recorded PCI device.
+func (p PCIInfo) nvml() nvml.PciInfo {
+	info := nvml.PciInfo{
+		Domain:         p.Domain,
+		Bus:            p.Bus,
+		Device:         p.Device,
+		PciDeviceId:    p.DeviceID,
+		PciSubSystemId: p.SubSystemID,
+	}
+	for i := 0; i < len(p.BusID) && i < len(info.BusId)-1; i++ {
+		info.BusId[i] = int8(p.BusID[i])
+	}
+	for i := 0; i < len(p.BusID) && i < len(info.BusIdLegacy)-1; i++ {
+		info.BusIdLegacy[i] = int8(p.BusID[i])
+	}
+	return info
+}
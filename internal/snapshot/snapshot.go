@@ -0,0 +1,275 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package snapshot records the state of the NVIDIA driver and GPUs on a system
+// as reported by NVML so that CDI specifications can be generated for the
+// system without access to its hardware.
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+)
+
+// Version is the version of the snapshot format.
+const Version = "1.0"
+
+// A Snapshot describes the state of the NVIDIA driver and GPUs on a system.
+type Snapshot struct {
+	// Version is the version of the snapshot format.
+	Version string `json:"version"`
+	// DriverVersion is the version of the NVIDIA driver.
+	DriverVersion string `json:"driverVersion"`
+	// CUDADriverVersion is the CUDA version supported by the driver as
+	// reported by NVML (e.g. 12040 for CUDA 12.4).
+	CUDADriverVersion int `json:"cudaDriverVersion,omitempty"`
+	// ConfComputeState is the confidential computing state of the system. This
+	// is not set if confidential computing is not supported.
+	ConfComputeState *nvml.ConfComputeSystemState `json:"confComputeState,omitempty"`
+	// Devices are the GPUs on the system.
+	Devices []Device `json:"devices"`
+	// DeviceNodes are the NVIDIA device nodes that were found on the system.
+	DeviceNodes []string `json:"deviceNodes,omitempty"`
+	// DriverFiles are the driver libraries that were found on the system.
+	DriverFiles []string `json:"driverFiles,omitempty"`
+}
+
+// A Device describes a single GPU.
+type Device struct {
+	Index                 int                     `json:"index"`
+	UUID                  string                  `json:"uuid"`
+	Name                  string                  `json:"name"`
+	Minor                 int                     `json:"minor"`
+	PCI                   PCIInfo                 `json:"pci"`
+	Brand                 nvml.BrandType          `json:"brand"`
+	Architecture          nvml.DeviceArchitecture `json:"architecture"`
+	CudaComputeCapability string                  `json:"cudaComputeCapability,omitempty"`
+	// MigMode is the current MIG mode of the device. This is not set if the
+	// device does not support MIG.
+	MigMode *int `json:"migMode,omitempty"`
+	// GSPFirmwareMode indicates whether GSP firmware is enabled. This is not
+	// set if the mode could not be queried.
+	GSPFirmwareMode *bool `json:"gspFirmwareMode,omitempty"`
+	// PersistenceMode indicates whether persistence mode is enabled. This is
+	// not set if the mode could not be queried.
+	PersistenceMode *bool       `json:"persistenceMode,omitempty"`
+	MigDevices      []MigDevice `json:"migDevices,omitempty"`
+}
+
+// PCIInfo describes the PCI device of a GPU.
+type PCIInfo struct {
+	BusID       string `json:"busID"`
+	Domain      uint32 `json:"domain"`
+	Bus         uint32 `json:"bus"`
+	Device      uint32 `json:"device"`
+	DeviceID    uint32 `json:"deviceID"`
+	SubSystemID uint32 `json:"subSystemID"`
+}
+
+// A MigDevice describes a MIG device of a GPU.
+type MigDevice struct {
+	Index             int    `json:"index"`
+	UUID              string `json:"uuid"`
+	GPUInstanceID     int    `json:"gpuInstanceID"`
+	ComputeInstanceID int    `json:"computeInstanceID"`
+}
+
+// Load reads a snapshot from the specified file.
+func Load(filename string) (*Snapshot, error) {
+	contents, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	var s Snapshot
+	if err := json.Unmarshal(contents, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %v: %w", filename, err)
+	}
+	if s.Version != Version {
+		return nil, fmt.Errorf("unsupported snapshot version %q", s.Version)
+	}
+	return &s, nil
+}
+
+// WriteTo writes the snapshot as JSON to the specified writer.
+func (s *Snapshot) WriteTo(w io.Writer) (int64, error) {
+	contents, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+	n, err := w.Write(append(contents, '\n'))
+	return int64(n), err
+}
+
+// FromNVML creates a snapshot of the driver and devices reported by the
+// specified NVML library. Properties that are not supported by a device are
+// omitted.
+func FromNVML(nvmllib nvml.Interface) (*Snapshot, error) {
+	if ret := nvmllib.Init(); ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to initialize NVML: %v", ret)
+	}
+	defer func() {
+		_ = nvmllib.Shutdown()
+	}()
+
+	driverVersion, ret := nvmllib.SystemGetDriverVersion()
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get driver version: %v", ret)
+	}
+
+	s := &Snapshot{
+		Version:       Version,
+		DriverVersion: driverVersion,
+	}
+	if cudaDriverVersion, ret := nvmllib.SystemGetCudaDriverVersion(); ret == nvml.SUCCESS {
+		s.CUDADriverVersion = cudaDriverVersion
+	}
+	if state, ret := nvmllib.SystemGetConfComputeState(); ret == nvml.SUCCESS {
+		s.ConfComputeState = &state
+	}
+
+	count, ret := nvmllib.DeviceGetCount()
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get device count: %v", ret)
+	}
+	for i := 0; i < count; i++ {
+		handle, ret := nvmllib.DeviceGetHandleByIndex(i)
+		if ret != nvml.SUCCESS {
+			return nil, fmt.Errorf("failed to get handle for device %d: %v", i, ret)
+		}
+		device, err := newDevice(i, handle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get snapshot of device %d: %w", i, err)
+		}
+		s.Devices = append(s.Devices, *device)
+	}
+
+	return s, nil
+}
+
+func newDevice(index int, handle nvml.Device) (*Device, error) {
+	d := &Device{
+		Index: index,
+	}
+
+	var ret nvml.Return
+	if d.UUID, ret = handle.GetUUID(); ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get UUID: %v", ret)
+	}
+	if d.Name, ret = handle.GetName(); ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get name: %v", ret)
+	}
+	if d.Minor, ret = handle.GetMinorNumber(); ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get minor number: %v", ret)
+	}
+	pciInfo, ret := handle.GetPciInfo()
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get PCI info: %v", ret)
+	}
+	d.PCI = newPCIInfo(pciInfo)
+	if d.Brand, ret = handle.GetBrand(); ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get brand: %v", ret)
+	}
+	if d.Architecture, ret = handle.GetArchitecture(); ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get architecture: %v", ret)
+	}
+	if major, minor, ret := handle.GetCudaComputeCapability(); ret == nvml.SUCCESS {
+		d.CudaComputeCapability = fmt.Sprintf("%d.%d", major, minor)
+	}
+	if enabled, _, ret := handle.GetGspFirmwareMode(); ret == nvml.SUCCESS {
+		d.GSPFirmwareMode = &enabled
+	}
+	if mode, ret := handle.GetPersistenceMode(); ret == nvml.SUCCESS {
+		enabled := mode == nvml.FEATURE_ENABLED
+		d.PersistenceMode = &enabled
+	}
+
+	migMode, _, ret := handle.GetMigMode()
+	switch ret {
+	case nvml.SUCCESS:
+		d.MigMode = &migMode
+	case nvml.ERROR_NOT_SUPPORTED:
+		return d, nil
+	default:
+		return nil, fmt.Errorf("failed to get MIG mode: %v", ret)
+	}
+	if migMode != nvml.DEVICE_MIG_ENABLE {
+		return d, nil
+	}
+
+	migDevices, err := newMigDevices(handle)
+	if err != nil {
+		return nil, err
+	}
+	d.MigDevices = migDevices
+
+	return d, nil
+}
+
+func newMigDevices(handle nvml.Device) ([]MigDevice, error) {
+	count, ret := handle.GetMaxMigDeviceCount()
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get MIG device count: %v", ret)
+	}
+
+	var migDevices []MigDevice
+	for i := 0; i < count; i++ {
+		migHandle, ret := handle.GetMigDeviceHandleByIndex(i)
+		if ret == nvml.ERROR_NOT_FOUND {
+			continue
+		}
+		if ret != nvml.SUCCESS {
+			return nil, fmt.Errorf("failed to get handle for MIG device %d: %v", i, ret)
+		}
+
+		m := MigDevice{
+			Index: i,
+		}
+		if m.UUID, ret = migHandle.GetUUID(); ret != nvml.SUCCESS {
+			return nil, fmt.Errorf("failed to get UUID of MIG device %d: %v", i, ret)
+		}
+		if m.GPUInstanceID, ret = migHandle.GetGpuInstanceId(); ret != nvml.SUCCESS {
+			return nil, fmt.Errorf("failed to get GPU instance ID of MIG device %d: %v", i, ret)
+		}
+		if m.ComputeInstanceID, ret = migHandle.GetComputeInstanceId(); ret != nvml.SUCCESS {
+			return nil, fmt.Errorf("failed to get compute instance ID of MIG device %d: %v", i, ret)
+		}
+		migDevices = append(migDevices, m)
+	}
+	return migDevices, nil
+}
+
+func newPCIInfo(info nvml.PciInfo) PCIInfo {
+	var busID []byte
+	for _, b := range info.BusId {
+		if b == 0 {
+			break
+		}
+		busID = append(busID, byte(b))
+	}
+	return PCIInfo{
+		BusID:       string(busID),
+		Domain:      info.Domain,
+		Bus:         info.Bus,
+		Device:      info.Device,
+		DeviceID:    info.PciDeviceId,
+		SubSystemID: info.PciSubSystemId,
+	}
+}
@@ -0,0 +1,164 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package snapshot
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTrip(t *testing.T) {
+	server := dgxa100.New()
+	server.SystemGetConfComputeStateFunc = func() (nvml.ConfComputeSystemState, nvml.Return) {
+		return nvml.ConfComputeSystemState{}, nvml.ERROR_NOT_SUPPORTED
+	}
+	for i, d := range server.Devices {
+		busID := fmt.Sprintf("00000000:%02X:00.0", i+1)
+		(d.(*dgxa100.Device)).GetPciInfoFunc = func() (nvml.PciInfo, nvml.Return) {
+			info := nvml.PciInfo{
+				PciDeviceId: 0x20B010DE,
+			}
+			for j := range busID {
+				info.BusId[j] = int8(busID[j])
+			}
+			return info, nvml.SUCCESS
+		}
+		(d.(*dgxa100.Device)).GetGspFirmwareModeFunc = func() (bool, bool, nvml.Return) {
+			return true, true, nvml.SUCCESS
+		}
+		(d.(*dgxa100.Device)).GetPersistenceModeFunc = func() (nvml.EnableState, nvml.Return) {
+			return 0, nvml.ERROR_NOT_SUPPORTED
+		}
+	}
+
+	s, err := FromNVML(server)
+	require.NoError(t, err)
+	require.Equal(t, Version, s.Version)
+	require.Len(t, s.Devices, len(server.Devices))
+
+	filename := filepath.Join(t.TempDir(), "snapshot.json")
+	var buf bytes.Buffer
+	_, err = s.WriteTo(&buf)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filename, buf.Bytes(), 0600))
+
+	loaded, err := Load(filename)
+	require.NoError(t, err)
+	require.EqualValues(t, s, loaded)
+
+	replayed, err := FromNVML(loaded.NVML())
+	require.NoError(t, err)
+	require.EqualValues(t, s, replayed)
+
+	nvmllib := loaded.NVML()
+	for i, d := range s.Devices {
+		byIndex, ret := nvmllib.DeviceGetHandleByIndex(i)
+		require.Equal(t, nvml.SUCCESS, ret)
+
+		byUUID, ret := nvmllib.DeviceGetHandleByUUID(d.UUID)
+		require.Equal(t, nvml.SUCCESS, ret)
+		require.Same(t, byIndex, byUUID)
+
+		byBusID, ret := nvmllib.DeviceGetHandleByPciBusId(d.PCI.BusID)
+		require.Equal(t, nvml.SUCCESS, ret)
+		require.Same(t, byIndex, byBusID)
+	}
+}
+
+func TestMigDevices(t *testing.T) {
+	migMode := nvml.DEVICE_MIG_ENABLE
+	s := &Snapshot{
+		Version:       Version,
+		DriverVersion: "999.88.77",
+		Devices: []Device{
+			{
+				Index:   0,
+				UUID:    "GPU-0",
+				Name:    "Mock GPU",
+				PCI:     PCIInfo{BusID: "00000000:01:00.0"},
+				MigMode: &migMode,
+				MigDevices: []MigDevice{
+					{Index: 0, UUID: "MIG-0", GPUInstanceID: 1, ComputeInstanceID: 0},
+					{Index: 2, UUID: "MIG-2", GPUInstanceID: 2, ComputeInstanceID: 0},
+				},
+			},
+		},
+	}
+
+	replayed, err := FromNVML(s.NVML())
+	require.NoError(t, err)
+	require.EqualValues(t, s, replayed)
+
+	nvmllib := s.NVML()
+	migDevice, ret := nvmllib.DeviceGetHandleByUUID("MIG-2")
+	require.Equal(t, nvml.SUCCESS, ret)
+	isMig, ret := migDevice.IsMigDeviceHandle()
+	require.Equal(t, nvml.SUCCESS, ret)
+	require.True(t, isMig)
+
+	parent, ret := migDevice.GetDeviceHandleFromMigDeviceHandle()
+	require.Equal(t, nvml.SUCCESS, ret)
+	uuid, ret := parent.GetUUID()
+	require.Equal(t, nvml.SUCCESS, ret)
+	require.Equal(t, "GPU-0", uuid)
+}
+
+func TestLoad(t *testing.T) {
+	testCases := []struct {
+		description   string
+		contents      string
+		expectedError bool
+	}{
+		{
+			description: "supported version",
+			contents:    `{"version": "1.0", "driverVersion": "999.88.77"}`,
+		},
+		{
+			description:   "unsupported version",
+			contents:      `{"version": "2.0", "driverVersion": "999.88.77"}`,
+			expectedError: true,
+		},
+		{
+			description:   "invalid json",
+			contents:      `{"version":`,
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "snapshot.json")
+			require.NoError(t, os.WriteFile(filename, []byte(tc.contents), 0600))
+
+			s, err := Load(filename)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "999.88.77", s.DriverVersion)
+		})
+	}
+}
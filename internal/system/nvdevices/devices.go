@@ -19,9 +19,11 @@ package nvdevices
 import (
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/changeset"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/info/proc/devices"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 )
@@ -35,9 +37,16 @@ type Interface struct {
 	logger logger.Interface
 
 	dryRun bool
+	// changes records the device nodes that would be created for a dry-run.
+	changes *changeset.ChangeSet
 	// devRoot is the root directory where device nodes are expected to exist.
 	devRoot string
 
+	// uid, gid, and fileMode define the properties of created device nodes.
+	uid      *uint32
+	gid      *uint32
+	fileMode *os.FileMode
+
 	mknoder
 }
 
@@ -62,10 +71,15 @@ func New(opts ...Option) (*Interface, error) {
 		i.Devices = devices
 	}
 
+	if i.fileMode == nil {
+		defaultFileMode := os.FileMode(0666)
+		i.fileMode = &defaultFileMode
+	}
+
 	if i.dryRun {
-		i.mknoder = &mknodLogger{i.logger}
+		i.mknoder = &mknodLogger{i.logger, *i.fileMode, i.uid, i.gid, i.changes}
 	} else {
-		i.mknoder = &mknodUnix{i.logger}
+		i.mknoder = &mknodUnix{i.logger, *i.fileMode, i.uid, i.gid}
 	}
 	return i, nil
 }
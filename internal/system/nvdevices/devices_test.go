@@ -18,11 +18,14 @@ package nvdevices
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/require"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/changeset"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/info/proc/devices"
 )
 
@@ -150,3 +153,35 @@ func TestCreateControlDevices(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateControlDevicesDryRun(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	devRoot := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(devRoot, "dev"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(devRoot, "dev", "nvidiactl"), nil, 0600))
+
+	changes := changeset.New(true)
+	d, err := New(
+		WithLogger(logger),
+		WithDryRun(true),
+		WithChangeSet(changes),
+		WithDevRoot(devRoot),
+		WithDevices(devices.New(
+			devices.WithDeviceToMajor(map[string]int{
+				"nvidia":     195,
+				"nvidia-uvm": 243,
+			}),
+		)),
+	)
+	require.NoError(t, err)
+	require.NoError(t, d.CreateNVIDIAControlDevices())
+
+	expectedChanges := []changeset.Change{
+		{Action: changeset.ActionCreate, Path: filepath.Join(devRoot, "dev/nvidia-modeset"), Description: "character device 195:254 with mode 0666"},
+		{Action: changeset.ActionCreate, Path: filepath.Join(devRoot, "dev/nvidia-uvm"), Description: "character device 243:0 with mode 0666"},
+		{Action: changeset.ActionCreate, Path: filepath.Join(devRoot, "dev/nvidia-uvm-tools"), Description: "character device 243:1 with mode 0666"},
+	}
+	require.True(t, changes.Changed)
+	require.EqualValues(t, expectedChanges, changes.Changes)
+	require.NoFileExists(t, filepath.Join(devRoot, "dev", "nvidia-uvm"))
+}
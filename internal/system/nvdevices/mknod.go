@@ -22,6 +22,7 @@ import (
 
 	"golang.org/x/sys/unix"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/changeset"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 )
 
@@ -32,15 +33,37 @@ type mknoder interface {
 
 type mknodLogger struct {
 	logger.Interface
+	fileMode os.FileMode
+	uid      *uint32
+	gid      *uint32
+	changes  *changeset.ChangeSet
 }
 
 func (m *mknodLogger) Mknode(path string, major, minor int) error {
-	m.Infof("Running: mknod --mode=0666 %s c %d %d", path, major, minor)
+	m.Infof("Running: mknod --mode=%#o %s c %d %d", m.fileMode.Perm(), path, major, minor)
+	if m.uid != nil || m.gid != nil {
+		m.Infof("Running: chown %s %s", formatOwnership(m.uid, m.gid), path)
+	}
+	if m.changes == nil {
+		return nil
+	}
+	// Existing device nodes are skipped when the device nodes are created.
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		return nil
+	}
+	m.changes.Add(changeset.Change{
+		Action:      changeset.ActionCreate,
+		Path:        path,
+		Description: fmt.Sprintf("character device %d:%d with mode %#o", major, minor, m.fileMode.Perm()),
+	})
 	return nil
 }
 
 type mknodUnix struct {
-	logger logger.Interface
+	logger   logger.Interface
+	fileMode os.FileMode
+	uid      *uint32
+	gid      *uint32
 }
 
 func (m *mknodUnix) Mknode(path string, major, minor int) error {
@@ -56,5 +79,31 @@ func (m *mknodUnix) Mknode(path string, major, minor int) error {
 	if err != nil {
 		return err
 	}
-	return unix.Chmod(path, 0666)
+	if err := unix.Chmod(path, uint32(m.fileMode.Perm())); err != nil {
+		return err
+	}
+	if m.uid == nil && m.gid == nil {
+		return nil
+	}
+	// A value of -1 leaves the corresponding ID unchanged.
+	uid, gid := -1, -1
+	if m.uid != nil {
+		uid = int(*m.uid)
+	}
+	if m.gid != nil {
+		gid = int(*m.gid)
+	}
+	return unix.Chown(path, uid, gid)
+}
+
+// formatOwnership returns the owner and group in the form expected by chown.
+func formatOwnership(uid *uint32, gid *uint32) string {
+	var s string
+	if uid != nil {
+		s = fmt.Sprintf("%d", *uid)
+	}
+	if gid != nil {
+		s += fmt.Sprintf(":%d", *gid)
+	}
+	return s
 }
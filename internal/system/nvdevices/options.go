@@ -17,6 +17,9 @@
 package nvdevices
 
 import (
+	"os"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/changeset"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/info/proc/devices"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 )
@@ -31,6 +34,14 @@ func WithDryRun(dryRun bool) Option {
 	}
 }
 
+// WithChangeSet sets the change set in which the device nodes that would be
+// created are recorded for a dry-run.
+func WithChangeSet(changes *changeset.ChangeSet) Option {
+	return func(i *Interface) {
+		i.changes = changes
+	}
+}
+
 // WithLogger sets the logger for the Interface struct.
 func WithLogger(logger logger.Interface) Option {
 	return func(i *Interface) {
@@ -51,3 +62,13 @@ func WithDevices(devices devices.Devices) Option {
 		i.Devices = devices
 	}
 }
+
+// WithDeviceNodeOwnership sets the owner, group, and file mode for created
+// device nodes. Nil values retain the default behaviour.
+func WithDeviceNodeOwnership(uid *uint32, gid *uint32, fileMode *os.FileMode) Option {
+	return func(i *Interface) {
+		i.uid = uid
+		i.gid = gid
+		i.fileMode = fileMode
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"os/exec"
 	"strings"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/changeset"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 )
 
@@ -32,10 +33,18 @@ type cmder interface {
 
 type cmderLogger struct {
 	logger.Interface
+	changes *changeset.ChangeSet
 }
 
 func (c *cmderLogger) Run(cmd string, args ...string) error {
 	c.Infof("Running: %v %v", cmd, strings.Join(args, " "))
+	if c.changes != nil {
+		c.changes.Add(changeset.Change{
+			Action:      changeset.ActionRun,
+			Path:        cmd,
+			Description: strings.Join(append([]string{cmd}, args...), " "),
+		})
+	}
 	return nil
 }
 
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/changeset"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 )
 
@@ -28,7 +29,9 @@ type Interface struct {
 	logger logger.Interface
 
 	dryRun bool
-	root   string
+	// changes records the commands that would be run for a dry-run.
+	changes *changeset.ChangeSet
+	root    string
 
 	cmder
 }
@@ -48,7 +51,7 @@ func New(opts ...Option) *Interface {
 	}
 
 	if m.dryRun {
-		m.cmder = &cmderLogger{m.logger}
+		m.cmder = &cmderLogger{m.logger, m.changes}
 	} else {
 		m.cmder = &cmderExec{}
 	}
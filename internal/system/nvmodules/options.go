@@ -16,7 +16,10 @@
 
 package nvmodules
 
-import "github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+import (
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/changeset"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
 
 // Option is a function that sets an option on the Interface struct.
 type Option func(*Interface)
@@ -28,6 +31,14 @@ func WithDryRun(dryRun bool) Option {
 	}
 }
 
+// WithChangeSet sets the change set in which the commands that would be run
+// are recorded for a dry-run.
+func WithChangeSet(changes *changeset.ChangeSet) Option {
+	return func(i *Interface) {
+		i.changes = changes
+	}
+}
+
 // WithLogger sets the logger for the Interface struct.
 func WithLogger(logger logger.Interface) Option {
 	return func(i *Interface) {
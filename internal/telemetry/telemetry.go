@@ -0,0 +1,133 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package telemetry implements opt-in usage reporting for the NVIDIA
+// Container Toolkit. Reports are anonymized: they contain the toolkit version,
+// the injection mode, the container engine type, and the class of a failure,
+// but no host, container, device, or user identifiers.
+package telemetry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/messages"
+)
+
+const (
+	// EventCreate is reported when a container is created.
+	EventCreate = "create"
+
+	// EngineUnknown is reported if the container engine cannot be determined.
+	EngineUnknown = "unknown"
+	// FailureUnclassified is reported for failures that are not associated
+	// with a message in the catalog.
+	FailureUnclassified = "unclassified"
+
+	// defaultTimeout is the maximum time that is spent sending a report.
+	// This is kept short since reports are sent while a container is being
+	// created.
+	defaultTimeout = time.Second
+)
+
+// A Report is a single anonymized usage report.
+type Report struct {
+	Version   string `json:"version"`
+	Component string `json:"component"`
+	Event     string `json:"event"`
+	Mode      string `json:"mode,omitempty"`
+	Engine    string `json:"engine,omitempty"`
+	// FailureClass is the catalog ID of the error that caused the operation
+	// to fail. It is empty if the operation succeeded.
+	FailureClass string `json:"failureClass,omitempty"`
+}
+
+// NewCreateReport returns a report for the creation of the container with the
+// specified OCI spec by the specified component using the specified mode.
+func NewCreateReport(component string, mode string, spec *specs.Spec, err error) *Report {
+	return &Report{
+		Version:      info.GetVersionParts()[0],
+		Component:    component,
+		Event:        EventCreate,
+		Mode:         mode,
+		Engine:       GetEngine(spec),
+		FailureClass: GetFailureClass(err),
+	}
+}
+
+// GetEngine returns the type of the container engine that created the
+// specified OCI spec. This is based on the annotations and cgroups paths that
+// the engines are known to set.
+func GetEngine(spec *specs.Spec) string {
+	if spec == nil {
+		return EngineUnknown
+	}
+	if spec.Annotations["io.container.manager"] == "libpod" {
+		return "podman"
+	}
+	for key := range spec.Annotations {
+		switch {
+		case strings.HasPrefix(key, "io.kubernetes.cri-o."):
+			return "cri-o"
+		case strings.HasPrefix(key, "io.kubernetes.cri."), strings.HasPrefix(key, "nerdctl/"):
+			return "containerd"
+		}
+	}
+	if spec.Linux != nil && strings.Contains(spec.Linux.CgroupsPath, "docker") {
+		return "docker"
+	}
+	return EngineUnknown
+}
+
+// GetFailureClass returns the class of the specified error. Only the catalog
+// ID is reported since error messages may contain identifying information.
+func GetFailureClass(err error) string {
+	if err == nil {
+		return ""
+	}
+	if id, ok := messages.GetID(err); ok {
+		return string(id)
+	}
+	return FailureUnclassified
+}
+
+// Send posts the specified report as JSON to the specified endpoint.
+func Send(endpoint string, r *Report) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+
+	client := &http.Client{Timeout: defaultTimeout}
+	resp, err := client.Post(endpoint, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to send report: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response from %v: %v", endpoint, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package telemetry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/messages"
+)
+
+func TestGetEngine(t *testing.T) {
+	testCases := []struct {
+		description    string
+		spec           *specs.Spec
+		expectedEngine string
+	}{
+		{
+			description:    "nil spec",
+			expectedEngine: EngineUnknown,
+		},
+		{
+			description: "podman",
+			spec: &specs.Spec{
+				Annotations: map[string]string{"io.container.manager": "libpod"},
+			},
+			expectedEngine: "podman",
+		},
+		{
+			description: "cri-o",
+			spec: &specs.Spec{
+				Annotations: map[string]string{"io.kubernetes.cri-o.ContainerType": "container"},
+			},
+			expectedEngine: "cri-o",
+		},
+		{
+			description: "containerd cri plugin",
+			spec: &specs.Spec{
+				Annotations: map[string]string{"io.kubernetes.cri.container-type": "container"},
+			},
+			expectedEngine: "containerd",
+		},
+		{
+			description: "docker",
+			spec: &specs.Spec{
+				Linux: &specs.Linux{CgroupsPath: "system.slice:docker:0123456789ab"},
+			},
+			expectedEngine: "docker",
+		},
+		{
+			description:    "no known markers",
+			spec:           &specs.Spec{},
+			expectedEngine: EngineUnknown,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expectedEngine, GetEngine(tc.spec))
+		})
+	}
+}
+
+func TestGetFailureClass(t *testing.T) {
+	require.Equal(t, "", GetFailureClass(nil))
+	require.Equal(t, FailureUnclassified, GetFailureClass(fmt.Errorf("failed on host example.com")))
+	require.Equal(t,
+		string(messages.LowLevelRuntimeNotFound),
+		GetFailureClass(fmt.Errorf("wrapped: %w", messages.New(messages.LowLevelRuntimeNotFound, nil))),
+	)
+}
+
+func TestSend(t *testing.T) {
+	var received Report
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	report := NewCreateReport("nvidia-container-runtime", "cdi", &specs.Spec{}, nil)
+	require.NoError(t, Send(server.URL, report))
+	require.EqualValues(t, *report, received)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	require.Error(t, Send(failing.URL, report))
+}
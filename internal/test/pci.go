@@ -0,0 +1,44 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// CreatePCIDevice creates a PCI device in the specified sysfs root in the
+// specified IOMMU group and bound to the specified driver. The path to the
+// device is returned.
+func CreatePCIDevice(t testing.TB, sysfs string, address string, group string, driver string) string {
+	devicePath := filepath.Join(sysfs, "bus", "pci", "devices", address)
+	groupPath := filepath.Join(sysfs, "kernel", "iommu_groups", group)
+	require.NoError(t, os.MkdirAll(devicePath, 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(groupPath, "devices"), 0755))
+	require.NoError(t, os.Symlink(groupPath, filepath.Join(devicePath, "iommu_group")))
+	require.NoError(t, os.Symlink(devicePath, filepath.Join(groupPath, "devices", address)))
+	if driver != "" {
+		driverPath := filepath.Join(sysfs, "bus", "pci", "drivers", driver)
+		require.NoError(t, os.MkdirAll(driverPath, 0755))
+		require.NoError(t, os.Symlink(driverPath, filepath.Join(devicePath, "driver")))
+	}
+	return devicePath
+}
@@ -0,0 +1,90 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package vfio
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvpci"
+)
+
+const (
+	// PCIDriver is the name of the driver that devices passed through to a
+	// VM must be bound to.
+	PCIDriver = "vfio-pci"
+)
+
+// compatibleDrivers lists the drivers that may be bound to other devices in
+// the IOMMU group of a GPU that is passed through using VFIO.
+var compatibleDrivers = map[string]bool{
+	PCIDriver:  true,
+	"pci-stub": true,
+	"pcieport": true,
+}
+
+// PCIDevice represents a member of an IOMMU group and the driver that it is
+// bound to. The driver is empty if no driver is bound.
+type PCIDevice struct {
+	Address string
+	Driver  string
+}
+
+// IsCompatibleDriver checks whether the specified driver may be bound to a
+// device in the IOMMU group of a GPU that is passed through using VFIO.
+func IsCompatibleDriver(driver string) bool {
+	return compatibleDrivers[driver]
+}
+
+// GetIOMMUGroupDevices returns the devices in the IOMMU group of the
+// specified GPU (including the GPU itself) sorted by PCI address.
+func GetIOMMUGroupDevices(gpu *nvpci.NvidiaPCIDevice) ([]PCIDevice, error) {
+	groupDevicesPath := filepath.Join(gpu.Path, "iommu_group", "devices")
+	members, err := os.ReadDir(groupDevicesPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read members of IOMMU group %v: %w", gpu.IommuGroup, err)
+	}
+
+	var devices []PCIDevice
+	for _, member := range members {
+		driver, err := getPCIDeviceDriver(filepath.Join(groupDevicesPath, member.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get driver for device %v: %w", member.Name(), err)
+		}
+		devices = append(devices, PCIDevice{Address: member.Name(), Driver: driver})
+	}
+	sort.Slice(devices, func(i, j int) bool {
+		return devices[i].Address < devices[j].Address
+	})
+	return devices, nil
+}
+
+// getPCIDeviceDriver returns the name of the driver bound to the specified PCI
+// device. An empty string is returned if no driver is bound.
+func getPCIDeviceDriver(devicePath string) (string, error) {
+	driver, err := filepath.EvalSymlinks(filepath.Join(devicePath, "driver"))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return filepath.Base(driver), nil
+}
@@ -0,0 +1,77 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package vfio
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvpci"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+)
+
+func TestGetIOMMUGroupDevices(t *testing.T) {
+	testCases := []struct {
+		description     string
+		setup           func(string) *nvpci.NvidiaPCIDevice
+		expectedDevices []PCIDevice
+		expectedError   bool
+	}{
+		{
+			description: "members are returned sorted with their drivers",
+			setup: func(sysfs string) *nvpci.NvidiaPCIDevice {
+				test.CreatePCIDevice(t, sysfs, "0000:01:00.1", "10", "snd_hda_intel")
+				test.CreatePCIDevice(t, sysfs, "0000:01:00.2", "10", "")
+				return &nvpci.NvidiaPCIDevice{
+					Address:    "0000:01:00.0",
+					IommuGroup: 10,
+					Path:       test.CreatePCIDevice(t, sysfs, "0000:01:00.0", "10", "nvidia"),
+				}
+			},
+			expectedDevices: []PCIDevice{
+				{Address: "0000:01:00.0", Driver: "nvidia"},
+				{Address: "0000:01:00.1", Driver: "snd_hda_intel"},
+				{Address: "0000:01:00.2", Driver: ""},
+			},
+		},
+		{
+			description: "missing IOMMU group returns error",
+			setup: func(sysfs string) *nvpci.NvidiaPCIDevice {
+				return &nvpci.NvidiaPCIDevice{
+					Address:    "0000:01:00.0",
+					IommuGroup: 10,
+					Path:       sysfs,
+				}
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			devices, err := GetIOMMUGroupDevices(tc.setup(t.TempDir()))
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedDevices, devices)
+		})
+	}
+}
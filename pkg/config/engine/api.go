@@ -27,6 +27,30 @@ type Interface interface {
 	String() string
 }
 
+// CDIChecker is implemented by runtime configs that can report whether the
+// use of CDI devices is enabled.
+type CDIChecker interface {
+	IsCDIEnabled() bool
+}
+
+// CDIDisabler is implemented by runtime configs that allow the use of CDI
+// devices to be disabled again.
+type CDIDisabler interface {
+	DisableCDI()
+}
+
+// DefaultRuntimeSetter is implemented by runtime configs that allow the
+// default runtime to be set to an existing runtime.
+type DefaultRuntimeSetter interface {
+	SetDefaultRuntime(string)
+}
+
+// CDISpecDirsSetter is implemented by runtime configs that allow the
+// directories that are searched for CDI specifications to be configured.
+type CDISpecDirsSetter interface {
+	SetCDISpecDirs(...string)
+}
+
 // RuntimeConfig defines the interface to query container runtime handler configuration
 type RuntimeConfig interface {
 	GetBinaryPath() string
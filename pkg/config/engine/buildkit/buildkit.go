@@ -0,0 +1,169 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package buildkit
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/toml"
+)
+
+// Config represents the buildkitd config.
+// Since the OCI worker of buildkitd only supports a single low-level runtime,
+// the NVIDIA runtime is configured as the binary for this worker.
+type Config struct {
+	*toml.Tree
+	Logger logger.Interface
+}
+
+type buildkitRuntime struct {
+	tree *toml.Tree
+}
+
+var _ engine.RuntimeConfig = (*buildkitRuntime)(nil)
+
+// GetBinaryPath retrieves the path to the low-level runtime binary for the
+// OCI worker. If no path is available, the empty string is returned.
+func (c *buildkitRuntime) GetBinaryPath() string {
+	if c.tree != nil {
+		if binaryPath, ok := c.tree.GetPath([]string{"binary"}).(string); ok {
+			return binaryPath
+		}
+	}
+	return ""
+}
+
+var _ engine.Interface = (*Config)(nil)
+var _ engine.CDIChecker = (*Config)(nil)
+var _ engine.CDISpecDirsSetter = (*Config)(nil)
+
+// New creates a buildkitd config with the specified options
+func New(opts ...Option) (engine.Interface, error) {
+	b := &builder{}
+	for _, opt := range opts {
+		opt(b)
+	}
+	if b.logger == nil {
+		b.logger = logger.New()
+	}
+	if b.configSource == nil {
+		b.configSource = toml.FromFile(b.path)
+	}
+
+	tomlConfig, err := b.configSource.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := Config{
+		Tree:   tomlConfig,
+		Logger: b.logger,
+	}
+	return &cfg, nil
+}
+
+// AddRuntime sets the specified runtime as the binary for the OCI worker.
+// Since only a single runtime is supported by the worker, the runtime name is
+// ignored and the runtime is always used for all build steps.
+func (c *Config) AddRuntime(name string, path string, setAsDefault bool) error {
+	if c == nil {
+		return fmt.Errorf("config is nil")
+	}
+	if !setAsDefault {
+		c.Logger.Infof("The buildkitd OCI worker only supports a single runtime; using %v for all build steps", path)
+	}
+
+	config := *c.Tree
+	config.SetPath([]string{"worker", "oci", "binary"}, path)
+
+	*c.Tree = config
+	return nil
+}
+
+// DefaultRuntime returns the binary configured for the OCI worker.
+func (c *Config) DefaultRuntime() string {
+	if c == nil || c.Tree == nil {
+		return ""
+	}
+	if binary, ok := c.GetPath([]string{"worker", "oci", "binary"}).(string); ok {
+		return binary
+	}
+	return ""
+}
+
+// RemoveRuntime removes the binary configured for the OCI worker so that the
+// buildkitd default (runc) is used.
+func (c *Config) RemoveRuntime(name string) error {
+	if c == nil {
+		return nil
+	}
+
+	config := *c.Tree
+	binaryPath := []string{"worker", "oci", "binary"}
+	config.DeletePath(binaryPath)
+	for i := 1; i < len(binaryPath); i++ {
+		remainingPath := binaryPath[:len(binaryPath)-i]
+		if entry, ok := config.GetPath(remainingPath).(*toml.Tree); ok {
+			if len(entry.Keys()) != 0 {
+				break
+			}
+			config.DeletePath(remainingPath)
+		}
+	}
+
+	*c.Tree = config
+	return nil
+}
+
+// GetRuntimeConfig returns the runtime config for the OCI worker.
+// Since only a single runtime is supported, the name is ignored.
+func (c *Config) GetRuntimeConfig(name string) (engine.RuntimeConfig, error) {
+	if c == nil || c.Tree == nil {
+		return nil, fmt.Errorf("config is nil")
+	}
+	return &buildkitRuntime{
+		tree: c.GetSubtreeByPath([]string{"worker", "oci"}),
+	}, nil
+}
+
+// EnableCDI enables the use of CDI devices in build steps.
+func (c *Config) EnableCDI() {
+	config := *c.Tree
+	config.SetPath([]string{"cdi", "disabled"}, false)
+	*c.Tree = config
+}
+
+// SetCDISpecDirs sets the directories that are searched for CDI
+// specifications in build steps.
+func (c *Config) SetCDISpecDirs(dirs ...string) {
+	config := *c.Tree
+	config.SetPath([]string{"cdi", "specDirs"}, dirs)
+	*c.Tree = config
+}
+
+// IsCDIEnabled returns whether the use of CDI devices in build steps is
+// enabled. This is disabled by default.
+func (c *Config) IsCDIEnabled() bool {
+	if c == nil || c.Tree == nil {
+		return false
+	}
+	disabled, ok := c.GetPath([]string{"cdi", "disabled"}).(bool)
+	return ok && !disabled
+}
@@ -0,0 +1,137 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package buildkit
+
+import (
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/toml"
+)
+
+func TestAddRuntime(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	testCases := []struct {
+		description    string
+		config         string
+		enableCDI      bool
+		expectedConfig string
+	}{
+		{
+			description: "empty config",
+			expectedConfig: `
+			[worker.oci]
+			binary = "/usr/bin/nvidia-container-runtime"
+			`,
+		},
+		{
+			description: "existing worker options are retained",
+			config: `
+			[worker.oci]
+			enabled = true
+			binary = "/usr/bin/runc"
+			`,
+			expectedConfig: `
+			[worker.oci]
+			enabled = true
+			binary = "/usr/bin/nvidia-container-runtime"
+			`,
+		},
+		{
+			description: "CDI is enabled",
+			enableCDI:   true,
+			expectedConfig: `
+			[cdi]
+			disabled = false
+			[worker.oci]
+			binary = "/usr/bin/nvidia-container-runtime"
+			`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cfg, err := toml.Load(tc.config)
+			require.NoError(t, err)
+			expectedConfig, err := toml.Load(tc.expectedConfig)
+			require.NoError(t, err)
+
+			c := &Config{
+				Logger: logger,
+				Tree:   cfg,
+			}
+
+			err = c.AddRuntime("nvidia", "/usr/bin/nvidia-container-runtime", true)
+			require.NoError(t, err)
+			if tc.enableCDI {
+				c.EnableCDI()
+			}
+
+			require.EqualValues(t, expectedConfig.String(), cfg.String())
+			require.Equal(t, "/usr/bin/nvidia-container-runtime", c.DefaultRuntime())
+		})
+	}
+}
+
+func TestRemoveRuntime(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	testCases := []struct {
+		description    string
+		config         string
+		expectedConfig string
+	}{
+		{
+			description: "empty worker is removed",
+			config: `
+			[worker.oci]
+			binary = "/usr/bin/nvidia-container-runtime"
+			`,
+		},
+		{
+			description: "other worker options are retained",
+			config: `
+			[worker.oci]
+			enabled = true
+			binary = "/usr/bin/nvidia-container-runtime"
+			`,
+			expectedConfig: `
+			[worker.oci]
+			enabled = true
+			`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cfg, err := toml.Load(tc.config)
+			require.NoError(t, err)
+			expectedConfig, err := toml.Load(tc.expectedConfig)
+			require.NoError(t, err)
+
+			c := &Config{
+				Logger: logger,
+				Tree:   cfg,
+			}
+
+			require.NoError(t, c.RemoveRuntime("nvidia"))
+			require.EqualValues(t, expectedConfig.String(), cfg.String())
+		})
+	}
+}
@@ -111,6 +111,38 @@ func (c *Config) EnableCDI() {
 	*c.Tree = config
 }
 
+// DisableCDI removes the enable_cdi field from the Containerd config.
+func (c *Config) DisableCDI() {
+	config := *c.Tree
+	config.DeletePath([]string{"plugins", c.CRIRuntimePluginName, "enable_cdi"})
+	*c.Tree = config
+}
+
+// SetDefaultRuntime sets the default_runtime_name field in the Containerd
+// config.
+func (c *Config) SetDefaultRuntime(name string) {
+	config := *c.Tree
+	config.SetPath([]string{"plugins", c.CRIRuntimePluginName, "containerd", "default_runtime_name"}, name)
+	*c.Tree = config
+}
+
+// SetCDISpecDirs sets the cdi_spec_dirs field in the Containerd config.
+func (c *Config) SetCDISpecDirs(dirs ...string) {
+	config := *c.Tree
+	config.SetPath([]string{"plugins", c.CRIRuntimePluginName, "cdi_spec_dirs"}, dirs)
+	*c.Tree = config
+}
+
+// IsCDIEnabled returns whether the enable_cdi field is set to true in the
+// Containerd config.
+func (c *Config) IsCDIEnabled() bool {
+	if c == nil || c.Tree == nil {
+		return false
+	}
+	enabled, _ := c.GetPath([]string{"plugins", c.CRIRuntimePluginName, "enable_cdi"}).(bool)
+	return enabled
+}
+
 // RemoveRuntime removes a runtime from the docker config
 func (c *Config) RemoveRuntime(name string) error {
 	if c == nil || c.Tree == nil {
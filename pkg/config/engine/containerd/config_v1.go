@@ -27,6 +27,10 @@ import (
 type ConfigV1 Config
 
 var _ engine.Interface = (*ConfigV1)(nil)
+var _ engine.CDIChecker = (*ConfigV1)(nil)
+var _ engine.CDISpecDirsSetter = (*ConfigV1)(nil)
+var _ engine.CDIDisabler = (*ConfigV1)(nil)
+var _ engine.DefaultRuntimeSetter = (*ConfigV1)(nil)
 
 // AddRuntime adds a runtime to the containerd config
 func (c *ConfigV1) AddRuntime(name string, path string, setAsDefault bool) error {
@@ -164,3 +168,35 @@ func (c *ConfigV1) EnableCDI() {
 	config.SetPath([]string{"plugins", "cri", "containerd", "enable_cdi"}, true)
 	*c.Tree = config
 }
+
+// DisableCDI removes the enable_cdi field from the Containerd config.
+func (c *ConfigV1) DisableCDI() {
+	config := *c.Tree
+	config.DeletePath([]string{"plugins", "cri", "containerd", "enable_cdi"})
+	*c.Tree = config
+}
+
+// SetDefaultRuntime sets the default_runtime_name field in the Containerd
+// config.
+func (c *ConfigV1) SetDefaultRuntime(name string) {
+	config := *c.Tree
+	config.SetPath([]string{"plugins", "cri", "containerd", "default_runtime_name"}, name)
+	*c.Tree = config
+}
+
+// SetCDISpecDirs sets the cdi_spec_dirs field in the Containerd config.
+func (c *ConfigV1) SetCDISpecDirs(dirs ...string) {
+	config := *c.Tree
+	config.SetPath([]string{"plugins", "cri", "containerd", "cdi_spec_dirs"}, dirs)
+	*c.Tree = config
+}
+
+// IsCDIEnabled returns whether the enable_cdi field is set to true in the
+// Containerd config.
+func (c *ConfigV1) IsCDIEnabled() bool {
+	if c == nil || c.Tree == nil {
+		return false
+	}
+	enabled, _ := c.GetPath([]string{"plugins", "cri", "containerd", "enable_cdi"}).(bool)
+	return enabled
+}
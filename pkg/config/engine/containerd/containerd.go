@@ -49,6 +49,10 @@ type Config struct {
 }
 
 var _ engine.Interface = (*Config)(nil)
+var _ engine.CDIChecker = (*Config)(nil)
+var _ engine.CDISpecDirsSetter = (*Config)(nil)
+var _ engine.CDIDisabler = (*Config)(nil)
+var _ engine.DefaultRuntimeSetter = (*Config)(nil)
 
 type containerdCfgRuntime struct {
 	tree *toml.Tree
@@ -48,6 +48,9 @@ func (c *crioRuntime) GetBinaryPath() string {
 }
 
 var _ engine.Interface = (*Config)(nil)
+var _ engine.CDIChecker = (*Config)(nil)
+var _ engine.CDISpecDirsSetter = (*Config)(nil)
+var _ engine.DefaultRuntimeSetter = (*Config)(nil)
 
 // New creates a cri-o config with the specified options
 func New(opts ...Option) (engine.Interface, error) {
@@ -143,6 +146,13 @@ func (c *Config) RemoveRuntime(name string) error {
 	return nil
 }
 
+// SetDefaultRuntime sets the default_runtime option in the cri-o config.
+func (c *Config) SetDefaultRuntime(name string) {
+	config := *c.Tree
+	config.SetPath([]string{"crio", "runtime", "default_runtime"}, name)
+	*c.Tree = config
+}
+
 func (c *Config) GetRuntimeConfig(name string) (engine.RuntimeConfig, error) {
 	if c == nil || c.Tree == nil {
 		return nil, fmt.Errorf("config is nil")
@@ -156,6 +166,19 @@ func (c *Config) GetRuntimeConfig(name string) (engine.RuntimeConfig, error) {
 // EnableCDI is a no-op for CRI-O since it always enabled where supported.
 func (c *Config) EnableCDI() {}
 
+// SetCDISpecDirs sets the cdi_spec_dirs field in the CRI-O config.
+func (c *Config) SetCDISpecDirs(dirs ...string) {
+	config := *c.Tree
+	config.SetPath([]string{"crio", "runtime", "cdi_spec_dirs"}, dirs)
+	*c.Tree = config
+}
+
+// IsCDIEnabled always returns true for CRI-O since CDI is enabled where
+// supported.
+func (c *Config) IsCDIEnabled() bool {
+	return true
+}
+
 // CommandLineSource returns the CLI-based crio config loader
 func CommandLineSource(hostRoot string, executablePath string) toml.Loader {
 	if executablePath == "" {
@@ -202,3 +202,27 @@ monitor_path = "/usr/libexec/crio/conmon"
 		})
 	}
 }
+
+func TestSetCDISpecDirs(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	cfg, err := toml.Load(`
+	[crio.runtime]
+	default_runtime = "crun"
+	`)
+	require.NoError(t, err)
+	expectedConfig, err := toml.Load(`
+	[crio.runtime]
+	cdi_spec_dirs = ["/var/run/cdi/tenants/team-a"]
+	default_runtime = "crun"
+	`)
+	require.NoError(t, err)
+
+	c := &Config{
+		Logger: logger,
+		Tree:   cfg,
+	}
+	c.SetCDISpecDirs("/var/run/cdi/tenants/team-a")
+
+	require.EqualValues(t, expectedConfig.String(), cfg.String())
+}
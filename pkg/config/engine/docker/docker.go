@@ -34,6 +34,10 @@ const (
 type Config map[string]interface{}
 
 var _ engine.Interface = (*Config)(nil)
+var _ engine.CDIChecker = (*Config)(nil)
+var _ engine.CDISpecDirsSetter = (*Config)(nil)
+var _ engine.CDIDisabler = (*Config)(nil)
+var _ engine.DefaultRuntimeSetter = (*Config)(nil)
 
 type dockerRuntime map[string]interface{}
 
@@ -110,17 +114,76 @@ func (c *Config) EnableCDI() {
 	}
 	config := *c
 
-	features, ok := config["features"].(map[string]bool)
-	if !ok {
-		features = make(map[string]bool)
+	switch features := config["features"].(type) {
+	case map[string]bool:
+		features["cdi"] = true
+	case map[string]interface{}:
+		// A config read from file stores the features as generic values.
+		features["cdi"] = true
+	default:
+		config["features"] = map[string]bool{"cdi": true}
+	}
+
+	*c = config
+}
+
+// DisableCDI removes the features.cdi setting from the docker config.
+func (c *Config) DisableCDI() {
+	if c == nil {
+		return
 	}
-	features["cdi"] = true
+	config := *c
 
-	config["features"] = features
+	switch features := config["features"].(type) {
+	case map[string]bool:
+		delete(features, "cdi")
+		if len(features) == 0 {
+			delete(config, "features")
+		}
+	case map[string]interface{}:
+		delete(features, "cdi")
+		if len(features) == 0 {
+			delete(config, "features")
+		}
+	}
 
 	*c = config
 }
 
+// SetDefaultRuntime sets the default-runtime option in the docker config.
+func (c *Config) SetDefaultRuntime(name string) {
+	if c == nil {
+		return
+	}
+	(*c)["default-runtime"] = name
+}
+
+// SetCDISpecDirs sets the cdi-spec-dirs option in the docker config.
+func (c *Config) SetCDISpecDirs(dirs ...string) {
+	if c == nil {
+		return
+	}
+	(*c)["cdi-spec-dirs"] = dirs
+}
+
+// IsCDIEnabled returns whether features.cdi is set to true in the docker
+// config.
+func (c *Config) IsCDIEnabled() bool {
+	if c == nil {
+		return false
+	}
+	features, ok := (*c)["features"].(map[string]bool)
+	if ok {
+		return features["cdi"]
+	}
+	// A config read from file stores the features as generic values.
+	if features, ok := (*c)["features"].(map[string]interface{}); ok {
+		enabled, _ := features["cdi"].(bool)
+		return enabled
+	}
+	return false
+}
+
 // RemoveRuntime removes a runtime from the docker config
 func (c *Config) RemoveRuntime(name string) error {
 	if c == nil {
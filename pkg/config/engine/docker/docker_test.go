@@ -247,3 +247,49 @@ func TestGetRuntimeConfig(t *testing.T) {
 		require.Equal(t, tc.expected, rc.GetBinaryPath())
 	}
 }
+
+func TestSetCDISpecDirs(t *testing.T) {
+	c := Config{
+		"default-runtime": "runc",
+	}
+	c.SetCDISpecDirs("/etc/cdi", "/var/run/cdi/tenants/team-a")
+
+	contents, err := json.Marshal(c)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"default-runtime": "runc", "cdi-spec-dirs": ["/etc/cdi", "/var/run/cdi/tenants/team-a"]}`, string(contents))
+}
+
+func TestDisableCDI(t *testing.T) {
+	testCases := []struct {
+		description string
+		config      Config
+		expected    string
+	}{
+		{
+			description: "only feature is removed",
+			config:      Config{"features": map[string]interface{}{"cdi": true}},
+			expected:    `{}`,
+		},
+		{
+			description: "other features are retained",
+			config:      Config{"features": map[string]interface{}{"cdi": true, "containerd-snapshotter": true}},
+			expected:    `{"features": {"containerd-snapshotter": true}}`,
+		},
+		{
+			description: "enabled feature is removed",
+			config:      Config{"features": map[string]bool{"cdi": true}},
+			expected:    `{}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.config.DisableCDI()
+			require.False(t, tc.config.IsCDIEnabled())
+
+			contents, err := json.Marshal(tc.config)
+			require.NoError(t, err)
+			require.JSONEq(t, tc.expected, string(contents))
+		})
+	}
+}
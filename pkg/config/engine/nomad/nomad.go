@@ -0,0 +1,213 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nomad
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine"
+)
+
+const (
+	defaultDockerRuntime = "runc"
+)
+
+// Config defines a Nomad agent config file fragment in JSON format.
+// Nomad merges all config files in its config directory, allowing the
+// settings required for the NVIDIA runtime to be managed in a separate file.
+// Since the runtimes themselves are registered with the Docker daemon, only
+// the list of runtimes that tasks are allowed to request is managed here.
+type Config struct {
+	config map[string]interface{}
+	logger logger.Interface
+}
+
+var _ engine.Interface = (*Config)(nil)
+
+type nomadRuntime struct{}
+
+var _ engine.RuntimeConfig = (*nomadRuntime)(nil)
+
+// GetBinaryPath returns the empty string since the runtime binaries are
+// configured in the Docker daemon config.
+func (r *nomadRuntime) GetBinaryPath() string {
+	return ""
+}
+
+// New creates a Nomad config with the specified options
+func New(opts ...Option) (engine.Interface, error) {
+	b := &builder{}
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	if b.logger == nil {
+		b.logger = logger.New()
+	}
+
+	return b.build()
+}
+
+// AddRuntime adds the specified runtime to the runtimes that tasks using the
+// docker driver are allowed to request. The default runc runtime is included
+// so that existing tasks are not affected. Since the default runtime is
+// determined by the Docker daemon, setAsDefault is ignored.
+func (c *Config) AddRuntime(name string, path string, setAsDefault bool) error {
+	if c == nil {
+		return fmt.Errorf("config is nil")
+	}
+	if setAsDefault {
+		c.logger.Warningf("The default runtime for Nomad tasks is determined by the Docker daemon config; ignoring set-as-default")
+	}
+
+	allowed := c.getAllowedRuntimes()
+	for _, runtime := range []string{defaultDockerRuntime, name} {
+		if !slices.Contains(allowed, runtime) {
+			allowed = append(allowed, runtime)
+		}
+	}
+	c.setAllowedRuntimes(allowed)
+	return nil
+}
+
+// DefaultRuntime returns the empty string since the default runtime is
+// determined by the Docker daemon config.
+func (c *Config) DefaultRuntime() string {
+	return ""
+}
+
+// EnableCDI is a no-op for Nomad. Devices are requested by the NVIDIA
+// Container Runtime from the environment variables set by the Nomad device
+// plugin.
+func (c *Config) EnableCDI() {}
+
+// RemoveRuntime removes the specified runtime from the allowed runtimes.
+func (c *Config) RemoveRuntime(name string) error {
+	if c == nil {
+		return nil
+	}
+
+	allowed := slices.DeleteFunc(c.getAllowedRuntimes(), func(runtime string) bool {
+		return runtime == name
+	})
+	if len(allowed) == 1 && allowed[0] == defaultDockerRuntime {
+		allowed = nil
+	}
+	c.setAllowedRuntimes(allowed)
+	return nil
+}
+
+// GetRuntimeConfig returns the config for the specified runtime.
+func (c *Config) GetRuntimeConfig(name string) (engine.RuntimeConfig, error) {
+	if c == nil {
+		return nil, fmt.Errorf("config is nil")
+	}
+	return &nomadRuntime{}, nil
+}
+
+// Save writes the config to the specified path. An empty config removes the
+// file.
+func (c Config) Save(path string) (int64, error) {
+	var output []byte
+	if len(c.config) > 0 {
+		var err error
+		output, err = json.MarshalIndent(c.config, "", "    ")
+		if err != nil {
+			return 0, fmt.Errorf("unable to convert to JSON: %v", err)
+		}
+	}
+
+	n, err := config.Raw(path).Write(output)
+	return int64(n), err
+}
+
+// String returns the string representation of the JSON config.
+func (c Config) String() string {
+	output, err := json.MarshalIndent(c.config, "", "    ")
+	if err != nil {
+		return fmt.Sprintf("invalid JSON: %v", err)
+	}
+	return string(output)
+}
+
+// getAllowedRuntimes returns the plugin.docker.config.allow_runtimes setting.
+func (c *Config) getAllowedRuntimes() []string {
+	dockerConfig := c.getDockerPluginConfig()
+	values, _ := dockerConfig["allow_runtimes"].([]interface{})
+
+	var allowed []string
+	for _, value := range values {
+		if runtime, ok := value.(string); ok {
+			allowed = append(allowed, runtime)
+		}
+	}
+	return allowed
+}
+
+// setAllowedRuntimes updates the plugin.docker.config.allow_runtimes setting.
+// Empty entries are removed from the config.
+func (c *Config) setAllowedRuntimes(allowed []string) {
+	if c.config == nil {
+		c.config = make(map[string]interface{})
+	}
+	plugins, _ := c.config["plugin"].(map[string]interface{})
+	if plugins == nil {
+		plugins = make(map[string]interface{})
+	}
+	docker, _ := plugins["docker"].(map[string]interface{})
+	if docker == nil {
+		docker = make(map[string]interface{})
+	}
+	dockerConfig := c.getDockerPluginConfig()
+	if dockerConfig == nil {
+		dockerConfig = make(map[string]interface{})
+	}
+
+	if len(allowed) > 0 {
+		var values []interface{}
+		for _, runtime := range allowed {
+			values = append(values, runtime)
+		}
+		dockerConfig["allow_runtimes"] = values
+	} else {
+		delete(dockerConfig, "allow_runtimes")
+	}
+
+	setOrDelete(docker, "config", dockerConfig)
+	setOrDelete(plugins, "docker", docker)
+	setOrDelete(c.config, "plugin", plugins)
+}
+
+func (c *Config) getDockerPluginConfig() map[string]interface{} {
+	plugins, _ := c.config["plugin"].(map[string]interface{})
+	docker, _ := plugins["docker"].(map[string]interface{})
+	dockerConfig, _ := docker["config"].(map[string]interface{})
+	return dockerConfig
+}
+
+func setOrDelete(m map[string]interface{}, key string, value map[string]interface{}) {
+	if len(value) == 0 {
+		delete(m, key)
+		return
+	}
+	m[key] = value
+}
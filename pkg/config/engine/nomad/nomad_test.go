@@ -0,0 +1,102 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nomad
+
+import (
+	"encoding/json"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddRuntime(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	testCases := []struct {
+		description    string
+		config         string
+		expectedConfig string
+	}{
+		{
+			description:    "empty config",
+			expectedConfig: `{"plugin": {"docker": {"config": {"allow_runtimes": ["runc", "nvidia"]}}}}`,
+		},
+		{
+			description:    "existing runtimes are retained",
+			config:         `{"plugin": {"docker": {"config": {"allow_privileged": true, "allow_runtimes": ["runc", "kata"]}}}}`,
+			expectedConfig: `{"plugin": {"docker": {"config": {"allow_privileged": true, "allow_runtimes": ["runc", "kata", "nvidia"]}}}}`,
+		},
+		{
+			description:    "runtime is not added twice",
+			config:         `{"plugin": {"docker": {"config": {"allow_runtimes": ["nvidia", "runc"]}}}}`,
+			expectedConfig: `{"plugin": {"docker": {"config": {"allow_runtimes": ["nvidia", "runc"]}}}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c := &Config{
+				config: make(map[string]interface{}),
+				logger: logger,
+			}
+			if tc.config != "" {
+				require.NoError(t, json.Unmarshal([]byte(tc.config), &c.config))
+			}
+
+			require.NoError(t, c.AddRuntime("nvidia", "/usr/bin/nvidia-container-runtime", false))
+			require.JSONEq(t, tc.expectedConfig, c.String())
+		})
+	}
+}
+
+func TestRemoveRuntime(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	testCases := []struct {
+		description    string
+		config         string
+		expectedConfig string
+	}{
+		{
+			description:    "empty config is removed",
+			config:         `{"plugin": {"docker": {"config": {"allow_runtimes": ["runc", "nvidia"]}}}}`,
+			expectedConfig: `{}`,
+		},
+		{
+			description:    "other runtimes are retained",
+			config:         `{"plugin": {"docker": {"config": {"allow_runtimes": ["runc", "kata", "nvidia"]}}}}`,
+			expectedConfig: `{"plugin": {"docker": {"config": {"allow_runtimes": ["runc", "kata"]}}}}`,
+		},
+		{
+			description:    "other settings are retained",
+			config:         `{"client": {"enabled": true}, "plugin": {"docker": {"config": {"allow_runtimes": ["runc", "nvidia"]}}}}`,
+			expectedConfig: `{"client": {"enabled": true}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c := &Config{
+				logger: logger,
+			}
+			require.NoError(t, json.Unmarshal([]byte(tc.config), &c.config))
+
+			require.NoError(t, c.RemoveRuntime("nvidia"))
+			require.JSONEq(t, tc.expectedConfig, c.String())
+		})
+	}
+}
@@ -0,0 +1,73 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nomad
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+type builder struct {
+	logger logger.Interface
+	path   string
+}
+
+// Option defines a function that can be used to configure the config builder
+type Option func(*builder)
+
+// WithLogger sets the logger for the config builder
+func WithLogger(logger logger.Interface) Option {
+	return func(b *builder) {
+		b.logger = logger
+	}
+}
+
+// WithPath sets the path for the config builder
+func WithPath(path string) Option {
+	return func(b *builder) {
+		b.path = path
+	}
+}
+
+func (b *builder) build() (*Config, error) {
+	cfg := &Config{
+		config: make(map[string]interface{}),
+		logger: b.logger,
+	}
+	if b.path == "" {
+		return cfg, nil
+	}
+
+	readBytes, err := os.ReadFile(b.path)
+	if os.IsNotExist(err) {
+		b.logger.Infof("Config file does not exist; using empty config")
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config: %v", err)
+	}
+
+	b.logger.Infof("Loading config from %v", b.path)
+	if err := json.Unmarshal(readBytes, &cfg.config); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
@@ -0,0 +1,167 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package podman
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/toml"
+)
+
+// Config represents a containers.conf file (or drop-in) as used by podman.
+// Runtimes are registered as [engine.runtimes] entries and the default
+// runtime is set using the engine.runtime setting.
+type Config struct {
+	*toml.Tree
+	Logger logger.Interface
+}
+
+type podmanRuntime struct {
+	paths []string
+}
+
+var _ engine.RuntimeConfig = (*podmanRuntime)(nil)
+
+// GetBinaryPath retrieves the first path configured for the runtime.
+// If no path is available, the empty string is returned.
+func (r *podmanRuntime) GetBinaryPath() string {
+	if r == nil || len(r.paths) == 0 {
+		return ""
+	}
+	return r.paths[0]
+}
+
+var _ engine.Interface = (*Config)(nil)
+var _ engine.CDIChecker = (*Config)(nil)
+var _ engine.CDISpecDirsSetter = (*Config)(nil)
+
+// New creates a containers.conf config with the specified options
+func New(opts ...Option) (engine.Interface, error) {
+	b := &builder{}
+	for _, opt := range opts {
+		opt(b)
+	}
+	if b.logger == nil {
+		b.logger = logger.New()
+	}
+	if b.configSource == nil {
+		b.configSource = toml.FromFile(b.path)
+	}
+
+	tomlConfig, err := b.configSource.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := Config{
+		Tree:   tomlConfig,
+		Logger: b.logger,
+	}
+	return &cfg, nil
+}
+
+// AddRuntime adds a runtime with the specified path to the config.
+func (c *Config) AddRuntime(name string, path string, setAsDefault bool) error {
+	if c == nil {
+		return fmt.Errorf("config is nil")
+	}
+
+	config := *c.Tree
+	config.SetPath([]string{"engine", "runtimes", name}, []string{path})
+	if setAsDefault {
+		config.SetPath([]string{"engine", "runtime"}, name)
+	}
+
+	*c.Tree = config
+	return nil
+}
+
+// DefaultRuntime returns the default runtime set in the config.
+func (c *Config) DefaultRuntime() string {
+	if c == nil || c.Tree == nil {
+		return ""
+	}
+	if runtime, ok := c.GetPath([]string{"engine", "runtime"}).(string); ok {
+		return runtime
+	}
+	return ""
+}
+
+// RemoveRuntime removes the specified runtime from the config. If this is
+// the default runtime, the default is also removed.
+func (c *Config) RemoveRuntime(name string) error {
+	if c == nil || c.Tree == nil {
+		return nil
+	}
+
+	config := *c.Tree
+	if c.DefaultRuntime() == name {
+		config.DeletePath([]string{"engine", "runtime"})
+	}
+	runtimePath := []string{"engine", "runtimes", name}
+	config.DeletePath(runtimePath)
+	for i := 1; i < len(runtimePath); i++ {
+		remainingPath := runtimePath[:len(runtimePath)-i]
+		if entry, ok := config.GetPath(remainingPath).(*toml.Tree); ok {
+			if len(entry.Keys()) != 0 {
+				break
+			}
+			config.DeletePath(remainingPath)
+		}
+	}
+
+	*c.Tree = config
+	return nil
+}
+
+// GetRuntimeConfig returns the config for the specified runtime.
+func (c *Config) GetRuntimeConfig(name string) (engine.RuntimeConfig, error) {
+	if c == nil || c.Tree == nil {
+		return nil, fmt.Errorf("config is nil")
+	}
+	r := &podmanRuntime{}
+	switch paths := c.GetPath([]string{"engine", "runtimes", name}).(type) {
+	case []string:
+		r.paths = paths
+	case []interface{}:
+		for _, path := range paths {
+			if p, ok := path.(string); ok {
+				r.paths = append(r.paths, p)
+			}
+		}
+	}
+	return r, nil
+}
+
+// EnableCDI is a no-op since CDI support is built into podman.
+func (c *Config) EnableCDI() {}
+
+// SetCDISpecDirs sets the engine.cdi_spec_dirs setting in the containers.conf
+// file.
+func (c *Config) SetCDISpecDirs(dirs ...string) {
+	config := *c.Tree
+	config.SetPath([]string{"engine", "cdi_spec_dirs"}, dirs)
+	*c.Tree = config
+}
+
+// IsCDIEnabled always returns true since CDI support is built into podman.
+func (c *Config) IsCDIEnabled() bool {
+	return true
+}
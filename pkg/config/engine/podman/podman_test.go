@@ -0,0 +1,136 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package podman
+
+import (
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/toml"
+)
+
+func TestAddRuntime(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	testCases := []struct {
+		description     string
+		config          string
+		setAsDefault    bool
+		expectedConfig  string
+		expectedDefault string
+	}{
+		{
+			description: "empty config",
+			expectedConfig: `
+			[engine.runtimes]
+			nvidia = ["/usr/bin/nvidia-container-runtime"]
+			`,
+		},
+		{
+			description:  "runtime is set as default",
+			setAsDefault: true,
+			config: `
+			[engine]
+			cgroup_manager = "systemd"
+			`,
+			expectedConfig: `
+			[engine]
+			cgroup_manager = "systemd"
+			runtime = "nvidia"
+			[engine.runtimes]
+			nvidia = ["/usr/bin/nvidia-container-runtime"]
+			`,
+			expectedDefault: "nvidia",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cfg, err := toml.Load(tc.config)
+			require.NoError(t, err)
+			expectedConfig, err := toml.Load(tc.expectedConfig)
+			require.NoError(t, err)
+
+			c := &Config{
+				Logger: logger,
+				Tree:   cfg,
+			}
+
+			require.NoError(t, c.AddRuntime("nvidia", "/usr/bin/nvidia-container-runtime", tc.setAsDefault))
+			require.EqualValues(t, expectedConfig.String(), cfg.String())
+			require.Equal(t, tc.expectedDefault, c.DefaultRuntime())
+
+			runtime, err := c.GetRuntimeConfig("nvidia")
+			require.NoError(t, err)
+			require.Equal(t, "/usr/bin/nvidia-container-runtime", runtime.GetBinaryPath())
+		})
+	}
+}
+
+func TestRemoveRuntime(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	testCases := []struct {
+		description    string
+		config         string
+		expectedConfig string
+	}{
+		{
+			description: "empty engine is removed",
+			config: `
+			[engine]
+			runtime = "nvidia"
+			[engine.runtimes]
+			nvidia = ["/usr/bin/nvidia-container-runtime"]
+			`,
+		},
+		{
+			description: "other settings are retained",
+			config: `
+			[engine]
+			runtime = "crun"
+			[engine.runtimes]
+			nvidia = ["/usr/bin/nvidia-container-runtime"]
+			crun = ["/usr/bin/crun"]
+			`,
+			expectedConfig: `
+			[engine]
+			runtime = "crun"
+			[engine.runtimes]
+			crun = ["/usr/bin/crun"]
+			`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cfg, err := toml.Load(tc.config)
+			require.NoError(t, err)
+			expectedConfig, err := toml.Load(tc.expectedConfig)
+			require.NoError(t, err)
+
+			c := &Config{
+				Logger: logger,
+				Tree:   cfg,
+			}
+
+			require.NoError(t, c.RemoveRuntime("nvidia"))
+			require.EqualValues(t, expectedConfig.String(), cfg.String())
+		})
+	}
+}
@@ -0,0 +1,237 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/filelock"
+)
+
+const (
+	defaultFileMode = 0644
+)
+
+// A FileSet is a set of config files that are updated as a unit. The
+// contents of all files are first written to temporary files in the target
+// directories and synced to disk. Only once all files have been staged are
+// they renamed into place. This ensures that an interrupted update never
+// leaves a partially written config file that prevents a container engine
+// from starting.
+type FileSet struct {
+	files []*pendingFile
+}
+
+type pendingFile struct {
+	path     string
+	contents []byte
+
+	staged   string
+	original []byte
+	existed  bool
+	applied  bool
+}
+
+// Add adds the specified file to the set. Empty contents cause the file to be
+// removed when the set is committed.
+func (s *FileSet) Add(path string, contents []byte) {
+	for _, f := range s.files {
+		if f.path == path {
+			f.contents = contents
+			return
+		}
+	}
+	s.files = append(s.files, &pendingFile{path: path, contents: contents})
+}
+
+// Commit writes the files in the set. An exclusive lock is held for each file
+// while the set is committed. If the lock for a file is already held by the
+// current process (e.g. since the file is loaded and saved under the lock),
+// it is not acquired again. If a file cannot be staged, none of the files
+// are modified. If a file cannot be renamed into place, the files that have
+// already been updated are restored.
+func (s *FileSet) Commit() (rerr error) {
+	var paths []string
+	for _, f := range s.files {
+		paths = append(paths, f.path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if filelock.IsHeld(path) {
+			continue
+		}
+		lock, err := filelock.Acquire(path)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := lock.Unlock(); err != nil && rerr == nil {
+				rerr = fmt.Errorf("failed to unlock %v: %w", path, err)
+			}
+		}()
+	}
+
+	defer s.cleanup()
+	for _, f := range s.files {
+		if err := f.stage(); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range s.files {
+		if err := f.apply(); err != nil {
+			return errors.Join(err, s.rollback())
+		}
+	}
+	return nil
+}
+
+// stage records the original contents of the file and writes the new
+// contents to a temporary file in the same directory.
+func (f *pendingFile) stage() error {
+	original, err := os.ReadFile(f.path)
+	switch {
+	case err == nil:
+		f.original = original
+		f.existed = true
+	case !os.IsNotExist(err):
+		return fmt.Errorf("unable to read %v: %v", f.path, err)
+	}
+
+	if len(f.contents) == 0 {
+		if !f.existed {
+			return fmt.Errorf("unable to remove empty file: %v does not exist", f.path)
+		}
+		return nil
+	}
+
+	staged, err := writeTemp(f.path, f.contents)
+	if err != nil {
+		return err
+	}
+	f.staged = staged
+	return nil
+}
+
+// apply moves the staged file into place or removes the file if the new
+// contents are empty.
+func (f *pendingFile) apply() error {
+	if f.staged == "" {
+		if err := os.Remove(f.path); err != nil {
+			return fmt.Errorf("unable to remove empty file: %v", err)
+		}
+	} else {
+		if err := os.Rename(f.staged, f.path); err != nil {
+			return fmt.Errorf("unable to replace %v: %v", f.path, err)
+		}
+		f.staged = ""
+	}
+	f.applied = true
+	return syncDir(filepath.Dir(f.path))
+}
+
+// rollback restores the original contents of the files that have already
+// been applied.
+func (s *FileSet) rollback() error {
+	var errs error
+	for _, f := range s.files {
+		if !f.applied {
+			continue
+		}
+		if !f.existed {
+			if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+				errs = errors.Join(errs, fmt.Errorf("unable to remove %v: %v", f.path, err))
+			}
+			continue
+		}
+		restored, err := writeTemp(f.path, f.original)
+		if err == nil {
+			err = os.Rename(restored, f.path)
+		}
+		if err != nil {
+			os.Remove(restored)
+			errs = errors.Join(errs, fmt.Errorf("unable to restore %v: %v", f.path, err))
+		}
+	}
+	return errs
+}
+
+// cleanup removes any staged files that were not applied.
+func (s *FileSet) cleanup() {
+	for _, f := range s.files {
+		if f.staged != "" {
+			os.Remove(f.staged)
+			f.staged = ""
+		}
+	}
+}
+
+// writeTemp writes the specified contents to a temporary file in the
+// directory of path and syncs it to disk. The file mode of an existing file
+// at path is preserved. The path of the temporary file is returned.
+func writeTemp(path string, contents []byte) (string, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("unable to create directory %v: %v", dir, err)
+	}
+
+	mode := os.FileMode(defaultFileMode)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	f, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return "", fmt.Errorf("unable to create temporary file for %v: %v", path, err)
+	}
+	err = func() error {
+		if _, err := f.Write(contents); err != nil {
+			return err
+		}
+		if err := f.Chmod(mode); err != nil {
+			return err
+		}
+		return f.Sync()
+	}()
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("unable to write temporary file for %v: %v", path, err)
+	}
+	return f.Name(), nil
+}
+
+// syncDir syncs the specified directory so that renames and removals in the
+// directory are persisted.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("unable to open directory %v: %v", dir, err)
+	}
+	defer d.Close()
+	if err := d.Sync(); err != nil {
+		return fmt.Errorf("unable to sync directory %v: %v", dir, err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSetCommit(t *testing.T) {
+	testCases := []struct {
+		description      string
+		existing         map[string]string
+		updates          map[string]string
+		expectedError    bool
+		expectedContents map[string]string
+	}{
+		{
+			description: "files are written",
+			existing: map[string]string{
+				"daemon.json": "{}",
+			},
+			updates: map[string]string{
+				"daemon.json":        `{"runtimes": {}}`,
+				"conf.d/nvidia.toml": "version = 2",
+			},
+			expectedContents: map[string]string{
+				"daemon.json":        `{"runtimes": {}}`,
+				"conf.d/nvidia.toml": "version = 2",
+			},
+		},
+		{
+			description: "empty contents remove file",
+			existing: map[string]string{
+				"daemon.json": "{}",
+			},
+			updates: map[string]string{
+				"daemon.json": "",
+			},
+			expectedContents: map[string]string{},
+		},
+		{
+			description: "no files are modified if a file cannot be staged",
+			existing: map[string]string{
+				"daemon.json": "{}",
+				"not-a-dir":   "",
+			},
+			updates: map[string]string{
+				"daemon.json":           `{"runtimes": {}}`,
+				"not-a-dir/config.toml": "version = 2",
+			},
+			expectedError: true,
+			expectedContents: map[string]string{
+				"daemon.json": "{}",
+				"not-a-dir":   "",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, contents := range tc.existing {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(contents), 0600))
+			}
+
+			var files FileSet
+			for name, contents := range tc.updates {
+				files.Add(filepath.Join(dir, name), []byte(contents))
+			}
+			err := files.Commit()
+			if tc.expectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			contents := make(map[string]string)
+			err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+				if err != nil || info.IsDir() || filepath.Ext(path) == ".lock" {
+					return err
+				}
+				name, _ := filepath.Rel(dir, path)
+				data, err := os.ReadFile(path)
+				contents[name] = string(data)
+				return err
+			})
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedContents, contents)
+		})
+	}
+}
+
+func TestFileSetPreservesFileMode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(path, []byte("version = 1"), 0600))
+
+	var files FileSet
+	files.Add(path, []byte("version = 2"))
+	require.NoError(t, files.Commit())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
@@ -17,40 +17,34 @@
 package ocihook
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config"
 )
 
-// CreateHook creates an OCI hook file for the specified NVIDIA Container Runtime hook path
+// CreateHook creates an OCI hook file for the specified NVIDIA Container Runtime hook path.
+// The hook file is replaced atomically so that an interrupted write does not
+// leave a partially written hook file.
 func CreateHook(hookFilePath string, nvidiaContainerRuntimeHookExecutablePath string) error {
-	var output io.Writer
-	if hookFilePath == "" {
-		output = os.Stdout
-	} else {
-		if hooksDir := filepath.Dir(hookFilePath); hooksDir != "" {
-			err := os.MkdirAll(hooksDir, 0755)
-			if err != nil {
-				return fmt.Errorf("error creating hooks directory %v: %v", hooksDir, err)
-			}
-		}
-
-		hookFile, err := os.Create(hookFilePath)
-		if err != nil {
-			return fmt.Errorf("error creating hook file '%v': %v", hookFilePath, err)
-		}
-		defer hookFile.Close()
-		output = hookFile
-	}
-
-	encoder := json.NewEncoder(output)
+	var output bytes.Buffer
+	encoder := json.NewEncoder(&output)
 	encoder.SetIndent("", "  ")
 	if err := encoder.Encode(generateOciHook(nvidiaContainerRuntimeHookExecutablePath)); err != nil {
 		return fmt.Errorf("error writing hook file: %v", err)
 	}
+
+	if hookFilePath == "" {
+		_, err := os.Stdout.Write(output.Bytes())
+		return err
+	}
+	if _, err := config.Raw(hookFilePath).Write(output.Bytes()); err != nil {
+		return fmt.Errorf("error creating hook file '%v': %v", hookFilePath, err)
+	}
 	return nil
 }
 
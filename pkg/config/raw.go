@@ -19,13 +19,16 @@ package config
 import (
 	"fmt"
 	"os"
-	"path/filepath"
 )
 
 // Raw represents a raw config file
 type Raw string
 
-// Write writes the specified contents to a config file.
+// Write writes the specified contents to a config file. The file is replaced
+// atomically so that an interrupted write does not leave a partially written
+// file. An exclusive lock is held while the file is written so that
+// concurrent writes from different components do not corrupt the file. If
+// the contents are empty, the file is removed.
 func (c Raw) Write(output []byte) (int, error) {
 	path := string(c)
 	if path == "" {
@@ -36,26 +39,10 @@ func (c Raw) Write(output []byte) (int, error) {
 		return n, err
 	}
 
-	if len(output) == 0 {
-		err := os.Remove(path)
-		if err != nil {
-			return 0, fmt.Errorf("unable to remove empty file: %v", err)
-		}
-		return 0, nil
-	}
-
-	if dir := filepath.Dir(path); dir != "" {
-		err := os.MkdirAll(dir, 0755)
-		if err != nil {
-			return 0, fmt.Errorf("unable to create directory %v: %v", dir, err)
-		}
+	var files FileSet
+	files.Add(path, output)
+	if err := files.Commit(); err != nil {
+		return 0, fmt.Errorf("unable to write %v: %w", path, err)
 	}
-
-	f, err := os.Create(path)
-	if err != nil {
-		return 0, fmt.Errorf("unable to open %v for writing: %v", path, err)
-	}
-	defer f.Close()
-
-	return f.Write(output)
+	return len(output), nil
 }
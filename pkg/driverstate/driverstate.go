@@ -0,0 +1,187 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package driverstate defines the handshake between containerized NVIDIA
+// drivers and the NVIDIA Container Toolkit.
+//
+// A driver container writes a Driver state file to a shared state directory
+// each time it starts, upgrades, or starts unloading the driver. The
+// generation in the state file must change each time the driver container
+// starts. The toolkit watches the state directory, performs the required
+// reconciliation (refreshing the ldcache of the driver root, regenerating CDI
+// specifications, and signaling the container engine) and acknowledges the
+// observed state by writing a Toolkit state file to the same directory.
+package driverstate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// DefaultDir is the default directory used to exchange state files.
+	DefaultDir = "/run/nvidia/driver-state"
+	// DriverStateFilename is the name of the file written by the driver
+	// container.
+	DriverStateFilename = "driver.json"
+	// ToolkitStateFilename is the name of the file written by the toolkit.
+	ToolkitStateFilename = "toolkit.json"
+)
+
+// A DriverPhase describes the state of a containerized driver.
+type DriverPhase string
+
+const (
+	// DriverPhaseReady indicates that the driver is loaded and that its
+	// libraries and device nodes are available.
+	DriverPhaseReady = DriverPhase("ready")
+	// DriverPhaseUnloading indicates that the driver is about to be
+	// unloaded (e.g. for an upgrade).
+	DriverPhaseUnloading = DriverPhase("unloading")
+)
+
+// A ToolkitPhase describes the result of a reconciliation by the toolkit.
+type ToolkitPhase string
+
+const (
+	// ToolkitPhaseReconciled indicates that the toolkit has processed the
+	// observed driver state.
+	ToolkitPhaseReconciled = ToolkitPhase("reconciled")
+	// ToolkitPhaseFailed indicates that the toolkit failed to process the
+	// observed driver state. The message contains the reason.
+	ToolkitPhaseFailed = ToolkitPhase("failed")
+)
+
+// Driver represents the state written by a driver container.
+type Driver struct {
+	// Generation identifies a specific start of the driver container.
+	Generation string      `json:"generation"`
+	Phase      DriverPhase `json:"phase"`
+	Version    string      `json:"version,omitempty"`
+}
+
+// Toolkit represents the acknowledgement written by the toolkit.
+type Toolkit struct {
+	ObservedGeneration string       `json:"observedGeneration"`
+	ObservedPhase      DriverPhase  `json:"observedPhase"`
+	Phase              ToolkitPhase `json:"phase"`
+	Message            string       `json:"message,omitempty"`
+}
+
+// Acknowledges returns true if the toolkit state acknowledges the specified
+// driver state.
+func (t *Toolkit) Acknowledges(d *Driver) bool {
+	if t == nil || d == nil {
+		return false
+	}
+	return t.ObservedGeneration == d.Generation && t.ObservedPhase == d.Phase
+}
+
+// ReadDriver reads the driver state from the specified directory. If no
+// driver state exists, nil is returned.
+func ReadDriver(dir string) (*Driver, error) {
+	d := &Driver{}
+	if err := read(filepath.Join(dir, DriverStateFilename), d); err != nil {
+		return nil, err
+	}
+	if d.Generation == "" && d.Phase == "" {
+		return nil, nil
+	}
+	return d, nil
+}
+
+// WriteDriver atomically writes the driver state to the specified directory.
+func WriteDriver(dir string, d *Driver) error {
+	if d.Generation == "" {
+		return fmt.Errorf("a driver generation must be specified")
+	}
+	switch d.Phase {
+	case DriverPhaseReady, DriverPhaseUnloading:
+	default:
+		return fmt.Errorf("invalid driver phase %q", d.Phase)
+	}
+	return write(dir, DriverStateFilename, d)
+}
+
+// ReadToolkit reads the toolkit state from the specified directory. If no
+// toolkit state exists, nil is returned.
+func ReadToolkit(dir string) (*Toolkit, error) {
+	t := &Toolkit{}
+	if err := read(filepath.Join(dir, ToolkitStateFilename), t); err != nil {
+		return nil, err
+	}
+	if t.ObservedGeneration == "" && t.Phase == "" {
+		return nil, nil
+	}
+	return t, nil
+}
+
+// WriteToolkit atomically writes the toolkit state to the specified directory.
+func WriteToolkit(dir string, t *Toolkit) error {
+	return write(dir, ToolkitStateFilename, t)
+}
+
+func read(path string, v interface{}) error {
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %v: %w", path, err)
+	}
+	if err := json.Unmarshal(contents, v); err != nil {
+		return fmt.Errorf("failed to parse %v: %w", path, err)
+	}
+	return nil
+}
+
+// write writes the specified value to a temporary file in dir and renames it
+// so that readers never observe a partially written state file.
+func write(dir string, name string, v interface{}) error {
+	contents, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+name+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(contents, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to set permissions: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, name)); err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	return nil
+}
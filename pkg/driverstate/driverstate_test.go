@@ -0,0 +1,90 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package driverstate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDriverState(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "state")
+
+	d, err := ReadDriver(dir)
+	require.NoError(t, err)
+	require.Nil(t, d)
+
+	require.Error(t, WriteDriver(dir, &Driver{Phase: DriverPhaseReady}))
+	require.Error(t, WriteDriver(dir, &Driver{Generation: "1", Phase: "unknown"}))
+
+	expected := &Driver{Generation: "1", Phase: DriverPhaseReady, Version: "570.124.06"}
+	require.NoError(t, WriteDriver(dir, expected))
+
+	d, err = ReadDriver(dir)
+	require.NoError(t, err)
+	require.EqualValues(t, expected, d)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "temporary files should be removed")
+}
+
+func TestToolkitAcknowledges(t *testing.T) {
+	testCases := []struct {
+		description string
+		toolkit     *Toolkit
+		driver      *Driver
+		expected    bool
+	}{
+		{
+			description: "no toolkit state",
+			driver:      &Driver{Generation: "1", Phase: DriverPhaseReady},
+		},
+		{
+			description: "matching state is acknowledged",
+			toolkit:     &Toolkit{ObservedGeneration: "1", ObservedPhase: DriverPhaseReady, Phase: ToolkitPhaseReconciled},
+			driver:      &Driver{Generation: "1", Phase: DriverPhaseReady},
+			expected:    true,
+		},
+		{
+			description: "failed reconciliation is acknowledged",
+			toolkit:     &Toolkit{ObservedGeneration: "1", ObservedPhase: DriverPhaseReady, Phase: ToolkitPhaseFailed},
+			driver:      &Driver{Generation: "1", Phase: DriverPhaseReady},
+			expected:    true,
+		},
+		{
+			description: "new generation is not acknowledged",
+			toolkit:     &Toolkit{ObservedGeneration: "1", ObservedPhase: DriverPhaseReady, Phase: ToolkitPhaseReconciled},
+			driver:      &Driver{Generation: "2", Phase: DriverPhaseReady},
+		},
+		{
+			description: "new phase is not acknowledged",
+			toolkit:     &Toolkit{ObservedGeneration: "1", ObservedPhase: DriverPhaseReady, Phase: ToolkitPhaseReconciled},
+			driver:      &Driver{Generation: "1", Phase: DriverPhaseUnloading},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.toolkit.Acknowledges(tc.driver))
+		})
+	}
+}
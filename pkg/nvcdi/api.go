@@ -17,6 +17,8 @@
 package nvcdi
 
 import (
+	"slices"
+
 	"tags.cncf.io/container-device-interface/pkg/cdi"
 	"tags.cncf.io/container-device-interface/specs-go"
 
@@ -39,6 +41,23 @@ type SpecGenerator interface {
 	GetSpec(...string) (spec.Interface, error)
 }
 
+// A PerDeviceSpecGenerator is used to generate the device specs for each
+// device separately. This allows a CDI spec to be generated for each device
+// so that the specs of other devices are not affected if a device is removed
+// or reconfigured.
+type PerDeviceSpecGenerator interface {
+	// GetPerDeviceSpecsByID returns the device specs for the devices with
+	// the specified IDs grouped by device UUID. Device specs that are not
+	// associated with a single device are grouped under an empty key.
+	GetPerDeviceSpecsByID(...string) (map[string][]specs.Device, error)
+}
+
+// A SpecAnnotator provides the annotations to add to a spec that is
+// assembled from the device specs and common edits of an Interface.
+type SpecAnnotator interface {
+	GetSpecAnnotations() map[string]string
+}
+
 // A DeviceSpecGenerator is used to generate the specs for one or more devices.
 type DeviceSpecGenerator interface {
 	GetDeviceSpecs() ([]specs.Device, error)
@@ -75,6 +94,26 @@ const (
 	HookUpdateLDCache = UpdateLDCacheHook
 )
 
+// SpecHooks lists the hooks that can be included in a generated CDI spec.
+// Each of these hooks can be disabled using WithDisabledHook.
+var SpecHooks = []HookName{
+	discover.ChmodHook,
+	CreateSonameSymlinksHook,
+	CreateSymlinksHook,
+	DisableDeviceNodeModificationHook,
+	EnableCudaCompatHook,
+	UpdateLDCacheHook,
+}
+
+// IsSpecHook checks whether the specified hook can be included in a generated
+// CDI spec. The special hook name AllHooks is also accepted.
+func IsSpecHook[T string | HookName](hook T) bool {
+	if HookName(hook) == AllHooks {
+		return true
+	}
+	return slices.Contains(SpecHooks, HookName(hook))
+}
+
 // A FeatureFlag refers to a specific feature that can be toggled in the CDI api.
 // All features are off by default.
 type FeatureFlag string
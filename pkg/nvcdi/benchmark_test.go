@@ -0,0 +1,248 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	mocknvml "github.com/NVIDIA/go-nvml/pkg/nvml/mock"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/nvcaps"
+)
+
+const benchmarkDriverVersion = "999.88.77"
+
+// A benchmarkFixture describes a fake driver root and the devices reported by
+// the mock NVML library for that root.
+type benchmarkFixture struct {
+	gpus int
+	// migDevicesPerGPU is the number of MIG devices on each GPU. If this is
+	// zero, MIG mode is disabled for all GPUs.
+	migDevicesPerGPU int
+	// libraries is the number of driver libraries, including libcuda.so, in the
+	// driver root.
+	libraries int
+}
+
+// benchmarkFixtures lists the fixtures for which spec generation is
+// benchmarked. The names of the fixtures are used to compare results across
+// runs and should not be changed for existing entries.
+var benchmarkFixtures = []benchmarkFixture{
+	{gpus: 1, libraries: 1},
+	{gpus: 1, libraries: 64},
+	{gpus: 8, libraries: 1},
+	{gpus: 8, libraries: 64},
+	{gpus: 8, libraries: 256},
+	{gpus: 1, migDevicesPerGPU: 7, libraries: 64},
+	{gpus: 8, migDevicesPerGPU: 1, libraries: 64},
+	{gpus: 8, migDevicesPerGPU: 7, libraries: 64},
+}
+
+func (f benchmarkFixture) String() string {
+	return fmt.Sprintf("gpus=%d/mig=%d/libraries=%d", f.gpus, f.migDevicesPerGPU, f.libraries)
+}
+
+// BenchmarkGetSpec measures the time and allocations required to generate a
+// CDI specification for all devices in nvml mode. This can be run using:
+//
+//	make bench
+//
+// and compared to a previous run using benchstat.
+func BenchmarkGetSpec(b *testing.B) {
+	b.Setenv("__NVCT_TESTING_DEVICES_ARE_FILES", "true")
+
+	for _, f := range benchmarkFixtures {
+		b.Run(f.String(), func(b *testing.B) {
+			lib := f.newLib(b)
+
+			// Generate the spec once to ensure that the fixture is valid and
+			// to report the number of generated devices.
+			spec, err := lib.GetSpec()
+			if err != nil {
+				b.Fatalf("failed to generate spec: %v", err)
+			}
+			devices := len(spec.Raw().Devices)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := lib.GetSpec(); err != nil {
+					b.Fatalf("failed to generate spec: %v", err)
+				}
+			}
+			b.ReportMetric(float64(devices), "devices")
+		})
+	}
+}
+
+// newLib creates an nvcdi library for the fixture. A fake driver root is
+// created in a temporary directory.
+func (f benchmarkFixture) newLib(b *testing.B) Interface {
+	b.Helper()
+	logger, _ := testlog.NewNullLogger()
+
+	driverRoot := b.TempDir()
+	migCaps := f.createDriverRoot(b, driverRoot)
+
+	lib, err := New(
+		WithLogger(logger),
+		WithMode(ModeNvml),
+		WithDriverRoot(driverRoot),
+		WithNvmlLib(f.newServer()),
+		func(l *nvcdilib) {
+			l.migCaps = migCaps
+		},
+	)
+	if err != nil {
+		b.Fatalf("failed to create library: %v", err)
+	}
+	return lib
+}
+
+// createDriverRoot creates the device nodes and driver libraries for the
+// fixture in the specified root. The MIG capabilities for the created MIG
+// capability device nodes are returned.
+func (f benchmarkFixture) createDriverRoot(b *testing.B, root string) nvcaps.MigCaps {
+	b.Helper()
+
+	deviceNodes := []string{
+		"nvidiactl",
+		"nvidia-uvm",
+		"nvidia-uvm-tools",
+		"nvidia-modeset",
+	}
+	migCaps := make(nvcaps.MigCaps)
+	minor := 1
+	for gpu := 0; gpu < f.gpus; gpu++ {
+		deviceNodes = append(deviceNodes, fmt.Sprintf("nvidia%d", gpu))
+		for mig := 0; mig < f.migDevicesPerGPU; mig++ {
+			gi, ci := migPlacement(mig)
+			for _, migCap := range []nvcaps.MigCap{
+				nvcaps.NewGPUInstanceCap(gpu, gi),
+				nvcaps.NewComputeInstanceCap(gpu, gi, ci),
+			} {
+				migCaps[migCap] = nvcaps.MigMinor(minor)
+				deviceNodes = append(deviceNodes, fmt.Sprintf("nvidia-caps/nvidia-cap%d", minor))
+				minor++
+			}
+		}
+	}
+	for _, deviceNode := range deviceNodes {
+		path := filepath.Join(root, "dev", deviceNode)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			b.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0600); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	libraries := []string{"libcuda.so." + benchmarkDriverVersion}
+	for i := 1; i < f.libraries; i++ {
+		libraries = append(libraries, fmt.Sprintf("libnvidia-benchmark%d.so.%s", i, benchmarkDriverVersion))
+	}
+	libraryRoot := filepath.Join(root, "lib", "x86_64-linux-gnu")
+	if err := os.MkdirAll(libraryRoot, 0755); err != nil {
+		b.Fatal(err)
+	}
+	for _, library := range libraries {
+		if err := os.WriteFile(filepath.Join(libraryRoot, library), nil, 0600); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	return migCaps
+}
+
+// newServer creates a mock NVML server, based on the DGX A100 mock, that
+// reports the GPUs and MIG devices for the fixture.
+func (f benchmarkFixture) newServer() *dgxa100.Server {
+	server := dgxa100.New()
+	server.SystemGetDriverVersionFunc = func() (string, nvml.Return) {
+		return benchmarkDriverVersion, nvml.SUCCESS
+	}
+	server.DeviceGetCountFunc = func() (int, nvml.Return) {
+		return f.gpus, nvml.SUCCESS
+	}
+	server.SystemGetConfComputeStateFunc = func() (nvml.ConfComputeSystemState, nvml.Return) {
+		return nvml.ConfComputeSystemState{}, nvml.ERROR_NOT_SUPPORTED
+	}
+	for i, d := range server.Devices {
+		device := d.(*dgxa100.Device)
+		device.GetGspFirmwareModeFunc = func() (bool, bool, nvml.Return) {
+			return false, false, nvml.ERROR_NOT_SUPPORTED
+		}
+		device.GetPersistenceModeFunc = func() (nvml.EnableState, nvml.Return) {
+			return 0, nvml.ERROR_NOT_SUPPORTED
+		}
+		device.IsMigDeviceHandleFunc = func() (bool, nvml.Return) {
+			return false, nvml.SUCCESS
+		}
+
+		migDevices := make([]nvml.Device, f.migDevicesPerGPU)
+		for j := range migDevices {
+			gi, ci := migPlacement(j)
+			migDevices[j] = &mocknvml.Device{
+				IsMigDeviceHandleFunc: func() (bool, nvml.Return) {
+					return true, nvml.SUCCESS
+				},
+				GetDeviceHandleFromMigDeviceHandleFunc: func() (nvml.Device, nvml.Return) {
+					return device, nvml.SUCCESS
+				},
+				GetIndexFunc: func() (int, nvml.Return) {
+					return j, nvml.SUCCESS
+				},
+				GetGpuInstanceIdFunc: func() (int, nvml.Return) {
+					return gi, nvml.SUCCESS
+				},
+				GetComputeInstanceIdFunc: func() (int, nvml.Return) {
+					return ci, nvml.SUCCESS
+				},
+			}
+		}
+		if len(migDevices) > 0 {
+			device.MigMode = nvml.DEVICE_MIG_ENABLE
+		}
+		device.GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+			return len(migDevices), nvml.SUCCESS
+		}
+		device.GetMigDeviceHandleByIndexFunc = func(n int) (nvml.Device, nvml.Return) {
+			if n < 0 || n >= len(migDevices) {
+				return nil, nvml.ERROR_INVALID_ARGUMENT
+			}
+			return migDevices[n], nvml.SUCCESS
+		}
+		device.GetIndexFunc = func() (int, nvml.Return) {
+			return i, nvml.SUCCESS
+		}
+	}
+	return server
+}
+
+// migPlacement returns the GPU instance and compute instance IDs for the MIG
+// device with the specified index. Each MIG device is assigned its own GPU
+// instance.
+func migPlacement(index int) (int, int) {
+	return index + 1, 0
+}
@@ -0,0 +1,226 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/edits"
+)
+
+// capabilityDevices lists the driver capabilities for which a separate CDI
+// device is generated if capability devices are enabled.
+var capabilityDevices = []image.DriverCapability{
+	image.DriverCapabilityCompute,
+	image.DriverCapabilityUtility,
+	image.DriverCapabilityGraphics,
+	image.DriverCapabilityVideo,
+}
+
+// capabilityPatterns maps a driver capability to the patterns of the driver
+// libraries and binaries associated with it. The patterns are matched against
+// the file name. Driver files that do not match any pattern are included in
+// the common edits.
+var capabilityPatterns = map[image.DriverCapability][]string{
+	image.DriverCapabilityCompute: {
+		"libcuda.so*",
+		"libcudadebugger.so*",
+		"libnvidia-allocator.so*",
+		"libnvidia-compiler.so*",
+		"libnvidia-fatbinaryloader.so*",
+		"libnvidia-gpucomp.so*",
+		"libnvidia-nvvm.so*",
+		"libnvidia-opencl.so*",
+		"libnvidia-pkcs11*.so*",
+		"libnvidia-ptxjitcompiler.so*",
+		"nvidia-cuda-mps-control",
+		"nvidia-cuda-mps-server",
+	},
+	image.DriverCapabilityUtility: {
+		"libnvidia-cfg.so*",
+		"libnvidia-ml.so*",
+		"nvidia-debugdump",
+		"nvidia-persistenced",
+		"nvidia-smi",
+	},
+	image.DriverCapabilityGraphics: {
+		"libEGL_nvidia.so*",
+		"libGLESv1_CM_nvidia.so*",
+		"libGLESv2_nvidia.so*",
+		"libGLX_nvidia.so*",
+		"libnvidia-eglcore.so*",
+		"libnvidia-fbc.so*",
+		"libnvidia-glcore.so*",
+		"libnvidia-glsi.so*",
+		"libnvidia-glvkspirv.so*",
+		"libnvidia-ifr.so*",
+		"libnvidia-rtcore.so*",
+		"libnvidia-tls.so*",
+		"libnvoptix.so*",
+	},
+	image.DriverCapabilityVideo: {
+		"libnvcuvid.so*",
+		"libnvidia-encode.so*",
+		"libnvidia-opticalflow.so*",
+		"libvdpau_nvidia.so*",
+	},
+}
+
+// getCapabilityDevice returns the driver capability for the specified device
+// ID. If the ID does not refer to a capability device, false is returned.
+func getCapabilityDevice(id string) (image.DriverCapability, bool) {
+	for _, capability := range capabilityDevices {
+		if id == string(capability) {
+			return capability, true
+		}
+	}
+	return "", false
+}
+
+// getCapability returns the driver capability that the specified driver file
+// is associated with. An empty capability is returned for files that are
+// required independent of the requested capabilities.
+func getCapability(path string) image.DriverCapability {
+	name := filepath.Base(path)
+	for _, capability := range capabilityDevices {
+		for _, pattern := range capabilityPatterns[capability] {
+			if match, _ := filepath.Match(pattern, name); match {
+				return capability
+			}
+		}
+	}
+	return ""
+}
+
+// capabilityMounts wraps a discoverer and only returns the mounts that are
+// associated with the specified capability. If the capability is empty, the
+// mounts that are not associated with any capability are returned. All other
+// entities of the wrapped discoverer are returned unchanged.
+type capabilityMounts struct {
+	discover.Discover
+	capability image.DriverCapability
+}
+
+// Mounts returns the filtered mounts of the wrapped discoverer.
+func (d *capabilityMounts) Mounts() ([]discover.Mount, error) {
+	mounts, err := d.Discover.Mounts()
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []discover.Mount
+	for _, mount := range mounts {
+		if getCapability(mount.HostPath) != d.capability {
+			continue
+		}
+		filtered = append(filtered, mount)
+	}
+	return filtered, nil
+}
+
+// mountsOnly wraps a discoverer and only returns its mounts.
+type mountsOnly struct {
+	discover.None
+	mounts discover.Discover
+}
+
+// Mounts returns the mounts of the wrapped discoverer.
+func (d *mountsOnly) Mounts() ([]discover.Mount, error) {
+	return d.mounts.Mounts()
+}
+
+// A capabilityDeviceSpecGenerator generates the CDI device specification for
+// the driver files associated with a single driver capability.
+type capabilityDeviceSpecGenerator struct {
+	*nvmllib
+	capability image.DriverCapability
+}
+
+var _ DeviceSpecGenerator = (*capabilityDeviceSpecGenerator)(nil)
+
+// getCapabilityDeviceSpecGenerators returns the device spec generators for all
+// capability devices. If capability devices are not enabled, no generators are
+// returned.
+func (l *nvmllib) getCapabilityDeviceSpecGenerators() DeviceSpecGenerators {
+	if !l.capabilityDevices {
+		return nil
+	}
+	var generators DeviceSpecGenerators
+	for _, capability := range capabilityDevices {
+		generators = append(generators, &capabilityDeviceSpecGenerator{nvmllib: l, capability: capability})
+	}
+	return generators
+}
+
+// GetDeviceSpecs returns the CDI device specification for the capability.
+// If no driver files are associated with the capability, no device is
+// returned.
+func (l *capabilityDeviceSpecGenerator) GetDeviceSpecs() ([]specs.Device, error) {
+	d, err := l.newCapabilityDiscoverer()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discoverer for capability %q: %w", l.capability, err)
+	}
+
+	deviceEdits, err := edits.FromDiscoverer(d)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create container edits for capability %q: %w", l.capability, err)
+	}
+	if len(deviceEdits.Mounts) == 0 && len(deviceEdits.Hooks) == 0 {
+		l.logger.Warningf("No driver files found for capability %q; skipping device", l.capability)
+		return nil, nil
+	}
+
+	deviceSpec := specs.Device{
+		Name:           string(l.capability),
+		ContainerEdits: *deviceEdits.ContainerEdits,
+	}
+	return []specs.Device{deviceSpec}, nil
+}
+
+// newCapabilityDiscoverer creates a discoverer for the driver files associated
+// with the capability. Only mounts are included since the hooks required for
+// the driver libraries are part of the common edits. For the graphics
+// capability, the graphics mounts (e.g. the Vulkan ICD files) are included.
+func (l *capabilityDeviceSpecGenerator) newCapabilityDiscoverer() (discover.Discover, error) {
+	driverFiles, err := l.NewDriverDiscoverer()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discoverer for driver files: %w", err)
+	}
+
+	discoverers := []discover.Discover{
+		&capabilityMounts{
+			Discover:   &mountsOnly{mounts: driverFiles},
+			capability: l.capability,
+		},
+	}
+
+	if l.capability == image.DriverCapabilityGraphics {
+		graphicsMounts, err := discover.NewGraphicsMountsDiscoverer(l.logger, l.driver, l.hookCreator)
+		if err != nil {
+			l.logger.Warningf("failed to create discoverer for graphics mounts: %v", err)
+		}
+		discoverers = append(discoverers, graphicsMounts)
+	}
+
+	return discover.Merge(discoverers...), nil
+}
@@ -0,0 +1,105 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+)
+
+func TestCapabilityMounts(t *testing.T) {
+	driverFiles := &discover.DiscoverMock{
+		MountsFunc: func() ([]discover.Mount, error) {
+			return []discover.Mount{
+				{HostPath: "/usr/lib64/libcuda.so.550.54.15", Path: "/usr/lib64/libcuda.so.550.54.15"},
+				{HostPath: "/usr/lib64/libnvidia-ml.so.550.54.15", Path: "/usr/lib64/libnvidia-ml.so.550.54.15"},
+				{HostPath: "/usr/lib64/libGLX_nvidia.so.550.54.15", Path: "/usr/lib64/libGLX_nvidia.so.550.54.15"},
+				{HostPath: "/usr/lib64/libnvcuvid.so.550.54.15", Path: "/usr/lib64/libnvcuvid.so.550.54.15"},
+				{HostPath: "/usr/lib64/libnvidia-ngx.so.550.54.15", Path: "/usr/lib64/libnvidia-ngx.so.550.54.15"},
+				{HostPath: "/usr/bin/nvidia-smi", Path: "/usr/bin/nvidia-smi"},
+			}, nil
+		},
+		HooksFunc: func() ([]discover.Hook, error) {
+			return []discover.Hook{{Path: "/usr/bin/nvidia-cdi-hook"}}, nil
+		},
+	}
+
+	testCases := []struct {
+		description   string
+		capability    image.DriverCapability
+		mountsOnly    bool
+		expectedPaths []string
+		expectedHooks int
+	}{
+		{
+			description:   "common edits exclude capability files",
+			expectedPaths: []string{"/usr/lib64/libnvidia-ngx.so.550.54.15"},
+			expectedHooks: 1,
+		},
+		{
+			description:   "compute",
+			capability:    image.DriverCapabilityCompute,
+			mountsOnly:    true,
+			expectedPaths: []string{"/usr/lib64/libcuda.so.550.54.15"},
+		},
+		{
+			description:   "utility includes binaries",
+			capability:    image.DriverCapabilityUtility,
+			mountsOnly:    true,
+			expectedPaths: []string{"/usr/lib64/libnvidia-ml.so.550.54.15", "/usr/bin/nvidia-smi"},
+		},
+		{
+			description:   "graphics",
+			capability:    image.DriverCapabilityGraphics,
+			mountsOnly:    true,
+			expectedPaths: []string{"/usr/lib64/libGLX_nvidia.so.550.54.15"},
+		},
+		{
+			description:   "video",
+			capability:    image.DriverCapabilityVideo,
+			mountsOnly:    true,
+			expectedPaths: []string{"/usr/lib64/libnvcuvid.so.550.54.15"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var d discover.Discover = driverFiles
+			if tc.mountsOnly {
+				d = &mountsOnly{mounts: driverFiles}
+			}
+			d = &capabilityMounts{Discover: d, capability: tc.capability}
+
+			mounts, err := d.Mounts()
+			require.NoError(t, err)
+			var paths []string
+			for _, mount := range mounts {
+				paths = append(paths, mount.Path)
+			}
+			require.EqualValues(t, tc.expectedPaths, paths)
+
+			hooks, err := d.Hooks()
+			require.NoError(t, err)
+			require.Len(t, hooks, tc.expectedHooks)
+		})
+	}
+}
@@ -36,6 +36,12 @@ func (l *nvmllib) newCommonNVMLDiscoverer() (discover.Discover, error) {
 		},
 	)
 
+	nvswitchDevices, err := (*nvcdilib)(l).newNvSwitchDiscoverer()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discoverer for NVSwitch devices: %v", err)
+	}
+	metaDevices = discover.Merge(metaDevices, nvswitchDevices)
+
 	graphicsMounts, err := discover.NewGraphicsMountsDiscoverer(l.logger, l.driver, l.hookCreator)
 	if err != nil {
 		l.logger.Warningf("failed to create discoverer for graphics mounts: %v", err)
@@ -46,11 +52,37 @@ func (l *nvmllib) newCommonNVMLDiscoverer() (discover.Discover, error) {
 		return nil, fmt.Errorf("failed to create discoverer for driver files: %v", err)
 	}
 
-	d := discover.Merge(
+	discoverers := []discover.Discover{
 		metaDevices,
 		graphicsMounts,
 		driverFiles,
-	)
+	}
+	if l.minimalDriverFiles {
+		discoverers = []discover.Discover{
+			metaDevices,
+			driverFiles,
+		}
+	}
+	if l.capabilityDevices {
+		// The driver files associated with a specific capability as well as
+		// the graphics mounts are included in the capability devices instead.
+		discoverers = []discover.Discover{
+			metaDevices,
+			&capabilityMounts{Discover: driverFiles},
+		}
+	}
+
+	if l.includeRDMA {
+		rdma, err := discover.NewGPUDirectRDMADiscoverer(l.logger, l.devRoot)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create discoverer for GPUDirect RDMA devices: %v", err)
+		}
+		discoverers = append(discoverers, rdma)
+	}
+
+	discoverers = append(discoverers, newNvidiaSMILiteDiscoverer(l.logger, l.nvidiaSMILitePath))
+
+	d := discover.Merge(discoverers...)
 
 	return d, nil
 }
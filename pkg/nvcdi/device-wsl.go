@@ -17,6 +17,10 @@
 package nvcdi
 
 import (
+	"fmt"
+	"os"
+	"strings"
+
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 )
@@ -26,6 +30,8 @@ const (
 )
 
 // newDXGDeviceDiscoverer returns a Discoverer for DXG devices under WSL2.
+// When running in a user namespace (e.g. for rootless containers), device
+// nodes cannot be created and the DXG device node is bind mounted instead.
 func newDXGDeviceDiscoverer(logger logger.Interface, devRoot string) discover.Discover {
 	deviceNodes := discover.NewCharDeviceDiscoverer(
 		logger,
@@ -33,5 +39,57 @@ func newDXGDeviceDiscoverer(logger logger.Interface, devRoot string) discover.Di
 		[]string{dxgDeviceNode},
 	)
 
-	return deviceNodes
+	if !isRunningInUserNamespace() {
+		return deviceNodes
+	}
+	logger.Infof("Running in a user namespace; %v will be bind mounted", dxgDeviceNode)
+	return &deviceNodesAsMounts{
+		logger:      logger,
+		deviceNodes: deviceNodes,
+	}
+}
+
+// deviceNodesAsMounts converts the device nodes of the wrapped discoverer to
+// read-write bind mounts.
+type deviceNodesAsMounts struct {
+	discover.None
+	logger      logger.Interface
+	deviceNodes discover.Discover
+}
+
+// Mounts returns a bind mount for each discovered device node.
+func (d *deviceNodesAsMounts) Mounts() ([]discover.Mount, error) {
+	devices, err := d.deviceNodes.Devices()
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover device nodes: %w", err)
+	}
+
+	var mounts []discover.Mount
+	for _, device := range devices {
+		mount := discover.Mount{
+			HostPath: device.HostPath,
+			Path:     device.Path,
+			Options: []string{
+				"rw",
+				"nosuid",
+				"noexec",
+				"rbind",
+				"rprivate",
+			},
+		}
+		mounts = append(mounts, mount)
+	}
+	return mounts, nil
+}
+
+// isRunningInUserNamespace checks whether the current process is running in a
+// user namespace other than the initial user namespace.
+func isRunningInUserNamespace() bool {
+	contents, err := os.ReadFile("/proc/self/uid_map")
+	if err != nil {
+		return false
+	}
+	// In the initial user namespace the full range of UIDs is mapped.
+	fields := strings.Fields(string(contents))
+	return !(len(fields) == 3 && fields[0] == "0" && fields[1] == "0" && fields[2] == "4294967295")
 }
@@ -0,0 +1,59 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+)
+
+func TestDeviceNodesAsMounts(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	d := &deviceNodesAsMounts{
+		logger: logger,
+		deviceNodes: &discover.DiscoverMock{
+			DevicesFunc: func() ([]discover.Device, error) {
+				return []discover.Device{
+					{Path: "/dev/dxg", HostPath: "/host/dev/dxg"},
+				}, nil
+			},
+		},
+	}
+
+	devices, err := d.Devices()
+	require.NoError(t, err)
+	require.Empty(t, devices)
+
+	mounts, err := d.Mounts()
+	require.NoError(t, err)
+	require.EqualValues(t,
+		[]discover.Mount{
+			{
+				Path:     "/dev/dxg",
+				HostPath: "/host/dev/dxg",
+				Options:  []string{"rw", "nosuid", "noexec", "rbind", "rprivate"},
+			},
+		},
+		mounts,
+	)
+}
@@ -0,0 +1,174 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"maps"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+)
+
+const (
+	// GSPFirmwareModeAnnotation is the spec annotation used to indicate whether
+	// the GPUs that the spec was generated for use the GSP firmware.
+	GSPFirmwareModeAnnotation = "nvidia.com/gsp-firmware-mode"
+	// ConfidentialComputingModeAnnotation is the spec annotation used to
+	// indicate the confidential computing (CC) mode of the system that the
+	// spec was generated for.
+	ConfidentialComputingModeAnnotation = "nvidia.com/cc-mode"
+	// PersistenceModeAnnotation is the spec annotation used to indicate
+	// whether persistence mode is enabled for the GPUs that the spec was
+	// generated for.
+	PersistenceModeAnnotation = "nvidia.com/persistence-mode"
+)
+
+const (
+	featureEnabled  = "enabled"
+	featureDisabled = "disabled"
+	// featureMixed indicates that a feature is enabled for some but not all
+	// GPUs.
+	featureMixed = "mixed"
+
+	ccModeOn       = "on"
+	ccModeOff      = "off"
+	ccModeDevtools = "devtools"
+)
+
+// A driverFeatureAnnotator returns the spec annotations that describe the
+// features of the driver.
+type driverFeatureAnnotator interface {
+	getDriverFeatureAnnotations() map[string]string
+}
+
+var _ driverFeatureAnnotator = (*nvmllib)(nil)
+
+// getDriverFeatureAnnotations returns the annotations that describe the
+// features of the driver and the GPUs that it manages. This allows the
+// capabilities of a node to be determined from its CDI specs. Note that the
+// flavor of the kernel module is described by the KernelModuleFlavorAnnotation.
+//
+// Features that cannot be queried are not included and errors are only
+// logged since the annotations are informational.
+func (l *nvmllib) getDriverFeatureAnnotations() map[string]string {
+	if r := l.nvmllib.Init(); r != nvml.SUCCESS {
+		l.logger.Warningf("Failed to initialize NVML; skipping driver feature annotations: %v", r)
+		return nil
+	}
+	defer func() {
+		if r := l.nvmllib.Shutdown(); r != nvml.SUCCESS {
+			l.logger.Warningf("Failed to shutdown NVML: %v", r)
+		}
+	}()
+
+	annotations := make(map[string]string)
+	if mode := l.getConfidentialComputingMode(); mode != "" {
+		annotations[ConfidentialComputingModeAnnotation] = mode
+	}
+
+	count, r := l.nvmllib.DeviceGetCount()
+	if r != nvml.SUCCESS {
+		l.logger.Warningf("Failed to get device count: %v", r)
+		return annotations
+	}
+	var gspFirmwareModes, persistenceModes []bool
+	for i := 0; i < count; i++ {
+		device, r := l.nvmllib.DeviceGetHandleByIndex(i)
+		if r != nvml.SUCCESS {
+			l.logger.Warningf("Failed to get device %d: %v", i, r)
+			continue
+		}
+		if enabled, _, r := device.GetGspFirmwareMode(); r == nvml.SUCCESS {
+			gspFirmwareModes = append(gspFirmwareModes, enabled)
+		} else {
+			l.logger.Debugf("Failed to get GSP firmware mode of device %d: %v", i, r)
+		}
+		if mode, r := device.GetPersistenceMode(); r == nvml.SUCCESS {
+			persistenceModes = append(persistenceModes, mode == nvml.FEATURE_ENABLED)
+		} else {
+			l.logger.Debugf("Failed to get persistence mode of device %d: %v", i, r)
+		}
+	}
+	if value := getFeatureValue(gspFirmwareModes); value != "" {
+		annotations[GSPFirmwareModeAnnotation] = value
+	}
+	if value := getFeatureValue(persistenceModes); value != "" {
+		annotations[PersistenceModeAnnotation] = value
+	}
+	return annotations
+}
+
+// getConfidentialComputingMode returns the CC mode of the system. An empty
+// string is returned if this cannot be determined.
+func (l *nvmllib) getConfidentialComputingMode() string {
+	state, r := l.nvmllib.SystemGetConfComputeState()
+	if r != nvml.SUCCESS {
+		l.logger.Debugf("Failed to get confidential computing state: %v", r)
+		return ""
+	}
+	switch {
+	case state.CcFeature != nvml.CC_SYSTEM_FEATURE_ENABLED:
+		return ccModeOff
+	case state.DevToolsMode == nvml.CC_SYSTEM_DEVTOOLS_MODE_ON:
+		return ccModeDevtools
+	default:
+		return ccModeOn
+	}
+}
+
+// getFeatureValue returns the annotation value for a feature that has the
+// specified states for each GPU. If no states are specified, an empty string
+// is returned.
+func getFeatureValue(states []bool) string {
+	if len(states) == 0 {
+		return ""
+	}
+	var enabled int
+	for _, state := range states {
+		if state {
+			enabled++
+		}
+	}
+	switch enabled {
+	case 0:
+		return featureDisabled
+	case len(states):
+		return featureEnabled
+	default:
+		return featureMixed
+	}
+}
+
+// getSpecAnnotations returns the annotations for generated specs. If supported
+// by the factory, the annotations describing the driver features are added to
+// the annotations that were determined when the library was constructed.
+func (l *wrapper) getSpecAnnotations() map[string]string {
+	annotator, ok := l.factory.(driverFeatureAnnotator)
+	if !ok {
+		return l.annotations
+	}
+	features := annotator.getDriverFeatureAnnotations()
+	if len(features) == 0 {
+		return l.annotations
+	}
+	annotations := maps.Clone(l.annotations)
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	maps.Copy(annotations, features)
+	return annotations
+}
@@ -0,0 +1,130 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDriverFeatureAnnotations(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description         string
+		ccState             nvml.ConfComputeSystemState
+		ccReturn            nvml.Return
+		gspFirmwareModes    []bool
+		persistenceModes    []nvml.EnableState
+		featureReturn       nvml.Return
+		expectedAnnotations map[string]string
+	}{
+		{
+			description:      "all features are reported",
+			ccReturn:         nvml.SUCCESS,
+			gspFirmwareModes: []bool{true, true},
+			persistenceModes: []nvml.EnableState{nvml.FEATURE_ENABLED, nvml.FEATURE_ENABLED},
+			featureReturn:    nvml.SUCCESS,
+			expectedAnnotations: map[string]string{
+				"nvidia.com/cc-mode":           "off",
+				"nvidia.com/gsp-firmware-mode": "enabled",
+				"nvidia.com/persistence-mode":  "enabled",
+			},
+		},
+		{
+			description: "cc mode with devtools",
+			ccState: nvml.ConfComputeSystemState{
+				CcFeature:    nvml.CC_SYSTEM_FEATURE_ENABLED,
+				DevToolsMode: nvml.CC_SYSTEM_DEVTOOLS_MODE_ON,
+			},
+			ccReturn:         nvml.SUCCESS,
+			gspFirmwareModes: []bool{true},
+			persistenceModes: []nvml.EnableState{nvml.FEATURE_DISABLED},
+			featureReturn:    nvml.SUCCESS,
+			expectedAnnotations: map[string]string{
+				"nvidia.com/cc-mode":           "devtools",
+				"nvidia.com/gsp-firmware-mode": "enabled",
+				"nvidia.com/persistence-mode":  "disabled",
+			},
+		},
+		{
+			description:      "differing GPUs are reported as mixed",
+			ccState:          nvml.ConfComputeSystemState{CcFeature: nvml.CC_SYSTEM_FEATURE_ENABLED},
+			ccReturn:         nvml.SUCCESS,
+			gspFirmwareModes: []bool{true, false},
+			persistenceModes: []nvml.EnableState{nvml.FEATURE_DISABLED, nvml.FEATURE_ENABLED},
+			featureReturn:    nvml.SUCCESS,
+			expectedAnnotations: map[string]string{
+				"nvidia.com/cc-mode":           "on",
+				"nvidia.com/gsp-firmware-mode": "mixed",
+				"nvidia.com/persistence-mode":  "mixed",
+			},
+		},
+		{
+			description:         "unsupported features are skipped",
+			ccReturn:            nvml.ERROR_NOT_SUPPORTED,
+			gspFirmwareModes:    []bool{true},
+			persistenceModes:    []nvml.EnableState{nvml.FEATURE_ENABLED},
+			featureReturn:       nvml.ERROR_NOT_SUPPORTED,
+			expectedAnnotations: map[string]string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var devices []nvml.Device
+			for i := range tc.gspFirmwareModes {
+				devices = append(devices, &mock.Device{
+					GetGspFirmwareModeFunc: func() (bool, bool, nvml.Return) {
+						return tc.gspFirmwareModes[i], false, tc.featureReturn
+					},
+					GetPersistenceModeFunc: func() (nvml.EnableState, nvml.Return) {
+						return tc.persistenceModes[i], tc.featureReturn
+					},
+				})
+			}
+			mockNvml := &mock.Interface{
+				InitFunc: func() nvml.Return {
+					return nvml.SUCCESS
+				},
+				ShutdownFunc: func() nvml.Return {
+					return nvml.SUCCESS
+				},
+				SystemGetConfComputeStateFunc: func() (nvml.ConfComputeSystemState, nvml.Return) {
+					return tc.ccState, tc.ccReturn
+				},
+				DeviceGetCountFunc: func() (int, nvml.Return) {
+					return len(devices), nvml.SUCCESS
+				},
+				DeviceGetHandleByIndexFunc: func(i int) (nvml.Device, nvml.Return) {
+					return devices[i], nvml.SUCCESS
+				},
+			}
+
+			l := &nvmllib{
+				logger:  logger,
+				nvmllib: mockNvml,
+			}
+			require.EqualValues(t, tc.expectedAnnotations, l.getDriverFeatureAnnotations())
+		})
+	}
+}
@@ -30,13 +30,19 @@ import (
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/cuda"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/messages"
 )
 
 // NewDriverDiscoverer creates a discoverer for the libraries and binaries associated with a driver installation.
-// The supplied NVML Library is used to query the expected driver version.
+// The supplied NVML Library is used to query the expected driver version unless
+// a driver version was explicitly requested.
 func (l *nvmllib) NewDriverDiscoverer() (discover.Discover, error) {
+	if l.driverVersion != "" {
+		return (*nvcdilib)(l).newDriverVersionDiscoverer(l.driverVersion)
+	}
+
 	if r := l.nvmllib.Init(); r != nvml.SUCCESS {
-		return nil, fmt.Errorf("failed to initialize NVML: %v", r)
+		return nil, messages.Wrap(r, messages.NVMLInitFailed, nil)
 	}
 	defer func() {
 		if r := l.nvmllib.Shutdown(); r != nvml.SUCCESS {
@@ -67,8 +73,21 @@ func (l *nvcdilib) newDriverVersionDiscoverer(version string) (discover.Discover
 	if err != nil {
 		return nil, fmt.Errorf("failed to create discoverer for GSP firmware: %v", err)
 	}
+	if l.requiresGSPFirmware() {
+		firmwares = &requiredMounts{
+			Discover:    firmwares,
+			description: "GSP firmware for the open kernel module",
+		}
+	}
 
-	binaries := NewDriverBinariesDiscoverer(l.logger, l.driver.Root)
+	var binariesByRoot []discover.Discover
+	for _, driver := range l.drivers() {
+		binariesByRoot = append(binariesByRoot, NewDriverBinariesDiscoverer(l.logger, driver.Root))
+	}
+	binaries := mergeDriverRootMounts(l.logger, binariesByRoot...)
+	if l.minimalDriverFiles {
+		binaries = discover.None{}
+	}
 
 	d := discover.Merge(
 		libraries,
@@ -82,20 +101,23 @@ func (l *nvcdilib) newDriverVersionDiscoverer(version string) (discover.Discover
 
 // NewDriverLibraryDiscoverer creates a discoverer for the libraries associated with the specified driver version.
 func (l *nvcdilib) NewDriverLibraryDiscoverer(version string) (discover.Discover, error) {
+	l.logger.Infof("Using driver version %v", version)
 	libraryPaths, libCudaDirectoryPath, err := getVersionLibs(l.logger, l.driver, version)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get libraries for driver version: %v", err)
 	}
-
-	libraries := discover.NewMounts(
-		l.logger,
-		lookup.NewFileLocator(
-			lookup.WithLogger(l.logger),
-			lookup.WithRoot(l.driver.Root),
-		),
-		l.driver.Root,
-		libraryPaths,
-	)
+	librariesByRoot := []discover.Discover{
+		l.newDriverLibraryMounts(l.driver, libraryPaths),
+	}
+	for _, driver := range l.additionalDrivers {
+		additionalLibraryPaths, err := getAdditionalVersionLibs(l.logger, driver, version, libCudaDirectoryPath)
+		if err != nil {
+			l.logger.Warningf("Ignoring error in locating libraries in driver root %v: %v", driver.Root, err)
+			continue
+		}
+		librariesByRoot = append(librariesByRoot, l.newDriverLibraryMounts(driver, additionalLibraryPaths))
+	}
+	libraries := mergeDriverRootMounts(l.logger, librariesByRoot...)
 
 	var discoverers []discover.Discover
 
@@ -127,6 +149,23 @@ func (l *nvcdilib) NewDriverLibraryDiscoverer(version string) (discover.Discover
 	return d, nil
 }
 
+// newDriverLibraryMounts creates a discoverer for the specified driver
+// libraries in the specified driver root.
+func (l *nvcdilib) newDriverLibraryMounts(driver *root.Driver, libraryPaths []string) discover.Discover {
+	if l.minimalDriverFiles {
+		libraryPaths = filterMinimalDriverLibraries(libraryPaths)
+	}
+	return discover.NewMounts(
+		l.logger,
+		lookup.NewFileLocator(
+			lookup.WithLogger(l.logger),
+			lookup.WithRoot(driver.Root),
+		),
+		driver.Root,
+		libraryPaths,
+	)
+}
+
 func getUTSRelease() (string, error) {
 	utsname := &unix.Utsname{}
 	if err := unix.Uname(utsname); err != nil {
@@ -210,8 +249,6 @@ func NewDriverBinariesDiscoverer(logger logger.Interface, driverRoot string) dis
 // Although the ldcache at the specified driverRoot is queried, the paths are returned relative to this driverRoot.
 // This allows the standard mount location logic to be used for resolving the mounts.
 func getVersionLibs(logger logger.Interface, driver *root.Driver, version string) ([]string, string, error) {
-	logger.Infof("Using driver version %v", version)
-
 	libCudaPaths, err := cuda.New(
 		driver.Libraries(),
 	).Locate("." + version)
@@ -220,6 +257,18 @@ func getVersionLibs(logger logger.Interface, driver *root.Driver, version string
 	}
 	libCudaDirectoryPath := filepath.Dir(libCudaPaths[0])
 
+	libs, err := getVersionLibsInDirectory(logger, driver, libCudaDirectoryPath, version)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return libs, driver.RelativeToRoot(libCudaDirectoryPath), nil
+}
+
+// getVersionLibsInDirectory returns the libraries ending in the specified
+// driver version in the specified directory and its vdpau subdirectory. The
+// paths are returned relative to the driver root.
+func getVersionLibsInDirectory(logger logger.Interface, driver *root.Driver, libCudaDirectoryPath string, version string) ([]string, error) {
 	libraries := lookup.NewFileLocator(
 		lookup.WithLogger(logger),
 		lookup.WithSearchPaths(
@@ -231,19 +280,17 @@ func getVersionLibs(logger logger.Interface, driver *root.Driver, version string
 
 	libs, err := libraries.Locate("*.so." + version)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to locate libraries for driver version %v: %v", version, err)
+		return nil, fmt.Errorf("failed to locate libraries for driver version %v: %v", version, err)
 	}
 
 	if driver.Root == "/" || driver.Root == "" {
-		return libs, libCudaDirectoryPath, nil
+		return libs, nil
 	}
 
-	libCudaDirectoryPath = driver.RelativeToRoot(libCudaDirectoryPath)
-
 	var relative []string
 	for _, l := range libs {
 		relative = append(relative, strings.TrimPrefix(l, driver.Root))
 	}
 
-	return relative, libCudaDirectoryPath, nil
+	return relative, nil
 }
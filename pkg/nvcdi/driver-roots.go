@@ -0,0 +1,110 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+)
+
+// A driverRootMounts discoverer merges the mounts discovered in multiple
+// driver roots. The discoverers are ordered by precedence and a mount is only
+// included if no mount for a file with the same name was discovered by a
+// discoverer with a higher precedence. This allows, for example, libraries
+// that are missing from a driver container to be included from the host.
+type driverRootMounts struct {
+	discover.None
+	logger      logger.Interface
+	discoverers []discover.Discover
+}
+
+var _ discover.Discover = (*driverRootMounts)(nil)
+
+// mergeDriverRootMounts returns a discoverer for the mounts of the specified
+// discoverers. If only a single discoverer is specified, it is returned as
+// is.
+func mergeDriverRootMounts(logger logger.Interface, discoverers ...discover.Discover) discover.Discover {
+	if len(discoverers) == 1 {
+		return discoverers[0]
+	}
+	return &driverRootMounts{
+		logger:      logger,
+		discoverers: discoverers,
+	}
+}
+
+// Mounts returns the merged mounts of the discoverers.
+func (d *driverRootMounts) Mounts() ([]discover.Mount, error) {
+	seen := make(map[string]string)
+	var mounts []discover.Mount
+	for i, di := range d.discoverers {
+		discovered, err := di.Mounts()
+		if err != nil {
+			return nil, fmt.Errorf("error discovering mounts for driver root %v: %w", i, err)
+		}
+		for _, mount := range discovered {
+			name := filepath.Base(mount.Path)
+			if existing, ok := seen[name]; ok {
+				if existing != mount.HostPath {
+					d.logger.Debugf("Ignoring %v since %v has a higher precedence", mount.HostPath, existing)
+				}
+				continue
+			}
+			seen[name] = mount.HostPath
+			mounts = append(mounts, mount)
+		}
+	}
+	return mounts, nil
+}
+
+// drivers returns the driver root followed by the additional driver roots in
+// order of precedence.
+func (l *nvcdilib) drivers() []*root.Driver {
+	return append([]*root.Driver{l.driver}, l.additionalDrivers...)
+}
+
+// locateLibrary locates the specified library in the driver roots. The
+// candidates from the first driver root in which the library is found are
+// returned.
+func (l *nvcdilib) locateLibrary(name string) ([]string, error) {
+	var errs error
+	for _, driver := range l.drivers() {
+		candidates, err := driver.Libraries().Locate(name)
+		if err == nil {
+			return candidates, nil
+		}
+		errs = errors.Join(errs, err)
+	}
+	return nil, errs
+}
+
+// getAdditionalVersionLibs returns the libraries for the specified driver
+// version in an additional driver root. Since libcuda.so is not required to be
+// present in an additional driver root, the libraries are located in the same
+// directory as in the driver root if libcuda.so is not found.
+func getAdditionalVersionLibs(logger logger.Interface, driver *root.Driver, version string, libCudaDirectoryPath string) ([]string, error) {
+	if libs, _, err := getVersionLibs(logger, driver, version); err == nil {
+		return libs, nil
+	}
+	return getVersionLibsInDirectory(logger, driver, filepath.Join(driver.Root, libCudaDirectoryPath), version)
+}
@@ -0,0 +1,82 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+)
+
+func TestDriverRootMounts(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	driverContainer := &discover.DiscoverMock{
+		MountsFunc: func() ([]discover.Mount, error) {
+			return []discover.Mount{
+				{Path: "/usr/lib64/libcuda.so.999.88.77", HostPath: "/run/nvidia/driver/usr/lib64/libcuda.so.999.88.77"},
+			}, nil
+		},
+	}
+	host := &discover.DiscoverMock{
+		MountsFunc: func() ([]discover.Mount, error) {
+			return []discover.Mount{
+				{Path: "/usr/lib/x86_64-linux-gnu/libcuda.so.999.88.77", HostPath: "/usr/lib/x86_64-linux-gnu/libcuda.so.999.88.77"},
+				{Path: "/usr/lib/x86_64-linux-gnu/libnvidia-egl-wayland.so.999.88.77", HostPath: "/usr/lib/x86_64-linux-gnu/libnvidia-egl-wayland.so.999.88.77"},
+			}, nil
+		},
+	}
+
+	mounts, err := mergeDriverRootMounts(logger, driverContainer, host).Mounts()
+	require.NoError(t, err)
+	require.EqualValues(t,
+		[]discover.Mount{
+			{Path: "/usr/lib64/libcuda.so.999.88.77", HostPath: "/run/nvidia/driver/usr/lib64/libcuda.so.999.88.77"},
+			{Path: "/usr/lib/x86_64-linux-gnu/libnvidia-egl-wayland.so.999.88.77", HostPath: "/usr/lib/x86_64-linux-gnu/libnvidia-egl-wayland.so.999.88.77"},
+		},
+		mounts,
+	)
+
+	require.Equal(t, driverContainer, mergeDriverRootMounts(logger, driverContainer))
+}
+
+func TestGetAdditionalVersionLibs(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	hostRoot := t.TempDir()
+	libDir := filepath.Join(hostRoot, "lib", "x86_64-linux-gnu")
+	require.NoError(t, os.MkdirAll(libDir, 0755))
+	for _, lib := range []string{"libnvidia-egl-wayland.so.999.88.77", "libnvidia-egl-wayland.so.1", "libnvidia-ml.so.111.22.33"} {
+		require.NoError(t, os.WriteFile(filepath.Join(libDir, lib), nil, 0600))
+	}
+
+	driver := root.New(
+		root.WithLogger(logger),
+		root.WithDriverRoot(hostRoot),
+	)
+
+	libs, err := getAdditionalVersionLibs(logger, driver, "999.88.77", "/lib/x86_64-linux-gnu")
+	require.NoError(t, err)
+	require.EqualValues(t, []string{"/lib/x86_64-linux-gnu/libnvidia-egl-wayland.so.999.88.77"}, libs)
+}
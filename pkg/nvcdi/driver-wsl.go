@@ -18,6 +18,7 @@ package nvcdi
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
@@ -38,6 +39,14 @@ var requiredDriverStoreFiles = []string{
 	"nvidia-smi",                    /* nvidia-smi binary*/
 }
 
+const (
+	// wslDriverStoreRoot is the folder in which the Windows driver stores are
+	// made available in WSL2.
+	wslDriverStoreRoot = "/usr/lib/wsl/drivers"
+	// wslDriverStoreMarker is a file that is expected to be present in an NVIDIA driver store.
+	wslDriverStoreMarker = "libcuda.so.1.1"
+)
+
 // newWSLDriverDiscoverer returns a Discoverer for WSL2 drivers.
 func newWSLDriverDiscoverer(logger logger.Interface, driverRoot string, hookCreator discover.HookCreator, ldconfigPath string) (discover.Discover, error) {
 	if err := dxcore.Init(); err != nil {
@@ -49,7 +58,7 @@ func newWSLDriverDiscoverer(logger logger.Interface, driverRoot string, hookCrea
 		}
 	}()
 
-	driverStorePaths := dxcore.GetDriverStorePaths()
+	driverStorePaths := resolveDriverStorePaths(logger, wslDriverStoreRoot, dxcore.GetDriverStorePaths())
 	if len(driverStorePaths) == 0 {
 		return nil, fmt.Errorf("no driver store paths found")
 	}
@@ -90,6 +99,51 @@ func newWSLDriverDiscoverer(logger logger.Interface, driverRoot string, hookCrea
 	return d, nil
 }
 
+// resolveDriverStorePaths returns the driver store paths to use.
+// The paths reported by dxcore are used if these contain the expected driver
+// files. Since the driver store path changes when the Windows driver is
+// updated, the reported paths may be stale -- for example if the WSL VM has not
+// been restarted. In this case, the NVIDIA driver stores are located by
+// searching the specified root instead.
+func resolveDriverStorePaths(logger logger.Interface, driverStoreRoot string, reported []string) []string {
+	var paths []string
+	for _, path := range reported {
+		if !isDriverStore(path) {
+			logger.Warningf("Ignoring invalid driver store path %v", path)
+			continue
+		}
+		paths = append(paths, path)
+	}
+	if len(paths) > 0 {
+		return paths
+	}
+
+	candidates, err := filepath.Glob(filepath.Join(driverStoreRoot, "nv*"))
+	if err != nil {
+		logger.Warningf("Failed to search for driver stores in %v: %v", driverStoreRoot, err)
+		return nil
+	}
+	for _, candidate := range candidates {
+		if !isDriverStore(candidate) {
+			continue
+		}
+		paths = append(paths, candidate)
+	}
+	if len(paths) > 0 {
+		logger.Infof("Located driver stores at %v", paths)
+	}
+	return paths
+}
+
+// isDriverStore checks whether the specified path is an NVIDIA driver store.
+func isDriverStore(path string) bool {
+	info, err := os.Stat(filepath.Join(path, wslDriverStoreMarker))
+	if err != nil {
+		return false
+	}
+	return !info.IsDir()
+}
+
 type nvidiaSMISimlinkHook struct {
 	discover.None
 	logger      logger.Interface
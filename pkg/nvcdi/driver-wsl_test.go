@@ -18,6 +18,8 @@ package nvcdi
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -166,3 +168,64 @@ func TestNvidiaSMISymlinkHook(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveDriverStorePaths(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description   string
+		driverStores  []string
+		otherDirs     []string
+		reported      []string
+		expectedPaths []string
+	}{
+		{
+			description:   "valid reported path is used",
+			driverStores:  []string{"nvmi.inf_amd64_current", "nvmi.inf_amd64_other"},
+			reported:      []string{"nvmi.inf_amd64_current"},
+			expectedPaths: []string{"nvmi.inf_amd64_current"},
+		},
+		{
+			description:   "stale reported path is replaced",
+			driverStores:  []string{"nvmi.inf_amd64_new"},
+			reported:      []string{"nvmi.inf_amd64_old"},
+			expectedPaths: []string{"nvmi.inf_amd64_new"},
+		},
+		{
+			description:   "non-driver-store folders are ignored",
+			driverStores:  []string{"nvmi.inf_amd64_new"},
+			otherDirs:     []string{"nvother.inf_amd64_abc", "u0123.inf_amd64_abc"},
+			expectedPaths: []string{"nvmi.inf_amd64_new"},
+		},
+		{
+			description: "no driver stores returns empty",
+			otherDirs:   []string{"nvother.inf_amd64_abc"},
+			reported:    []string{"nvmi.inf_amd64_old"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			root := t.TempDir()
+			for _, dir := range tc.otherDirs {
+				require.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0755))
+			}
+			for _, dir := range tc.driverStores {
+				require.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0755))
+				require.NoError(t, os.WriteFile(filepath.Join(root, dir, wslDriverStoreMarker), nil, 0644))
+			}
+
+			var reported []string
+			for _, path := range tc.reported {
+				reported = append(reported, filepath.Join(root, path))
+			}
+			var expectedPaths []string
+			for _, path := range tc.expectedPaths {
+				expectedPaths = append(expectedPaths, filepath.Join(root, path))
+			}
+
+			paths := resolveDriverStorePaths(logger, root, reported)
+			require.EqualValues(t, expectedPaths, paths)
+		})
+	}
+}
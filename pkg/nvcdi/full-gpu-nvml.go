@@ -127,5 +127,16 @@ func (l *fullGPUDeviceSpecGenerator) newFullGPUDiscoverer(d device.Device) (disc
 		deviceFolderPermissionHooks,
 	)
 
+	if l.includeRDMA {
+		pciBusID, err := d.GetPCIBusID()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get PCI bus ID: %v", err)
+		}
+		dd = discover.Merge(
+			dd,
+			discover.NewColocatedUverbsDiscoverer(l.logger, l.devRoot, pciBusID),
+		)
+	}
+
 	return dd, nil
 }
@@ -35,8 +35,10 @@ func (l *gdslib) DeviceSpecGenerators(...string) (DeviceSpecGenerator, error) {
 }
 
 // GetDeviceSpecs returns the CDI device specs for a single all device.
+// This includes the nvidia-fs device nodes, the cuFile libraries, and the
+// cuFile config file.
 func (l *gdslib) GetDeviceSpecs() ([]specs.Device, error) {
-	discoverer, err := discover.NewGDSDiscoverer(l.logger, l.driverRoot, l.devRoot)
+	discoverer, err := discover.NewGDSDiscovererWithLibraries(l.logger, l.driver, l.devRoot, l.hookCreator, l.ldconfigPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GPUDirect Storage discoverer: %v", err)
 	}
@@ -0,0 +1,81 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info/proc"
+)
+
+const (
+	// KernelModuleFlavorAnnotation is the spec annotation used to indicate the
+	// flavor (open or proprietary) of the kernel module that the spec was
+	// generated for.
+	KernelModuleFlavorAnnotation = "nvidia.com/kernel-module-flavor"
+)
+
+// getKernelModuleInfo returns the information for the loaded kernel module.
+// Since the kernel module is shared by all containers on the system, the host
+// /proc is always used. If the information cannot be determined, nil is
+// returned.
+func (l *nvcdilib) getKernelModuleInfo() *proc.KernelModuleInfo {
+	info, err := proc.GetKernelModuleInfo("/")
+	if err != nil {
+		l.logger.Debugf("Could not determine kernel module flavor: %v", err)
+		return nil
+	}
+	l.logger.Infof("Detected %v kernel module version %v", info.Flavor, info.Version)
+	return info
+}
+
+// getSpecAnnotations returns the annotations for generated specs.
+func (l *nvcdilib) getSpecAnnotations() map[string]string {
+	if l.kernelModule == nil {
+		return nil
+	}
+	return map[string]string{
+		KernelModuleFlavorAnnotation: string(l.kernelModule.Flavor),
+	}
+}
+
+// requiresGSPFirmware checks whether the GSP firmware is required for the
+// loaded kernel module. This is the case for the open kernel module.
+func (l *nvcdilib) requiresGSPFirmware() bool {
+	return l.kernelModule != nil && l.kernelModule.Flavor == proc.KernelModuleFlavorOpen
+}
+
+// requiredMounts wraps a discoverer and returns an error if no mounts are
+// discovered.
+type requiredMounts struct {
+	discover.Discover
+	description string
+}
+
+// Mounts returns the mounts of the wrapped discoverer or an error if there are none.
+func (d *requiredMounts) Mounts() ([]discover.Mount, error) {
+	mounts, err := d.Discover.Mounts()
+	if err != nil {
+		return nil, err
+	}
+	if len(mounts) == 0 {
+		return nil, fmt.Errorf("no %v found", d.description)
+	}
+	return mounts, nil
+}
@@ -39,7 +39,9 @@ type imexChannel struct {
 var _ deviceSpecGeneratorFactory = (*imexlib)(nil)
 
 const (
-	classImexChannel = "imex-channel"
+	// ClassImexChannel is the default CDI class of the devices generated for
+	// IMEX channels.
+	ClassImexChannel = "imex-channel"
 )
 
 // GetCommonEdits returns an empty set of edits for IMEX devices.
@@ -27,6 +27,7 @@ import (
 	"tags.cncf.io/container-device-interface/specs-go"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/edits"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/messages"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/nvsandboxutils"
 )
 
@@ -50,6 +51,8 @@ func (l *nvmllib) GetCommonEdits() (*cdi.ContainerEdits, error) {
 // * an index of a GPU or MIG device
 // * a UUID of a GPU or MIG device
 // * the special ID 'all'
+// * a driver capability (compute, utility, graphics, or video) if capability
+// devices are enabled
 func (l *nvmllib) DeviceSpecGenerators(ids ...string) (DeviceSpecGenerator, error) {
 	if err := l.init(); err != nil {
 		return nil, err
@@ -64,11 +67,18 @@ func (l *nvmllib) DeviceSpecGenerators(ids ...string) (DeviceSpecGenerator, erro
 }
 
 func (l *nvmllib) getDeviceSpecGeneratorsForIDs(ids ...string) (DeviceSpecGenerator, error) {
+	var deviceIDs []string
 	var identifiers []device.Identifier
+	var capabilityGenerators DeviceSpecGenerators
 	for _, id := range ids {
 		if id == "all" {
 			return l.getDeviceSpecGeneratorsForAllDevices()
 		}
+		if capability, ok := getCapabilityDevice(id); ok && l.capabilityDevices {
+			capabilityGenerators = append(capabilityGenerators, &capabilityDeviceSpecGenerator{nvmllib: l, capability: capability})
+			continue
+		}
+		deviceIDs = append(deviceIDs, id)
 		identifiers = append(identifiers, device.Identifier(id))
 	}
 
@@ -79,12 +89,13 @@ func (l *nvmllib) getDeviceSpecGeneratorsForIDs(ids ...string) (DeviceSpecGenera
 
 	var DeviceSpecGenerators DeviceSpecGenerators
 	for i, device := range devices {
-		editor, err := l.newDeviceSpecGeneratorFromNVMLDevice(ids[i], device)
+		editor, err := l.newDeviceSpecGeneratorFromNVMLDevice(deviceIDs[i], device)
 		if err != nil {
 			return nil, err
 		}
 		DeviceSpecGenerators = append(DeviceSpecGenerators, editor)
 	}
+	DeviceSpecGenerators = append(DeviceSpecGenerators, capabilityGenerators...)
 
 	return DeviceSpecGenerators, nil
 }
@@ -146,6 +157,7 @@ func (l *nvmllib) getDeviceSpecGeneratorsForAllDevices() (DeviceSpecGenerator, e
 	if err != nil {
 		return nil, fmt.Errorf("failed to get MIG device editors: %w", err)
 	}
+	DeviceSpecGenerators = append(DeviceSpecGenerators, l.getCapabilityDeviceSpecGenerators()...)
 
 	return DeviceSpecGenerators, nil
 }
@@ -199,7 +211,7 @@ func (l *nvmllib) getNVMLDeviceByID(id device.Identifier) (nvml.Device, error) {
 
 func (l *nvmllib) init() error {
 	if r := l.nvmllib.Init(); r != nvml.SUCCESS {
-		return fmt.Errorf("failed to initialize NVML: %w", r)
+		return messages.Wrap(r, messages.NVMLInitFailed, nil)
 	}
 
 	if l.nvsandboxutilslib == nil {
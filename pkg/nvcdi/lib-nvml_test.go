@@ -32,6 +32,7 @@ func TestNvmllibGetDeviceSpecGeneratorsForIDs(t *testing.T) {
 	testCases := []struct {
 		name               string
 		ids                []string
+		capabilityDevices  bool
 		setupMock          func(*dgxa100.Server)
 		expectedError      error
 		expectedLength     int
@@ -103,6 +104,28 @@ func TestNvmllibGetDeviceSpecGeneratorsForIDs(t *testing.T) {
 			expectedError:  nil,
 			expectedLength: 1,
 		},
+		{
+			name:              "all devices with capability devices",
+			ids:               []string{"all"},
+			capabilityDevices: true,
+			expectedError:     nil,
+			expectedLength:    12,
+		},
+		{
+			name:              "GPU index and capability device",
+			ids:               []string{"0", "graphics"},
+			capabilityDevices: true,
+			setupMock: func(server *dgxa100.Server) {
+				for _, d := range server.Devices {
+					// TODO: This is not implemented in the mock.
+					(d.(*dgxa100.Device)).IsMigDeviceHandleFunc = func() (bool, nvml.Return) {
+						return false, nvml.SUCCESS
+					}
+				}
+			},
+			expectedError:  nil,
+			expectedLength: 2,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -117,8 +140,9 @@ func TestNvmllibGetDeviceSpecGeneratorsForIDs(t *testing.T) {
 			mockDev := device.New(mockNvml)
 
 			l := &nvmllib{
-				nvmllib:   mockNvml,
-				devicelib: mockDev,
+				nvmllib:           mockNvml,
+				devicelib:         mockDev,
+				capabilityDevices: tc.capabilityDevices,
 			}
 			// Call the function under test
 			generators, err := l.getDeviceSpecGeneratorsForIDs(tc.ids...)
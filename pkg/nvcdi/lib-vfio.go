@@ -0,0 +1,216 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvpci"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/edits"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/vfio"
+)
+
+type vfiolib nvcdilib
+
+type vfioDevice struct {
+	*vfiolib
+	index int
+	group int
+}
+
+var _ deviceSpecGeneratorFactory = (*vfiolib)(nil)
+
+const (
+	classVfio = "vfio"
+)
+
+// GetCommonEdits returns the edits for the VFIO container device which is
+// required for all VFIO groups.
+func (l *vfiolib) GetCommonEdits() (*cdi.ContainerEdits, error) {
+	d := discover.NewCharDeviceDiscoverer(
+		l.logger,
+		l.devRoot,
+		[]string{"/dev/vfio/vfio"},
+	)
+	return edits.FromDiscoverer(d)
+}
+
+// DeviceSpecGenerators returns the CDI device spec generators for the
+// specified GPUs that are bound to the vfio-pci driver. The devices are named
+// using the configured device namers with the index of the GPU in the list of
+// VFIO devices.
+// Valid IDs are:
+// * the index of the GPU in the list of VFIO devices
+// * the PCI bus ID of the GPU
+// * the special ID 'all'
+func (l *vfiolib) DeviceSpecGenerators(ids ...string) (DeviceSpecGenerator, error) {
+	gpus, err := l.getVfioGPUs()
+	if err != nil {
+		return nil, err
+	}
+
+	var deviceSpecGenerators DeviceSpecGenerators
+	for _, id := range ids {
+		if id == "all" {
+			deviceSpecGenerators = nil
+			for i, gpu := range gpus {
+				deviceSpecGenerators = append(deviceSpecGenerators, l.newVfioDevice(i, gpu))
+			}
+			return deviceSpecGenerators, nil
+		}
+		index, err := findVfioGPU(gpus, id)
+		if err != nil {
+			return nil, err
+		}
+		deviceSpecGenerators = append(deviceSpecGenerators, l.newVfioDevice(index, gpus[index]))
+	}
+	return deviceSpecGenerators, nil
+}
+
+func (l *vfiolib) newVfioDevice(index int, gpu *nvpci.NvidiaPCIDevice) *vfioDevice {
+	return &vfioDevice{
+		vfiolib: l,
+		index:   index,
+		group:   gpu.IommuGroup,
+	}
+}
+
+// findVfioGPU returns the index of the GPU with the specified index or PCI bus
+// ID.
+func findVfioGPU(gpus []*nvpci.NvidiaPCIDevice, id string) (int, error) {
+	if index, err := strconv.Atoi(id); err == nil {
+		if index < 0 || index >= len(gpus) {
+			return 0, fmt.Errorf("invalid VFIO device index %v", id)
+		}
+		return index, nil
+	}
+	for i, gpu := range gpus {
+		if gpu.Address == id {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("no GPU bound to %v with PCI bus ID %v", vfio.PCIDriver, id)
+}
+
+// getVfioGPUs returns the NVIDIA GPUs that are bound to the vfio-pci driver.
+// An error is returned if the IOMMU group of a GPU contains devices that are
+// not bound to a VFIO-compatible driver since such a group cannot be used by
+// a VMM.
+func (l *vfiolib) getVfioGPUs() ([]*nvpci.NvidiaPCIDevice, error) {
+	pcilib := l.pcilib
+	if pcilib == nil {
+		pcilib = nvpci.New(
+			nvpci.WithPCIDevicesRoot(filepath.Join(l.driverRoot, nvpci.PCIDevicesRoot)),
+			nvpci.WithLogger(l.logger),
+		)
+	}
+	gpus, err := pcilib.GetGPUs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GPUs: %w", err)
+	}
+
+	var vfioGPUs []*nvpci.NvidiaPCIDevice
+	for _, gpu := range gpus {
+		if gpu.Driver != vfio.PCIDriver {
+			l.logger.Debugf("Skipping GPU %v bound to driver %q", gpu.Address, gpu.Driver)
+			continue
+		}
+		if gpu.IommuGroup < 0 {
+			return nil, fmt.Errorf("GPU %v is not in an IOMMU group", gpu.Address)
+		}
+		if err := validateIOMMUGroup(gpu); err != nil {
+			return nil, err
+		}
+		vfioGPUs = append(vfioGPUs, gpu)
+	}
+	return vfioGPUs, nil
+}
+
+// validateIOMMUGroup ensures that all devices in the IOMMU group of the
+// specified GPU are either unbound or bound to VFIO-compatible drivers.
+func validateIOMMUGroup(gpu *nvpci.NvidiaPCIDevice) error {
+	members, err := vfio.GetIOMMUGroupDevices(gpu)
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		if member.Driver != "" && !vfio.IsCompatibleDriver(member.Driver) {
+			return fmt.Errorf("IOMMU group %v of GPU %v contains device %v bound to driver %q", gpu.IommuGroup, gpu.Address, member.Address, member.Driver)
+		}
+	}
+	return nil
+}
+
+// GetDeviceSpecs returns the CDI device specs for the VFIO group of a GPU.
+// A device is returned for each name generated by the device namers.
+func (d *vfioDevice) GetDeviceSpecs() ([]specs.Device, error) {
+	names, err := d.getNames()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get device names: %w", err)
+	}
+
+	path := fmt.Sprintf("/dev/vfio/%d", d.group)
+	var deviceSpecs []specs.Device
+	for _, name := range names {
+		deviceSpec := specs.Device{
+			Name: name,
+			ContainerEdits: specs.ContainerEdits{
+				DeviceNodes: []*specs.DeviceNode{
+					{
+						Path:     path,
+						HostPath: filepath.Join(d.devRoot, path),
+					},
+				},
+			},
+		}
+		deviceSpecs = append(deviceSpecs, deviceSpec)
+	}
+	return deviceSpecs, nil
+}
+
+// getNames returns the names of the device. Since a GPU that is bound to the
+// vfio-pci driver is not visible to the NVIDIA driver, its UUID cannot be
+// queried and namers that require the UUID are skipped.
+func (d *vfioDevice) getNames() ([]string, error) {
+	var names []string
+	for _, namer := range d.deviceNamers {
+		name, err := namer.GetDeviceName(d.index, uuidUnsupported{})
+		if errors.Is(err, errUUIDUnsupported) {
+			d.logger.Debugf("Skipping unsupported device name strategy for VFIO device %d", d.index)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if name == "" {
+			continue
+		}
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return nil, errors.New("no names defined")
+	}
+	return names, nil
+}
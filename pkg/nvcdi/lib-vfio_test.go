@@ -0,0 +1,197 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvpci"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+)
+
+type fakePCILib struct {
+	nvpci.Interface
+	gpus []*nvpci.NvidiaPCIDevice
+}
+
+func (f *fakePCILib) GetGPUs() ([]*nvpci.NvidiaPCIDevice, error) {
+	return f.gpus, nil
+}
+
+func TestVfioMode(t *testing.T) {
+	testCases := []struct {
+		description   string
+		setup         func(string) []*nvpci.NvidiaPCIDevice
+		namers        []string
+		ids           []string
+		expectedError bool
+		expectedNodes map[string]string
+	}{
+		{
+			description: "GPUs not bound to vfio-pci are skipped",
+			setup: func(sysfs string) []*nvpci.NvidiaPCIDevice {
+				return []*nvpci.NvidiaPCIDevice{
+					{
+						Address:    "0000:01:00.0",
+						Driver:     "nvidia",
+						IommuGroup: 10,
+						Path:       test.CreatePCIDevice(t, sysfs, "0000:01:00.0", "10", "nvidia"),
+					},
+					{
+						Address:    "0000:02:00.0",
+						Driver:     "vfio-pci",
+						IommuGroup: 20,
+						Path:       test.CreatePCIDevice(t, sysfs, "0000:02:00.0", "20", "vfio-pci"),
+					},
+				}
+			},
+			ids: []string{"all"},
+			expectedNodes: map[string]string{
+				"0": "/dev/vfio/20",
+			},
+		},
+		{
+			description: "GPU can be selected by PCI bus ID",
+			setup: func(sysfs string) []*nvpci.NvidiaPCIDevice {
+				return []*nvpci.NvidiaPCIDevice{
+					{
+						Address:    "0000:02:00.0",
+						Driver:     "vfio-pci",
+						IommuGroup: 20,
+						Path:       test.CreatePCIDevice(t, sysfs, "0000:02:00.0", "20", "vfio-pci"),
+					},
+				}
+			},
+			ids: []string{"0000:02:00.0"},
+			expectedNodes: map[string]string{
+				"0": "/dev/vfio/20",
+			},
+		},
+		{
+			description: "device name strategies requiring a UUID are skipped",
+			setup: func(sysfs string) []*nvpci.NvidiaPCIDevice {
+				return []*nvpci.NvidiaPCIDevice{
+					{
+						Address:    "0000:02:00.0",
+						Driver:     "vfio-pci",
+						IommuGroup: 20,
+						Path:       test.CreatePCIDevice(t, sysfs, "0000:02:00.0", "20", "vfio-pci"),
+					},
+				}
+			},
+			namers: []string{DeviceNameStrategyIndex, DeviceNameStrategyUUID, DeviceNameStrategyTypeIndex},
+			ids:    []string{"all"},
+			expectedNodes: map[string]string{
+				"0":    "/dev/vfio/20",
+				"gpu0": "/dev/vfio/20",
+			},
+		},
+		{
+			description: "group with vfio-compatible members is valid",
+			setup: func(sysfs string) []*nvpci.NvidiaPCIDevice {
+				test.CreatePCIDevice(t, sysfs, "0000:02:00.1", "20", "vfio-pci")
+				return []*nvpci.NvidiaPCIDevice{
+					{
+						Address:    "0000:02:00.0",
+						Driver:     "vfio-pci",
+						IommuGroup: 20,
+						Path:       test.CreatePCIDevice(t, sysfs, "0000:02:00.0", "20", "vfio-pci"),
+					},
+				}
+			},
+			ids: []string{"all"},
+			expectedNodes: map[string]string{
+				"0": "/dev/vfio/20",
+			},
+		},
+		{
+			description: "group with member bound to host driver is invalid",
+			setup: func(sysfs string) []*nvpci.NvidiaPCIDevice {
+				test.CreatePCIDevice(t, sysfs, "0000:02:00.1", "20", "snd_hda_intel")
+				return []*nvpci.NvidiaPCIDevice{
+					{
+						Address:    "0000:02:00.0",
+						Driver:     "vfio-pci",
+						IommuGroup: 20,
+						Path:       test.CreatePCIDevice(t, sysfs, "0000:02:00.0", "20", "vfio-pci"),
+					},
+				}
+			},
+			ids:           []string{"all"},
+			expectedError: true,
+		},
+		{
+			description: "invalid index returns error",
+			setup: func(sysfs string) []*nvpci.NvidiaPCIDevice {
+				return []*nvpci.NvidiaPCIDevice{
+					{
+						Address:    "0000:02:00.0",
+						Driver:     "vfio-pci",
+						IommuGroup: 20,
+						Path:       test.CreatePCIDevice(t, sysfs, "0000:02:00.0", "20", "vfio-pci"),
+					},
+				}
+			},
+			ids:           []string{"1"},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			logger, _ := testlog.NewNullLogger()
+			devRoot := t.TempDir()
+
+			var deviceNamers []DeviceNamer
+			for _, strategy := range tc.namers {
+				namer, err := NewDeviceNamer(strategy)
+				require.NoError(t, err)
+				deviceNamers = append(deviceNamers, namer)
+			}
+
+			lib, err := New(
+				WithLogger(logger),
+				WithMode(ModeVfio),
+				WithDevRoot(devRoot),
+				WithDeviceNamers(deviceNamers...),
+				WithPCILib(&fakePCILib{gpus: tc.setup(t.TempDir())}),
+			)
+			require.NoError(t, err)
+
+			devices, err := lib.GetDeviceSpecsByID(tc.ids...)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			nodes := make(map[string]string)
+			for _, device := range devices {
+				require.Len(t, device.ContainerEdits.DeviceNodes, 1)
+				node := device.ContainerEdits.DeviceNodes[0]
+				require.Equal(t, filepath.Join(devRoot, node.Path), node.HostPath)
+				nodes[device.Name] = node.Path
+			}
+			require.EqualValues(t, tc.expectedNodes, nodes)
+		})
+	}
+}
@@ -21,29 +21,41 @@ import (
 
 	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
 	"github.com/NVIDIA/go-nvlib/pkg/nvlib/info"
+	"github.com/NVIDIA/go-nvlib/pkg/nvpci"
 	"github.com/NVIDIA/go-nvml/pkg/nvml"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/failinject"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info/proc"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/nvcaps"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/nvsandboxutils"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/platform-support/tegra/csv"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/transform"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/plugin"
 )
 
 type nvcdilib struct {
-	logger             logger.Interface
-	nvmllib            nvml.Interface
-	nvsandboxutilslib  nvsandboxutils.Interface
-	mode               Mode
-	devicelib          device.Interface
-	deviceNamers       DeviceNamers
-	driverRoot         string
-	devRoot            string
-	nvidiaCDIHookPath  string
-	ldconfigPath       string
-	configSearchPaths  []string
-	librarySearchPaths []string
+	logger            logger.Interface
+	nvmllib           nvml.Interface
+	pcilib            nvpci.Interface
+	nvsandboxutilslib nvsandboxutils.Interface
+	mode              Mode
+	devicelib         device.Interface
+	deviceNamers      DeviceNamers
+	// migDeviceNamer, if set, is used to generate the names of MIG devices
+	// instead of the deviceNamers.
+	migDeviceNamer DeviceNamer
+	driverRoot     string
+	// additionalDriverRoots are searched for driver libraries and binaries
+	// that are not found in the driverRoot. The roots are searched in order.
+	additionalDriverRoots []string
+	devRoot               string
+	nvidiaCDIHookPath     string
+	ldconfigPath          string
+	configSearchPaths     []string
+	librarySearchPaths    []string
 
 	csvFiles          []string
 	csvIgnorePatterns []string
@@ -51,15 +63,51 @@ type nvcdilib struct {
 	vendor string
 	class  string
 
+	// includeRDMA indicates whether the devices required for GPUDirect RDMA
+	// should be included.
+	includeRDMA bool
+
+	// capabilityDevices indicates whether the driver files associated with
+	// each driver capability are included in a separate CDI device instead of
+	// in the common edits.
+	capabilityDevices bool
+
+	// minimalDriverFiles indicates whether only the CUDA driver library and
+	// NVML are included from the driver files.
+	minimalDriverFiles bool
+	// nvidiaSMILitePath is the path of the nvidia-smi-lite executable that is
+	// included in the common edits. If this is not set, it is not included.
+	nvidiaSMILitePath string
+
 	driver  *root.Driver
 	infolib info.Interface
+	// additionalDrivers are the driver roots constructed from the
+	// additionalDriverRoots.
+	additionalDrivers []*root.Driver
+
+	// driverVersion is the explicitly requested driver version.
+	driverVersion string
+	// kernelModule stores the information for the loaded kernel module.
+	kernelModule *proc.KernelModuleInfo
 
 	mergedDeviceOptions []transform.MergedDeviceOption
 
 	featureFlags map[FeatureFlag]bool
 
 	disabledHooks []discover.HookName
+	hookEnv       []string
 	hookCreator   discover.HookCreator
+
+	deviceNodeOwnership transform.Transformer
+
+	// plugins lists the executables that are invoked to add container edits
+	// to the common edits.
+	plugins []string
+
+	// migCaps stores the MIG capability device paths. If this is not set,
+	// these are read from /proc/driver/nvidia-caps when MIG devices are
+	// processed.
+	migCaps nvcaps.MigCaps
 }
 
 // New creates a new nvcdi library
@@ -78,6 +126,9 @@ func New(opts ...Option) (Interface, error) {
 		indexNamer, _ := NewDeviceNamer(DeviceNameStrategyIndex)
 		l.deviceNamers = []DeviceNamer{indexNamer}
 	}
+	if l.migDeviceNamer != nil {
+		l.deviceNamers = l.deviceNamers.withMigDeviceNamer(l.migDeviceNamer)
+	}
 	if l.nvidiaCDIHookPath == "" {
 		l.nvidiaCDIHookPath = "/usr/bin/nvidia-cdi-hook"
 	}
@@ -93,9 +144,16 @@ func New(opts ...Option) (Interface, error) {
 		root.WithLibrarySearchPaths(l.librarySearchPaths...),
 		root.WithConfigSearchPaths(l.configSearchPaths...),
 	)
+	for _, driverRoot := range l.additionalDriverRoots {
+		l.additionalDrivers = append(l.additionalDrivers, root.New(
+			root.WithLogger(l.logger),
+			root.WithDriverRoot(driverRoot),
+			root.WithLibrarySearchPaths(l.librarySearchPaths...),
+		))
+	}
 	if l.nvmllib == nil {
 		var nvmlOpts []nvml.LibraryOption
-		candidates, err := l.driver.Libraries().Locate("libnvidia-ml.so.1")
+		candidates, err := l.locateLibrary("libnvidia-ml.so.1")
 		if err != nil {
 			l.logger.Warningf("Ignoring error in locating libnvidia-ml.so.1: %v", err)
 		} else {
@@ -105,6 +163,19 @@ func New(opts ...Option) (Interface, error) {
 		}
 		l.nvmllib = nvml.New(nvmlOpts...)
 	}
+	l.nvmllib = failinject.NVML(l.nvmllib)
+	if l.driverVersion != "" {
+		// Note that NVML is located before the driver root is updated for the
+		// requested version since it is used to query the devices.
+		driver, err := l.driver.ForVersion(l.driverVersion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve driver version %v: %w", l.driverVersion, err)
+		}
+		l.driver = driver
+	}
+	if l.minimalDriverFiles && l.capabilityDevices {
+		return nil, fmt.Errorf("minimal driver files are not supported with capability devices")
+	}
 	l.nvsandboxutilslib = l.getNvsandboxUtilsLib()
 	if l.devicelib == nil {
 		l.devicelib = device.New(l.nvmllib)
@@ -126,6 +197,7 @@ func New(opts ...Option) (Interface, error) {
 		}
 		factory = (*csvlib)(l)
 	case ModeManagement:
+		l.kernelModule = l.getKernelModuleInfo()
 		if l.vendor == "" {
 			l.vendor = "management.nvidia.com"
 		}
@@ -133,6 +205,7 @@ func New(opts ...Option) (Interface, error) {
 		l.disabledHooks = append(l.disabledHooks, HookEnableCudaCompat, DisableDeviceNodeModificationHook)
 		factory = (*managementlib)(l)
 	case ModeNvml:
+		l.kernelModule = l.getKernelModuleInfo()
 		factory = (*nvmllib)(l)
 	case ModeWsl:
 		factory = (*wsllib)(l)
@@ -148,9 +221,14 @@ func New(opts ...Option) (Interface, error) {
 		factory = (*mofedlib)(l)
 	case ModeImex:
 		if l.class == "" {
-			l.class = classImexChannel
+			l.class = ClassImexChannel
 		}
 		factory = (*imexlib)(l)
+	case ModeVfio:
+		if l.class == "" {
+			l.class = classVfio
+		}
+		factory = (*vfiolib)(l)
 	default:
 		return nil, fmt.Errorf("unknown mode %q", l.mode)
 	}
@@ -159,19 +237,41 @@ func New(opts ...Option) (Interface, error) {
 	l.hookCreator = discover.NewHookCreator(
 		discover.WithNVIDIACDIHookPath(l.nvidiaCDIHookPath),
 		discover.WithDisabledHooks(l.disabledHooks...),
+		discover.WithEnv(l.hookEnv...),
 	)
 
 	w := wrapper{
 		factory:             factory,
 		vendor:              l.vendor,
 		class:               l.class,
+		annotations:         l.getSpecAnnotations(),
 		mergedDeviceOptions: l.mergedDeviceOptions,
+		deviceNodeOwnership: l.deviceNodeOwnership,
+		plugins:             l.getPluginDiscoverer(),
 	}
 	return &w, nil
 }
 
+// getPluginDiscoverer returns a discoverer for the edits returned by the
+// configured plugins. If no plugins are configured, nil is returned.
+func (l *nvcdilib) getPluginDiscoverer() discover.Discover {
+	if len(l.plugins) == 0 {
+		return nil
+	}
+	request := &plugin.Request{
+		Pipeline:   plugin.PipelineCDI,
+		DriverRoot: l.driverRoot,
+		DevRoot:    l.devRoot,
+	}
+	return discover.NewPluginDiscoverers(l.logger, l.plugins, request)
+}
+
 // getCudaVersion returns the CUDA version of the current system.
+// If a driver version was explicitly requested, this is returned instead.
 func (l *nvcdilib) getCudaVersion() (string, error) {
+	if l.driverVersion != "" {
+		return l.driverVersion, nil
+	}
 	version, err := l.getCudaVersionNvsandboxutils()
 	if err == nil {
 		return version, err
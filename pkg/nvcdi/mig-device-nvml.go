@@ -90,12 +90,16 @@ func (l *migDeviceSpecGenerator) GetDeviceSpecs() ([]specs.Device, error) {
 
 // GetMIGDeviceEdits returns the CDI edits for the MIG device represented by 'mig' on 'parent'.
 func (l *migDeviceSpecGenerator) getDeviceEdits() (*cdi.ContainerEdits, error) {
-	deviceNodes, err := dgpu.NewForMigDevice(l.device, l.migDevice,
+	opts := []dgpu.Option{
 		dgpu.WithDevRoot(l.devRoot),
 		dgpu.WithLogger(l.logger),
 		dgpu.WithHookCreator(l.hookCreator),
 		dgpu.WithNvsandboxuitilsLib(l.nvsandboxutilslib),
-	)
+	}
+	if l.migCaps != nil {
+		opts = append(opts, dgpu.WithMIGCaps(l.migCaps))
+	}
+	deviceNodes, err := dgpu.NewForMigDevice(l.device, l.migDevice, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create device discoverer: %v", err)
 	}
@@ -109,5 +113,5 @@ func (l *migDeviceSpecGenerator) getDeviceEdits() (*cdi.ContainerEdits, error) {
 }
 
 func (l *migDeviceSpecGenerator) getNames() ([]string, error) {
-	return l.deviceNamers.GetMigDeviceNames(l.index, convert{l.device}, l.migIndex, convert{l.migDevice})
+	return l.deviceNamers.GetMigDeviceNames(l.index, convert{l.device}, l.migIndex, convertMigDevice{l.migDevice})
 }
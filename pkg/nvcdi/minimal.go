@@ -0,0 +1,94 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+const (
+	nvidiaSMILiteContainerPath = "/usr/bin/nvidia-smi-lite"
+)
+
+// minimalDriverLibraryPatterns lists the patterns of the driver libraries that
+// are included if minimal driver files are requested.
+var minimalDriverLibraryPatterns = []string{
+	"libcuda.so*",
+	"libnvidia-ml.so*",
+}
+
+// filterMinimalDriverLibraries returns the driver libraries that are included
+// if minimal driver files are requested.
+func filterMinimalDriverLibraries(libraries []string) []string {
+	var filtered []string
+	for _, library := range libraries {
+		name := filepath.Base(library)
+		for _, pattern := range minimalDriverLibraryPatterns {
+			if match, _ := filepath.Match(pattern, name); match {
+				filtered = append(filtered, library)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// nvidiaSMILite is a discoverer for the nvidia-smi-lite executable. Since this
+// is only used for debugging, a missing executable is not considered an error.
+type nvidiaSMILite struct {
+	discover.None
+	logger logger.Interface
+	path   string
+}
+
+// newNvidiaSMILiteDiscoverer creates a discoverer for the nvidia-smi-lite
+// executable at the specified path. If no path is specified, nothing is
+// discovered.
+func newNvidiaSMILiteDiscoverer(logger logger.Interface, path string) discover.Discover {
+	if path == "" {
+		return discover.None{}
+	}
+	return &nvidiaSMILite{
+		logger: logger,
+		path:   path,
+	}
+}
+
+// Mounts returns a read-only mount for the nvidia-smi-lite executable.
+func (d *nvidiaSMILite) Mounts() ([]discover.Mount, error) {
+	if _, err := os.Stat(d.path); err != nil {
+		d.logger.Warningf("Skipping nvidia-smi-lite: %v", err)
+		return nil, nil
+	}
+	mount := discover.Mount{
+		HostPath: d.path,
+		Path:     nvidiaSMILiteContainerPath,
+		Options: []string{
+			"ro",
+			"nosuid",
+			"nodev",
+			"rbind",
+			"rprivate",
+		},
+	}
+	return []discover.Mount{mount}, nil
+}
@@ -0,0 +1,89 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+)
+
+func TestFilterMinimalDriverLibraries(t *testing.T) {
+	libraries := []string{
+		"/usr/lib64/libcuda.so.550.54.15",
+		"/usr/lib64/libcudadebugger.so.550.54.15",
+		"/usr/lib64/libnvidia-ml.so.550.54.15",
+		"/usr/lib64/libnvidia-cfg.so.550.54.15",
+		"/usr/lib64/libGLX_nvidia.so.550.54.15",
+	}
+
+	require.EqualValues(t,
+		[]string{
+			"/usr/lib64/libcuda.so.550.54.15",
+			"/usr/lib64/libnvidia-ml.so.550.54.15",
+		},
+		filterMinimalDriverLibraries(libraries),
+	)
+}
+
+func TestNvidiaSMILiteDiscoverer(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	path := filepath.Join(t.TempDir(), "nvidia-smi-lite")
+	require.NoError(t, os.WriteFile(path, nil, 0755))
+
+	testCases := []struct {
+		description    string
+		path           string
+		expectedMounts []discover.Mount
+	}{
+		{
+			description: "no path returns no mounts",
+		},
+		{
+			description: "missing executable is skipped",
+			path:        filepath.Join(filepath.Dir(path), "missing"),
+		},
+		{
+			description: "executable is mounted",
+			path:        path,
+			expectedMounts: []discover.Mount{
+				{
+					HostPath: path,
+					Path:     "/usr/bin/nvidia-smi-lite",
+					Options:  []string{"ro", "nosuid", "nodev", "rbind", "rprivate"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			d := newNvidiaSMILiteDiscoverer(logger, tc.path)
+
+			mounts, err := d.Mounts()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedMounts, mounts)
+		})
+	}
+}
@@ -42,6 +42,9 @@ const (
 	ModeCSV = Mode("csv")
 	// ModeImex configures the CDI spec generated to generate a spec for the available IMEX channels.
 	ModeImex = Mode("imex")
+	// ModeVfio configures the CDI spec generator to generate a spec for the
+	// VFIO groups of GPUs bound to the vfio-pci driver.
+	ModeVfio = Mode("vfio")
 )
 
 type modeConstraint interface {
@@ -66,6 +69,7 @@ func getModes() modes {
 			ModeGds,
 			ModeMofed,
 			ModeCSV,
+			ModeVfio,
 		}
 		lookup := make(map[Mode]bool)
 
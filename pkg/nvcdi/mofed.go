@@ -35,8 +35,9 @@ func (l *mofedlib) DeviceSpecGenerators(...string) (DeviceSpecGenerator, error)
 }
 
 // GetDeviceSpecs returns the CDI device specs for a single all device.
+// This includes the InfiniBand device nodes and the user-space RDMA libraries.
 func (l *mofedlib) GetDeviceSpecs() ([]specs.Device, error) {
-	discoverer, err := discover.NewMOFEDDiscoverer(l.logger, l.driverRoot)
+	discoverer, err := discover.NewMOFEDDiscovererWithLibraries(l.logger, l.driver, l.devRoot, l.hookCreator, l.ldconfigPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create MOFED discoverer: %v", err)
 	}
@@ -19,7 +19,9 @@ package nvcdi
 import (
 	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
 	"github.com/NVIDIA/go-nvml/pkg/nvml"
 )
 
@@ -47,6 +49,18 @@ const (
 	DeviceNameStrategyUUID = "uuid"
 )
 
+// Supported MIG device naming strategies
+const (
+	// MigDeviceNameStrategyIndex generates MIG device names such as 1:0
+	MigDeviceNameStrategyIndex = "index"
+	// MigDeviceNameStrategyUUID uses the MIG device UUID as the name
+	MigDeviceNameStrategyUUID = "uuid"
+	// MigDeviceNameStrategyProfile generates MIG device names such as
+	// 1g.10gb-0 where MIG devices with the same profile are numbered in the
+	// order in which they are named.
+	MigDeviceNameStrategyProfile = "profile"
+)
+
 type deviceNameIndex struct {
 	gpuPrefix string
 	migPrefix string
@@ -82,7 +96,7 @@ func (s deviceNameIndex) GetMigDeviceName(i int, _ UUIDer, j int, _ UUIDer) (str
 func (s deviceNameUUID) GetDeviceName(i int, d UUIDer) (string, error) {
 	uuid, err := d.GetUUID()
 	if err != nil {
-		return "", fmt.Errorf("failed to get device UUID: %v", err)
+		return "", fmt.Errorf("failed to get device UUID: %w", err)
 	}
 	return uuid, nil
 }
@@ -96,6 +110,108 @@ func (s deviceNameUUID) GetMigDeviceName(i int, _ UUIDer, j int, mig UUIDer) (st
 	return uuid, nil
 }
 
+// NewMigDeviceNamer creates a Device Namer that only generates names for MIG
+// devices based on the supplied strategy. No names are generated for full
+// GPUs. See WithMigDeviceNamer.
+func NewMigDeviceNamer(strategy string) (DeviceNamer, error) {
+	switch strategy {
+	case MigDeviceNameStrategyIndex:
+		return migDevicesOnly{deviceNameIndex{}}, nil
+	case MigDeviceNameStrategyUUID:
+		return migDevicesOnly{deviceNameUUID{}}, nil
+	case MigDeviceNameStrategyProfile:
+		return migDevicesOnly{newDeviceNameProfile()}, nil
+	}
+
+	return nil, fmt.Errorf("invalid MIG device name strategy: %v", strategy)
+}
+
+// gpuDevicesOnly wraps a device namer so that no names are generated for MIG
+// devices.
+type gpuDevicesOnly struct {
+	DeviceNamer
+}
+
+// GetMigDeviceName returns an empty name so that the MIG device is skipped.
+func (s gpuDevicesOnly) GetMigDeviceName(int, UUIDer, int, UUIDer) (string, error) {
+	return "", nil
+}
+
+// migDevicesOnly wraps a device namer so that no names are generated for full
+// GPUs.
+type migDevicesOnly struct {
+	DeviceNamer
+}
+
+// GetDeviceName returns an empty name so that the full GPU is skipped.
+func (s migDevicesOnly) GetDeviceName(int, UUIDer) (string, error) {
+	return "", nil
+}
+
+// A migProfiler returns the MIG profile of a MIG device.
+type migProfiler interface {
+	GetProfile() (device.MigProfile, error)
+}
+
+// deviceNameProfile names MIG devices by their MIG profile. Since a profile
+// is not unique, a per-profile index is appended. The names are recorded by
+// UUID so that a MIG device is assigned the same name if it is named more
+// than once.
+type deviceNameProfile struct {
+	names  map[string]string
+	counts map[string]int
+}
+
+func newDeviceNameProfile() *deviceNameProfile {
+	return &deviceNameProfile{
+		names:  make(map[string]string),
+		counts: make(map[string]int),
+	}
+}
+
+// GetDeviceName returns the name for the specified device based on the naming strategy
+func (s *deviceNameProfile) GetDeviceName(i int, d UUIDer) (string, error) {
+	return deviceNameIndex{}.GetDeviceName(i, d)
+}
+
+// GetMigDeviceName returns the name for the specified device based on the naming strategy
+func (s *deviceNameProfile) GetMigDeviceName(i int, _ UUIDer, j int, mig UUIDer) (string, error) {
+	profiler, ok := mig.(migProfiler)
+	if !ok {
+		return "", fmt.Errorf("failed to get MIG profile for MIG device %d:%d: unsupported", i, j)
+	}
+	uuid, err := mig.GetUUID()
+	if err != nil {
+		return "", fmt.Errorf("failed to get device UUID: %v", err)
+	}
+	if name, ok := s.names[uuid]; ok {
+		return name, nil
+	}
+
+	profile, err := profiler.GetProfile()
+	if err != nil {
+		return "", fmt.Errorf("failed to get MIG profile: %v", err)
+	}
+	// Profiles with attributes such as 1g.10gb+me contain characters that
+	// are not valid in CDI device names.
+	profileName := strings.ReplaceAll(profile.String(), "+", "_")
+
+	name := fmt.Sprintf("%s-%d", profileName, s.counts[profileName])
+	s.counts[profileName]++
+	s.names[uuid] = name
+	return name, nil
+}
+
+// withMigDeviceNamer returns device namers where the names for MIG devices
+// are only generated by the specified namer.
+func (l DeviceNamers) withMigDeviceNamer(migDeviceNamer DeviceNamer) DeviceNamers {
+	var namers DeviceNamers
+	for _, namer := range l {
+		namers = append(namers, gpuDevicesOnly{namer})
+	}
+	return append(namers, migDevicesOnly{migDeviceNamer})
+}
+
 //go:generate moq -rm -fmt=goimports -stub -out namer_nvml_mock.go . nvmlUUIDer
 type nvmlUUIDer interface {
 	GetUUID() (string, nvml.Return)
@@ -124,6 +240,19 @@ func (m convert) GetUUID() (string, error) {
 	return uuid, nil
 }
 
+// convertMigDevice converts a MIG device to a UUIDer while still allowing
+// its MIG profile to be queried.
+type convertMigDevice struct {
+	device.MigDevice
+}
+
+func (m convertMigDevice) GetUUID() (string, error) {
+	if m.MigDevice == nil {
+		return uuidUnsupported{}.GetUUID()
+	}
+	return convert{m.MigDevice}.GetUUID()
+}
+
 var errUUIDUnsupported = errors.New("GetUUID is not supported")
 
 func (m uuidUnsupported) GetUUID() (string, error) {
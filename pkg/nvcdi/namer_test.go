@@ -19,6 +19,7 @@ package nvcdi
 import (
 	"testing"
 
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
 	"github.com/NVIDIA/go-nvml/pkg/nvml"
 	"github.com/stretchr/testify/require"
 )
@@ -65,3 +66,87 @@ func TestConvert(t *testing.T) {
 		})
 	}
 }
+
+type fakeMigDevice struct {
+	uuid    string
+	profile device.MigProfile
+}
+
+func (d fakeMigDevice) GetUUID() (string, error) {
+	return d.uuid, nil
+}
+
+func (d fakeMigDevice) GetProfile() (device.MigProfile, error) {
+	return d.profile, nil
+}
+
+func TestMigDeviceNamer(t *testing.T) {
+	gpu := fakeMigDevice{uuid: "GPU-0"}
+	migs := []fakeMigDevice{
+		{uuid: "MIG-0", profile: &device.MigProfileInfo{C: 1, G: 1, GB: 10}},
+		{uuid: "MIG-1", profile: &device.MigProfileInfo{C: 1, G: 1, GB: 10}},
+		{uuid: "MIG-2", profile: &device.MigProfileInfo{C: 3, G: 3, GB: 40}},
+		{uuid: "MIG-3", profile: &device.MigProfileInfo{C: 1, G: 1, GB: 10, Attributes: []string{device.AttributeMediaExtensions}}},
+	}
+
+	testCases := []struct {
+		description      string
+		strategy         string
+		expectedError    bool
+		expectedGPUNames []string
+		expectedMigNames [][]string
+	}{
+		{
+			description:   "invalid strategy returns error",
+			strategy:      "type-index",
+			expectedError: true,
+		},
+		{
+			description:      "index strategy",
+			strategy:         MigDeviceNameStrategyIndex,
+			expectedGPUNames: []string{"0"},
+			expectedMigNames: [][]string{{"0:0"}, {"0:1"}, {"0:2"}, {"0:3"}},
+		},
+		{
+			description:      "uuid strategy",
+			strategy:         MigDeviceNameStrategyUUID,
+			expectedGPUNames: []string{"0"},
+			expectedMigNames: [][]string{{"MIG-0"}, {"MIG-1"}, {"MIG-2"}, {"MIG-3"}},
+		},
+		{
+			description:      "profile strategy numbers devices per profile",
+			strategy:         MigDeviceNameStrategyProfile,
+			expectedGPUNames: []string{"0"},
+			expectedMigNames: [][]string{{"1g.10gb-0"}, {"1g.10gb-1"}, {"3g.40gb-0"}, {"1g.10gb_me-0"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			migNamer, err := NewMigDeviceNamer(tc.strategy)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			indexNamer, _ := NewDeviceNamer(DeviceNameStrategyIndex)
+			namers := DeviceNamers{indexNamer}.withMigDeviceNamer(migNamer)
+
+			gpuNames, err := namers.GetDeviceNames(0, gpu)
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedGPUNames, gpuNames)
+
+			for j, mig := range migs {
+				names, err := namers.GetMigDeviceNames(0, gpu, j, mig)
+				require.NoError(t, err)
+				require.EqualValues(t, tc.expectedMigNames[j], names)
+			}
+
+			// Naming a MIG device again returns the same names.
+			names, err := namers.GetMigDeviceNames(0, gpu, 1, migs[1])
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedMigNames[1], names)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup"
+)
+
+// fabricManagerSocket is the path of the socket created by the NVIDIA Fabric
+// Manager relative to /run or /var/run.
+const fabricManagerSocket = "/nvidia-fabricmanager/socket"
+
+// nvswitchDevices discovers the NVSwitch device nodes that are present on
+// NVSwitch-based systems such as HGX and DGX systems. These are required for
+// NVLink communication between the GPUs.
+type nvswitchDevices struct {
+	discover.Discover
+	logger logger.Interface
+	// fabricManager is used to locate the Fabric Manager socket.
+	fabricManager lookup.Locator
+}
+
+func (l *nvcdilib) newNvSwitchDiscoverer() (discover.Discover, error) {
+	devices, err := discover.NewNvSwitchDiscoverer(l.logger, l.devRoot)
+	if err != nil {
+		return nil, err
+	}
+	d := &nvswitchDevices{
+		Discover: devices,
+		logger:   l.logger,
+		fabricManager: lookup.NewFileLocator(
+			lookup.WithLogger(l.logger),
+			lookup.WithRoot(l.driver.Root),
+			lookup.WithSearchPaths("/run", "/var/run"),
+			lookup.WithCount(1),
+		),
+	}
+	return d, nil
+}
+
+// Devices returns the NVSwitch device nodes. If device nodes are found but
+// the Fabric Manager socket is not, a warning is logged since NVLink is not
+// available to the GPUs until the Fabric Manager has been started.
+func (d *nvswitchDevices) Devices() ([]discover.Device, error) {
+	devices, err := d.Discover.Devices()
+	if err != nil || len(devices) == 0 {
+		return devices, err
+	}
+	if _, err := d.fabricManager.Locate(fabricManagerSocket); err != nil {
+		d.logger.Warningf("Found %d NVSwitch device nodes but the Fabric Manager socket was not found; ensure that nvidia-fabricmanager is running", len(devices))
+	}
+	return devices, nil
+}
@@ -0,0 +1,112 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+)
+
+func TestNvSwitchDiscoverer(t *testing.T) {
+	t.Setenv("__NVCT_TESTING_DEVICES_ARE_FILES", "true")
+
+	testCases := []struct {
+		description     string
+		deviceNodes     []string
+		socket          string
+		expectedDevices []string
+		expectedWarning bool
+	}{
+		{
+			description: "no nvswitch devices",
+			deviceNodes: []string{"nvidia0", "nvidiactl"},
+		},
+		{
+			description: "nvswitch devices are discovered",
+			deviceNodes: []string{"nvidia0", "nvidiactl", "nvidia-nvswitchctl", "nvidia-nvswitch0", "nvidia-nvswitch1"},
+			socket:      "/run/nvidia-fabricmanager/socket",
+			expectedDevices: []string{
+				"/dev/nvidia-nvswitchctl",
+				"/dev/nvidia-nvswitch0",
+				"/dev/nvidia-nvswitch1",
+			},
+		},
+		{
+			description: "missing fabric manager socket is reported",
+			deviceNodes: []string{"nvidia-nvswitchctl", "nvidia-nvswitch0"},
+			expectedDevices: []string{
+				"/dev/nvidia-nvswitchctl",
+				"/dev/nvidia-nvswitch0",
+			},
+			expectedWarning: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			logger, hook := testlog.NewNullLogger()
+
+			driverRoot := t.TempDir()
+			require.NoError(t, os.MkdirAll(filepath.Join(driverRoot, "dev"), 0755))
+			for _, deviceNode := range tc.deviceNodes {
+				require.NoError(t, os.WriteFile(filepath.Join(driverRoot, "dev", deviceNode), nil, 0600))
+			}
+			if tc.socket != "" {
+				require.NoError(t, os.MkdirAll(filepath.Join(driverRoot, filepath.Dir(tc.socket)), 0755))
+				require.NoError(t, os.WriteFile(filepath.Join(driverRoot, tc.socket), nil, 0600))
+			}
+
+			l := &nvcdilib{
+				logger:  logger,
+				devRoot: driverRoot,
+				driver: root.New(
+					root.WithLogger(logger),
+					root.WithDriverRoot(driverRoot),
+				),
+			}
+
+			d, err := l.newNvSwitchDiscoverer()
+			require.NoError(t, err)
+
+			devices, err := d.Devices()
+			require.NoError(t, err)
+
+			var paths []string
+			for _, device := range devices {
+				paths = append(paths, device.Path)
+			}
+			require.EqualValues(t, tc.expectedDevices, paths)
+
+			var warned bool
+			for _, entry := range hook.AllEntries() {
+				if entry.Level == logrus.WarnLevel && strings.Contains(entry.Message, "Fabric Manager") {
+					warned = true
+				}
+			}
+			require.Equal(t, tc.expectedWarning, warned)
+		})
+	}
+}
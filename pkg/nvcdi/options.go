@@ -17,8 +17,11 @@
 package nvcdi
 
 import (
+	"os"
+
 	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
 	"github.com/NVIDIA/go-nvlib/pkg/nvlib/info"
+	"github.com/NVIDIA/go-nvlib/pkg/nvpci"
 	"github.com/NVIDIA/go-nvml/pkg/nvml"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
@@ -50,6 +53,15 @@ func WithDeviceNamers(namers ...DeviceNamer) Option {
 	}
 }
 
+// WithMigDeviceNamer sets the device namer used to generate the names of MIG
+// devices. If this is set, the namers set using WithDeviceNamers are only used
+// for full GPUs.
+func WithMigDeviceNamer(namer DeviceNamer) Option {
+	return func(l *nvcdilib) {
+		l.migDeviceNamer = namer
+	}
+}
+
 // WithDriverRoot sets the driver root for the library
 func WithDriverRoot(root string) Option {
 	return func(l *nvcdilib) {
@@ -57,6 +69,17 @@ func WithDriverRoot(root string) Option {
 	}
 }
 
+// WithAdditionalDriverRoots sets the driver roots that are searched for
+// driver libraries and binaries in addition to the driver root. A library or
+// binary is only included from the first root in which it is found, with the
+// driver root having the highest precedence followed by the additional roots
+// in the order specified.
+func WithAdditionalDriverRoots(roots ...string) Option {
+	return func(l *nvcdilib) {
+		l.additionalDriverRoots = roots
+	}
+}
+
 // WithDevRoot sets the root where /dev is located.
 func WithDevRoot(root string) Option {
 	return func(l *nvcdilib) {
@@ -99,6 +122,54 @@ func WithNvmlLib(nvmllib nvml.Interface) Option {
 	}
 }
 
+// WithGPUDirectRDMA sets whether the devices required for GPUDirect RDMA are
+// included in the generated spec. If enabled, the RDMA connection manager is
+// included in the common edits and the InfiniBand uverbs devices co-located
+// with each full GPU are included in the edits for that device.
+func WithGPUDirectRDMA(includeRDMA bool) Option {
+	return func(l *nvcdilib) {
+		l.includeRDMA = includeRDMA
+	}
+}
+
+// WithCapabilityDevices sets whether the driver files associated with the
+// compute, utility, graphics, and video driver capabilities are included in a
+// separate CDI device for each capability (e.g. nvidia.com/gpu=graphics)
+// instead of in the common edits. This is only supported in nvml mode.
+func WithCapabilityDevices(capabilityDevices bool) Option {
+	return func(l *nvcdilib) {
+		l.capabilityDevices = capabilityDevices
+	}
+}
+
+// WithMinimalDriverFiles sets whether only the CUDA driver library
+// (libcuda.so) and NVML (libnvidia-ml.so) are included from the driver files.
+// The driver binaries (e.g. nvidia-smi) and the graphics mounts are not
+// included. This is intended for minimal (e.g. distroless) images and is only
+// supported in nvml mode.
+func WithMinimalDriverFiles(minimalDriverFiles bool) Option {
+	return func(l *nvcdilib) {
+		l.minimalDriverFiles = minimalDriverFiles
+	}
+}
+
+// WithNvidiaSMILitePath sets the path of the nvidia-smi-lite executable that
+// is included in the common edits. It is mounted at /usr/bin/nvidia-smi-lite
+// in the container and can be used for debugging if the driver binaries are
+// not included. This is only supported in nvml mode.
+func WithNvidiaSMILitePath(path string) Option {
+	return func(l *nvcdilib) {
+		l.nvidiaSMILitePath = path
+	}
+}
+
+// WithPCILib sets the PCI library used to enumerate GPUs in VFIO mode.
+func WithPCILib(pcilib nvpci.Interface) Option {
+	return func(l *nvcdilib) {
+		l.pcilib = pcilib
+	}
+}
+
 // WithMode sets the discovery mode for the library
 func WithMode[m modeConstraint](mode m) Option {
 	return func(l *nvcdilib) {
@@ -150,13 +221,25 @@ func WithConfigSearchPaths(paths []string) Option {
 }
 
 // WithLibrarySearchPaths sets the library search paths.
-// This is currently only used for CSV-mode.
+// If set, these paths are searched for driver libraries instead of the
+// standard library paths and the ldcache.
 func WithLibrarySearchPaths(paths []string) Option {
 	return func(o *nvcdilib) {
 		o.librarySearchPaths = paths
 	}
 }
 
+// WithDriverVersion sets the driver version for which the user-space
+// components are discovered. This allows a specific version to be selected on
+// systems where multiple driver versions are installed in versioned
+// directories (e.g. /usr/lib/nvidia-550). If this is not set, the version is
+// queried from the driver.
+func WithDriverVersion(version string) Option {
+	return func(o *nvcdilib) {
+		o.driverVersion = version
+	}
+}
+
 // WithDisabledHook allows specific hooks to the disabled.
 // This option can be specified multiple times for each hook.
 func WithDisabledHook[T string | HookName](hook T) Option {
@@ -165,6 +248,14 @@ func WithDisabledHook[T string | HookName](hook T) Option {
 	}
 }
 
+// WithHookEnv sets additional environment variables that are set for all
+// hooks in the generated CDI specifications.
+func WithHookEnv(env ...string) Option {
+	return func(o *nvcdilib) {
+		o.hookEnv = append(o.hookEnv, env...)
+	}
+}
+
 // WithFeatureFlag allows specified features to be toggled on.
 // This option can be specified multiple times for each feature flag.
 func WithFeatureFlag(featureFlag FeatureFlag) Option {
@@ -175,3 +266,25 @@ func WithFeatureFlag(featureFlag FeatureFlag) Option {
 		o.featureFlags[featureFlag] = true
 	}
 }
+
+// WithDeviceNodeOwnership sets the owner, group, and file mode to use for all
+// device nodes in the generated CDI specifications.
+// Properties that are nil are not set in the generated specifications.
+func WithDeviceNodeOwnership(uid *uint32, gid *uint32, fileMode *os.FileMode) Option {
+	return func(o *nvcdilib) {
+		if uid == nil && gid == nil && fileMode == nil {
+			o.deviceNodeOwnership = nil
+			return
+		}
+		o.deviceNodeOwnership = transform.NewDeviceNodeOwnershipTransformer(uid, gid, fileMode)
+	}
+}
+
+// WithPlugins sets the plugins that are invoked to add container edits to
+// the common edits of the generated CDI specifications.
+// See the pkg/plugin package for a description of the protocol.
+func WithPlugins(plugins ...string) Option {
+	return func(o *nvcdilib) {
+		o.plugins = append(o.plugins, plugins...)
+	}
+}
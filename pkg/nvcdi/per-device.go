@@ -0,0 +1,137 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"fmt"
+
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+// A deviceKeyer returns a stable key (e.g. the UUID) for the device that the
+// device specs of a DeviceSpecGenerator are generated for.
+type deviceKeyer interface {
+	getDeviceKey() (string, error)
+}
+
+// A perDeviceSpecGenerator generates device specs grouped by device key.
+type perDeviceSpecGenerator interface {
+	getPerDeviceSpecs() (map[string][]specs.Device, error)
+}
+
+var _ PerDeviceSpecGenerator = (*wrapper)(nil)
+
+var _ perDeviceSpecGenerator = (DeviceSpecGenerators)(nil)
+var _ perDeviceSpecGenerator = (*deviceSpecGeneratorsWithAndShutdown)(nil)
+
+var _ deviceKeyer = (*fullGPUDeviceSpecGenerator)(nil)
+var _ deviceKeyer = (*migDeviceSpecGenerator)(nil)
+
+// GetPerDeviceSpecsByID returns the CDI device specs for devices with the
+// specified IDs grouped by the UUID of the device. Device specs that are not
+// associated with a single device (e.g. capability devices or devices in
+// modes where UUIDs are not available) are grouped under an empty key.
+func (l *wrapper) GetPerDeviceSpecsByID(devices ...string) (map[string][]specs.Device, error) {
+	generators, err := l.factory.DeviceSpecGenerators(devices...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct device spec generators: %w", err)
+	}
+	perDeviceSpecs, err := getPerDeviceSpecs(generators)
+	if err != nil {
+		return nil, err
+	}
+	if l.deviceNodeOwnership != nil {
+		for _, deviceSpecs := range perDeviceSpecs {
+			if err := l.deviceNodeOwnership.Transform(&specs.Spec{Devices: deviceSpecs}); err != nil {
+				return nil, fmt.Errorf("failed to set device node ownership: %w", err)
+			}
+		}
+	}
+	return perDeviceSpecs, nil
+}
+
+// getPerDeviceSpecs returns the device specs of the specified generator
+// grouped by device key.
+func getPerDeviceSpecs(dsg DeviceSpecGenerator) (map[string][]specs.Device, error) {
+	if g, ok := dsg.(perDeviceSpecGenerator); ok {
+		return g.getPerDeviceSpecs()
+	}
+
+	var key string
+	if k, ok := dsg.(deviceKeyer); ok {
+		var err error
+		key, err = k.getDeviceKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get device key: %w", err)
+		}
+	}
+	deviceSpecs, err := dsg.GetDeviceSpecs()
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]specs.Device{key: deviceSpecs}, nil
+}
+
+// getPerDeviceSpecs returns the combined device specs for each device spec
+// generator grouped by device key. Since the device specs for a parent GPU
+// may be generated more than once, device specs with the same name are only
+// included once.
+func (g DeviceSpecGenerators) getPerDeviceSpecs() (map[string][]specs.Device, error) {
+	perDeviceSpecs := make(map[string][]specs.Device)
+	seen := make(map[string]bool)
+	for _, dsg := range g {
+		if dsg == nil {
+			continue
+		}
+		groups, err := getPerDeviceSpecs(dsg)
+		if err != nil {
+			return nil, err
+		}
+		for key, deviceSpecs := range groups {
+			for _, deviceSpec := range deviceSpecs {
+				if seen[deviceSpec.Name] {
+					continue
+				}
+				seen[deviceSpec.Name] = true
+				perDeviceSpecs[key] = append(perDeviceSpecs[key], deviceSpec)
+			}
+		}
+	}
+	return perDeviceSpecs, nil
+}
+
+// getPerDeviceSpecs ensures that the init and shutdown are called before (and
+// after) generating the required device specs.
+func (d *deviceSpecGeneratorsWithAndShutdown) getPerDeviceSpecs() (map[string][]specs.Device, error) {
+	if err := d.init(); err != nil {
+		return nil, err
+	}
+	defer d.tryShutdown()
+
+	return getPerDeviceSpecs(d.DeviceSpecGenerator)
+}
+
+// getDeviceKey returns the UUID of the full GPU.
+func (l *fullGPUDeviceSpecGenerator) getDeviceKey() (string, error) {
+	return convert{l.device}.GetUUID()
+}
+
+// getDeviceKey returns the UUID of the MIG device.
+func (l *migDeviceSpecGenerator) getDeviceKey() (string, error) {
+	return convertMigDevice{l.migDevice}.GetUUID()
+}
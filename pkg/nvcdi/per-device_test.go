@@ -0,0 +1,105 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+type fakeDeviceSpecGenerator struct {
+	key   string
+	names []string
+}
+
+func (g *fakeDeviceSpecGenerator) GetDeviceSpecs() ([]specs.Device, error) {
+	var deviceSpecs []specs.Device
+	for _, name := range g.names {
+		deviceSpecs = append(deviceSpecs, specs.Device{Name: name})
+	}
+	return deviceSpecs, nil
+}
+
+type fakeKeyedDeviceSpecGenerator struct {
+	fakeDeviceSpecGenerator
+}
+
+func (g *fakeKeyedDeviceSpecGenerator) getDeviceKey() (string, error) {
+	return g.key, nil
+}
+
+func TestGetPerDeviceSpecs(t *testing.T) {
+	testCases := []struct {
+		description    string
+		generator      DeviceSpecGenerator
+		expectedGroups map[string][]string
+	}{
+		{
+			description: "generator without key is grouped under empty key",
+			generator:   &fakeDeviceSpecGenerator{names: []string{"compute"}},
+			expectedGroups: map[string][]string{
+				"": {"compute"},
+			},
+		},
+		{
+			description: "generators are grouped by key",
+			generator: DeviceSpecGenerators{
+				&fakeKeyedDeviceSpecGenerator{fakeDeviceSpecGenerator{key: "GPU-0", names: []string{"0", "GPU-0"}}},
+				&fakeKeyedDeviceSpecGenerator{fakeDeviceSpecGenerator{key: "MIG-1", names: []string{"1:0", "MIG-1"}}},
+				&fakeDeviceSpecGenerator{names: []string{"compute"}},
+				nil,
+			},
+			expectedGroups: map[string][]string{
+				"":      {"compute"},
+				"GPU-0": {"0", "GPU-0"},
+				"MIG-1": {"1:0", "MIG-1"},
+			},
+		},
+		{
+			description: "repeated parent devices are included once",
+			generator: DeviceSpecGenerators{
+				&fakeKeyedDeviceSpecGenerator{fakeDeviceSpecGenerator{key: "GPU-1", names: []string{"1"}}},
+				&fakeKeyedDeviceSpecGenerator{fakeDeviceSpecGenerator{key: "MIG-1", names: []string{"1:0"}}},
+				&fakeKeyedDeviceSpecGenerator{fakeDeviceSpecGenerator{key: "GPU-1", names: []string{"1"}}},
+				&fakeKeyedDeviceSpecGenerator{fakeDeviceSpecGenerator{key: "MIG-2", names: []string{"1:1"}}},
+			},
+			expectedGroups: map[string][]string{
+				"GPU-1": {"1"},
+				"MIG-1": {"1:0"},
+				"MIG-2": {"1:1"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			perDeviceSpecs, err := getPerDeviceSpecs(tc.generator)
+			require.NoError(t, err)
+
+			groups := make(map[string][]string)
+			for key, deviceSpecs := range perDeviceSpecs {
+				for _, deviceSpec := range deviceSpecs {
+					groups[key] = append(groups[key], deviceSpec.Name)
+				}
+			}
+			require.EqualValues(t, tc.expectedGroups, groups)
+		})
+	}
+}
@@ -31,6 +31,7 @@ type builder struct {
 	version     string
 	vendor      string
 	class       string
+	annotations map[string]string
 	deviceSpecs []cdi.Device
 	edits       cdi.ContainerEdits
 	format      string
@@ -88,6 +89,7 @@ func (o *builder) Build() (*spec, error) {
 		raw = &cdi.Spec{
 			Version:        o.version,
 			Kind:           fmt.Sprintf("%s/%s", o.vendor, o.class),
+			Annotations:    o.annotations,
 			Devices:        o.deviceSpecs,
 			ContainerEdits: o.edits,
 		}
@@ -113,6 +115,14 @@ func (o *builder) Build() (*spec, error) {
 		}
 	}
 
+	// Specs that are generated from device specs and edits are sorted so that
+	// the spec does not depend on the order in which entities are discovered.
+	if o.raw == nil {
+		if err := transform.NewSorter().Transform(raw); err != nil {
+			return nil, fmt.Errorf("failed to sort spec: %v", err)
+		}
+	}
+
 	s := spec{
 		Spec:            raw,
 		format:          o.format,
@@ -132,6 +142,13 @@ func WithDeviceSpecs(deviceSpecs []cdi.Device) Option {
 	}
 }
 
+// WithAnnotations sets the spec-level annotations for the spec builder.
+func WithAnnotations(annotations map[string]string) Option {
+	return func(o *builder) {
+		o.annotations = annotations
+	}
+}
+
 // WithEdits sets the container edits for the spec builder
 func WithEdits(edits cdi.ContainerEdits) Option {
 	return func(o *builder) {
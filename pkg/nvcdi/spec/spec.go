@@ -25,6 +25,7 @@ import (
 	"tags.cncf.io/container-device-interface/pkg/cdi"
 	"tags.cncf.io/container-device-interface/specs-go"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/filelock"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/transform"
 )
 
@@ -43,7 +44,21 @@ func New(opts ...Option) (Interface, error) {
 }
 
 // Save writes the spec to the specified path and overwrites the file if it exists.
+// An exclusive lock is held while the spec is written so that other components
+// (e.g. the installer and the CDI refresh service) do not write the same spec
+// concurrently.
 func (s *spec) Save(path string) error {
+	path, err := s.normalizePath(path)
+	if err != nil {
+		return fmt.Errorf("failed to normalize path: %w", err)
+	}
+	return filelock.WithLock(path, func() error {
+		return s.save(path)
+	})
+}
+
+// save writes the spec to the specified path without acquiring a lock.
+func (s *spec) save(path string) error {
 	if s.transformOnSave != nil {
 		err := s.transformOnSave.Transform(s.Raw())
 		if err != nil {
@@ -85,7 +100,7 @@ func (s *spec) WriteTo(w io.Writer) (int64, error) {
 	}
 	defer os.Remove(tmpFile.Name())
 
-	if err := s.Save(tmpFile.Name()); err != nil {
+	if err := s.save(tmpFile.Name()); err != nil {
 		return 0, err
 	}
 
@@ -0,0 +1,94 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package transform
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+const (
+	// ChecksumAnnotation is the spec annotation that contains the checksum of
+	// the contents of a spec.
+	ChecksumAnnotation = "nvidia.com/cdi-spec-checksum"
+	// GeneratorVersionAnnotation is the spec annotation that contains the
+	// version of the NVIDIA Container Toolkit that generated a spec.
+	GeneratorVersionAnnotation = "nvidia.com/cdi-generator-version"
+)
+
+type checksumAnnotator struct {
+	generatorVersion string
+}
+
+var _ Transformer = (*checksumAnnotator)(nil)
+
+// NewChecksumAnnotator creates a transformer that adds annotations containing
+// the checksum of the contents of a spec and the specified generator version.
+func NewChecksumAnnotator(generatorVersion string) Transformer {
+	return &checksumAnnotator{
+		generatorVersion: generatorVersion,
+	}
+}
+
+// Transform adds the checksum and generator version annotations to the
+// specified spec. Existing checksum annotations are replaced.
+func (t checksumAnnotator) Transform(spec *specs.Spec) error {
+	if spec == nil {
+		return nil
+	}
+	checksum, err := GetChecksum(spec)
+	if err != nil {
+		return err
+	}
+	annotations := maps.Clone(spec.Annotations)
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[ChecksumAnnotation] = checksum
+	if t.generatorVersion != "" {
+		annotations[GeneratorVersionAnnotation] = t.generatorVersion
+	}
+	spec.Annotations = annotations
+	return nil
+}
+
+// GetChecksum returns the checksum of the contents of the specified spec.
+// The checksum covers the kind, devices, and container edits of the spec.
+// The CDI version and annotations are not included so that the checksum only
+// changes if the entities that are injected into a container change.
+func GetChecksum(spec *specs.Spec) (string, error) {
+	contents, err := json.Marshal(struct {
+		Kind           string               `json:"kind"`
+		Devices        []specs.Device       `json:"devices"`
+		ContainerEdits specs.ContainerEdits `json:"containerEdits"`
+	}{
+		Kind:           spec.Kind,
+		Devices:        spec.Devices,
+		ContainerEdits: spec.ContainerEdits,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal spec contents: %w", err)
+	}
+	checksum := sha256.Sum256(contents)
+	return "sha256:" + hex.EncodeToString(checksum[:]), nil
+}
@@ -0,0 +1,69 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package transform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+func TestChecksumAnnotator(t *testing.T) {
+	newSpec := func() *specs.Spec {
+		return &specs.Spec{
+			Version: "0.6.0",
+			Kind:    "nvidia.com/gpu",
+			Devices: []specs.Device{
+				{
+					Name: "0",
+					ContainerEdits: specs.ContainerEdits{
+						DeviceNodes: []*specs.DeviceNode{{Path: "/dev/nvidia0"}},
+					},
+				},
+			},
+			ContainerEdits: specs.ContainerEdits{
+				Env: []string{"NVIDIA_VISIBLE_DEVICES=void"},
+			},
+		}
+	}
+
+	spec := newSpec()
+	require.NoError(t, NewChecksumAnnotator("1.2.3").Transform(spec))
+	checksum := spec.Annotations[ChecksumAnnotation]
+	require.Regexp(t, "^sha256:[0-9a-f]{64}$", checksum)
+	require.Equal(t, "1.2.3", spec.Annotations[GeneratorVersionAnnotation])
+
+	// The checksum does not depend on the version or existing annotations.
+	other := newSpec()
+	other.Version = "1.0.0"
+	other.Annotations = map[string]string{"example.com/annotation": "value"}
+	require.NoError(t, NewChecksumAnnotator("").Transform(other))
+	require.Equal(t, checksum, other.Annotations[ChecksumAnnotation])
+	require.NotContains(t, other.Annotations, GeneratorVersionAnnotation)
+	require.Equal(t, "value", other.Annotations["example.com/annotation"])
+
+	// Annotating an annotated spec does not change the checksum.
+	require.NoError(t, NewChecksumAnnotator("1.2.3").Transform(spec))
+	require.Equal(t, checksum, spec.Annotations[ChecksumAnnotation])
+
+	// The checksum changes if the contents change.
+	spec.Devices[0].ContainerEdits.DeviceNodes[0].Path = "/dev/nvidia1"
+	require.NoError(t, NewChecksumAnnotator("1.2.3").Transform(spec))
+	require.NotEqual(t, checksum, spec.Annotations[ChecksumAnnotation])
+}
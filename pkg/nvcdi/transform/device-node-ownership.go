@@ -0,0 +1,75 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package transform
+
+import (
+	"os"
+
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+type deviceNodeOwnership struct {
+	uid      *uint32
+	gid      *uint32
+	fileMode *os.FileMode
+}
+
+var _ Transformer = (*deviceNodeOwnership)(nil)
+
+// NewDeviceNodeOwnershipTransformer creates a transformer that sets the
+// specified owner, group, and file mode for all device nodes in a spec.
+// Properties that are nil are not modified.
+func NewDeviceNodeOwnershipTransformer(uid *uint32, gid *uint32, fileMode *os.FileMode) Transformer {
+	return &deviceNodeOwnership{
+		uid:      uid,
+		gid:      gid,
+		fileMode: fileMode,
+	}
+}
+
+// Transform updates the device nodes in the common and device-specific edits.
+func (d deviceNodeOwnership) Transform(spec *specs.Spec) error {
+	if spec == nil {
+		return nil
+	}
+	d.transformEdits(&spec.ContainerEdits)
+	for i := range spec.Devices {
+		d.transformEdits(&spec.Devices[i].ContainerEdits)
+	}
+	return nil
+}
+
+func (d deviceNodeOwnership) transformEdits(edits *specs.ContainerEdits) {
+	for _, deviceNode := range edits.DeviceNodes {
+		if deviceNode == nil {
+			continue
+		}
+		if d.uid != nil {
+			uid := *d.uid
+			deviceNode.UID = &uid
+		}
+		if d.gid != nil {
+			gid := *d.gid
+			deviceNode.GID = &gid
+		}
+		if d.fileMode != nil {
+			fileMode := *d.fileMode
+			deviceNode.FileMode = &fileMode
+		}
+	}
+}
@@ -0,0 +1,96 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package transform
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+func TestDeviceNodeOwnershipTransformer(t *testing.T) {
+	ptr := func(v uint32) *uint32 { return &v }
+	mode := func(m os.FileMode) *os.FileMode { return &m }
+
+	testCases := []struct {
+		description string
+		uid         *uint32
+		gid         *uint32
+		fileMode    *os.FileMode
+		spec        *specs.Spec
+		expected    *specs.Spec
+	}{
+		{
+			description: "nil spec is supported",
+		},
+		{
+			description: "no properties leaves spec unmodified",
+			spec: &specs.Spec{
+				ContainerEdits: specs.ContainerEdits{
+					DeviceNodes: []*specs.DeviceNode{{Path: "/dev/nvidiactl"}},
+				},
+			},
+			expected: &specs.Spec{
+				ContainerEdits: specs.ContainerEdits{
+					DeviceNodes: []*specs.DeviceNode{{Path: "/dev/nvidiactl"}},
+				},
+			},
+		},
+		{
+			description: "properties are set on common and device edits",
+			gid:         ptr(44),
+			fileMode:    mode(0660),
+			spec: &specs.Spec{
+				ContainerEdits: specs.ContainerEdits{
+					DeviceNodes: []*specs.DeviceNode{{Path: "/dev/nvidiactl", UID: ptr(1)}},
+				},
+				Devices: []specs.Device{
+					{
+						Name: "0",
+						ContainerEdits: specs.ContainerEdits{
+							DeviceNodes: []*specs.DeviceNode{{Path: "/dev/nvidia0"}},
+						},
+					},
+				},
+			},
+			expected: &specs.Spec{
+				ContainerEdits: specs.ContainerEdits{
+					DeviceNodes: []*specs.DeviceNode{{Path: "/dev/nvidiactl", UID: ptr(1), GID: ptr(44), FileMode: mode(0660)}},
+				},
+				Devices: []specs.Device{
+					{
+						Name: "0",
+						ContainerEdits: specs.ContainerEdits{
+							DeviceNodes: []*specs.DeviceNode{{Path: "/dev/nvidia0", GID: ptr(44), FileMode: mode(0660)}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := NewDeviceNodeOwnershipTransformer(tc.uid, tc.gid, tc.fileMode).Transform(tc.spec)
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expected, tc.spec)
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"sort"
 	"strings"
 
+	"tags.cncf.io/container-device-interface/pkg/cdi"
 	"tags.cncf.io/container-device-interface/specs-go"
 )
 
@@ -29,9 +30,21 @@ type sorter struct{}
 
 var _ Transformer = (*sorter)(nil)
 
-// NewSorter creates a transformer that sorts container edits.
+// hookOrder defines the order of hooks by their lifecycle phase.
+var hookOrder = map[string]int{
+	cdi.PrestartHook:        0,
+	cdi.CreateRuntimeHook:   1,
+	cdi.CreateContainerHook: 2,
+	cdi.StartContainerHook:  3,
+	cdi.PoststartHook:       4,
+	cdi.PoststopHook:        5,
+}
+
+// NewSorter creates a transformer that sorts devices and container edits so
+// that the generated spec does not depend on the order in which the entities
+// were discovered.
 func NewSorter() Transformer {
-	return nil
+	return sorter{}
 }
 
 // Transform sorts the entities in the specified CDI specification.
@@ -57,6 +70,8 @@ func (d sorter) Transform(spec *specs.Spec) error {
 func (d sorter) transformEdits(edits *specs.ContainerEdits) error {
 	edits.DeviceNodes = d.sortDeviceNodes(edits.DeviceNodes)
 	edits.Mounts = d.sortMounts(edits.Mounts)
+	edits.Env = d.sortEnv(edits.Env)
+	edits.Hooks = d.sortHooks(edits.Hooks)
 	return nil
 }
 
@@ -73,10 +88,13 @@ func (d sorter) sortDeviceNodes(entities []*specs.DeviceNode) []*specs.DeviceNod
 	sort.Slice(entities, func(i, j int) bool {
 		ip := strings.Count(filepath.Clean(entities[i].Path), string(os.PathSeparator))
 		jp := strings.Count(filepath.Clean(entities[j].Path), string(os.PathSeparator))
-		if ip == jp {
+		if ip != jp {
+			return ip < jp
+		}
+		if entities[i].Path != entities[j].Path {
 			return entities[i].Path < entities[j].Path
 		}
-		return ip < jp
+		return entities[i].HostPath < entities[j].HostPath
 	})
 	return entities
 }
@@ -87,10 +105,34 @@ func (d sorter) sortMounts(entities []*specs.Mount) []*specs.Mount {
 	sort.Slice(entities, func(i, j int) bool {
 		ip := strings.Count(filepath.Clean(entities[i].ContainerPath), string(os.PathSeparator))
 		jp := strings.Count(filepath.Clean(entities[j].ContainerPath), string(os.PathSeparator))
-		if ip == jp {
+		if ip != jp {
+			return ip < jp
+		}
+		if entities[i].ContainerPath != entities[j].ContainerPath {
 			return entities[i].ContainerPath < entities[j].ContainerPath
 		}
-		return ip < jp
+		return entities[i].HostPath < entities[j].HostPath
 	})
 	return entities
 }
+
+// sortEnv sorts the specified environment variables by name. Since the last
+// value of an environment variable takes precedence, the order of
+// environment variables with the same name is preserved.
+func (d sorter) sortEnv(envs []string) []string {
+	sort.SliceStable(envs, func(i, j int) bool {
+		ik, _, _ := strings.Cut(envs[i], "=")
+		jk, _, _ := strings.Cut(envs[j], "=")
+		return ik < jk
+	})
+	return envs
+}
+
+// sortHooks sorts the specified hooks by lifecycle phase. Since hooks in the
+// same phase are run in order, the order of these hooks is preserved.
+func (d sorter) sortHooks(hooks []*specs.Hook) []*specs.Hook {
+	sort.SliceStable(hooks, func(i, j int) bool {
+		return hookOrder[hooks[i].HookName] < hookOrder[hooks[j].HookName]
+	})
+	return hooks
+}
@@ -353,3 +353,64 @@ func TestStortMounts(t *testing.T) {
 		})
 	}
 }
+
+func TestSortEnv(t *testing.T) {
+	testCases := []struct {
+		description string
+		env         []string
+		expectedEnv []string
+	}{
+		{
+			description: "unsorted gets sorted",
+			env:         []string{"NVIDIA_VISIBLE_DEVICES=void", "NVIDIA_CTK_LIBCUDA_DIR=/lib"},
+			expectedEnv: []string{"NVIDIA_CTK_LIBCUDA_DIR=/lib", "NVIDIA_VISIBLE_DEVICES=void"},
+		},
+		{
+			description: "order of duplicate names is preserved",
+			env:         []string{"FOO=b", "BAR=c", "FOO=a"},
+			expectedEnv: []string{"BAR=c", "FOO=b", "FOO=a"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			s := sorter{}
+			sorted := s.sortEnv(tc.env)
+
+			require.EqualValues(t, tc.expectedEnv, sorted)
+		})
+	}
+}
+
+func TestSortHooks(t *testing.T) {
+	testCases := []struct {
+		description   string
+		hooks         []*specs.Hook
+		expectedHooks []*specs.Hook
+	}{
+		{
+			description: "hooks are sorted by phase and order within phase is preserved",
+			hooks: []*specs.Hook{
+				{HookName: "createContainer", Path: "/usr/bin/hook-b"},
+				{HookName: "prestart", Path: "/usr/bin/hook-c"},
+				{HookName: "createContainer", Path: "/usr/bin/hook-a"},
+				{HookName: "poststop", Path: "/usr/bin/hook-d"},
+			},
+			expectedHooks: []*specs.Hook{
+				{HookName: "prestart", Path: "/usr/bin/hook-c"},
+				{HookName: "createContainer", Path: "/usr/bin/hook-b"},
+				{HookName: "createContainer", Path: "/usr/bin/hook-a"},
+				{HookName: "poststop", Path: "/usr/bin/hook-d"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			s := sorter{}
+			sorted := s.sortHooks(tc.hooks)
+
+			require.EqualValues(t, tc.expectedHooks, sorted)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package transform
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+const (
+	// UserDefinedAnnotation is the device annotation that marks a device in an
+	// existing spec as defined by the user. Such devices are preserved as is
+	// when a spec is regenerated.
+	UserDefinedAnnotation = "nvidia.com/cdi-user-defined"
+	// UserEditsAnnotation is the spec or device annotation that contains the
+	// JSON-encoded container edits that are added to the common edits or the
+	// edits of the device when a spec is regenerated.
+	UserEditsAnnotation = "nvidia.com/cdi-user-edits"
+)
+
+type userEdits struct {
+	logger   logger.Interface
+	existing *specs.Spec
+}
+
+var _ Transformer = (*userEdits)(nil)
+
+// NewUserEditsMerger creates a transformer that re-applies the user-defined
+// devices and edits from the specified existing spec to a generated spec.
+// Only entities that are marked with the user-defined or user-edits
+// annotations are considered. All other entities in the existing spec are
+// replaced by the generated entities.
+func NewUserEditsMerger(logger logger.Interface, existing *specs.Spec) Transformer {
+	return &userEdits{
+		logger:   logger,
+		existing: existing,
+	}
+}
+
+// Transform adds the user-defined devices and edits from the existing spec
+// to the specified spec. An error is returned if a user-defined device has the
+// same name as a generated device. User edits for a device that is no longer
+// generated are dropped with a warning.
+func (t userEdits) Transform(spec *specs.Spec) error {
+	if spec == nil || t.existing == nil {
+		return nil
+	}
+
+	var modified bool
+	if value, ok := t.existing.Annotations[UserEditsAnnotation]; ok {
+		if err := mergeUserEdits(&spec.ContainerEdits, value); err != nil {
+			return fmt.Errorf("invalid user edits for spec: %w", err)
+		}
+		spec.Annotations = withAnnotation(spec.Annotations, UserEditsAnnotation, value)
+		modified = true
+	}
+
+	generated := make(map[string]int)
+	for i, device := range spec.Devices {
+		generated[device.Name] = i
+	}
+
+	for _, device := range t.existing.Devices {
+		_, isGenerated := generated[device.Name]
+		if device.Annotations[UserDefinedAnnotation] == "true" {
+			if isGenerated {
+				return fmt.Errorf("user-defined device %q conflicts with a generated device", device.Name)
+			}
+			t.logger.Infof("Preserving user-defined device %q", device.Name)
+			spec.Devices = append(spec.Devices, device)
+			modified = true
+			continue
+		}
+
+		value, ok := device.Annotations[UserEditsAnnotation]
+		if !ok {
+			continue
+		}
+		if !isGenerated {
+			t.logger.Warningf("Dropping user edits for device %q since the device was not generated", device.Name)
+			continue
+		}
+		d := &spec.Devices[generated[device.Name]]
+		if err := mergeUserEdits(&d.ContainerEdits, value); err != nil {
+			return fmt.Errorf("invalid user edits for device %q: %w", device.Name, err)
+		}
+		d.Annotations = withAnnotation(d.Annotations, UserEditsAnnotation, value)
+		modified = true
+	}
+	if !modified {
+		return nil
+	}
+
+	dedupe, err := NewDedupe()
+	if err != nil {
+		return err
+	}
+	return Merge(dedupe, NewSorter()).Transform(spec)
+}
+
+// mergeUserEdits adds the JSON-encoded container edits to the specified edits.
+func mergeUserEdits(edits *specs.ContainerEdits, value string) error {
+	var u specs.ContainerEdits
+	if err := json.Unmarshal([]byte(value), &u); err != nil {
+		return err
+	}
+	edits.Env = append(edits.Env, u.Env...)
+	edits.DeviceNodes = append(edits.DeviceNodes, u.DeviceNodes...)
+	edits.Hooks = append(edits.Hooks, u.Hooks...)
+	edits.Mounts = append(edits.Mounts, u.Mounts...)
+	edits.AdditionalGIDs = append(edits.AdditionalGIDs, u.AdditionalGIDs...)
+	return nil
+}
+
+func withAnnotation(annotations map[string]string, key string, value string) map[string]string {
+	annotations = maps.Clone(annotations)
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[key] = value
+	return annotations
+}
@@ -0,0 +1,229 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package transform
+
+import (
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+func TestUserEditsMerger(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	generated := func() *specs.Spec {
+		return &specs.Spec{
+			Kind: "nvidia.com/gpu",
+			Devices: []specs.Device{
+				{
+					Name: "0",
+					ContainerEdits: specs.ContainerEdits{
+						DeviceNodes: []*specs.DeviceNode{{Path: "/dev/nvidia0"}},
+					},
+				},
+			},
+			ContainerEdits: specs.ContainerEdits{
+				Env: []string{"NVIDIA_VISIBLE_DEVICES=void"},
+			},
+		}
+	}
+
+	testCases := []struct {
+		description   string
+		existing      *specs.Spec
+		expectedError bool
+		expectedSpec  *specs.Spec
+	}{
+		{
+			description:  "no existing spec",
+			expectedSpec: generated(),
+		},
+		{
+			description: "unannotated entities are replaced",
+			existing: &specs.Spec{
+				Kind: "nvidia.com/gpu",
+				Devices: []specs.Device{
+					{
+						Name: "1",
+						ContainerEdits: specs.ContainerEdits{
+							DeviceNodes: []*specs.DeviceNode{{Path: "/dev/nvidia1"}},
+						},
+					},
+				},
+				ContainerEdits: specs.ContainerEdits{
+					Env: []string{"FOO=bar"},
+				},
+			},
+			expectedSpec: generated(),
+		},
+		{
+			description: "user edits are added to common edits",
+			existing: &specs.Spec{
+				Kind: "nvidia.com/gpu",
+				Annotations: map[string]string{
+					UserEditsAnnotation: `{"env": ["LICENSE_SERVER=license.example.com"], "mounts": [{"hostPath": "/etc/site/license", "containerPath": "/etc/license"}]}`,
+				},
+				ContainerEdits: specs.ContainerEdits{
+					Env: []string{"LICENSE_SERVER=license.example.com", "NVIDIA_VISIBLE_DEVICES=void"},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				Kind: "nvidia.com/gpu",
+				Annotations: map[string]string{
+					UserEditsAnnotation: `{"env": ["LICENSE_SERVER=license.example.com"], "mounts": [{"hostPath": "/etc/site/license", "containerPath": "/etc/license"}]}`,
+				},
+				Devices: []specs.Device{
+					{
+						Name: "0",
+						ContainerEdits: specs.ContainerEdits{
+							DeviceNodes: []*specs.DeviceNode{{Path: "/dev/nvidia0"}},
+						},
+					},
+				},
+				ContainerEdits: specs.ContainerEdits{
+					Env: []string{"LICENSE_SERVER=license.example.com", "NVIDIA_VISIBLE_DEVICES=void"},
+					Mounts: []*specs.Mount{
+						{HostPath: "/etc/site/license", ContainerPath: "/etc/license"},
+					},
+				},
+			},
+		},
+		{
+			description: "user edits are added to generated device",
+			existing: &specs.Spec{
+				Kind: "nvidia.com/gpu",
+				Devices: []specs.Device{
+					{
+						Name: "0",
+						Annotations: map[string]string{
+							UserEditsAnnotation: `{"env": ["GPU_ZERO=true"]}`,
+						},
+					},
+					{
+						Name: "1",
+						Annotations: map[string]string{
+							UserEditsAnnotation: `{"env": ["GPU_ONE=true"]}`,
+						},
+					},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				Kind: "nvidia.com/gpu",
+				Devices: []specs.Device{
+					{
+						Name: "0",
+						Annotations: map[string]string{
+							UserEditsAnnotation: `{"env": ["GPU_ZERO=true"]}`,
+						},
+						ContainerEdits: specs.ContainerEdits{
+							Env:         []string{"GPU_ZERO=true"},
+							DeviceNodes: []*specs.DeviceNode{{Path: "/dev/nvidia0"}},
+						},
+					},
+				},
+				ContainerEdits: specs.ContainerEdits{
+					Env: []string{"NVIDIA_VISIBLE_DEVICES=void"},
+				},
+			},
+		},
+		{
+			description: "user-defined device is preserved",
+			existing: &specs.Spec{
+				Kind: "nvidia.com/gpu",
+				Devices: []specs.Device{
+					{
+						Name: "monitoring",
+						Annotations: map[string]string{
+							UserDefinedAnnotation: "true",
+						},
+						ContainerEdits: specs.ContainerEdits{
+							Mounts: []*specs.Mount{
+								{HostPath: "/run/monitoring.sock", ContainerPath: "/run/monitoring.sock"},
+							},
+						},
+					},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				Kind: "nvidia.com/gpu",
+				Devices: []specs.Device{
+					{
+						Name: "0",
+						ContainerEdits: specs.ContainerEdits{
+							DeviceNodes: []*specs.DeviceNode{{Path: "/dev/nvidia0"}},
+						},
+					},
+					{
+						Name: "monitoring",
+						Annotations: map[string]string{
+							UserDefinedAnnotation: "true",
+						},
+						ContainerEdits: specs.ContainerEdits{
+							Mounts: []*specs.Mount{
+								{HostPath: "/run/monitoring.sock", ContainerPath: "/run/monitoring.sock"},
+							},
+						},
+					},
+				},
+				ContainerEdits: specs.ContainerEdits{
+					Env: []string{"NVIDIA_VISIBLE_DEVICES=void"},
+				},
+			},
+		},
+		{
+			description: "user-defined device conflicting with generated device returns error",
+			existing: &specs.Spec{
+				Kind: "nvidia.com/gpu",
+				Devices: []specs.Device{
+					{
+						Name: "0",
+						Annotations: map[string]string{
+							UserDefinedAnnotation: "true",
+						},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			description: "invalid user edits return error",
+			existing: &specs.Spec{
+				Kind: "nvidia.com/gpu",
+				Annotations: map[string]string{
+					UserEditsAnnotation: `{"env":`,
+				},
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			spec := generated()
+			err := NewUserEditsMerger(logger, tc.existing).Transform(spec)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedSpec, spec)
+		})
+	}
+}
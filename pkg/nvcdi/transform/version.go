@@ -0,0 +1,193 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package transform
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/mod/semver"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+// supportedVersions are the CDI spec versions that a spec can be downgraded
+// to. Earlier versions are not supported by the CDI packages.
+var supportedVersions = []string{"0.3.0", "0.4.0", "0.5.0", "0.6.0", "0.7.0", "0.8.0", "1.0.0"}
+
+type version struct {
+	logger  logger.Interface
+	version string
+}
+
+var _ Transformer = (*version)(nil)
+
+// NewVersionTransformer creates a transformer that sets the version of a spec
+// to the specified CDI spec version. Features that are not supported by the
+// version are removed from the spec and a warning is logged for each removed
+// feature. If a feature cannot be removed without changing the meaning of the
+// spec (e.g. device names that start with a digit), an error is returned.
+// Versions may be specified without a patch version (e.g. 0.5).
+func NewVersionTransformer(logger logger.Interface, v string) (Transformer, error) {
+	normalized, err := NormalizeVersion(v)
+	if err != nil {
+		return nil, err
+	}
+	return &version{
+		logger:  logger,
+		version: normalized,
+	}, nil
+}
+
+// NormalizeVersion returns the specified CDI spec version in the form used in
+// CDI specs (e.g. 0.5.0). An error is returned for unsupported versions.
+func NormalizeVersion(v string) (string, error) {
+	normalized := strings.TrimPrefix(v, "v")
+	if strings.Count(normalized, ".") == 1 {
+		normalized += ".0"
+	}
+	for _, supported := range supportedVersions {
+		if normalized == supported {
+			return normalized, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported CDI spec version %q; supported versions are %v", v, strings.Join(supportedVersions, ", "))
+}
+
+// Transform removes the features that require a later version from the spec
+// and sets the spec version.
+func (t version) Transform(spec *specs.Spec) error {
+	if spec == nil {
+		return nil
+	}
+	if t.isBefore("0.7.0") {
+		if err := t.removeV070Features(spec); err != nil {
+			return err
+		}
+	}
+	if t.isBefore("0.6.0") {
+		if err := t.removeV060Features(spec); err != nil {
+			return err
+		}
+	}
+	if t.isBefore("0.5.0") {
+		if err := t.removeV050Features(spec); err != nil {
+			return err
+		}
+	}
+	if t.isBefore("0.4.0") {
+		if err := t.removeV040Features(spec); err != nil {
+			return err
+		}
+	}
+	spec.Version = t.version
+	return nil
+}
+
+func (t version) isBefore(v string) bool {
+	return semver.Compare("v"+t.version, "v"+v) < 0
+}
+
+// removeV070Features removes additional GIDs and Intel RDT settings.
+func (t version) removeV070Features(spec *specs.Spec) error {
+	return t.forEachEdits(spec, func(name string, edits *specs.ContainerEdits) error {
+		if len(edits.AdditionalGIDs) > 0 {
+			t.logger.Warningf("Removing additional GIDs %v from %v; these require CDI spec version 0.7.0", edits.AdditionalGIDs, name)
+			edits.AdditionalGIDs = nil
+		}
+		if edits.IntelRdt != nil {
+			t.logger.Warningf("Removing Intel RDT settings from %v; these require CDI spec version 0.7.0", name)
+			edits.IntelRdt = nil
+		}
+		return nil
+	})
+}
+
+// removeV060Features removes spec and device annotations. Since the class is
+// part of the device names, a class containing a dot cannot be removed.
+func (t version) removeV060Features(spec *specs.Spec) error {
+	if _, class, _ := strings.Cut(spec.Kind, "/"); strings.Contains(class, ".") {
+		return fmt.Errorf("the class of kind %q requires CDI spec version 0.6.0", spec.Kind)
+	}
+	if len(spec.Annotations) > 0 {
+		t.logger.Warningf("Removing spec annotations; these require CDI spec version 0.6.0")
+		spec.Annotations = nil
+	}
+	for i := range spec.Devices {
+		if len(spec.Devices[i].Annotations) > 0 {
+			t.logger.Warningf("Removing annotations from device %q; these require CDI spec version 0.6.0", spec.Devices[i].Name)
+			spec.Devices[i].Annotations = nil
+		}
+	}
+	return nil
+}
+
+// removeV050Features removes host paths that match the container path of
+// device nodes. Device names starting with a digit and host paths that differ
+// from the container path cannot be represented in earlier versions.
+func (t version) removeV050Features(spec *specs.Spec) error {
+	for _, device := range spec.Devices {
+		if len(device.Name) > 0 && '0' <= device.Name[0] && device.Name[0] <= '9' {
+			return fmt.Errorf("device name %q requires CDI spec version 0.5.0; use a device name strategy such as 'uuid' instead", device.Name)
+		}
+	}
+	return t.forEachEdits(spec, func(name string, edits *specs.ContainerEdits) error {
+		for _, deviceNode := range edits.DeviceNodes {
+			if deviceNode == nil || deviceNode.HostPath == "" {
+				continue
+			}
+			if deviceNode.HostPath != deviceNode.Path {
+				return fmt.Errorf("device node %v of %v has host path %v which requires CDI spec version 0.5.0", deviceNode.Path, name, deviceNode.HostPath)
+			}
+			deviceNode.HostPath = ""
+		}
+		return nil
+	})
+}
+
+// removeV040Features removes mount types. Mounts without a type are treated
+// as bind mounts by earlier versions.
+func (t version) removeV040Features(spec *specs.Spec) error {
+	return t.forEachEdits(spec, func(name string, edits *specs.ContainerEdits) error {
+		for _, mount := range edits.Mounts {
+			if mount == nil || mount.Type == "" {
+				continue
+			}
+			if mount.Type != "bind" {
+				t.logger.Warningf("Removing mount type %q of %v from %v; mount types require CDI spec version 0.4.0", mount.Type, mount.ContainerPath, name)
+			}
+			mount.Type = ""
+		}
+		return nil
+	})
+}
+
+// forEachEdits calls the specified function for the common edits and the
+// edits of each device in the spec.
+func (t version) forEachEdits(spec *specs.Spec, f func(string, *specs.ContainerEdits) error) error {
+	if err := f("the common edits", &spec.ContainerEdits); err != nil {
+		return err
+	}
+	for i := range spec.Devices {
+		if err := f(fmt.Sprintf("device %q", spec.Devices[i].Name), &spec.Devices[i].ContainerEdits); err != nil {
+			return err
+		}
+	}
+	return nil
+}
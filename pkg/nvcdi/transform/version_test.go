@@ -0,0 +1,206 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package transform
+
+import (
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+func TestVersionTransformer(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description   string
+		version       string
+		spec          *specs.Spec
+		expectedError bool
+		expectedSpec  *specs.Spec
+	}{
+		{
+			description: "current version is unchanged",
+			version:     "1.0",
+			spec: &specs.Spec{
+				Version:     "0.6.0",
+				Kind:        "nvidia.com/gpu",
+				Annotations: map[string]string{"foo": "bar"},
+			},
+			expectedSpec: &specs.Spec{
+				Version:     "1.0.0",
+				Kind:        "nvidia.com/gpu",
+				Annotations: map[string]string{"foo": "bar"},
+			},
+		},
+		{
+			description: "annotations and additional GIDs are removed for v0.5.0",
+			version:     "0.5",
+			spec: &specs.Spec{
+				Version:     "0.7.0",
+				Kind:        "nvidia.com/gpu",
+				Annotations: map[string]string{"foo": "bar"},
+				Devices: []specs.Device{
+					{
+						Name:        "0",
+						Annotations: map[string]string{"foo": "bar"},
+						ContainerEdits: specs.ContainerEdits{
+							AdditionalGIDs: []uint32{44},
+							DeviceNodes: []*specs.DeviceNode{
+								{Path: "/dev/nvidia0", HostPath: "/host/dev/nvidia0"},
+							},
+						},
+					},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				Version: "0.5.0",
+				Kind:    "nvidia.com/gpu",
+				Devices: []specs.Device{
+					{
+						Name: "0",
+						ContainerEdits: specs.ContainerEdits{
+							DeviceNodes: []*specs.DeviceNode{
+								{Path: "/dev/nvidia0", HostPath: "/host/dev/nvidia0"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "matching host paths and mount types are removed for v0.3.0",
+			version:     "0.3.0",
+			spec: &specs.Spec{
+				Version: "0.5.0",
+				Kind:    "nvidia.com/gpu",
+				Devices: []specs.Device{
+					{
+						Name: "gpu0",
+						ContainerEdits: specs.ContainerEdits{
+							DeviceNodes: []*specs.DeviceNode{
+								{Path: "/dev/nvidia0", HostPath: "/dev/nvidia0"},
+							},
+						},
+					},
+				},
+				ContainerEdits: specs.ContainerEdits{
+					Mounts: []*specs.Mount{
+						{HostPath: "/lib/libcuda.so.1", ContainerPath: "/lib/libcuda.so.1", Type: "bind"},
+					},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				Version: "0.3.0",
+				Kind:    "nvidia.com/gpu",
+				Devices: []specs.Device{
+					{
+						Name: "gpu0",
+						ContainerEdits: specs.ContainerEdits{
+							DeviceNodes: []*specs.DeviceNode{
+								{Path: "/dev/nvidia0"},
+							},
+						},
+					},
+				},
+				ContainerEdits: specs.ContainerEdits{
+					Mounts: []*specs.Mount{
+						{HostPath: "/lib/libcuda.so.1", ContainerPath: "/lib/libcuda.so.1"},
+					},
+				},
+			},
+		},
+		{
+			description: "device name starting with digit requires v0.5.0",
+			version:     "0.4.0",
+			spec: &specs.Spec{
+				Version: "0.5.0",
+				Kind:    "nvidia.com/gpu",
+				Devices: []specs.Device{
+					{Name: "0"},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			description: "differing host path requires v0.5.0",
+			version:     "0.4.0",
+			spec: &specs.Spec{
+				Version: "0.5.0",
+				Kind:    "nvidia.com/gpu",
+				ContainerEdits: specs.ContainerEdits{
+					DeviceNodes: []*specs.DeviceNode{
+						{Path: "/dev/nvidiactl", HostPath: "/host/dev/nvidiactl"},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			description: "class with dot requires v0.6.0",
+			version:     "0.5.0",
+			spec: &specs.Spec{
+				Version: "0.6.0",
+				Kind:    "nvidia.com/gpu.mig",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			transformer, err := NewVersionTransformer(logger, tc.version)
+			require.NoError(t, err)
+
+			err = transformer.Transform(tc.spec)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedSpec, tc.spec)
+		})
+	}
+}
+
+func TestNormalizeVersion(t *testing.T) {
+	testCases := []struct {
+		version         string
+		expectedVersion string
+		expectedError   bool
+	}{
+		{version: "0.5", expectedVersion: "0.5.0"},
+		{version: "v0.8.0", expectedVersion: "0.8.0"},
+		{version: "1.0.0", expectedVersion: "1.0.0"},
+		{version: "0.2.0", expectedError: true},
+		{version: "0.9", expectedError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.version, func(t *testing.T) {
+			v, err := NormalizeVersion(tc.version)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedVersion, v)
+		})
+	}
+}
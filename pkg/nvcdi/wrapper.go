@@ -23,6 +23,8 @@ import (
 	"tags.cncf.io/container-device-interface/specs-go"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/edits"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/transform"
 )
@@ -30,12 +32,19 @@ import (
 type wrapper struct {
 	factory deviceSpecGeneratorFactory
 
-	vendor string
-	class  string
+	vendor      string
+	class       string
+	annotations map[string]string
 
 	mergedDeviceOptions []transform.MergedDeviceOption
+
+	deviceNodeOwnership transform.Transformer
+
+	plugins discover.Discover
 }
 
+var _ SpecAnnotator = (*wrapper)(nil)
+
 // TODO: Rename this type
 type deviceSpecGeneratorFactory interface {
 	DeviceSpecGenerators(...string) (DeviceSpecGenerator, error)
@@ -68,10 +77,16 @@ func (l *wrapper) GetSpec(devices ...string) (spec.Interface, error) {
 		spec.WithEdits(*edits.ContainerEdits),
 		spec.WithVendor(l.vendor),
 		spec.WithClass(l.class),
+		spec.WithAnnotations(l.getSpecAnnotations()),
 		spec.WithMergedDeviceOptions(l.mergedDeviceOptions...),
 	)
 }
 
+// GetSpecAnnotations returns the annotations to add to a generated spec.
+func (l *wrapper) GetSpecAnnotations() map[string]string {
+	return l.getSpecAnnotations()
+}
+
 // GetDeviceSpecsByID returns the CDI device specs for devices with the
 // specified IDs.
 // The device IDs are interpreted by the configured factory.
@@ -80,7 +95,16 @@ func (l *wrapper) GetDeviceSpecsByID(devices ...string) ([]specs.Device, error)
 	if err != nil {
 		return nil, fmt.Errorf("failed to construct device spec generators: %w", err)
 	}
-	return generators.GetDeviceSpecs()
+	deviceSpecs, err := generators.GetDeviceSpecs()
+	if err != nil {
+		return nil, err
+	}
+	if l.deviceNodeOwnership != nil {
+		if err := l.deviceNodeOwnership.Transform(&specs.Spec{Devices: deviceSpecs}); err != nil {
+			return nil, fmt.Errorf("failed to set device node ownership: %w", err)
+		}
+	}
+	return deviceSpecs, nil
 }
 
 // GetAllDeviceSpecs returns the device specs for all available devices.
@@ -92,13 +116,26 @@ func (l *wrapper) GetAllDeviceSpecs() ([]specs.Device, error) {
 
 // GetCommonEdits returns the wrapped edits and adds additional edits on top.
 func (m *wrapper) GetCommonEdits() (*cdi.ContainerEdits, error) {
-	edits, err := m.factory.GetCommonEdits()
+	commonEdits, err := m.factory.GetCommonEdits()
 	if err != nil {
 		return nil, err
 	}
-	edits.Env = append(edits.Env, image.EnvVarNvidiaVisibleDevices+"=void")
+	if m.plugins != nil {
+		pluginEdits, err := edits.FromDiscoverer(m.plugins)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get edits from plugins: %w", err)
+		}
+		commonEdits.Append(pluginEdits)
+	}
+	commonEdits.Env = append(commonEdits.Env, image.EnvVarNvidiaVisibleDevices+"=void")
+
+	if m.deviceNodeOwnership != nil && commonEdits.ContainerEdits != nil {
+		if err := m.deviceNodeOwnership.Transform(&specs.Spec{ContainerEdits: *commonEdits.ContainerEdits}); err != nil {
+			return nil, fmt.Errorf("failed to set device node ownership: %w", err)
+		}
+	}
 
-	return edits, nil
+	return commonEdits, nil
 }
 
 // GetDeviceSpecs returns the combined specs for each device spec generator.
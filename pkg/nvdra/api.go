@@ -0,0 +1,78 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvdra
+
+import (
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+)
+
+// Interface defines the API for the nvdra package.
+// This package provides the device discovery, CDI spec generation and health
+// information required by Kubernetes Dynamic Resource Allocation (DRA)
+// drivers for NVIDIA GPUs.
+type Interface interface {
+	// GetDevices returns the allocatable devices on the node.
+	GetDevices() ([]Device, error)
+	// GetClaimSpec returns a CDI spec for the devices allocated to the
+	// resource claim with the specified UID.
+	GetClaimSpec(claimUID string, ids ...string) (spec.Interface, error)
+}
+
+// A DeviceType refers to the type of an allocatable device.
+type DeviceType string
+
+const (
+	// DeviceTypeGPU is a full GPU.
+	DeviceTypeGPU = DeviceType("gpu")
+	// DeviceTypeMIG is a MIG device.
+	DeviceTypeMIG = DeviceType("mig")
+)
+
+// A Device represents an allocatable device as advertised by a DRA driver.
+type Device struct {
+	// Name is the name of the device. This is unique on the node and is
+	// a valid DNS label as required for the devices in a ResourceSlice.
+	Name string
+	// UUID is the UUID of the device. This can be used to request the device
+	// in a call to GetClaimSpec.
+	UUID string
+	// Type is the type of the device.
+	Type DeviceType
+	// Attributes are the attributes of the device.
+	Attributes map[string]Attribute
+	// Capacity defines the capacity of the device.
+	Capacity map[string]int64
+	// Health is the health of the device.
+	Health Health
+}
+
+// An Attribute is a typed device attribute. Exactly one of the fields is set.
+// This matches the attribute types supported by the resource.k8s.io API.
+type Attribute struct {
+	IntValue     *int64
+	BoolValue    *bool
+	StringValue  *string
+	VersionValue *string
+}
+
+// Health describes the health of a device.
+type Health struct {
+	Healthy bool
+	// Reason describes why a device is not healthy.
+	Reason string
+}
@@ -0,0 +1,370 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvdra
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+)
+
+const (
+	defaultVendor = "k8s.gpu.nvidia.com"
+	defaultClass  = "claim"
+)
+
+type nvdralib struct {
+	logger     logger.Interface
+	nvmllib    nvml.Interface
+	devicelib  device.Interface
+	cdilib     nvcdi.Interface
+	cdiOptions []nvcdi.Option
+
+	vendor string
+	class  string
+}
+
+var _ Interface = (*nvdralib)(nil)
+
+// New creates a new nvdra library.
+func New(opts ...Option) (Interface, error) {
+	l := &nvdralib{}
+	for _, opt := range opts {
+		opt(l)
+	}
+	if l.logger == nil {
+		l.logger = logger.New()
+	}
+	if l.vendor == "" {
+		l.vendor = defaultVendor
+	}
+	if l.class == "" {
+		l.class = defaultClass
+	}
+	if l.nvmllib == nil {
+		l.nvmllib = nvml.New()
+	}
+	l.devicelib = device.New(l.nvmllib)
+
+	uuidNamer, _ := nvcdi.NewDeviceNamer(nvcdi.DeviceNameStrategyUUID)
+	cdiOptions := []nvcdi.Option{
+		nvcdi.WithLogger(l.logger),
+		nvcdi.WithNvmlLib(l.nvmllib),
+		nvcdi.WithDeviceLib(l.devicelib),
+		nvcdi.WithMode(nvcdi.ModeNvml),
+	}
+	cdiOptions = append(cdiOptions, l.cdiOptions...)
+	// The device names are always generated from the UUIDs so that these are
+	// stable across claims.
+	cdiOptions = append(cdiOptions, nvcdi.WithDeviceNamers(uuidNamer))
+
+	cdilib, err := nvcdi.New(cdiOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CDI library: %w", err)
+	}
+	l.cdilib = cdilib
+
+	return l, nil
+}
+
+// GetDevices returns the full GPUs and MIG devices on the node.
+// Full GPUs with MIG mode enabled are not included.
+func (l *nvdralib) GetDevices() ([]Device, error) {
+	if r := l.nvmllib.Init(); r != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to initialize NVML: %w", r)
+	}
+	defer func() {
+		if r := l.nvmllib.Shutdown(); r != nvml.SUCCESS {
+			l.logger.Warningf("failed to shutdown NVML: %v", r)
+		}
+	}()
+
+	common, err := l.getCommonAttributes()
+	if err != nil {
+		return nil, err
+	}
+
+	var devices []Device
+	err = l.devicelib.VisitDevices(func(i int, d device.Device) error {
+		isMigEnabled, err := d.IsMigEnabled()
+		if err != nil {
+			return err
+		}
+		if isMigEnabled {
+			return nil
+		}
+		gpu, err := l.getGPUDevice(i, d, common)
+		if err != nil {
+			return fmt.Errorf("failed to get GPU %d: %w", i, err)
+		}
+		devices = append(devices, *gpu)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = l.devicelib.VisitMigDevices(func(i int, d device.Device, j int, mig device.MigDevice) error {
+		migDevice, err := l.getMIGDevice(i, d, j, mig, common)
+		if err != nil {
+			return fmt.Errorf("failed to get MIG device %d:%d: %w", i, j, err)
+		}
+		devices = append(devices, *migDevice)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return devices, nil
+}
+
+// GetClaimSpec returns a CDI spec for the devices with the specified IDs that
+// are allocated to the specified resource claim. The device names in the spec
+// are prefixed with the claim UID so that the specs for different claims do
+// not conflict.
+func (l *nvdralib) GetClaimSpec(claimUID string, ids ...string) (spec.Interface, error) {
+	if claimUID == "" {
+		return nil, errors.New("a claim UID is required")
+	}
+	if len(ids) == 0 {
+		return nil, errors.New("at least one device ID is required")
+	}
+
+	deviceSpecs, err := l.cdilib.GetDeviceSpecsByID(ids...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CDI device specs: %w", err)
+	}
+	for i := range deviceSpecs {
+		deviceSpecs[i].Name = GetClaimDeviceName(claimUID, deviceSpecs[i].Name)
+	}
+
+	edits, err := l.cdilib.GetCommonEdits()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get common CDI spec edits: %w", err)
+	}
+
+	return spec.New(
+		spec.WithDeviceSpecs(deviceSpecs),
+		spec.WithEdits(*edits.ContainerEdits),
+		spec.WithVendor(l.vendor),
+		spec.WithClass(l.class),
+		spec.WithAnnotations(l.getSpecAnnotations()),
+	)
+}
+
+// getSpecAnnotations returns the spec annotations of the CDI library if it
+// provides these.
+func (l *nvdralib) getSpecAnnotations() map[string]string {
+	annotator, ok := l.cdilib.(nvcdi.SpecAnnotator)
+	if !ok {
+		return nil
+	}
+	return annotator.GetSpecAnnotations()
+}
+
+// GetClaimDeviceName returns the name of the CDI device for the specified
+// device in a claim spec.
+func GetClaimDeviceName(claimUID string, name string) string {
+	return claimUID + "-" + name
+}
+
+// getCommonAttributes returns the attributes that are shared by all devices.
+func (l *nvdralib) getCommonAttributes() (map[string]Attribute, error) {
+	driverVersion, r := l.nvmllib.SystemGetDriverVersion()
+	if r != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get driver version: %w", r)
+	}
+	cudaDriverVersion, r := l.nvmllib.SystemGetCudaDriverVersion()
+	if r != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get CUDA driver version: %w", r)
+	}
+	return map[string]Attribute{
+		"driverVersion":     versionAttribute(driverVersion),
+		"cudaDriverVersion": versionAttribute(fmt.Sprintf("%d.%d", cudaDriverVersion/1000, (cudaDriverVersion%1000)/10)),
+	}, nil
+}
+
+func (l *nvdralib) getGPUDevice(i int, d device.Device, common map[string]Attribute) (*Device, error) {
+	uuid, r := d.GetUUID()
+	if r != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get UUID: %w", r)
+	}
+	minor, r := d.GetMinorNumber()
+	if r != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get minor number: %w", r)
+	}
+	memory, r := d.GetMemoryInfo()
+	if r != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get memory info: %w", r)
+	}
+	pciBusID, err := d.GetPCIBusID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PCI bus ID: %w", err)
+	}
+
+	attributes, err := getProductAttributes(d)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range common {
+		attributes[k] = v
+	}
+	attributes["type"] = stringAttribute(string(DeviceTypeGPU))
+	attributes["uuid"] = stringAttribute(uuid)
+	attributes["index"] = intAttribute(int64(i))
+	attributes["minor"] = intAttribute(int64(minor))
+	attributes["pciBusID"] = stringAttribute(pciBusID)
+
+	return &Device{
+		Name:       fmt.Sprintf("gpu-%d", i),
+		UUID:       uuid,
+		Type:       DeviceTypeGPU,
+		Attributes: attributes,
+		Capacity: map[string]int64{
+			"memory": int64(memory.Total),
+		},
+		Health: l.getHealth(d),
+	}, nil
+}
+
+func (l *nvdralib) getMIGDevice(i int, parent device.Device, j int, mig device.MigDevice, common map[string]Attribute) (*Device, error) {
+	uuid, r := mig.GetUUID()
+	if r != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get UUID: %w", r)
+	}
+	parentUUID, r := parent.GetUUID()
+	if r != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get parent UUID: %w", r)
+	}
+	memory, r := mig.GetMemoryInfo()
+	if r != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get memory info: %w", r)
+	}
+	profile, err := mig.GetProfile()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get MIG profile: %w", err)
+	}
+
+	attributes, err := getProductAttributes(parent)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range common {
+		attributes[k] = v
+	}
+	attributes["type"] = stringAttribute(string(DeviceTypeMIG))
+	attributes["uuid"] = stringAttribute(uuid)
+	attributes["parentUUID"] = stringAttribute(parentUUID)
+	attributes["index"] = intAttribute(int64(i))
+	attributes["migIndex"] = intAttribute(int64(j))
+	attributes["profile"] = stringAttribute(profile.String())
+
+	return &Device{
+		Name:       fmt.Sprintf("mig-%d-%d", i, j),
+		UUID:       uuid,
+		Type:       DeviceTypeMIG,
+		Attributes: attributes,
+		Capacity: map[string]int64{
+			"memory": int64(memory.Total),
+		},
+		// The health of a MIG device is determined by its parent.
+		Health: l.getHealth(parent),
+	}, nil
+}
+
+// getProductAttributes returns the attributes describing the product of the
+// specified GPU.
+func getProductAttributes(d device.Device) (map[string]Attribute, error) {
+	productName, r := d.GetName()
+	if r != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get product name: %w", r)
+	}
+	brand, err := d.GetBrandAsString()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get brand: %w", err)
+	}
+	architecture, err := d.GetArchitectureAsString()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get architecture: %w", err)
+	}
+	cudaComputeCapability, err := d.GetCudaComputeCapabilityAsString()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CUDA compute capability: %w", err)
+	}
+	return map[string]Attribute{
+		"productName":           stringAttribute(productName),
+		"brand":                 stringAttribute(brand),
+		"architecture":          stringAttribute(architecture),
+		"cudaComputeCapability": versionAttribute(cudaComputeCapability),
+	}, nil
+}
+
+// getHealth returns the health of the specified GPU.
+// A GPU is considered unhealthy if a row remapping has failed or if a pending
+// row remapping requires a GPU reset. If row remapping is not supported, the
+// GPU is considered healthy.
+func (l *nvdralib) getHealth(d device.Device) Health {
+	_, _, isPending, failureOccurred, r := d.GetRemappedRows()
+	switch {
+	case r == nvml.ERROR_NOT_SUPPORTED:
+		return Health{Healthy: true}
+	case r != nvml.SUCCESS:
+		l.logger.Warningf("Failed to get remapped rows: %v", r)
+		return Health{Reason: fmt.Sprintf("failed to get remapped rows: %v", r)}
+	case failureOccurred:
+		return Health{Reason: "row remapping failed"}
+	case isPending:
+		return Health{Reason: "row remapping is pending; a GPU reset is required"}
+	}
+	return Health{Healthy: true}
+}
+
+func intAttribute(v int64) Attribute {
+	return Attribute{IntValue: &v}
+}
+
+func stringAttribute(v string) Attribute {
+	return Attribute{StringValue: &v}
+}
+
+// versionAttribute returns a version attribute for the specified version.
+// Since version attributes are required to be semantic versions, missing
+// components are set to 0.
+func versionAttribute(v string) Attribute {
+	parts := strings.SplitN(v, ".", 3)
+	for len(parts) < 3 {
+		parts = append(parts, "0")
+	}
+	for i, part := range parts {
+		// Leading zeros are not allowed in semantic versions.
+		if n, err := strconv.Atoi(part); err == nil {
+			parts[i] = strconv.Itoa(n)
+		}
+	}
+	version := strings.Join(parts, ".")
+	return Attribute{VersionValue: &version}
+}
@@ -0,0 +1,164 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvdra
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
+
+func TestGetDevices(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	server := dgxa100.New()
+	server.DeviceGetCountFunc = func() (int, nvml.Return) {
+		return 2, nvml.SUCCESS
+	}
+	remappedRows := []nvml.Return{nvml.ERROR_NOT_SUPPORTED, nvml.SUCCESS}
+	for i, d := range server.Devices {
+		mock := d.(*dgxa100.Device)
+		// TODO: This is not implemented in the mock.
+		mock.GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+			return 0, nvml.SUCCESS
+		}
+		mock.GetRemappedRowsFunc = func() (int, int, bool, bool, nvml.Return) {
+			if i >= len(remappedRows) {
+				return 0, 0, false, false, nvml.ERROR_UNKNOWN
+			}
+			return 0, 0, true, false, remappedRows[i]
+		}
+	}
+
+	l := &nvdralib{
+		logger:    logger,
+		nvmllib:   server,
+		devicelib: device.New(server),
+	}
+
+	devices, err := l.GetDevices()
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+
+	gpu := devices[0]
+	require.Equal(t, "gpu-0", gpu.Name)
+	require.Equal(t, server.Devices[0].(*dgxa100.Device).UUID, gpu.UUID)
+	require.Equal(t, DeviceTypeGPU, gpu.Type)
+	require.Equal(t, int64(42949672960), gpu.Capacity["memory"])
+	require.Equal(t, Health{Healthy: true}, gpu.Health)
+
+	require.Equal(t, "Mock NVIDIA A100-SXM4-40GB", *gpu.Attributes["productName"].StringValue)
+	require.Equal(t, "Ampere", *gpu.Attributes["architecture"].StringValue)
+	require.Equal(t, int64(0), *gpu.Attributes["index"].IntValue)
+	require.Equal(t, "8.0.0", *gpu.Attributes["cudaComputeCapability"].VersionValue)
+	require.Equal(t, "550.54.15", *gpu.Attributes["driverVersion"].VersionValue)
+	require.Equal(t, "12.4.0", *gpu.Attributes["cudaDriverVersion"].VersionValue)
+
+	require.Equal(t, "gpu-1", devices[1].Name)
+	require.False(t, devices[1].Health.Healthy)
+	require.Contains(t, devices[1].Health.Reason, "pending")
+}
+
+type fakeCDILib struct {
+	nvcdi.Interface
+	deviceSpecs []specs.Device
+}
+
+func (f *fakeCDILib) GetDeviceSpecsByID(...string) ([]specs.Device, error) {
+	return f.deviceSpecs, nil
+}
+
+func (f *fakeCDILib) GetCommonEdits() (*cdi.ContainerEdits, error) {
+	return &cdi.ContainerEdits{
+		ContainerEdits: &specs.ContainerEdits{
+			Env: []string{"NVIDIA_VISIBLE_DEVICES=void"},
+		},
+	}, nil
+}
+
+func TestGetClaimSpec(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description   string
+		claimUID      string
+		ids           []string
+		expectedError bool
+		expectedNames []string
+	}{
+		{
+			description:   "claim UID is required",
+			ids:           []string{"GPU-1"},
+			expectedError: true,
+		},
+		{
+			description:   "device IDs are required",
+			claimUID:      "claim-uid",
+			expectedError: true,
+		},
+		{
+			description:   "device names are prefixed with claim UID",
+			claimUID:      "claim-uid",
+			ids:           []string{"GPU-1"},
+			expectedNames: []string{"claim-uid-GPU-1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			l := &nvdralib{
+				logger: logger,
+				cdilib: &fakeCDILib{
+					deviceSpecs: []specs.Device{
+						{
+							Name: "GPU-1",
+							ContainerEdits: specs.ContainerEdits{
+								DeviceNodes: []*specs.DeviceNode{{Path: "/dev/nvidia1"}},
+							},
+						},
+					},
+				},
+				vendor: defaultVendor,
+				class:  defaultClass,
+			}
+
+			s, err := l.GetClaimSpec(tc.claimUID, tc.ids...)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			raw := s.Raw()
+			require.Equal(t, "k8s.gpu.nvidia.com/claim", raw.Kind)
+			var names []string
+			for _, d := range raw.Devices {
+				names = append(names, d.Name)
+			}
+			require.EqualValues(t, tc.expectedNames, names)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvdra
+
+import (
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
+
+// Option is a function that configures the nvdralib
+type Option func(*nvdralib)
+
+// WithLogger sets the logger for the library
+func WithLogger(logger logger.Interface) Option {
+	return func(l *nvdralib) {
+		l.logger = logger
+	}
+}
+
+// WithNvmlLib sets the nvml library for the library
+func WithNvmlLib(nvmllib nvml.Interface) Option {
+	return func(l *nvdralib) {
+		l.nvmllib = nvmllib
+	}
+}
+
+// WithVendor sets the vendor for the generated claim specs.
+func WithVendor(vendor string) Option {
+	return func(l *nvdralib) {
+		l.vendor = vendor
+	}
+}
+
+// WithClass sets the class for the generated claim specs.
+func WithClass(class string) Option {
+	return func(l *nvdralib) {
+		l.class = class
+	}
+}
+
+// WithCDIOptions sets additional options for the generation of CDI specs.
+// This can be used to set the driver root or the path to the nvidia-cdi-hook,
+// for example.
+func WithCDIOptions(opts ...nvcdi.Option) Option {
+	return func(l *nvdralib) {
+		l.cdiOptions = append(l.cdiOptions, opts...)
+	}
+}
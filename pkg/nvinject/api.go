@@ -0,0 +1,52 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package nvinject provides the OCI spec modifications performed by the
+// NVIDIA Container Runtime as a library. This allows the NVIDIA devices to be
+// injected into a container by Go runtimes and shims directly:
+//
+//	lib, err := nvinject.New(nvinject.WithMode("cdi"))
+//	if err != nil {
+//		return err
+//	}
+//	if err := lib.Modify(spec, "nvidia.com/gpu=0"); err != nil {
+//		return err
+//	}
+//
+// The NVIDIA Container Toolkit config file is loaded as for the NVIDIA
+// Container Runtime and determines how the devices are injected.
+package nvinject
+
+import (
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// Interface defines the API for the nvinject package.
+// This package exposes the OCI spec modifications performed by the NVIDIA
+// Container Runtime so that other Go runtimes and shims can inject NVIDIA
+// devices directly instead of invoking the nvidia-container-runtime binary.
+type Interface interface {
+	// Modify applies the modifications required to make the requested
+	// devices available in the container with the specified OCI spec. The
+	// spec is updated in place.
+	//
+	// The devices are specified as for the NVIDIA_VISIBLE_DEVICES
+	// environment variable (e.g. all, an index, a UUID, or a fully-qualified
+	// CDI device name) and override the devices requested in the spec. If no
+	// devices are specified, the devices requested in the spec are injected.
+	Modify(spec *specs.Spec, devices ...string) error
+}
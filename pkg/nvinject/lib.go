@@ -0,0 +1,115 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvinject
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/runtime"
+)
+
+type nvinjectlib struct {
+	logger     logger.Interface
+	configFile string
+	mode       string
+	driverRoot string
+
+	cfg    *config.Config
+	driver *root.Driver
+}
+
+var _ Interface = (*nvinjectlib)(nil)
+
+// New creates a new nvinject library.
+func New(opts ...Option) (Interface, error) {
+	l := &nvinjectlib{}
+	for _, opt := range opts {
+		opt(l)
+	}
+	if l.logger == nil {
+		l.logger = logger.New()
+	}
+	if l.configFile == "" {
+		l.configFile = config.GetConfigFilePath()
+	}
+
+	cfgToml, err := config.New(config.WithConfigFile(l.configFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	cfg, err := cfgToml.Config()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if l.mode != "" {
+		cfg.NVIDIAContainerRuntimeConfig.Mode = l.mode
+	}
+	if l.driverRoot != "" {
+		cfg.NVIDIAContainerCLIConfig.Root = l.driverRoot
+	}
+	// We resolve the paths as the NVIDIA Container Runtime does to ensure that
+	// the modifications match.
+	//nolint:staticcheck  // TODO(elezar): We should swith the nvidia-container-runtime from using nvidia-ctk to using nvidia-cdi-hook.
+	cfg.NVIDIACTKConfig.Path = config.ResolveNVIDIACTKPath(l.logger, cfg.NVIDIACTKConfig.Path)
+	cfg.NVIDIAContainerRuntimeHookConfig.Path = config.ResolveNVIDIAContainerRuntimeHookPath(l.logger, cfg.NVIDIAContainerRuntimeHookConfig.Path)
+	l.cfg = cfg
+
+	l.driver = root.New(
+		root.WithLogger(l.logger),
+		root.WithDriverRoot(cfg.NVIDIAContainerCLIConfig.Root),
+	)
+
+	return l, nil
+}
+
+// Modify applies the NVIDIA Container Runtime modifications to the specified
+// OCI spec.
+func (l *nvinjectlib) Modify(spec *specs.Spec, devices ...string) error {
+	if spec == nil {
+		return fmt.Errorf("cannot modify nil spec")
+	}
+	if len(devices) > 0 {
+		setVisibleDevices(spec, devices...)
+	}
+	return runtime.ModifySpec(l.logger, l.cfg, l.driver, spec)
+}
+
+// setVisibleDevices sets the NVIDIA_VISIBLE_DEVICES envvar in the specified
+// spec to the requested devices, replacing any existing value.
+func setVisibleDevices(spec *specs.Spec, devices ...string) {
+	if spec.Process == nil {
+		spec.Process = &specs.Process{}
+	}
+	prefix := image.EnvVarNvidiaVisibleDevices + "="
+
+	var env []string
+	for _, e := range spec.Process.Env {
+		if strings.HasPrefix(e, prefix) {
+			continue
+		}
+		env = append(env, e)
+	}
+	spec.Process.Env = append(env, prefix+strings.Join(devices, ","))
+}
@@ -0,0 +1,120 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvinject
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModify(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	configFile := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`
+[nvidia-container-runtime-hook]
+path = "/usr/bin/nvidia-container-runtime-hook"
+`), 0600))
+
+	expectedHooks := &specs.Hooks{
+		Prestart: []specs.Hook{
+			{
+				Path: "/usr/bin/nvidia-container-runtime-hook",
+				Args: []string{"nvidia-container-runtime-hook", "prestart"},
+			},
+		},
+	}
+
+	testCases := []struct {
+		description   string
+		spec          *specs.Spec
+		devices       []string
+		expectedError bool
+		expectedSpec  *specs.Spec
+	}{
+		{
+			description:   "nil spec returns error",
+			expectedError: true,
+		},
+		{
+			description: "devices requested in spec are injected",
+			spec: &specs.Spec{
+				Process: &specs.Process{
+					Env: []string{"NVIDIA_VISIBLE_DEVICES=all"},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				Process: &specs.Process{
+					Env: []string{"NVIDIA_VISIBLE_DEVICES=all"},
+				},
+				Hooks: expectedHooks,
+			},
+		},
+		{
+			description: "requested devices override spec",
+			spec: &specs.Spec{
+				Process: &specs.Process{
+					Env: []string{"NVIDIA_VISIBLE_DEVICES=all", "FOO=bar"},
+				},
+			},
+			devices: []string{"0", "1"},
+			expectedSpec: &specs.Spec{
+				Process: &specs.Process{
+					Env: []string{"FOO=bar", "NVIDIA_VISIBLE_DEVICES=0,1"},
+				},
+				Hooks: expectedHooks,
+			},
+		},
+		{
+			description: "spec without process is supported",
+			spec:        &specs.Spec{},
+			devices:     []string{"all"},
+			expectedSpec: &specs.Spec{
+				Process: &specs.Process{
+					Env: []string{"NVIDIA_VISIBLE_DEVICES=all"},
+				},
+				Hooks: expectedHooks,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			lib, err := New(
+				WithLogger(logger),
+				WithConfigFile(configFile),
+				WithMode("legacy"),
+				WithDriverRoot(t.TempDir()),
+			)
+			require.NoError(t, err)
+
+			err = lib.Modify(tc.spec, tc.devices...)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedSpec, tc.spec)
+		})
+	}
+}
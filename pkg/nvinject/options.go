@@ -0,0 +1,57 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvinject
+
+import (
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+// Option is a function that configures the nvinjectlib
+type Option func(*nvinjectlib)
+
+// WithLogger sets the logger for the library
+func WithLogger(logger logger.Interface) Option {
+	return func(l *nvinjectlib) {
+		l.logger = logger
+	}
+}
+
+// WithConfigFile sets the path to the NVIDIA Container Toolkit config file.
+// If this is not specified, the config file used by the NVIDIA Container
+// Runtime is loaded. If the file does not exist, the default config is used.
+func WithConfigFile(configFile string) Option {
+	return func(l *nvinjectlib) {
+		l.configFile = configFile
+	}
+}
+
+// WithMode sets the mode used to inject devices (e.g. legacy, csv, cdi).
+// This overrides the nvidia-container-runtime.mode setting of the config.
+func WithMode(mode string) Option {
+	return func(l *nvinjectlib) {
+		l.mode = mode
+	}
+}
+
+// WithDriverRoot sets the root of the NVIDIA GPU driver installation.
+// This overrides the nvidia-container-cli.root setting of the config.
+func WithDriverRoot(driverRoot string) Option {
+	return func(l *nvinjectlib) {
+		l.driverRoot = driverRoot
+	}
+}
@@ -0,0 +1,154 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package plugin defines the protocol used to extend the NVIDIA Container
+// Toolkit with additional discovery logic without modifying the toolkit
+// itself.
+//
+// A plugin is an executable that reads a JSON-encoded Request from its
+// standard input and writes a JSON-encoded Response to its standard output.
+// The container edits in the response are added to those generated by the
+// toolkit. Messages written to standard error are logged and a non-zero exit
+// code is treated as an error.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	cdi "tags.cncf.io/container-device-interface/specs-go"
+)
+
+// APIVersion is the version of the plugin protocol.
+const APIVersion = "v1"
+
+// DefaultTimeout is the time that a plugin is allowed to run before it is
+// terminated.
+const DefaultTimeout = 10 * time.Second
+
+// A Pipeline identifies the component that invokes a plugin.
+type Pipeline string
+
+const (
+	// PipelineRuntime indicates that the plugin is invoked by the NVIDIA
+	// Container Runtime while modifying the OCI spec of a container.
+	PipelineRuntime = Pipeline("runtime")
+	// PipelineCDI indicates that the plugin is invoked while generating a CDI
+	// specification. The returned edits are added to the common edits of the
+	// generated spec.
+	PipelineCDI = Pipeline("cdi")
+)
+
+// A Request is sent to a plugin on its standard input.
+type Request struct {
+	APIVersion string   `json:"apiVersion"`
+	Pipeline   Pipeline `json:"pipeline"`
+	// DriverRoot is the root at which the NVIDIA driver is installed.
+	DriverRoot string `json:"driverRoot,omitempty"`
+	// DevRoot is the root under which device nodes are found.
+	DevRoot string `json:"devRoot,omitempty"`
+	// Devices lists the devices requested by the container. This is only set
+	// for the runtime pipeline.
+	Devices []string `json:"devices,omitempty"`
+	// Spec is the OCI spec of the container being modified. This is only set
+	// for the runtime pipeline.
+	Spec *specs.Spec `json:"spec,omitempty"`
+}
+
+// A Response is returned by a plugin on its standard output.
+type Response struct {
+	APIVersion     string             `json:"apiVersion"`
+	ContainerEdits cdi.ContainerEdits `json:"containerEdits"`
+}
+
+// A Handler generates the response to a plugin request.
+type Handler func(*Request) (*Response, error)
+
+// Serve reads a request from r, invokes the specified handler, and writes the
+// response to w. This is intended to be called from the main function of a
+// plugin as:
+//
+//	plugin.Serve(os.Stdin, os.Stdout, handler)
+func Serve(r io.Reader, w io.Writer, handler Handler) error {
+	var request Request
+	if err := json.NewDecoder(r).Decode(&request); err != nil {
+		return fmt.Errorf("failed to decode request: %w", err)
+	}
+	if request.APIVersion != APIVersion {
+		return fmt.Errorf("unsupported API version %q", request.APIVersion)
+	}
+
+	response, err := handler(&request)
+	if err != nil {
+		return err
+	}
+	if response == nil {
+		response = &Response{}
+	}
+	response.APIVersion = APIVersion
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// Exec runs the plugin at the specified path with the specified request and
+// returns its response. The plugin is terminated if it does not complete
+// within the specified timeout.
+func Exec(ctx context.Context, path string, timeout time.Duration, request *Request) (*Response, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	r := *request
+	r.APIVersion = APIVersion
+	input, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("plugin %v failed: %w: %v", path, err, msg)
+		}
+		return nil, fmt.Errorf("plugin %v failed: %w", path, err)
+	}
+
+	var response Response
+	if err := json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		return nil, fmt.Errorf("failed to decode response from plugin %v: %w", path, err)
+	}
+	if response.APIVersion != APIVersion {
+		return nil, fmt.Errorf("plugin %v returned unsupported API version %q", path, response.APIVersion)
+	}
+	return &response, nil
+}
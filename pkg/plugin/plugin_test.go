@@ -0,0 +1,153 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	cdi "tags.cncf.io/container-device-interface/specs-go"
+)
+
+func TestServe(t *testing.T) {
+	testCases := []struct {
+		description      string
+		input            string
+		handler          Handler
+		expectedError    bool
+		expectedResponse string
+	}{
+		{
+			description:   "invalid request returns error",
+			input:         "not-json",
+			expectedError: true,
+		},
+		{
+			description:   "unsupported API version returns error",
+			input:         `{"apiVersion": "v0"}`,
+			expectedError: true,
+		},
+		{
+			description: "handler error is returned",
+			input:       `{"apiVersion": "v1"}`,
+			handler: func(r *Request) (*Response, error) {
+				return nil, fmt.Errorf("failed")
+			},
+			expectedError: true,
+		},
+		{
+			description: "nil response returns empty edits",
+			input:       `{"apiVersion": "v1"}`,
+			handler: func(r *Request) (*Response, error) {
+				return nil, nil
+			},
+			expectedResponse: `{"apiVersion":"v1","containerEdits":{}}`,
+		},
+		{
+			description: "edits are returned",
+			input:       `{"apiVersion": "v1", "pipeline": "cdi"}`,
+			handler: func(r *Request) (*Response, error) {
+				return &Response{
+					ContainerEdits: cdi.ContainerEdits{
+						Env: []string{"PIPELINE=" + string(r.Pipeline)},
+					},
+				}, nil
+			},
+			expectedResponse: `{"apiVersion":"v1","containerEdits":{"env":["PIPELINE=cdi"]}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			err := Serve(strings.NewReader(tc.input), w, tc.handler)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.JSONEq(t, tc.expectedResponse, w.String())
+		})
+	}
+}
+
+func TestExec(t *testing.T) {
+	testCases := []struct {
+		description      string
+		script           string
+		timeout          time.Duration
+		expectedError    bool
+		expectedResponse *Response
+	}{
+		{
+			description: "response is decoded",
+			script:      `cat > /dev/null; echo '{"apiVersion": "v1", "containerEdits": {"env": ["FOO=bar"]}}'`,
+			expectedResponse: &Response{
+				APIVersion: "v1",
+				ContainerEdits: cdi.ContainerEdits{
+					Env: []string{"FOO=bar"},
+				},
+			},
+		},
+		{
+			description: "request is passed on stdin",
+			script:      `grep -q '"apiVersion":"v1"' && echo '{"apiVersion": "v1"}'`,
+			expectedResponse: &Response{
+				APIVersion: "v1",
+			},
+		},
+		{
+			description:   "non-zero exit code returns error",
+			script:        `echo "failed" >&2; exit 1`,
+			expectedError: true,
+		},
+		{
+			description:   "unsupported API version returns error",
+			script:        `echo '{"apiVersion": "v0"}'`,
+			expectedError: true,
+		},
+		{
+			description:   "plugin is terminated after timeout",
+			script:        `exec sleep 10`,
+			timeout:       100 * time.Millisecond,
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "plugin")
+			require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+tc.script+"\n"), 0755))
+
+			response, err := Exec(context.Background(), path, tc.timeout, &Request{Pipeline: PipelineRuntime})
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedResponse, response)
+		})
+	}
+}
@@ -0,0 +1,279 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// A mountLeakCase describes a container that is run with a host directory
+// bind-mounted at /empty using shared mount propagation.
+type mountLeakCase struct {
+	description string
+	labels      Labels
+	// command returns the command that runs the container with the specified
+	// host directory mounted. The container is expected to exit immediately.
+	command func(sharedDir string) string
+}
+
+// describeMountLeakTests adds specs that check that running each of the
+// specified containers does not leak mounts to the host. Mounts added to a
+// container by the NVIDIA Container Toolkit must not propagate back to the
+// host through the shared bind mount and must not remain once the container
+// has exited.
+//
+// Since the runner for a suite is only created once the suite is run, it is
+// accessed through getRunner.
+func describeMountLeakTests(getRunner func() Runner, cases ...mountLeakCase) {
+	When("Running containers with shared mount propagation", Ordered, func() {
+		var mountsBefore string
+		var sharedDir string
+
+		BeforeAll(func(ctx context.Context) {
+			output, _, err := getRunner().Run("mktemp -d")
+			Expect(err).ToNot(HaveOccurred())
+			sharedDir = strings.TrimSpace(output)
+
+			output, _, err = getRunner().Run("mount | sort")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(output).ToNot(BeEmpty())
+			mountsBefore = output
+		})
+
+		AfterAll(func(ctx context.Context) {
+			if sharedDir != "" {
+				_, _, _ = getRunner().Run("sudo rm -rf " + sharedDir)
+			}
+		})
+
+		AfterEach(func() {
+			mountsAfter, _, err := getRunner().Run("mount | sort")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mountsAfter).To(Equal(mountsBefore))
+
+			// Mounts that propagated back to the host through the shared
+			// mount leave their mount points behind in the shared directory.
+			output, _, err := getRunner().Run("sudo find " + sharedDir + " -mindepth 1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(strings.TrimSpace(output)).To(BeEmpty())
+		})
+
+		for _, tc := range cases {
+			It("should not leak mounts "+tc.description, tc.labels, func(ctx context.Context) {
+				_, _, err := getRunner().Run(tc.command(sharedDir))
+				Expect(err).ToNot(HaveOccurred())
+			})
+		}
+	})
+}
+
+// skipIfNotInstalled skips the current container if the specified command is
+// not available on the host. This allows the suites for the various container
+// engines to be run on hosts where only some of the engines are installed.
+func skipIfNotInstalled(runner Runner, command string) {
+	if _, _, err := runner.Run("command -v " + command); err != nil {
+		Skip(fmt.Sprintf("%v is not installed", command))
+	}
+}
+
+// Integration tests for Podman. The NVIDIA Container Toolkit is expected to be
+// installed on the host. GPUs are requested using CDI with a spec generated by
+// nvidia-ctk or by passing the nvidia-container-runtime as the OCI runtime.
+var _ = Describe("podman", Ordered, ContinueOnFailure, Label("podman"), func() {
+	var runner Runner
+
+	BeforeAll(func(ctx context.Context) {
+		runner = NewRunner(
+			WithHost(sshHost),
+			WithPort(sshPort),
+			WithSshKey(sshKey),
+			WithSshUser(sshUser),
+		)
+		skipIfNotInstalled(runner, "podman")
+
+		_, _, err := runner.Run("sudo nvidia-ctk cdi generate --output=/var/run/cdi/nvidia.yaml")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, _, err = runner.Run("sudo podman pull docker.io/library/ubuntu")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	podmanRun := func(sharedDir string, args string) string {
+		return "sudo podman run --rm " + args + " --mount type=bind,source=" + sharedDir + ",target=/empty,bind-propagation=shared docker.io/library/ubuntu true"
+	}
+
+	describeMountLeakTests(
+		func() Runner { return runner },
+		mountLeakCase{
+			description: "when using CDI",
+			command: func(sharedDir string) string {
+				return podmanRun(sharedDir, "--device=nvidia.com/gpu=all")
+			},
+		},
+		mountLeakCase{
+			description: "when using the nvidia-container-runtime",
+			command: func(sharedDir string) string {
+				return podmanRun(sharedDir, "--runtime=/usr/bin/nvidia-container-runtime -e NVIDIA_VISIBLE_DEVICES=all -e NVIDIA_DRIVER_CAPABILITIES=all")
+			},
+		},
+		mountLeakCase{
+			description: "when using automatic CDI spec generation",
+			command: func(sharedDir string) string {
+				return podmanRun(sharedDir, "--runtime=/usr/bin/nvidia-container-runtime -e NVIDIA_VISIBLE_DEVICES=runtime.nvidia.com/gpu=all")
+			},
+		},
+	)
+})
+
+// Integration tests for containerd. The NVIDIA Container Toolkit is expected
+// to be installed on the host. Containers are run using ctr with the
+// nvidia-container-runtime as the runc binary so that containerd does not need
+// to be configured.
+var _ = Describe("containerd", Ordered, ContinueOnFailure, Label("containerd"), func() {
+	var runner Runner
+
+	BeforeAll(func(ctx context.Context) {
+		runner = NewRunner(
+			WithHost(sshHost),
+			WithPort(sshPort),
+			WithSshKey(sshKey),
+			WithSshUser(sshUser),
+		)
+		skipIfNotInstalled(runner, "ctr")
+
+		_, _, err := runner.Run("sudo ctr image pull docker.io/library/ubuntu:latest")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	ctrRun := func(sharedDir string, args string) string {
+		return "sudo ctr run --rm --runc-binary=/usr/bin/nvidia-container-runtime " + args +
+			" --mount type=bind,src=" + sharedDir + ",dst=/empty,options=rbind:rshared" +
+			" docker.io/library/ubuntu:latest nvidia-ctk-e2e-mount-leaks true"
+	}
+
+	describeMountLeakTests(
+		func() Runner { return runner },
+		mountLeakCase{
+			description: "when using the nvidia-container-runtime",
+			command: func(sharedDir string) string {
+				return ctrRun(sharedDir, "--env NVIDIA_VISIBLE_DEVICES=all --env NVIDIA_DRIVER_CAPABILITIES=all")
+			},
+		},
+		mountLeakCase{
+			description: "when using automatic CDI spec generation",
+			command: func(sharedDir string) string {
+				return ctrRun(sharedDir, "--env NVIDIA_VISIBLE_DEVICES=runtime.nvidia.com/gpu=all")
+			},
+		},
+	)
+})
+
+// crictlRunTemplate is a script that runs a container in a new pod sandbox
+// using crictl and waits for it to exit. The pod sandbox is removed when the
+// script exits.
+var crictlRunTemplate = `
+set -e
+
+cat > %[1]s/pod.json <<'EOF'
+{
+  "metadata": {"name": "nvidia-ctk-e2e-mount-leaks", "namespace": "default", "uid": "nvidia-ctk-e2e-mount-leaks"},
+  "linux": {}
+}
+EOF
+
+cat > %[1]s/container.json <<'EOF'
+{
+  "metadata": {"name": "nvidia-ctk-e2e-mount-leaks"},
+  "image": {"image": "docker.io/library/ubuntu:latest"},
+  "command": ["true"],
+  "envs": [{"key": "NVIDIA_VISIBLE_DEVICES", "value": "%[3]s"}],
+  "mounts": [{"container_path": "/empty", "host_path": "%[2]s", "propagation": "PROPAGATION_BIDIRECTIONAL"}]
+}
+EOF
+
+POD=$(sudo crictl runp --runtime=nvidia %[1]s/pod.json)
+trap 'sudo crictl stopp $POD > /dev/null; sudo crictl rmp $POD > /dev/null' EXIT
+
+CONTAINER=$(sudo crictl create $POD %[1]s/container.json %[1]s/pod.json)
+sudo crictl start $CONTAINER
+
+for i in $(seq 1 30); do
+	STATE=$(sudo crictl inspect --output go-template --template '{{.status.state}}' $CONTAINER)
+	if [ "$STATE" = "CONTAINER_EXITED" ]; then
+		exit 0
+	fi
+	sleep 1
+done
+echo "Timed out waiting for container $CONTAINER to exit"
+exit 1
+`
+
+// Integration tests for CRI-O. The NVIDIA Container Toolkit is expected to be
+// installed on the host and CRI-O must be configured with an nvidia runtime
+// handler (e.g. using nvidia-ctk runtime configure --runtime=crio).
+var _ = Describe("cri-o", Ordered, ContinueOnFailure, Label("crio"), func() {
+	var runner Runner
+	var configDir string
+
+	BeforeAll(func(ctx context.Context) {
+		runner = NewRunner(
+			WithHost(sshHost),
+			WithPort(sshPort),
+			WithSshKey(sshKey),
+			WithSshUser(sshUser),
+		)
+		skipIfNotInstalled(runner, "crictl")
+
+		output, _, err := runner.Run("mktemp -d")
+		Expect(err).ToNot(HaveOccurred())
+		configDir = strings.TrimSpace(output)
+
+		_, _, err = runner.Run("sudo crictl pull docker.io/library/ubuntu:latest")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterAll(func(ctx context.Context) {
+		if configDir != "" {
+			_, _, _ = runner.Run("rm -rf " + configDir)
+		}
+	})
+
+	crictlRun := func(sharedDir string, visibleDevices string) string {
+		return fmt.Sprintf(crictlRunTemplate, configDir, sharedDir, visibleDevices)
+	}
+
+	describeMountLeakTests(
+		func() Runner { return runner },
+		mountLeakCase{
+			description: "when using the nvidia-container-runtime",
+			command: func(sharedDir string) string {
+				return crictlRun(sharedDir, "all")
+			},
+		},
+		mountLeakCase{
+			description: "when using automatic CDI spec generation",
+			command: func(sharedDir string) string {
+				return crictlRun(sharedDir, "runtime.nvidia.com/gpu=all")
+			},
+		},
+	)
+})
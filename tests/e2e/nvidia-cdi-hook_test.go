@@ -0,0 +1,215 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// A rootfsVariant describes a container image whose root filesystem is used
+// to exercise the nvidia-cdi-hook binary in isolation.
+type rootfsVariant struct {
+	name  string
+	image string
+}
+
+// rootfsVariants covers images with different base layouts. These include
+// glibc- and musl-based images as well as images without a shell or a package
+// manager.
+var rootfsVariants = []rootfsVariant{
+	{name: "ubuntu", image: "ubuntu"},
+	{name: "ubi", image: "registry.access.redhat.com/ubi9/ubi"},
+	{name: "alpine", image: "alpine"},
+	{name: "distroless", image: "gcr.io/distroless/base-debian12"},
+}
+
+// Integration tests for the nvidia-cdi-hook binary.
+// Each hook is invoked directly against a bundle whose root filesystem was
+// exported from a container image so that failures that are specific to an
+// image layout can be detected without involving a container engine.
+var _ = Describe("nvidia-cdi-hook", Ordered, ContinueOnFailure, Label("hooks"), func() {
+	var runner Runner
+	var hostDriverVersion string
+
+	// Install the NVIDIA Container Toolkit
+	BeforeAll(func(ctx context.Context) {
+		runner = NewRunner(
+			WithHost(sshHost),
+			WithPort(sshPort),
+			WithSshKey(sshKey),
+			WithSshUser(sshUser),
+		)
+
+		if installCTK {
+			installer, err := NewToolkitInstaller(
+				WithRunner(runner),
+				WithImage(imageName+":"+imageTag),
+				WithTemplate(dockerInstallTemplate),
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = installer.Install()
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		output, _, err := runner.Run("nvidia-smi --query-gpu=driver_version --format=csv,noheader | head -n 1")
+		Expect(err).ToNot(HaveOccurred())
+		hostDriverVersion = strings.TrimSpace(output)
+		Expect(hostDriverVersion).ToNot(BeEmpty())
+	})
+
+	for _, variant := range rootfsVariants {
+		When("running the hooks against a "+variant.name+" root filesystem", Ordered, Label(variant.name), func() {
+			var bundleDir string
+			var rootfs string
+			var state string
+
+			BeforeAll(func(ctx context.Context) {
+				_, _, err := runner.Run("docker pull " + variant.image)
+				Expect(err).ToNot(HaveOccurred())
+
+				output, _, err := runner.Run("mktemp -d -p $(pwd)")
+				Expect(err).ToNot(HaveOccurred())
+				bundleDir = strings.TrimSpace(output)
+				rootfs = filepath.Join(bundleDir, "rootfs")
+				state = filepath.Join(bundleDir, "state.json")
+
+				// Images such as distroless do not define a command. Since the
+				// container is never started, any command can be specified.
+				_, _, err = runner.Run(fmt.Sprintf(`set -e
+id=$(docker create %s /nonexistent)
+trap "docker rm -f $id > /dev/null" EXIT
+sudo mkdir -p %s
+docker export $id | sudo tar -x -C %s`, variant.image, rootfs, rootfs))
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, err = runner.Run(fmt.Sprintf(`set -e
+echo '{"ociVersion": "1.2.0", "root": {"path": "rootfs"}}' | sudo tee %s/config.json > /dev/null
+echo '{"ociVersion": "1.2.0", "id": "nvidia-cdi-hook-e2e", "status": "creating", "bundle": "%s"}' | sudo tee %s > /dev/null`,
+					bundleDir, bundleDir, state))
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			AfterAll(func(ctx context.Context) {
+				if bundleDir != "" {
+					runner.Run(fmt.Sprintf("sudo rm -rf %s", bundleDir))
+				}
+			})
+
+			It("should create symlinks", func(ctx context.Context) {
+				_, _, err := runner.Run(fmt.Sprintf("sudo nvidia-cdi-hook create-symlinks --container-spec=%s --link=libcuda.so.1::/usr/lib/nvidia-e2e/libcuda.so", state))
+				Expect(err).ToNot(HaveOccurred())
+
+				output, _, err := runner.Run("sudo readlink " + filepath.Join(rootfs, "usr/lib/nvidia-e2e/libcuda.so"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(strings.TrimSpace(output)).To(Equal("libcuda.so.1"))
+			})
+
+			It("should replace existing symlinks", func(ctx context.Context) {
+				_, _, err := runner.Run(fmt.Sprintf("sudo nvidia-cdi-hook create-symlinks --container-spec=%s --link=libcuda.so.999::/usr/lib/nvidia-e2e/libcuda.so", state))
+				Expect(err).ToNot(HaveOccurred())
+
+				output, _, err := runner.Run("sudo readlink " + filepath.Join(rootfs, "usr/lib/nvidia-e2e/libcuda.so"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(strings.TrimSpace(output)).To(Equal("libcuda.so.999"))
+			})
+
+			It("should set the permissions of folders", func(ctx context.Context) {
+				_, _, err := runner.Run(fmt.Sprintf("sudo mkdir -p -m 700 %s", filepath.Join(rootfs, "dev/dri")))
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, err = runner.Run(fmt.Sprintf("sudo nvidia-cdi-hook chmod --container-spec=%s --mode=755 --path=/dev/dri", state))
+				Expect(err).ToNot(HaveOccurred())
+
+				output, _, err := runner.Run("sudo stat -c %a " + filepath.Join(rootfs, "dev/dri"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(strings.TrimSpace(output)).To(Equal("755"))
+			})
+
+			It("should update the ldcache", func(ctx context.Context) {
+				_, _, err := runner.Run(fmt.Sprintf(`set -e
+libcuda=$(ldconfig -p | awk '/libcuda.so.1 /{print $NF; exit}')
+sudo mkdir -p %s
+sudo cp -L $libcuda %s/`, filepath.Join(rootfs, "usr/lib/nvidia-e2e"), filepath.Join(rootfs, "usr/lib/nvidia-e2e")))
+				Expect(err).ToNot(HaveOccurred())
+
+				// Images that do not include an ldcache (e.g. musl-based images)
+				// are not expected to have one after the hook has run.
+				_, _, err = runner.Run("sudo test -e " + filepath.Join(rootfs, "etc/ld.so.cache"))
+				hasLdcache := err == nil
+
+				_, _, err = runner.Run(fmt.Sprintf("sudo nvidia-cdi-hook update-ldcache --container-spec=%s --folder=/usr/lib/nvidia-e2e", state))
+				Expect(err).ToNot(HaveOccurred())
+
+				if !hasLdcache {
+					GinkgoLogr.Info("Skipping ldcache check for root filesystem without an ldcache", "variant", variant.name)
+					return
+				}
+
+				output, _, err := runner.Run(fmt.Sprintf("sudo ldconfig -p -C %s | grep libcuda.so.1", filepath.Join(rootfs, "etc/ld.so.cache")))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(output).To(ContainSubstring("/usr/lib/nvidia-e2e/libcuda.so.1"))
+			})
+
+			It("should add the CUDA compat libraries to the ldconfig search path", func(ctx context.Context) {
+				compatDir := filepath.Join(rootfs, "usr/local/cuda/compat")
+				_, _, err := runner.Run(fmt.Sprintf("sudo mkdir -p %s && sudo touch %s/libcuda.so.999.0.0", compatDir, compatDir))
+				Expect(err).ToNot(HaveOccurred())
+				defer runner.Run(fmt.Sprintf("sudo rm -rf %s", filepath.Join(rootfs, "usr/local/cuda")))
+
+				_, _, err = runner.Run("sudo test -e " + filepath.Join(rootfs, "etc/ld.so.cache"))
+				hasLdcache := err == nil
+
+				_, _, err = runner.Run(fmt.Sprintf("sudo nvidia-cdi-hook enable-cuda-compat --container-spec=%s --host-driver-version=%s", state, hostDriverVersion))
+				Expect(err).ToNot(HaveOccurred())
+
+				output, _, err := runner.Run(fmt.Sprintf("sudo sh -c 'cat %s/00-compat-*.conf 2>/dev/null || true'", filepath.Join(rootfs, "etc/ld.so.conf.d")))
+				Expect(err).ToNot(HaveOccurred())
+				if !hasLdcache {
+					// The hook is a no-op if the container does not have an
+					// ldcache.
+					Expect(output).To(BeEmpty())
+					return
+				}
+				Expect(strings.TrimSpace(output)).To(Equal("/usr/local/cuda/compat"))
+			})
+
+			It("should not modify the CUDA compat libraries for a newer host driver", func(ctx context.Context) {
+				compatDir := filepath.Join(rootfs, "usr/local/cuda/compat")
+				_, _, err := runner.Run(fmt.Sprintf("sudo rm -f %s/00-compat-*.conf", filepath.Join(rootfs, "etc/ld.so.conf.d")))
+				Expect(err).ToNot(HaveOccurred())
+				_, _, err = runner.Run(fmt.Sprintf("sudo mkdir -p %s && sudo touch %s/libcuda.so.1.0.0", compatDir, compatDir))
+				Expect(err).ToNot(HaveOccurred())
+				defer runner.Run(fmt.Sprintf("sudo rm -rf %s", filepath.Join(rootfs, "usr/local/cuda")))
+
+				_, _, err = runner.Run(fmt.Sprintf("sudo nvidia-cdi-hook enable-cuda-compat --container-spec=%s --host-driver-version=%s", state, hostDriverVersion))
+				Expect(err).ToNot(HaveOccurred())
+
+				output, _, err := runner.Run(fmt.Sprintf("sudo sh -c 'ls %s/00-compat-*.conf 2>/dev/null || true'", filepath.Join(rootfs, "etc/ld.so.conf.d")))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(output).To(BeEmpty())
+			})
+		})
+	}
+})
@@ -259,45 +259,28 @@ var _ = Describe("docker", Ordered, ContinueOnFailure, func() {
 		})
 	})
 
-	When("Running containers with shared mount propagation", Ordered, func() {
-		var mountsBefore string
-		var tmpDirPath string
-
-		BeforeAll(func(ctx context.Context) {
-			_, _, err := runner.Run("docker pull ubuntu")
-			Expect(err).ToNot(HaveOccurred())
-
-			tmpDirPath = GinkgoT().TempDir()
-			_, _, err = runner.Run("mkdir -p " + tmpDirPath)
-			Expect(err).ToNot(HaveOccurred())
-
-			output, _, err := runner.Run("mount | sort")
-			Expect(err).ToNot(HaveOccurred())
-			Expect(output).ToNot(BeEmpty())
-			mountsBefore = output
-		})
-
-		AfterEach(func() {
-			mountsAfter, _, err := runner.Run("mount | sort")
-			Expect(err).ToNot(HaveOccurred())
-			Expect(mountsAfter).To(Equal(mountsBefore))
-		})
-
-		It("should not leak mounts when using the nvidia-container-runtime-hook", Label("legacy"), func(ctx context.Context) {
-			_, _, err := runner.Run("docker run --rm -i --runtime=runc -e NVIDIA_VISIBLE_DEVICES=all -e NVIDIA_DRIVER_CAPABILITIES=all --mount type=bind,source=" + tmpDirPath + ",target=/empty,bind-propagation=shared ubuntu true")
-			Expect(err).ToNot(HaveOccurred())
-		})
-
-		It("should not leak mounts when using the nvidia-container-runtime", func(ctx context.Context) {
-			_, _, err := runner.Run("docker run --rm -i --runtime=nvidia -e NVIDIA_VISIBLE_DEVICES=all -e NVIDIA_DRIVER_CAPABILITIES=all --mount type=bind,source=" + tmpDirPath + ",target=/empty,bind-propagation=shared ubuntu true")
-			Expect(err).ToNot(HaveOccurred())
-		})
-
-		It("should not leak mounts when using CDI mode", func(ctx context.Context) {
-			_, _, err := runner.Run("docker run --rm -i --runtime=nvidia -e NVIDIA_VISIBLE_DEVICES=all -e NVIDIA_DRIVER_CAPABILITIES=runtime.nvidia.com/gpu=all --mount type=bind,source=" + tmpDirPath + ",target=/empty,bind-propagation=shared ubuntu true")
-			Expect(err).ToNot(HaveOccurred())
-		})
-	})
+	describeMountLeakTests(
+		func() Runner { return runner },
+		mountLeakCase{
+			description: "when using the nvidia-container-runtime-hook",
+			labels:      Label("legacy"),
+			command: func(sharedDir string) string {
+				return "docker run --rm -i --runtime=runc -e NVIDIA_VISIBLE_DEVICES=all -e NVIDIA_DRIVER_CAPABILITIES=all --mount type=bind,source=" + sharedDir + ",target=/empty,bind-propagation=shared ubuntu true"
+			},
+		},
+		mountLeakCase{
+			description: "when using the nvidia-container-runtime",
+			command: func(sharedDir string) string {
+				return "docker run --rm -i --runtime=nvidia -e NVIDIA_VISIBLE_DEVICES=all -e NVIDIA_DRIVER_CAPABILITIES=all --mount type=bind,source=" + sharedDir + ",target=/empty,bind-propagation=shared ubuntu true"
+			},
+		},
+		mountLeakCase{
+			description: "when using CDI mode",
+			command: func(sharedDir string) string {
+				return "docker run --rm -i --runtime=nvidia -e NVIDIA_VISIBLE_DEVICES=runtime.nvidia.com/gpu=all --mount type=bind,source=" + sharedDir + ",target=/empty,bind-propagation=shared ubuntu true"
+			},
+		},
+	)
 
 	When("Running a container where the firmware folder resolves outside the container root", Ordered, func() {
 		var outputDir string
@@ -351,4 +334,60 @@ EOF`)
 			Expect(stderr).To(ContainSubstring("nvidia-container-cli.real: mount error: path error:"))
 		})
 	})
+
+	// On Tegra-based systems the iGPU is made available using the CSV mode or
+	// a CDI specification generated from the installed BSP.
+	When("Running a docker build that requires GPU access", Ordered, func() {
+		var hostOutput string
+
+		BeforeAll(func(ctx context.Context) {
+			if _, _, err := runner.Run("docker buildx version"); err != nil {
+				Skip("BuildKit GPU build tests require docker buildx")
+			}
+
+			var err error
+			hostOutput, _, err = runner.Run("nvidia-smi -L")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, _, err = runner.Run("sudo nvidia-ctk cdi generate --output=/var/run/cdi/nvidia.yaml")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should support CDI devices in RUN instructions", func(ctx context.Context) {
+			dockerfile := `# syntax=docker/dockerfile:1-labs\nFROM ubuntu\nRUN --device=nvidia.com/gpu=all nvidia-smi -L\n`
+			_, buildOutput, err := runner.Run("printf '" + dockerfile + "' | docker buildx build --allow device --no-cache --progress=plain -")
+			Expect(err).ToNot(HaveOccurred())
+			for _, line := range strings.Split(strings.TrimSpace(hostOutput), "\n") {
+				Expect(buildOutput).To(ContainSubstring(line))
+			}
+		})
+	})
+
+	When("running on a Tegra-based system", Ordered, Label("tegra"), func() {
+		BeforeAll(func(ctx context.Context) {
+			_, _, err := runner.Run("docker pull ubuntu")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should inject the iGPU device nodes using the nvidia-container-runtime", func(ctx context.Context) {
+			output, _, err := runner.Run("docker run --rm -i --runtime=nvidia -e NVIDIA_VISIBLE_DEVICES=all ubuntu ls /dev/nvmap")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(strings.TrimSpace(output)).To(Equal("/dev/nvmap"))
+		})
+
+		It("should inject the tegra libraries using the nvidia-container-runtime", func(ctx context.Context) {
+			output, _, err := runner.Run("docker run --rm -i --runtime=nvidia -e NVIDIA_VISIBLE_DEVICES=all ubuntu bash -c \"ldconfig -p | grep libcuda.so\"")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(output).ToNot(BeEmpty())
+		})
+
+		It("should support a generated CDI specification", func(ctx context.Context) {
+			_, _, err := runner.Run("sudo nvidia-ctk cdi generate --mode=csv --output=/var/run/cdi/nvidia.yaml")
+			Expect(err).ToNot(HaveOccurred())
+
+			output, _, err := runner.Run("docker run --rm -i --runtime=nvidia -e NVIDIA_VISIBLE_DEVICES=nvidia.com/gpu=all ubuntu ls /dev/nvmap")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(strings.TrimSpace(output)).To(Equal("/dev/nvmap"))
+		})
+	})
 })